@@ -50,7 +50,10 @@ func main() {
 	}
 
 	validate := config.NewValidator()
-	app := config.NewGin(logger)
+	app, err := config.NewGin(env, logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize gin: %+v", err))
+	}
 
 	config.NewApi(&config.ApiConfig{
 		DB:          database,
@@ -7,6 +7,7 @@ import (
 	"expense-management-system/internal/delivery/messaging"
 	"expense-management-system/internal/httpclient"
 	"expense-management-system/internal/repository"
+	"expense-management-system/internal/storage"
 	"expense-management-system/internal/usecase"
 	"fmt"
 	"log"
@@ -58,13 +59,19 @@ func main() {
 	)
 
 	expenseRepository := repository.NewExpenseRepository(database)
+	ledgerRepository := repository.NewLedgerRepository(database)
 	paymentPartnerRepository := repository.NewPaymentPartnerRepository(paymentPartnerClient)
+	paymentVoucherRepository := repository.NewPaymentVoucherRepository(database)
+	fileStorage := storage.NewDatabaseFileStorage(database)
 	paymentProcessorUsecase := usecase.NewPaymentProcessorUsecase(
 		logger,
 		redisClient,
 		tx,
 		expenseRepository,
+		ledgerRepository,
 		paymentPartnerRepository,
+		paymentVoucherRepository,
+		fileStorage,
 		env.PaymentLockDuration,
 	)
 
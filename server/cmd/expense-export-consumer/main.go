@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"expense-management-system/internal/config"
+	"expense-management-system/internal/delivery/messaging"
+	"expense-management-system/internal/repository"
+	"expense-management-system/internal/storage"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	ctx := context.Background()
+
+	logger, err := config.NewLogger()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	env, err := config.NewEnv()
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize env: %+v", err))
+	}
+
+	database, err := config.NewDatabase(ctx, env)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize database: %+v", err))
+	}
+
+	kafkaConsumer, err := config.NewKafkaConsumer(env, logger)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to initialize consumer: %+v", err))
+	}
+
+	expenseRepository := repository.NewExpenseRepository(database)
+	expenseExportRepository := repository.NewExpenseExportRepository(database)
+	fileStorage := storage.NewDatabaseFileStorage(database)
+	// the consumer only processes exports, it never queues one so it doesn't need a producer
+	expenseExportUsecase := usecase.NewExpenseExportUsecase(
+		logger,
+		expenseRepository,
+		expenseExportRepository,
+		fileStorage,
+		nil,
+		env.ExportSyncMaxRows,
+	)
+
+	exportHandler := messaging.NewExpenseExportRequestedHandler(logger, expenseExportUsecase)
+
+	consumerCfg := &messaging.ConsumerConfig{
+		Topic:              env.KafkaTopicExpenseExportRequested,
+		MaxRetries:         env.KafkaMaxRetries,
+		BackoffDuration:    time.Second * time.Duration(env.KafkaBackoffDuration),
+		MaxExecuteDuration: time.Second * time.Duration(env.ExportMaxExecuteDuration),
+	}
+	exportConsumer, err := messaging.NewConsumer(logger, kafkaConsumer, consumerCfg, exportHandler.Consume)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("failed to start consumer: %+v", err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		err := exportConsumer.Consume(ctx)
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case s := <-quit:
+		logger.Info("stop signal received, shutting down...", zap.String("signal", s.String()))
+	case e := <-errCh:
+		logger.Error("consumer error, shutting down...", zap.Error(e))
+	}
+
+	cancel()
+	wg.Wait()
+
+	logger.Info("consumer exited properly")
+}
@@ -0,0 +1,10 @@
+UPDATE users SET role = 'manager' WHERE role = 'admin';
+
+ALTER TYPE user_role RENAME TO user_role_old;
+CREATE TYPE user_role AS ENUM ('employee', 'manager');
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE user_role USING role::text::user_role;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'employee';
+
+DROP TYPE user_role_old;
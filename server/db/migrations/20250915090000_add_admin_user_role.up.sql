@@ -0,0 +1 @@
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'admin';
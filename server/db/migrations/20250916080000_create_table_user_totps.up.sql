@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_totps (
+    user_id BIGINT PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    recovery_codes TEXT[] NOT NULL DEFAULT '{}',
+    enabled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_totps_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT
+);
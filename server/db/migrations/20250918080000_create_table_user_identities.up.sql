@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_identities_issuer_subject UNIQUE (issuer, subject),
+    CONSTRAINT fk_user_identities_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
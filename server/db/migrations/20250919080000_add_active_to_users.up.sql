@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN external_id VARCHAR(255) UNIQUE,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE users SET updated_at = created_at;
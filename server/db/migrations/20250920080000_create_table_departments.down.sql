@@ -0,0 +1,8 @@
+ALTER TABLE expenses
+    DROP COLUMN IF EXISTS cost_center,
+    DROP COLUMN IF EXISTS department_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS department_id;
+
+DROP TABLE IF EXISTS departments;
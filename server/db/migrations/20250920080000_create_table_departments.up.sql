@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS departments (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    cost_center VARCHAR(50) UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE users
+    ADD COLUMN department_id BIGINT,
+    ADD CONSTRAINT fk_users_department_id
+        FOREIGN KEY(department_id)
+        REFERENCES departments(id)
+        ON DELETE RESTRICT;
+
+CREATE INDEX idx_users_department_id ON users (department_id);
+
+-- snapshot of the submitter department, moving the user later doesn't move the spend
+ALTER TABLE expenses
+    ADD COLUMN department_id BIGINT,
+    ADD COLUMN cost_center VARCHAR(50),
+    ADD CONSTRAINT fk_expenses_department_id
+        FOREIGN KEY(department_id)
+        REFERENCES departments(id)
+        ON DELETE RESTRICT;
+
+CREATE INDEX idx_expenses_department_id_created_at ON expenses (department_id, created_at);
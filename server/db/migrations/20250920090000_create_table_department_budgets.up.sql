@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS department_budgets (
+    id BIGSERIAL PRIMARY KEY,
+    department_id BIGINT NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_department_budgets_department_id
+        FOREIGN KEY(department_id)
+        REFERENCES departments(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT amount_check CHECK (amount > 0),
+    CONSTRAINT period_check CHECK (period_end >= period_start)
+);
+
+CREATE INDEX idx_department_budgets_department_id_period ON department_budgets (department_id, period_start, period_end);
@@ -0,0 +1,20 @@
+ALTER TABLE approvals
+    DROP COLUMN IF EXISTS org_id;
+
+ALTER TABLE expenses
+    DROP COLUMN IF EXISTS approval_threshold,
+    DROP COLUMN IF EXISTS org_id;
+
+ALTER TABLE departments
+    DROP CONSTRAINT IF EXISTS departments_org_id_name_key,
+    DROP CONSTRAINT IF EXISTS departments_org_id_cost_center_key,
+    DROP COLUMN IF EXISTS org_id,
+    ADD CONSTRAINT departments_name_key UNIQUE (name),
+    ADD CONSTRAINT departments_cost_center_key UNIQUE (cost_center);
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_org_id_external_id_key,
+    DROP COLUMN IF EXISTS org_id,
+    ADD CONSTRAINT users_external_id_key UNIQUE (external_id);
+
+DROP TABLE IF EXISTS organizations;
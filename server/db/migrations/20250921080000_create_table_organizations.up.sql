@@ -0,0 +1,77 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    min_expense_amount BIGINT NOT NULL,
+    max_expense_amount BIGINT NOT NULL,
+    approval_threshold_amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT min_expense_amount_check CHECK (min_expense_amount > 0),
+    CONSTRAINT max_expense_amount_check CHECK (max_expense_amount >= min_expense_amount),
+    CONSTRAINT approval_threshold_amount_check CHECK (approval_threshold_amount > 0)
+);
+
+-- existing data belongs to the default organization, it keeps the limits that used to be hard coded
+INSERT INTO organizations (id, name, min_expense_amount, max_expense_amount, approval_threshold_amount)
+VALUES (1, 'Default', 10000, 50000000, 1000000);
+
+SELECT setval(pg_get_serial_sequence('organizations', 'id'), (SELECT MAX(id) FROM organizations));
+
+ALTER TABLE users
+    ADD COLUMN org_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT fk_users_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX idx_users_org_id ON users (org_id);
+
+-- every organization provisions from its own identity provider, emails stay unique for login
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_external_id_key,
+    ADD CONSTRAINT users_org_id_external_id_key UNIQUE (org_id, external_id);
+
+ALTER TABLE departments
+    ADD COLUMN org_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT fk_departments_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE departments ALTER COLUMN org_id DROP DEFAULT;
+
+-- names and cost centers only have to be unique inside an organization
+ALTER TABLE departments
+    DROP CONSTRAINT IF EXISTS departments_name_key,
+    DROP CONSTRAINT IF EXISTS departments_cost_center_key,
+    ADD CONSTRAINT departments_org_id_name_key UNIQUE (org_id, name),
+    ADD CONSTRAINT departments_org_id_cost_center_key UNIQUE (org_id, cost_center);
+
+-- the approval threshold is a snapshot, changing the setting doesn't reclassify past expenses
+ALTER TABLE expenses
+    ADD COLUMN org_id BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN approval_threshold BIGINT NOT NULL DEFAULT 1000000,
+    ADD CONSTRAINT fk_expenses_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE expenses
+    ALTER COLUMN org_id DROP DEFAULT,
+    ALTER COLUMN approval_threshold DROP DEFAULT;
+
+CREATE INDEX idx_expenses_org_id_created_at ON expenses (org_id, created_at);
+
+ALTER TABLE approvals
+    ADD COLUMN org_id BIGINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT fk_approvals_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE approvals ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX idx_approvals_org_id ON approvals (org_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_expenses_user_id_created_at;
+DROP INDEX IF EXISTS idx_expenses_org_id_status_created_at;
+DROP INDEX IF EXISTS idx_expenses_org_id_processed_at;
+DROP INDEX IF EXISTS idx_expenses_org_id_amount;
@@ -0,0 +1,9 @@
+-- backs sorting the expense list by amount, id is the tie breaker of the keyset cursor
+CREATE INDEX idx_expenses_org_id_amount ON expenses (org_id, amount, id);
+
+-- only processed expenses have a processed_at, the rest don't need to be indexed
+CREATE INDEX idx_expenses_org_id_processed_at ON expenses (org_id, processed_at) WHERE processed_at IS NOT NULL;
+
+CREATE INDEX idx_expenses_org_id_status_created_at ON expenses (org_id, status, created_at, id);
+
+CREATE INDEX idx_expenses_user_id_created_at ON expenses (user_id, created_at, id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_approvals_notes_trgm;
+DROP INDEX IF EXISTS idx_expenses_description_trgm;
+
+DROP INDEX IF EXISTS idx_users_name_tsv;
+DROP INDEX IF EXISTS idx_approvals_notes_tsv;
+DROP INDEX IF EXISTS idx_expenses_description_tsv;
+
+DROP EXTENSION IF EXISTS pg_trgm;
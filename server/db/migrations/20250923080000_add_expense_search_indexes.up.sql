@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the expressions have to match the search query exactly for the planner to use them
+CREATE INDEX idx_expenses_description_tsv ON expenses USING GIN (to_tsvector('simple', description));
+CREATE INDEX idx_approvals_notes_tsv ON approvals USING GIN (to_tsvector('simple', COALESCE(notes, '')));
+CREATE INDEX idx_users_name_tsv ON users USING GIN (to_tsvector('simple', name));
+
+-- trigrams catch misspelled words that full-text search misses
+CREATE INDEX idx_expenses_description_trgm ON expenses USING GIN (description gin_trgm_ops);
+CREATE INDEX idx_approvals_notes_trgm ON approvals USING GIN (notes gin_trgm_ops);
+CREATE INDEX idx_users_name_trgm ON users USING GIN (name gin_trgm_ops);
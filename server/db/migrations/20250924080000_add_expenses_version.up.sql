@@ -0,0 +1,2 @@
+-- bumped on every change of an expense, served as the ETag for optimistic concurrency
+ALTER TABLE expenses ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- empty means the user has no preference and the Accept-Language header decides
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(2) NOT NULL DEFAULT '',
+    ADD CONSTRAINT chk_users_locale CHECK (locale IN ('', 'en', 'id'));
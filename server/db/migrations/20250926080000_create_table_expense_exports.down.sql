@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS expense_exports;
+
+DROP TYPE IF EXISTS expense_export_status;
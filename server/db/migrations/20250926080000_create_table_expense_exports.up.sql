@@ -0,0 +1,33 @@
+CREATE TYPE expense_export_status AS ENUM (
+    'pending',
+    'processing',
+    'completed',
+    'failed'
+);
+
+CREATE TABLE IF NOT EXISTS expense_exports (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    format VARCHAR(4) NOT NULL,
+    filters JSONB NOT NULL,
+    status expense_export_status NOT NULL DEFAULT 'pending',
+    row_count INT,
+    file BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_expense_exports_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_expense_exports_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT format_check CHECK (format IN ('csv', 'xlsx'))
+);
+
+CREATE INDEX idx_expense_exports_user_id ON expense_exports (user_id);
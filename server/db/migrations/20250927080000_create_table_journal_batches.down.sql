@@ -0,0 +1,8 @@
+ALTER TABLE expenses
+    DROP COLUMN IF EXISTS journal_batch_id;
+
+DROP TABLE IF EXISTS journal_lines;
+
+DROP TABLE IF EXISTS journal_batches;
+
+DROP TABLE IF EXISTS gl_account_mappings;
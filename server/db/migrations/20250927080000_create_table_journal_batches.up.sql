@@ -0,0 +1,78 @@
+-- expenses have no category, the cost center picks the accounts and the row without one is the default
+CREATE TABLE IF NOT EXISTS gl_account_mappings (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    cost_center VARCHAR(50),
+    expense_account VARCHAR(50) NOT NULL,
+    credit_account VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_gl_account_mappings_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT uq_gl_account_mappings_org_id_cost_center
+        UNIQUE NULLS NOT DISTINCT (org_id, cost_center)
+);
+
+CREATE TABLE IF NOT EXISTS journal_batches (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    period VARCHAR(7) NOT NULL,
+    created_by BIGINT NOT NULL,
+    line_count INT NOT NULL,
+    total_amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_journal_batches_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_journal_batches_created_by
+        FOREIGN KEY(created_by)
+        REFERENCES users(id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_journal_batches_org_id_period ON journal_batches (org_id, period);
+
+-- the lines are what was posted, they are never updated
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id BIGINT NOT NULL,
+    expense_id BIGINT NOT NULL,
+    line_no INT NOT NULL,
+    entry_date DATE NOT NULL,
+    account VARCHAR(50) NOT NULL,
+    debit BIGINT NOT NULL DEFAULT 0,
+    credit BIGINT NOT NULL DEFAULT 0,
+    cost_center VARCHAR(50),
+    memo VARCHAR(300) NOT NULL,
+
+    CONSTRAINT fk_journal_lines_batch_id
+        FOREIGN KEY(batch_id)
+        REFERENCES journal_batches(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_journal_lines_expense_id
+        FOREIGN KEY(expense_id)
+        REFERENCES expenses(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT uq_journal_lines_batch_id_line_no UNIQUE (batch_id, line_no),
+    CONSTRAINT debit_or_credit_check CHECK ((debit > 0 AND credit = 0) OR (debit = 0 AND credit > 0))
+);
+
+-- set once the expense is in a batch, it's never exported again
+ALTER TABLE expenses
+    ADD COLUMN journal_batch_id BIGINT,
+    ADD CONSTRAINT fk_expenses_journal_batch_id
+        FOREIGN KEY(journal_batch_id)
+        REFERENCES journal_batches(id)
+        ON DELETE RESTRICT;
+
+CREATE INDEX idx_expenses_org_id_processed_at_unexported ON expenses (org_id, processed_at)
+    WHERE status = 'completed' AND journal_batch_id IS NULL;
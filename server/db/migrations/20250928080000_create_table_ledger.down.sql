@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS ledger_postings;
+
+DROP TABLE IF EXISTS ledger_entries;
+
+DROP TYPE IF EXISTS ledger_entry_type;
+
+DROP TABLE IF EXISTS ledger_accounts;
+
+DROP TYPE IF EXISTS ledger_account_type;
@@ -0,0 +1,139 @@
+DROP TYPE IF EXISTS ledger_account_type;
+CREATE TYPE ledger_account_type AS ENUM (
+    'reimbursement_expense',
+    'employee_payable',
+    'cash'
+);
+
+-- the expense and cash accounts belong to the organization, every employee has their own payable account
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    type ledger_account_type NOT NULL,
+    user_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_ledger_accounts_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_ledger_accounts_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT uq_ledger_accounts_org_id_type_user_id
+        UNIQUE NULLS NOT DISTINCT (org_id, type, user_id),
+
+    CONSTRAINT user_id_check CHECK ((type = 'employee_payable') = (user_id IS NOT NULL))
+);
+
+DROP TYPE IF EXISTS ledger_entry_type;
+CREATE TYPE ledger_entry_type AS ENUM (
+    'approval',
+    'payout',
+    'reversal'
+);
+
+-- entries and postings are only ever inserted, a mistake is corrected with a reversal
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    expense_id BIGINT NOT NULL,
+    type ledger_entry_type NOT NULL,
+    reverses_entry_id BIGINT,
+    memo VARCHAR(255) NOT NULL,
+    created_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_ledger_entries_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_ledger_entries_expense_id
+        FOREIGN KEY(expense_id)
+        REFERENCES expenses(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_ledger_entries_reverses_entry_id
+        FOREIGN KEY(reverses_entry_id)
+        REFERENCES ledger_entries(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_ledger_entries_created_by
+        FOREIGN KEY(created_by)
+        REFERENCES users(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT reverses_entry_id_check CHECK ((type = 'reversal') = (reverses_entry_id IS NOT NULL))
+);
+
+-- an expense is approved and paid once and an entry is reversed once, a retry can't post twice
+CREATE UNIQUE INDEX uq_ledger_entries_expense_id_type ON ledger_entries (expense_id, type) WHERE type <> 'reversal';
+CREATE UNIQUE INDEX uq_ledger_entries_reverses_entry_id ON ledger_entries (reverses_entry_id);
+CREATE INDEX idx_ledger_entries_org_id ON ledger_entries (org_id);
+
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    debit BIGINT NOT NULL DEFAULT 0,
+    credit BIGINT NOT NULL DEFAULT 0,
+
+    CONSTRAINT fk_ledger_postings_entry_id
+        FOREIGN KEY(entry_id)
+        REFERENCES ledger_entries(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_ledger_postings_account_id
+        FOREIGN KEY(account_id)
+        REFERENCES ledger_accounts(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT debit_or_credit_check CHECK ((debit > 0 AND credit = 0) OR (debit = 0 AND credit > 0))
+);
+
+CREATE INDEX idx_ledger_postings_entry_id ON ledger_postings (entry_id);
+CREATE INDEX idx_ledger_postings_account_id ON ledger_postings (account_id);
+
+-- post what already happened so the balances start from the current state of the expenses
+INSERT INTO ledger_accounts (org_id, type)
+SELECT id, 'reimbursement_expense' FROM organizations
+UNION ALL
+SELECT id, 'cash' FROM organizations;
+
+INSERT INTO ledger_accounts (org_id, type, user_id)
+SELECT DISTINCT org_id, 'employee_payable'::ledger_account_type, user_id FROM expenses WHERE status IN ('approved', 'completed');
+
+INSERT INTO ledger_entries (org_id, expense_id, type, memo, created_at)
+SELECT org_id, id, 'approval', 'Expense approved', created_at FROM expenses WHERE status IN ('approved', 'completed');
+
+INSERT INTO ledger_entries (org_id, expense_id, type, memo, created_at)
+SELECT org_id, id, 'payout', 'Expense paid out', COALESCE(processed_at, created_at) FROM expenses WHERE status = 'completed';
+
+INSERT INTO ledger_postings (entry_id, account_id, debit, credit)
+SELECT le.id, la.id, e.amount, 0
+FROM ledger_entries AS le
+JOIN expenses AS e ON e.id = le.expense_id
+JOIN ledger_accounts AS la ON la.org_id = le.org_id AND la.type = 'reimbursement_expense'
+WHERE le.type = 'approval'
+UNION ALL
+SELECT le.id, la.id, 0, e.amount
+FROM ledger_entries AS le
+JOIN expenses AS e ON e.id = le.expense_id
+JOIN ledger_accounts AS la ON la.org_id = le.org_id AND la.type = 'employee_payable' AND la.user_id = e.user_id
+WHERE le.type = 'approval'
+UNION ALL
+SELECT le.id, la.id, e.amount, 0
+FROM ledger_entries AS le
+JOIN expenses AS e ON e.id = le.expense_id
+JOIN ledger_accounts AS la ON la.org_id = le.org_id AND la.type = 'employee_payable' AND la.user_id = e.user_id
+WHERE le.type = 'payout'
+UNION ALL
+SELECT le.id, la.id, 0, e.amount
+FROM ledger_entries AS le
+JOIN expenses AS e ON e.id = le.expense_id
+JOIN ledger_accounts AS la ON la.org_id = le.org_id AND la.type = 'cash'
+WHERE le.type = 'payout';
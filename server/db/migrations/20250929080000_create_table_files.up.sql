@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS files (
+    key VARCHAR(255) PRIMARY KEY,
+    content_type VARCHAR(100) NOT NULL,
+    content BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
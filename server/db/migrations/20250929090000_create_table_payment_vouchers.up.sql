@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS payment_vouchers (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    expense_id BIGINT NOT NULL,
+    partner_transaction_id VARCHAR(255) NOT NULL,
+    file_key VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    generated_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_payment_vouchers_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_payment_vouchers_expense_id
+        FOREIGN KEY(expense_id)
+        REFERENCES expenses(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT uq_payment_vouchers_expense_id UNIQUE (expense_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS expense_comment_mentions;
+
+DROP TABLE IF EXISTS expense_comments;
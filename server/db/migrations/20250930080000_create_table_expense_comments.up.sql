@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS expense_comments (
+    id BIGSERIAL PRIMARY KEY,
+    expense_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_expense_comments_expense_id
+        FOREIGN KEY(expense_id)
+        REFERENCES expenses(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_expense_comments_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_expense_comments_expense_id ON expense_comments (expense_id, created_at);
+
+CREATE TABLE IF NOT EXISTS expense_comment_mentions (
+    comment_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+
+    PRIMARY KEY (comment_id, user_id),
+
+    CONSTRAINT fk_expense_comment_mentions_comment_id
+        FOREIGN KEY(comment_id)
+        REFERENCES expense_comments(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_expense_comment_mentions_user_id
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT
+);
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS expense_review_rounds;
+
+-- enum values can't be dropped, the type is recreated without it
+UPDATE expenses SET status = 'awaiting_approval' WHERE status = 'changes_requested';
+
+DROP INDEX IF EXISTS idx_expenses_org_id_processed_at_unexported;
+
+ALTER TYPE expense_status RENAME TO expense_status_old;
+CREATE TYPE expense_status AS ENUM ('awaiting_approval', 'approved', 'rejected', 'completed');
+
+ALTER TABLE expenses ALTER COLUMN status TYPE expense_status USING status::text::expense_status;
+
+DROP TYPE expense_status_old;
+
+CREATE INDEX idx_expenses_org_id_processed_at_unexported ON expenses (org_id, processed_at)
+    WHERE status = 'completed' AND journal_batch_id IS NULL;
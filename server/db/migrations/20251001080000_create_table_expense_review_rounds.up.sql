@@ -0,0 +1,31 @@
+ALTER TYPE expense_status ADD VALUE IF NOT EXISTS 'changes_requested';
+
+-- every request for changes opens a round, the resubmission of the submitter closes it
+CREATE TABLE IF NOT EXISTS expense_review_rounds (
+    id BIGSERIAL PRIMARY KEY,
+    org_id BIGINT NOT NULL,
+    expense_id BIGINT NOT NULL,
+    round INT NOT NULL,
+    reviewer_id BIGINT NOT NULL,
+    notes TEXT NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resubmitted_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_expense_review_rounds_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_expense_review_rounds_expense_id
+        FOREIGN KEY(expense_id)
+        REFERENCES expenses(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_expense_review_rounds_reviewer_id
+        FOREIGN KEY(reviewer_id)
+        REFERENCES users(id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT uq_expense_review_rounds_expense_id_round UNIQUE (expense_id, round),
+    CONSTRAINT round_check CHECK (round > 0)
+);
@@ -0,0 +1,13 @@
+-- large files are stored in parts so they're never held in memory whole, their files row has an empty content
+CREATE TABLE IF NOT EXISTS file_chunks (
+    key VARCHAR(255) NOT NULL,
+    seq INT NOT NULL,
+    content BYTEA NOT NULL,
+
+    PRIMARY KEY (key, seq),
+
+    CONSTRAINT fk_file_chunks_key
+        FOREIGN KEY(key)
+        REFERENCES files(key)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+ALTER TABLE expense_exports ADD COLUMN file BYTEA;
+
+UPDATE expense_exports e SET file = (
+    SELECT string_agg(c.content, ''::BYTEA ORDER BY c.seq) FROM file_chunks c WHERE c.key = e.file_key
+) WHERE e.file_key IS NOT NULL;
+
+DELETE FROM files WHERE key IN (SELECT file_key FROM expense_exports WHERE file_key IS NOT NULL);
+
+ALTER TABLE expense_exports DROP COLUMN IF EXISTS file_key;
@@ -0,0 +1,17 @@
+ALTER TABLE expense_exports ADD COLUMN file_key VARCHAR(255);
+
+-- completed exports move to the file storage as a single chunk
+INSERT INTO files (key, content_type, content, created_at)
+SELECT 'exports/' || org_id || '/' || id || '.' || format,
+    CASE format WHEN 'xlsx' THEN 'application/vnd.openxmlformats-officedocument.spreadsheetml.sheet' ELSE 'text/csv; charset=utf-8' END,
+    ''::BYTEA,
+    COALESCE(completed_at, created_at)
+FROM expense_exports WHERE file IS NOT NULL;
+
+INSERT INTO file_chunks (key, seq, content)
+SELECT 'exports/' || org_id || '/' || id || '.' || format, 0, file
+FROM expense_exports WHERE file IS NOT NULL;
+
+UPDATE expense_exports SET file_key = 'exports/' || org_id || '/' || id || '.' || format WHERE file IS NOT NULL;
+
+ALTER TABLE expense_exports DROP COLUMN file;
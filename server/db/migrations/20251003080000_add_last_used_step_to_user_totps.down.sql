@@ -0,0 +1 @@
+ALTER TABLE user_totps DROP COLUMN IF EXISTS last_used_step;
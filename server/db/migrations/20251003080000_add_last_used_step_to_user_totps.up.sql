@@ -0,0 +1 @@
+ALTER TABLE user_totps ADD COLUMN IF NOT EXISTS last_used_step BIGINT;
@@ -0,0 +1,3 @@
+ALTER TABLE department_budgets DROP CONSTRAINT IF EXISTS excl_department_budgets_department_id_period;
+
+DROP EXTENSION IF EXISTS btree_gist;
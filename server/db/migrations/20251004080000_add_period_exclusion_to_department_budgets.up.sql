@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- budgets of a department can not overlap, creating them concurrently can not race past the check
+ALTER TABLE department_budgets ADD CONSTRAINT excl_department_budgets_department_id_period
+    EXCLUDE USING gist (department_id WITH =, daterange(period_start, period_end, '[]') WITH &&);
@@ -0,0 +1,4 @@
+ALTER TABLE user_identities DROP COLUMN IF EXISTS org_id;
+ALTER TABLE personal_access_tokens DROP COLUMN IF EXISTS org_id;
+ALTER TABLE expense_comments DROP COLUMN IF EXISTS org_id;
+ALTER TABLE department_budgets DROP COLUMN IF EXISTS org_id;
@@ -0,0 +1,36 @@
+-- every query of these tables is scoped to the organization, the existing rows take it from their parent
+ALTER TABLE department_budgets ADD COLUMN org_id BIGINT;
+UPDATE department_budgets AS b SET org_id = d.org_id FROM departments AS d WHERE d.id = b.department_id;
+ALTER TABLE department_budgets
+    ALTER COLUMN org_id SET NOT NULL,
+    ADD CONSTRAINT fk_department_budgets_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE expense_comments ADD COLUMN org_id BIGINT;
+UPDATE expense_comments AS c SET org_id = e.org_id FROM expenses AS e WHERE e.id = c.expense_id;
+ALTER TABLE expense_comments
+    ALTER COLUMN org_id SET NOT NULL,
+    ADD CONSTRAINT fk_expense_comments_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE personal_access_tokens ADD COLUMN org_id BIGINT;
+UPDATE personal_access_tokens AS t SET org_id = u.org_id FROM users AS u WHERE u.id = t.user_id;
+ALTER TABLE personal_access_tokens
+    ALTER COLUMN org_id SET NOT NULL,
+    ADD CONSTRAINT fk_personal_access_tokens_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE user_identities ADD COLUMN org_id BIGINT;
+UPDATE user_identities AS i SET org_id = u.org_id FROM users AS u WHERE u.id = i.user_id;
+ALTER TABLE user_identities
+    ALTER COLUMN org_id SET NOT NULL,
+    ADD CONSTRAINT fk_user_identities_org_id
+        FOREIGN KEY(org_id)
+        REFERENCES organizations(id)
+        ON DELETE RESTRICT;
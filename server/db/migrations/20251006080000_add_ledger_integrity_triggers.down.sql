@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trg_ledger_postings_balanced ON ledger_postings;
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_balanced ON ledger_entries;
+
+DROP FUNCTION IF EXISTS ledger_check_entry_balanced();
+
+DROP TRIGGER IF EXISTS trg_ledger_postings_append_only ON ledger_postings;
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_append_only ON ledger_entries;
+
+DROP FUNCTION IF EXISTS ledger_reject_change();
@@ -0,0 +1,51 @@
+-- entries and postings are only ever inserted, a mistake is corrected with a reversal
+CREATE OR REPLACE FUNCTION ledger_reject_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION '% is append only, post a reversal instead', TG_TABLE_NAME
+        USING ERRCODE = 'restrict_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+CREATE TRIGGER trg_ledger_postings_append_only
+    BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+-- the postings of an entry are inserted one by one, so the balance is checked at commit
+-- when the whole entry is there, an entry without postings doesn't balance either
+CREATE OR REPLACE FUNCTION ledger_check_entry_balanced() RETURNS TRIGGER AS $$
+DECLARE
+    v_entry_id BIGINT;
+    v_debit BIGINT;
+    v_credit BIGINT;
+BEGIN
+    IF TG_TABLE_NAME = 'ledger_entries' THEN
+        v_entry_id := NEW.id;
+    ELSE
+        v_entry_id := NEW.entry_id;
+    END IF;
+
+    SELECT COALESCE(SUM(debit), 0), COALESCE(SUM(credit), 0) INTO v_debit, v_credit
+    FROM ledger_postings WHERE entry_id = v_entry_id;
+
+    IF v_debit = 0 OR v_debit <> v_credit THEN
+        RAISE EXCEPTION 'ledger entry % is not balanced, debit % and credit %', v_entry_id, v_debit, v_credit
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_ledger_entries_balanced
+    AFTER INSERT ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_check_entry_balanced();
+
+CREATE CONSTRAINT TRIGGER trg_ledger_postings_balanced
+    AFTER INSERT ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_check_entry_balanced();
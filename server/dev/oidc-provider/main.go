@@ -0,0 +1,281 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const keyID = "dev-key"
+
+type Config struct {
+	IssuerURL    string // iss claim, discovery and token endpoints are served below it
+	AuthorizeURL string // browser facing authorize endpoint, defaults to the issuer
+	ClientID     string
+	ClientSecret string // checked on the token endpoint when set
+}
+
+type authorization struct {
+	ClientID      string
+	RedirectURI   string
+	Nonce         string
+	CodeChallenge string
+	Email         string
+	Name          string
+	ExpiresAt     time.Time
+}
+
+type Server struct {
+	cfg   Config
+	key   *rsa.PrivateKey
+	codes map[string]authorization // code => authorization, single use
+	mu    sync.Mutex
+}
+
+func NewServer(cfg Config) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AuthorizeURL == "" {
+		cfg.AuthorizeURL = cfg.IssuerURL + "/authorize"
+	}
+
+	return &Server{
+		cfg:   cfg,
+		key:   key,
+		codes: make(map[string]authorization),
+	}, nil
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discoveryHandler)
+	mux.HandleFunc("/jwks", s.jwksHandler)
+	mux.HandleFunc("/authorize", s.authorizeHandler)
+	mux.HandleFunc("/token", s.tokenHandler)
+	return mux
+}
+
+func (s *Server) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.cfg.IssuerURL,
+		"authorization_endpoint":                s.cfg.AuthorizeURL,
+		"token_endpoint":                        s.cfg.IssuerURL + "/token",
+		"jwks_uri":                              s.cfg.IssuerURL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": keyID,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	})
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!doctype html>
+<html>
+<body>
+  <h3>Fake OIDC provider</h3>
+  <form method="post">
+    <label>Email <input name="email" type="email" required></label>
+    <label>Name <input name="name"></label>
+    <button type="submit">Sign in</button>
+  </form>
+</body>
+</html>`))
+
+// authorizeHandler signs in whoever is named in login_hint right away,
+// otherwise it renders a form asking for an email and posts back to itself
+func (s *Server) authorizeHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("response_type") != "code" || q.Get("client_id") != s.cfg.ClientID || q.Get("redirect_uri") == "" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "pkce with S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	email := q.Get("login_hint")
+	name := ""
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return
+		}
+		email = r.PostForm.Get("email")
+		name = r.PostForm.Get("name")
+	}
+
+	if email == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		loginPage.Execute(w, nil)
+		return
+	}
+
+	if name == "" {
+		name = strings.Split(email, "@")[0]
+	}
+
+	code := uuid.NewString()
+
+	s.mu.Lock()
+	s.codes[code] = authorization{
+		ClientID:      q.Get("client_id"),
+		RedirectURI:   q.Get("redirect_uri"),
+		Nonce:         q.Get("nonce"),
+		CodeChallenge: q.Get("code_challenge"),
+		Email:         email,
+		Name:          name,
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	redirectURL, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	rq := redirectURL.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirectURL.RawQuery = rq.Encode()
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+func (s *Server) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if s.cfg.ClientSecret != "" {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != s.cfg.ClientID || clientSecret != s.cfg.ClientSecret {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+			return
+		}
+	}
+
+	code := r.PostForm.Get("code")
+
+	s.mu.Lock()
+	authz, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	if r.PostForm.Get("grant_type") != "authorization_code" || !ok || time.Now().After(authz.ExpiresAt) ||
+		authz.ClientID != r.PostForm.Get("client_id") || authz.RedirectURI != r.PostForm.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != authz.CodeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.cfg.IssuerURL,
+		"sub":            subjectFor(authz.Email),
+		"aud":            authz.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          authz.Nonce,
+		"email":          authz.Email,
+		"email_verified": true,
+		"name":           authz.Name,
+	})
+	token.Header["kid"] = keyID
+
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": uuid.NewString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+// subjectFor keeps the subject stable per email across restarts
+func subjectFor(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func getEnv(key, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
+
+func main() {
+	port := getEnv("APP_PORT", "9600")
+
+	server, err := NewServer(Config{
+		IssuerURL:    getEnv("ISSUER_URL", "http://localhost:"+port),
+		AuthorizeURL: os.Getenv("AUTHORIZE_URL"),
+		ClientID:     getEnv("CLIENT_ID", "expense-management"),
+		ClientSecret: os.Getenv("CLIENT_SECRET"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("mock oidc provider running at port %s\n", port)
+	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), server.Handler()))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"expense-management-system/internal/auth"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:5173/sso/callback"
+
+// authorize walks the authorization endpoint the way a browser would and returns the code
+func authorize(t *testing.T, p auth.OIDCProvider, nonce, verifier, loginHint string) string {
+	authURL, err := p.AuthCodeURL(context.Background(), "state-1", nonce, auth.PKCEChallenge(verifier))
+	require.NoError(t, err)
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL + "&login_hint=" + url.QueryEscape(loginHint))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", location.Query().Get("state"))
+
+	return location.Query().Get("code")
+}
+
+func TestOIDCProvider_Flow(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientSecret  string
+		exchangeNonce string
+		verifier      string
+		wantErr       error
+	}{
+		{
+			name:          "pkce verifier mismatch",
+			exchangeNonce: "nonce-1",
+			verifier:      "another-verifier-another-verifier-another-ve",
+			wantErr:       auth.ErrOIDCInvalidGrant,
+		},
+		{
+			name:          "nonce mismatch",
+			exchangeNonce: "nonce-2",
+			wantErr:       auth.ErrOIDCInvalidIDToken,
+		},
+		{
+			name:          "wrong client secret",
+			clientSecret:  "wrong",
+			exchangeNonce: "nonce-1",
+			wantErr:       auth.ErrOIDCInvalidGrant,
+		},
+		{
+			name:          "success",
+			clientSecret:  "secret",
+			exchangeNonce: "nonce-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewUnstartedServer(nil)
+			fake, err := NewServer(Config{
+				IssuerURL:    "http://" + ts.Listener.Addr().String(),
+				ClientID:     "expense-management",
+				ClientSecret: "secret",
+			})
+			require.NoError(t, err)
+			ts.Config.Handler = fake.Handler()
+			ts.Start()
+			defer ts.Close()
+
+			clientSecret := tt.clientSecret
+			if clientSecret == "" {
+				clientSecret = "secret"
+			}
+
+			p := auth.NewOIDCProvider(auth.OIDCConfig{
+				IssuerURL:    ts.URL,
+				ClientID:     "expense-management",
+				ClientSecret: clientSecret,
+				RedirectURL:  redirectURL,
+				Timeout:      3 * time.Second,
+			})
+
+			verifier, err := auth.GenerateOpaqueToken()
+			require.NoError(t, err)
+
+			code := authorize(t, p, "nonce-1", verifier, "john@mail.com")
+
+			exchangeVerifier := verifier
+			if tt.verifier != "" {
+				exchangeVerifier = tt.verifier
+			}
+
+			identity, err := p.Exchange(context.Background(), code, exchangeVerifier, tt.exchangeNonce)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, ts.URL, identity.Issuer)
+			assert.Equal(t, subjectFor("john@mail.com"), identity.Subject)
+			assert.Equal(t, "john@mail.com", identity.Email)
+			assert.True(t, identity.EmailVerified)
+			assert.Equal(t, "john", identity.Name)
+
+			// codes are single use
+			_, err = p.Exchange(context.Background(), code, verifier, tt.exchangeNonce)
+			assert.ErrorIs(t, err, auth.ErrOIDCInvalidGrant)
+		})
+	}
+}
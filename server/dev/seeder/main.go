@@ -50,6 +50,8 @@ func main() {
 
 	var (
 		defaultReceiptURL = "https://placehold.co/500x700"
+		// policy of the default organization created by the migration
+		defaultApprovalThreshold = uint64(1000000)
 
 		users    []entity.User
 		expenses []entity.Expense
@@ -61,6 +63,7 @@ func main() {
 		{ID: 2, Email: "wawan@mail.com", Name: "Wawan", PasswordHash: "$2a$10$KOIpI26eF/WTIE8s8GV56OCo6qK/GOHaIppLcNX8elxXnuUekOw82", Role: "manager", CreatedAt: time.Date(2025, 9, 1, 13, 3, 30, 000, time.UTC)},
 		{ID: 3, Email: "budi@mail.com", Name: "Budi", PasswordHash: "$2a$10$ZnT7RBH14iLQXsQGN1Z99O7lASuaJYYiIZcyCpyj9oC8w6m7..Wxu", Role: "employee", CreatedAt: time.Date(2025, 9, 2, 13, 4, 30, 000, time.UTC)},
 		{ID: 4, Email: "lala@mail.com", Name: "Lala", PasswordHash: "$2a$10$UFBubu4rYw7.ZvVd9rq75Otj12ppjaVOJO/VTBjyc0wkP.fhfBBsO", Role: "employee", CreatedAt: time.Date(2025, 9, 2, 13, 5, 30, 000, time.UTC)},
+		{ID: 5, Email: "admin@mail.com", Name: "Admin", PasswordHash: "$2a$10$1yvh.PWIxVSQEHnscBlBgOYhzVT1TpClGPbcJl9jDMI.CTWnnNjTe", Role: "admin", CreatedAt: time.Date(2025, 9, 2, 13, 6, 30, 000, time.UTC)},
 	}
 	expenses = []entity.Expense{
 		{ID: 1, UserID: 3, Amount: 150000, Description: "Snacks", ReceiptURL: &defaultReceiptURL, Status: entity.ExpenseStatusCompleted, CreatedAt: time.Date(2025, 8, 2, 13, 2, 30, 000, time.UTC)},
@@ -88,14 +91,18 @@ func main() {
 		{ID: 19, UserID: 3, Amount: 750000, Description: "Foods and drinks", ReceiptURL: nil, Status: entity.ExpenseStatusApproved, CreatedAt: time.Date(2025, 8, 6, 3, 2, 30, 000, time.UTC)},
 		{ID: 20, UserID: 3, Amount: 1210000, Description: "Team dinner", ReceiptURL: &defaultReceiptURL, Status: entity.ExpenseStatusAwaitingApproval, CreatedAt: time.Date(2025, 8, 6, 4, 2, 30, 000, time.UTC)},
 	}
+	for i := range expenses {
+		expenses[i].OrgID = entity.DefaultOrganizationID
+		expenses[i].ApprovalThreshold = defaultApprovalThreshold
+	}
 
 	//  users table
 	logger.Info("seeding users table ...")
 	for _, u := range users {
 		_, err = tx.Exec(ctx,
-			`INSERT INTO users (id, email, name, password_hash, role, created_at) 
-			 VALUES ($1, $2, $3, $4, $5, $6)`,
-			u.ID, u.Email, u.Name, u.PasswordHash, u.Role, u.CreatedAt,
+			`INSERT INTO users (id, org_id, email, name, password_hash, role, created_at) 
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			u.ID, entity.DefaultOrganizationID, u.Email, u.Name, u.PasswordHash, u.Role, u.CreatedAt,
 		)
 		if err != nil {
 			return
@@ -114,9 +121,9 @@ func main() {
 		}
 
 		_, err = tx.Exec(ctx,
-			`INSERT INTO expenses (id, user_id, amount, description, receipt_url, status, created_at, processed_at) 
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-			e.ID, e.UserID, e.Amount, e.Description, e.ReceiptURL, e.Status, e.CreatedAt, processedAt,
+			`INSERT INTO expenses (id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, created_at, processed_at) 
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			e.ID, e.OrgID, e.UserID, e.Amount, e.Description, e.ReceiptURL, e.Status, e.ApprovalThreshold, e.CreatedAt, processedAt,
 		)
 		if err != nil {
 			return
@@ -154,9 +161,9 @@ func main() {
 			}
 
 			_, err = tx.Exec(ctx,
-				`INSERT INTO approvals (expense_id, approver_id, status, notes, created_at) 
-				 VALUES ($1, $2, $3, $4, $5)`,
-				e.ID, approverID, status, notes, e.CreatedAt.Add(5*time.Second),
+				`INSERT INTO approvals (org_id, expense_id, approver_id, status, notes, created_at) 
+				 VALUES ($1, $2, $3, $4, $5, $6)`,
+				e.OrgID, e.ID, approverID, status, notes, e.CreatedAt.Add(5*time.Second),
 			)
 			if err != nil {
 				return
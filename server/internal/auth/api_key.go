@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	APIKeyPrefix = "ems_"
+
+	ScopeExpensesRead  = "expenses:read"
+	ScopeExpensesWrite = "expenses:write"
+	ScopeSCIM          = "scim" // user provisioning, admins only
+)
+
+//go:generate mockery --name=APIKeyAuthenticator --structname APIKeyAuthenticator --outpkg=mocks --output=./../mocks
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*JWTClaims, error)
+}
+
+// IsAPIKey tells a personal access token apart from a jwt by its prefix
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
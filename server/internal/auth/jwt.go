@@ -9,18 +9,59 @@ import (
 )
 
 const (
-	PrefixRevokeKey = "revoke-jwt-token"
+	PrefixRevokeKey             = "revoke-jwt-token"
+	PrefixRevokeUserKey         = "revoke-user-tokens" // unix time before which all tokens of a user are revoked
+	PrefixTwoFactorChallengeKey = "2fa-challenge"
+	PrefixPasswordResetKey      = "password-reset"
+	PrefixPasswordResetUserKey  = "password-reset-user" // hashes of the reset tokens issued to a user
+	PrefixPasswordForgotKey     = "password-forgot"     // present while another reset mail to the email is throttled
+	PrefixSSOStateKey           = "sso-state"
+	PrefixDeactivatedUserKey    = "deactivated-user" // present while the user is deactivated, kept as long as the sessions
 )
 
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
+	UserID   string   `json:"user_id"`
+	OrgID    uint64   `json:"org_id"` // organization of the user, every query is scoped by it
+	Role     string   `json:"role"`
+	Locale   string   `json:"locale,omitempty"` // preferred language of the user, empty follows Accept-Language
+	AMR      []string `json:"amr,omitempty"`    // authentication methods used on top of the password
+	APIKeyID uint64   `json:"-"`                // set when authenticated with a personal access token
+	Scopes   []string `json:"-"`                // only limits personal access tokens
 	jwt.RegisteredClaims
 }
 
+func (c *JWTClaims) IsAPIKey() bool {
+	return c.APIKeyID != 0
+}
+
+// HasScope reports whether the token may be used for scope, a login session is never limited
+func (c *JWTClaims) HasScope(scope string) bool {
+	if !c.IsAPIKey() {
+		return true
+	}
+
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *JWTClaims) HasAMR(method string) bool {
+	for _, m := range c.AMR {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
 //go:generate mockery --name=JWTToken --structname JWTToken --outpkg=mocks --output=./../mocks
 type JWTToken interface {
-	Create(userID string, role string) (string, error)
+	Create(userID string, orgID uint64, role string, locale string, amr ...string) (string, error)
 	Parse(jwtToken string) (*JWTClaims, error)
 }
 
@@ -36,11 +77,14 @@ func NewJWTToken(secretKey string, expireDuration time.Duration) JWTToken {
 	}
 }
 
-func (j *jwtToken) Create(userID string, role string) (string, error) {
+func (j *jwtToken) Create(userID string, orgID uint64, role string, locale string, amr ...string) (string, error) {
 	now := time.Now()
 	claims := JWTClaims{
 		UserID: userID,
+		OrgID:  orgID,
 		Role:   role,
+		Locale: locale,
+		AMR:    amr,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.expireDuration)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -73,5 +117,10 @@ func (j *jwtToken) Parse(tokenString string) (*JWTClaims, error) {
 		return nil, errors.New("expired jwt claims")
 	}
 
+	// issued before organizations existed, the user has to login again
+	if claims.OrgID == 0 {
+		return nil, errors.New("jwt claims without organization")
+	}
+
 	return claims, nil
 }
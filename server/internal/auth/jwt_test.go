@@ -32,7 +32,7 @@ func TestJWTToken_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			jwt := auth.NewJWTToken(tt.secretKey, time.Second)
-			token, err := jwt.Create(tt.userID, "manager")
+			token, err := jwt.Create(tt.userID, 1, "manager", "")
 
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.NotEmpty(t, token)
@@ -44,7 +44,7 @@ func TestJWTToken_Parse(t *testing.T) {
 	validSecret := "valid-secret"
 	invalidSecret := "invalid-secret"
 	jwt := auth.NewJWTToken(validSecret, 10*time.Second)
-	validToken, _ := jwt.Create("1", "manager")
+	validToken, _ := jwt.Create("1", 1, "manager", "")
 
 	tests := []struct {
 		name       string
@@ -63,7 +63,7 @@ func TestJWTToken_Parse(t *testing.T) {
 			token: func() string {
 				invToken, _ := auth.
 					NewJWTToken(invalidSecret, 10*time.Second).
-					Create("1", "manager")
+					Create("1", 1, "manager", "")
 				return invToken
 			}(),
 			wantErrMsg: "token signature is invalid: signature is invalid",
@@ -73,13 +73,21 @@ func TestJWTToken_Parse(t *testing.T) {
 			token: func() string {
 				expToken, _ := auth.
 					NewJWTToken(validSecret, 10*time.Millisecond).
-					Create("1", "manager")
+					Create("1", 1, "manager", "")
 				// wait token expired
 				time.Sleep(20 * time.Millisecond)
 				return expToken
 			}(),
 			wantErrMsg: "token has invalid claims: token is expired",
 		},
+		{
+			name: "token without organization",
+			token: func() string {
+				oldToken, _ := jwt.Create("1", 0, "manager", "")
+				return oldToken
+			}(),
+			wantErrMsg: "jwt claims without organization",
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,3 +104,67 @@ func TestJWTToken_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTClaims_HasAMR(t *testing.T) {
+	jwt := auth.NewJWTToken("valid-secret", 10*time.Second)
+	otpToken, _ := jwt.Create("1", 1, "manager", "", auth.AMRTOTP)
+	pwdToken, _ := jwt.Create("1", 1, "manager", "")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantRes bool
+	}{
+		{
+			name:    "token with otp",
+			token:   otpToken,
+			wantRes: true,
+		},
+		{
+			name:    "token without otp",
+			token:   pwdToken,
+			wantRes: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := jwt.Parse(tt.token)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantRes, claims.HasAMR(auth.AMRTOTP))
+		})
+	}
+}
+
+func TestJWTToken_Locale(t *testing.T) {
+	jwt := auth.NewJWTToken("valid-secret", 10*time.Second)
+	idToken, _ := jwt.Create("1", 1, "manager", "id")
+	noneToken, _ := jwt.Create("1", 1, "manager", "")
+
+	tests := []struct {
+		name       string
+		token      string
+		wantLocale string
+	}{
+		{
+			name:       "token with locale",
+			token:      idToken,
+			wantLocale: "id",
+		},
+		{
+			name:       "token without locale",
+			token:      noneToken,
+			wantLocale: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := jwt.Parse(tt.token)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLocale, claims.Locale)
+		})
+	}
+}
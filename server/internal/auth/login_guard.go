@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	PrefixLoginAttemptKey = "login-attempt"
+	PrefixLoginBlockKey   = "login-block"
+
+	// number of failed attempts per account before progressive delays kick in
+	loginFreeAttempts = 3
+)
+
+type LoginGuardConfig struct {
+	MaxAccountAttempts int
+	MaxIPAttempts      int
+	AttemptWindow      time.Duration
+	LockDuration       time.Duration
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+}
+
+//go:generate mockery --name=LoginGuard --structname LoginGuard --outpkg=mocks --output=./../mocks
+type LoginGuard interface {
+	Check(ctx context.Context, email string, ip string) (time.Duration, error)
+	Succeed(ctx context.Context, email string, ip string) error
+	Reset(ctx context.Context, email string) error
+}
+
+// attemptScript counts an attempt of a scope unless it's blocked, and blocks the next attempts once
+// the count is over the free attempts. It runs atomically so parallel guesses can't all pass before
+// the block is set. It returns how long the scope is still blocked in milliseconds, 0 when the attempt
+// is allowed.
+//
+// KEYS[1] attempt key, KEYS[2] block key
+// ARGV[1] window, ARGV[2] free attempts, ARGV[3] max attempts, ARGV[4] lock duration,
+// ARGV[5] base delay, ARGV[6] max delay, durations in milliseconds
+const attemptScript = `
+local blocked = redis.call('PTTL', KEYS[2])
+if blocked > 0 then
+	return blocked
+end
+
+local attempts = redis.call('INCR', KEYS[1])
+if attempts == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+
+local block = 0
+if attempts >= tonumber(ARGV[3]) then
+	block = tonumber(ARGV[4])
+elseif attempts > tonumber(ARGV[2]) then
+	block = math.min(tonumber(ARGV[5]) * 2 ^ (attempts - tonumber(ARGV[2]) - 1), tonumber(ARGV[6]))
+end
+
+if block > 0 then
+	redis.call('SET', KEYS[2], 'true', 'PX', math.floor(block))
+end
+
+return 0`
+
+// releaseScript takes back an attempt that succeeded, without creating a counter that already expired
+const releaseScript = `
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('DECR', KEYS[1])
+end
+return 0`
+
+type loginGuard struct {
+	redisClient storage.RedisClient
+	config      LoginGuardConfig
+}
+
+func NewLoginGuard(redisClient storage.RedisClient, config LoginGuardConfig) LoginGuard {
+	return &loginGuard{
+		redisClient: redisClient,
+		config:      config,
+	}
+}
+
+// Check counts the login attempt before it runs and returns how long the caller must wait when it's
+// not allowed, either because of a progressive delay or a temporary lockout. The attempt that reaches
+// a limit is still allowed, the block applies to the ones after it.
+func (g *loginGuard) Check(ctx context.Context, email string, ip string) (time.Duration, error) {
+	scopes := loginScopes(email, ip)
+
+	// the ip has no progressive delay, only the lockout
+	limits := []struct {
+		freeAttempts int
+		maxAttempts  int
+	}{
+		{loginFreeAttempts, g.config.MaxAccountAttempts},
+		{g.config.MaxIPAttempts, g.config.MaxIPAttempts},
+	}
+
+	for i, scope := range scopes {
+		blocked, err := g.redisClient.Eval(ctx, attemptScript, []string{attemptKey(scope), blockKey(scope)},
+			g.config.AttemptWindow.Milliseconds(),
+			limits[i].freeAttempts,
+			limits[i].maxAttempts,
+			g.config.LockDuration.Milliseconds(),
+			g.config.BaseDelay.Milliseconds(),
+			g.config.MaxDelay.Milliseconds(),
+		).Int64()
+		if err != nil {
+			return 0, err
+		}
+
+		if blocked > 0 {
+			return time.Duration(blocked) * time.Millisecond, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// Succeed clears the attempts of the account and takes the attempt back from the ip,
+// so only failed attempts count towards the limits
+func (g *loginGuard) Succeed(ctx context.Context, email string, ip string) error {
+	err := g.Reset(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return g.redisClient.Eval(ctx, releaseScript, []string{attemptKey(loginScopes(email, ip)[1])}).Err()
+}
+
+// Reset clears the failed attempts and any lockout of an account,
+// it's called after a successful login and by the admin unlock action
+func (g *loginGuard) Reset(ctx context.Context, email string) error {
+	scope := accountScope(email)
+
+	return g.redisClient.Del(ctx, attemptKey(scope), blockKey(scope)).Err()
+}
+
+func loginScopes(email string, ip string) []string {
+	return []string{accountScope(email), fmt.Sprintf("ip:%s", ip)}
+}
+
+func accountScope(email string) string {
+	return fmt.Sprintf("account:%s", strings.ToLower(strings.TrimSpace(email)))
+}
+
+func attemptKey(scope string) string {
+	return fmt.Sprintf("%s:%s", PrefixLoginAttemptKey, scope)
+}
+
+func blockKey(scope string) string {
+	return fmt.Sprintf("%s:%s", PrefixLoginBlockKey, scope)
+}
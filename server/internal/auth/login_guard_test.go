@@ -0,0 +1,169 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/mocks"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginGuardSuite struct {
+	suite.Suite
+	ctx    context.Context
+	config auth.LoginGuardConfig
+}
+
+func (s *LoginGuardSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.config = auth.LoginGuardConfig{
+		MaxAccountAttempts: 6,
+		MaxIPAttempts:      20,
+		AttemptWindow:      15 * time.Minute,
+		LockDuration:       15 * time.Minute,
+		BaseDelay:          time.Second,
+		MaxDelay:           time.Minute,
+	}
+}
+
+func (s *LoginGuardSuite) evalCmd(val int64, err error) *redis.Cmd {
+	cmd := redis.NewCmd(s.ctx)
+	cmd.SetVal(val)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *LoginGuardSuite) TestLoginGuard_Check() {
+	accountKeys := []string{"login-attempt:account:john@mail.com", "login-block:account:john@mail.com"}
+	ipKeys := []string{"login-attempt:ip:127.0.0.1", "login-block:ip:127.0.0.1"}
+	window, lock, base, maxDelay := int64(900000), int64(900000), int64(1000), int64(60000)
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient)
+		wantRes    time.Duration
+		wantErrMsg string
+	}{
+		{
+			name: "error on count account attempt",
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("Eval", mock.Anything, mock.Anything, accountKeys, window, 3, 6, lock, base, maxDelay).
+					Return(s.evalCmd(0, errors.New("something error")))
+			},
+			wantRes:    0,
+			wantErrMsg: "something error",
+		},
+		{
+			name: "allowed",
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("Eval", mock.Anything, mock.Anything, accountKeys, window, 3, 6, lock, base, maxDelay).
+					Return(s.evalCmd(0, nil))
+				rc.On("Eval", mock.Anything, mock.Anything, ipKeys, window, 20, 20, lock, base, maxDelay).
+					Return(s.evalCmd(0, nil))
+			},
+			wantRes:    0,
+			wantErrMsg: "",
+		},
+		{
+			name: "blocked by account",
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("Eval", mock.Anything, mock.Anything, accountKeys, window, 3, 6, lock, base, maxDelay).
+					Return(s.evalCmd(2000, nil))
+			},
+			wantRes:    2 * time.Second,
+			wantErrMsg: "",
+		},
+		{
+			name: "blocked by ip",
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("Eval", mock.Anything, mock.Anything, accountKeys, window, 3, 6, lock, base, maxDelay).
+					Return(s.evalCmd(0, nil))
+				rc.On("Eval", mock.Anything, mock.Anything, ipKeys, window, 20, 20, lock, base, maxDelay).
+					Return(s.evalCmd(600000, nil))
+			},
+			wantRes:    10 * time.Minute,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			tt.mockFunc(rc)
+
+			guard := auth.NewLoginGuard(rc, s.config)
+			res, err := guard.Check(s.ctx, " John@mail.com", "127.0.0.1")
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *LoginGuardSuite) TestLoginGuard_Succeed() {
+	tests := []struct {
+		name       string
+		mockFunc   func(rc *mocks.RedisClient)
+		wantErrMsg string
+	}{
+		{
+			name: "error on reset account",
+			mockFunc: func(rc *mocks.RedisClient) {
+				cmd := redis.NewIntCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("Del", mock.Anything, "login-attempt:account:john@mail.com", "login-block:account:john@mail.com").
+					Return(cmd)
+			},
+			wantErrMsg: "something error",
+		},
+		{
+			name: "success takes the attempt back from the ip",
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("Del", mock.Anything, "login-attempt:account:john@mail.com", "login-block:account:john@mail.com").
+					Return(redis.NewIntCmd(s.ctx))
+				rc.On("Eval", mock.Anything, mock.Anything, []string{"login-attempt:ip:127.0.0.1"}).
+					Return(s.evalCmd(0, nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			tt.mockFunc(rc)
+
+			guard := auth.NewLoginGuard(rc, s.config)
+			err := guard.Succeed(s.ctx, "john@mail.com", "127.0.0.1")
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *LoginGuardSuite) TestLoginGuard_Reset() {
+	rc := mocks.NewRedisClient(s.T())
+	rc.On("Del", mock.Anything, "login-attempt:account:john@mail.com", "login-block:account:john@mail.com").
+		Return(redis.NewIntCmd(s.ctx))
+
+	guard := auth.NewLoginGuard(rc, s.config)
+	err := guard.Reset(s.ctx, "john@mail.com")
+
+	s.Nil(err)
+}
+
+func TestLoginGuardSuite(t *testing.T) {
+	suite.Run(t, new(LoginGuardSuite))
+}
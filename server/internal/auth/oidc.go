@@ -0,0 +1,305 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+var (
+	// ErrOIDCInvalidGrant means the provider refused the authorization code or code verifier
+	ErrOIDCInvalidGrant = errors.New("oidc invalid grant")
+	// ErrOIDCInvalidIDToken means the id token failed signature, issuer, audience, expiry or nonce checks
+	ErrOIDCInvalidIDToken = errors.New("oidc invalid id token")
+)
+
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Timeout      time.Duration
+}
+
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+//go:generate mockery --name=OIDCProvider --structname OIDCProvider --outpkg=mocks --output=./../mocks
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OIDCIdentity, error)
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcIDTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+type oidcProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewOIDCProvider returns a provider that discovers its endpoints lazily,
+// so the api still starts while the identity provider is unreachable
+func NewOIDCProvider(cfg OIDCConfig) OIDCProvider {
+	return &oidcProvider{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}
+}
+
+// PKCEChallenge derives the S256 code challenge sent along the authorization request
+func PKCEChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse authorization endpoint = %w", err)
+	}
+
+	q := authURL.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", "openid email profile")
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	authURL.RawQuery = q.Encode()
+
+	return authURL.String(), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*OIDCIdentity, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request = %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed = %w", err)
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response = %w", err)
+	}
+
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("%w with status code = %d", ErrOIDCInvalidGrant, resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint error with status code = %d", resp.StatusCode)
+	}
+
+	var tokenRes struct {
+		IDToken string `json:"id_token"`
+	}
+	err = json.Unmarshal(rawBody, &tokenRes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token response = %w", err)
+	}
+
+	claims, err := p.verifyIDToken(ctx, d, tokenRes.IDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w = %s", ErrOIDCInvalidIDToken, err.Error())
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w = nonce mismatch", ErrOIDCInvalidIDToken)
+	}
+
+	return &OIDCIdentity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, d *oidcDiscovery, idToken string) (*oidcIDTokenClaims, error) {
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.getKey(ctx, d, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("missing subject")
+	}
+
+	return claims, nil
+}
+
+func (p *oidcProvider) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.IssuerURL, "/")
+
+	var d oidcDiscovery
+	err := p.getJSON(ctx, issuer+oidcDiscoveryPath, &d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider = %w", err)
+	}
+
+	if d.Issuer != issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch, want %s got %s", issuer, d.Issuer)
+	}
+
+	p.discovery = &d
+
+	return p.discovery, nil
+}
+
+// getKey looks up the signing key by kid and refetches the key set once
+// when the kid is unknown, which happens after the provider rotates keys
+func (p *oidcProvider) getKey(ctx context.Context, d *oidcDiscovery, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	err := p.getJSON(ctx, d.JWKSURI, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks = %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode jwk modulus (%s) = %w", k.Kid, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode jwk exponent (%s) = %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	p.keys = keys
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key (%s)", kid)
+	}
+
+	return key, nil
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request = %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed = %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code = %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,96 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"expense-management-system/internal/auth"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPKCEChallenge(t *testing.T) {
+	got := auth.PKCEChallenge("verifier-0123456789-abcdefghijklmnopqrstuvwxyz")
+
+	assert.Equal(t, "P2z9-HISyfGmQCy3zRro9mnHlNQpSqpAR_U0x5EiBdU", got)
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		issuer     string
+		status     int
+		wantErrMsg string
+	}{
+		{
+			name:       "discovery error",
+			status:     http.StatusInternalServerError,
+			wantErrMsg: "failed to discover oidc provider = unexpected status code = 500",
+		},
+		{
+			name:       "issuer mismatch",
+			issuer:     "https://evil.example.com",
+			status:     http.StatusOK,
+			wantErrMsg: "oidc issuer mismatch",
+		},
+		{
+			name:   "success",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				issuer := tt.issuer
+				if issuer == "" {
+					issuer = ts.URL
+				}
+
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(map[string]string{
+					"issuer":                 issuer,
+					"authorization_endpoint": "https://idp.example.com/authorize",
+					"token_endpoint":         ts.URL + "/token",
+					"jwks_uri":               ts.URL + "/jwks",
+				})
+			}))
+			defer ts.Close()
+
+			p := auth.NewOIDCProvider(auth.OIDCConfig{
+				IssuerURL:   ts.URL,
+				ClientID:    "expense-management",
+				RedirectURL: "http://localhost:5173/sso/callback",
+				Timeout:     time.Second,
+			})
+
+			got, err := p.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+			if tt.wantErrMsg != "" {
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			u, err := url.Parse(got)
+			require.NoError(t, err)
+			assert.Equal(t, "idp.example.com", u.Host)
+			assert.Equal(t, url.Values{
+				"response_type":         {"code"},
+				"client_id":             {"expense-management"},
+				"redirect_uri":          {"http://localhost:5173/sso/callback"},
+				"scope":                 {"openid email profile"},
+				"state":                 {"state"},
+				"nonce":                 {"nonce"},
+				"code_challenge":        {"challenge"},
+				"code_challenge_method": {"S256"},
+			}, u.Query())
+		})
+	}
+}
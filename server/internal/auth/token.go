@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const opaqueTokenBytes = 32
+
+// GenerateOpaqueToken returns a random url-safe token meant to be handed out once,
+// only its HashOpaqueToken value should be persisted
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,26 @@
+package auth_test
+
+import (
+	"expense-management-system/internal/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	token1, err := auth.GenerateOpaqueToken()
+	assert.NoError(t, err)
+	assert.Len(t, token1, 43)
+
+	token2, err := auth.GenerateOpaqueToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token1, token2)
+}
+
+func TestHashOpaqueToken(t *testing.T) {
+	assert.Equal(t,
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		auth.HashOpaqueToken("hello"),
+	)
+	assert.NotEqual(t, auth.HashOpaqueToken("hello"), auth.HashOpaqueToken("hello2"))
+}
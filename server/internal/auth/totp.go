@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	AMRTOTP = "otp"
+
+	totpPeriod  = 30
+	totpDigits  = 6
+	totpSkew    = 1 // accepted time steps before and after the current one
+	secretBytes = 20
+
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 5
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+//go:generate mockery --name=TOTP --structname TOTP --outpkg=mocks --output=./../mocks
+type TOTP interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(secret string, account string) string
+	Validate(secret string, code string) (int64, bool)
+}
+
+type totp struct {
+	issuer string
+}
+
+func NewTOTP(issuer string) TOTP {
+	return &totp{
+		issuer: issuer,
+	}
+}
+
+func (t *totp) GenerateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI returns the otpauth uri that authenticator apps read from a qr code
+func (t *totp) ProvisioningURI(secret string, account string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", t.issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(fmt.Sprintf("%s:%s", t.issuer, account))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// Validate returns the time step the code belongs to, callers store it to refuse
+// the same or an older step again while it is still inside the accepted window
+func (t *totp) Validate(secret string, code string) (int64, bool) {
+	now := time.Now()
+	for i := -totpSkew; i <= totpSkew; i++ {
+		at := now.Add(time.Duration(i*totpPeriod) * time.Second)
+		expected, err := GenerateTOTPCode(secret, at)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateTOTPCode computes the rfc 6238 code of a base32 secret at the given time
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret = %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/totpPeriod))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000), nil
+}
+
+// GenerateRecoveryCodes returns plain codes to show once to the user,
+// only their hashes are stored
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, recoveryCodeBytes)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		h := hex.EncodeToString(b)
+		codes[i] = fmt.Sprintf("%s-%s", h[:5], h[5:])
+	}
+
+	return codes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,100 @@
+package auth_test
+
+import (
+	"expense-management-system/internal/auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret of the rfc 6238 test vectors ("12345678901234567890") in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP_GenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		time       time.Time
+		wantRes    string
+		wantErrMsg string
+	}{
+		{
+			name:    "rfc vector 59",
+			secret:  rfcSecret,
+			time:    time.Unix(59, 0),
+			wantRes: "287082",
+		},
+		{
+			name:    "rfc vector 1111111109",
+			secret:  rfcSecret,
+			time:    time.Unix(1111111109, 0),
+			wantRes: "081804",
+		},
+		{
+			name:       "invalid secret",
+			secret:     "not-base32!",
+			time:       time.Unix(59, 0),
+			wantRes:    "",
+			wantErrMsg: "invalid totp secret = illegal base32 data at input byte 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := auth.GenerateTOTPCode(tt.secret, tt.time)
+
+			assert.Equal(t, tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				assert.Equal(t, tt.wantErrMsg, err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	totp := auth.NewTOTP("expense-management")
+	secret, err := totp.GenerateSecret()
+	assert.Nil(t, err)
+
+	current, _ := auth.GenerateTOTPCode(secret, time.Now())
+	previous, _ := auth.GenerateTOTPCode(secret, time.Now().Add(-30*time.Second))
+	expired, _ := auth.GenerateTOTPCode(secret, time.Now().Add(-5*time.Minute))
+
+	step, ok := totp.Validate(secret, current)
+	assert.True(t, ok)
+	assert.Equal(t, time.Now().Unix()/30, step)
+
+	previousStep, ok := totp.Validate(secret, previous)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, previousStep)
+
+	_, ok = totp.Validate(secret, expired)
+	assert.False(t, ok)
+
+	_, ok = totp.Validate(secret, "abcdef")
+	assert.False(t, ok)
+}
+
+func TestTOTP_ProvisioningURI(t *testing.T) {
+	totp := auth.NewTOTP("expense-management")
+
+	res := totp.ProvisioningURI(rfcSecret, "john@mail.com")
+
+	assert.Equal(t, "otpauth://totp/expense-management:john@mail.com?"+
+		"algorithm=SHA1&digits=6&issuer=expense-management&period=30&secret="+rfcSecret, res)
+}
+
+func TestTOTP_RecoveryCodes(t *testing.T) {
+	codes, err := auth.GenerateRecoveryCodes()
+
+	assert.Nil(t, err)
+	assert.Len(t, codes, 10)
+	for _, c := range codes {
+		assert.Len(t, c, 11)
+		assert.Equal(t, auth.HashRecoveryCode(c), auth.HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(c, "-", ""))))
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"expense-management-system/internal/delivery/http"
 	"expense-management-system/internal/delivery/http/middleware"
 	"expense-management-system/internal/delivery/http/route"
+	"expense-management-system/internal/mail"
 	"expense-management-system/internal/messaging"
 	"expense-management-system/internal/metrics"
 	"expense-management-system/internal/repository"
+	"expense-management-system/internal/storage"
 	"expense-management-system/internal/usecase"
 	"strings"
 	"time"
@@ -39,54 +41,264 @@ func NewApi(cfg *ApiConfig) {
 	metrics.Init()
 
 	allowedOrigins := strings.Split(cfg.Config.CorsAllowOrigins, ",")
-	rateLimiter := redisrate.NewLimiter(cfg.RedisClient)
 
+	limiterMiddleware := middleware.NewLimiterMiddleware(cfg.Log, redisrate.NewLimiter(cfg.RedisClient),
+		middleware.RateLimitConfig{
+			Policies: map[string]redisrate.Limit{
+				middleware.RateLimitPolicyGlobal:   cfg.Config.RateLimitGlobal,
+				middleware.RateLimitPolicyAPI:      cfg.Config.RateLimitAPI,
+				middleware.RateLimitPolicyPublic:   cfg.Config.RateLimitPublic,
+				middleware.RateLimitPolicyLogin:    cfg.Config.RateLimitLogin,
+				middleware.RateLimitPolicyRegister: cfg.Config.RateLimitRegister,
+			},
+			FailMode: cfg.Config.RateLimitFailMode,
+		})
 	commonMiddlewares := []gin.HandlerFunc{
 		requestid.New(),
 		middleware.NewRequestLoggerMiddleware(cfg.Log),
 		middleware.NewRecoverMiddleware(cfg.Log),
 		middleware.NewErrorMiddleware(cfg.Log),
 		middleware.NewCorsMiddleware(allowedOrigins),
-		middleware.NewLimiterMiddleware(rateLimiter),
+		limiterMiddleware(middleware.RateLimitPolicyGlobal),
 	}
 
-	jwtToken := auth.NewJWTToken(cfg.Config.JWTSecretKey, time.Hour*24*time.Duration(cfg.Config.JWTExpirationDay))
-	authMiddleware := middleware.NewAuthMiddleware(cfg.Log, cfg.RedisClient, jwtToken)
+	jwtExpiration := time.Hour * 24 * time.Duration(cfg.Config.JWTExpirationDay)
+	jwtToken := auth.NewJWTToken(cfg.Config.JWTSecretKey, jwtExpiration)
+	twoFactorMiddleware := middleware.NewTwoFactorMiddleware(cfg.Log)
+	sessionMiddleware := middleware.NewSessionMiddleware(cfg.Log)
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(cfg.Log, cfg.RedisClient,
+		time.Second*time.Duration(cfg.Config.IdempotencyTTL))
+	scopeMiddleware := middleware.NewScopeMiddleware(cfg.Log)
+	totp := auth.NewTOTP(cfg.Config.TOTPIssuer)
+	loginGuard := auth.NewLoginGuard(cfg.RedisClient, auth.LoginGuardConfig{
+		MaxAccountAttempts: cfg.Config.LoginMaxAccountAttempts,
+		MaxIPAttempts:      cfg.Config.LoginMaxIPAttempts,
+		AttemptWindow:      time.Second * time.Duration(cfg.Config.LoginAttemptWindow),
+		LockDuration:       time.Second * time.Duration(cfg.Config.LoginLockDuration),
+		BaseDelay:          time.Second * time.Duration(cfg.Config.LoginBaseDelay),
+		MaxDelay:           time.Second * time.Duration(cfg.Config.LoginMaxDelay),
+	})
+
+	oidcProvider := auth.NewOIDCProvider(auth.OIDCConfig{
+		IssuerURL:    cfg.Config.OIDCIssuerURL,
+		ClientID:     cfg.Config.OIDCClientID,
+		ClientSecret: cfg.Config.OIDCClientSecret,
+		RedirectURL:  cfg.Config.OIDCRedirectURL,
+		Timeout:      time.Second * time.Duration(cfg.Config.OIDCTimeout),
+	})
+
+	var mailSender mail.Sender
+	if cfg.Config.MailDriver == mail.DriverSMTP {
+		mailSender = mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     cfg.Config.SMTPHost,
+			Port:     cfg.Config.SMTPPort,
+			Username: cfg.Config.SMTPUsername,
+			Password: cfg.Config.SMTPPassword,
+			From:     cfg.Config.MailFrom,
+		})
+	} else {
+		mailSender = mail.NewLogSender(cfg.Log)
+	}
 
 	expenseApprovedProducer := messaging.NewExpenseApprovedProducer(
 		cfg.Log,
 		cfg.Producer,
 		cfg.Config.KafkaTopicExpenseApproved,
 	)
+	expenseExportRequestedProducer := messaging.NewExpenseExportRequestedProducer(
+		cfg.Log,
+		cfg.Producer,
+		cfg.Config.KafkaTopicExpenseExportRequested,
+	)
+	expenseCommentedProducer := messaging.NewExpenseCommentedProducer(
+		cfg.Log,
+		cfg.Producer,
+		cfg.Config.KafkaTopicExpenseCommented,
+	)
 
 	userRepository := repository.NewUserRepository(cfg.DB)
+	userTOTPRepository := repository.NewUserTOTPRepository(cfg.DB)
+	userIdentityRepository := repository.NewUserIdentityRepository(cfg.DB)
 	expenseRepository := repository.NewExpenseRepository(cfg.DB)
+	expenseExportRepository := repository.NewExpenseExportRepository(cfg.DB)
 	approvalRepository := repository.NewApprovalRepository(cfg.DB)
+	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(cfg.DB)
+	departmentRepository := repository.NewDepartmentRepository(cfg.DB)
+	departmentBudgetRepository := repository.NewDepartmentBudgetRepository(cfg.DB)
+	organizationRepository := repository.NewOrganizationRepository(cfg.DB)
+	glAccountMappingRepository := repository.NewGLAccountMappingRepository(cfg.DB)
+	journalBatchRepository := repository.NewJournalBatchRepository(cfg.DB)
+	ledgerRepository := repository.NewLedgerRepository(cfg.DB)
+	analyticsRepository := repository.NewAnalyticsRepository(cfg.DB)
+	paymentVoucherRepository := repository.NewPaymentVoucherRepository(cfg.DB)
+	expenseCommentRepository := repository.NewExpenseCommentRepository(cfg.DB)
+	expenseReviewRoundRepository := repository.NewExpenseReviewRoundRepository(cfg.DB)
+	fileStorage := storage.NewDatabaseFileStorage(cfg.DB)
 
-	authUsecase := usecase.NewAuthUsecase(cfg.Log, cfg.RedisClient, jwtToken, userRepository)
-	userUsecase := usecase.NewUserUsecase(cfg.Log, userRepository)
-	expenseUsecase := usecase.NewExpenseUsecase(cfg.Log, expenseRepository, expenseApprovedProducer)
+	authUsecase := usecase.NewAuthUsecase(
+		cfg.Log,
+		cfg.RedisClient,
+		jwtToken,
+		totp,
+		loginGuard,
+		userRepository,
+		userTOTPRepository,
+	)
+	ssoUsecase := usecase.NewSSOUsecase(
+		cfg.Log,
+		cfg.RedisClient,
+		cfg.TX,
+		jwtToken,
+		oidcProvider,
+		userRepository,
+		userIdentityRepository,
+		userTOTPRepository,
+		usecase.SSOConfig{
+			OrgDomains: cfg.Config.SSOOrgDomains,
+		},
+	)
+	userUsecase := usecase.NewUserUsecase(
+		cfg.Log,
+		cfg.RedisClient,
+		userRepository,
+		personalAccessTokenRepository,
+		departmentRepository,
+		jwtExpiration,
+	)
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(cfg.Log, totp, userRepository, userTOTPRepository)
+	passwordUsecase := usecase.NewPasswordUsecase(
+		cfg.Log,
+		cfg.RedisClient,
+		mailSender,
+		loginGuard,
+		userRepository,
+		personalAccessTokenRepository,
+		usecase.PasswordConfig{
+			ResetURL:       cfg.Config.PasswordResetURL,
+			ResetTokenTTL:  time.Second * time.Duration(cfg.Config.PasswordResetTTL),
+			ForgotThrottle: time.Second * time.Duration(cfg.Config.PasswordForgotThrottle),
+			SessionTTL:     jwtExpiration,
+		},
+	)
+	personalAccessTokenUsecase := usecase.NewPersonalAccessTokenUsecase(
+		cfg.Log,
+		userRepository,
+		personalAccessTokenRepository,
+	)
+	scimUsecase := usecase.NewSCIMUsecase(
+		cfg.Log,
+		cfg.RedisClient,
+		userRepository,
+		personalAccessTokenRepository,
+		departmentRepository,
+		jwtExpiration,
+	)
+	expenseUsecase := usecase.NewExpenseUsecase(
+		cfg.Log,
+		cfg.TX,
+		expenseRepository,
+		organizationRepository,
+		departmentBudgetRepository,
+		ledgerRepository,
+		expenseCommentRepository,
+		expenseReviewRoundRepository,
+		expenseApprovedProducer,
+	)
 	approvalUsecase := usecase.NewApprovalUsecase(
 		cfg.Log,
 		cfg.TX,
 		approvalRepository,
 		expenseRepository,
+		departmentBudgetRepository,
+		ledgerRepository,
+		expenseReviewRoundRepository,
 		expenseApprovedProducer,
+		cfg.Config.BudgetExceededPolicy,
+	)
+	expenseExportUsecase := usecase.NewExpenseExportUsecase(
+		cfg.Log,
+		expenseRepository,
+		expenseExportRepository,
+		fileStorage,
+		expenseExportRequestedProducer,
+		cfg.Config.ExportSyncMaxRows,
+	)
+	departmentUsecase := usecase.NewDepartmentUsecase(cfg.Log, departmentRepository, departmentBudgetRepository)
+	organizationUsecase := usecase.NewOrganizationUsecase(cfg.Log, organizationRepository)
+	journalUsecase := usecase.NewJournalUsecase(
+		cfg.Log,
+		cfg.TX,
+		expenseRepository,
+		glAccountMappingRepository,
+		journalBatchRepository,
+	)
+	ledgerUsecase := usecase.NewLedgerUsecase(cfg.Log, cfg.TX, expenseRepository, ledgerRepository)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(cfg.Log, analyticsRepository)
+	statementUsecase := usecase.NewStatementUsecase(cfg.Log, userRepository, organizationRepository, expenseRepository)
+	paymentVoucherUsecase := usecase.NewPaymentVoucherUsecase(cfg.Log, expenseRepository, paymentVoucherRepository, fileStorage)
+	expenseCommentUsecase := usecase.NewExpenseCommentUsecase(
+		cfg.Log,
+		cfg.TX,
+		expenseRepository,
+		userRepository,
+		expenseCommentRepository,
+		expenseCommentedProducer,
+		time.Duration(cfg.Config.CommentEditWindow)*time.Second,
 	)
 
+	authMiddleware := middleware.NewAuthMiddleware(cfg.Log, cfg.RedisClient, jwtToken, personalAccessTokenUsecase)
+
 	authController := http.NewAuthController(cfg.Log, cfg.Validate, authUsecase)
+	ssoController := http.NewSSOController(cfg.Log, cfg.Validate, ssoUsecase)
 	userController := http.NewUserController(cfg.Log, cfg.Validate, userUsecase)
+	twoFactorController := http.NewTwoFactorController(cfg.Log, cfg.Validate, twoFactorUsecase)
+	passwordController := http.NewPasswordController(cfg.Log, cfg.Validate, passwordUsecase)
+	personalAccessTokenController := http.NewPersonalAccessTokenController(
+		cfg.Log,
+		cfg.Validate,
+		personalAccessTokenUsecase,
+	)
 	expenseController := http.NewExpenseController(cfg.Log, cfg.Validate, expenseUsecase)
+	expenseExportController := http.NewExpenseExportController(cfg.Log, expenseExportUsecase)
 	approvalController := http.NewApprovalController(cfg.Log, cfg.Validate, approvalUsecase)
+	scimController := http.NewSCIMController(cfg.Log, cfg.Validate, scimUsecase)
+	departmentController := http.NewDepartmentController(cfg.Log, cfg.Validate, departmentUsecase)
+	organizationController := http.NewOrganizationController(cfg.Log, cfg.Validate, organizationUsecase)
+	journalController := http.NewJournalController(cfg.Log, cfg.Validate, journalUsecase)
+	ledgerController := http.NewLedgerController(cfg.Log, cfg.Validate, ledgerUsecase)
+	analyticsController := http.NewAnalyticsController(cfg.Log, analyticsUsecase)
+	statementController := http.NewStatementController(cfg.Log, statementUsecase)
+	paymentVoucherController := http.NewPaymentVoucherController(cfg.Log, paymentVoucherUsecase)
+	expenseCommentController := http.NewExpenseCommentController(cfg.Log, cfg.Validate, expenseCommentUsecase)
 
 	routeCfg := route.RouteConfig{
-		App:                cfg.App,
-		CommonMiddlewares:  commonMiddlewares,
-		AuthMiddlware:      authMiddleware,
-		AuthController:     authController,
-		UserController:     userController,
-		ExpenseController:  expenseController,
-		ApprovalController: approvalController,
+		App:                           cfg.App,
+		CommonMiddlewares:             commonMiddlewares,
+		AuthMiddlware:                 authMiddleware,
+		TwoFactorMiddleware:           twoFactorMiddleware,
+		SessionMiddleware:             sessionMiddleware,
+		IdempotencyMiddleware:         idempotencyMiddleware,
+		LimiterMiddleware:             limiterMiddleware,
+		ScopeMiddleware:               scopeMiddleware,
+		SCIMErrorMiddleware:           middleware.NewSCIMErrorMiddleware(cfg.Log),
+		AuthController:                authController,
+		UserController:                userController,
+		TwoFactorController:           twoFactorController,
+		PasswordController:            passwordController,
+		SSOController:                 ssoController,
+		PersonalAccessTokenController: personalAccessTokenController,
+		ExpenseController:             expenseController,
+		ExpenseExportController:       expenseExportController,
+		PaymentVoucherController:      paymentVoucherController,
+		ExpenseCommentController:      expenseCommentController,
+		ApprovalController:            approvalController,
+		SCIMController:                scimController,
+		DepartmentController:          departmentController,
+		OrganizationController:        organizationController,
+		JournalController:             journalController,
+		LedgerController:              ledgerController,
+		AnalyticsController:           analyticsController,
+		StatementController:           statementController,
 	}
 	routeCfg.Setup()
 }
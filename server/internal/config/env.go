@@ -1,11 +1,17 @@
 package config
 
 import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mail"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
+
+	redisrate "github.com/go-redis/redis_rate/v10"
 )
 
 type Env struct {
@@ -16,6 +22,7 @@ type Env struct {
 	AppIdleTimeout  int
 
 	CorsAllowOrigins string
+	TrustedProxies   []string // nil trusts no proxy, the client ip is the remote address
 
 	DBHost            string
 	DBPort            string
@@ -35,17 +42,62 @@ type Env struct {
 	JWTSecretKey     string
 	JWTExpirationDay int
 
-	KafkaBrokerHost           string
-	KafkaConsumerGroup        string
-	KafkaAutoOffsetReset      string
-	KafkaTopicExpenseApproved string
-	KafkaMaxRetries           int
-	KafkaBackoffDuration      int
-	KafkaMaxExecuteDuration   int
+	LoginMaxAccountAttempts int
+	LoginMaxIPAttempts      int
+	LoginAttemptWindow      int
+	LoginLockDuration       int
+	LoginBaseDelay          int
+	LoginMaxDelay           int
+
+	TOTPIssuer string
+
+	MailDriver   string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	PasswordResetURL       string
+	PasswordResetTTL       int
+	PasswordForgotThrottle int
+
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCTimeout      int
+	SSOOrgDomains    map[string]uint64
+
+	KafkaBrokerHost                  string
+	KafkaConsumerGroup               string
+	KafkaAutoOffsetReset             string
+	KafkaTopicExpenseApproved        string
+	KafkaTopicExpenseExportRequested string
+	KafkaTopicExpenseCommented       string
+	KafkaMaxRetries                  int
+	KafkaBackoffDuration             int
+	KafkaMaxExecuteDuration          int
 
 	PaymentPartnerHost    string
 	PaymentPartnerTimeout int
 	PaymentLockDuration   int
+
+	BudgetExceededPolicy entity.BudgetPolicy
+
+	IdempotencyTTL int
+
+	ExportSyncMaxRows        int
+	ExportMaxExecuteDuration int
+
+	CommentEditWindow int
+
+	RateLimitGlobal   redisrate.Limit
+	RateLimitAPI      redisrate.Limit
+	RateLimitPublic   redisrate.Limit
+	RateLimitLogin    redisrate.Limit
+	RateLimitRegister redisrate.Limit
+	RateLimitFailMode middleware.RateLimitFailMode
 }
 
 func NewEnv() (*Env, error) {
@@ -83,10 +135,37 @@ func NewEnv() (*Env, error) {
 		JWTSecretKey:     getEnvString("JWT_SECRET_KEY", ""),
 		JWTExpirationDay: getEnvInt("JWT_EXPIRATION_DAY", 1),
 
-		KafkaBrokerHost:           getEnvString("KAFKA_BROKER_HOST", "127.0.0.1:9092"),
-		KafkaConsumerGroup:        getEnvString("KAFKA_CONSUMER_GROUP", "expense-management"),
-		KafkaAutoOffsetReset:      getEnvString("KAFKA_AUTO_OFFSET_RESET", "latest"),
-		KafkaTopicExpenseApproved: getEnvString("KAFKA_TOPIC_EXPENSE_APPROVED", "expense-approved"),
+		LoginMaxAccountAttempts: getEnvInt("LOGIN_MAX_ACCOUNT_ATTEMPTS", 10),
+		LoginMaxIPAttempts:      getEnvInt("LOGIN_MAX_IP_ATTEMPTS", 50),
+		LoginAttemptWindow:      getEnvInt("LOGIN_ATTEMPT_WINDOW", 900),
+		LoginLockDuration:       getEnvInt("LOGIN_LOCK_DURATION", 900),
+		LoginBaseDelay:          getEnvInt("LOGIN_BASE_DELAY", 1),
+		LoginMaxDelay:           getEnvInt("LOGIN_MAX_DELAY", 60),
+
+		TOTPIssuer: getEnvString("TOTP_ISSUER", "Expense Management"),
+
+		MailFrom:     getEnvString("MAIL_FROM", "no-reply@expense-management.local"),
+		SMTPHost:     getEnvString("SMTP_HOST", "127.0.0.1"),
+		SMTPPort:     getEnvInt("SMTP_PORT", 1025),
+		SMTPUsername: getEnvString("SMTP_USERNAME", ""),
+		SMTPPassword: getEnvString("SMTP_PASSWORD", ""),
+
+		PasswordResetURL:       getEnvString("PASSWORD_RESET_URL", "http://localhost:5173/reset-password"),
+		PasswordResetTTL:       getEnvInt("PASSWORD_RESET_TTL", 1800),
+		PasswordForgotThrottle: getEnvInt("PASSWORD_FORGOT_THROTTLE", 60),
+
+		OIDCIssuerURL:    getEnvString("OIDC_ISSUER_URL", "http://localhost:9600"),
+		OIDCClientID:     getEnvString("OIDC_CLIENT_ID", "expense-management"),
+		OIDCClientSecret: getEnvString("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:  getEnvString("OIDC_REDIRECT_URL", "http://localhost:5173/sso/callback"),
+		OIDCTimeout:      getEnvInt("OIDC_TIMEOUT", 5),
+
+		KafkaBrokerHost:                  getEnvString("KAFKA_BROKER_HOST", "127.0.0.1:9092"),
+		KafkaConsumerGroup:               getEnvString("KAFKA_CONSUMER_GROUP", "expense-management"),
+		KafkaAutoOffsetReset:             getEnvString("KAFKA_AUTO_OFFSET_RESET", "latest"),
+		KafkaTopicExpenseApproved:        getEnvString("KAFKA_TOPIC_EXPENSE_APPROVED", "expense-approved"),
+		KafkaTopicExpenseExportRequested: getEnvString("KAFKA_TOPIC_EXPENSE_EXPORT_REQUESTED", "expense-export-requested"),
+		KafkaTopicExpenseCommented:       getEnvString("KAFKA_TOPIC_EXPENSE_COMMENTED", "expense-commented"),
 
 		KafkaMaxRetries:         getEnvInt("KAFKA_MAX_RETRIES", 3),
 		KafkaBackoffDuration:    getEnvInt("KAFKA_BACKOFF_DURATION", 1),
@@ -95,11 +174,101 @@ func NewEnv() (*Env, error) {
 		PaymentPartnerHost:    getEnvString("PAYMENT_PARTNER_HOST", "http://127.0.0.1:9500"),
 		PaymentPartnerTimeout: getEnvInt("PAYMENT_PARTNER_TIMEOUT", 3),
 		PaymentLockDuration:   getEnvInt("PAYMENT_LOCK_DURATION", 30),
+
+		IdempotencyTTL: getEnvInt("IDEMPOTENCY_TTL", 86400),
+
+		ExportSyncMaxRows:        getEnvInt("EXPORT_SYNC_MAX_ROWS", 5000),
+		ExportMaxExecuteDuration: getEnvInt("EXPORT_MAX_EXECUTE_DURATION", 120),
+
+		CommentEditWindow: getEnvInt("COMMENT_EDIT_WINDOW", 900),
 	}
 
+	cfg.TrustedProxies = parseList(getEnvString("TRUSTED_PROXIES", ""))
+
+	orgDomains, err := parseOrgDomains(getEnvString("SSO_ORG_DOMAINS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sso org domains = %w", err)
+	}
+	cfg.SSOOrgDomains = orgDomains
+
+	mailDriver, err := mail.ParseDriver(getEnvString("MAIL_DRIVER", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mail driver = %w", err)
+	}
+	cfg.MailDriver = mailDriver
+
+	budgetPolicy, err := entity.ParseBudgetPolicy(getEnvString("BUDGET_EXCEEDED_POLICY", "block"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse budget exceeded policy = %w", err)
+	}
+	cfg.BudgetExceededPolicy = budgetPolicy
+
+	rateLimits := []struct {
+		key        string
+		defaultVal string
+		limit      *redisrate.Limit
+	}{
+		{"RATE_LIMIT_GLOBAL", "50/1s", &cfg.RateLimitGlobal},
+		{"RATE_LIMIT_API", "10/1s", &cfg.RateLimitAPI},
+		{"RATE_LIMIT_PUBLIC", "10/1s", &cfg.RateLimitPublic},
+		{"RATE_LIMIT_LOGIN", "5/1m", &cfg.RateLimitLogin},
+		{"RATE_LIMIT_REGISTER", "5/1h", &cfg.RateLimitRegister},
+	}
+	for _, rl := range rateLimits {
+		limit, err := middleware.ParseRateLimit(getEnvString(rl.key, rl.defaultVal))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s = %w", strings.ToLower(rl.key), err)
+		}
+		*rl.limit = limit
+	}
+
+	failMode, err := middleware.ParseRateLimitFailMode(getEnvString("RATE_LIMIT_FAIL_MODE", "open"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit fail mode = %w", err)
+	}
+	cfg.RateLimitFailMode = failMode
+
 	return cfg, nil
 }
 
+// parseList reads comma separated values, it returns nil when there is none
+func parseList(str string) []string {
+	var values []string
+	for _, value := range strings.Split(str, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// parseOrgDomains reads comma separated domain=organization id pairs, e.g. "mail.com=1,example.com=2"
+func parseOrgDomains(str string) (map[string]uint64, error) {
+	orgDomains := make(map[string]uint64)
+	for _, pair := range strings.Split(str, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		domain, orgID, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid org domain = %s", pair)
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSpace(orgID), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid organization id of domain (%s) = %w", domain, err)
+		}
+
+		orgDomains[strings.ToLower(strings.TrimSpace(domain))] = id
+	}
+
+	return orgDomains, nil
+}
+
 func getEnvString(key string, defaultVal string) string {
 	if val, ok := os.LookupEnv(key); ok {
 		return val
@@ -1,14 +1,23 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func NewGin(logger *zap.Logger) *gin.Engine {
+func NewGin(env *Env, logger *zap.Logger) (*gin.Engine, error) {
 	gin.SetMode(gin.ReleaseMode)
 
 	engine := gin.New()
 
-	return engine
+	// X-Forwarded-For is only read from these proxies, otherwise any client could pick the ip
+	// that the login throttle and the rate limiter count on
+	err := engine.SetTrustedProxies(env.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies = %w", err)
+	}
+
+	return engine, nil
 }
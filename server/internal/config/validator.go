@@ -1,9 +1,23 @@
 package config
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
 func NewValidator() *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+
+	// report the json names so the client can match an error to its input
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+
+	return validate
 }
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AnalyticsController struct {
+	log              *zap.Logger
+	analyticsUsecase usecase.AnalyticsUsecase
+}
+
+func NewAnalyticsController(log *zap.Logger, analyticsUsecase usecase.AnalyticsUsecase) *AnalyticsController {
+	return &AnalyticsController{
+		log:              log,
+		analyticsUsecase: analyticsUsecase,
+	}
+}
+
+func (c *AnalyticsController) Summary(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	request := &model.AnalyticsRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	}
+
+	invalidFilters := parseAnalyticsFilters(ctx, request)
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	res, err := c.analyticsUsecase.Summary(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get analytics summary", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *AnalyticsController) Breakdown(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	request := &model.AnalyticsRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	}
+
+	invalidFilters := parseAnalyticsFilters(ctx, request)
+
+	groupBy, ok := model.ParseAnalyticsGroupBy(ctx.DefaultQuery("group_by", string(model.AnalyticsGroupByStatus)))
+	if !ok {
+		invalidFilters = append(invalidFilters, "group_by")
+	}
+	request.GroupBy = groupBy
+
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	res, err := c.analyticsUsecase.Breakdown(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get analytics breakdown", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+// parseAnalyticsFilters fills the range and the department of the request,
+// returning the names of the filters that could not be parsed
+func parseAnalyticsFilters(ctx *gin.Context, request *model.AnalyticsRequest) []string {
+	var invalid []string
+
+	times := []struct {
+		name   string
+		target **time.Time
+		to     bool
+	}{
+		{"created_from", &request.CreatedFrom, false},
+		{"created_to", &request.CreatedTo, true},
+	}
+	for _, t := range times {
+		value := ctx.Query(t.name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := parseTimeFilter(value, t.to)
+		if err != nil {
+			invalid = append(invalid, t.name)
+			continue
+		}
+		*t.target = &parsed
+	}
+
+	if value := ctx.Query("department_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 {
+			invalid = append(invalid, "department_id")
+		} else {
+			request.DepartmentID = &id
+		}
+	}
+
+	return invalid
+}
@@ -0,0 +1,171 @@
+package http_test
+
+import (
+	"errors"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AnalyticsControllerSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *AnalyticsControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *AnalyticsControllerSuite) TestAnalyticsController_Summary() {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 9, 30, 23, 59, 59, 999999000, time.UTC)
+	departmentID := uint64(2)
+	toApprove := int64(3600)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.AnalyticsUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid filters",
+			query:      "?created_from=yesterday&department_id=abc",
+			mockFunc:   func(a *mocks.AnalyticsUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'created_from' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'department_id' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error on summary",
+			query: "",
+			mockFunc: func(a *mocks.AnalyticsUsecase) {
+				a.On("Summary", mock.Anything, &model.AnalyticsRequest{OrgID: 1, UserRole: "manager"}).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?created_from=2025-09-01&created_to=2025-09-30&department_id=2",
+			mockFunc: func(a *mocks.AnalyticsUsecase) {
+				a.On("Summary", mock.Anything, mock.MatchedBy(func(req *model.AnalyticsRequest) bool {
+					return req.OrgID == 1 && req.CreatedFrom.Equal(from) && req.CreatedTo.Equal(to) &&
+						*req.DepartmentID == departmentID
+				})).Return(&model.AnalyticsSummaryResponse{
+					TotalCount:           1,
+					TotalAmountIDR:       50000,
+					TotalAmountFormatted: "Rp 50.000",
+					ByStatus: []model.AnalyticsGroupResponse{
+						{Key: "completed", Label: "completed", Count: 1, AmountIDR: 50000, AmountFormatted: "Rp 50.000"},
+					},
+					AvgTimeToApproveSeconds: &toApprove,
+					OldestPending:           []model.AnalyticsPendingResponse{},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"total_count":1,"total_amount_idr":50000,"total_amount_formatted":"Rp 50.000",` +
+				`"by_status":[{"key":"completed","label":"completed","count":1,"amount_idr":50000,"amount_formatted":"Rp 50.000"}],` +
+				`"avg_time_to_approve_seconds":3600,"avg_time_to_pay_seconds":null,"pending_count":0,"oldest_pending":[]},` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAnalyticsUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAnalyticsController(s.log, au)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/analytics/summary", ac.Summary)
+
+			req := httptest.NewRequest("GET", "/api/analytics/summary"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *AnalyticsControllerSuite) TestAnalyticsController_Breakdown() {
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.AnalyticsUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid group",
+			query:      "?group_by=category",
+			mockFunc:   func(a *mocks.AnalyticsUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'group_by' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error forbidden",
+			query: "",
+			mockFunc: func(a *mocks.AnalyticsUsecase) {
+				a.On("Breakdown", mock.Anything, &model.AnalyticsRequest{OrgID: 1, UserRole: "manager", GroupBy: model.AnalyticsGroupByStatus}).
+					Return(nil, model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"Forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:  "success",
+			query: "?group_by=department",
+			mockFunc: func(a *mocks.AnalyticsUsecase) {
+				a.On("Breakdown", mock.Anything, &model.AnalyticsRequest{OrgID: 1, UserRole: "manager", GroupBy: model.AnalyticsGroupByDepartment}).
+					Return([]model.AnalyticsGroupResponse{
+						{Key: "2", Label: "Engineering", Count: 3, AmountIDR: 1250000, AmountFormatted: "Rp 1.250.000"},
+						{Key: "", Label: "", Count: 1, AmountIDR: 20000, AmountFormatted: "Rp 20.000"},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"key":"2","label":"Engineering","count":3,"amount_idr":1250000,"amount_formatted":"Rp 1.250.000"},` +
+				`{"key":"","label":"","count":1,"amount_idr":20000,"amount_formatted":"Rp 20.000"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAnalyticsUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAnalyticsController(s.log, au)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/analytics/breakdown", ac.Breakdown)
+
+			req := httptest.NewRequest("GET", "/api/analytics/breakdown"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestAnalyticsControllerSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsControllerSuite))
+}
@@ -6,6 +6,7 @@ import (
 	"expense-management-system/internal/usecase"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -66,9 +67,18 @@ func (c *ApprovalController) Approve(ctx *gin.Context) {
 		return
 	}
 
+	version, err := model.ParseIfMatch(ctx.GetHeader("If-Match"))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse if-match", err)
+		ctx.Error(model.ErrExpenseModified)
+		return
+	}
+
 	request.ID = id
 	request.UserID = userID
+	request.OrgID = claims.OrgID
 	request.UserRole = claims.Role
+	request.Version = version
 	err = c.approvalUsecase.Approve(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.log, "failed to approve expense", err)
@@ -121,9 +131,18 @@ func (c *ApprovalController) Reject(ctx *gin.Context) {
 		return
 	}
 
+	version, err := model.ParseIfMatch(ctx.GetHeader("If-Match"))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse if-match", err)
+		ctx.Error(model.ErrExpenseModified)
+		return
+	}
+
 	request.ID = id
 	request.UserID = userID
+	request.OrgID = claims.OrgID
 	request.UserRole = claims.Role
+	request.Version = version
 	err = c.approvalUsecase.Reject(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.log, "failed to reject expense", err)
@@ -136,3 +155,67 @@ func (c *ApprovalController) Reject(ctx *gin.Context) {
 		model.NewSuccessMessageResponse("Expense rejected", http.StatusOK),
 	)
 }
+
+func (c *ApprovalController) RequestChanges(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	// unlike approve and reject, the notes are required so the submitter knows what to change
+	request := new(model.RequestChangesExpenseRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	// trimmed first so notes of only whitespace fail the required rule
+	request.Notes = strings.TrimSpace(request.Notes)
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := model.ParseIfMatch(ctx.GetHeader("If-Match"))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse if-match", err)
+		ctx.Error(model.ErrExpenseModified)
+		return
+	}
+
+	request.ID = id
+	request.UserID = userID
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	request.Version = version
+	err = c.approvalUsecase.RequestChanges(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to request changes to expense", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Changes requested", http.StatusOK),
+	)
+}
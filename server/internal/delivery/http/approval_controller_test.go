@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"expense-management-system/internal/config"
 	internalHttp "expense-management-system/internal/delivery/http"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
@@ -27,13 +28,14 @@ type ApprovalControllerSuite struct {
 
 func (s *ApprovalControllerSuite) SetupTest() {
 	s.log = zap.NewNop()
-	s.validate = validator.New()
+	s.validate = config.NewValidator()
 }
 
 func (s *ApprovalControllerSuite) TestApprovalController_Approve() {
 	tests := []struct {
 		name       string
 		body       any
+		ifMatch    string
 		mockFunc   func(a *mocks.ApprovalUsecase)
 		wantStatus int
 		wantRes    string
@@ -78,6 +80,27 @@ func (s *ApprovalControllerSuite) TestApprovalController_Approve() {
 			wantStatus: http.StatusOK,
 			wantRes:    `{"message":"Expense approved","meta":{"http_status":200}}`,
 		},
+		{
+			name:       "error on weak if-match",
+			body:       nil,
+			ifMatch:    `W/"2"`,
+			mockFunc:   func(a *mocks.ApprovalUsecase) {},
+			wantStatus: http.StatusPreconditionFailed,
+			wantRes: `{"errors":[{"code":1040,"message":"Expense was changed by someone else, reload it and try again"}],` +
+				`"meta":{"http_status":412}}`,
+		},
+		{
+			name:    "success with if-match",
+			body:    nil,
+			ifMatch: `"2"`,
+			mockFunc: func(a *mocks.ApprovalUsecase) {
+				a.On("Approve", mock.Anything, mock.MatchedBy(func(r *model.ApprovalExpenseRequest) bool {
+					return r.Version != nil && *r.Version == 2
+				})).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Expense approved","meta":{"http_status":200}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,6 +117,9 @@ func (s *ApprovalControllerSuite) TestApprovalController_Approve() {
 			reqBody, _ := json.Marshal(tt.body)
 			req := httptest.NewRequest("PUT", "/expenses/1/approve", bytes.NewReader(reqBody))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			rec := httptest.NewRecorder()
 			app.ServeHTTP(rec, req)
@@ -108,6 +134,7 @@ func (s *ApprovalControllerSuite) TestApprovalController_Reject() {
 	tests := []struct {
 		name       string
 		body       any
+		ifMatch    string
 		mockFunc   func(a *mocks.ApprovalUsecase)
 		wantStatus int
 		wantRes    string
@@ -152,6 +179,27 @@ func (s *ApprovalControllerSuite) TestApprovalController_Reject() {
 			wantStatus: http.StatusOK,
 			wantRes:    `{"message":"Expense rejected","meta":{"http_status":200}}`,
 		},
+		{
+			name:       "error on weak if-match",
+			body:       nil,
+			ifMatch:    `W/"2"`,
+			mockFunc:   func(a *mocks.ApprovalUsecase) {},
+			wantStatus: http.StatusPreconditionFailed,
+			wantRes: `{"errors":[{"code":1040,"message":"Expense was changed by someone else, reload it and try again"}],` +
+				`"meta":{"http_status":412}}`,
+		},
+		{
+			name:    "success with if-match",
+			body:    nil,
+			ifMatch: `"2"`,
+			mockFunc: func(a *mocks.ApprovalUsecase) {
+				a.On("Reject", mock.Anything, mock.MatchedBy(func(r *model.ApprovalExpenseRequest) bool {
+					return r.Version != nil && *r.Version == 2
+				})).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Expense rejected","meta":{"http_status":200}}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,6 +216,102 @@ func (s *ApprovalControllerSuite) TestApprovalController_Reject() {
 			reqBody, _ := json.Marshal(tt.body)
 			req := httptest.NewRequest("PUT", "/expenses/1/reject", bytes.NewReader(reqBody))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ApprovalControllerSuite) TestApprovalController_RequestChanges() {
+	tests := []struct {
+		name       string
+		body       any
+		ifMatch    string
+		mockFunc   func(a *mocks.ApprovalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on missing notes",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.ApprovalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"notes is required","field":"notes","rule":"required"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on blank notes",
+			body: map[string]interface{}{
+				"notes": "  \n\t ",
+			},
+			mockFunc:   func(a *mocks.ApprovalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"notes is required","field":"notes","rule":"required"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error on request changes",
+			body: map[string]interface{}{
+				"notes": "Wrong amount",
+			},
+			mockFunc: func(a *mocks.ApprovalUsecase) {
+				a.On("RequestChanges", mock.Anything, mock.Anything).
+					Return(model.ErrExpenseAlreadyProcessed)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes:    `{"errors":[{"code":1006,"message":"Expense already processed"}],"meta":{"http_status":422}}`,
+		},
+		{
+			name: "error on weak if-match",
+			body: map[string]interface{}{
+				"notes": "Wrong amount",
+			},
+			ifMatch:    `W/"2"`,
+			mockFunc:   func(a *mocks.ApprovalUsecase) {},
+			wantStatus: http.StatusPreconditionFailed,
+			wantRes: `{"errors":[{"code":1040,"message":"Expense was changed by someone else, reload it and try again"}],` +
+				`"meta":{"http_status":412}}`,
+		},
+		{
+			name: "success with if-match",
+			body: map[string]interface{}{
+				"notes": "Wrong amount",
+			},
+			ifMatch: `"2"`,
+			mockFunc: func(a *mocks.ApprovalUsecase) {
+				a.On("RequestChanges", mock.Anything, mock.MatchedBy(func(r *model.RequestChangesExpenseRequest) bool {
+					return r.ID == 1 && r.Notes == "Wrong amount" && r.Version != nil && *r.Version == 2
+				})).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Changes requested","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewApprovalUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewApprovalController(s.log, s.validate, au)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.PUT("/expenses/:id/request-changes", ac.RequestChanges)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/expenses/1/request-changes", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			rec := httptest.NewRecorder()
 			app.ServeHTTP(rec, req)
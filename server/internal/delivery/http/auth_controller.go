@@ -5,6 +5,7 @@ import (
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/usecase"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -41,6 +42,7 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
+	request.IPAddress = ctx.ClientIP()
 	res, err := c.authUsecase.Login(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.log, "failed to login", err)
@@ -54,6 +56,34 @@ func (c *AuthController) Login(ctx *gin.Context) {
 	)
 }
 
+func (c *AuthController) VerifyTwoFactor(ctx *gin.Context) {
+	request := new(model.VerifyTwoFactorRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res, err := c.authUsecase.VerifyTwoFactor(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to verify two-factor", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
 func (c *AuthController) Logout(ctx *gin.Context) {
 	claims, err := middleware.GetJWTClaims(ctx)
 	if err != nil {
@@ -77,3 +107,35 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 		model.NewSuccessMessageResponse("Logged out", http.StatusOK),
 	)
 }
+
+func (c *AuthController) Unlock(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.authUsecase.Unlock(ctx.Request.Context(), &model.UnlockLoginRequest{
+		ID:       id,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to unlock login", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Login unlocked", http.StatusOK),
+	)
+}
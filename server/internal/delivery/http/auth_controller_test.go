@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"expense-management-system/internal/config"
 	internalHttp "expense-management-system/internal/delivery/http"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
@@ -27,7 +28,7 @@ type AuthControllerSuite struct {
 
 func (s *AuthControllerSuite) SetupTest() {
 	s.log = zap.NewNop()
-	s.validate = validator.New()
+	s.validate = config.NewValidator()
 }
 
 func (s *AuthControllerSuite) TestAuthController_Login() {
@@ -43,8 +44,8 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			body:       nil,
 			mockFunc:   func(a *mocks.AuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"Email failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Password failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"email is required","field":"email","rule":"required"},` +
+				`{"code":2000,"message":"password is required","field":"password","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "invalid body",
@@ -54,8 +55,8 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			},
 			mockFunc:   func(a *mocks.AuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"Email failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Password failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"email is required","field":"email","rule":"required"},` +
+				`{"code":2000,"message":"password is required","field":"password","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "error on validate body",
@@ -65,8 +66,8 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			},
 			mockFunc:   func(a *mocks.AuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"Email failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Password failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"email is required","field":"email","rule":"required"},` +
+				`{"code":2000,"message":"password is required","field":"password","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "custom error on login",
@@ -76,10 +77,10 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 			},
 			mockFunc: func(a *mocks.AuthUsecase) {
 				a.On("Login", mock.Anything, mock.Anything).
-					Return(nil, model.ErrUserNotFound)
+					Return(nil, model.ErrInvalidCredentials)
 			},
-			wantStatus: http.StatusNotFound,
-			wantRes:    `{"errors":[{"code":1001,"message":"User not found"}],"meta":{"http_status":404}}`,
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1002,"message":"Invalid email or password"}],"meta":{"http_status":401}}`,
 		},
 		{
 			name: "unexpected error on login",
@@ -133,6 +134,77 @@ func (s *AuthControllerSuite) TestAuthController_Login() {
 	}
 }
 
+func (s *AuthControllerSuite) TestAuthController_VerifyTwoFactor() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"challenge_token": "", "code": "123"},
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"challenge_token is required","field":"challenge_token","rule":"required"},` +
+				`{"code":2011,"message":"code must be at least 6 characters","field":"code","rule":"min"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error on verify",
+			body: map[string]interface{}{
+				"challenge_token": "challenge-123",
+				"code":            "123456",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("VerifyTwoFactor", mock.Anything, mock.Anything).
+					Return(nil, model.ErrInvalidTwoFactorCode)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1009,"message":"Invalid two-factor code"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"challenge_token": "challenge-123",
+				"code":            "123456",
+			},
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("VerifyTwoFactor", mock.Anything, &model.VerifyTwoFactorRequest{
+					ChallengeToken: "challenge-123",
+					Code:           "123456",
+				}).Return(&model.LoginResponse{
+					AccessToken: "qwerty-12345",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"access_token":"qwerty-12345"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au)
+
+			app := test.NewApi(s.log)
+			app.POST("/api/auth/2fa/verify", ac.VerifyTwoFactor)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/auth/2fa/verify", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func (s *AuthControllerSuite) TestAuthController_Logout() {
 	tests := []struct {
 		name       string
@@ -182,6 +254,66 @@ func (s *AuthControllerSuite) TestAuthController_Logout() {
 	}
 }
 
+func (s *AuthControllerSuite) TestAuthController_Unlock() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.AuthUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.AuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error on unlock",
+			id:   "2",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("Unlock", mock.Anything, &model.UnlockLoginRequest{OrgID: 1, ID: 2, UserRole: "admin"}).
+					Return(model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1001,"message":"User not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(a *mocks.AuthUsecase) {
+				a.On("Unlock", mock.Anything, &model.UnlockLoginRequest{OrgID: 1, ID: 2, UserRole: "admin"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Login unlocked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			au := mocks.NewAuthUsecase(s.T())
+			tt.mockFunc(au)
+
+			ac := internalHttp.NewAuthController(s.log, s.validate, au)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/users/:id/unlock", ac.Unlock)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.id+"/unlock", nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func TestAuthControllerSuite(t *testing.T) {
 	suite.Run(t, new(AuthControllerSuite))
 }
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type DepartmentController struct {
+	log               *zap.Logger
+	validate          *validator.Validate
+	departmentUsecase usecase.DepartmentUsecase
+}
+
+func NewDepartmentController(log *zap.Logger, validate *validator.Validate,
+	departmentUsecase usecase.DepartmentUsecase) *DepartmentController {
+	return &DepartmentController{
+		log:               log,
+		validate:          validate,
+		departmentUsecase: departmentUsecase,
+	}
+}
+
+func (c *DepartmentController) List(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.departmentUsecase.List(ctx.Request.Context(), &model.ListDepartmentRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list departments", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *DepartmentController) Create(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	request := new(model.CreateDepartmentRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.departmentUsecase.Create(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create department", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *DepartmentController) Update(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.UpdateDepartmentRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.ID = id
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.departmentUsecase.Update(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update department", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *DepartmentController) ListBudgets(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.departmentUsecase.ListBudgets(ctx.Request.Context(), &model.ListDepartmentBudgetRequest{
+		DepartmentID: id,
+		OrgID:        claims.OrgID,
+		UserRole:     claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list department budgets", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *DepartmentController) CreateBudget(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.CreateDepartmentBudgetRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.DepartmentID = id
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.departmentUsecase.CreateBudget(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create department budget", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
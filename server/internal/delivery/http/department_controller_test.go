@@ -0,0 +1,385 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type DepartmentControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *DepartmentControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *DepartmentControllerSuite) TestDepartmentController_List() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.DepartmentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("List", mock.Anything, &model.ListDepartmentRequest{OrgID: 1, UserRole: "admin"}).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("List", mock.Anything, &model.ListDepartmentRequest{OrgID: 1, UserRole: "admin"}).
+					Return([]model.DepartmentResponse{
+						{ID: 1, Name: "Finance", CostCenter: "CC-100", CreatedAt: "2025-10-27T13:07:31Z"},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"name":"Finance","cost_center":"CC-100","created_at":"2025-10-27T13:07:31Z"}],` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			du := mocks.NewDepartmentUsecase(s.T())
+			tt.mockFunc(du)
+
+			dc := internalHttp.NewDepartmentController(s.log, s.validate, du)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/departments", dc.List)
+
+			req := httptest.NewRequest("GET", "/api/admin/departments", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *DepartmentControllerSuite) TestDepartmentController_Create() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.DepartmentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"name": "", "cost_center": ""},
+			mockFunc:   func(a *mocks.DepartmentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"name is required","field":"name","rule":"required"},` +
+				`{"code":2000,"message":"cost_center is required","field":"cost_center","rule":"required"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on create",
+			body: map[string]interface{}{"name": "Finance", "cost_center": "CC-100"},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("Create", mock.Anything, mock.Anything).
+					Return(nil, model.ErrDepartmentAlreadyExist)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: `{"errors":[{"code":1028,"message":"Department with the same name or cost center already exist"}],` +
+				`"meta":{"http_status":409}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"name": "Finance", "cost_center": "CC-100"},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("Create", mock.Anything, &model.CreateDepartmentRequest{
+					OrgID:      1,
+					UserRole:   "admin",
+					Name:       "Finance",
+					CostCenter: "CC-100",
+				}).Return(&model.DepartmentResponse{
+					ID:         1,
+					Name:       "Finance",
+					CostCenter: "CC-100",
+					CreatedAt:  "2025-10-27T13:07:31Z",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":1,"name":"Finance","cost_center":"CC-100","created_at":"2025-10-27T13:07:31Z"},` +
+				`"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			du := mocks.NewDepartmentUsecase(s.T())
+			tt.mockFunc(du)
+
+			dc := internalHttp.NewDepartmentController(s.log, s.validate, du)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/departments", dc.Create)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/admin/departments", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *DepartmentControllerSuite) TestDepartmentController_Update() {
+	tests := []struct {
+		name       string
+		id         string
+		body       any
+		mockFunc   func(a *mocks.DepartmentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			body:       map[string]interface{}{"name": "Finance"},
+			mockFunc:   func(a *mocks.DepartmentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on department not found",
+			id:   "1",
+			body: map[string]interface{}{"name": "Finance"},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("Update", mock.Anything, mock.Anything).
+					Return(nil, model.ErrDepartmentNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1027,"message":"Department not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "1",
+			body: map[string]interface{}{"cost_center": "CC-200"},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("Update", mock.Anything, mock.MatchedBy(func(req *model.UpdateDepartmentRequest) bool {
+					return req.ID == 1 && req.UserRole == "admin" && req.Name == nil && *req.CostCenter == "CC-200"
+				})).Return(&model.DepartmentResponse{
+					ID:         1,
+					Name:       "Finance",
+					CostCenter: "CC-200",
+					CreatedAt:  "2025-10-27T13:07:31Z",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"name":"Finance","cost_center":"CC-200","created_at":"2025-10-27T13:07:31Z"},` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			du := mocks.NewDepartmentUsecase(s.T())
+			tt.mockFunc(du)
+
+			dc := internalHttp.NewDepartmentController(s.log, s.validate, du)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.PATCH("/api/admin/departments/:id", dc.Update)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PATCH", "/api/admin/departments/"+tt.id, bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *DepartmentControllerSuite) TestDepartmentController_ListBudgets() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.DepartmentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.DepartmentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			id:   "1",
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("ListBudgets", mock.Anything, &model.ListDepartmentBudgetRequest{OrgID: 1, DepartmentID: 1, UserRole: "admin"}).
+					Return([]model.DepartmentBudgetResponse{
+						{
+							ID:                 1,
+							DepartmentID:       1,
+							PeriodStart:        "2025-07-01",
+							PeriodEnd:          "2025-09-30",
+							AmountIDR:          50000000,
+							AmountFormatted:    "Rp 50.000.000",
+							SpentIDR:           52000000,
+							SpentFormatted:     "Rp 52.000.000",
+							RemainingIDR:       -2000000,
+							RemainingFormatted: "-Rp 2.000.000",
+							CreatedAt:          "2025-10-27T13:07:31Z",
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"department_id":1,"period_start":"2025-07-01","period_end":"2025-09-30",` +
+				`"amount_idr":50000000,"amount_formatted":"Rp 50.000.000","spent_idr":52000000,"spent_formatted":"Rp 52.000.000",` +
+				`"remaining_idr":-2000000,"remaining_formatted":"-Rp 2.000.000","created_at":"2025-10-27T13:07:31Z"}],` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			du := mocks.NewDepartmentUsecase(s.T())
+			tt.mockFunc(du)
+
+			dc := internalHttp.NewDepartmentController(s.log, s.validate, du)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/departments/:id/budgets", dc.ListBudgets)
+
+			req := httptest.NewRequest("GET", "/api/admin/departments/"+tt.id+"/budgets", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *DepartmentControllerSuite) TestDepartmentController_CreateBudget() {
+	tests := []struct {
+		name       string
+		id         string
+		body       any
+		mockFunc   func(a *mocks.DepartmentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			id:         "1",
+			body:       map[string]interface{}{"period_start": "01-07-2025", "period_end": "2025-09-30", "amount_idr": 0},
+			mockFunc:   func(a *mocks.DepartmentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2005,"message":"period_start must be in the 2006-01-02 format","field":"period_start",` +
+				`"rule":"datetime"},` +
+				`{"code":2000,"message":"amount_idr is required","field":"amount_idr","rule":"required"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on overlap",
+			id:   "1",
+			body: map[string]interface{}{"period_start": "2025-07-01", "period_end": "2025-09-30", "amount_idr": 50000000},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("CreateBudget", mock.Anything, mock.Anything).
+					Return(nil, model.ErrBudgetPeriodOverlap)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: `{"errors":[{"code":1030,"message":"Budget period overlaps another budget of the department"}],` +
+				`"meta":{"http_status":409}}`,
+		},
+		{
+			name: "success",
+			id:   "1",
+			body: map[string]interface{}{"period_start": "2025-07-01", "period_end": "2025-09-30", "amount_idr": 50000000},
+			mockFunc: func(a *mocks.DepartmentUsecase) {
+				a.On("CreateBudget", mock.Anything, &model.CreateDepartmentBudgetRequest{
+					OrgID:        1,
+					DepartmentID: 1,
+					UserRole:     "admin",
+					PeriodStart:  "2025-07-01",
+					PeriodEnd:    "2025-09-30",
+					AmountIDR:    50000000,
+				}).Return(&model.DepartmentBudgetResponse{
+					ID:                 1,
+					DepartmentID:       1,
+					PeriodStart:        "2025-07-01",
+					PeriodEnd:          "2025-09-30",
+					AmountIDR:          50000000,
+					AmountFormatted:    "Rp 50.000.000",
+					SpentFormatted:     "Rp 0",
+					RemainingIDR:       50000000,
+					RemainingFormatted: "Rp 50.000.000",
+					CreatedAt:          "2025-10-27T13:07:31Z",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":1,"department_id":1,"period_start":"2025-07-01","period_end":"2025-09-30",` +
+				`"amount_idr":50000000,"amount_formatted":"Rp 50.000.000","spent_idr":0,"spent_formatted":"Rp 0",` +
+				`"remaining_idr":50000000,"remaining_formatted":"Rp 50.000.000","created_at":"2025-10-27T13:07:31Z"},` +
+				`"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			du := mocks.NewDepartmentUsecase(s.T())
+			tt.mockFunc(du)
+
+			dc := internalHttp.NewDepartmentController(s.log, s.validate, du)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/departments/:id/budgets", dc.CreateBudget)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/admin/departments/"+tt.id+"/budgets", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestDepartmentControllerSuite(t *testing.T) {
+	suite.Run(t, new(DepartmentControllerSuite))
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type ExpenseCommentController struct {
+	log                   *zap.Logger
+	validate              *validator.Validate
+	expenseCommentUsecase usecase.ExpenseCommentUsecase
+}
+
+func NewExpenseCommentController(log *zap.Logger, validate *validator.Validate,
+	expenseCommentUsecase usecase.ExpenseCommentUsecase) *ExpenseCommentController {
+	return &ExpenseCommentController{
+		log:                   log,
+		validate:              validate,
+		expenseCommentUsecase: expenseCommentUsecase,
+	}
+}
+
+func (c *ExpenseCommentController) List(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.expenseCommentUsecase.List(ctx.Request.Context(), &model.GetExpenseRequest{
+		ID:       id,
+		OrgID:    claims.OrgID,
+		UserID:   userID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list expense comments", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *ExpenseCommentController) Create(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.CreateExpenseCommentRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.ExpenseID = id
+	request.OrgID = claims.OrgID
+	request.UserID = userID
+	request.UserRole = claims.Role
+	res, err := c.expenseCommentUsecase.Create(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create expense comment", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *ExpenseCommentController) Update(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	commentID, err := strconv.ParseUint(ctx.Param("comment_id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert comment id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.UpdateExpenseCommentRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.ID = commentID
+	request.ExpenseID = id
+	request.OrgID = claims.OrgID
+	request.UserID = userID
+	request.UserRole = claims.Role
+	res, err := c.expenseCommentUsecase.Update(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update expense comment", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *ExpenseCommentController) Delete(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	commentID, err := strconv.ParseUint(ctx.Param("comment_id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert comment id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.expenseCommentUsecase.Delete(ctx.Request.Context(), &model.DeleteExpenseCommentRequest{
+		ID:        commentID,
+		ExpenseID: id,
+		OrgID:     claims.OrgID,
+		UserID:    userID,
+		UserRole:  claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to delete expense comment", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Comment deleted", http.StatusOK),
+	)
+}
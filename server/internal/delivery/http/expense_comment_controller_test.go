@@ -0,0 +1,275 @@
+package http_test
+
+import (
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseCommentControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *ExpenseCommentControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *ExpenseCommentControllerSuite) TestExpenseCommentController_List() {
+	request := &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 1, UserRole: "employee"}
+
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(u *mocks.ExpenseCommentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid id",
+			id:         "abc",
+			mockFunc:   func(u *mocks.ExpenseCommentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on forbidden",
+			id:   "7",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("List", mock.Anything, request).Return(nil, model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"Forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name: "success",
+			id:   "7",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("List", mock.Anything, request).Return([]model.ExpenseCommentResponse{
+					{
+						ID:        1,
+						Body:      "Please attach the boarding pass",
+						User:      model.UserSimpleResponse{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+						Mentions:  []model.UserSimpleResponse{},
+						CreatedAt: "2025-09-30T08:00:00Z",
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"body":"Please attach the boarding pass",` +
+				`"user":{"id":3,"email":"jane@mail.com","name":"Jane Roe"},"mentions":[],` +
+				`"created_at":"2025-09-30T08:00:00Z","edited_at":null}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			u := mocks.NewExpenseCommentUsecase(s.T())
+			tt.mockFunc(u)
+
+			rec := s.serve(u, "GET", "/api/expenses/"+tt.id+"/comments", "")
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ExpenseCommentControllerSuite) TestExpenseCommentController_Create() {
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func(u *mocks.ExpenseCommentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid body",
+			body:       `{"body":`,
+			mockFunc:   func(u *mocks.ExpenseCommentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on invalid mention",
+			body: `{"body":"Hello","mentions":[9]}`,
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("Create", mock.Anything, &model.CreateExpenseCommentRequest{
+					ExpenseID: 7, OrgID: 1, UserID: 1, UserRole: "employee", Body: "Hello", Mentions: []uint64{9},
+				}).Return(nil, model.ErrInvalidCommentMention)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1054,"message":"Only the submitter and the managers of the organization can be mentioned"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: `{"body":"Hello"}`,
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("Create", mock.Anything, &model.CreateExpenseCommentRequest{
+					ExpenseID: 7, OrgID: 1, UserID: 1, UserRole: "employee", Body: "Hello",
+				}).Return(&model.ExpenseCommentResponse{
+					ID:        11,
+					Body:      "Hello",
+					User:      model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+					Mentions:  []model.UserSimpleResponse{},
+					CreatedAt: "2025-09-30T08:00:00Z",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":11,"body":"Hello","user":{"id":1,"email":"john@mail.com","name":"John Doe"},` +
+				`"mentions":[],"created_at":"2025-09-30T08:00:00Z","edited_at":null},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			u := mocks.NewExpenseCommentUsecase(s.T())
+			tt.mockFunc(u)
+
+			rec := s.serve(u, "POST", "/api/expenses/7/comments", tt.body)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ExpenseCommentControllerSuite) TestExpenseCommentController_Update() {
+	tests := []struct {
+		name       string
+		commentID  string
+		mockFunc   func(u *mocks.ExpenseCommentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid comment id",
+			commentID:  "abc",
+			mockFunc:   func(u *mocks.ExpenseCommentUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:      "error on edit window passed",
+			commentID: "11",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("Update", mock.Anything, &model.UpdateExpenseCommentRequest{
+					ID: 11, ExpenseID: 7, OrgID: 1, UserID: 1, UserRole: "employee", Body: "Edited",
+				}).Return(nil, model.NewCommentEditWindowError(15*time.Minute))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes: `{"errors":[{"code":1055,"message":"Comments can only be edited or deleted within 15 minutes of posting"}],` +
+				`"meta":{"http_status":422}}`,
+		},
+		{
+			name:      "success",
+			commentID: "11",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				editedAt := "2025-09-30T08:05:00Z"
+				u.On("Update", mock.Anything, &model.UpdateExpenseCommentRequest{
+					ID: 11, ExpenseID: 7, OrgID: 1, UserID: 1, UserRole: "employee", Body: "Edited",
+				}).Return(&model.ExpenseCommentResponse{
+					ID:        11,
+					Body:      "Edited",
+					User:      model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+					Mentions:  []model.UserSimpleResponse{},
+					CreatedAt: "2025-09-30T08:00:00Z",
+					EditedAt:  &editedAt,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":11,"body":"Edited","user":{"id":1,"email":"john@mail.com","name":"John Doe"},` +
+				`"mentions":[],"created_at":"2025-09-30T08:00:00Z","edited_at":"2025-09-30T08:05:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			u := mocks.NewExpenseCommentUsecase(s.T())
+			tt.mockFunc(u)
+
+			rec := s.serve(u, "PUT", "/api/expenses/7/comments/"+tt.commentID, `{"body":"Edited"}`)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ExpenseCommentControllerSuite) TestExpenseCommentController_Delete() {
+	request := &model.DeleteExpenseCommentRequest{ID: 11, ExpenseID: 7, OrgID: 1, UserID: 1, UserRole: "employee"}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(u *mocks.ExpenseCommentUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on delete",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("Delete", mock.Anything, request).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(u *mocks.ExpenseCommentUsecase) {
+				u.On("Delete", mock.Anything, request).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Comment deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			u := mocks.NewExpenseCommentUsecase(s.T())
+			tt.mockFunc(u)
+
+			rec := s.serve(u, "DELETE", "/api/expenses/7/comments/11", "")
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ExpenseCommentControllerSuite) serve(u *mocks.ExpenseCommentUsecase, method string, target string, body string) *httptest.ResponseRecorder {
+	cc := internalHttp.NewExpenseCommentController(s.log, s.validate, u)
+
+	app := test.NewApi(s.log)
+	app.Use(test.NewAuthMiddleware(1, "employee"))
+	app.GET("/api/expenses/:id/comments", cc.List)
+	app.POST("/api/expenses/:id/comments", cc.Create)
+	app.PUT("/api/expenses/:id/comments/:comment_id", cc.Update)
+	app.DELETE("/api/expenses/:id/comments/:comment_id", cc.Delete)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestExpenseCommentControllerSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseCommentControllerSuite))
+}
@@ -7,12 +7,22 @@ import (
 	"expense-management-system/internal/usecase"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 )
 
+const (
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 100
+	defaultListLimit     = 10
+	maxListLimit         = 100
+)
+
 type ExpenseController struct {
 	log            *zap.Logger
 	validate       *validator.Validate
@@ -56,6 +66,7 @@ func (c *ExpenseController) Create(ctx *gin.Context) {
 		return
 	}
 
+	request.OrgID = claims.OrgID
 	request.UserID = userID
 	res, err := c.expenseUsecase.Create(ctx.Request.Context(), request)
 	if err != nil {
@@ -85,58 +96,117 @@ func (c *ExpenseController) List(ctx *gin.Context) {
 		return
 	}
 
-	var (
-		view   model.ExpenseView
-		status *string
-	)
-
-	switch ctx.Query("view") {
-	case "personal":
-		view = model.ExpenseViewPersonal
-	case "approval_queue":
-		view = model.ExpenseViewApprovalQueue
-	default:
-		view = model.ExpenseViewPersonal
+	view, err := parseExpenseView(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
-	statusQuery := ctx.Query("status")
-	_, err = entity.ParseExpenseStatus(statusQuery)
-	if err == nil {
-		status = &statusQuery
+	limit, offset, invalidFilters := parsePaging(ctx)
+
+	request := &model.ListExpenseRequest{
+		UserID:   userID,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+		View:     view,
+		Limit:    limit,
+		Offset:   offset,
 	}
 
-	autoApproved := ctx.Query("auto_approved") == "true"
+	invalidFilters = append(invalidFilters, parseExpenseFilters(ctx, request)...)
 
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if err != nil || limit <= 0 {
-		limit = 10
+	// counting is what gets slow on big tables, cursor pages skip it unless asked
+	includeTotal := ctx.Query("cursor") == ""
+	if includeTotalQuery := ctx.Query("include_total"); includeTotalQuery != "" {
+		includeTotal, err = strconv.ParseBool(includeTotalQuery)
+		if err != nil {
+			invalidFilters = append(invalidFilters, "include_total")
+		}
 	}
+	request.SkipTotal = !includeTotal
 
-	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	if err != nil || limit < 0 {
-		offset = 0
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
 	}
 
-	request := &model.ListExpenseRequest{
-		UserID:       userID,
-		UserRole:     claims.Role,
-		View:         view,
-		Status:       status,
-		AutoApproved: autoApproved,
-		Limit:        limit,
-		Offset:       offset,
+	if cursorQuery := ctx.Query("cursor"); cursorQuery != "" {
+		cursor := new(model.ExpenseCursor)
+		err = model.DecodeCursor(cursorQuery, cursor)
+		if err != nil || !cursor.Matches(request) {
+			LogWarn(ctx, c.log, "failed to decode cursor", err)
+			ctx.Error(model.ErrInvalidCursor)
+			return
+		}
+		request.Cursor = cursor
+		request.Offset = 0
 	}
-	res, total, err := c.expenseUsecase.List(ctx.Request.Context(), request)
+
+	res, page, err := c.expenseUsecase.List(ctx.Request.Context(), request)
 	if err != nil {
 		LogWarn(ctx, c.log, "failed to get expenses", err)
 		ctx.Error(err)
 		return
 	}
 
+	meta := model.MetaWithPage{
+		Limit:      limit,
+		Offset:     request.Offset,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListResponse(res, meta),
+	)
+}
+
+func (c *ExpenseController) Search(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	query := strings.TrimSpace(ctx.Query("q"))
+	if length := utf8.RuneCountInString(query); length < minSearchQueryLength || length > maxSearchQueryLength {
+		ctx.Error(model.NewInvalidFilterError("q"))
+		return
+	}
+
+	limit, offset, invalidFilters := parsePaging(ctx)
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	res, total, err := c.expenseUsecase.Search(ctx.Request.Context(), &model.SearchExpenseRequest{
+		OrgID:    claims.OrgID,
+		UserID:   userID,
+		UserRole: claims.Role,
+		Query:    query,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to search expenses", err)
+		ctx.Error(err)
+		return
+	}
+
 	meta := model.MetaWithPage{
 		Limit:      limit,
 		Offset:     offset,
-		Total:      total,
+		Total:      &total,
 		HTTPStatus: http.StatusOK,
 	}
 	ctx.JSON(
@@ -170,6 +240,7 @@ func (c *ExpenseController) Get(ctx *gin.Context) {
 	res, err := c.expenseUsecase.FindByID(ctx.Request.Context(), &model.GetExpenseRequest{
 		ID:       id,
 		UserID:   userID,
+		OrgID:    claims.OrgID,
 		UserRole: claims.Role,
 	})
 	if err != nil {
@@ -178,8 +249,241 @@ func (c *ExpenseController) Get(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", model.FormatETag(res.Version))
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *ExpenseController) Update(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.UpdateExpenseRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	version, err := model.ParseIfMatch(ctx.GetHeader("If-Match"))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse if-match", err)
+		ctx.Error(model.ErrExpenseModified)
+		return
+	}
+
+	request.ID = id
+	request.UserID = userID
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	request.Version = version
+	res, err := c.expenseUsecase.Update(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update expense", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("ETag", model.FormatETag(res.Version))
+
 	ctx.JSON(
 		http.StatusOK,
 		model.NewSuccessResponse(res, http.StatusOK),
 	)
 }
+
+// parseExpenseView defaults to the personal view, an unknown view is rejected because the filters depend on it
+func parseExpenseView(ctx *gin.Context) (model.ExpenseView, error) {
+	switch ctx.Query("view") {
+	case "", "personal":
+		return model.ExpenseViewPersonal, nil
+	case "approval_queue":
+		return model.ExpenseViewApprovalQueue, nil
+	case "organization":
+		return model.ExpenseViewOrganization, nil
+	default:
+		return "", model.NewInvalidFilterError("view")
+	}
+}
+
+// parsePaging returns the limit and offset of the list, it returns the name of every paging parameter that is invalid
+func parsePaging(ctx *gin.Context) (int, int, []string) {
+	var invalid []string
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(defaultListLimit)))
+	if err != nil || limit <= 0 || limit > maxListLimit {
+		invalid = append(invalid, "limit")
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		invalid = append(invalid, "offset")
+	}
+
+	return limit, offset, invalid
+}
+
+// parseExpenseFilters fills the filters and sort of the list, it returns the name of every filter that is invalid
+func parseExpenseFilters(ctx *gin.Context, request *model.ListExpenseRequest) []string {
+	var invalid []string
+
+	for _, status := range queryList(ctx, "status") {
+		_, err := entity.ParseExpenseStatus(status)
+		if err != nil || request.View == model.ExpenseViewApprovalQueue {
+			invalid = append(invalid, "status")
+			break
+		}
+		request.Statuses = append(request.Statuses, status)
+	}
+
+	if value := ctx.Query("auto_approved"); value != "" {
+		autoApproved, err := strconv.ParseBool(value)
+		if err != nil {
+			invalid = append(invalid, "auto_approved")
+		}
+		request.AutoApproved = autoApproved
+	}
+
+	// managers narrow their views down to some submitters, the personal view is always the current user
+	for _, value := range queryList(ctx, "user_id") {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 || request.View == model.ExpenseViewPersonal {
+			invalid = append(invalid, "user_id")
+			break
+		}
+		request.UserIDs = append(request.UserIDs, id)
+	}
+
+	times := []struct {
+		name   string
+		target **time.Time
+		to     bool
+	}{
+		{"created_from", &request.CreatedFrom, false},
+		{"created_to", &request.CreatedTo, true},
+		{"processed_from", &request.ProcessedFrom, false},
+		{"processed_to", &request.ProcessedTo, true},
+	}
+	for _, t := range times {
+		value := ctx.Query(t.name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := parseTimeFilter(value, t.to)
+		if err != nil {
+			invalid = append(invalid, t.name)
+			continue
+		}
+		*t.target = &parsed
+	}
+
+	amounts := []struct {
+		name   string
+		target **uint64
+	}{
+		{"min_amount_idr", &request.MinAmountIDR},
+		{"max_amount_idr", &request.MaxAmountIDR},
+	}
+	for _, a := range amounts {
+		value := ctx.Query(a.name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			invalid = append(invalid, a.name)
+			continue
+		}
+		*a.target = &parsed
+	}
+
+	if request.CreatedFrom != nil && request.CreatedTo != nil && request.CreatedFrom.After(*request.CreatedTo) {
+		invalid = append(invalid, "created_to")
+	}
+	if request.ProcessedFrom != nil && request.ProcessedTo != nil && request.ProcessedFrom.After(*request.ProcessedTo) {
+		invalid = append(invalid, "processed_to")
+	}
+	if request.MinAmountIDR != nil && request.MaxAmountIDR != nil && *request.MinAmountIDR > *request.MaxAmountIDR {
+		invalid = append(invalid, "max_amount_idr")
+	}
+
+	switch sortBy := model.ExpenseSortBy(ctx.DefaultQuery("sort_by", string(model.ExpenseSortByCreatedAt))); sortBy {
+	case model.ExpenseSortByCreatedAt, model.ExpenseSortByAmount, model.ExpenseSortByStatus:
+		request.SortBy = sortBy
+	default:
+		invalid = append(invalid, "sort_by")
+	}
+
+	switch sortOrder := model.SortOrder(ctx.DefaultQuery("sort_order", string(model.SortOrderDesc))); sortOrder {
+	case model.SortOrderAsc, model.SortOrderDesc:
+		request.SortOrder = sortOrder
+	default:
+		invalid = append(invalid, "sort_order")
+	}
+
+	return invalid
+}
+
+// queryList accepts both a repeated parameter and a comma separated one
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, value := range ctx.QueryArray(key) {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return values
+}
+
+// parseTimeFilter accepts RFC 3339 or a plain date in UTC, a plain date used as an upper bound covers the whole day
+func parseTimeFilter(value string, to bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if to {
+		// postgres keeps microseconds, a nanosecond less would round up to the next day
+		t = t.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+
+	return t, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"expense-management-system/internal/config"
 	internalHttp "expense-management-system/internal/delivery/http"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
@@ -28,7 +29,7 @@ type ExpenseControllerSuite struct {
 
 func (s *ExpenseControllerSuite) SetupTest() {
 	s.log = zap.NewNop()
-	s.validate = validator.New()
+	s.validate = config.NewValidator()
 }
 
 func (s *ExpenseControllerSuite) TestExpenseController_Create() {
@@ -44,8 +45,8 @@ func (s *ExpenseControllerSuite) TestExpenseController_Create() {
 			body:       nil,
 			mockFunc:   func(a *mocks.ExpenseUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"AmountIDR failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Description failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"amount_idr is required","field":"amount_idr","rule":"required"},` +
+				`{"code":2000,"message":"description is required","field":"description","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "error on validate body",
@@ -55,9 +56,9 @@ func (s *ExpenseControllerSuite) TestExpenseController_Create() {
 			},
 			mockFunc:   func(a *mocks.ExpenseUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"AmountIDR failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Description failed on the 'required' rule"},` +
-				`{"code":2002,"message":"ReceiptURL failed on the 'url' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"amount_idr is required","field":"amount_idr","rule":"required"},` +
+				`{"code":2000,"message":"description is required","field":"description","rule":"required"},` +
+				`{"code":2002,"message":"receipt_url must be a valid URL","field":"receipt_url","rule":"url"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "error on create",
@@ -87,6 +88,7 @@ func (s *ExpenseControllerSuite) TestExpenseController_Create() {
 				a.On("Create", mock.Anything, mock.Anything).Return(&model.ExpenseCreateResponse{
 					ID:               1,
 					AmountIDR:        10000,
+					AmountFormatted:  "Rp 10.000",
 					Description:      "Supplies",
 					ReceiptURL:       &receipt,
 					Status:           "approved",
@@ -96,8 +98,10 @@ func (s *ExpenseControllerSuite) TestExpenseController_Create() {
 				}, nil)
 			},
 			wantStatus: http.StatusCreated,
-			wantRes: `{"data":{"id":1,"amount_idr":10000,"description":"Supplies","receipt_url":"https://example.com/receipt.jpg",` +
-				`"status":"approved","requires_approval":false,"auto_approved":true,"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":201}}`,
+			wantRes: `{"data":{"id":1,"amount_idr":10000,"amount_formatted":"Rp 10.000","description":"Supplies",` +
+				`"receipt_url":"https://example.com/receipt.jpg",` +
+				`"status":"approved","cost_center":null,"requires_approval":false,"auto_approved":true,` +
+				`"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":201}}`,
 		},
 	}
 
@@ -126,17 +130,140 @@ func (s *ExpenseControllerSuite) TestExpenseController_Create() {
 }
 
 func (s *ExpenseControllerSuite) TestExpenseController_List() {
+	now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
+	description := "dummy description"
+	receipt := "https://example.com/receipt.jpg"
+	expenses := []model.ExpenseWithUserResponse{
+		{
+			ID:               1,
+			AmountIDR:        10000,
+			AmountFormatted:  "Rp 10.000",
+			Description:      description,
+			ReceiptURL:       &receipt,
+			Status:           "approved",
+			RequiresApproval: false,
+			AutoApproved:     true,
+			CreatedAt:        now.Format(time.RFC3339),
+			User: model.UserSimpleResponse{
+				ID:    1,
+				Email: "john@mail.com",
+				Name:  "John Doe",
+			},
+		},
+	}
+	total := 1
+	filters := (&model.ListExpenseRequest{
+		View:      model.ExpenseViewPersonal,
+		SortBy:    model.ExpenseSortByCreatedAt,
+		SortOrder: model.SortOrderDesc,
+	}).FilterHash()
+	cursor := model.EncodeCursor(model.ExpenseCursor{
+		ID:        5,
+		Filters:   filters,
+		SortBy:    model.ExpenseSortByCreatedAt,
+		SortOrder: model.SortOrderDesc,
+		CreatedAt: &now,
+	})
+	nextCursor := model.EncodeCursor(model.ExpenseCursor{
+		ID:        1,
+		Filters:   filters,
+		SortBy:    model.ExpenseSortByCreatedAt,
+		SortOrder: model.SortOrderDesc,
+		CreatedAt: &now,
+	})
+	data := `{"data":[{"id":1,"amount_idr":10000,"amount_formatted":"Rp 10.000","description":"dummy description",` +
+		`"receipt_url":"https://example.com/receipt.jpg",` +
+		`"status":"approved","cost_center":null,"requires_approval":false,"auto_approved":true,"created_at":"2025-10-27T13:07:31Z",` +
+		`"user":{"id":1,"email":"john@mail.com","name":"John Doe"}}]`
+
 	tests := []struct {
 		name       string
+		query      string
 		mockFunc   func(a *mocks.ExpenseUsecase)
 		wantStatus int
 		wantRes    string
 	}{
+		{
+			name:       "error invalid cursor",
+			query:      "?cursor=not-a-cursor",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1035,"message":"Invalid cursor"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error cursor of another sort",
+			query:      "?sort_by=amount&cursor=" + cursor,
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1035,"message":"Invalid cursor"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error cursor of other filters",
+			query:      "?status=approved&cursor=" + cursor,
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1035,"message":"Invalid cursor"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error invalid include total",
+			query:      "?include_total=maybe&cursor=" + cursor,
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'include_total' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error invalid filters",
+			query:      "?status=approved,paid&created_from=yesterday&min_amount_idr=-1&sort_by=name&sort_order=up",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'status' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'created_from' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'min_amount_idr' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'sort_by' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'sort_order' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error unknown view",
+			query:      "?view=everything",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'view' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error invalid paging",
+			query:      "?limit=101&offset=-1",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'limit' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'offset' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error filters of another view",
+			query:      "?view=approval_queue&status=approved",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'status' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error user filter on personal view",
+			query:      "?user_id=2",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'user_id' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error reversed ranges",
+			query:      "?created_from=2025-09-01&created_to=2025-08-01&min_amount_idr=50000&max_amount_idr=10000",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'created_to' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'max_amount_idr' filter"}],"meta":{"http_status":400}}`,
+		},
 		{
 			name: "error on list",
 			mockFunc: func(a *mocks.ExpenseUsecase) {
 				a.On("List", mock.Anything, mock.Anything).
-					Return([]model.ExpenseDetailResponse{}, 0, errors.New("something error"))
+					Return([]model.ExpenseWithUserResponse{}, nil, errors.New("something error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
@@ -144,33 +271,75 @@ func (s *ExpenseControllerSuite) TestExpenseController_List() {
 		{
 			name: "success",
 			mockFunc: func(a *mocks.ExpenseUsecase) {
-				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
-				description := "dummy description"
-				receipt := "https://example.com/receipt.jpg"
-
-				a.On("List", mock.Anything, mock.Anything).
-					Return([]model.ExpenseWithUserResponse{
-						{
-							ID:               1,
-							AmountIDR:        10000,
-							Description:      description,
-							ReceiptURL:       &receipt,
-							Status:           "approved",
-							RequiresApproval: false,
-							AutoApproved:     true,
-							CreatedAt:        now.Format(time.RFC3339),
-							User: model.UserSimpleResponse{
-								ID:    1,
-								Email: "john@mail.com",
-								Name:  "John Doe",
-							},
-						},
-					}, 1, nil)
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.Cursor == nil && !r.SkipTotal && r.Limit == 10 &&
+						r.SortBy == model.ExpenseSortByCreatedAt && r.SortOrder == model.SortOrderDesc
+				})).Return(expenses, &model.Page{Total: &total}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    data + `,"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+		},
+		{
+			name: "success with filters",
+			query: "?status=approved&status=completed,rejected&created_from=2025-08-01&created_to=2025-08-31" +
+				"&processed_from=2025-08-02T00:00:00Z&min_amount_idr=10000&max_amount_idr=500000&sort_by=amount&sort_order=asc",
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return s.Equal([]string{"approved", "completed", "rejected"}, r.Statuses) &&
+						s.Equal(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), *r.CreatedFrom) &&
+						s.Equal(time.Date(2025, 8, 31, 23, 59, 59, 999999000, time.UTC), *r.CreatedTo) &&
+						s.Equal(time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC), *r.ProcessedFrom) &&
+						r.ProcessedTo == nil && *r.MinAmountIDR == 10000 && *r.MaxAmountIDR == 500000 &&
+						r.SortBy == model.ExpenseSortByAmount && r.SortOrder == model.SortOrderAsc
+				})).Return(expenses, &model.Page{Total: &total}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    data + `,"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+		},
+		{
+			name:  "success approval queue with user filter",
+			query: "?view=approval_queue&user_id=2,3",
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.View == model.ExpenseViewApprovalQueue && s.Equal([]uint64{2, 3}, r.UserIDs)
+				})).Return(expenses, &model.Page{Total: &total}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    data + `,"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+		},
+		{
+			name:  "success organization with status and user filter",
+			query: "?view=organization&status=completed&user_id=2",
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.View == model.ExpenseViewOrganization && s.Equal([]string{"completed"}, r.Statuses) &&
+						s.Equal([]uint64{2}, r.UserIDs)
+				})).Return(expenses, &model.Page{Total: &total}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    data + `,"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+		},
+		{
+			name:  "success with cursor",
+			query: "?limit=1&offset=20&cursor=" + cursor,
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.Cursor.ID == 5 && r.SkipTotal && r.Offset == 0 && r.Limit == 1
+				})).Return(expenses, &model.Page{NextCursor: &nextCursor}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    data + `,"meta":{"limit":1,"offset":0,"next_cursor":"` + nextCursor + `","http_status":200}}`,
+		},
+		{
+			name:  "success with cursor and total",
+			query: "?limit=1&include_total=true&cursor=" + cursor,
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.Cursor.ID == 5 && !r.SkipTotal
+				})).Return(expenses, &model.Page{Total: &total}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes: `{"data":[{"id":1,"amount_idr":10000,"description":"dummy description","receipt_url":"https://example.com/receipt.jpg",` +
-				`"status":"approved","requires_approval":false,"auto_approved":true,"created_at":"2025-10-27T13:07:31Z",` +
-				`"user":{"id":1,"email":"john@mail.com","name":"John Doe"}}],"meta":{"limit":10,"offset":0,"total":1,"http_status":200}}`,
+			wantRes:    data + `,"meta":{"limit":1,"offset":0,"total":1,"http_status":200}}`,
 		},
 	}
 
@@ -185,7 +354,7 @@ func (s *ExpenseControllerSuite) TestExpenseController_List() {
 			app.Use(test.NewAuthMiddleware(1, "manager"))
 			app.GET("/api/expenses", ec.List)
 
-			req := httptest.NewRequest("GET", "/api/expenses", nil)
+			req := httptest.NewRequest("GET", "/api/expenses"+tt.query, nil)
 			req.Header.Set("Content-Type", "application/json")
 
 			rec := httptest.NewRecorder()
@@ -197,12 +366,117 @@ func (s *ExpenseControllerSuite) TestExpenseController_List() {
 	}
 }
 
+func (s *ExpenseControllerSuite) TestExpenseController_Search() {
+	results := []model.ExpenseSearchResultResponse{
+		{
+			ExpenseWithUserResponse: model.ExpenseWithUserResponse{
+				ID:              1,
+				AmountIDR:       150000,
+				AmountFormatted: "Rp 150.000",
+				Description:     "taxi Surabaya",
+				Status:          "approved",
+				AutoApproved:    true,
+				CreatedAt:       "2025-03-12T10:00:00Z",
+				User:            model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John"},
+			},
+			Rank: 0.5,
+			Highlights: model.ExpenseSearchHighlightResponse{
+				Description: "taxi <mark>Surabaya</mark>",
+			},
+		},
+	}
+	total := 1
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.ExpenseUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error missing query",
+			query:      "",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'q' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error query too short",
+			query:      "?q=+a+",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'q' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error invalid paging",
+			query:      "?q=surabaya&limit=0&offset=abc",
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'limit' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'offset' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error on search",
+			query: "?q=surabaya",
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("Search", mock.Anything, mock.Anything).
+					Return([]model.ExpenseSearchResultResponse{}, 0, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?q=+taxi+surabaya+&limit=5&offset=5",
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("Search", mock.Anything, &model.SearchExpenseRequest{
+					OrgID:    1,
+					UserID:   1,
+					UserRole: "employee",
+					Query:    "taxi surabaya",
+					Limit:    5,
+					Offset:   5,
+				}).Return(results, total, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"amount_idr":150000,"amount_formatted":"Rp 150.000","description":"taxi Surabaya",` +
+				`"receipt_url":null,"status":"approved",` +
+				`"cost_center":null,"requires_approval":false,"auto_approved":true,"created_at":"2025-03-12T10:00:00Z",` +
+				`"user":{"id":1,"email":"john@mail.com","name":"John"},"rank":0.5,` +
+				`"highlights":{"description":"taxi \u003cmark\u003eSurabaya\u003c/mark\u003e","approval_notes":null,"user_name":null}}],` +
+				`"meta":{"limit":5,"offset":5,"total":1,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewExpenseUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewExpenseController(s.log, s.validate, eu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.GET("/api/expenses/search", ec.Search)
+
+			req := httptest.NewRequest("GET", "/api/expenses/search"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func (s *ExpenseControllerSuite) TestExpenseController_Get() {
 	tests := []struct {
 		name       string
 		mockFunc   func(a *mocks.ExpenseUsecase)
 		wantStatus int
 		wantRes    string
+		wantETag   string
 	}{
 		{
 			name: "error on get",
@@ -224,12 +498,14 @@ func (s *ExpenseControllerSuite) TestExpenseController_Get() {
 				a.On("FindByID", mock.Anything, mock.Anything).Return(&model.ExpenseDetailResponse{
 					ID:               1,
 					AmountIDR:        10000,
+					AmountFormatted:  "Rp 10.000",
 					Description:      description,
 					ReceiptURL:       &receipt,
 					Status:           "approved",
 					RequiresApproval: false,
 					AutoApproved:     true,
 					CreatedAt:        now.Format(time.RFC3339),
+					Version:          2,
 					User: model.UserSimpleResponse{
 						ID:    1,
 						Email: "john@mail.com",
@@ -244,13 +520,21 @@ func (s *ExpenseControllerSuite) TestExpenseController_Get() {
 						Notes:         &notes,
 						CreatedAt:     now.Format(time.RFC3339),
 					},
+					ReviewRounds: []model.ExpenseReviewRoundResponse{},
+					Comments:     []model.ExpenseCommentResponse{},
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantRes: `{"data":{"id":1,"amount_idr":10000,"description":"dummy description","receipt_url":"https://example.com/receipt.jpg",` +
-				`"status":"approved","requires_approval":false,"auto_approved":true,"created_at":"2025-10-27T13:07:31Z","processed_at":null,` +
-				`"user":{"id":1,"email":"john@mail.com","name":"John Doe"},"approval":{"id":1,"approver_id":1,"approver_email":"john@mail.com",` +
-				`"approver_name":"John Doe","status":"approved","notes":"dummy notes","created_at":"2025-10-27T13:07:31Z"}},"meta":{"http_status":200}}`,
+			wantRes: `{"data":{"id":1,"amount_idr":10000,"amount_formatted":"Rp 10.000","description":"dummy description",` +
+				`"receipt_url":"https://example.com/receipt.jpg",` +
+				`"status":"approved","cost_center":null,"requires_approval":false,"auto_approved":true,` +
+				`"created_at":"2025-10-27T13:07:31Z","processed_at":null,` +
+				`"version":2,"user":{"id":1,"email":"john@mail.com","name":"John Doe"},"approval":{"id":1,"approver_id":1,` +
+				`"approver_email":"john@mail.com",` +
+				`"approver_name":"John Doe","status":"approved","notes":"dummy notes","created_at":"2025-10-27T13:07:31Z"},` +
+				`"review_rounds":[],"comments":[]},` +
+				`"meta":{"http_status":200}}`,
+			wantETag: `"2"`,
 		},
 	}
 
@@ -273,6 +557,112 @@ func (s *ExpenseControllerSuite) TestExpenseController_Get() {
 
 			s.Equal(tt.wantStatus, rec.Code)
 			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			s.Equal(tt.wantETag, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func (s *ExpenseControllerSuite) TestExpenseController_Update() {
+	tests := []struct {
+		name       string
+		body       any
+		ifMatch    string
+		mockFunc   func(a *mocks.ExpenseUsecase)
+		wantStatus int
+		wantRes    string
+		wantETag   string
+	}{
+		{
+			name:       "empty body",
+			body:       nil,
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"amount_idr is required","field":"amount_idr","rule":"required"},` +
+				`{"code":2000,"message":"description is required","field":"description","rule":"required"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on expense not editable",
+			body: map[string]interface{}{
+				"amount_idr":  10000,
+				"description": "Supplies",
+			},
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("Update", mock.Anything, mock.Anything).Return(nil, model.ErrExpenseNotEditable)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes: `{"errors":[{"code":1056,"message":"Only expenses sent back with changes requested can be edited"}],` +
+				`"meta":{"http_status":422}}`,
+		},
+		{
+			name: "error on weak if-match",
+			body: map[string]interface{}{
+				"amount_idr":  10000,
+				"description": "Supplies",
+			},
+			ifMatch:    `W/"2"`,
+			mockFunc:   func(a *mocks.ExpenseUsecase) {},
+			wantStatus: http.StatusPreconditionFailed,
+			wantRes: `{"errors":[{"code":1040,"message":"Expense was changed by someone else, reload it and try again"}],` +
+				`"meta":{"http_status":412}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"amount_idr":  10000,
+				"description": "Supplies",
+			},
+			ifMatch: `"2"`,
+			mockFunc: func(a *mocks.ExpenseUsecase) {
+				a.On("Update", mock.Anything, mock.MatchedBy(func(r *model.UpdateExpenseRequest) bool {
+					return r.ID == 1 && r.UserID == 1 && r.AmountIDR == 10000 && r.Version != nil && *r.Version == 2
+				})).Return(&model.ExpenseDetailResponse{
+					ID:              1,
+					AmountIDR:       10000,
+					AmountFormatted: "Rp 10.000",
+					Description:     "Supplies",
+					Status:          "approved",
+					AutoApproved:    true,
+					CreatedAt:       "2025-10-27T13:07:31Z",
+					Version:         3,
+					User:            model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+					ReviewRounds:    []model.ExpenseReviewRoundResponse{},
+					Comments:        []model.ExpenseCommentResponse{},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"amount_idr":10000,"amount_formatted":"Rp 10.000","description":"Supplies",` +
+				`"receipt_url":null,"status":"approved","cost_center":null,"requires_approval":false,"auto_approved":true,` +
+				`"created_at":"2025-10-27T13:07:31Z","processed_at":null,` +
+				`"version":3,"user":{"id":1,"email":"john@mail.com","name":"John Doe"},"approval":null,` +
+				`"review_rounds":[],"comments":[]},"meta":{"http_status":200}}`,
+			wantETag: `"3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewExpenseUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewExpenseController(s.log, s.validate, eu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.PUT("/api/expenses/:id", ec.Update)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/api/expenses/1", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			s.Equal(tt.wantETag, rec.Header().Get("ETag"))
 		})
 	}
 }
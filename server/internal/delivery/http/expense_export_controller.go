@@ -0,0 +1,198 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ExpenseExportController struct {
+	log                  *zap.Logger
+	expenseExportUsecase usecase.ExpenseExportUsecase
+}
+
+func NewExpenseExportController(log *zap.Logger, expenseExportUsecase usecase.ExpenseExportUsecase) *ExpenseExportController {
+	return &ExpenseExportController{
+		log:                  log,
+		expenseExportUsecase: expenseExportUsecase,
+	}
+}
+
+// Export streams the file right away, a large export or one asked with async=true
+// is queued instead and answered with the job to poll
+func (c *ExpenseExportController) Export(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	view, err := parseExpenseView(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request := &model.CreateExpenseExportRequest{
+		ListExpenseRequest: model.ListExpenseRequest{
+			UserID:   userID,
+			OrgID:    claims.OrgID,
+			UserRole: claims.Role,
+			View:     view,
+		},
+		Format: ctx.DefaultQuery("format", string(entity.ExportFormatCSV)),
+	}
+
+	invalidFilters := parseExpenseFilters(ctx, &request.ListExpenseRequest)
+	if _, err := entity.ParseExportFormat(request.Format); err != nil {
+		invalidFilters = append(invalidFilters, "format")
+	}
+	if value := ctx.Query("async"); value != "" {
+		request.Async, err = strconv.ParseBool(value)
+		if err != nil {
+			invalidFilters = append(invalidFilters, "async")
+		}
+	}
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	res, err := c.expenseExportUsecase.Create(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create expense export", err)
+		ctx.Error(err)
+		return
+	}
+
+	if res != nil {
+		ctx.JSON(
+			http.StatusAccepted,
+			model.NewSuccessResponse(res, http.StatusAccepted),
+		)
+		return
+	}
+
+	format := entity.ExportFormat(request.Format)
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, usecase.ExpenseExportFileName(time.Now(), format)))
+	ctx.Status(http.StatusOK)
+
+	_, err = c.expenseExportUsecase.Stream(ctx.Request.Context(), request, ctx.Writer)
+	if err != nil {
+		// the rows already sent can't be taken back, closing the connection without finishing
+		// the response tells the client the file is truncated instead of letting it look complete
+		if ctx.Writer.Written() {
+			c.log.Error(fmt.Sprintf("failed to stream expense export = %s", err.Error()),
+				zap.Strings("tags", []string{"expense-export", "stream"}),
+			)
+			panic(http.ErrAbortHandler)
+		}
+
+		LogWarn(ctx, c.log, "failed to stream expense export", err)
+		ctx.Header("Content-Type", "")
+		ctx.Header("Content-Disposition", "")
+		ctx.Error(err)
+		return
+	}
+}
+
+func (c *ExpenseExportController) Get(ctx *gin.Context) {
+	request, ok := c.parseGetRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.expenseExportUsecase.Get(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get expense export", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *ExpenseExportController) Download(ctx *gin.Context) {
+	request, ok := c.parseGetRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.expenseExportUsecase.Download(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to download expense export", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Type", res.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, res.Name))
+	ctx.Status(http.StatusOK)
+
+	_, err = io.Copy(ctx.Writer, res.Content)
+	if err != nil {
+		// the part already sent can't be taken back, the connection is closed the same way as in Export
+		if ctx.Writer.Written() {
+			c.log.Error(fmt.Sprintf("failed to stream expense export file = %s", err.Error()),
+				zap.Strings("tags", []string{"expense-export", "download"}),
+			)
+			panic(http.ErrAbortHandler)
+		}
+
+		LogWarn(ctx, c.log, "failed to stream expense export file", err)
+		ctx.Header("Content-Type", "")
+		ctx.Header("Content-Disposition", "")
+		ctx.Error(err)
+		return
+	}
+}
+
+func (c *ExpenseExportController) parseGetRequest(ctx *gin.Context) (*model.GetExpenseExportRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	return &model.GetExpenseExportRequest{
+		ID:     id,
+		OrgID:  claims.OrgID,
+		UserID: userID,
+	}, true
+}
@@ -0,0 +1,332 @@
+package http_test
+
+import (
+	"errors"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseExportControllerSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *ExpenseExportControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *ExpenseExportControllerSuite) TestExpenseExportController_Export() {
+	rowCount := 0
+
+	tests := []struct {
+		name            string
+		query           string
+		mockFunc        func(a *mocks.ExpenseExportUsecase)
+		wantStatus      int
+		wantContentType string
+		wantRes         string
+		wantAbort       bool
+	}{
+		{
+			name:       "error invalid filters",
+			query:      "?format=pdf&async=maybe&sort_by=name",
+			mockFunc:   func(a *mocks.ExpenseExportUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'sort_by' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'format' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'async' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error unknown view",
+			query:      "?view=everything",
+			mockFunc:   func(a *mocks.ExpenseExportUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'view' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error forbidden",
+			query: "?view=organization",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Create", mock.Anything, mock.Anything).Return(nil, model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"Forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:  "error on stream before writing",
+			query: "",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+				a.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("something error"))
+			},
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "application/json; charset=utf-8",
+			wantRes:         `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "error on stream after writing",
+			query: "",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+				a.On("Stream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					_, _ = io.WriteString(args.Get(2).(io.Writer), "Expense ID\n")
+				}).Return(0, errors.New("something error"))
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantRes:         "Expense ID",
+			wantAbort:       true,
+		},
+		{
+			name:  "success queued",
+			query: "?view=organization&format=xlsx&async=true",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Create", mock.Anything, mock.MatchedBy(func(r *model.CreateExpenseExportRequest) bool {
+					return r.View == model.ExpenseViewOrganization && r.Format == "xlsx" && r.Async && r.UserRole == "manager"
+				})).Return(&model.ExpenseExportResponse{
+					ID:        5,
+					Format:    "xlsx",
+					Status:    "pending",
+					CreatedAt: "2025-09-26T08:00:00Z",
+				}, nil)
+			},
+			wantStatus:      http.StatusAccepted,
+			wantContentType: "application/json; charset=utf-8",
+			wantRes: `{"data":{"id":5,"format":"xlsx","status":"pending","row_count":null,"download_url":null,` +
+				`"created_at":"2025-09-26T08:00:00Z","completed_at":null},"meta":{"http_status":202}}`,
+		},
+		{
+			name:  "success streamed",
+			query: "?status=completed",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Create", mock.Anything, mock.MatchedBy(func(r *model.CreateExpenseExportRequest) bool {
+					return r.View == model.ExpenseViewPersonal && r.Format == "csv" && s.Equal([]string{"completed"}, r.Statuses)
+				})).Return(nil, nil)
+				a.On("Stream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					_, _ = io.WriteString(args.Get(2).(io.Writer), "Expense ID\n")
+				}).Return(rowCount, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantRes:         "Expense ID",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewExpenseExportUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewExpenseExportController(s.log, eu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/expenses/export", ec.Export)
+
+			req := httptest.NewRequest("GET", "/api/expenses/export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			if tt.wantAbort {
+				s.PanicsWithValue(http.ErrAbortHandler, func() { app.ServeHTTP(rec, req) })
+			} else {
+				app.ServeHTTP(rec, req)
+			}
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			if tt.wantContentType != "" {
+				s.Equal(tt.wantContentType, rec.Header().Get("Content-Type"))
+			}
+			if tt.wantStatus == http.StatusOK {
+				s.Regexp(`^attachment; filename="expenses-\d{8}-\d{6}\.csv"$`, rec.Header().Get("Content-Disposition"))
+			} else {
+				s.Empty(rec.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportControllerSuite) TestExpenseExportController_Get() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.ExpenseExportUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.ExpenseExportUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error not found",
+			id:   "5",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Get", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(nil, model.ErrExpenseExportNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1041,"message":"Export not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "5",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				rowCount := 12000
+				downloadURL := "/api/expenses/exports/5/download"
+				completedAt := "2025-09-26T08:01:00Z"
+				a.On("Get", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(&model.ExpenseExportResponse{
+						ID:          5,
+						Format:      "csv",
+						Status:      "completed",
+						RowCount:    &rowCount,
+						DownloadURL: &downloadURL,
+						CreatedAt:   "2025-09-26T08:00:00Z",
+						CompletedAt: &completedAt,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":5,"format":"csv","status":"completed","row_count":12000,` +
+				`"download_url":"/api/expenses/exports/5/download","created_at":"2025-09-26T08:00:00Z",` +
+				`"completed_at":"2025-09-26T08:01:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewExpenseExportUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewExpenseExportController(s.log, eu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/expenses/exports/:id", ec.Get)
+
+			req := httptest.NewRequest("GET", "/api/expenses/exports/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ExpenseExportControllerSuite) TestExpenseExportController_Download() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.ExpenseExportUsecase)
+		wantStatus int
+		wantHeader http.Header
+		wantRes    string
+		wantAbort  bool
+	}{
+		{
+			name: "error not ready",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Download", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(nil, model.ErrExpenseExportNotReady)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: `{"errors":[{"code":1042,"message":"Export is not ready yet, check its status and try again later"}],` +
+				`"meta":{"http_status":409}}`,
+		},
+		{
+			name: "error on read before anything was sent",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Download", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(&model.FileStreamResponse{
+						Name:        "expenses-20250926-080000.csv",
+						ContentType: "text/csv; charset=utf-8",
+						Content:     iotest.ErrReader(errors.New("something error")),
+					}, nil)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantHeader: http.Header{
+				"Content-Disposition": nil,
+			},
+			wantRes: `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "error on read after writing",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Download", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(&model.FileStreamResponse{
+						Name:        "expenses-20250926-080000.csv",
+						ContentType: "text/csv; charset=utf-8",
+						Content:     io.MultiReader(strings.NewReader("Expense ID\n"), iotest.ErrReader(errors.New("something error"))),
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{
+				"Content-Disposition": {`attachment; filename="expenses-20250926-080000.csv"`},
+			},
+			wantRes:   "Expense ID",
+			wantAbort: true,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.ExpenseExportUsecase) {
+				a.On("Download", mock.Anything, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 1}).
+					Return(&model.FileStreamResponse{
+						Name:        "expenses-20250926-080000.csv",
+						ContentType: "text/csv; charset=utf-8",
+						Content:     strings.NewReader("Expense ID\n"),
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{
+				"Content-Type":        {"text/csv; charset=utf-8"},
+				"Content-Disposition": {`attachment; filename="expenses-20250926-080000.csv"`},
+			},
+			wantRes: "Expense ID",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eu := mocks.NewExpenseExportUsecase(s.T())
+			tt.mockFunc(eu)
+
+			ec := internalHttp.NewExpenseExportController(s.log, eu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/expenses/exports/:id/download", ec.Download)
+
+			req := httptest.NewRequest("GET", "/api/expenses/exports/5/download", nil)
+			rec := httptest.NewRecorder()
+			if tt.wantAbort {
+				s.PanicsWithValue(http.ErrAbortHandler, func() { app.ServeHTTP(rec, req) })
+			} else {
+				app.ServeHTTP(rec, req)
+			}
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			for key := range tt.wantHeader {
+				s.Equal(tt.wantHeader.Get(key), rec.Header().Get(key))
+			}
+		})
+	}
+}
+
+func TestExpenseExportControllerSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseExportControllerSuite))
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type JournalController struct {
+	log            *zap.Logger
+	validate       *validator.Validate
+	journalUsecase usecase.JournalUsecase
+}
+
+func NewJournalController(log *zap.Logger, validate *validator.Validate, journalUsecase usecase.JournalUsecase) *JournalController {
+	return &JournalController{
+		log:            log,
+		validate:       validate,
+		journalUsecase: journalUsecase,
+	}
+}
+
+func (c *JournalController) ListMappings(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.journalUsecase.ListMappings(ctx.Request.Context(), &model.ListGLAccountMappingRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list gl account mappings", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *JournalController) UpsertMapping(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	request := new(model.UpsertGLAccountMappingRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.journalUsecase.UpsertMapping(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to upsert gl account mapping", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *JournalController) DeleteMapping(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.journalUsecase.DeleteMapping(ctx.Request.Context(), &model.DeleteGLAccountMappingRequest{
+		ID:       id,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to delete gl account mapping", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("GL account mapping deleted", http.StatusOK),
+	)
+}
+
+func (c *JournalController) CreateBatch(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.CreateJournalBatchRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.OrgID = claims.OrgID
+	request.UserID = userID
+	request.UserRole = claims.Role
+	res, err := c.journalUsecase.CreateBatch(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create journal batch", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *JournalController) ListBatches(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.journalUsecase.ListBatches(ctx.Request.Context(), &model.ListJournalBatchRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list journal batches", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *JournalController) GetBatch(ctx *gin.Context) {
+	request, ok := c.parseGetBatchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.journalUsecase.GetBatch(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get journal batch", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *JournalController) DownloadBatch(ctx *gin.Context) {
+	request, ok := c.parseGetBatchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.journalUsecase.DownloadBatch(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to download journal batch", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, res.Name))
+	ctx.Data(http.StatusOK, res.ContentType, res.Content)
+}
+
+func (c *JournalController) parseGetBatchRequest(ctx *gin.Context) (*model.GetJournalBatchRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	return &model.GetJournalBatchRequest{
+		ID:       id,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	}, true
+}
@@ -0,0 +1,400 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type JournalControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *JournalControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *JournalControllerSuite) TestJournalController_ListMappings() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.JournalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("ListMappings", mock.Anything, &model.ListGLAccountMappingRequest{OrgID: 1, UserRole: "admin"}).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("ListMappings", mock.Anything, &model.ListGLAccountMappingRequest{OrgID: 1, UserRole: "admin"}).
+					Return([]model.GLAccountMappingResponse{
+						{ID: 1, ExpenseAccount: "6000", CreditAccount: "2100", CreatedAt: "2025-10-01T08:00:00Z", UpdatedAt: "2025-10-01T08:00:00Z"},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"cost_center":null,"expense_account":"6000","credit_account":"2100",` +
+				`"created_at":"2025-10-01T08:00:00Z","updated_at":"2025-10-01T08:00:00Z"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ju := mocks.NewJournalUsecase(s.T())
+			tt.mockFunc(ju)
+
+			jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/gl-account-mappings", jc.ListMappings)
+
+			req := httptest.NewRequest("GET", "/api/admin/gl-account-mappings", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *JournalControllerSuite) TestJournalController_UpsertMapping() {
+	costCenter := "CC-100"
+
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.JournalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"expense_account": "2100", "credit_account": "2100"},
+			mockFunc:   func(a *mocks.JournalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2008,"message":"credit_account must be different from expense_account",` +
+				`"field":"credit_account","rule":"nefield"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"cost_center": "CC-100", "expense_account": "6100", "credit_account": "2100"},
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("UpsertMapping", mock.Anything, &model.UpsertGLAccountMappingRequest{
+					OrgID:          1,
+					UserRole:       "admin",
+					CostCenter:     &costCenter,
+					ExpenseAccount: "6100",
+					CreditAccount:  "2100",
+				}).Return(&model.GLAccountMappingResponse{
+					ID:             2,
+					CostCenter:     &costCenter,
+					ExpenseAccount: "6100",
+					CreditAccount:  "2100",
+					CreatedAt:      "2025-10-01T08:00:00Z",
+					UpdatedAt:      "2025-10-01T08:00:00Z",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":2,"cost_center":"CC-100","expense_account":"6100","credit_account":"2100",` +
+				`"created_at":"2025-10-01T08:00:00Z","updated_at":"2025-10-01T08:00:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ju := mocks.NewJournalUsecase(s.T())
+			tt.mockFunc(ju)
+
+			jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.PUT("/api/admin/gl-account-mappings", jc.UpsertMapping)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/api/admin/gl-account-mappings", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *JournalControllerSuite) TestJournalController_DeleteMapping() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.JournalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.JournalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error not found",
+			id:   "2",
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("DeleteMapping", mock.Anything, &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "admin"}).
+					Return(model.ErrGLAccountMappingNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1043,"message":"GL account mapping not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("DeleteMapping", mock.Anything, &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "admin"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"GL account mapping deleted","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ju := mocks.NewJournalUsecase(s.T())
+			tt.mockFunc(ju)
+
+			jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.DELETE("/api/admin/gl-account-mappings/:id", jc.DeleteMapping)
+
+			req := httptest.NewRequest("DELETE", "/api/admin/gl-account-mappings/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *JournalControllerSuite) TestJournalController_CreateBatch() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.JournalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"period": "2025-09-01"},
+			mockFunc:   func(a *mocks.JournalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2005,"message":"period must be in the 2006-01 format","field":"period","rule":"datetime"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error missing mapping",
+			body: map[string]interface{}{"period": "2025-09"},
+			mockFunc: func(a *mocks.JournalUsecase) {
+				err := model.NewGLAccountMappingMissingError("CC-200")
+				err.Append(model.ErrDefaultGLAccountMissing.Errors[0])
+				a.On("CreateBatch", mock.Anything, mock.Anything).Return(nil, err)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes: `{"errors":[{"code":1044,"message":"No GL account mapping for the 'CC-200' cost center and no default mapping"},` +
+				`{"code":1045,"message":"Expenses without a cost center need a default GL account mapping"}],"meta":{"http_status":422}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"period": "2025-09"},
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("CreateBatch", mock.Anything, &model.CreateJournalBatchRequest{
+					OrgID:    1,
+					UserID:   1,
+					UserRole: "admin",
+					Period:   "2025-09",
+				}).Return(&model.JournalBatchResponse{
+					ID:                   4,
+					Period:               "2025-09",
+					CreatedBy:            1,
+					LineCount:            2,
+					TotalAmountIDR:       150000,
+					TotalAmountFormatted: "Rp 150.000",
+					DownloadURL:          "/api/admin/journal-batches/4/download",
+					CreatedAt:            "2025-10-01T08:00:00Z",
+					Lines: []model.JournalLineResponse{
+						{LineNo: 1, ExpenseID: 7, EntryDate: "2025-09-14", Account: "6000", DebitIDR: 150000, Memo: "EXP-000000007 Taxi"},
+						{LineNo: 2, ExpenseID: 7, EntryDate: "2025-09-14", Account: "2100", CreditIDR: 150000, Memo: "EXP-000000007 Taxi"},
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":4,"period":"2025-09","created_by":1,"line_count":2,"total_amount_idr":150000,` +
+				`"total_amount_formatted":"Rp 150.000","download_url":"/api/admin/journal-batches/4/download",` +
+				`"created_at":"2025-10-01T08:00:00Z","lines":[` +
+				`{"line_no":1,"expense_id":7,"entry_date":"2025-09-14","account":"6000","debit_idr":150000,"credit_idr":0,` +
+				`"cost_center":null,"memo":"EXP-000000007 Taxi"},` +
+				`{"line_no":2,"expense_id":7,"entry_date":"2025-09-14","account":"2100","debit_idr":0,"credit_idr":150000,` +
+				`"cost_center":null,"memo":"EXP-000000007 Taxi"}]},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ju := mocks.NewJournalUsecase(s.T())
+			tt.mockFunc(ju)
+
+			jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/journal-batches", jc.CreateBatch)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/admin/journal-batches", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *JournalControllerSuite) TestJournalController_ListBatches() {
+	ju := mocks.NewJournalUsecase(s.T())
+	ju.On("ListBatches", mock.Anything, &model.ListJournalBatchRequest{OrgID: 1, UserRole: "admin"}).
+		Return([]model.JournalBatchResponse{
+			{
+				ID:                   4,
+				Period:               "2025-09",
+				CreatedBy:            1,
+				LineCount:            2,
+				TotalAmountIDR:       150000,
+				TotalAmountFormatted: "Rp 150.000",
+				DownloadURL:          "/api/admin/journal-batches/4/download",
+				CreatedAt:            "2025-10-01T08:00:00Z",
+			},
+		}, nil)
+
+	jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+	app := test.NewApi(s.log)
+	app.Use(test.NewAuthMiddleware(1, "admin"))
+	app.GET("/api/admin/journal-batches", jc.ListBatches)
+
+	req := httptest.NewRequest("GET", "/api/admin/journal-batches", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(`{"data":[{"id":4,"period":"2025-09","created_by":1,"line_count":2,"total_amount_idr":150000,`+
+		`"total_amount_formatted":"Rp 150.000","download_url":"/api/admin/journal-batches/4/download",`+
+		`"created_at":"2025-10-01T08:00:00Z"}],"meta":{"http_status":200}}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func (s *JournalControllerSuite) TestJournalController_GetBatch() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.JournalUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.JournalUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error not found",
+			id:   "4",
+			mockFunc: func(a *mocks.JournalUsecase) {
+				a.On("GetBatch", mock.Anything, &model.GetJournalBatchRequest{ID: 4, OrgID: 1, UserRole: "admin"}).
+					Return(nil, model.ErrJournalBatchNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1047,"message":"Journal batch not found"}],"meta":{"http_status":404}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ju := mocks.NewJournalUsecase(s.T())
+			tt.mockFunc(ju)
+
+			jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/journal-batches/:id", jc.GetBatch)
+
+			req := httptest.NewRequest("GET", "/api/admin/journal-batches/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *JournalControllerSuite) TestJournalController_DownloadBatch() {
+	ju := mocks.NewJournalUsecase(s.T())
+	ju.On("DownloadBatch", mock.Anything, &model.GetJournalBatchRequest{ID: 4, OrgID: 1, UserRole: "admin"}).
+		Return(&model.FileResponse{
+			Name:        "journal-2025-09-4.csv",
+			ContentType: "text/csv; charset=utf-8",
+			Content:     []byte("Batch ID\n"),
+		}, nil)
+
+	jc := internalHttp.NewJournalController(s.log, s.validate, ju)
+
+	app := test.NewApi(s.log)
+	app.Use(test.NewAuthMiddleware(1, "admin"))
+	app.GET("/api/admin/journal-batches/:id/download", jc.DownloadBatch)
+
+	req := httptest.NewRequest("GET", "/api/admin/journal-batches/4/download", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("Batch ID", strings.TrimSpace(rec.Body.String()))
+	s.Equal("text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal(`attachment; filename="journal-2025-09-4.csv"`, rec.Header().Get("Content-Disposition"))
+}
+
+func TestJournalControllerSuite(t *testing.T) {
+	suite.Run(t, new(JournalControllerSuite))
+}
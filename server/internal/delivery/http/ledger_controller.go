@@ -0,0 +1,171 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type LedgerController struct {
+	log           *zap.Logger
+	validate      *validator.Validate
+	ledgerUsecase usecase.LedgerUsecase
+}
+
+func NewLedgerController(log *zap.Logger, validate *validator.Validate, ledgerUsecase usecase.LedgerUsecase) *LedgerController {
+	return &LedgerController{
+		log:           log,
+		validate:      validate,
+		ledgerUsecase: ledgerUsecase,
+	}
+}
+
+func (c *LedgerController) ListBalances(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	var userID *uint64
+	userQuery, err := strconv.ParseUint(ctx.Query("user_id"), 10, 64)
+	if err == nil {
+		userID = &userQuery
+	}
+
+	res, err := c.ledgerUsecase.ListBalances(ctx.Request.Context(), &model.ListLedgerBalanceRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+		UserID:   userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list ledger balances", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *LedgerController) ListEntries(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	var (
+		expenseID *uint64
+		userID    *uint64
+	)
+
+	expenseQuery, err := strconv.ParseUint(ctx.Query("expense_id"), 10, 64)
+	if err == nil {
+		expenseID = &expenseQuery
+	}
+
+	userQuery, err := strconv.ParseUint(ctx.Query("user_id"), 10, 64)
+	if err == nil {
+		userID = &userQuery
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	request := &model.ListLedgerEntryRequest{
+		OrgID:     claims.OrgID,
+		UserRole:  claims.Role,
+		ExpenseID: expenseID,
+		UserID:    userID,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	res, total, err := c.ledgerUsecase.ListEntries(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list ledger entries", err)
+		ctx.Error(err)
+		return
+	}
+
+	meta := model.MetaWithPage{
+		Limit:      limit,
+		Offset:     offset,
+		Total:      &total,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListResponse(res, meta),
+	)
+}
+
+func (c *LedgerController) Reverse(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.ReverseLedgerEntryRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.ID = id
+	request.OrgID = claims.OrgID
+	request.UserID = userID
+	request.UserRole = claims.Role
+	res, err := c.ledgerUsecase.Reverse(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to reverse ledger entry", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
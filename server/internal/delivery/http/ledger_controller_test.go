@@ -0,0 +1,254 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type LedgerControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *LedgerControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *LedgerControllerSuite) TestLedgerController_ListBalances() {
+	userID := uint64(3)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.LedgerUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:  "error on list",
+			query: "",
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("ListBalances", mock.Anything, &model.ListLedgerBalanceRequest{OrgID: 1, UserRole: "admin"}).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?user_id=3",
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("ListBalances", mock.Anything, &model.ListLedgerBalanceRequest{OrgID: 1, UserRole: "admin", UserID: &userID}).
+					Return([]model.LedgerBalanceResponse{
+						{
+							User:             model.UserSimpleResponse{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+							BalanceIDR:       50000,
+							BalanceFormatted: "Rp 50.000",
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"user":{"id":3,"email":"john@mail.com","name":"John Doe"},"balance_idr":50000,` +
+				`"balance_formatted":"Rp 50.000"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			lu := mocks.NewLedgerUsecase(s.T())
+			tt.mockFunc(lu)
+
+			lc := internalHttp.NewLedgerController(s.log, s.validate, lu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/ledger/balances", lc.ListBalances)
+
+			req := httptest.NewRequest("GET", "/api/admin/ledger/balances"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *LedgerControllerSuite) TestLedgerController_ListEntries() {
+	expenseID := uint64(7)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.LedgerUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:  "error on list",
+			query: "",
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("ListEntries", mock.Anything, &model.ListLedgerEntryRequest{OrgID: 1, UserRole: "admin", Limit: 10}).
+					Return(nil, 0, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?expense_id=7&limit=5&offset=5",
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("ListEntries", mock.Anything, &model.ListLedgerEntryRequest{
+					OrgID: 1, UserRole: "admin", ExpenseID: &expenseID, Limit: 5, Offset: 5,
+				}).Return([]model.LedgerEntryResponse{
+					{
+						ID:        10,
+						ExpenseID: 7,
+						Type:      "approval",
+						Memo:      "Expense approved",
+						CreatedAt: "2025-10-01T08:00:00Z",
+						Postings: []model.LedgerPostingResponse{
+							{Account: "reimbursement_expense", DebitIDR: 50000},
+						},
+					},
+				}, 6, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":10,"expense_id":7,"type":"approval","reverses_entry_id":null,"memo":"Expense approved",` +
+				`"created_by":null,"created_at":"2025-10-01T08:00:00Z","postings":[{"account":"reimbursement_expense",` +
+				`"user_id":null,"debit_idr":50000,"credit_idr":0}]}],"meta":{"limit":5,"offset":5,"total":6,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			lu := mocks.NewLedgerUsecase(s.T())
+			tt.mockFunc(lu)
+
+			lc := internalHttp.NewLedgerController(s.log, s.validate, lu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/ledger/entries", lc.ListEntries)
+
+			req := httptest.NewRequest("GET", "/api/admin/ledger/entries"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *LedgerControllerSuite) TestLedgerController_Reverse() {
+	reversesEntryID := uint64(10)
+	createdBy := uint64(1)
+
+	tests := []struct {
+		name       string
+		id         string
+		body       any
+		mockFunc   func(a *mocks.LedgerUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on convert id",
+			id:         "abc",
+			body:       map[string]interface{}{"reason": "Bank transfer bounced"},
+			mockFunc:   func(a *mocks.LedgerUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on validate body",
+			id:         "10",
+			body:       map[string]interface{}{},
+			mockFunc:   func(a *mocks.LedgerUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"reason is required","field":"reason","rule":"required"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error already reversed",
+			id:   "10",
+			body: map[string]interface{}{"reason": "Bank transfer bounced"},
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("Reverse", mock.Anything, &model.ReverseLedgerEntryRequest{
+					ID: 10, OrgID: 1, UserID: 1, UserRole: "admin", Reason: "Bank transfer bounced",
+				}).Return(nil, model.ErrLedgerEntryReversed)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1049,"message":"Ledger entry is already reversed"}],"meta":{"http_status":409}}`,
+		},
+		{
+			name: "success",
+			id:   "10",
+			body: map[string]interface{}{"reason": "Bank transfer bounced"},
+			mockFunc: func(a *mocks.LedgerUsecase) {
+				a.On("Reverse", mock.Anything, &model.ReverseLedgerEntryRequest{
+					ID: 10, OrgID: 1, UserID: 1, UserRole: "admin", Reason: "Bank transfer bounced",
+				}).Return(&model.LedgerEntryResponse{
+					ID:              11,
+					ExpenseID:       7,
+					Type:            "reversal",
+					ReversesEntryID: &reversesEntryID,
+					Memo:            "Bank transfer bounced",
+					CreatedBy:       &createdBy,
+					CreatedAt:       "2025-10-01T08:00:00Z",
+					Postings: []model.LedgerPostingResponse{
+						{Account: "cash", DebitIDR: 50000},
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":11,"expense_id":7,"type":"reversal","reverses_entry_id":10,"memo":"Bank transfer bounced",` +
+				`"created_by":1,"created_at":"2025-10-01T08:00:00Z","postings":[{"account":"cash","user_id":null,` +
+				`"debit_idr":50000,"credit_idr":0}]},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			lu := mocks.NewLedgerUsecase(s.T())
+			tt.mockFunc(lu)
+
+			lc := internalHttp.NewLedgerController(s.log, s.validate, lu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/ledger/entries/:id/reverse", lc.Reverse)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/admin/ledger/entries/"+tt.id+"/reverse", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestLedgerControllerSuite(t *testing.T) {
+	suite.Run(t, new(LedgerControllerSuite))
+}
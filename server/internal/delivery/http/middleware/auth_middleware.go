@@ -10,10 +10,14 @@ import (
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
-func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken) gin.HandlerFunc {
+// NewAuthMiddleware accepts both a login jwt and a personal access token,
+// the latter is mapped onto the same claims so handlers don't need to tell them apart
+func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken,
+	apiKeyAuthenticator auth.APIKeyAuthenticator) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -27,8 +31,17 @@ func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtT
 			return
 		}
 
+		var (
+			claims *auth.JWTClaims
+			err    error
+		)
+
 		token := strings.TrimPrefix(authHeader, "Bearer ")
-		claims, err := jwtToken.Parse(token)
+		if auth.IsAPIKey(token) {
+			claims, err = apiKeyAuthenticator.Authenticate(ctx.Request.Context(), token)
+		} else {
+			claims, err = jwtToken.Parse(token)
+		}
 		if err != nil {
 			logger.Warn(err.Error(),
 				zap.Any("request_id", requestid.Get(ctx)),
@@ -59,6 +72,45 @@ func NewAuthMiddleware(logger *zap.Logger, redisClient storage.RedisClient, jwtT
 			return
 		}
 
+		revokeUserKey := fmt.Sprintf("%s:%s", auth.PrefixRevokeUserKey, claims.UserID)
+		revokedAt, err := redisClient.Get(ctx.Request.Context(), revokeUserKey).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			logger.Warn(err.Error(),
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+			)
+			ctx.Error(model.ErrInvalidAuthToken)
+			ctx.Abort()
+			return
+		}
+
+		// iat only has second precision, so a token issued in the same second is revoked as well
+		if err == nil && (claims.IssuedAt == nil || claims.IssuedAt.Unix() <= revokedAt) {
+			ctx.Error(model.ErrTokenRevoked)
+			ctx.Abort()
+			return
+		}
+
+		deactivatedKey := fmt.Sprintf("%s:%s", auth.PrefixDeactivatedUserKey, claims.UserID)
+		exists, err = redisClient.Exists(ctx.Request.Context(), deactivatedKey).Result()
+		if err != nil {
+			logger.Warn(err.Error(),
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+			)
+			ctx.Error(model.ErrInvalidAuthToken)
+			ctx.Abort()
+			return
+		}
+
+		if exists == 1 {
+			ctx.Error(model.ErrUserDeactivated)
+			ctx.Abort()
+			return
+		}
+
 		ctx.Set("claims", claims)
 		ctx.Next()
 	}
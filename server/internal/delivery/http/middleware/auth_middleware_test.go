@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -34,21 +35,21 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 	tests := []struct {
 		name       string
 		authToken  string
-		mockFunc   func(rc *mocks.RedisClient, j *mocks.JWTToken)
+		mockFunc   func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator)
 		wantStatus int
 		wantRes    string
 	}{
 		{
 			name:       "empty auth token",
 			authToken:  "",
-			mockFunc:   func(rc *mocks.RedisClient, j *mocks.JWTToken) {},
+			mockFunc:   func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {},
 			wantStatus: http.StatusUnauthorized,
 			wantRes:    `{"errors":[{"code":104,"message":"Missing or invalid auth header"}],"meta":{"http_status":401}}`,
 		},
 		{
 			name:      "invalid auth token",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
 				j.On("Parse", "dummy-token").
 					Return(nil, errors.New("something error"))
 			},
@@ -58,7 +59,7 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		{
 			name:      "error on get revoked token cache",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
 					Role:   "manager",
@@ -76,7 +77,7 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		{
 			name:      "revoked auth token",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
 					Role:   "manager",
@@ -91,10 +92,160 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 			wantStatus: http.StatusUnauthorized,
 			wantRes:    `{"errors":[{"code":106,"message":"Token revoked"}],"meta":{"http_status":401}}`,
 		},
+		{
+			name:      "error on get revoked user tokens cache",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Role:   "manager",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID:       "zxc-123",
+						IssuedAt: jwt.NewNumericDate(time.Unix(1700000000, 0)),
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetErr(errors.New("something error"))
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"Invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "token issued before user tokens revoked",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Role:   "manager",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID:       "zxc-123",
+						IssuedAt: jwt.NewNumericDate(time.Unix(1700000000, 0)),
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetVal("1700000000")
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":106,"message":"Token revoked"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "success token issued after user tokens revoked",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Role:   "manager",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID:       "zxc-123",
+						IssuedAt: jwt.NewNumericDate(time.Unix(1700000000, 0)),
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetVal("1699999999")
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+				rc.On("Exists", mock.Anything, "deactivated-user:1").Return(existsCmd)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"user_id":"1","role":"manager"},"meta":{"http_status":200}}`,
+		},
+		{
+			name:      "error on get deactivated user cache",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Role:   "manager",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+				deactivatedCmd := redis.NewIntCmd(context.Background())
+				deactivatedCmd.SetErr(errors.New("something error"))
+				rc.On("Exists", mock.Anything, "deactivated-user:1").Return(deactivatedCmd)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"Invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "deactivated user",
+			authToken: "Bearer dummy-token",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
+					UserID: "1",
+					Role:   "manager",
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID: "zxc-123",
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+				deactivatedCmd := redis.NewIntCmd(context.Background())
+				deactivatedCmd.SetVal(1)
+				rc.On("Exists", mock.Anything, "deactivated-user:1").Return(deactivatedCmd)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1021,"message":"User account is deactivated"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:      "invalid api key",
+			authToken: "Bearer ems_dummy-key",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				a.On("Authenticate", mock.Anything, "ems_dummy-key").
+					Return(nil, errors.New("access token not found"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":105,"message":"Invalid auth token"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:      "success api key",
+			authToken: "Bearer ems_dummy-key",
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
+				a.On("Authenticate", mock.Anything, "ems_dummy-key").Return(&auth.JWTClaims{
+					UserID:   "1",
+					Role:     "manager",
+					APIKeyID: 3,
+					Scopes:   []string{"expenses:read"},
+					RegisteredClaims: jwt.RegisteredClaims{
+						ID:       "pat-3",
+						IssuedAt: jwt.NewNumericDate(time.Unix(1700000000, 0)),
+					},
+				}, nil)
+				existsCmd := redis.NewIntCmd(context.Background())
+				existsCmd.SetVal(0)
+				rc.On("Exists", mock.Anything, "revoke-jwt-token:pat-3").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+				rc.On("Exists", mock.Anything, "deactivated-user:1").Return(existsCmd)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"user_id":"1","role":"manager"},"meta":{"http_status":200}}`,
+		},
 		{
 			name:      "success",
 			authToken: "Bearer dummy-token",
-			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken) {
+			mockFunc: func(rc *mocks.RedisClient, j *mocks.JWTToken, a *mocks.APIKeyAuthenticator) {
 				j.On("Parse", "dummy-token").Return(&auth.JWTClaims{
 					UserID: "1",
 					Role:   "manager",
@@ -105,6 +256,10 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 				existsCmd := redis.NewIntCmd(context.Background())
 				existsCmd.SetVal(0)
 				rc.On("Exists", mock.Anything, "revoke-jwt-token:zxc-123").Return(existsCmd)
+				getCmd := redis.NewStringCmd(context.Background())
+				getCmd.SetErr(redis.Nil)
+				rc.On("Get", mock.Anything, "revoke-user-tokens:1").Return(getCmd)
+				rc.On("Exists", mock.Anything, "deactivated-user:1").Return(existsCmd)
 			},
 			wantStatus: http.StatusOK,
 			wantRes:    `{"data":{"user_id":"1","role":"manager"},"meta":{"http_status":200}}`,
@@ -115,9 +270,10 @@ func (s *AuthMiddlewareSuite) TestAuthMiddleware_Handler() {
 		s.Run(tt.name, func() {
 			rc := mocks.NewRedisClient(s.T())
 			jwt := mocks.NewJWTToken(s.T())
-			tt.mockFunc(rc, jwt)
+			ak := mocks.NewAPIKeyAuthenticator(s.T())
+			tt.mockFunc(rc, jwt, ak)
 
-			authMw := middleware.NewAuthMiddleware(s.log, rc, jwt)
+			authMw := middleware.NewAuthMiddleware(s.log, rc, jwt, ak)
 
 			app := test.NewApi(s.log)
 			app.Use(authMw)
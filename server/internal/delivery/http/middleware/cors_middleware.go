@@ -8,11 +8,16 @@ import (
 )
 
 func NewCorsMiddleware(origins []string) gin.HandlerFunc {
+	exposeHeaders := []string{
+		"Content-Length", "ETag", IdempotentReplayedHeader,
+		RateLimitLimitHeader, RateLimitRemainingHeader, RateLimitResetHeader, RateLimitPolicyHeader, RetryAfterHeader,
+	}
+
 	config := cors.Config{
 		AllowOrigins:     origins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match", IdempotencyKeyHeader},
+		ExposeHeaders:    exposeHeaders,
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -16,6 +16,12 @@ func NewRecoverMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
+				// a handler that already sent part of the response aborts on purpose,
+				// net/http closes the connection so the client sees the body is incomplete
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+
 				var err error
 				switch t := r.(type) {
 				case string:
@@ -33,8 +39,11 @@ func NewRecoverMiddleware(logger *zap.Logger) gin.HandlerFunc {
 					zap.Error(err),
 				)
 
+				locale := RequestLocale(ctx)
+				ctx.Header("Content-Language", string(locale))
+
 				resp := model.ErrorResponse{}
-				resp.Errors = model.ErrInternalServerError.Errors
+				resp.Errors = model.ErrInternalServerError.Localize(locale)
 				resp.Meta.HTTPStatus = model.ErrInternalServerError.HTTPStatus
 
 				ctx.JSON(http.StatusInternalServerError, resp)
@@ -59,37 +68,46 @@ func NewErrorMiddleware(logger *zap.Logger) gin.HandlerFunc {
 				zap.Error(err),
 			)
 
+			locale := RequestLocale(ctx)
+			ctx.Header("Content-Language", string(locale))
+
 			resp := model.ErrorResponse{}
 
 			var customErr *model.CustomError
 			if errors.As(err, &customErr) {
-				resp.Errors = customErr.Errors
+				resp.Errors = customErr.Localize(locale)
 				resp.Meta.HTTPStatus = customErr.HTTPStatus
 
 				ctx.JSON(customErr.HTTPStatus, resp)
 				return
 			}
 
-			if valErrs, ok := err.(validator.ValidationErrors); ok {
-				errItems := make([]model.ErrorItem, len(valErrs))
-				for i, fe := range valErrs {
-					errItems[i] = model.ErrorItem{
-						Code:    2000 + i,
-						Message: fmt.Sprintf("%s failed on the '%s' rule", fe.Field(), fe.Tag()),
-					}
-				}
-
-				resp.Errors = errItems
-				resp.Meta.HTTPStatus = http.StatusBadRequest
+			var valErrs validator.ValidationErrors
+			if errors.As(err, &valErrs) {
+				valErr := model.NewValidationError(valErrs)
+				resp.Errors = valErr.Localize(locale)
+				resp.Meta.HTTPStatus = valErr.HTTPStatus
 
-				ctx.JSON(http.StatusBadRequest, resp)
+				ctx.JSON(valErr.HTTPStatus, resp)
 				return
 			}
 
-			resp.Errors = model.ErrInternalServerError.Errors
+			resp.Errors = model.ErrInternalServerError.Localize(locale)
 			resp.Meta.HTTPStatus = model.ErrInternalServerError.HTTPStatus
 
 			ctx.JSON(http.StatusInternalServerError, resp)
 		}
 	}
 }
+
+// RequestLocale is the language of the messages of the request, the preference of the logged in user
+// wins over the Accept-Language header
+func RequestLocale(ctx *gin.Context) model.Locale {
+	if claims, err := GetJWTClaims(ctx); err == nil {
+		if locale, ok := model.ParseLocale(claims.Locale); ok {
+			return locale
+		}
+	}
+
+	return model.NegotiateLocale(ctx.GetHeader("Accept-Language"))
+}
@@ -0,0 +1,112 @@
+package middleware_test
+
+import (
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/config"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ErrorMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *ErrorMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *ErrorMiddlewareSuite) TestErrorMiddleware_Localize() {
+	validate := config.NewValidator()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		userLocale     string
+		err            error
+		wantStatus     int
+		wantLanguage   string
+		wantRes        string
+	}{
+		{
+			name:         "english by default",
+			err:          model.ErrExpenseNotFound,
+			wantStatus:   http.StatusNotFound,
+			wantLanguage: "en",
+			wantRes:      `{"errors":[{"code":1003,"message":"Expense not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name:           "indonesian from accept-language",
+			acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			err:            model.NewExpenseMaxAmountError(50000000),
+			wantStatus:     http.StatusBadRequest,
+			wantLanguage:   "id",
+			wantRes: `{"errors":[{"code":1005,"message":"Jumlah tidak boleh lebih dari Rp 50.000.000"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name:           "user preference wins over accept-language",
+			acceptLanguage: "id",
+			userLocale:     "en",
+			err:            model.ErrExpenseNotFound,
+			wantStatus:     http.StatusNotFound,
+			wantLanguage:   "en",
+			wantRes:        `{"errors":[{"code":1003,"message":"Expense not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name:         "indonesian validation error",
+			userLocale:   "id",
+			err:          validate.Struct(&model.CreateExpenseRequest{}),
+			wantStatus:   http.StatusBadRequest,
+			wantLanguage: "id",
+			wantRes: `{"errors":[{"code":2000,"message":"amount_idr wajib diisi","field":"amount_idr","rule":"required"},` +
+				`{"code":2000,"message":"description wajib diisi","field":"description","rule":"required"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name:           "indonesian unexpected error",
+			acceptLanguage: "id",
+			err:            http.ErrHandlerTimeout,
+			wantStatus:     http.StatusInternalServerError,
+			wantLanguage:   "id",
+			wantRes:        `{"errors":[{"code":100,"message":"Terjadi kesalahan pada server"}],"meta":{"http_status":500}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := test.NewApi(s.log)
+			app.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &auth.JWTClaims{UserID: "1", Locale: tt.userLocale})
+				ctx.Next()
+			})
+			app.GET("/", func(ctx *gin.Context) {
+				ctx.Error(tt.err)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantLanguage, rec.Header().Get("Content-Language"))
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestErrorMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(ErrorMiddlewareSuite))
+}
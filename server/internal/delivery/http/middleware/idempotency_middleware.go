@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	PrefixIdempotencyKey      = "idempotency"
+	maxIdempotencyKeyLength   = 255
+	idempotencyLockExpiration = time.Minute // a crashed request frees the key after this
+)
+
+// idempotencyRecord is stored in redis under the key of the user, it's only
+// completed once the handler finished without an error
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// NewIdempotencyMiddleware must run after the auth middleware. A request with an Idempotency-Key
+// runs once per user and key, retries get the stored response back for the given ttl.
+// Requests without the header are passed through untouched.
+func NewIdempotencyMiddleware(logger *zap.Logger, redisClient storage.RedisClient, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			ctx.Error(model.ErrInvalidIdempotencyKey)
+			ctx.Abort()
+			return
+		}
+
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			logIdempotencyWarn(ctx, logger, err)
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			logIdempotencyWarn(ctx, logger, err)
+			ctx.Error(model.ErrBadRequest)
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		redisKey := fmt.Sprintf("%s:%s:%s", PrefixIdempotencyKey, claims.UserID, key)
+		fingerprint := requestFingerprint(ctx.Request, body)
+
+		lock, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+		acquired, err := redisClient.SetNX(ctx.Request.Context(), redisKey, lock, idempotencyLockExpiration).Result()
+		if err != nil {
+			// redis being down shouldn't stop expenses from being submitted
+			logIdempotencyWarn(ctx, logger, err)
+			ctx.Next()
+			return
+		}
+
+		if !acquired {
+			replayIdempotentResponse(ctx, logger, redisClient, redisKey, fingerprint)
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: ctx.Writer, body: &bytes.Buffer{}}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		// errors are rendered by the error middleware after this returns, the key is released
+		// instead so a retry runs the request again
+		if len(ctx.Errors) > 0 || writer.Status() >= http.StatusInternalServerError {
+			err = redisClient.Del(ctx.Request.Context(), redisKey).Err()
+			if err != nil {
+				logIdempotencyWarn(ctx, logger, err)
+			}
+			return
+		}
+
+		record, _ := json.Marshal(idempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		err = redisClient.Set(ctx.Request.Context(), redisKey, record, ttl).Err()
+		if err != nil {
+			logIdempotencyWarn(ctx, logger, err)
+		}
+	}
+}
+
+func replayIdempotentResponse(ctx *gin.Context, logger *zap.Logger, redisClient storage.RedisClient,
+	redisKey string, fingerprint string) {
+	value, err := redisClient.Get(ctx.Request.Context(), redisKey).Bytes()
+	if err != nil {
+		// the first request failed and released the key in between, the client can retry
+		if !errors.Is(err, redis.Nil) {
+			logIdempotencyWarn(ctx, logger, err)
+		}
+		ctx.Error(model.ErrIdempotencyKeyInProgress)
+		ctx.Abort()
+		return
+	}
+
+	var record idempotencyRecord
+	err = json.Unmarshal(value, &record)
+	if err != nil {
+		logIdempotencyWarn(ctx, logger, err)
+		ctx.Error(model.ErrInternalServerError)
+		ctx.Abort()
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		ctx.Error(model.ErrIdempotencyKeyReused)
+		ctx.Abort()
+		return
+	}
+
+	if !record.Completed {
+		ctx.Error(model.ErrIdempotencyKeyInProgress)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Header(IdempotentReplayedHeader, "true")
+	ctx.Data(record.Status, record.ContentType, record.Body)
+	ctx.Abort()
+}
+
+// requestFingerprint ties a key to one endpoint, precondition and body, reusing it elsewhere is a conflict
+func requestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	if ifMatch := req.Header.Get("If-Match"); ifMatch != "" {
+		hash.Write([]byte("If-Match: " + ifMatch + "\n"))
+	}
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func logIdempotencyWarn(ctx *gin.Context, logger *zap.Logger, err error) {
+	logger.Warn(err.Error(),
+		zap.Any("request_id", requestid.Get(ctx)),
+		zap.Any("path", ctx.Request.RequestURI),
+		zap.Any("method", ctx.Request.Method),
+	)
+}
@@ -0,0 +1,240 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type IdempotencyMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ttl time.Duration
+}
+
+func (s *IdempotencyMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ttl = 24 * time.Hour
+}
+
+func (s *IdempotencyMiddlewareSuite) record(ifMatch, body string, completed bool) string {
+	fingerprint := "POST /api/expenses\n"
+	if ifMatch != "" {
+		fingerprint += "If-Match: " + ifMatch + "\n"
+	}
+	hash := sha256.Sum256([]byte(fingerprint + body))
+	record := struct {
+		Fingerprint string `json:"fingerprint"`
+		Completed   bool   `json:"completed"`
+		Status      int    `json:"status,omitempty"`
+		ContentType string `json:"content_type,omitempty"`
+		Body        []byte `json:"body,omitempty"`
+	}{Fingerprint: hex.EncodeToString(hash[:]), Completed: completed}
+	if completed {
+		record.Status = http.StatusCreated
+		record.ContentType = "application/json; charset=utf-8"
+		record.Body = []byte(`{"data":{"id":1},"meta":{"http_status":201}}`)
+	}
+
+	value, _ := json.Marshal(record)
+	return string(value)
+}
+
+func (s *IdempotencyMiddlewareSuite) TestIdempotencyMiddleware_Handler() {
+	body := `{"amount_idr":15000,"description":"taxi"}`
+	redisKey := "idempotency:1:key-1"
+
+	tests := []struct {
+		name         string
+		key          string
+		ifMatch      string
+		body         string
+		handlerErr   error
+		mockFunc     func(rc *mocks.RedisClient)
+		wantStatus   int
+		wantRes      string
+		wantReplayed bool
+		wantCalls    int
+	}{
+		{
+			name:       "without key",
+			body:       body,
+			mockFunc:   func(rc *mocks.RedisClient) {},
+			wantStatus: http.StatusCreated,
+			wantRes:    `{"data":{"id":1},"meta":{"http_status":201}}`,
+			wantCalls:  1,
+		},
+		{
+			name:       "key too long",
+			key:        strings.Repeat("k", 256),
+			body:       body,
+			mockFunc:   func(rc *mocks.RedisClient) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1037,"message":"Idempotency-Key must be between 1 and 255 characters"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on lock runs the request",
+			key:  "key-1",
+			body: body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				cmd := redis.NewBoolCmd(context.Background())
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).Return(cmd)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes:    `{"data":{"id":1},"meta":{"http_status":201}}`,
+			wantCalls:  1,
+		},
+		{
+			name: "first request stores the response",
+			key:  "key-1",
+			body: body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(true, nil))
+				rc.On("Set", mock.Anything, redisKey, mock.MatchedBy(func(value []byte) bool {
+					return string(value) == s.record("", body, true)
+				}), s.ttl).Return(redis.NewStatusResult("OK", nil))
+			},
+			wantStatus: http.StatusCreated,
+			wantRes:    `{"data":{"id":1},"meta":{"http_status":201}}`,
+			wantCalls:  1,
+		},
+		{
+			name:       "failed request releases the key",
+			key:        "key-1",
+			body:       body,
+			handlerErr: model.ErrBadRequest,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(true, nil))
+				rc.On("Del", mock.Anything, redisKey).Return(redis.NewIntResult(1, nil))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+			wantCalls:  1,
+		},
+		{
+			name: "retry replays the stored response",
+			key:  "key-1",
+			body: body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(false, nil))
+				rc.On("Get", mock.Anything, redisKey).Return(redis.NewStringResult(s.record("", body, true), nil))
+			},
+			wantStatus:   http.StatusCreated,
+			wantRes:      `{"data":{"id":1},"meta":{"http_status":201}}`,
+			wantReplayed: true,
+		},
+		{
+			name: "same key with another body",
+			key:  "key-1",
+			body: `{"amount_idr":99000,"description":"taxi"}`,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(false, nil))
+				rc.On("Get", mock.Anything, redisKey).Return(redis.NewStringResult(s.record("", body, true), nil))
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1038,"message":"Idempotency-Key was already used for a different request"}],"meta":{"http_status":409}}`,
+		},
+		{
+			name:    "retry with the same If-Match replays the stored response",
+			key:     "key-1",
+			ifMatch: `"3"`,
+			body:    body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(false, nil))
+				rc.On("Get", mock.Anything, redisKey).Return(redis.NewStringResult(s.record(`"3"`, body, true), nil))
+			},
+			wantStatus:   http.StatusCreated,
+			wantRes:      `{"data":{"id":1},"meta":{"http_status":201}}`,
+			wantReplayed: true,
+		},
+		{
+			name:    "same key with another If-Match",
+			key:     "key-1",
+			ifMatch: `"4"`,
+			body:    body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(false, nil))
+				rc.On("Get", mock.Anything, redisKey).Return(redis.NewStringResult(s.record(`"3"`, body, true), nil))
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1038,"message":"Idempotency-Key was already used for a different request"}],"meta":{"http_status":409}}`,
+		},
+		{
+			name: "same key still in progress",
+			key:  "key-1",
+			body: body,
+			mockFunc: func(rc *mocks.RedisClient) {
+				rc.On("SetNX", mock.Anything, redisKey, mock.Anything, time.Minute).
+					Return(redis.NewBoolResult(false, nil))
+				rc.On("Get", mock.Anything, redisKey).Return(redis.NewStringResult(s.record("", body, false), nil))
+			},
+			wantStatus: http.StatusConflict,
+			wantRes:    `{"errors":[{"code":1039,"message":"A request with the same Idempotency-Key is still in progress"}],"meta":{"http_status":409}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			tt.mockFunc(rc)
+
+			calls := 0
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.Use(middleware.NewIdempotencyMiddleware(s.log, rc, s.ttl))
+			app.POST("/api/expenses", func(ctx *gin.Context) {
+				calls++
+				if tt.handlerErr != nil {
+					ctx.Error(tt.handlerErr)
+					return
+				}
+				ctx.JSON(http.StatusCreated, model.NewSuccessResponse(map[string]int{"id": 1}, http.StatusCreated))
+			})
+
+			req := httptest.NewRequest("POST", "/api/expenses", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set(middleware.IdempotencyKeyHeader, tt.key)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			s.Equal(tt.wantCalls, calls)
+			s.Equal(tt.wantReplayed, rec.Header().Get(middleware.IdempotentReplayedHeader) == "true")
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyMiddlewareSuite))
+}
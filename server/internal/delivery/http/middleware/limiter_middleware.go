@@ -1,34 +1,151 @@
 package middleware
 
 import (
+	"errors"
 	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
 	"fmt"
-	"net/http"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
 	redisrate "github.com/go-redis/redis_rate/v10"
 )
 
-const limiterRateRequest = "rate_request_%s"
+const (
+	PrefixRateLimitKey = "rate-limit"
+
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// route groups with their own limit, see route.go. The global policy runs on every request
+// before auth, so it's always counted per client ip.
+const (
+	RateLimitPolicyGlobal   = "global"
+	RateLimitPolicyAPI      = "api"
+	RateLimitPolicyPublic   = "public"
+	RateLimitPolicyLogin    = "login"
+	RateLimitPolicyRegister = "register"
+)
+
+type RateLimitFailMode string
+
+const (
+	RateLimitFailOpen   RateLimitFailMode = "open"
+	RateLimitFailClosed RateLimitFailMode = "closed"
+)
+
+var (
+	ErrInvalidRateLimit         = errors.New("invalid rate limit, expected <requests>/<period> (e.g. 10/1s)")
+	ErrInvalidRateLimitFailMode = errors.New("invalid rate limit fail mode")
+)
 
-func NewLimiterMiddleware(rate *redisrate.Limiter) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		res, _ := rate.Allow(ctx, fmt.Sprintf(limiterRateRequest, ctx.ClientIP()), redisrate.Limit{
-			Rate:   10,
-			Burst:  10,
-			Period: time.Second,
-		})
+type RateLimitConfig struct {
+	Policies map[string]redisrate.Limit
+	FailMode RateLimitFailMode
+}
 
-		if res.Allowed <= 0 {
-			resp := model.ErrorResponse{}
-			resp.Errors = model.ErrTooManyRequest.Errors
-			resp.Meta.HTTPStatus = model.ErrTooManyRequest.HTTPStatus
+// ParseRateLimit parses a limit such as 10/1s or 5/1m, the burst is the same as the rate
+func ParseRateLimit(str string) (redisrate.Limit, error) {
+	rateStr, periodStr, found := strings.Cut(strings.TrimSpace(str), "/")
+	if !found {
+		return redisrate.Limit{}, ErrInvalidRateLimit
+	}
+
+	rate, err := strconv.Atoi(rateStr)
+	if err != nil || rate <= 0 {
+		return redisrate.Limit{}, ErrInvalidRateLimit
+	}
+
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return redisrate.Limit{}, ErrInvalidRateLimit
+	}
+
+	return redisrate.Limit{Rate: rate, Burst: rate, Period: period}, nil
+}
 
-			ctx.JSON(http.StatusTooManyRequests, resp)
-			ctx.Abort()
+func ParseRateLimitFailMode(str string) (RateLimitFailMode, error) {
+	switch mode := RateLimitFailMode(str); mode {
+	case RateLimitFailOpen, RateLimitFailClosed:
+		return mode, nil
+	default:
+		return "", ErrInvalidRateLimitFailMode
+	}
+}
+
+// NewLimiterMiddleware returns the limiter of a policy. Requests are counted per user once the
+// auth middleware ran before it and per client ip otherwise, every policy has its own counters.
+// The config decides whether requests pass or fail with 503 when the limiter is unavailable.
+func NewLimiterMiddleware(
+	logger *zap.Logger,
+	limiter storage.RateLimiter,
+	config RateLimitConfig,
+) func(policy string) gin.HandlerFunc {
+	return func(policy string) gin.HandlerFunc {
+		limit, ok := config.Policies[policy]
+		if !ok {
+			panic("rate limit policy not configured: " + policy)
 		}
 
-		ctx.Next()
+		return func(ctx *gin.Context) {
+			res, err := limiter.Allow(ctx.Request.Context(), rateLimitKey(ctx, policy), limit)
+			if err != nil {
+				logger.Warn(err.Error(),
+					zap.Any("request_id", requestid.Get(ctx)),
+					zap.Any("path", ctx.Request.RequestURI),
+					zap.Any("method", ctx.Request.Method),
+					zap.String("policy", policy),
+				)
+
+				if config.FailMode == RateLimitFailClosed {
+					ctx.Error(model.ErrServiceUnavailable)
+					ctx.Abort()
+					return
+				}
+
+				ctx.Next()
+				return
+			}
+
+			ctx.Header(RateLimitLimitHeader, strconv.Itoa(limit.Burst))
+			ctx.Header(RateLimitRemainingHeader, strconv.Itoa(res.Remaining))
+			ctx.Header(RateLimitResetHeader, strconv.Itoa(ceilSeconds(res.ResetAfter)))
+			ctx.Header(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", limit.Burst, ceilSeconds(limit.Period)))
+
+			if res.Allowed <= 0 {
+				ctx.Header(RetryAfterHeader, strconv.Itoa(max(ceilSeconds(res.RetryAfter), 1)))
+				ctx.Error(model.ErrTooManyRequest)
+				ctx.Abort()
+				return
+			}
+
+			ctx.Next()
+		}
 	}
 }
+
+func rateLimitKey(ctx *gin.Context, policy string) string {
+	if claims, err := GetJWTClaims(ctx); err == nil {
+		return fmt.Sprintf("%s:%s:user:%s", PrefixRateLimitKey, policy, claims.UserID)
+	}
+
+	return fmt.Sprintf("%s:%s:ip:%s", PrefixRateLimitKey, policy, ctx.ClientIP())
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(d.Seconds()))
+}
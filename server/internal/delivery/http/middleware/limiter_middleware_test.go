@@ -0,0 +1,247 @@
+package middleware_test
+
+import (
+	"errors"
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	redisrate "github.com/go-redis/redis_rate/v10"
+)
+
+type LimiterMiddlewareSuite struct {
+	suite.Suite
+	log   *zap.Logger
+	limit redisrate.Limit
+}
+
+func (s *LimiterMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.limit = redisrate.Limit{Rate: 10, Burst: 10, Period: time.Second}
+}
+
+func (s *LimiterMiddlewareSuite) TestLimiterMiddleware_Handler() {
+	tests := []struct {
+		name        string
+		userID      uint64
+		failMode    middleware.RateLimitFailMode
+		mockFunc    func(rl *mocks.RateLimiter)
+		wantStatus  int
+		wantRes     string
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "allowed per user",
+			userID: 1,
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:api:user:1", s.limit).
+					Return(&redisrate.Result{Limit: s.limit, Allowed: 1, Remaining: 9, RetryAfter: -1,
+						ResetAfter: 100 * time.Millisecond}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "1",
+				"RateLimit-Policy":    "10;w=1",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name: "allowed per client ip without auth",
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:api:ip:192.0.2.1", s.limit).
+					Return(&redisrate.Result{Limit: s.limit, Allowed: 1, Remaining: 9, RetryAfter: -1,
+						ResetAfter: 100 * time.Millisecond}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "9",
+			},
+		},
+		{
+			name:   "exceeded",
+			userID: 1,
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:api:user:1", s.limit).
+					Return(&redisrate.Result{Limit: s.limit, Allowed: 0, Remaining: 0, RetryAfter: 300 * time.Millisecond,
+						ResetAfter: time.Second}, nil)
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantRes:    `{"errors":[{"code":107,"message":"Too many requests"}],"meta":{"http_status":429}}`,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "1",
+				"Retry-After":         "1",
+			},
+		},
+		{
+			name:     "limiter unavailable fails open",
+			userID:   1,
+			failMode: middleware.RateLimitFailOpen,
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:api:user:1", s.limit).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    "OK",
+			wantHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+		{
+			name:     "limiter unavailable fails closed",
+			userID:   1,
+			failMode: middleware.RateLimitFailClosed,
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:api:user:1", s.limit).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantRes: `{"errors":[{"code":108,"message":"Service is temporarily unavailable, please try again later"}],` +
+				`"meta":{"http_status":503}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rl := mocks.NewRateLimiter(s.T())
+			tt.mockFunc(rl)
+
+			limiterMiddleware := middleware.NewLimiterMiddleware(s.log, rl, middleware.RateLimitConfig{
+				Policies: map[string]redisrate.Limit{middleware.RateLimitPolicyAPI: s.limit},
+				FailMode: tt.failMode,
+			})
+
+			app := test.NewApi(s.log)
+			if tt.userID != 0 {
+				app.Use(test.NewAuthMiddleware(tt.userID, "employee"))
+			}
+			app.GET("/api/expenses", limiterMiddleware(middleware.RateLimitPolicyAPI), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/api/expenses", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			for header, want := range tt.wantHeaders {
+				s.Equal(want, rec.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func (s *LimiterMiddlewareSuite) TestLimiterMiddleware_GlobalBeforeAuth() {
+	globalLimit := redisrate.Limit{Rate: 50, Burst: 50, Period: time.Second}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(rl *mocks.RateLimiter)
+		wantStatus int
+		wantAuth   bool
+	}{
+		{
+			name: "counted per client ip and then per user",
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:global:ip:192.0.2.1", globalLimit).
+					Return(&redisrate.Result{Limit: globalLimit, Allowed: 1, Remaining: 49, RetryAfter: -1}, nil)
+				rl.On("Allow", mock.Anything, "rate-limit:api:user:1", s.limit).
+					Return(&redisrate.Result{Limit: s.limit, Allowed: 1, Remaining: 9, RetryAfter: -1}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantAuth:   true,
+		},
+		{
+			name: "exceeded per client ip never reaches auth",
+			mockFunc: func(rl *mocks.RateLimiter) {
+				rl.On("Allow", mock.Anything, "rate-limit:global:ip:192.0.2.1", globalLimit).
+					Return(&redisrate.Result{Limit: globalLimit, Allowed: 0, Remaining: 0, RetryAfter: time.Second}, nil)
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rl := mocks.NewRateLimiter(s.T())
+			tt.mockFunc(rl)
+
+			limiterMiddleware := middleware.NewLimiterMiddleware(s.log, rl, middleware.RateLimitConfig{
+				Policies: map[string]redisrate.Limit{
+					middleware.RateLimitPolicyGlobal: globalLimit,
+					middleware.RateLimitPolicyAPI:    s.limit,
+				},
+			})
+
+			authCalled := false
+			authMiddleware := test.NewAuthMiddleware(1, "employee")
+
+			app := test.NewApi(s.log)
+			app.Use(limiterMiddleware(middleware.RateLimitPolicyGlobal))
+			app.GET("/api/expenses", func(ctx *gin.Context) {
+				authCalled = true
+				authMiddleware(ctx)
+			}, limiterMiddleware(middleware.RateLimitPolicyAPI), func(ctx *gin.Context) {
+				ctx.String(http.StatusOK, "OK")
+			})
+
+			req := httptest.NewRequest("GET", "/api/expenses", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantAuth, authCalled)
+		})
+	}
+}
+
+func (s *LimiterMiddlewareSuite) TestLimiterMiddleware_UnknownPolicy() {
+	limiterMiddleware := middleware.NewLimiterMiddleware(s.log, mocks.NewRateLimiter(s.T()), middleware.RateLimitConfig{})
+
+	s.Panics(func() {
+		limiterMiddleware(middleware.RateLimitPolicyLogin)
+	})
+}
+
+func (s *LimiterMiddlewareSuite) TestParseRateLimit() {
+	tests := []struct {
+		name    string
+		str     string
+		want    redisrate.Limit
+		wantErr error
+	}{
+		{name: "per second", str: "10/1s", want: redisrate.Limit{Rate: 10, Burst: 10, Period: time.Second}},
+		{name: "per minute", str: " 5/1m ", want: redisrate.Limit{Rate: 5, Burst: 5, Period: time.Minute}},
+		{name: "without period", str: "10", wantErr: middleware.ErrInvalidRateLimit},
+		{name: "zero rate", str: "0/1s", wantErr: middleware.ErrInvalidRateLimit},
+		{name: "invalid period", str: "10/second", wantErr: middleware.ErrInvalidRateLimit},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := middleware.ParseRateLimit(tt.str)
+			s.Equal(tt.want, got)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestLimiterMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(LimiterMiddlewareSuite))
+}
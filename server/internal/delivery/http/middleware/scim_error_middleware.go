@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"expense-management-system/internal/model"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// NewSCIMErrorMiddleware renders errors of the scim endpoints in the format scim clients expect,
+// the errors are cleared afterwards so NewErrorMiddleware leaves the response alone
+func NewSCIMErrorMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 {
+			return
+		}
+
+		err := ctx.Errors.Last().Err
+		ctx.Errors = ctx.Errors[:0]
+
+		logger.Error(err.Error(),
+			zap.Any("request_id", requestid.Get(ctx)),
+			zap.Any("path", ctx.Request.RequestURI),
+			zap.Any("method", ctx.Request.Method),
+			zap.Error(err),
+		)
+
+		resp := model.SCIMErrorResponse{
+			Schemas: []string{model.SCIMSchemaError},
+		}
+
+		status := model.ErrInternalServerError.HTTPStatus
+		resp.Detail = model.ErrInternalServerError.Error()
+
+		var customErr *model.CustomError
+		var valErrs validator.ValidationErrors
+		switch {
+		case errors.As(err, &customErr):
+			status = customErr.HTTPStatus
+			resp.SCIMType = scimErrorType(customErr)
+			resp.Detail = customErr.Error()
+		case errors.As(err, &valErrs):
+			status = http.StatusBadRequest
+			resp.SCIMType = "invalidValue"
+			resp.Detail = model.NewValidationError(valErrs).Error()
+		}
+
+		resp.Status = fmt.Sprint(status)
+
+		ctx.Header("Content-Type", model.SCIMContentType)
+		ctx.JSON(status, resp)
+	}
+}
+
+func scimErrorType(err *model.CustomError) string {
+	switch err {
+	case model.ErrInvalidSCIMFilter:
+		return "invalidFilter"
+	case model.ErrInvalidSCIMPatch, model.ErrBadRequest:
+		return "invalidValue"
+	case model.ErrUserAlreadyExist:
+		return "uniqueness"
+	default:
+		return ""
+	}
+}
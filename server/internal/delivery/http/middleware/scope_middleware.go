@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"expense-management-system/internal/model"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NewScopeMiddleware must run after the auth middleware, the returned handler rejects
+// personal access tokens without the given scope, a login session always passes
+func NewScopeMiddleware(logger *zap.Logger) func(scope string) gin.HandlerFunc {
+	return func(scope string) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+			claims, err := GetJWTClaims(ctx)
+			if err != nil {
+				logger.Warn(err.Error(),
+					zap.Any("request_id", requestid.Get(ctx)),
+					zap.Any("path", ctx.Request.RequestURI),
+					zap.Any("method", ctx.Request.Method),
+				)
+				ctx.Error(model.ErrUnauthorized)
+				ctx.Abort()
+				return
+			}
+
+			if !claims.HasScope(scope) {
+				ctx.Error(model.ErrInsufficientScope)
+				ctx.Abort()
+				return
+			}
+
+			ctx.Next()
+		}
+	}
+}
+
+// NewSessionMiddleware must run after the auth middleware, it keeps personal access tokens
+// away from account management such as passwords, two-factor and the tokens themselves
+func NewSessionMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			logger.Warn(err.Error(),
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+			)
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if claims.IsAPIKey() {
+			ctx.Error(model.ErrSessionRequired)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
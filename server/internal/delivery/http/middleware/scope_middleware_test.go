@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ScopeMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *ScopeMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *ScopeMiddlewareSuite) TestScopeMiddleware_Handler() {
+	tests := []struct {
+		name       string
+		authMw     gin.HandlerFunc
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			authMw:     func(ctx *gin.Context) { ctx.Next() },
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"Unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "api key without scope",
+			authMw:     test.NewAPIKeyMiddleware(1, "employee", auth.ScopeExpensesWrite),
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1017,"message":"Access token lacks the required scope"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "api key with scope",
+			authMw:     test.NewAPIKeyMiddleware(1, "employee", auth.ScopeExpensesRead),
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"OK","meta":{"http_status":200}}`,
+		},
+		{
+			name:       "login session",
+			authMw:     test.NewAuthMiddleware(1, "employee"),
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"OK","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := test.NewApi(s.log)
+			app.Use(tt.authMw)
+			app.Use(middleware.NewScopeMiddleware(s.log)(auth.ScopeExpensesRead))
+			app.GET("/", func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, model.NewSuccessMessageResponse("OK", http.StatusOK))
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *ScopeMiddlewareSuite) TestSessionMiddleware_Handler() {
+	tests := []struct {
+		name       string
+		authMw     gin.HandlerFunc
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			authMw:     func(ctx *gin.Context) { ctx.Next() },
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"Unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "api key",
+			authMw:     test.NewAPIKeyMiddleware(1, "employee", auth.ScopeExpensesRead),
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1018,"message":"This action requires a login session"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "login session",
+			authMw:     test.NewAuthMiddleware(1, "employee"),
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"OK","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := test.NewApi(s.log)
+			app.Use(tt.authMw)
+			app.Use(middleware.NewSessionMiddleware(s.log))
+			app.GET("/", func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, model.NewSuccessMessageResponse("OK", http.StatusOK))
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestScopeMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(ScopeMiddlewareSuite))
+}
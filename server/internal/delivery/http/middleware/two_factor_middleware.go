@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NewTwoFactorMiddleware must run after the auth middleware, it rejects managers
+// and admins whose access token was issued without a totp verification
+func NewTwoFactorMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, err := GetJWTClaims(ctx)
+		if err != nil {
+			logger.Warn(err.Error(),
+				zap.Any("request_id", requestid.Get(ctx)),
+				zap.Any("path", ctx.Request.RequestURI),
+				zap.Any("method", ctx.Request.Method),
+			)
+			ctx.Error(model.ErrUnauthorized)
+			ctx.Abort()
+			return
+		}
+
+		if entity.UserRole(claims.Role).RequiresTwoFactor() && !claims.HasAMR(auth.AMRTOTP) {
+			ctx.Error(model.ErrTwoFactorRequired)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type TwoFactorMiddlewareSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *TwoFactorMiddlewareSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *TwoFactorMiddlewareSuite) TestTwoFactorMiddleware_Handler() {
+	tests := []struct {
+		name       string
+		authMw     gin.HandlerFunc
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "missing claims",
+			authMw:     func(ctx *gin.Context) { ctx.Next() },
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":101,"message":"Unauthorized"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name:       "manager without totp",
+			authMw:     test.NewAuthMiddleware(1, "manager"),
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":1011,"message":"Two-factor authentication is required"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:       "manager with totp",
+			authMw:     test.NewAuthMiddleware(1, "manager", auth.AMRTOTP),
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"OK","meta":{"http_status":200}}`,
+		},
+		{
+			name:       "employee without totp",
+			authMw:     test.NewAuthMiddleware(1, "employee"),
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"OK","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			app := test.NewApi(s.log)
+			app.Use(tt.authMw)
+			app.Use(middleware.NewTwoFactorMiddleware(s.log))
+			app.GET("/", func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, model.NewSuccessMessageResponse("OK", http.StatusOK))
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestTwoFactorMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorMiddlewareSuite))
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type OrganizationController struct {
+	log                 *zap.Logger
+	validate            *validator.Validate
+	organizationUsecase usecase.OrganizationUsecase
+}
+
+func NewOrganizationController(log *zap.Logger, validate *validator.Validate,
+	organizationUsecase usecase.OrganizationUsecase) *OrganizationController {
+	return &OrganizationController{
+		log:                 log,
+		validate:            validate,
+		organizationUsecase: organizationUsecase,
+	}
+}
+
+func (c *OrganizationController) Get(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.organizationUsecase.Get(ctx.Request.Context(), &model.GetOrganizationRequest{
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get organization", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *OrganizationController) Update(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	request := new(model.UpdateOrganizationRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.organizationUsecase.Update(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update organization", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
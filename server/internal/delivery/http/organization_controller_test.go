@@ -0,0 +1,195 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrganizationControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *OrganizationControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *OrganizationControllerSuite) TestOrganizationController_Get() {
+	tests := []struct {
+		name       string
+		mockFunc   func(a *mocks.OrganizationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error on get",
+			mockFunc: func(a *mocks.OrganizationUsecase) {
+				a.On("Get", mock.Anything, &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"}).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "error not found",
+			mockFunc: func(a *mocks.OrganizationUsecase) {
+				a.On("Get", mock.Anything, &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"}).
+					Return(nil, model.ErrOrganizationNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1033,"message":"Organization not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(a *mocks.OrganizationUsecase) {
+				a.On("Get", mock.Anything, &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"}).
+					Return(&model.OrganizationResponse{
+						ID:                               1,
+						Name:                             "Default",
+						MinExpenseAmountIDR:              10000,
+						MinExpenseAmountFormatted:        "Rp 10.000",
+						MaxExpenseAmountIDR:              50000000,
+						MaxExpenseAmountFormatted:        "Rp 50.000.000",
+						ApprovalThresholdAmountIDR:       1000000,
+						ApprovalThresholdAmountFormatted: "Rp 1.000.000",
+						CreatedAt:                        "2025-09-21T08:00:00Z",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"name":"Default","min_expense_amount_idr":10000,"min_expense_amount_formatted":"Rp 10.000",` +
+				`"max_expense_amount_idr":50000000,"max_expense_amount_formatted":"Rp 50.000.000",` +
+				`"approval_threshold_amount_idr":1000000,"approval_threshold_amount_formatted":"Rp 1.000.000",` +
+				`"created_at":"2025-09-21T08:00:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ou := mocks.NewOrganizationUsecase(s.T())
+			tt.mockFunc(ou)
+
+			oc := internalHttp.NewOrganizationController(s.log, s.validate, ou)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/organization", oc.Get)
+
+			req := httptest.NewRequest("GET", "/api/admin/organization", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *OrganizationControllerSuite) TestOrganizationController_Update() {
+	threshold := uint64(2000000)
+
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.OrganizationUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on parse body",
+			body:       map[string]interface{}{"approval_threshold_amount_idr": "abc"},
+			mockFunc:   func(a *mocks.OrganizationUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"approval_threshold_amount_idr": 0},
+			mockFunc:   func(a *mocks.OrganizationUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2013,"message":"approval_threshold_amount_idr must be greater than 0",` +
+				`"field":"approval_threshold_amount_idr","rule":"gt"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error invalid policy",
+			body: map[string]interface{}{"min_expense_amount_idr": 100000000},
+			mockFunc: func(a *mocks.OrganizationUsecase) {
+				a.On("Update", mock.Anything, mock.Anything).
+					Return(nil, model.ErrInvalidExpensePolicy)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1034,"message":"Minimum amount can't be greater than the maximum amount"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"approval_threshold_amount_idr": 2000000},
+			mockFunc: func(a *mocks.OrganizationUsecase) {
+				a.On("Update", mock.Anything, &model.UpdateOrganizationRequest{
+					OrgID:                      1,
+					UserRole:                   "admin",
+					ApprovalThresholdAmountIDR: &threshold,
+				}).Return(&model.OrganizationResponse{
+					ID:                               1,
+					Name:                             "Default",
+					MinExpenseAmountIDR:              10000,
+					MinExpenseAmountFormatted:        "Rp 10.000",
+					MaxExpenseAmountIDR:              50000000,
+					MaxExpenseAmountFormatted:        "Rp 50.000.000",
+					ApprovalThresholdAmountIDR:       2000000,
+					ApprovalThresholdAmountFormatted: "Rp 2.000.000",
+					CreatedAt:                        "2025-09-21T08:00:00Z",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"name":"Default","min_expense_amount_idr":10000,"min_expense_amount_formatted":"Rp 10.000",` +
+				`"max_expense_amount_idr":50000000,"max_expense_amount_formatted":"Rp 50.000.000",` +
+				`"approval_threshold_amount_idr":2000000,"approval_threshold_amount_formatted":"Rp 2.000.000",` +
+				`"created_at":"2025-09-21T08:00:00Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ou := mocks.NewOrganizationUsecase(s.T())
+			tt.mockFunc(ou)
+
+			oc := internalHttp.NewOrganizationController(s.log, s.validate, ou)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.PATCH("/api/admin/organization", oc.Update)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PATCH", "/api/admin/organization", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestOrganizationControllerSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationControllerSuite))
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PasswordController struct {
+	log             *zap.Logger
+	validate        *validator.Validate
+	passwordUsecase usecase.PasswordUsecase
+}
+
+func NewPasswordController(log *zap.Logger, validate *validator.Validate,
+	passwordUsecase usecase.PasswordUsecase) *PasswordController {
+	return &PasswordController{
+		log:             log,
+		validate:        validate,
+		passwordUsecase: passwordUsecase,
+	}
+}
+
+func (c *PasswordController) Forgot(ctx *gin.Context) {
+	request := new(model.ForgotPasswordRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = c.passwordUsecase.Forgot(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to request password reset", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("If the email is registered, a password reset link has been sent", http.StatusOK),
+	)
+}
+
+func (c *PasswordController) Reset(ctx *gin.Context) {
+	request := new(model.ResetPasswordRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	err = c.passwordUsecase.Reset(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to reset password", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Password has been reset", http.StatusOK),
+	)
+}
+
+func (c *PasswordController) Change(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.ChangePasswordRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.UserID = userID
+	request.OrgID = claims.OrgID
+	err = c.passwordUsecase.Change(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to change password", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Password has been changed, please login again", http.StatusOK),
+	)
+}
@@ -0,0 +1,223 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PasswordControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *PasswordControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *PasswordControllerSuite) TestPasswordController_Forgot() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(p *mocks.PasswordUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"email": "john"},
+			mockFunc:   func(p *mocks.PasswordUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":2001,"message":"email must be a valid email address","field":"email","rule":"email"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "unexpected error",
+			body: map[string]interface{}{"email": "john@mail.com"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Forgot", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"email": "john@mail.com"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Forgot", mock.Anything, &model.ForgotPasswordRequest{Email: "john@mail.com"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"message":"If the email is registered, a password reset link has been sent",` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPasswordUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPasswordController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.POST("/api/auth/password/forgot", pc.Forgot)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/auth/password/forgot", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PasswordControllerSuite) TestPasswordController_Reset() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(p *mocks.PasswordUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"token": "", "password": "123"},
+			mockFunc:   func(p *mocks.PasswordUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2000,"message":"token is required","field":"token","rule":"required"},` +
+				`{"code":2011,"message":"password must be at least 4 characters","field":"password","rule":"min"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error",
+			body: map[string]interface{}{"token": "reset-token", "password": "new-password"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Reset", mock.Anything, mock.Anything).Return(model.ErrInvalidResetToken)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1014,"message":"Invalid or expired password reset token"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"token": "reset-token", "password": "new-password"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Reset", mock.Anything, &model.ResetPasswordRequest{
+					Token:    "reset-token",
+					Password: "new-password",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Password has been reset","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPasswordUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPasswordController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.POST("/api/auth/password/reset", pc.Reset)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/auth/password/reset", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PasswordControllerSuite) TestPasswordController_Change() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(p *mocks.PasswordUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error same password",
+			body:       map[string]interface{}{"current_password": "password", "new_password": "password"},
+			mockFunc:   func(p *mocks.PasswordUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2008,"message":"new_password must be different from current_password","field":"new_password","rule":"nefield"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error",
+			body: map[string]interface{}{"current_password": "wrong", "new_password": "new-password"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Change", mock.Anything, mock.Anything).Return(model.ErrInvalidCurrentPassword)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes:    `{"errors":[{"code":1015,"message":"Current password is incorrect"}],"meta":{"http_status":422}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"current_password": "password", "new_password": "new-password"},
+			mockFunc: func(p *mocks.PasswordUsecase) {
+				p.On("Change", mock.Anything, &model.ChangePasswordRequest{
+					UserID:          1,
+					OrgID:           1,
+					CurrentPassword: "password",
+					NewPassword:     "new-password",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Password has been changed, please login again","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPasswordUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPasswordController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.PUT("/api/users/me/password", pc.Change)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/api/users/me/password", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPasswordControllerSuite(t *testing.T) {
+	suite.Run(t, new(PasswordControllerSuite))
+}
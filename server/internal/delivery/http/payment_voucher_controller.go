@@ -0,0 +1,63 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PaymentVoucherController struct {
+	log                   *zap.Logger
+	paymentVoucherUsecase usecase.PaymentVoucherUsecase
+}
+
+func NewPaymentVoucherController(log *zap.Logger, paymentVoucherUsecase usecase.PaymentVoucherUsecase) *PaymentVoucherController {
+	return &PaymentVoucherController{
+		log:                   log,
+		paymentVoucherUsecase: paymentVoucherUsecase,
+	}
+}
+
+func (c *PaymentVoucherController) Download(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.paymentVoucherUsecase.Download(ctx.Request.Context(), &model.GetExpenseRequest{
+		ID:       id,
+		UserID:   userID,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to download payment voucher", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, res.Name))
+	ctx.Data(http.StatusOK, res.ContentType, res.Content)
+}
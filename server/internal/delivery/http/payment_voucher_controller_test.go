@@ -0,0 +1,91 @@
+package http_test
+
+import (
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PaymentVoucherControllerSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *PaymentVoucherControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *PaymentVoucherControllerSuite) TestPaymentVoucherController_Download() {
+	request := &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 1, UserRole: "employee"}
+
+	s.Run("error on invalid id", func() {
+		rec := s.download(mocks.NewPaymentVoucherUsecase(s.T()), "abc")
+
+		s.Equal(http.StatusBadRequest, rec.Code)
+	})
+
+	s.Run("error on voucher not found", func() {
+		pu := mocks.NewPaymentVoucherUsecase(s.T())
+		pu.On("Download", mock.Anything, request).Return(nil, model.ErrPaymentVoucherNotFound)
+
+		rec := s.download(pu, "7")
+
+		s.Equal(http.StatusNotFound, rec.Code)
+		s.Equal(`{"errors":[{"code":1052,"message":"Payment voucher not found, the expense is not paid yet"}],"meta":{"http_status":404}}`,
+			strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("error on voucher not ready", func() {
+		pu := mocks.NewPaymentVoucherUsecase(s.T())
+		pu.On("Download", mock.Anything, request).Return(nil, model.ErrPaymentVoucherNotReady)
+
+		rec := s.download(pu, "7")
+
+		s.Equal(http.StatusConflict, rec.Code)
+		s.Equal(`{"errors":[{"code":1058,"message":"Payment voucher is not ready yet, try again later"}],"meta":{"http_status":409}}`,
+			strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("success", func() {
+		pu := mocks.NewPaymentVoucherUsecase(s.T())
+		pu.On("Download", mock.Anything, request).Return(&model.FileResponse{
+			Name:        "voucher-EXP-000000007.pdf",
+			ContentType: "application/pdf",
+			Content:     []byte("%PDF-1.4"),
+		}, nil)
+
+		rec := s.download(pu, "7")
+
+		s.Equal(http.StatusOK, rec.Code)
+		s.Equal("application/pdf", rec.Header().Get("Content-Type"))
+		s.Equal(`attachment; filename="voucher-EXP-000000007.pdf"`, rec.Header().Get("Content-Disposition"))
+		s.Equal("%PDF-1.4", rec.Body.String())
+	})
+}
+
+func (s *PaymentVoucherControllerSuite) download(pu *mocks.PaymentVoucherUsecase, id string) *httptest.ResponseRecorder {
+	pc := internalHttp.NewPaymentVoucherController(s.log, pu)
+
+	app := test.NewApi(s.log)
+	app.Use(test.NewAuthMiddleware(1, "employee"))
+	app.GET("/api/expenses/:id/voucher", pc.Download)
+
+	req := httptest.NewRequest("GET", "/api/expenses/"+id+"/voucher", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestPaymentVoucherControllerSuite(t *testing.T) {
+	suite.Run(t, new(PaymentVoucherControllerSuite))
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenController struct {
+	log                        *zap.Logger
+	validate                   *validator.Validate
+	personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase
+}
+
+func NewPersonalAccessTokenController(log *zap.Logger, validate *validator.Validate,
+	personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		log:                        log,
+		validate:                   validate,
+		personalAccessTokenUsecase: personalAccessTokenUsecase,
+	}
+}
+
+func (c *PersonalAccessTokenController) List(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.personalAccessTokenUsecase.List(ctx.Request.Context(), &model.ListPersonalAccessTokenRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list access tokens", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *PersonalAccessTokenController) Create(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.CreatePersonalAccessTokenRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.UserID = userID
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.personalAccessTokenUsecase.Create(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create access token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *PersonalAccessTokenController) Revoke(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.personalAccessTokenUsecase.Revoke(ctx.Request.Context(), &model.RevokePersonalAccessTokenRequest{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to revoke access token", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Access token revoked", http.StatusOK),
+	)
+}
@@ -0,0 +1,237 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *PersonalAccessTokenControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_List() {
+	tests := []struct {
+		name       string
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "unexpected error",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("List", mock.Anything, mock.Anything).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("List", mock.Anything, &model.ListPersonalAccessTokenRequest{
+					UserID: 1,
+				}).Return([]model.PersonalAccessTokenResponse{
+					{
+						ID:          2,
+						Name:        "finance script",
+						TokenPrefix: "ems_abcd",
+						Scopes:      []string{"expenses:read"},
+						ExpiresAt:   "2025-10-17T08:00:00Z",
+						CreatedAt:   "2025-09-17T08:00:00Z",
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":2,"name":"finance script","token_prefix":"ems_abcd",` +
+				`"scopes":["expenses:read"],"expires_at":"2025-10-17T08:00:00Z","last_used_at":null,` +
+				`"created_at":"2025-09-17T08:00:00Z"}],"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.GET("/api/users/me/tokens", pc.List)
+
+			req := httptest.NewRequest("GET", "/api/users/me/tokens", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_Create() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"name": "script", "scopes": []string{"admin"}, "expires_in_days": 30},
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2006,"message":"scopes[0] must be one of: expenses:read, expenses:write, scim","field":"scopes[0]","rule":"oneof"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "unexpected error",
+			body: map[string]interface{}{"name": "script", "scopes": []string{"expenses:read"}, "expires_in_days": 30},
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Create", mock.Anything, mock.Anything).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"name": "script", "scopes": []string{"expenses:read"}, "expires_in_days": 30},
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Create", mock.Anything, &model.CreatePersonalAccessTokenRequest{
+					UserID:        1,
+					OrgID:         1,
+					UserRole:      "employee",
+					Name:          "script",
+					Scopes:        []string{"expenses:read"},
+					ExpiresInDays: 30,
+				}).Return(&model.CreatePersonalAccessTokenResponse{
+					PersonalAccessTokenResponse: model.PersonalAccessTokenResponse{
+						ID:          2,
+						Name:        "script",
+						TokenPrefix: "ems_abcd",
+						Scopes:      []string{"expenses:read"},
+						ExpiresAt:   "2025-10-17T08:00:00Z",
+						CreatedAt:   "2025-09-17T08:00:00Z",
+					},
+					Token: "ems_abcdef",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"id":2,"name":"script","token_prefix":"ems_abcd","scopes":["expenses:read"],` +
+				`"expires_at":"2025-10-17T08:00:00Z","last_used_at":null,"created_at":"2025-09-17T08:00:00Z",` +
+				`"token":"ems_abcdef"},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.POST("/api/users/me/tokens", pc.Create)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/users/me/tokens", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PersonalAccessTokenControllerSuite) TestPersonalAccessTokenController_Revoke() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(p *mocks.PersonalAccessTokenUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "invalid id",
+			id:         "abc",
+			mockFunc:   func(p *mocks.PersonalAccessTokenUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error",
+			id:   "2",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Revoke", mock.Anything, mock.Anything).Return(model.ErrAccessTokenNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1016,"message":"Access token not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(p *mocks.PersonalAccessTokenUsecase) {
+				p.On("Revoke", mock.Anything, &model.RevokePersonalAccessTokenRequest{
+					ID:     2,
+					UserID: 1,
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Access token revoked","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pu := mocks.NewPersonalAccessTokenUsecase(s.T())
+			tt.mockFunc(pu)
+
+			pc := internalHttp.NewPersonalAccessTokenController(s.log, s.validate, pu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.DELETE("/api/users/me/tokens/:id", pc.Revoke)
+
+			req := httptest.NewRequest("DELETE", "/api/users/me/tokens/"+tt.id, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestPersonalAccessTokenControllerSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenControllerSuite))
+}
@@ -2,7 +2,9 @@ package route
 
 import (
 	"embed"
+	"expense-management-system/internal/auth"
 	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/delivery/http/middleware"
 	"io/fs"
 	"net/http"
 
@@ -17,14 +19,34 @@ var swaggerJSON []byte
 var swaggerUI embed.FS
 
 type RouteConfig struct {
-	App                *gin.Engine
-	CommonMiddlewares  []gin.HandlerFunc
-	AuthMiddlware      gin.HandlerFunc
-	AuthController     *internalHttp.AuthController
-	UserController     *internalHttp.UserController
-	ExpenseController  *internalHttp.ExpenseController
-	ApprovalController *internalHttp.ApprovalController
-	CorsAllowOrigins   []string
+	App                           *gin.Engine
+	CommonMiddlewares             []gin.HandlerFunc
+	AuthMiddlware                 gin.HandlerFunc
+	TwoFactorMiddleware           gin.HandlerFunc
+	SessionMiddleware             gin.HandlerFunc
+	IdempotencyMiddleware         gin.HandlerFunc
+	LimiterMiddleware             func(policy string) gin.HandlerFunc
+	ScopeMiddleware               func(scope string) gin.HandlerFunc
+	SCIMErrorMiddleware           gin.HandlerFunc
+	AuthController                *internalHttp.AuthController
+	UserController                *internalHttp.UserController
+	TwoFactorController           *internalHttp.TwoFactorController
+	PasswordController            *internalHttp.PasswordController
+	SSOController                 *internalHttp.SSOController
+	PersonalAccessTokenController *internalHttp.PersonalAccessTokenController
+	ExpenseController             *internalHttp.ExpenseController
+	ExpenseExportController       *internalHttp.ExpenseExportController
+	PaymentVoucherController      *internalHttp.PaymentVoucherController
+	ExpenseCommentController      *internalHttp.ExpenseCommentController
+	ApprovalController            *internalHttp.ApprovalController
+	SCIMController                *internalHttp.SCIMController
+	DepartmentController          *internalHttp.DepartmentController
+	JournalController             *internalHttp.JournalController
+	LedgerController              *internalHttp.LedgerController
+	AnalyticsController           *internalHttp.AnalyticsController
+	StatementController           *internalHttp.StatementController
+	OrganizationController        *internalHttp.OrganizationController
+	CorsAllowOrigins              []string
 }
 
 func (c *RouteConfig) Setup() {
@@ -44,19 +66,133 @@ func (c *RouteConfig) Setup() {
 
 	api := c.App.Group("/api")
 
+	// every request is limited per client ip by the global policy of the common middlewares, on top of that
+	// requests without auth are limited per client ip by their policy, the rest per user after the auth middleware
+	apiLimit := c.LimiterMiddleware(middleware.RateLimitPolicyAPI)
+	publicLimit := c.LimiterMiddleware(middleware.RateLimitPolicyPublic)
+
 	// without auth
-	api.POST("/auth/login", c.AuthController.Login)
-	api.POST("/users", c.UserController.Register)
-
-	// with auth
-	api.POST("/auth/logout", c.AuthMiddlware, c.AuthController.Logout)
-	api.GET("/users/me", c.AuthMiddlware, c.UserController.Me)
-
-	api.POST("/expenses", c.AuthMiddlware, c.ExpenseController.Create)
-	api.GET("/expenses", c.AuthMiddlware, c.ExpenseController.List)
-	api.GET("/expenses/:id", c.AuthMiddlware, c.ExpenseController.Get)
-	api.PUT("/expenses/:id/approve", c.AuthMiddlware, c.ApprovalController.Approve)
-	api.PUT("/expenses/:id/reject", c.AuthMiddlware, c.ApprovalController.Reject)
+	api.POST("/auth/login", c.LimiterMiddleware(middleware.RateLimitPolicyLogin), c.AuthController.Login)
+	api.POST("/auth/2fa/verify", publicLimit, c.AuthController.VerifyTwoFactor)
+	api.GET("/auth/sso/authorize", publicLimit, c.SSOController.Authorize)
+	api.POST("/auth/sso/callback", publicLimit, c.SSOController.Callback)
+	api.POST("/auth/password/forgot", publicLimit, c.PasswordController.Forgot)
+	api.POST("/auth/password/reset", publicLimit, c.PasswordController.Reset)
+	api.POST("/users", c.LimiterMiddleware(middleware.RateLimitPolicyRegister), c.UserController.Register)
+
+	// with auth, accepts login sessions and api keys
+	api.GET("/users/me", c.AuthMiddlware, apiLimit, c.UserController.Me)
+	api.GET("/users/me/summary", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.StatementController.Summary)
+	api.GET("/users/me/statements/:period", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.StatementController.Download)
+
+	api.POST("/expenses", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesWrite),
+		c.IdempotencyMiddleware, c.ExpenseController.Create)
+	api.GET("/expenses", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead), c.ExpenseController.List)
+	api.GET("/expenses/export", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.ExpenseExportController.Export)
+	api.GET("/expenses/exports/:id", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.ExpenseExportController.Get)
+	api.GET("/expenses/exports/:id/download", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.ExpenseExportController.Download)
+	api.GET("/expenses/search", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.ExpenseController.Search)
+	api.GET("/expenses/:id", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead), c.ExpenseController.Get)
+	api.PUT("/expenses/:id", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesWrite),
+		c.ExpenseController.Update)
+	api.GET("/expenses/:id/voucher", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.PaymentVoucherController.Download)
+	api.GET("/expenses/:id/comments", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesRead),
+		c.ExpenseCommentController.List)
+	api.POST("/expenses/:id/comments", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesWrite),
+		c.IdempotencyMiddleware, c.ExpenseCommentController.Create)
+	api.PUT("/expenses/:id/comments/:comment_id", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesWrite),
+		c.ExpenseCommentController.Update)
+	api.DELETE("/expenses/:id/comments/:comment_id", c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeExpensesWrite),
+		c.ExpenseCommentController.Delete)
+
+	// with auth, login sessions only
+	api.POST("/auth/logout", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.AuthController.Logout)
+	api.PUT("/users/me/password", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.PasswordController.Change)
+	api.PUT("/users/me/locale", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.UserController.UpdateLocale)
+	api.GET("/users/me/2fa", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorController.Status)
+	api.POST("/users/me/2fa", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorController.Enroll)
+	api.POST("/users/me/2fa/activate", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorController.Activate)
+	api.DELETE("/users/me/2fa", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorController.Disable)
+	api.GET("/users/me/tokens", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.PersonalAccessTokenController.List)
+	api.POST("/users/me/tokens", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.PersonalAccessTokenController.Create)
+	api.DELETE("/users/me/tokens/:id", c.AuthMiddlware, apiLimit, c.SessionMiddleware,
+		c.PersonalAccessTokenController.Revoke)
+
+	// with auth and two-factor for managers and above
+	api.PUT("/expenses/:id/approve", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.IdempotencyMiddleware, c.ApprovalController.Approve)
+	api.PUT("/expenses/:id/reject", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.IdempotencyMiddleware, c.ApprovalController.Reject)
+	api.PUT("/expenses/:id/request-changes", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.IdempotencyMiddleware, c.ApprovalController.RequestChanges)
+	api.GET("/analytics/summary", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.AnalyticsController.Summary)
+	api.GET("/analytics/breakdown", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.AnalyticsController.Breakdown)
+
+	// admin only
+	api.POST("/admin/users/:id/unlock", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.AuthController.Unlock)
+	api.GET("/admin/users", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware, c.UserController.List)
+	api.PATCH("/admin/users/:id", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.UserController.Update)
+	api.POST("/admin/users/:id/deactivate", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.UserController.Deactivate)
+	api.POST("/admin/users/:id/reactivate", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.UserController.Reactivate)
+	api.GET("/admin/organization", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.OrganizationController.Get)
+	api.PATCH("/admin/organization", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.OrganizationController.Update)
+	api.GET("/admin/departments", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.DepartmentController.List)
+	api.POST("/admin/departments", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.DepartmentController.Create)
+	api.PATCH("/admin/departments/:id", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.DepartmentController.Update)
+	api.GET("/admin/departments/:id/budgets", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.DepartmentController.ListBudgets)
+	api.POST("/admin/departments/:id/budgets", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.DepartmentController.CreateBudget)
+	api.GET("/admin/gl-account-mappings", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.ListMappings)
+	api.PUT("/admin/gl-account-mappings", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.UpsertMapping)
+	api.DELETE("/admin/gl-account-mappings/:id", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.DeleteMapping)
+	api.GET("/admin/journal-batches", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.ListBatches)
+	api.POST("/admin/journal-batches", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.CreateBatch)
+	api.GET("/admin/journal-batches/:id", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.GetBatch)
+	api.GET("/admin/journal-batches/:id/download", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.JournalController.DownloadBatch)
+	api.GET("/admin/ledger/balances", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.LedgerController.ListBalances)
+	api.GET("/admin/ledger/entries", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.LedgerController.ListEntries)
+	api.POST("/admin/ledger/entries/:id/reverse", c.AuthMiddlware, apiLimit, c.SessionMiddleware, c.TwoFactorMiddleware,
+		c.LedgerController.Reverse)
+
+	// scim provisioning, api keys with the scim scope
+	scim := c.App.Group("/scim/v2", c.SCIMErrorMiddleware, c.AuthMiddlware, apiLimit, c.ScopeMiddleware(auth.ScopeSCIM))
+	scim.GET("/Users", c.SCIMController.ListUsers)
+	scim.POST("/Users", c.SCIMController.CreateUser)
+	scim.GET("/Users/:id", c.SCIMController.GetUser)
+	scim.PUT("/Users/:id", c.SCIMController.ReplaceUser)
+	scim.PATCH("/Users/:id", c.SCIMController.PatchUser)
+	scim.DELETE("/Users/:id", c.SCIMController.DeleteUser)
+	scim.GET("/Groups", c.SCIMController.ListGroups)
+	scim.GET("/Groups/:id", c.SCIMController.GetGroup)
+	scim.PATCH("/Groups/:id", c.SCIMController.PatchGroup)
 }
 
 func SetupSwagger(app *gin.Engine) {
@@ -0,0 +1,283 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// SCIMController serves the scim 2.0 provisioning api, responses are plain scim
+// resources instead of the usual data/meta envelope
+type SCIMController struct {
+	log         *zap.Logger
+	validate    *validator.Validate
+	scimUsecase usecase.SCIMUsecase
+}
+
+func NewSCIMController(log *zap.Logger, validate *validator.Validate, scimUsecase usecase.SCIMUsecase) *SCIMController {
+	return &SCIMController{
+		log:         log,
+		validate:    validate,
+		scimUsecase: scimUsecase,
+	}
+}
+
+func (c *SCIMController) ListUsers(ctx *gin.Context) {
+	request, ok := c.listRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.scimUsecase.ListUsers(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list scim users", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) GetUser(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.scimUsecase.GetUser(ctx.Request.Context(), &model.SCIMResourceRequest{
+		ID:       ctx.Param("id"),
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get scim user", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) CreateUser(ctx *gin.Context) {
+	request, ok := c.userRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.scimUsecase.CreateUser(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to create scim user", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusCreated, res)
+}
+
+func (c *SCIMController) ReplaceUser(ctx *gin.Context) {
+	request, ok := c.userRequest(ctx)
+	if !ok {
+		return
+	}
+
+	request.ID = ctx.Param("id")
+	res, err := c.scimUsecase.ReplaceUser(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to replace scim user", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) PatchUser(ctx *gin.Context) {
+	request, ok := c.patchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.scimUsecase.PatchUser(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to patch scim user", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) DeleteUser(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	err = c.scimUsecase.DeleteUser(ctx.Request.Context(), &model.SCIMResourceRequest{
+		ID:       ctx.Param("id"),
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to delete scim user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *SCIMController) ListGroups(ctx *gin.Context) {
+	request, ok := c.listRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.scimUsecase.ListGroups(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list scim groups", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) GetGroup(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	res, err := c.scimUsecase.GetGroup(ctx.Request.Context(), &model.SCIMResourceRequest{
+		ID:       ctx.Param("id"),
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get scim group", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) PatchGroup(ctx *gin.Context) {
+	request, ok := c.patchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.scimUsecase.PatchGroup(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to patch scim group", err)
+		ctx.Error(err)
+		return
+	}
+
+	c.render(ctx, http.StatusOK, res)
+}
+
+func (c *SCIMController) listRequest(ctx *gin.Context) (*model.ListSCIMRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	startIndex, err := strconv.Atoi(ctx.DefaultQuery("startIndex", "1"))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse start index", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	count, err := strconv.Atoi(ctx.DefaultQuery("count", strconv.Itoa(model.SCIMDefaultCount)))
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse count", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	return &model.ListSCIMRequest{
+		OrgID:      claims.OrgID,
+		UserRole:   claims.Role,
+		Filter:     ctx.Query("filter"),
+		StartIndex: startIndex,
+		Count:      count,
+	}, true
+}
+
+func (c *SCIMController) userRequest(ctx *gin.Context) (*model.SCIMUserRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	request := new(model.SCIMUserRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return nil, false
+	}
+
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	return request, true
+}
+
+func (c *SCIMController) patchRequest(ctx *gin.Context) (*model.SCIMPatchRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	request := new(model.SCIMPatchRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return nil, false
+	}
+
+	request.ID = ctx.Param("id")
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	return request, true
+}
+
+func (c *SCIMController) render(ctx *gin.Context, status int, res interface{}) {
+	ctx.Header("Content-Type", model.SCIMContentType)
+	ctx.JSON(status, res)
+}
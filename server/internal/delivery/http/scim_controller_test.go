@@ -0,0 +1,362 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SCIMControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *SCIMControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *SCIMControllerSuite) newApp(su *mocks.SCIMUsecase) *gin.Engine {
+	sc := internalHttp.NewSCIMController(s.log, s.validate, su)
+
+	app := test.NewApi(s.log)
+	scim := app.Group("/scim/v2", middleware.NewSCIMErrorMiddleware(s.log), test.NewAPIKeyMiddleware(1, "admin", "scim"))
+	scim.GET("/Users", sc.ListUsers)
+	scim.POST("/Users", sc.CreateUser)
+	scim.PATCH("/Users/:id", sc.PatchUser)
+	scim.DELETE("/Users/:id", sc.DeleteUser)
+	scim.GET("/Groups/:id", sc.GetGroup)
+
+	return app
+}
+
+func (s *SCIMControllerSuite) TestSCIMController_ListUsers() {
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(su *mocks.SCIMUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid count",
+			query:      "?count=abc",
+			mockFunc:   func(su *mocks.SCIMUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"400",` +
+				`"scimType":"invalidValue","detail":"Bad request"}`,
+		},
+		{
+			name:  "error invalid filter",
+			query: "?filter=" + `userName%20co%20"john"`,
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("ListUsers", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidSCIMFilter)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"400",` +
+				`"scimType":"invalidFilter","detail":"Invalid or unsupported SCIM filter"}`,
+		},
+		{
+			name:  "unexpected error",
+			query: "",
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("ListUsers", mock.Anything, mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"500",` +
+				`"detail":"Internal server error"}`,
+		},
+		{
+			name:  "success",
+			query: "?filter=" + `userName%20eq%20"john@mail.com"&startIndex=2&count=5`,
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("ListUsers", mock.Anything, &model.ListSCIMRequest{
+					OrgID:      1,
+					UserRole:   "admin",
+					Filter:     `userName eq "john@mail.com"`,
+					StartIndex: 2,
+					Count:      5,
+				}).Return(&model.SCIMListResponse{
+					Schemas:      []string{model.SCIMSchemaListResponse},
+					TotalResults: 0,
+					StartIndex:   2,
+					ItemsPerPage: 0,
+					Resources:    []model.SCIMUserResponse{},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:ListResponse"],"totalResults":0,` +
+				`"startIndex":2,"itemsPerPage":0,"Resources":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSCIMUsecase(s.T())
+			tt.mockFunc(su)
+
+			req := httptest.NewRequest("GET", "/scim/v2/Users"+tt.query, nil)
+
+			rec := httptest.NewRecorder()
+			s.newApp(su).ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(model.SCIMContentType, rec.Header().Get("Content-Type"))
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *SCIMControllerSuite) TestSCIMController_CreateUser() {
+	tests := []struct {
+		name       string
+		body       map[string]interface{}
+		mockFunc   func(su *mocks.SCIMUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"userName": "john"},
+			mockFunc:   func(su *mocks.SCIMUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"400",` +
+				`"scimType":"invalidValue","detail":"userName must be a valid email address"}`,
+		},
+		{
+			name: "error user already exist",
+			body: map[string]interface{}{"userName": "john@mail.com"},
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("CreateUser", mock.Anything, mock.Anything).Return(nil, model.ErrUserAlreadyExist)
+			},
+			wantStatus: http.StatusConflict,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"409",` +
+				`"scimType":"uniqueness","detail":"User with the same email or external id already exist"}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{
+				"schemas":  []string{model.SCIMSchemaUser},
+				"userName": "john@mail.com",
+				"name":     map[string]string{"givenName": "John", "familyName": "Doe"},
+			},
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("CreateUser", mock.Anything, &model.SCIMUserRequest{
+					OrgID:    1,
+					UserRole: "admin",
+					UserName: "john@mail.com",
+					Name:     &model.SCIMName{GivenName: "John", FamilyName: "Doe"},
+				}).Return(&model.SCIMUserResponse{
+					Schemas:     []string{model.SCIMSchemaUser},
+					ID:          "1",
+					UserName:    "john@mail.com",
+					Name:        model.SCIMName{Formatted: "John Doe", GivenName: "John", FamilyName: "Doe"},
+					DisplayName: "John Doe",
+					Emails:      []model.SCIMEmail{{Value: "john@mail.com", Type: "work", Primary: true}},
+					Active:      true,
+					Groups:      []model.SCIMMember{{Value: "role-employee", Display: "employee"}},
+					Meta:        model.SCIMMeta{ResourceType: "User", Location: "/scim/v2/Users/1"},
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"id":"1","userName":"john@mail.com",` +
+				`"name":{"formatted":"John Doe","givenName":"John","familyName":"Doe"},"displayName":"John Doe",` +
+				`"emails":[{"value":"john@mail.com","type":"work","primary":true}],"active":true,` +
+				`"groups":[{"value":"role-employee","display":"employee"}],` +
+				`"meta":{"resourceType":"User","location":"/scim/v2/Users/1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSCIMUsecase(s.T())
+			tt.mockFunc(su)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/scim/v2/Users", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", model.SCIMContentType)
+
+			rec := httptest.NewRecorder()
+			s.newApp(su).ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *SCIMControllerSuite) TestSCIMController_PatchUser() {
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func(su *mocks.SCIMUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[]}`,
+			mockFunc:   func(su *mocks.SCIMUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"400",` +
+				`"scimType":"invalidValue","detail":"Operations must be at least 1 item"}`,
+		},
+		{
+			name: "error user not found",
+			body: `{"Operations":[{"op":"replace","path":"active","value":false}]}`,
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("PatchUser", mock.Anything, mock.Anything).Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"404",` +
+				`"detail":"User not found"}`,
+		},
+		{
+			name: "success",
+			body: `{"Operations":[{"op":"replace","path":"active","value":false}]}`,
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("PatchUser", mock.Anything, &model.SCIMPatchRequest{
+					OrgID:    1,
+					ID:       "1",
+					UserRole: "admin",
+					Operations: []model.SCIMPatchOperation{
+						{Op: "replace", Path: "active", Value: json.RawMessage(`false`)},
+					},
+				}).Return(&model.SCIMUserResponse{ID: "1", Active: false}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"schemas":null,"id":"1","userName":"","name":{},"displayName":"","emails":null,` +
+				`"active":false,"groups":null,"meta":{"resourceType":"","location":""}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSCIMUsecase(s.T())
+			tt.mockFunc(su)
+
+			req := httptest.NewRequest("PATCH", "/scim/v2/Users/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", model.SCIMContentType)
+
+			rec := httptest.NewRecorder()
+			s.newApp(su).ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *SCIMControllerSuite) TestSCIMController_DeleteUser() {
+	tests := []struct {
+		name       string
+		mockFunc   func(su *mocks.SCIMUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error forbidden",
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("DeleteUser", mock.Anything, mock.Anything).Return(model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"403",` +
+				`"detail":"Forbidden"}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("DeleteUser", mock.Anything, &model.SCIMResourceRequest{OrgID: 1, ID: "1", UserRole: "admin"}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+			wantRes:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSCIMUsecase(s.T())
+			tt.mockFunc(su)
+
+			req := httptest.NewRequest("DELETE", "/scim/v2/Users/1", nil)
+
+			rec := httptest.NewRecorder()
+			s.newApp(su).ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *SCIMControllerSuite) TestSCIMController_GetGroup() {
+	tests := []struct {
+		name       string
+		mockFunc   func(su *mocks.SCIMUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error group not found",
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("GetGroup", mock.Anything, mock.Anything).Return(nil, model.ErrGroupNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"404",` +
+				`"detail":"Group not found"}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(su *mocks.SCIMUsecase) {
+				su.On("GetGroup", mock.Anything, &model.SCIMResourceRequest{OrgID: 1, ID: "role-manager", UserRole: "admin"}).
+					Return(&model.SCIMGroupResponse{
+						Schemas:     []string{model.SCIMSchemaGroup},
+						ID:          "role-manager",
+						DisplayName: "manager",
+						Members:     []model.SCIMMember{{Value: "2", Display: "Jane Doe"}},
+						Meta:        model.SCIMMeta{ResourceType: "Group", Location: "/scim/v2/Groups/role-manager"},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"id":"role-manager",` +
+				`"displayName":"manager","members":[{"value":"2","display":"Jane Doe"}],` +
+				`"meta":{"resourceType":"Group","location":"/scim/v2/Groups/role-manager"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSCIMUsecase(s.T())
+			tt.mockFunc(su)
+
+			req := httptest.NewRequest("GET", "/scim/v2/Groups/role-manager", nil)
+
+			rec := httptest.NewRecorder()
+			s.newApp(su).ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestSCIMControllerSuite(t *testing.T) {
+	suite.Run(t, new(SCIMControllerSuite))
+}
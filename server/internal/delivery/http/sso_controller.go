@@ -0,0 +1,94 @@
+package http
+
+import (
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// the state cookie is only sent back to the sso endpoints
+const (
+	ssoStateCookie     = "sso_state"
+	ssoStateCookiePath = "/api/auth/sso"
+)
+
+type SSOController struct {
+	log        *zap.Logger
+	validate   *validator.Validate
+	ssoUsecase usecase.SSOUsecase
+}
+
+func NewSSOController(log *zap.Logger, validate *validator.Validate,
+	ssoUsecase usecase.SSOUsecase) *SSOController {
+	return &SSOController{
+		log:        log,
+		validate:   validate,
+		ssoUsecase: ssoUsecase,
+	}
+}
+
+func (c *SSOController) Authorize(ctx *gin.Context) {
+	res, err := c.ssoUsecase.Authorize(ctx.Request.Context())
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to start sso login", err)
+		ctx.Error(err)
+		return
+	}
+
+	setSSOStateCookie(ctx, res.State, int(usecase.SSOStateTTL.Seconds()))
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *SSOController) Callback(ctx *gin.Context) {
+	request := new(model.SSOCallbackRequest)
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// a missing cookie leaves it empty, the usecase refuses the state then
+	request.StateCookie, _ = ctx.Cookie(ssoStateCookie)
+	setSSOStateCookie(ctx, "", -1)
+
+	res, err := c.ssoUsecase.Callback(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to finish sso login", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+// setSSOStateCookie sets the cookie that ties a login to the browser which started it,
+// a negative max age deletes it
+func setSSOStateCookie(ctx *gin.Context, state string, maxAge int) {
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    state,
+		Path:     ssoStateCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SSOControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *SSOControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *SSOControllerSuite) TestSSOController_Authorize() {
+	tests := []struct {
+		name       string
+		mockFunc   func(su *mocks.SSOUsecase)
+		wantStatus int
+		wantRes    string
+		wantCookie string
+	}{
+		{
+			name: "unexpected error",
+			mockFunc: func(su *mocks.SSOUsecase) {
+				su.On("Authorize", mock.Anything).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(su *mocks.SSOUsecase) {
+				su.On("Authorize", mock.Anything).Return(&model.SSOAuthorizeResponse{
+					AuthorizationURL: "https://idp.example.com/authorize?state=abc",
+					State:            "abc",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"authorization_url":"https://idp.example.com/authorize?state=abc"},` +
+				`"meta":{"http_status":200}}`,
+			wantCookie: "sso_state=abc; Path=/api/auth/sso; Max-Age=600; HttpOnly; Secure; SameSite=Lax",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSSOUsecase(s.T())
+			tt.mockFunc(su)
+
+			sc := internalHttp.NewSSOController(s.log, s.validate, su)
+
+			app := test.NewApi(s.log)
+			app.GET("/api/auth/sso/authorize", sc.Authorize)
+
+			req := httptest.NewRequest("GET", "/api/auth/sso/authorize", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+			s.Equal(tt.wantCookie, rec.Header().Get("Set-Cookie"))
+		})
+	}
+}
+
+func (s *SSOControllerSuite) TestSSOController_Callback() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(su *mocks.SSOUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"code": "code-1"},
+			mockFunc:   func(su *mocks.SSOUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":2000,"message":"state is required","field":"state","rule":"required"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error",
+			body: map[string]interface{}{"code": "code-1", "state": "state-1"},
+			mockFunc: func(su *mocks.SSOUsecase) {
+				su.On("Callback", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidSSOState)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1019,"message":"Invalid or expired single sign-on state"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"code": "code-1", "state": "state-1"},
+			mockFunc: func(su *mocks.SSOUsecase) {
+				su.On("Callback", mock.Anything, &model.SSOCallbackRequest{
+					Code:        "code-1",
+					State:       "state-1",
+					StateCookie: "state-1",
+				}).Return(&model.LoginResponse{AccessToken: "access-token"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"data":{"access_token":"access-token"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewSSOUsecase(s.T())
+			tt.mockFunc(su)
+
+			sc := internalHttp.NewSSOController(s.log, s.validate, su)
+
+			app := test.NewApi(s.log)
+			app.POST("/api/auth/sso/callback", sc.Callback)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/auth/sso/callback", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "sso_state", Value: "state-1"})
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *SSOControllerSuite) TestSSOController_CallbackClearsStateCookie() {
+	su := mocks.NewSSOUsecase(s.T())
+	su.On("Callback", mock.Anything, &model.SSOCallbackRequest{Code: "code-1", State: "state-1"}).
+		Return(nil, model.ErrInvalidSSOState)
+
+	sc := internalHttp.NewSSOController(s.log, s.validate, su)
+
+	app := test.NewApi(s.log)
+	app.POST("/api/auth/sso/callback", sc.Callback)
+
+	req := httptest.NewRequest("POST", "/api/auth/sso/callback", strings.NewReader(`{"code":"code-1","state":"state-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal("sso_state=; Path=/api/auth/sso; Max-Age=0; HttpOnly; Secure; SameSite=Lax", rec.Header().Get("Set-Cookie"))
+}
+
+func TestSSOControllerSuite(t *testing.T) {
+	suite.Run(t, new(SSOControllerSuite))
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	summaryMonthLayout   = "2006-01"
+	summaryDefaultMonths = 6  // months up to the current one when from isn't set
+	summaryMaxMonths     = 24 // longest range of a summary
+)
+
+type StatementController struct {
+	log              *zap.Logger
+	statementUsecase usecase.StatementUsecase
+}
+
+func NewStatementController(log *zap.Logger, statementUsecase usecase.StatementUsecase) *StatementController {
+	return &StatementController{
+		log:              log,
+		statementUsecase: statementUsecase,
+	}
+}
+
+func (c *StatementController) Summary(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := &model.UserSummaryRequest{UserID: userID, OrgID: claims.OrgID}
+	invalidFilters := parseSummaryRange(ctx, request, time.Now())
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	res, err := c.statementUsecase.Summary(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get user summary", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *StatementController) Download(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.statementUsecase.Download(ctx.Request.Context(), &model.GetStatementRequest{
+		UserID: userID,
+		OrgID:  claims.OrgID,
+		Period: ctx.Param("period"),
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to download statement", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, res.Name))
+	ctx.Data(http.StatusOK, res.ContentType, res.Content)
+}
+
+// parseSummaryRange reads the from and to months, by default the last months up to the current one,
+// returning the names of the filters that are invalid or make the range too long
+func parseSummaryRange(ctx *gin.Context, request *model.UserSummaryRequest, now time.Time) []string {
+	var invalid []string
+
+	now = now.UTC()
+	request.To = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if value := ctx.Query("to"); value != "" {
+		to, err := time.Parse(summaryMonthLayout, value)
+		if err != nil {
+			invalid = append(invalid, "to")
+		}
+		request.To = to
+	}
+
+	request.From = request.To.AddDate(0, 1-summaryDefaultMonths, 0)
+	if value := ctx.Query("from"); value != "" {
+		from, err := time.Parse(summaryMonthLayout, value)
+		if err != nil {
+			return append(invalid, "from")
+		}
+		request.From = from
+	}
+
+	if len(invalid) == 0 && (request.From.After(request.To) || !request.From.AddDate(0, summaryMaxMonths, 0).After(request.To)) {
+		invalid = append(invalid, "from")
+	}
+
+	return invalid
+}
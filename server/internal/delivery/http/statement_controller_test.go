@@ -0,0 +1,180 @@
+package http_test
+
+import (
+	"errors"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type StatementControllerSuite struct {
+	suite.Suite
+	log *zap.Logger
+}
+
+func (s *StatementControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+}
+
+func (s *StatementControllerSuite) TestStatementController_Summary() {
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	zero := model.SpendingTotalResponse{AmountFormatted: "Rp 0"}
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.StatementUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on invalid months",
+			query:      "?from=2025-13&to=september",
+			mockFunc:   func(a *mocks.StatementUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'to' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'from' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on from after to",
+			query:      "?from=2025-10&to=2025-09",
+			mockFunc:   func(a *mocks.StatementUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'from' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on range too long",
+			query:      "?from=2023-09&to=2025-09",
+			mockFunc:   func(a *mocks.StatementUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'from' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error on summary",
+			query: "",
+			mockFunc: func(a *mocks.StatementUsecase) {
+				a.On("Summary", mock.Anything, &model.UserSummaryRequest{
+					UserID: 1,
+					OrgID:  entity.DefaultOrganizationID,
+					From:   currentMonth.AddDate(0, -5, 0),
+					To:     currentMonth,
+				}).Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name:  "success",
+			query: "?from=2024-10&to=2025-09",
+			mockFunc: func(a *mocks.StatementUsecase) {
+				a.On("Summary", mock.Anything, &model.UserSummaryRequest{
+					UserID: 1,
+					OrgID:  entity.DefaultOrganizationID,
+					From:   time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+					To:     time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(&model.UserSummaryResponse{
+					Pending:        zero,
+					ApprovedUnpaid: model.SpendingTotalResponse{Count: 1, AmountIDR: 50000, AmountFormatted: "Rp 50.000"},
+					Paid:           zero,
+					Periods: []model.SpendingPeriodResponse{
+						{
+							Period:         "2025-09",
+							Pending:        zero,
+							ApprovedUnpaid: model.SpendingTotalResponse{Count: 1, AmountIDR: 50000, AmountFormatted: "Rp 50.000"},
+							Paid:           zero,
+						},
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"pending":{"count":0,"amount_idr":0,"amount_formatted":"Rp 0"},` +
+				`"approved_unpaid":{"count":1,"amount_idr":50000,"amount_formatted":"Rp 50.000"},` +
+				`"paid":{"count":0,"amount_idr":0,"amount_formatted":"Rp 0"},` +
+				`"periods":[{"period":"2025-09","pending":{"count":0,"amount_idr":0,"amount_formatted":"Rp 0"},` +
+				`"approved_unpaid":{"count":1,"amount_idr":50000,"amount_formatted":"Rp 50.000"},` +
+				`"paid":{"count":0,"amount_idr":0,"amount_formatted":"Rp 0"}}]},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			su := mocks.NewStatementUsecase(s.T())
+			tt.mockFunc(su)
+
+			sc := internalHttp.NewStatementController(s.log, su)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.GET("/api/users/me/summary", sc.Summary)
+
+			req := httptest.NewRequest("GET", "/api/users/me/summary"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *StatementControllerSuite) TestStatementController_Download() {
+	request := &model.GetStatementRequest{UserID: 1, OrgID: 1, Period: "2025-09"}
+
+	s.Run("error on invalid period", func() {
+		su := mocks.NewStatementUsecase(s.T())
+		su.On("Download", mock.Anything, &model.GetStatementRequest{UserID: 1, OrgID: 1, Period: "september"}).
+			Return(nil, model.ErrInvalidStatementPeriod)
+
+		rec := s.download(su, "september")
+
+		s.Equal(http.StatusBadRequest, rec.Code)
+		s.Equal(`{"errors":[{"code":1051,"message":"Statement period must be a month such as 2025-09"}],"meta":{"http_status":400}}`,
+			strings.TrimSpace(rec.Body.String()))
+	})
+
+	s.Run("success", func() {
+		su := mocks.NewStatementUsecase(s.T())
+		su.On("Download", mock.Anything, request).Return(&model.FileResponse{
+			Name:        "statement-2025-09.pdf",
+			ContentType: "application/pdf",
+			Content:     []byte("%PDF-1.4"),
+		}, nil)
+
+		rec := s.download(su, "2025-09")
+
+		s.Equal(http.StatusOK, rec.Code)
+		s.Equal("application/pdf", rec.Header().Get("Content-Type"))
+		s.Equal(`attachment; filename="statement-2025-09.pdf"`, rec.Header().Get("Content-Disposition"))
+		s.Equal("%PDF-1.4", rec.Body.String())
+	})
+}
+
+func (s *StatementControllerSuite) download(su *mocks.StatementUsecase, period string) *httptest.ResponseRecorder {
+	sc := internalHttp.NewStatementController(s.log, su)
+
+	app := test.NewApi(s.log)
+	app.Use(test.NewAuthMiddleware(1, "employee"))
+	app.GET("/api/users/me/statements/:period", sc.Download)
+
+	req := httptest.NewRequest("GET", "/api/users/me/statements/"+period, nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestStatementControllerSuite(t *testing.T) {
+	suite.Run(t, new(StatementControllerSuite))
+}
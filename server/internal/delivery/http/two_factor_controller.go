@@ -0,0 +1,177 @@
+package http
+
+import (
+	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+type TwoFactorController struct {
+	log              *zap.Logger
+	validate         *validator.Validate
+	twoFactorUsecase usecase.TwoFactorUsecase
+}
+
+func NewTwoFactorController(log *zap.Logger, validate *validator.Validate,
+	twoFactorUsecase usecase.TwoFactorUsecase) *TwoFactorController {
+	return &TwoFactorController{
+		log:              log,
+		validate:         validate,
+		twoFactorUsecase: twoFactorUsecase,
+	}
+}
+
+func (c *TwoFactorController) Status(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.twoFactorUsecase.Status(ctx.Request.Context(), &model.GetTwoFactorRequest{
+		UserID:   userID,
+		UserRole: claims.Role,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get two-factor status", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *TwoFactorController) Enroll(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	res, err := c.twoFactorUsecase.Enroll(ctx.Request.Context(), &model.EnrollTwoFactorRequest{
+		UserID: userID,
+		OrgID:  claims.OrgID,
+	})
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to enroll two-factor", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusCreated,
+		model.NewSuccessResponse(res, http.StatusCreated),
+	)
+}
+
+func (c *TwoFactorController) Activate(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.ActivateTwoFactorRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.UserID = userID
+	err = c.twoFactorUsecase.Activate(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to activate two-factor", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Two-factor authentication enabled", http.StatusOK),
+	)
+}
+
+func (c *TwoFactorController) Disable(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.DisableTwoFactorRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.UserID = userID
+	request.UserRole = claims.Role
+	err = c.twoFactorUsecase.Disable(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to disable two-factor", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("Two-factor authentication disabled", http.StatusOK),
+	)
+}
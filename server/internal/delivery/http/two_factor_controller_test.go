@@ -0,0 +1,268 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/config"
+	internalHttp "expense-management-system/internal/delivery/http"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type TwoFactorControllerSuite struct {
+	suite.Suite
+	log      *zap.Logger
+	validate *validator.Validate
+}
+
+func (s *TwoFactorControllerSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.validate = config.NewValidator()
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Status() {
+	tests := []struct {
+		name       string
+		mockFunc   func(t *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "unexpected error",
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Status", mock.Anything, mock.Anything).
+					Return(nil, errors.New("something error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantRes:    `{"errors":[{"code":100,"message":"Internal server error"}],"meta":{"http_status":500}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Status", mock.Anything, &model.GetTwoFactorRequest{
+					UserID:   1,
+					UserRole: "manager",
+				}).Return(&model.TwoFactorStatusResponse{
+					Enabled:                true,
+					Required:               true,
+					RecoveryCodesRemaining: 10,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"enabled":true,"required":true,"recovery_codes_remaining":10},` +
+				`"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.GET("/api/users/me/2fa", tc.Status)
+
+			req := httptest.NewRequest("GET", "/api/users/me/2fa", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Enroll() {
+	tests := []struct {
+		name       string
+		mockFunc   func(t *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "custom error",
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Enroll", mock.Anything, mock.Anything).
+					Return(nil, model.ErrTwoFactorAlreadyEnabled)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes: `{"errors":[{"code":1012,"message":"Two-factor authentication already enabled"}],` +
+				`"meta":{"http_status":422}}`,
+		},
+		{
+			name: "success",
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Enroll", mock.Anything, &model.EnrollTwoFactorRequest{
+					UserID: 1,
+					OrgID:  1,
+				}).Return(&model.TwoFactorEnrollResponse{
+					Secret:          "secret",
+					ProvisioningURI: "otpauth://totp/john",
+					RecoveryCodes:   []string{"abcde-12345"},
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantRes: `{"data":{"secret":"secret","provisioning_uri":"otpauth://totp/john",` +
+				`"recovery_codes":["abcde-12345"]},"meta":{"http_status":201}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.POST("/api/users/me/2fa", tc.Enroll)
+
+			req := httptest.NewRequest("POST", "/api/users/me/2fa", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Activate() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(t *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"code": "abc"},
+			mockFunc:   func(t *mocks.TwoFactorUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":2010,"message":"code must be exactly 6 characters","field":"code","rule":"len"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "custom error",
+			body: map[string]interface{}{"code": "123456"},
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Activate", mock.Anything, mock.Anything).Return(model.ErrInvalidTwoFactorCode)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantRes:    `{"errors":[{"code":1009,"message":"Invalid two-factor code"}],"meta":{"http_status":401}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"code": "123456"},
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Activate", mock.Anything, &model.ActivateTwoFactorRequest{
+					UserID: 1,
+					Code:   "123456",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Two-factor authentication enabled","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.POST("/api/users/me/2fa/activate", tc.Activate)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/users/me/2fa/activate", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *TwoFactorControllerSuite) TestTwoFactorController_Disable() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(t *mocks.TwoFactorUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "custom error",
+			body: map[string]interface{}{"code": "123456"},
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Disable", mock.Anything, mock.Anything).Return(model.ErrTwoFactorRequired)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes: `{"errors":[{"code":1011,"message":"Two-factor authentication is required"}],` +
+				`"meta":{"http_status":403}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"code": "123456"},
+			mockFunc: func(t *mocks.TwoFactorUsecase) {
+				t.On("Disable", mock.Anything, &model.DisableTwoFactorRequest{
+					UserID:   1,
+					UserRole: "employee",
+					Code:     "123456",
+				}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"Two-factor authentication disabled","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tu := mocks.NewTwoFactorUsecase(s.T())
+			tt.mockFunc(tu)
+
+			tc := internalHttp.NewTwoFactorController(s.log, s.validate, tu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "employee"))
+			app.DELETE("/api/users/me/2fa", tc.Disable)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("DELETE", "/api/users/me/2fa", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func TestTwoFactorControllerSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorControllerSuite))
+}
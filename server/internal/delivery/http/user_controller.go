@@ -2,6 +2,7 @@ package http
 
 import (
 	"expense-management-system/internal/delivery/http/middleware"
+	"expense-management-system/internal/entity"
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/usecase"
 	"net/http"
@@ -70,7 +71,8 @@ func (c *UserController) Me(ctx *gin.Context) {
 	}
 
 	res, err := c.userUsecase.FindByID(ctx.Request.Context(), &model.GetUserRequest{
-		ID: userID,
+		ID:    userID,
+		OrgID: claims.OrgID,
 	})
 	if err != nil {
 		LogWarn(ctx, c.log, "failed to get user", err)
@@ -83,3 +85,248 @@ func (c *UserController) Me(ctx *gin.Context) {
 		model.NewSuccessResponse(res, http.StatusOK),
 	)
 }
+
+func (c *UserController) UpdateLocale(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.UpdateLocaleRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.UserID = userID
+	request.OrgID = claims.OrgID
+	res, err := c.userUsecase.UpdateLocale(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update locale", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *UserController) List(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	var (
+		search         *string
+		role           *string
+		active         *bool
+		departmentID   *uint64
+		invalidFilters []string
+	)
+
+	if q := ctx.Query("search"); q != "" {
+		search = &q
+	}
+
+	if roleQuery := ctx.Query("role"); roleQuery != "" {
+		if _, err := entity.ParseUserRole(roleQuery); err != nil {
+			invalidFilters = append(invalidFilters, "role")
+		} else {
+			role = &roleQuery
+		}
+	}
+
+	if activeQuery := ctx.Query("active"); activeQuery != "" {
+		if value, err := strconv.ParseBool(activeQuery); err != nil {
+			invalidFilters = append(invalidFilters, "active")
+		} else {
+			active = &value
+		}
+	}
+
+	if departmentQuery := ctx.Query("department_id"); departmentQuery != "" {
+		if value, err := strconv.ParseUint(departmentQuery, 10, 64); err != nil {
+			invalidFilters = append(invalidFilters, "department_id")
+		} else {
+			departmentID = &value
+		}
+	}
+
+	if len(invalidFilters) > 0 {
+		ctx.Error(model.NewInvalidFilterError(invalidFilters...))
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	request := &model.ListUserRequest{
+		OrgID:        claims.OrgID,
+		UserRole:     claims.Role,
+		Search:       search,
+		Role:         role,
+		Active:       active,
+		DepartmentID: departmentID,
+		Limit:        limit,
+		Offset:       offset,
+	}
+	res, total, err := c.userUsecase.List(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to list users", err)
+		ctx.Error(err)
+		return
+	}
+
+	meta := model.MetaWithPage{
+		Limit:      limit,
+		Offset:     offset,
+		Total:      &total,
+		HTTPStatus: http.StatusOK,
+	}
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessListResponse(res, meta),
+	)
+}
+
+func (c *UserController) Update(ctx *gin.Context) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	request := new(model.UpdateUserRequest)
+	err = ctx.ShouldBindJSON(request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to parse request body", err)
+		ctx.Error(model.ErrBadRequest)
+		return
+	}
+
+	err = c.validate.Struct(request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	request.ID = id
+	request.OrgID = claims.OrgID
+	request.UserRole = claims.Role
+	res, err := c.userUsecase.Update(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to update user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessResponse(res, http.StatusOK),
+	)
+}
+
+func (c *UserController) Deactivate(ctx *gin.Context) {
+	request, ok := c.activeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.userUsecase.Deactivate(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to deactivate user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User deactivated", http.StatusOK),
+	)
+}
+
+func (c *UserController) Reactivate(ctx *gin.Context) {
+	request, ok := c.activeRequest(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.userUsecase.Reactivate(ctx.Request.Context(), request)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to reactivate user", err)
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		model.NewSuccessMessageResponse("User reactivated", http.StatusOK),
+	)
+}
+
+func (c *UserController) activeRequest(ctx *gin.Context) (*model.UpdateUserActiveRequest, bool) {
+	claims, err := middleware.GetJWTClaims(ctx)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to get jwt claims", err)
+		ctx.Error(model.ErrUnauthorized)
+		return nil, false
+	}
+
+	userID, err := strconv.ParseUint(claims.UserID, 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert user id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		LogWarn(ctx, c.log, "failed to convert id", err)
+		ctx.Error(model.ErrBadRequest)
+		return nil, false
+	}
+
+	return &model.UpdateUserActiveRequest{
+		ID:       id,
+		UserID:   userID,
+		OrgID:    claims.OrgID,
+		UserRole: claims.Role,
+	}, true
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"expense-management-system/internal/config"
 	internalHttp "expense-management-system/internal/delivery/http"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
@@ -28,7 +29,7 @@ type UserControllerSuite struct {
 
 func (s *UserControllerSuite) SetupTest() {
 	s.log = zap.NewNop()
-	s.validate = validator.New()
+	s.validate = config.NewValidator()
 }
 
 func (s *UserControllerSuite) TestUserController_Create() {
@@ -44,10 +45,10 @@ func (s *UserControllerSuite) TestUserController_Create() {
 			body:       nil,
 			mockFunc:   func(a *mocks.UserUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"Email failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Name failed on the 'required' rule"},` +
-				`{"code":2002,"message":"Password failed on the 'required' rule"},` +
-				`{"code":2003,"message":"Role failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"email is required","field":"email","rule":"required"},` +
+				`{"code":2000,"message":"name is required","field":"name","rule":"required"},` +
+				`{"code":2000,"message":"password is required","field":"password","rule":"required"},` +
+				`{"code":2000,"message":"role is required","field":"role","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "error on validate body",
@@ -59,10 +60,10 @@ func (s *UserControllerSuite) TestUserController_Create() {
 			},
 			mockFunc:   func(a *mocks.UserUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantRes: `{"errors":[{"code":2000,"message":"Email failed on the 'required' rule"},` +
-				`{"code":2001,"message":"Name failed on the 'required' rule"},` +
-				`{"code":2002,"message":"Password failed on the 'required' rule"},` +
-				`{"code":2003,"message":"Role failed on the 'required' rule"}],"meta":{"http_status":400}}`,
+			wantRes: `{"errors":[{"code":2000,"message":"email is required","field":"email","rule":"required"},` +
+				`{"code":2000,"message":"name is required","field":"name","rule":"required"},` +
+				`{"code":2000,"message":"password is required","field":"password","rule":"required"},` +
+				`{"code":2000,"message":"role is required","field":"role","rule":"required"}],"meta":{"http_status":400}}`,
 		},
 		{
 			name: "error on create",
@@ -94,12 +95,13 @@ func (s *UserControllerSuite) TestUserController_Create() {
 					Email:     "john@mail.com",
 					Name:      "John Doe",
 					Role:      "manager",
+					Active:    true,
 					CreatedAt: now.Format(time.RFC3339),
 				}, nil)
 			},
 			wantStatus: http.StatusCreated,
 			wantRes: `{"data":{"id":1,"email":"john@mail.com","name":"John Doe","role":"manager",` +
-				`"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":201}}`,
+				`"active":true,"department_id":null,"locale":null,"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":201}}`,
 		},
 	}
 
@@ -151,12 +153,13 @@ func (s *UserControllerSuite) TestUserController_Get() {
 					Email:     "john@mail.com",
 					Name:      "John Doe",
 					Role:      "manager",
+					Active:    true,
 					CreatedAt: now.Format(time.RFC3339),
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantRes: `{"data":{"id":1,"email":"john@mail.com","name":"John Doe","role":"manager",` +
-				`"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":200}}`,
+				`"active":true,"department_id":null,"locale":null,"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":200}}`,
 		},
 	}
 
@@ -183,6 +186,362 @@ func (s *UserControllerSuite) TestUserController_Get() {
 	}
 }
 
+func (s *UserControllerSuite) TestUserController_UpdateLocale() {
+	tests := []struct {
+		name       string
+		body       any
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error on parse body",
+			body:       map[string]interface{}{"locale": 1},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on validate body",
+			body:       map[string]interface{}{"locale": "fr"},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2006,"message":"locale must be one of: en, id","field":"locale","rule":"oneof"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on update",
+			body: map[string]interface{}{"locale": "id"},
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("UpdateLocale", mock.Anything, mock.Anything).
+					Return(nil, model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1001,"message":"User not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			body: map[string]interface{}{"locale": "id"},
+			mockFunc: func(a *mocks.UserUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
+				locale := "id"
+				a.On("UpdateLocale", mock.Anything, &model.UpdateLocaleRequest{UserID: 1, OrgID: 1, Locale: "id"}).
+					Return(&model.UserResponse{
+						ID:        1,
+						Email:     "john@mail.com",
+						Name:      "John Doe",
+						Role:      "manager",
+						Active:    true,
+						Locale:    &locale,
+						CreatedAt: now.Format(time.RFC3339),
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":1,"email":"john@mail.com","name":"John Doe","role":"manager",` +
+				`"active":true,"department_id":null,"locale":"id","created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "manager"))
+			app.PUT("/api/users/me/locale", uc.UpdateLocale)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PUT", "/api/users/me/locale", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_List() {
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid role",
+			query:      "?role=owner",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1036,"message":"Invalid value for the 'role' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error invalid active and department id",
+			query:      "?active=maybe&department_id=finance",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":1036,"message":"Invalid value for the 'active' filter"},` +
+				`{"code":1036,"message":"Invalid value for the 'department_id' filter"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:  "error on list",
+			query: "",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("List", mock.Anything, mock.Anything).
+					Return(nil, 0, model.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantRes:    `{"errors":[{"code":103,"message":"Forbidden"}],"meta":{"http_status":403}}`,
+		},
+		{
+			name:  "success",
+			query: "?search=john&role=manager&active=true&department_id=3&limit=5&offset=5",
+			mockFunc: func(a *mocks.UserUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
+				a.On("List", mock.Anything, mock.MatchedBy(func(req *model.ListUserRequest) bool {
+					return req.UserRole == "admin" && *req.Search == "john" && *req.Role == "manager" &&
+						*req.Active && *req.DepartmentID == 3 && req.Limit == 5 && req.Offset == 5
+				})).Return([]model.UserResponse{
+					{
+						ID:        1,
+						Email:     "john@mail.com",
+						Name:      "John Doe",
+						Role:      "manager",
+						Active:    true,
+						CreatedAt: now.Format(time.RFC3339),
+					},
+				}, 6, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":[{"id":1,"email":"john@mail.com","name":"John Doe","role":"manager",` +
+				`"active":true,"department_id":null,"locale":null,"created_at":"2025-10-27T13:07:31Z"}],` +
+				`"meta":{"limit":5,"offset":5,"total":6,"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.GET("/api/admin/users", uc.List)
+
+			req := httptest.NewRequest("GET", "/api/admin/users"+tt.query, nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Update() {
+	tests := []struct {
+		name       string
+		id         string
+		body       any
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			body:       map[string]interface{}{"name": "John Smith"},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name:       "error on validate body",
+			id:         "2",
+			body:       map[string]interface{}{"email": "john"},
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes: `{"errors":[{"code":2001,"message":"email must be a valid email address","field":"email","rule":"email"}],` +
+				`"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error on update",
+			id:   "2",
+			body: map[string]interface{}{"email": "smith@mail.com"},
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Update", mock.Anything, mock.Anything).
+					Return(nil, model.ErrEmailAlreadyExist)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":1000,"message":"Email already exist"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			body: map[string]interface{}{"name": "John Smith"},
+			mockFunc: func(a *mocks.UserUsecase) {
+				now := time.Date(2025, 10, 27, 13, 7, 31, 000, time.UTC)
+				a.On("Update", mock.Anything, mock.MatchedBy(func(req *model.UpdateUserRequest) bool {
+					return req.ID == 2 && req.UserRole == "admin" && *req.Name == "John Smith" && req.Email == nil
+				})).Return(&model.UserResponse{
+					ID:        2,
+					Email:     "john@mail.com",
+					Name:      "John Smith",
+					Role:      "manager",
+					Active:    true,
+					CreatedAt: now.Format(time.RFC3339),
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes: `{"data":{"id":2,"email":"john@mail.com","name":"John Smith","role":"manager",` +
+				`"active":true,"department_id":null,"locale":null,"created_at":"2025-10-27T13:07:31Z"},"meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.PATCH("/api/admin/users/:id", uc.Update)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("PATCH", "/api/admin/users/"+tt.id, bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Deactivate() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name:       "error invalid id",
+			id:         "abc",
+			mockFunc:   func(a *mocks.UserUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantRes:    `{"errors":[{"code":102,"message":"Bad request"}],"meta":{"http_status":400}}`,
+		},
+		{
+			name: "error deactivate self",
+			id:   "1",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Deactivate", mock.Anything, &model.UpdateUserActiveRequest{OrgID: 1, ID: 1, UserID: 1, UserRole: "admin"}).
+					Return(model.ErrCannotDeactivateSelf)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantRes: `{"errors":[{"code":1026,"message":"You can't deactivate your own account"}],` +
+				`"meta":{"http_status":422}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Deactivate", mock.Anything, &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User deactivated","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/users/:id/deactivate", uc.Deactivate)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.id+"/deactivate", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *UserControllerSuite) TestUserController_Reactivate() {
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func(a *mocks.UserUsecase)
+		wantStatus int
+		wantRes    string
+	}{
+		{
+			name: "error user not found",
+			id:   "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Reactivate", mock.Anything, mock.Anything).Return(model.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantRes:    `{"errors":[{"code":1001,"message":"User not found"}],"meta":{"http_status":404}}`,
+		},
+		{
+			name: "success",
+			id:   "2",
+			mockFunc: func(a *mocks.UserUsecase) {
+				a.On("Reactivate", mock.Anything, &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"}).
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantRes:    `{"message":"User reactivated","meta":{"http_status":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uu := mocks.NewUserUsecase(s.T())
+			tt.mockFunc(uu)
+
+			uc := internalHttp.NewUserController(s.log, s.validate, uu)
+
+			app := test.NewApi(s.log)
+			app.Use(test.NewAuthMiddleware(1, "admin"))
+			app.POST("/api/admin/users/:id/reactivate", uc.Reactivate)
+
+			req := httptest.NewRequest("POST", "/api/admin/users/"+tt.id+"/reactivate", nil)
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			s.Equal(tt.wantStatus, rec.Code)
+			s.Equal(tt.wantRes, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
 func TestUserControllerSuite(t *testing.T) {
 	suite.Run(t, new(UserControllerSuite))
 }
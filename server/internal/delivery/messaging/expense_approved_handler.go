@@ -3,6 +3,7 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"expense-management-system/internal/entity"
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/usecase"
 	"fmt"
@@ -36,8 +37,14 @@ func (c *ExpenseApprovedHandler) Consume(ctx context.Context, message *kafka.Mes
 		return fmt.Errorf("failed to unmarshal event for %s with key %s = %w", message.TopicPartition.String(), string(message.Key), err)
 	}
 
+	// events published before organizations existed don't carry one
+	if event.OrgID == 0 {
+		event.OrgID = entity.DefaultOrganizationID
+	}
+
 	req := &model.PaymentProcessorRequest{
 		ID:             event.ID,
+		OrgID:          event.OrgID,
 		UserID:         event.UserID,
 		Amount:         event.Amount,
 		IdempotencyKey: event.IdempotencyKey,
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"expense-management-system/internal/delivery/messaging"
+	"expense-management-system/internal/entity"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
 	"testing"
@@ -20,9 +21,10 @@ func TestExpenseApprovedHandler_Consume(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	topic := "expense-approved"
 
-	validMsg := func() *kafka.Message {
+	validMsg := func(orgID uint64) *kafka.Message {
 		event := &model.ExpenseApprovedEvent{
 			ID:             8,
+			OrgID:          orgID,
 			UserID:         2,
 			Amount:         17000,
 			IdempotencyKey: "EXP-00123ABC",
@@ -66,7 +68,7 @@ func TestExpenseApprovedHandler_Consume(t *testing.T) {
 		},
 		{
 			name:    "error on execute",
-			message: validMsg(),
+			message: validMsg(2),
 			mockFunc: func(t *mocks.PaymentProcessorUsecase) {
 				t.On("Execute", mock.Anything, mock.Anything).Return(errors.New("something error"))
 			},
@@ -74,9 +76,21 @@ func TestExpenseApprovedHandler_Consume(t *testing.T) {
 		},
 		{
 			name:    "success",
-			message: validMsg(),
+			message: validMsg(2),
 			mockFunc: func(t *mocks.PaymentProcessorUsecase) {
-				t.On("Execute", mock.Anything, mock.Anything).Return(nil)
+				t.On("Execute", mock.Anything, mock.MatchedBy(func(req *model.PaymentProcessorRequest) bool {
+					return req.OrgID == 2
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name:    "success event without organization",
+			message: validMsg(0),
+			mockFunc: func(t *mocks.PaymentProcessorUsecase) {
+				t.On("Execute", mock.Anything, mock.MatchedBy(func(req *model.PaymentProcessorRequest) bool {
+					return req.OrgID == entity.DefaultOrganizationID
+				})).Return(nil)
 			},
 			wantErrMsg: "",
 		},
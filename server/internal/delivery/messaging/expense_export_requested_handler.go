@@ -0,0 +1,54 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+)
+
+type ExpenseExportRequestedHandler struct {
+	log                  *zap.Logger
+	expenseExportUsecase usecase.ExpenseExportUsecase
+}
+
+func NewExpenseExportRequestedHandler(log *zap.Logger,
+	expenseExportUsecase usecase.ExpenseExportUsecase) *ExpenseExportRequestedHandler {
+	return &ExpenseExportRequestedHandler{
+		log:                  log,
+		expenseExportUsecase: expenseExportUsecase,
+	}
+}
+
+func (c *ExpenseExportRequestedHandler) Consume(ctx context.Context, message *kafka.Message) error {
+	c.log.Info(
+		fmt.Sprintf("processing event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	event := new(model.ExpenseExportRequestedEvent)
+	err := json.Unmarshal(message.Value, &event)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal event for %s with key %s = %w", message.TopicPartition.String(), string(message.Key), err)
+	}
+
+	req := &model.ProcessExpenseExportRequest{
+		ID:    event.ID,
+		OrgID: event.OrgID,
+	}
+	err = c.expenseExportUsecase.Process(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to process expense export for %s with key %s = %w", message.TopicPartition.String(), string(message.Key), err)
+	}
+
+	c.log.Info(
+		fmt.Sprintf("successfuly proceed event for %s with key %s", message.TopicPartition.String(), string(message.Key)),
+		zap.Any("event", string(message.Value)),
+	)
+
+	return nil
+}
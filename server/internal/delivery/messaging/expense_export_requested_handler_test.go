@@ -0,0 +1,99 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/delivery/messaging"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestExpenseExportRequestedHandler_Consume(t *testing.T) {
+	ctx := context.Background()
+	logger, _ := zap.NewDevelopment()
+	topic := "expense-export-requested"
+
+	validMsg := func() *kafka.Message {
+		event := &model.ExpenseExportRequestedEvent{
+			ID:     5,
+			OrgID:  2,
+			UserID: 3,
+		}
+		data, _ := json.Marshal(event)
+		msg := &kafka.Message{
+			TopicPartition: kafka.TopicPartition{
+				Topic:     &topic,
+				Partition: kafka.PartitionAny,
+			},
+			Value: data,
+			Key:   []byte(event.GetID()),
+		}
+
+		return msg
+	}
+
+	tests := []struct {
+		name       string
+		message    *kafka.Message
+		mockFunc   func(t *mocks.ExpenseExportUsecase)
+		wantErrMsg string
+	}{
+		{
+			name: "error on unrmarshal",
+			message: func() *kafka.Message {
+				data, _ := json.Marshal("dummy")
+				msg := &kafka.Message{
+					TopicPartition: kafka.TopicPartition{
+						Topic:     &topic,
+						Partition: kafka.PartitionAny,
+					},
+					Value: data,
+					Key:   []byte("1"),
+				}
+
+				return msg
+			}(),
+			mockFunc:   func(t *mocks.ExpenseExportUsecase) {},
+			wantErrMsg: "failed to unmarshal event for expense-export-requested",
+		},
+		{
+			name:    "error on process",
+			message: validMsg(),
+			mockFunc: func(t *mocks.ExpenseExportUsecase) {
+				t.On("Process", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "something error",
+		},
+		{
+			name:    "success",
+			message: validMsg(),
+			mockFunc: func(t *mocks.ExpenseExportUsecase) {
+				t.On("Process", mock.Anything, &model.ProcessExpenseExportRequest{ID: 5, OrgID: 2}).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := mocks.NewExpenseExportUsecase(t)
+			handler := messaging.NewExpenseExportRequestedHandler(logger, uc)
+			tt.mockFunc(uc)
+
+			err := handler.Consume(ctx, tt.message)
+
+			if tt.wantErrMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
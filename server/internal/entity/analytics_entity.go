@@ -0,0 +1,25 @@
+package entity
+
+// ExpenseGroupStat is the number and the sum of the expenses sharing the same key,
+// e.g. a status, a month or a department
+type ExpenseGroupStat struct {
+	Key    string `db:"key"`   // empty for the expenses without a department or a cost center
+	Label  string `db:"label"` // human readable key, the name of the department or the employee
+	Count  int    `db:"count"`
+	Amount uint64 `db:"amount"`
+}
+
+// ExpenseTurnaround is how long expenses wait, nil when no expense got that far
+type ExpenseTurnaround struct {
+	AvgSecondsToApprove *int64 `db:"avg_seconds_to_approve"` // submitted until approved by a manager
+	AvgSecondsToPay     *int64 `db:"avg_seconds_to_pay"`     // approved until paid out
+}
+
+// ExpenseStatusTotal is the number and the sum of the expenses of a user in a status,
+// within a month (e.g. 2025-09) or of all time when the month is empty
+type ExpenseStatusTotal struct {
+	Month  string        `db:"month"`
+	Status ExpenseStatus `db:"status"`
+	Count  int           `db:"count"`
+	Amount uint64        `db:"amount"`
+}
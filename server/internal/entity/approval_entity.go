@@ -11,6 +11,7 @@ const (
 
 type Approval struct {
 	ID         uint64         `db:"id"`
+	OrgID      uint64         `db:"org_id"`
 	ExpenseID  uint64         `db:"expense_id"`
 	ApproverID uint64         `db:"approver_id"`
 	Status     ApprovalStatus `db:"status"`
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type BudgetPolicy string
+
+const (
+	BudgetPolicyBlock    BudgetPolicy = "block"    // approvals over the remaining budget are refused
+	BudgetPolicyEscalate BudgetPolicy = "escalate" // approvals over the remaining budget need an admin
+)
+
+type Department struct {
+	ID         uint64    `db:"id"`
+	OrgID      uint64    `db:"org_id"`
+	Name       string    `db:"name"`
+	CostCenter string    `db:"cost_center"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type DepartmentBudget struct {
+	ID           uint64    `db:"id"`
+	OrgID        uint64    `db:"org_id"`
+	DepartmentID uint64    `db:"department_id"`
+	PeriodStart  time.Time `db:"period_start"` // inclusive date
+	PeriodEnd    time.Time `db:"period_end"`   // inclusive date
+	Amount       uint64    `db:"amount"`
+	Spent        uint64    `db:"spent"` // approved and completed expenses created within the period
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// Remaining goes below zero once auto-approved expenses overspend the budget
+func (b *DepartmentBudget) Remaining() int64 {
+	if b != nil {
+		return int64(b.Amount) - int64(b.Spent)
+	}
+
+	return 0
+}
+
+func (b *DepartmentBudget) Exceeded(amount uint64) bool {
+	if b != nil {
+		return int64(amount) > b.Remaining()
+	}
+
+	return false
+}
+
+func ParseBudgetPolicy(str string) (BudgetPolicy, error) {
+	switch str {
+	case "block":
+		return BudgetPolicyBlock, nil
+	case "escalate":
+		return BudgetPolicyEscalate, nil
+	default:
+		return "", fmt.Errorf("invalid budget policy = %s", str)
+	}
+}
@@ -0,0 +1,126 @@
+package entity_test
+
+import (
+	"expense-management-system/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepartmentBudget_Remaining(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   *entity.DepartmentBudget
+		wantRes int64
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			wantRes: 0,
+		},
+		{
+			name: "under budget",
+			model: &entity.DepartmentBudget{
+				Amount: 10_000_000,
+				Spent:  2_500_000,
+			},
+			wantRes: 7_500_000,
+		},
+		{
+			name: "over budget",
+			model: &entity.DepartmentBudget{
+				Amount: 10_000_000,
+				Spent:  12_000_000,
+			},
+			wantRes: -2_000_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRes, tt.model.Remaining())
+		})
+	}
+}
+
+func TestDepartmentBudget_Exceeded(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   *entity.DepartmentBudget
+		amount  uint64
+		wantRes bool
+	}{
+		{
+			name:    "nil model",
+			model:   nil,
+			amount:  1_000_000,
+			wantRes: false,
+		},
+		{
+			name: "amount less than remaining",
+			model: &entity.DepartmentBudget{
+				Amount: 10_000_000,
+				Spent:  8_000_000,
+			},
+			amount:  1_000_000,
+			wantRes: false,
+		},
+		{
+			name: "amount equal to remaining",
+			model: &entity.DepartmentBudget{
+				Amount: 10_000_000,
+				Spent:  8_000_000,
+			},
+			amount:  2_000_000,
+			wantRes: false,
+		},
+		{
+			name: "amount greater than remaining",
+			model: &entity.DepartmentBudget{
+				Amount: 10_000_000,
+				Spent:  8_000_000,
+			},
+			amount:  2_000_001,
+			wantRes: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRes, tt.model.Exceeded(tt.amount))
+		})
+	}
+}
+
+func TestParseBudgetPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		wantRes entity.BudgetPolicy
+		wantErr bool
+	}{
+		{
+			name:    "block",
+			str:     "block",
+			wantRes: entity.BudgetPolicyBlock,
+		},
+		{
+			name:    "escalate",
+			str:     "escalate",
+			wantRes: entity.BudgetPolicyEscalate,
+		},
+		{
+			name:    "invalid",
+			str:     "ignore",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := entity.ParseBudgetPolicy(tt.str)
+			assert.Equal(t, tt.wantRes, res)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
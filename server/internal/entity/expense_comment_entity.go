@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+)
+
+// ExpenseComment is a message on the thread of an expense between its submitter and the managers
+type ExpenseComment struct {
+	ID        uint64       `db:"id"`
+	OrgID     uint64       `db:"org_id"`
+	ExpenseID uint64       `db:"expense_id"`
+	UserID    uint64       `db:"user_id"`
+	Body      string       `db:"body"`
+	CreatedAt time.Time    `db:"created_at"`
+	EditedAt  *time.Time   `db:"edited_at"`
+	User      UserSimple   // the author
+	Mentions  []UserSimple // ordered by id
+}
+
+// Editable tells if the author can still edit or delete the comment
+func (c *ExpenseComment) Editable(window time.Duration, now time.Time) bool {
+	return now.Before(c.CreatedAt.Add(window))
+}
@@ -0,0 +1,44 @@
+package entity_test
+
+import (
+	"expense-management-system/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseComment_Editable(t *testing.T) {
+	createdAt := time.Date(2025, 9, 14, 8, 0, 0, 0, time.UTC)
+	comment := &entity.ExpenseComment{ID: 1, CreatedAt: createdAt}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantRes bool
+	}{
+		{
+			name:    "inside the window",
+			now:     createdAt.Add(14 * time.Minute),
+			wantRes: true,
+		},
+		{
+			name:    "at the end of the window",
+			now:     createdAt.Add(15 * time.Minute),
+			wantRes: false,
+		},
+		{
+			name:    "after the window",
+			now:     createdAt.Add(time.Hour),
+			wantRes: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := comment.Editable(15*time.Minute, tt.now)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
@@ -10,32 +10,34 @@ import (
 type ExpenseStatus string
 
 const (
-	MinExpenseAmount        = 10_000
-	MaxExpenseAmount        = 50_000_000
-	ApprovalThresholdAmount = 1_000_000
-
 	ExpenseStatusAwaitingApproval ExpenseStatus = "awaiting_approval"
 	ExpenseStatusApproved         ExpenseStatus = "approved"
 	ExpenseStatusRejected         ExpenseStatus = "rejected"
 	ExpenseStatusCompleted        ExpenseStatus = "completed"
+	ExpenseStatusChangesRequested ExpenseStatus = "changes_requested" // sent back to the submitter to edit
 
 	keyPrefix = "EXP-"
 )
 
 type Expense struct {
-	ID          uint64        `db:"id"`
-	UserID      uint64        `db:"user_id"`
-	Amount      uint64        `db:"amount"`
-	Description string        `db:"description"`
-	ReceiptURL  *string       `db:"receipt_url"`
-	Status      ExpenseStatus `db:"status"`
-	CreatedAt   time.Time     `db:"created_at"`
-	ProcessedAt *time.Time    `db:"processed_at"`
+	ID                uint64        `db:"id"`
+	OrgID             uint64        `db:"org_id"`
+	UserID            uint64        `db:"user_id"`
+	Amount            uint64        `db:"amount"`
+	Description       string        `db:"description"`
+	ReceiptURL        *string       `db:"receipt_url"`
+	Status            ExpenseStatus `db:"status"`
+	ApprovalThreshold uint64        `db:"approval_threshold"` // threshold of the organization when the expense was created
+	DepartmentID      *uint64       `db:"department_id"`      // department of the submitter when the expense was created
+	CostCenter        *string       `db:"cost_center"`        // cost center of that department at the same time
+	CreatedAt         time.Time     `db:"created_at"`
+	ProcessedAt       *time.Time    `db:"processed_at"`
+	Version           uint64        `db:"version"` // bumped on every change, optimistic concurrency compares it
 }
 
 func (e *Expense) RequiresApproval() bool {
 	if e != nil {
-		return e.Amount >= ApprovalThresholdAmount
+		return e.Amount >= e.ApprovalThreshold
 	}
 
 	return false
@@ -43,7 +45,7 @@ func (e *Expense) RequiresApproval() bool {
 
 func (e *Expense) AutoApproved() bool {
 	if e != nil {
-		return e.Amount < ApprovalThresholdAmount
+		return e.Amount < e.ApprovalThreshold
 	}
 
 	return false
@@ -67,6 +69,8 @@ func ParseExpenseStatus(str string) (ExpenseStatus, error) {
 		return ExpenseStatusRejected, nil
 	case "completed":
 		return ExpenseStatusCompleted, nil
+	case "changes_requested":
+		return ExpenseStatusChangesRequested, nil
 	default:
 		return "", fmt.Errorf("invalid status: %s", str)
 	}
@@ -82,3 +86,11 @@ type ExpenseDetail struct {
 	User     UserSimple
 	Approval *ApprovalDetail
 }
+
+type ExpenseSearchResult struct {
+	ExpenseWithUser
+	Rank                   float64
+	DescriptionHighlight   string
+	ApprovalNotesHighlight *string
+	UserNameHighlight      *string
+}
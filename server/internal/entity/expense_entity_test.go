@@ -21,21 +21,24 @@ func TestExpense_RequiresApproval(t *testing.T) {
 		{
 			name: "amount greater than threshold",
 			model: &entity.Expense{
-				Amount: 2500000,
+				Amount:            2500000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: true,
 		},
 		{
 			name: "amount equal to threshold",
 			model: &entity.Expense{
-				Amount: 1000000,
+				Amount:            1000000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: true,
 		},
 		{
 			name: "amount less than threshold",
 			model: &entity.Expense{
-				Amount: 15000,
+				Amount:            15000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: false,
 		},
@@ -64,21 +67,24 @@ func TestExpense_AutoApproved(t *testing.T) {
 		{
 			name: "amount greater than threshold",
 			model: &entity.Expense{
-				Amount: 2500000,
+				Amount:            2500000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: false,
 		},
 		{
 			name: "amount equal to threshold",
 			model: &entity.Expense{
-				Amount: 1000000,
+				Amount:            1000000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: false,
 		},
 		{
 			name: "amount less than threshold",
 			model: &entity.Expense{
-				Amount: 15000,
+				Amount:            15000,
+				ApprovalThreshold: 1000000,
 			},
 			wantRes: true,
 		},
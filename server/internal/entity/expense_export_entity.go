@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+type ExpenseExportStatus string
+
+const (
+	ExpenseExportStatusPending    ExpenseExportStatus = "pending"
+	ExpenseExportStatusProcessing ExpenseExportStatus = "processing"
+	ExpenseExportStatusCompleted  ExpenseExportStatus = "completed"
+	ExpenseExportStatusFailed     ExpenseExportStatus = "failed"
+)
+
+// ExpenseExport is an export that runs in the background, the file is kept in the file storage once completed
+type ExpenseExport struct {
+	ID          uint64              `db:"id"`
+	OrgID       uint64              `db:"org_id"`
+	UserID      uint64              `db:"user_id"`
+	Format      ExportFormat        `db:"format"`
+	Filters     []byte              `db:"filters"` // the list request as json, the export shows what the list showed
+	Status      ExpenseExportStatus `db:"status"`
+	RowCount    *int                `db:"row_count"`
+	FileKey     *string             `db:"file_key"` // nil until the file is stored
+	CreatedAt   time.Time           `db:"created_at"`
+	CompletedAt *time.Time          `db:"completed_at"`
+}
+
+// ExpenseExportFileKey is where the file of the export is stored
+func ExpenseExportFileKey(orgID uint64, id uint64, format ExportFormat) string {
+	return fmt.Sprintf("exports/%d/%d.%s", orgID, id, format)
+}
+
+func ParseExportFormat(str string) (ExportFormat, error) {
+	switch str {
+	case "csv":
+		return ExportFormatCSV, nil
+	case "xlsx":
+		return ExportFormatXLSX, nil
+	default:
+		return "", fmt.Errorf("invalid export format = %s", str)
+	}
+}
+
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
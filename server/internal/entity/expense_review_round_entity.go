@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ExpenseReviewRound is one request for changes on an expense. The approver opens it with the notes
+// of what to change, the submitter closes it by editing the expense, which sends it back for approval.
+type ExpenseReviewRound struct {
+	ID            uint64     `db:"id"`
+	OrgID         uint64     `db:"org_id"`
+	ExpenseID     uint64     `db:"expense_id"`
+	Round         int        `db:"round"` // 1 for the first request for changes of the expense
+	ReviewerID    uint64     `db:"reviewer_id"`
+	Notes         string     `db:"notes"`
+	RequestedAt   time.Time  `db:"requested_at"`
+	ResubmittedAt *time.Time `db:"resubmitted_at"` // nil while the submitter hasn't edited the expense
+	Reviewer      UserSimple
+}
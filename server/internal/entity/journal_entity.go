@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const accountingPeriodLayout = "2006-01"
+
+// GLAccountMapping tells which general ledger accounts the expenses of a cost center are posted to,
+// the mapping without a cost center is the default of the organization
+type GLAccountMapping struct {
+	ID             uint64    `db:"id"`
+	OrgID          uint64    `db:"org_id"`
+	CostCenter     *string   `db:"cost_center"`
+	ExpenseAccount string    `db:"expense_account"` // debited with the amount
+	CreditAccount  string    `db:"credit_account"`  // credited, the employee payable or the bank account
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+type GLAccountMappings []GLAccountMapping
+
+// Resolve returns the mapping of the cost center or the default one, nil when there is neither
+func (m GLAccountMappings) Resolve(costCenter *string) *GLAccountMapping {
+	var fallback *GLAccountMapping
+	for i := range m {
+		switch {
+		case m[i].CostCenter == nil:
+			fallback = &m[i]
+		case costCenter != nil && *m[i].CostCenter == *costCenter:
+			return &m[i]
+		}
+	}
+
+	return fallback
+}
+
+// JournalBatch holds the journal lines of the completed expenses of an accounting period,
+// an expense is only ever put in one batch
+type JournalBatch struct {
+	ID          uint64    `db:"id"`
+	OrgID       uint64    `db:"org_id"`
+	Period      string    `db:"period"` // e.g. 2025-09
+	CreatedBy   uint64    `db:"created_by"`
+	LineCount   int       `db:"line_count"`
+	TotalAmount uint64    `db:"total_amount"` // sum of the debits, the credits are the same
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type JournalLine struct {
+	ID         uint64    `db:"id"`
+	BatchID    uint64    `db:"batch_id"`
+	ExpenseID  uint64    `db:"expense_id"`
+	LineNo     int       `db:"line_no"`
+	EntryDate  time.Time `db:"entry_date"` // the day the expense was paid
+	Account    string    `db:"account"`
+	Debit      uint64    `db:"debit"`
+	Credit     uint64    `db:"credit"`
+	CostCenter *string   `db:"cost_center"`
+	Memo       string    `db:"memo"`
+}
+
+// NewJournalLines makes the balanced pair of an expense, the expense account is debited
+// and the credit account is credited with the same amount
+func NewJournalLines(expense *Expense, mapping *GLAccountMapping, lineNo int) []JournalLine {
+	var entryDate time.Time
+	if expense.ProcessedAt != nil {
+		entryDate = expense.ProcessedAt.UTC().Truncate(24 * time.Hour)
+	}
+
+	memo := fmt.Sprintf("%s %s", expense.GetKey(), expense.Description)
+
+	return []JournalLine{
+		{
+			ExpenseID:  expense.ID,
+			LineNo:     lineNo,
+			EntryDate:  entryDate,
+			Account:    mapping.ExpenseAccount,
+			Debit:      expense.Amount,
+			CostCenter: expense.CostCenter,
+			Memo:       memo,
+		},
+		{
+			ExpenseID:  expense.ID,
+			LineNo:     lineNo + 1,
+			EntryDate:  entryDate,
+			Account:    mapping.CreditAccount,
+			Credit:     expense.Amount,
+			CostCenter: expense.CostCenter,
+			Memo:       memo,
+		},
+	}
+}
+
+// ParseAccountingPeriod reads a period such as 2025-09, the end is the start of the next month
+func ParseAccountingPeriod(str string) (time.Time, time.Time, error) {
+	start, err := time.Parse(accountingPeriodLayout, str)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid accounting period = %s", str)
+	}
+
+	return start, start.AddDate(0, 1, 0), nil
+}
@@ -0,0 +1,128 @@
+package entity_test
+
+import (
+	"expense-management-system/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGLAccountMappings_Resolve(t *testing.T) {
+	finance := "CC-100"
+	sales := "CC-200"
+	mappings := entity.GLAccountMappings{
+		{ID: 1, ExpenseAccount: "6000", CreditAccount: "2100"},
+		{ID: 2, CostCenter: &finance, ExpenseAccount: "6100", CreditAccount: "2100"},
+	}
+
+	tests := []struct {
+		name       string
+		mappings   entity.GLAccountMappings
+		costCenter *string
+		wantID     uint64
+	}{
+		{
+			name:       "cost center mapping",
+			mappings:   mappings,
+			costCenter: &finance,
+			wantID:     2,
+		},
+		{
+			name:       "unmapped cost center uses the default",
+			mappings:   mappings,
+			costCenter: &sales,
+			wantID:     1,
+		},
+		{
+			name:       "no cost center uses the default",
+			mappings:   mappings,
+			costCenter: nil,
+			wantID:     1,
+		},
+		{
+			name:       "no default",
+			mappings:   mappings[1:],
+			costCenter: &sales,
+			wantID:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.mappings.Resolve(tt.costCenter)
+			if tt.wantID == 0 {
+				assert.Nil(t, res)
+				return
+			}
+			assert.Equal(t, tt.wantID, res.ID)
+		})
+	}
+}
+
+func TestNewJournalLines(t *testing.T) {
+	costCenter := "CC-100"
+	processedAt := time.Date(2025, 9, 14, 10, 30, 0, 0, time.UTC)
+	expense := &entity.Expense{
+		ID:          7,
+		Amount:      150_000,
+		Description: "Taxi",
+		CostCenter:  &costCenter,
+		ProcessedAt: &processedAt,
+	}
+	mapping := &entity.GLAccountMapping{ExpenseAccount: "6100", CreditAccount: "2100"}
+
+	lines := entity.NewJournalLines(expense, mapping, 3)
+
+	entryDate := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []entity.JournalLine{
+		{ExpenseID: 7, LineNo: 3, EntryDate: entryDate, Account: "6100", Debit: 150_000, CostCenter: &costCenter, Memo: "EXP-000000007 Taxi"},
+		{ExpenseID: 7, LineNo: 4, EntryDate: entryDate, Account: "2100", Credit: 150_000, CostCenter: &costCenter, Memo: "EXP-000000007 Taxi"},
+	}, lines)
+}
+
+func TestParseAccountingPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		str       string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			str:       "2025-09",
+			wantStart: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "end of year",
+			str:       "2025-12",
+			wantStart: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid month",
+			str:     "2025-13",
+			wantErr: true,
+		},
+		{
+			name:    "full date",
+			str:     "2025-09-01",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := entity.ParseAccountingPeriod(tt.str)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"time"
+)
+
+type LedgerAccountType string
+
+const (
+	LedgerAccountTypeReimbursementExpense LedgerAccountType = "reimbursement_expense"
+	LedgerAccountTypeEmployeePayable      LedgerAccountType = "employee_payable" // one per employee
+	LedgerAccountTypeCash                 LedgerAccountType = "cash"
+)
+
+type LedgerEntryType string
+
+const (
+	LedgerEntryTypeApproval LedgerEntryType = "approval"
+	LedgerEntryTypePayout   LedgerEntryType = "payout"
+	LedgerEntryTypeReversal LedgerEntryType = "reversal"
+)
+
+// LedgerEntry is an immutable and balanced set of postings, a mistake is corrected
+// by posting its reversal instead of changing it
+type LedgerEntry struct {
+	ID              uint64          `db:"id"`
+	OrgID           uint64          `db:"org_id"`
+	ExpenseID       uint64          `db:"expense_id"`
+	Type            LedgerEntryType `db:"type"`
+	ReversesEntryID *uint64         `db:"reverses_entry_id"`
+	Memo            string          `db:"memo"`
+	CreatedBy       *uint64         `db:"created_by"` // nil when posted by the system
+	CreatedAt       time.Time       `db:"created_at"`
+	Postings        []LedgerPosting
+}
+
+type LedgerPosting struct {
+	ID          uint64            `db:"id"`
+	EntryID     uint64            `db:"entry_id"`
+	AccountID   uint64            `db:"account_id"`
+	AccountType LedgerAccountType `db:"account_type"`
+	UserID      *uint64           `db:"user_id"` // owner of the employee payable account
+	Debit       uint64            `db:"debit"`
+	Credit      uint64            `db:"credit"`
+}
+
+// Balanced tells whether the debits and the credits of the entry add up to the same amount
+func (e *LedgerEntry) Balanced() bool {
+	if e == nil || len(e.Postings) == 0 {
+		return false
+	}
+
+	var debit, credit uint64
+	for _, p := range e.Postings {
+		debit += p.Debit
+		credit += p.Credit
+	}
+
+	return debit > 0 && debit == credit
+}
+
+// Reverse makes the entry that offsets this one, every posting is put on the other side
+func (e *LedgerEntry) Reverse(memo string, createdBy uint64) *LedgerEntry {
+	postings := make([]LedgerPosting, 0, len(e.Postings))
+	for _, p := range e.Postings {
+		postings = append(postings, LedgerPosting{
+			AccountType: p.AccountType,
+			UserID:      p.UserID,
+			Debit:       p.Credit,
+			Credit:      p.Debit,
+		})
+	}
+
+	return &LedgerEntry{
+		OrgID:           e.OrgID,
+		ExpenseID:       e.ExpenseID,
+		Type:            LedgerEntryTypeReversal,
+		ReversesEntryID: &e.ID,
+		Memo:            memo,
+		CreatedBy:       &createdBy,
+		Postings:        postings,
+	}
+}
+
+// NewApprovalLedgerEntry records that the organization owes the submitter the amount of the expense
+func NewApprovalLedgerEntry(expense *Expense) *LedgerEntry {
+	return &LedgerEntry{
+		OrgID:     expense.OrgID,
+		ExpenseID: expense.ID,
+		Type:      LedgerEntryTypeApproval,
+		Memo:      "Expense approved",
+		Postings: []LedgerPosting{
+			{AccountType: LedgerAccountTypeReimbursementExpense, Debit: expense.Amount},
+			{AccountType: LedgerAccountTypeEmployeePayable, UserID: &expense.UserID, Credit: expense.Amount},
+		},
+	}
+}
+
+// NewPayoutLedgerEntry settles the payable of the submitter with cash
+func NewPayoutLedgerEntry(expense *Expense) *LedgerEntry {
+	return &LedgerEntry{
+		OrgID:     expense.OrgID,
+		ExpenseID: expense.ID,
+		Type:      LedgerEntryTypePayout,
+		Memo:      "Expense paid out",
+		Postings: []LedgerPosting{
+			{AccountType: LedgerAccountTypeEmployeePayable, UserID: &expense.UserID, Debit: expense.Amount},
+			{AccountType: LedgerAccountTypeCash, Credit: expense.Amount},
+		},
+	}
+}
+
+// LedgerBalance is what the organization still owes an employee, the credits minus the debits
+// of their payable account
+type LedgerBalance struct {
+	User    UserSimple
+	Balance int64 `db:"balance"`
+}
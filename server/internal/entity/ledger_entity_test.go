@@ -0,0 +1,125 @@
+package entity_test
+
+import (
+	"expense-management-system/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerEntry_Balanced(t *testing.T) {
+	userID := uint64(3)
+
+	tests := []struct {
+		name  string
+		entry *entity.LedgerEntry
+		want  bool
+	}{
+		{
+			name:  "nil",
+			entry: nil,
+			want:  false,
+		},
+		{
+			name:  "no postings",
+			entry: &entity.LedgerEntry{},
+			want:  false,
+		},
+		{
+			name: "unbalanced",
+			entry: &entity.LedgerEntry{Postings: []entity.LedgerPosting{
+				{AccountType: entity.LedgerAccountTypeReimbursementExpense, Debit: 50_000},
+				{AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 40_000},
+			}},
+			want: false,
+		},
+		{
+			name: "zero amount",
+			entry: &entity.LedgerEntry{Postings: []entity.LedgerPosting{
+				{AccountType: entity.LedgerAccountTypeReimbursementExpense},
+				{AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID},
+			}},
+			want: false,
+		},
+		{
+			name:  "balanced",
+			entry: entity.NewApprovalLedgerEntry(&entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50_000}),
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Balanced())
+		})
+	}
+}
+
+func TestNewApprovalLedgerEntry(t *testing.T) {
+	userID := uint64(3)
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50_000}
+
+	entry := entity.NewApprovalLedgerEntry(expense)
+
+	assert.Equal(t, &entity.LedgerEntry{
+		OrgID:     1,
+		ExpenseID: 7,
+		Type:      entity.LedgerEntryTypeApproval,
+		Memo:      "Expense approved",
+		Postings: []entity.LedgerPosting{
+			{AccountType: entity.LedgerAccountTypeReimbursementExpense, Debit: 50_000},
+			{AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 50_000},
+		},
+	}, entry)
+}
+
+func TestNewPayoutLedgerEntry(t *testing.T) {
+	userID := uint64(3)
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50_000}
+
+	entry := entity.NewPayoutLedgerEntry(expense)
+
+	assert.Equal(t, &entity.LedgerEntry{
+		OrgID:     1,
+		ExpenseID: 7,
+		Type:      entity.LedgerEntryTypePayout,
+		Memo:      "Expense paid out",
+		Postings: []entity.LedgerPosting{
+			{AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Debit: 50_000},
+			{AccountType: entity.LedgerAccountTypeCash, Credit: 50_000},
+		},
+	}, entry)
+}
+
+func TestLedgerEntry_Reverse(t *testing.T) {
+	userID := uint64(3)
+	entry := &entity.LedgerEntry{
+		ID:        10,
+		OrgID:     1,
+		ExpenseID: 7,
+		Type:      entity.LedgerEntryTypePayout,
+		Memo:      "Expense paid out",
+		Postings: []entity.LedgerPosting{
+			{ID: 1, EntryID: 10, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Debit: 50_000},
+			{ID: 2, EntryID: 10, AccountID: 2, AccountType: entity.LedgerAccountTypeCash, Credit: 50_000},
+		},
+	}
+
+	reversal := entry.Reverse("Bank transfer bounced", 2)
+
+	entryID := uint64(10)
+	createdBy := uint64(2)
+	assert.Equal(t, &entity.LedgerEntry{
+		OrgID:           1,
+		ExpenseID:       7,
+		Type:            entity.LedgerEntryTypeReversal,
+		ReversesEntryID: &entryID,
+		Memo:            "Bank transfer bounced",
+		CreatedBy:       &createdBy,
+		Postings: []entity.LedgerPosting{
+			{AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 50_000},
+			{AccountType: entity.LedgerAccountTypeCash, Debit: 50_000},
+		},
+	}, reversal)
+	assert.True(t, reversal.Balanced())
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// DefaultOrganizationID owns the data from before organizations existed, self registered
+// and just-in-time provisioned users join it as well
+const DefaultOrganizationID = 1
+
+// Organization is a tenant, the expense limits are its own policy
+type Organization struct {
+	ID                      uint64    `db:"id"`
+	Name                    string    `db:"name"`
+	MinExpenseAmount        uint64    `db:"min_expense_amount"`
+	MaxExpenseAmount        uint64    `db:"max_expense_amount"`
+	ApprovalThresholdAmount uint64    `db:"approval_threshold_amount"` // expenses from this amount need an approval
+	CreatedAt               time.Time `db:"created_at"`
+	UpdatedAt               time.Time `db:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PaymentVoucher is the proof of payment of a completed expense. It's recorded with the partner
+// transaction in the payout transaction, the pdf is rendered afterwards and kept in the file storage.
+type PaymentVoucher struct {
+	ID                   uint64     `db:"id"`
+	OrgID                uint64     `db:"org_id"`
+	ExpenseID            uint64     `db:"expense_id"`
+	PartnerTransactionID string     `db:"partner_transaction_id"`
+	FileKey              *string    `db:"file_key"` // nil until the pdf is stored
+	CreatedAt            time.Time  `db:"created_at"`
+	GeneratedAt          *time.Time `db:"generated_at"`
+}
+
+// PaymentVoucherFileKey is where the pdf of the voucher of the expense is stored
+func PaymentVoucherFileKey(orgID uint64, expenseID uint64) string {
+	return fmt.Sprintf("vouchers/%d/%d.pdf", orgID, expenseID)
+}
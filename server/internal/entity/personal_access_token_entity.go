@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type PersonalAccessToken struct {
+	ID          uint64     `db:"id"`
+	OrgID       uint64     `db:"org_id"`
+	UserID      uint64     `db:"user_id"`
+	Name        string     `db:"name"`
+	TokenHash   string     `db:"token_hash"`
+	TokenPrefix string     `db:"token_prefix"` // first characters of the token, to tell keys apart
+	Scopes      []string   `db:"scopes"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	LastUsedAt  *time.Time `db:"last_used_at"`
+	RevokedAt   *time.Time `db:"revoked_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+func (t *PersonalAccessToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
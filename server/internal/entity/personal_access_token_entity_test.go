@@ -0,0 +1,42 @@
+package entity_test
+
+import (
+	"expense-management-system/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessToken_Active(t *testing.T) {
+	now := time.Date(2025, 9, 17, 10, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		token *entity.PersonalAccessToken
+		want  bool
+	}{
+		{
+			name:  "active",
+			token: &entity.PersonalAccessToken{ExpiresAt: now.Add(time.Hour)},
+			want:  true,
+		},
+		{
+			name:  "expired",
+			token: &entity.PersonalAccessToken{ExpiresAt: now},
+			want:  false,
+		},
+		{
+			name:  "revoked",
+			token: &entity.PersonalAccessToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.token.Active(now))
+		})
+	}
+}
@@ -10,15 +10,38 @@ type UserRole string
 const (
 	UserRoleEmployee UserRole = "employee"
 	UserRoleManager  UserRole = "manager"
+	UserRoleAdmin    UserRole = "admin"
 )
 
+var userRoleRanks = map[UserRole]int{
+	UserRoleEmployee: 1,
+	UserRoleManager:  2,
+	UserRoleAdmin:    3,
+}
+
+func (r UserRole) AtLeast(role UserRole) bool {
+	return userRoleRanks[r] >= userRoleRanks[role] && userRoleRanks[r] > 0
+}
+
+// RequiresTwoFactor reports whether the role moves company money
+// and therefore must log in with a second factor
+func (r UserRole) RequiresTwoFactor() bool {
+	return r.AtLeast(UserRoleManager)
+}
+
 type User struct {
 	ID           uint64    `db:"id"`
+	OrgID        uint64    `db:"org_id"`
 	Email        string    `db:"email"`
 	Name         string    `db:"name"`
 	PasswordHash string    `db:"password_hash"`
 	Role         UserRole  `db:"role"`
+	Active       bool      `db:"active"`      // inactive users can't login and their tokens are rejected
+	ExternalID   *string   `db:"external_id"` // id in the hr system that provisions the user
+	DepartmentID *uint64   `db:"department_id"`
+	Locale       string    `db:"locale"` // preferred language of messages, empty follows Accept-Language
 	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
 }
 
 func ParseUserRole(str string) (UserRole, error) {
@@ -27,6 +50,8 @@ func ParseUserRole(str string) (UserRole, error) {
 		return UserRoleEmployee, nil
 	case "manager":
 		return UserRoleManager, nil
+	case "admin":
+		return UserRoleAdmin, nil
 	default:
 		return "", fmt.Errorf("invalid user role = %s", str)
 	}
@@ -37,3 +62,9 @@ type UserSimple struct {
 	Email string `db:"email"`
 	Name  string `db:"name"`
 }
+
+// CanSeeExpense tells if the user can read and comment on the expense: its submitter
+// and the managers and admins of the organization
+func (u *User) CanSeeExpense(expense *Expense) bool {
+	return u.OrgID == expense.OrgID && (u.ID == expense.UserID || u.Role.AtLeast(UserRoleManager))
+}
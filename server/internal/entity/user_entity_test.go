@@ -26,6 +26,12 @@ func TestUserRole_ParseUserRole(t *testing.T) {
 			wantRes:    entity.UserRoleManager,
 			wantErrMsg: "",
 		},
+		{
+			name:       "admin role",
+			status:     "admin",
+			wantRes:    entity.UserRoleAdmin,
+			wantErrMsg: "",
+		},
 		{
 			name:       "unknown role",
 			status:     "unknown",
@@ -47,3 +53,90 @@ func TestUserRole_ParseUserRole(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRole_AtLeast(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    entity.UserRole
+		param   entity.UserRole
+		wantRes bool
+	}{
+		{
+			name:    "employee at least manager",
+			role:    entity.UserRoleEmployee,
+			param:   entity.UserRoleManager,
+			wantRes: false,
+		},
+		{
+			name:    "manager at least manager",
+			role:    entity.UserRoleManager,
+			param:   entity.UserRoleManager,
+			wantRes: true,
+		},
+		{
+			name:    "admin at least manager",
+			role:    entity.UserRoleAdmin,
+			param:   entity.UserRoleManager,
+			wantRes: true,
+		},
+		{
+			name:    "unknown role",
+			role:    entity.UserRole("unknown"),
+			param:   entity.UserRole("unknown"),
+			wantRes: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.role.AtLeast(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestUserRole_RequiresTwoFactor(t *testing.T) {
+	assert.False(t, entity.UserRoleEmployee.RequiresTwoFactor())
+	assert.True(t, entity.UserRoleManager.RequiresTwoFactor())
+	assert.True(t, entity.UserRoleAdmin.RequiresTwoFactor())
+}
+
+func TestUser_CanSeeExpense(t *testing.T) {
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: 2}
+
+	tests := []struct {
+		name    string
+		user    *entity.User
+		wantRes bool
+	}{
+		{
+			name:    "submitter",
+			user:    &entity.User{ID: 2, OrgID: 1, Role: entity.UserRoleEmployee},
+			wantRes: true,
+		},
+		{
+			name:    "another employee",
+			user:    &entity.User{ID: 3, OrgID: 1, Role: entity.UserRoleEmployee},
+			wantRes: false,
+		},
+		{
+			name:    "manager",
+			user:    &entity.User{ID: 4, OrgID: 1, Role: entity.UserRoleManager},
+			wantRes: true,
+		},
+		{
+			name:    "admin of another organization",
+			user:    &entity.User{ID: 5, OrgID: 2, Role: entity.UserRoleAdmin},
+			wantRes: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.user.CanSeeExpense(expense)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// UserIdentity links a local user to the subject of an external identity provider
+type UserIdentity struct {
+	ID        uint64    `db:"id"`
+	OrgID     uint64    `db:"org_id"`
+	UserID    uint64    `db:"user_id"`
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type UserTOTP struct {
+	UserID        uint64     `db:"user_id"`
+	Secret        string     `db:"secret"`
+	RecoveryCodes []string   `db:"recovery_codes"` // sha256 hashes of the unused codes
+	EnabledAt     *time.Time `db:"enabled_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+}
+
+func (t *UserTOTP) Enabled() bool {
+	if t != nil {
+		return t.EnabledAt != nil
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		if number, ok := formatNumber(value); ok {
+			record[i] = number
+			continue
+		}
+		record[i] = escapeFormula(formatString(value))
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeFormula keeps spreadsheet apps from running text such as =HYPERLINK(...) typed into a
+// description as a formula when the csv is opened, numbers are written as numbers and never escaped
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
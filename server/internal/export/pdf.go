@@ -0,0 +1,231 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 595.28 // A4 in points
+	pdfPageHeight = 841.89
+	pdfMargin     = 50
+	pdfFieldWidth = 120 // label column of Field
+
+	// the average glyph width of Helvetica relative to the font size, used to cut cells
+	// that don't fit their column without embedding the font metrics
+	pdfGlyphWidth = 0.55
+)
+
+// PDF lays out lines of text top to bottom on A4 pages and starts a new page when one is full.
+// It uses the standard Helvetica fonts every reader has, so no font file is embedded and the text
+// is limited to Latin-1, any other character is written as '?'.
+type PDF struct {
+	pages   []*bytes.Buffer
+	page    *bytes.Buffer
+	y       float64   // baseline of the last line
+	columns []float64 // widths of the current table
+	header  []string  // repeated at the top of every page the table continues on
+}
+
+func NewPDF() *PDF {
+	p := &PDF{}
+	p.newPage()
+
+	return p
+}
+
+func (p *PDF) newPage() {
+	p.page = new(bytes.Buffer)
+	p.pages = append(p.pages, p.page)
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// next moves to the baseline of a line of the size, it returns true when that needed a new page
+func (p *PDF) next(size float64) bool {
+	height := size * 1.5
+	if p.y-height < pdfMargin {
+		p.newPage()
+		p.y -= size
+		return true
+	}
+
+	p.y -= height
+	return false
+}
+
+func (p *PDF) text(x, size float64, bold bool, str string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.page, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, pdfNumber(size), pdfNumber(x), pdfNumber(p.y), pdfEscape(str))
+}
+
+func (p *PDF) Title(str string) {
+	p.next(16)
+	p.text(pdfMargin, 16, true, str)
+	p.Space()
+}
+
+func (p *PDF) Text(str string) {
+	p.next(10)
+	p.text(pdfMargin, 10, false, str)
+}
+
+// Field writes a bold label with its value next to it, a value too long for one line is wrapped
+func (p *PDF) Field(label, value string) {
+	lines := pdfWrap(value, pdfPageWidth-2*pdfMargin-pdfFieldWidth, 10)
+	p.next(10)
+	p.text(pdfMargin, 10, true, label)
+	p.text(pdfMargin+pdfFieldWidth, 10, false, lines[0])
+	for _, line := range lines[1:] {
+		p.next(10)
+		p.text(pdfMargin+pdfFieldWidth, 10, false, line)
+	}
+}
+
+func (p *PDF) Space() {
+	p.y -= 10
+}
+
+// Table starts a table with columns of the widths in points, the header is written in bold
+// and underlined. Cells too long for their column are cut.
+func (p *PDF) Table(widths []float64, header ...string) {
+	p.columns = widths
+	p.header = header
+	p.next(9)
+	p.writeHeader()
+}
+
+func (p *PDF) writeHeader() {
+	p.cells(true, p.header)
+	fmt.Fprintf(p.page, "0.5 w %s %s m %s %s l S\n",
+		pdfNumber(pdfMargin), pdfNumber(p.y-3), pdfNumber(pdfPageWidth-pdfMargin), pdfNumber(p.y-3))
+	p.y -= 3
+}
+
+func (p *PDF) Row(cells ...string) {
+	if p.next(9) {
+		p.writeHeader()
+		p.next(9)
+	}
+	p.cells(false, cells)
+}
+
+func (p *PDF) cells(bold bool, cells []string) {
+	x := float64(pdfMargin)
+	for i, cell := range cells {
+		if i >= len(p.columns) {
+			break
+		}
+		p.text(x, 9, bold, pdfFit(cell, p.columns[i], 9))
+		x += p.columns[i]
+	}
+}
+
+// Bytes returns the document with the page number at the bottom of every page
+func (p *PDF) Bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, then a page and its content for every page
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range p.pages {
+		content := page.String() + fmt.Sprintf("BT /F1 8 Tf %s %s Td (Page %d of %d) Tj ET\n",
+			pdfNumber(pdfMargin), pdfNumber(pdfMargin/2), i+1, len(p.pages))
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNumber(pdfPageWidth), pdfNumber(pdfPageHeight), 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// pdfNumber rounds to two decimals so the sums of the line heights stay short
+func pdfNumber(v float64) string {
+	str := strconv.FormatFloat(v, 'f', 2, 64)
+	str = strings.TrimRight(str, "0")
+
+	return strings.TrimSuffix(str, ".")
+}
+
+// pdfEscape returns the text as a WinAnsi string literal without the parentheses
+func pdfEscape(str string) string {
+	var b strings.Builder
+	for _, r := range str {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// pdfWrap breaks the text into lines of about the width at spaces, a word longer than a line is cut
+func pdfWrap(str string, width, size float64) []string {
+	limit := int(width / (size * pdfGlyphWidth))
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(str) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > limit {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+		if len(line) > limit {
+			line = []rune(pdfFit(string(line), width, size))
+		}
+	}
+
+	return append(lines, string(line))
+}
+
+// pdfFit cuts the text to about the width, leaving a small gap to the next column
+func pdfFit(str string, width, size float64) string {
+	limit := int((width - 6) / (size * pdfGlyphWidth))
+	runes := []rune(str)
+	if len(runes) <= limit {
+		return str
+	}
+	if limit <= 3 {
+		return string(runes[:max(limit, 0)])
+	}
+
+	return string(runes[:limit-3]) + "..."
+}
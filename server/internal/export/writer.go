@@ -0,0 +1,58 @@
+package export
+
+import (
+	"expense-management-system/internal/entity"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Writer writes the rows of a spreadsheet one at a time so an export never holds every row in memory.
+// A value is a string, an integer, a float or nil for an empty cell.
+type Writer interface {
+	Write(row []any) error
+	Close() error
+}
+
+func NewWriter(format entity.ExportFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case entity.ExportFormatCSV:
+		return NewCSVWriter(w), nil
+	case entity.ExportFormatXLSX:
+		return NewXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format = %s", format)
+	}
+}
+
+// formatNumber returns the text of a numeric value, ok is false for any other type
+func formatNumber(value any) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func formatString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,117 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/export"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	notes := "paid, in cash"
+
+	var buf bytes.Buffer
+	w := export.NewCSVWriter(&buf)
+	require.NoError(t, w.Write([]any{"ID", "Description", "Amount (IDR)", "Notes"}))
+	require.NoError(t, w.Write([]any{uint64(1), "=HYPERLINK(\"http://evil\")", uint64(15000), &notes}))
+	require.NoError(t, w.Write([]any{uint64(2), "-taxi", int64(-2500), (*string)(nil)}))
+	require.NoError(t, w.Close())
+
+	want := "ID,Description,Amount (IDR),Notes\n" +
+		"1,\"'=HYPERLINK(\"\"http://evil\"\")\",15000,\"paid, in cash\"\n" +
+		"2,'-taxi,-2500,\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(entity.ExportFormatXLSX, &buf)
+	require.NoError(t, err)
+
+	header := make([]any, 28)
+	for i := range header {
+		header[i] = "H"
+	}
+	require.NoError(t, w.Write(header))
+	require.NoError(t, w.Write([]any{uint64(1), "Taxi <airport> & back", nil, 12.5}))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	var names []string
+	var sheet string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			sheet = string(content)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+	}, names)
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">H</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="AB1" t="inlineStr" s="1">`)
+	assert.Contains(t, sheet, `<row r="2"><c r="A2"><v>1</v></c>`+
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">Taxi &lt;airport&gt; &amp; back</t></is></c>`+
+		`<c r="D2"><v>12.5</v></c></row>`)
+	assert.Contains(t, sheet, `</sheetData></worksheet>`)
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := export.NewWriter(entity.ExportFormat("pdf"), io.Discard)
+	assert.EqualError(t, err, "unsupported export format = pdf")
+}
+
+func TestPDF(t *testing.T) {
+	p := export.NewPDF()
+	p.Title("Reimbursement Statement (2025-09)")
+	p.Field("Employee", "Budi Santoso")
+	p.Field("Description", "Flight, hotel and taxi for the quarterly meeting with the regional sales team in Surabaya")
+	p.Table([]float64{100, 395}, "Reference", "Description")
+	for i := 0; i < 80; i++ {
+		p.Row("EXP-000000007", "Taxi to the airport — and back, a description far too long to fit in its column of the table")
+	}
+
+	content := string(p.Bytes())
+
+	assert.True(t, strings.HasPrefix(content, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(content, "%%EOF\n"))
+	assert.Contains(t, content, "<< /Type /Pages /Kids [5 0 R 7 0 R] /Count 2 >>")
+	assert.Contains(t, content, "BT /F2 16 Tf 50 767.89 Td (Reimbursement Statement \\(2025-09\\)) Tj ET\n")
+	assert.Contains(t, content, "BT /F2 10 Tf 50 742.89 Td (Employee) Tj ET\nBT /F1 10 Tf 170 742.89 Td (Budi Santoso) Tj ET\n")
+	// a long field value is wrapped at a space under the start of the value
+	assert.Contains(t, content, "BT /F1 10 Tf 170 727.89 Td (Flight, hotel and taxi for the quarterly meeting with the regional) Tj ET\n"+
+		"BT /F1 10 Tf 170 712.89 Td (sales team in Surabaya) Tj ET\n")
+	assert.Contains(t, content, "(Taxi to the airport ? and back, a description far too long to fit in its co...) Tj")
+	assert.Contains(t, content, "(Page 2 of 2) Tj")
+	// the header is repeated on the second page
+	assert.Equal(t, 2, strings.Count(content, "(Reference) Tj"))
+
+	// every object is where the cross reference table says it is
+	xref := content[strings.Index(content, "\nxref\n")+1:]
+	lines := strings.Split(xref, "\n")
+	for i, line := range lines[3:11] {
+		offset, err := strconv.Atoi(line[:10])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(content[offset:], fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+}
@@ -0,0 +1,168 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// the parts of a workbook with a single sheet, the sheet itself is written row by row
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		// style 1 is the bold font of the header row
+		name: "xl/styles.xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`,
+	},
+}
+
+const (
+	xlsxSheetName   = "xl/worksheets/sheet1.xml"
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// xlsxWriter writes a workbook without a shared strings table so rows don't have to be kept until
+// the end, text is written as inline strings and the first row is the bold header
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+func NewXLSXWriter(w io.Writer) (Writer, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s = %w", part.name, err)
+		}
+
+		_, err = io.WriteString(pw, part.content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s = %w", part.name, err)
+		}
+	}
+
+	sw, err := zw.Create(xlsxSheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s = %w", xlsxSheetName, err)
+	}
+
+	x := &xlsxWriter{zip: zw, sheet: bufio.NewWriter(sw)}
+	_, err = x.sheet.WriteString(xlsxSheetHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s = %w", xlsxSheetName, err)
+	}
+
+	return x, nil
+}
+
+func (x *xlsxWriter) Write(row []any) error {
+	x.rows++
+
+	style := ""
+	if x.rows == 1 {
+		style = ` s="1"`
+	}
+
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows)
+	for i, value := range row {
+		ref := columnName(i) + strconv.Itoa(x.rows)
+
+		if number, ok := formatNumber(value); ok {
+			fmt.Fprintf(x.sheet, `<c r="%s"%s><v>%s</v></c>`, ref, style, number)
+			continue
+		}
+
+		text := formatString(value)
+		if text == "" {
+			continue
+		}
+
+		fmt.Fprintf(x.sheet, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">`, ref, style)
+		err := xml.EscapeText(x.sheet, []byte(text))
+		if err != nil {
+			return err
+		}
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	_, err := x.sheet.WriteString(xlsxSheetFooter)
+	if err != nil {
+		return err
+	}
+
+	err = x.sheet.Flush()
+	if err != nil {
+		return err
+	}
+
+	return x.zip.Close()
+}
+
+// columnName turns a zero based index into the letters of the column (0 -> A, 26 -> AA)
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+
+	return name
+}
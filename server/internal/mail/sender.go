@@ -0,0 +1,117 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+var ErrMailDriverNotSet = errors.New("mail driver is not set")
+
+// ParseDriver refuses an empty driver so a deployment can't fall back to the log sender by accident
+func ParseDriver(str string) (string, error) {
+	switch str {
+	case DriverSMTP, DriverLog:
+		return str, nil
+	case "":
+		return "", ErrMailDriverNotSet
+	default:
+		return "", fmt.Errorf("invalid mail driver = %s", str)
+	}
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockery --name=Sender --structname Sender --outpkg=mocks --output=./../mocks
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type smtpSender struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPSender(cfg SMTPConfig) Sender {
+	return &smtpSender{
+		cfg: cfg,
+	}
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// no auth for local relays such as mailpit
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	err := smtp.SendMail(addr, auth, s.cfg.From, []string{msg.To}, buildMessage(s.cfg.From, msg))
+	if err != nil {
+		return fmt.Errorf("failed to send mail to (%s) = %w", msg.To, err)
+	}
+
+	return nil
+}
+
+type logSender struct {
+	log *zap.Logger
+}
+
+// NewLogSender writes mails to the log instead of delivering them, only meant for local development.
+// The body is left out because it can carry secrets such as a password reset link
+func NewLogSender(log *zap.Logger) Sender {
+	return &logSender{
+		log: log,
+	}
+}
+
+func (s *logSender) Send(ctx context.Context, msg *Message) error {
+	s.log.Info("mail sent",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.Int("body_length", len(msg.Body)),
+		zap.Strings("tags", []string{"mail", "log"}),
+	)
+
+	return nil
+}
+
+func buildMessage(from string, msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
@@ -0,0 +1,184 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"expense-management-system/internal/mail"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeSMTPServer accepts a single plain-text session and records the envelope and data
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen = %v", err)
+	}
+
+	s := &fakeSMTPServer{listener: l, done: make(chan struct{})}
+	go s.serve()
+	t.Cleanup(func() { l.Close() })
+
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(line string) {
+		w.WriteString(line + "\r\n")
+		w.Flush()
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var b strings.Builder
+			for {
+				dl, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dl == ".\r\n" {
+					break
+				}
+				b.WriteString(dl)
+			}
+			s.data = b.String()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	sender := mail.NewSMTPSender(mail.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "no-reply@mail.com",
+	})
+
+	err := sender.Send(context.Background(), &mail.Message{
+		To:      "john@mail.com",
+		Subject: "Reset your password",
+		Body:    "Hello John,\nclick the link below",
+	})
+	<-server.done
+
+	assert.NoError(t, err)
+	assert.Equal(t, "no-reply@mail.com", server.from)
+	assert.Equal(t, []string{"john@mail.com"}, server.to)
+	assert.Contains(t, server.data, "To: john@mail.com\r\n")
+	assert.Contains(t, server.data, "Subject: Reset your password\r\n")
+	assert.Contains(t, server.data, "Hello John,\r\nclick the link below")
+}
+
+func TestSMTPSender_SendError(t *testing.T) {
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	sender := mail.NewSMTPSender(mail.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: port,
+		From: "no-reply@mail.com",
+	})
+
+	err := sender.Send(context.Background(), &mail.Message{To: "john@mail.com"})
+
+	assert.ErrorContains(t, err, "failed to send mail to (john@mail.com)")
+}
+
+func TestLogSender_Send(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	sender := mail.NewLogSender(zap.New(core))
+
+	err := sender.Send(context.Background(), &mail.Message{
+		To:      "john@mail.com",
+		Subject: "Reset your password",
+		Body:    "http://localhost:5173/reset-password?token=secret",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "john@mail.com", fields["to"])
+	assert.NotContains(t, fmt.Sprint(fields), "secret")
+}
+
+func TestParseDriver(t *testing.T) {
+	tests := []struct {
+		name       string
+		driver     string
+		want       string
+		wantErrMsg string
+	}{
+		{name: "smtp", driver: "smtp", want: mail.DriverSMTP},
+		{name: "log", driver: "log", want: mail.DriverLog},
+		{name: "error not set", driver: "", wantErrMsg: "mail driver is not set"},
+		{name: "error invalid", driver: "sendmail", wantErrMsg: "invalid mail driver = sendmail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := mail.ParseDriver(tt.driver)
+
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, driver)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"expense-management-system/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type ExpenseCommentedProducer struct {
+	Producer[*model.ExpenseCommentedEvent]
+}
+
+func NewExpenseCommentedProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *ExpenseCommentedProducer {
+	return &ExpenseCommentedProducer{
+		Producer: &producer[*model.ExpenseCommentedEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package messaging_test
+
+import (
+	"errors"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseCommentedProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer messaging.Producer[*model.ExpenseCommentedEvent]
+	topic    string
+}
+
+func (s *ExpenseCommentedProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "expense-commented"
+	s.producer = messaging.NewExpenseCommentedProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *ExpenseCommentedProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *ExpenseCommentedProducerSuite) TestExpenseCommentedProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("expense-commented", *t)
+}
+
+func (s *ExpenseCommentedProducerSuite) TestExpenseCommentedProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.ExpenseCommentedEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.ExpenseCommentedEvent{
+				ID:               8,
+				OrgID:            1,
+				ExpenseID:        7,
+				UserID:           3,
+				MentionedUserIDs: []uint64{4},
+				RecipientIDs:     []uint64{2, 4},
+			},
+			wantErrMsg: "failed to produce message for expense-commented = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.ExpenseCommentedEvent{
+				ID:               8,
+				OrgID:            1,
+				ExpenseID:        7,
+				UserID:           3,
+				MentionedUserIDs: []uint64{4},
+				RecipientIDs:     []uint64{2, 4},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewExpenseCommentedProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestExpenseCommentedProducerSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseCommentedProducerSuite))
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"expense-management-system/internal/model"
+
+	"go.uber.org/zap"
+)
+
+type ExpenseExportRequestedProducer struct {
+	Producer[*model.ExpenseExportRequestedEvent]
+}
+
+func NewExpenseExportRequestedProducer(logger *zap.Logger, kProducer KafkaProducer, topic string) *ExpenseExportRequestedProducer {
+	return &ExpenseExportRequestedProducer{
+		Producer: &producer[*model.ExpenseExportRequestedEvent]{
+			Producer: kProducer,
+			Topic:    topic,
+			Log:      logger,
+		},
+	}
+}
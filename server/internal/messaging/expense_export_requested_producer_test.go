@@ -0,0 +1,93 @@
+package messaging_test
+
+import (
+	"errors"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseExportRequestedProducerSuite struct {
+	suite.Suite
+	logger   *zap.Logger
+	kafka    *mocks.KafkaProducer
+	producer *messaging.ExpenseExportRequestedProducer
+	topic    string
+}
+
+func (s *ExpenseExportRequestedProducerSuite) SetupTest() {
+	s.logger, _ = zap.NewDevelopment()
+	s.kafka = mocks.NewKafkaProducer(s.T())
+	s.topic = "expense-export-requested"
+	s.producer = messaging.NewExpenseExportRequestedProducer(s.logger, s.kafka, s.topic)
+}
+
+func (s *ExpenseExportRequestedProducerSuite) TearDownTest() {
+	s.kafka = mocks.NewKafkaProducer(s.T())
+}
+
+func (s *ExpenseExportRequestedProducerSuite) TestExpenseExportRequestedProducer_GetTopic() {
+	t := s.producer.GetTopic()
+
+	s.Equal("expense-export-requested", *t)
+}
+
+func (s *ExpenseExportRequestedProducerSuite) TestExpenseExportRequestedProducer_Send() {
+	tests := []struct {
+		name       string
+		mockFunc   func(k *mocks.KafkaProducer)
+		param      *model.ExpenseExportRequestedEvent
+		wantErrMsg string
+	}{
+		{
+			name: "error on produce",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			param: &model.ExpenseExportRequestedEvent{
+				ID:     5,
+				OrgID:  1,
+				UserID: 2,
+			},
+			wantErrMsg: "failed to produce message for expense-export-requested = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(k *mocks.KafkaProducer) {
+				k.On("Produce", mock.Anything, mock.Anything).Return(nil)
+			},
+			param: &model.ExpenseExportRequestedEvent{
+				ID:     5,
+				OrgID:  1,
+				UserID: 2,
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.kafka = mocks.NewKafkaProducer(s.T())
+			s.producer = messaging.NewExpenseExportRequestedProducer(s.logger, s.kafka, s.topic)
+			tt.mockFunc(s.kafka)
+
+			err := s.producer.Send(tt.param)
+
+			if tt.wantErrMsg == "" {
+				s.Nil(err)
+			} else {
+				s.Equal(tt.wantErrMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestExpenseExportRequestedProducerSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseExportRequestedProducerSuite))
+}
@@ -30,7 +30,8 @@ var (
 )
 
 const (
-	EventPusblishExpenseApprove = "publish_expense_approve"
+	EventPusblishExpenseApprove  = "publish_expense_approve"
+	EventPublishExpenseCommented = "publish_expense_commented"
 )
 
 func Init() {
@@ -0,0 +1,120 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AnalyticsRepository is an autogenerated mock type for the AnalyticsRepository type
+type AnalyticsRepository struct {
+	mock.Mock
+}
+
+// GroupStats provides a mock function with given fields: ctx, req
+func (_m *AnalyticsRepository) GroupStats(ctx context.Context, req *model.AnalyticsRequest) ([]entity.ExpenseGroupStat, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GroupStats")
+	}
+
+	var r0 []entity.ExpenseGroupStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) ([]entity.ExpenseGroupStat, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) []entity.ExpenseGroupStat); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseGroupStat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AnalyticsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OldestPending provides a mock function with given fields: ctx, req, limit
+func (_m *AnalyticsRepository) OldestPending(ctx context.Context, req *model.AnalyticsRequest, limit int) ([]entity.ExpenseWithUser, error) {
+	ret := _m.Called(ctx, req, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OldestPending")
+	}
+
+	var r0 []entity.ExpenseWithUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest, int) ([]entity.ExpenseWithUser, error)); ok {
+		return rf(ctx, req, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest, int) []entity.ExpenseWithUser); ok {
+		r0 = rf(ctx, req, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseWithUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AnalyticsRequest, int) error); ok {
+		r1 = rf(ctx, req, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Turnaround provides a mock function with given fields: ctx, req
+func (_m *AnalyticsRepository) Turnaround(ctx context.Context, req *model.AnalyticsRequest) (*entity.ExpenseTurnaround, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Turnaround")
+	}
+
+	var r0 *entity.ExpenseTurnaround
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) (*entity.ExpenseTurnaround, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) *entity.ExpenseTurnaround); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ExpenseTurnaround)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AnalyticsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAnalyticsRepository creates a new instance of AnalyticsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnalyticsRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AnalyticsRepository {
+	mock := &AnalyticsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
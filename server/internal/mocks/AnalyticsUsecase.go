@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AnalyticsUsecase is an autogenerated mock type for the AnalyticsUsecase type
+type AnalyticsUsecase struct {
+	mock.Mock
+}
+
+// Breakdown provides a mock function with given fields: ctx, req
+func (_m *AnalyticsUsecase) Breakdown(ctx context.Context, req *model.AnalyticsRequest) ([]model.AnalyticsGroupResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Breakdown")
+	}
+
+	var r0 []model.AnalyticsGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) ([]model.AnalyticsGroupResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) []model.AnalyticsGroupResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AnalyticsGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AnalyticsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Summary provides a mock function with given fields: ctx, req
+func (_m *AnalyticsUsecase) Summary(ctx context.Context, req *model.AnalyticsRequest) (*model.AnalyticsSummaryResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Summary")
+	}
+
+	var r0 *model.AnalyticsSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) (*model.AnalyticsSummaryResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.AnalyticsRequest) *model.AnalyticsSummaryResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AnalyticsSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.AnalyticsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAnalyticsUsecase creates a new instance of AnalyticsUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnalyticsUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AnalyticsUsecase {
+	mock := &AnalyticsUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
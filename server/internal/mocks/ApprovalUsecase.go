@@ -50,6 +50,24 @@ func (_m *ApprovalUsecase) Reject(ctx context.Context, req *model.ApprovalExpens
 	return r0
 }
 
+// RequestChanges provides a mock function with given fields: ctx, req
+func (_m *ApprovalUsecase) RequestChanges(ctx context.Context, req *model.RequestChangesExpenseRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RequestChangesExpenseRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewApprovalUsecase creates a new instance of ApprovalUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApprovalUsecase(t interface {
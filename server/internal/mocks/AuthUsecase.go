@@ -62,6 +62,54 @@ func (_m *AuthUsecase) Logout(ctx context.Context, req *model.LogoutRequest) err
 	return r0
 }
 
+// Unlock provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) Unlock(ctx context.Context, req *model.UnlockLoginRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UnlockLoginRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyTwoFactor provides a mock function with given fields: ctx, req
+func (_m *AuthUsecase) VerifyTwoFactor(ctx context.Context, req *model.VerifyTwoFactorRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTwoFactor")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VerifyTwoFactorRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.VerifyTwoFactorRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.VerifyTwoFactorRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthUsecase creates a new instance of AuthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthUsecase(t interface {
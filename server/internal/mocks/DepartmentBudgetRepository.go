@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DepartmentBudgetRepository is an autogenerated mock type for the DepartmentBudgetRepository type
+type DepartmentBudgetRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, budget
+func (_m *DepartmentBudgetRepository) Create(ctx context.Context, budget *entity.DepartmentBudget) (bool, error) {
+	ret := _m.Called(ctx, budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.DepartmentBudget) (bool, error)); ok {
+		return rf(ctx, budget)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.DepartmentBudget) bool); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.DepartmentBudget) error); ok {
+		r1 = rf(ctx, budget)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByDate provides a mock function with given fields: ctx, orgID, departmentID, date
+func (_m *DepartmentBudgetRepository) FindByDate(ctx context.Context, orgID uint64, departmentID uint64, date time.Time) (*entity.DepartmentBudget, error) {
+	ret := _m.Called(ctx, orgID, departmentID, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByDate")
+	}
+
+	var r0 *entity.DepartmentBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time) (*entity.DepartmentBudget, error)); ok {
+		return rf(ctx, orgID, departmentID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time) *entity.DepartmentBudget); ok {
+		r0 = rf(ctx, orgID, departmentID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DepartmentBudget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, time.Time) error); ok {
+		r1 = rf(ctx, orgID, departmentID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByDateWithLock provides a mock function with given fields: ctx, exec, orgID, departmentID, date
+func (_m *DepartmentBudgetRepository) FindByDateWithLock(ctx context.Context, exec db.Executor, orgID uint64, departmentID uint64, date time.Time) (*entity.DepartmentBudget, error) {
+	ret := _m.Called(ctx, exec, orgID, departmentID, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByDateWithLock")
+	}
+
+	var r0 *entity.DepartmentBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64, time.Time) (*entity.DepartmentBudget, error)); ok {
+		return rf(ctx, exec, orgID, departmentID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64, time.Time) *entity.DepartmentBudget); ok {
+		r0 = rf(ctx, exec, orgID, departmentID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DepartmentBudget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, uint64, time.Time) error); ok {
+		r1 = rf(ctx, exec, orgID, departmentID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByDepartmentID provides a mock function with given fields: ctx, orgID, departmentID
+func (_m *DepartmentBudgetRepository) ListByDepartmentID(ctx context.Context, orgID uint64, departmentID uint64) ([]entity.DepartmentBudget, error) {
+	ret := _m.Called(ctx, orgID, departmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByDepartmentID")
+	}
+
+	var r0 []entity.DepartmentBudget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]entity.DepartmentBudget, error)); ok {
+		return rf(ctx, orgID, departmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []entity.DepartmentBudget); ok {
+		r0 = rf(ctx, orgID, departmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DepartmentBudget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, departmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDepartmentBudgetRepository creates a new instance of DepartmentBudgetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDepartmentBudgetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DepartmentBudgetRepository {
+	mock := &DepartmentBudgetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
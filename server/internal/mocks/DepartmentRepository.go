@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DepartmentRepository is an autogenerated mock type for the DepartmentRepository type
+type DepartmentRepository struct {
+	mock.Mock
+}
+
+// CountByNameOrCostCenter provides a mock function with given fields: ctx, orgID, id, name, costCenter
+func (_m *DepartmentRepository) CountByNameOrCostCenter(ctx context.Context, orgID uint64, id uint64, name string, costCenter string) (int, error) {
+	ret := _m.Called(ctx, orgID, id, name, costCenter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByNameOrCostCenter")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, string, string) (int, error)); ok {
+		return rf(ctx, orgID, id, name, costCenter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, string, string) int); ok {
+		r0 = rf(ctx, orgID, id, name, costCenter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, string, string) error); ok {
+		r1 = rf(ctx, orgID, id, name, costCenter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, department
+func (_m *DepartmentRepository) Create(ctx context.Context, department *entity.Department) error {
+	ret := _m.Called(ctx, department)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Department) error); ok {
+		r0 = rf(ctx, department)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, orgID, id
+func (_m *DepartmentRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Department, error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.Department
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.Department, error)); ok {
+		return rf(ctx, orgID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.Department); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Department)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, orgID
+func (_m *DepartmentRepository) List(ctx context.Context, orgID uint64) ([]entity.Department, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.Department
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]entity.Department, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []entity.Department); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Department)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, department
+func (_m *DepartmentRepository) Update(ctx context.Context, department *entity.Department) error {
+	ret := _m.Called(ctx, department)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Department) error); ok {
+		r0 = rf(ctx, department)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDepartmentRepository creates a new instance of DepartmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDepartmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DepartmentRepository {
+	mock := &DepartmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
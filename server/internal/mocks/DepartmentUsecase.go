@@ -0,0 +1,179 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DepartmentUsecase is an autogenerated mock type for the DepartmentUsecase type
+type DepartmentUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *DepartmentUsecase) Create(ctx context.Context, req *model.CreateDepartmentRequest) (*model.DepartmentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.DepartmentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateDepartmentRequest) (*model.DepartmentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateDepartmentRequest) *model.DepartmentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DepartmentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateDepartmentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBudget provides a mock function with given fields: ctx, req
+func (_m *DepartmentUsecase) CreateBudget(ctx context.Context, req *model.CreateDepartmentBudgetRequest) (*model.DepartmentBudgetResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBudget")
+	}
+
+	var r0 *model.DepartmentBudgetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateDepartmentBudgetRequest) (*model.DepartmentBudgetResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateDepartmentBudgetRequest) *model.DepartmentBudgetResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DepartmentBudgetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateDepartmentBudgetRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, req
+func (_m *DepartmentUsecase) List(ctx context.Context, req *model.ListDepartmentRequest) ([]model.DepartmentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.DepartmentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListDepartmentRequest) ([]model.DepartmentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListDepartmentRequest) []model.DepartmentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DepartmentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListDepartmentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBudgets provides a mock function with given fields: ctx, req
+func (_m *DepartmentUsecase) ListBudgets(ctx context.Context, req *model.ListDepartmentBudgetRequest) ([]model.DepartmentBudgetResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBudgets")
+	}
+
+	var r0 []model.DepartmentBudgetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListDepartmentBudgetRequest) ([]model.DepartmentBudgetResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListDepartmentBudgetRequest) []model.DepartmentBudgetResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DepartmentBudgetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListDepartmentBudgetRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *DepartmentUsecase) Update(ctx context.Context, req *model.UpdateDepartmentRequest) (*model.DepartmentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.DepartmentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateDepartmentRequest) (*model.DepartmentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateDepartmentRequest) *model.DepartmentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DepartmentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateDepartmentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDepartmentUsecase creates a new instance of DepartmentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDepartmentUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DepartmentUsecase {
+	mock := &DepartmentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
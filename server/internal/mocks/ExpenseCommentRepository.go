@@ -0,0 +1,165 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExpenseCommentRepository is an autogenerated mock type for the ExpenseCommentRepository type
+type ExpenseCommentRepository struct {
+	mock.Mock
+}
+
+// CreateTx provides a mock function with given fields: ctx, exec, comment
+func (_m *ExpenseCommentRepository) CreateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment) error {
+	ret := _m.Called(ctx, exec, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.ExpenseComment) error); ok {
+		r0 = rf(ctx, exec, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, orgID, id, userID, window
+func (_m *ExpenseCommentRepository) Delete(ctx context.Context, orgID uint64, id uint64, userID uint64, window time.Duration) (bool, error) {
+	ret := _m.Called(ctx, orgID, id, userID, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64, time.Duration) (bool, error)); ok {
+		return rf(ctx, orgID, id, userID, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64, time.Duration) bool); ok {
+		r0 = rf(ctx, orgID, id, userID, window)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint64, time.Duration) error); ok {
+		r1 = rf(ctx, orgID, id, userID, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, orgID, expenseID, id
+func (_m *ExpenseCommentRepository) FindByID(ctx context.Context, orgID uint64, expenseID uint64, id uint64) (*entity.ExpenseComment, error) {
+	ret := _m.Called(ctx, orgID, expenseID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.ExpenseComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) (*entity.ExpenseComment, error)); ok {
+		return rf(ctx, orgID, expenseID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) *entity.ExpenseComment); ok {
+		r0 = rf(ctx, orgID, expenseID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ExpenseComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, expenseID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByExpenseID provides a mock function with given fields: ctx, orgID, expenseID
+func (_m *ExpenseCommentRepository) ListByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) ([]entity.ExpenseComment, error) {
+	ret := _m.Called(ctx, orgID, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByExpenseID")
+	}
+
+	var r0 []entity.ExpenseComment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]entity.ExpenseComment, error)); ok {
+		return rf(ctx, orgID, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []entity.ExpenseComment); ok {
+		r0 = rf(ctx, orgID, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTx provides a mock function with given fields: ctx, exec, comment, window
+func (_m *ExpenseCommentRepository) UpdateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment, window time.Duration) (bool, error) {
+	ret := _m.Called(ctx, exec, comment, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.ExpenseComment, time.Duration) (bool, error)); ok {
+		return rf(ctx, exec, comment, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.ExpenseComment, time.Duration) bool); ok {
+		r0 = rf(ctx, exec, comment, window)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, *entity.ExpenseComment, time.Duration) error); ok {
+		r1 = rf(ctx, exec, comment, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewExpenseCommentRepository creates a new instance of ExpenseCommentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpenseCommentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpenseCommentRepository {
+	mock := &ExpenseCommentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
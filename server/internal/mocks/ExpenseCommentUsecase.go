@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExpenseCommentUsecase is an autogenerated mock type for the ExpenseCommentUsecase type
+type ExpenseCommentUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *ExpenseCommentUsecase) Create(ctx context.Context, req *model.CreateExpenseCommentRequest) (*model.ExpenseCommentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.ExpenseCommentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseCommentRequest) (*model.ExpenseCommentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseCommentRequest) *model.ExpenseCommentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExpenseCommentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateExpenseCommentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, req
+func (_m *ExpenseCommentUsecase) Delete(ctx context.Context, req *model.DeleteExpenseCommentRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeleteExpenseCommentRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, req
+func (_m *ExpenseCommentUsecase) List(ctx context.Context, req *model.GetExpenseRequest) ([]model.ExpenseCommentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.ExpenseCommentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseRequest) ([]model.ExpenseCommentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseRequest) []model.ExpenseCommentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ExpenseCommentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetExpenseRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *ExpenseCommentUsecase) Update(ctx context.Context, req *model.UpdateExpenseCommentRequest) (*model.ExpenseCommentResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.ExpenseCommentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateExpenseCommentRequest) (*model.ExpenseCommentResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateExpenseCommentRequest) *model.ExpenseCommentResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExpenseCommentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateExpenseCommentRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewExpenseCommentUsecase creates a new instance of ExpenseCommentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpenseCommentUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpenseCommentUsecase {
+	mock := &ExpenseCommentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
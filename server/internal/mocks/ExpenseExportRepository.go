@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExpenseExportRepository is an autogenerated mock type for the ExpenseExportRepository type
+type ExpenseExportRepository struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: ctx, id, fileKey, rowCount, completedAt
+func (_m *ExpenseExportRepository) Complete(ctx context.Context, id uint64, fileKey string, rowCount int, completedAt time.Time) error {
+	ret := _m.Called(ctx, id, fileKey, rowCount, completedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, int, time.Time) error); ok {
+		r0 = rf(ctx, id, fileKey, rowCount, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, export
+func (_m *ExpenseExportRepository) Create(ctx context.Context, export *entity.ExpenseExport) error {
+	ret := _m.Called(ctx, export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ExpenseExport) error); ok {
+		r0 = rf(ctx, export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, orgID, id
+func (_m *ExpenseExportRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseExport, error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.ExpenseExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.ExpenseExport, error)); ok {
+		return rf(ctx, orgID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.ExpenseExport); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ExpenseExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *ExpenseExportRepository) UpdateStatus(ctx context.Context, id uint64, status entity.ExpenseExportStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, entity.ExpenseExportStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExpenseExportRepository creates a new instance of ExpenseExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpenseExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpenseExportRepository {
+	mock := &ExpenseExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
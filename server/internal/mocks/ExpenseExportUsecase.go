@@ -0,0 +1,166 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExpenseExportUsecase is an autogenerated mock type for the ExpenseExportUsecase type
+type ExpenseExportUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *ExpenseExportUsecase) Create(ctx context.Context, req *model.CreateExpenseExportRequest) (*model.ExpenseExportResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.ExpenseExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseExportRequest) (*model.ExpenseExportResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseExportRequest) *model.ExpenseExportResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExpenseExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateExpenseExportRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Download provides a mock function with given fields: ctx, req
+func (_m *ExpenseExportUsecase) Download(ctx context.Context, req *model.GetExpenseExportRequest) (*model.FileStreamResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 *model.FileStreamResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseExportRequest) (*model.FileStreamResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseExportRequest) *model.FileStreamResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileStreamResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetExpenseExportRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, req
+func (_m *ExpenseExportUsecase) Get(ctx context.Context, req *model.GetExpenseExportRequest) (*model.ExpenseExportResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.ExpenseExportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseExportRequest) (*model.ExpenseExportResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseExportRequest) *model.ExpenseExportResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExpenseExportResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetExpenseExportRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Process provides a mock function with given fields: ctx, req
+func (_m *ExpenseExportUsecase) Process(ctx context.Context, req *model.ProcessExpenseExportRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Process")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ProcessExpenseExportRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Stream provides a mock function with given fields: ctx, req, w
+func (_m *ExpenseExportUsecase) Stream(ctx context.Context, req *model.CreateExpenseExportRequest, w io.Writer) (int, error) {
+	ret := _m.Called(ctx, req, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseExportRequest, io.Writer) (int, error)); ok {
+		return rf(ctx, req, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateExpenseExportRequest, io.Writer) int); ok {
+		r0 = rf(ctx, req, w)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateExpenseExportRequest, io.Writer) error); ok {
+		r1 = rf(ctx, req, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewExpenseExportUsecase creates a new instance of ExpenseExportUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpenseExportUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpenseExportUsecase {
+	mock := &ExpenseExportUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,12 +6,10 @@ import (
 	context "context"
 	db "expense-management-system/internal/db"
 	entity "expense-management-system/internal/entity"
-
-	mock "github.com/stretchr/testify/mock"
-
 	model "expense-management-system/internal/model"
-
 	time "time"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // ExpenseRepository is an autogenerated mock type for the ExpenseRepository type
@@ -19,17 +17,17 @@ type ExpenseRepository struct {
 	mock.Mock
 }
 
-// CompleteByIDTx provides a mock function with given fields: ctx, exec, id, processedAt
-func (_m *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executor, id uint64, processedAt time.Time) error {
-	ret := _m.Called(ctx, exec, id, processedAt)
+// CompleteByIDTx provides a mock function with given fields: ctx, exec, orgID, id, processedAt
+func (_m *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64, processedAt time.Time) error {
+	ret := _m.Called(ctx, exec, orgID, id, processedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CompleteByIDTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time) error); ok {
-		r0 = rf(ctx, exec, id, processedAt)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64, time.Time) error); ok {
+		r0 = rf(ctx, exec, orgID, id, processedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -37,6 +35,34 @@ func (_m *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executo
 	return r0
 }
 
+// Count provides a mock function with given fields: ctx, req
+func (_m *ExpenseRepository) Count(ctx context.Context, req *model.ListExpenseRequest) (int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest) (int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest) int); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListExpenseRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, expense
 func (_m *ExpenseRepository) Create(ctx context.Context, expense *entity.Expense) error {
 	ret := _m.Called(ctx, expense)
@@ -55,9 +81,27 @@ func (_m *ExpenseRepository) Create(ctx context.Context, expense *entity.Expense
 	return r0
 }
 
-// FindByID provides a mock function with given fields: ctx, id
-func (_m *ExpenseRepository) FindByID(ctx context.Context, id uint64) (*entity.Expense, error) {
-	ret := _m.Called(ctx, id)
+// CreateTx provides a mock function with given fields: ctx, exec, expense
+func (_m *ExpenseRepository) CreateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error {
+	ret := _m.Called(ctx, exec, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.Expense) error); ok {
+		r0 = rf(ctx, exec, expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, orgID, id
+func (_m *ExpenseRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Expense, error) {
+	ret := _m.Called(ctx, orgID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByID")
@@ -65,19 +109,19 @@ func (_m *ExpenseRepository) FindByID(ctx context.Context, id uint64) (*entity.E
 
 	var r0 *entity.Expense
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*entity.Expense, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.Expense, error)); ok {
+		return rf(ctx, orgID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.Expense); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.Expense); ok {
+		r0 = rf(ctx, orgID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Expense)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -85,9 +129,9 @@ func (_m *ExpenseRepository) FindByID(ctx context.Context, id uint64) (*entity.E
 	return r0, r1
 }
 
-// FindByIDWithLock provides a mock function with given fields: ctx, exec, id
-func (_m *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Executor, id uint64) (*entity.Expense, error) {
-	ret := _m.Called(ctx, exec, id)
+// FindByIDWithLock provides a mock function with given fields: ctx, exec, orgID, id
+func (_m *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Executor, orgID uint64, id uint64) (*entity.Expense, error) {
+	ret := _m.Called(ctx, exec, orgID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByIDWithLock")
@@ -95,19 +139,19 @@ func (_m *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Execu
 
 	var r0 *entity.Expense
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) (*entity.Expense, error)); ok {
-		return rf(ctx, exec, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64) (*entity.Expense, error)); ok {
+		return rf(ctx, exec, orgID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64) *entity.Expense); ok {
-		r0 = rf(ctx, exec, id)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64) *entity.Expense); ok {
+		r0 = rf(ctx, exec, orgID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Expense)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64) error); ok {
-		r1 = rf(ctx, exec, id)
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, uint64) error); ok {
+		r1 = rf(ctx, exec, orgID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,9 +159,9 @@ func (_m *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Execu
 	return r0, r1
 }
 
-// FindDetailByID provides a mock function with given fields: ctx, id
-func (_m *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*entity.ExpenseDetail, error) {
-	ret := _m.Called(ctx, id)
+// FindDetailByID provides a mock function with given fields: ctx, orgID, id
+func (_m *ExpenseRepository) FindDetailByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseDetail, error) {
+	ret := _m.Called(ctx, orgID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindDetailByID")
@@ -125,19 +169,19 @@ func (_m *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*en
 
 	var r0 *entity.ExpenseDetail
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*entity.ExpenseDetail, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.ExpenseDetail, error)); ok {
+		return rf(ctx, orgID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.ExpenseDetail); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.ExpenseDetail); ok {
+		r0 = rf(ctx, orgID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.ExpenseDetail)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,17 +226,228 @@ func (_m *ExpenseRepository) List(ctx context.Context, req *model.ListExpenseReq
 	return r0, r1, r2
 }
 
-// UpdateStatusByIDTx provides a mock function with given fields: ctx, exec, id, status
-func (_m *ExpenseRepository) UpdateStatusByIDTx(ctx context.Context, exec db.Executor, id uint64, status entity.ExpenseStatus) error {
-	ret := _m.Called(ctx, exec, id, status)
+// ListByUserCreated provides a mock function with given fields: ctx, orgID, userID, from, to
+func (_m *ExpenseRepository) ListByUserCreated(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.Expense, error) {
+	ret := _m.Called(ctx, orgID, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserCreated")
+	}
+
+	var r0 []entity.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) ([]entity.Expense, error)); ok {
+		return rf(ctx, orgID, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) []entity.Expense); ok {
+		r0 = rf(ctx, orgID, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, orgID, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnexportedWithLock provides a mock function with given fields: ctx, exec, orgID, from, to
+func (_m *ExpenseRepository) ListUnexportedWithLock(ctx context.Context, exec db.Executor, orgID uint64, from time.Time, to time.Time) ([]entity.Expense, error) {
+	ret := _m.Called(ctx, exec, orgID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnexportedWithLock")
+	}
+
+	var r0 []entity.Expense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time, time.Time) ([]entity.Expense, error)); ok {
+		return rf(ctx, exec, orgID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, time.Time, time.Time) []entity.Expense); ok {
+		r0 = rf(ctx, exec, orgID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Expense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, exec, orgID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, req
+func (_m *ExpenseRepository) Search(ctx context.Context, req *model.SearchExpenseRequest) ([]entity.ExpenseSearchResult, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []entity.ExpenseSearchResult
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchExpenseRequest) ([]entity.ExpenseSearchResult, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchExpenseRequest) []entity.ExpenseSearchResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SearchExpenseRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.SearchExpenseRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetJournalBatchIDTx provides a mock function with given fields: ctx, exec, orgID, ids, batchID
+func (_m *ExpenseRepository) SetJournalBatchIDTx(ctx context.Context, exec db.Executor, orgID uint64, ids []uint64, batchID uint64) error {
+	ret := _m.Called(ctx, exec, orgID, ids, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetJournalBatchIDTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, []uint64, uint64) error); ok {
+		r0 = rf(ctx, exec, orgID, ids, batchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Stream provides a mock function with given fields: ctx, req, fn
+func (_m *ExpenseRepository) Stream(ctx context.Context, req *model.ListExpenseRequest, fn func(*entity.ExpenseDetail) error) error {
+	ret := _m.Called(ctx, req, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest, func(*entity.ExpenseDetail) error) error); ok {
+		r0 = rf(ctx, req, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SumByUserMonth provides a mock function with given fields: ctx, orgID, userID, from, to
+func (_m *ExpenseRepository) SumByUserMonth(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.ExpenseStatusTotal, error) {
+	ret := _m.Called(ctx, orgID, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByUserMonth")
+	}
+
+	var r0 []entity.ExpenseStatusTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) ([]entity.ExpenseStatusTotal, error)); ok {
+		return rf(ctx, orgID, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) []entity.ExpenseStatusTotal); ok {
+		r0 = rf(ctx, orgID, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseStatusTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, orgID, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumByUserStatus provides a mock function with given fields: ctx, orgID, userID
+func (_m *ExpenseRepository) SumByUserStatus(ctx context.Context, orgID uint64, userID uint64) ([]entity.ExpenseStatusTotal, error) {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByUserStatus")
+	}
+
+	var r0 []entity.ExpenseStatusTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]entity.ExpenseStatusTotal, error)); ok {
+		return rf(ctx, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []entity.ExpenseStatusTotal); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseStatusTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusByIDTx provides a mock function with given fields: ctx, exec, orgID, id, status
+func (_m *ExpenseRepository) UpdateStatusByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64, status entity.ExpenseStatus) error {
+	ret := _m.Called(ctx, exec, orgID, id, status)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatusByIDTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, entity.ExpenseStatus) error); ok {
-		r0 = rf(ctx, exec, id, status)
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64, entity.ExpenseStatus) error); ok {
+		r0 = rf(ctx, exec, orgID, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTx provides a mock function with given fields: ctx, exec, expense
+func (_m *ExpenseRepository) UpdateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error {
+	ret := _m.Called(ctx, exec, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.Expense) error); ok {
+		r0 = rf(ctx, exec, expense)
 	} else {
 		r0 = ret.Error(0)
 	}
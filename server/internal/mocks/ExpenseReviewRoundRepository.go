@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExpenseReviewRoundRepository is an autogenerated mock type for the ExpenseReviewRoundRepository type
+type ExpenseReviewRoundRepository struct {
+	mock.Mock
+}
+
+// CreateTx provides a mock function with given fields: ctx, exec, round
+func (_m *ExpenseReviewRoundRepository) CreateTx(ctx context.Context, exec db.Executor, round *entity.ExpenseReviewRound) error {
+	ret := _m.Called(ctx, exec, round)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.ExpenseReviewRound) error); ok {
+		r0 = rf(ctx, exec, round)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByExpenseID provides a mock function with given fields: ctx, orgID, expenseID
+func (_m *ExpenseReviewRoundRepository) ListByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) ([]entity.ExpenseReviewRound, error) {
+	ret := _m.Called(ctx, orgID, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByExpenseID")
+	}
+
+	var r0 []entity.ExpenseReviewRound
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]entity.ExpenseReviewRound, error)); ok {
+		return rf(ctx, orgID, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []entity.ExpenseReviewRound); ok {
+		r0 = rf(ctx, orgID, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ExpenseReviewRound)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResubmitTx provides a mock function with given fields: ctx, exec, orgID, expenseID, resubmittedAt
+func (_m *ExpenseReviewRoundRepository) ResubmitTx(ctx context.Context, exec db.Executor, orgID uint64, expenseID uint64, resubmittedAt time.Time) error {
+	ret := _m.Called(ctx, exec, orgID, expenseID, resubmittedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResubmitTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, uint64, time.Time) error); ok {
+		r0 = rf(ctx, exec, orgID, expenseID, resubmittedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExpenseReviewRoundRepository creates a new instance of ExpenseReviewRoundRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExpenseReviewRoundRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExpenseReviewRoundRepository {
+	mock := &ExpenseReviewRoundRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -75,7 +75,7 @@ func (_m *ExpenseUsecase) FindByID(ctx context.Context, req *model.GetExpenseReq
 }
 
 // List provides a mock function with given fields: ctx, req
-func (_m *ExpenseUsecase) List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, int, error) {
+func (_m *ExpenseUsecase) List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, *model.Page, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
@@ -83,9 +83,9 @@ func (_m *ExpenseUsecase) List(ctx context.Context, req *model.ListExpenseReques
 	}
 
 	var r0 []model.ExpenseWithUserResponse
-	var r1 int
+	var r1 *model.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, *model.Page, error)); ok {
 		return rf(ctx, req)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *model.ListExpenseRequest) []model.ExpenseWithUserResponse); ok {
@@ -96,10 +96,12 @@ func (_m *ExpenseUsecase) List(ctx context.Context, req *model.ListExpenseReques
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *model.ListExpenseRequest) int); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListExpenseRequest) *model.Page); ok {
 		r1 = rf(ctx, req)
 	} else {
-		r1 = ret.Get(1).(int)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.Page)
+		}
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, *model.ListExpenseRequest) error); ok {
@@ -111,6 +113,73 @@ func (_m *ExpenseUsecase) List(ctx context.Context, req *model.ListExpenseReques
 	return r0, r1, r2
 }
 
+// Search provides a mock function with given fields: ctx, req
+func (_m *ExpenseUsecase) Search(ctx context.Context, req *model.SearchExpenseRequest) ([]model.ExpenseSearchResultResponse, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []model.ExpenseSearchResultResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchExpenseRequest) ([]model.ExpenseSearchResultResponse, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SearchExpenseRequest) []model.ExpenseSearchResultResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ExpenseSearchResultResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SearchExpenseRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.SearchExpenseRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *ExpenseUsecase) Update(ctx context.Context, req *model.UpdateExpenseRequest) (*model.ExpenseDetailResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.ExpenseDetailResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateExpenseRequest) (*model.ExpenseDetailResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateExpenseRequest) *model.ExpenseDetailResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExpenseDetailResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateExpenseRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewExpenseUsecase creates a new instance of ExpenseUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExpenseUsecase(t interface {
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	storage "expense-management-system/internal/storage"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FileStorage is an autogenerated mock type for the FileStorage type
+type FileStorage struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *FileStorage) Get(ctx context.Context, key string) (*storage.File, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *storage.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*storage.File, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *storage.File); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Open provides a mock function with given fields: ctx, key
+func (_m *FileStorage) Open(ctx context.Context, key string) (*storage.FileStream, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *storage.FileStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*storage.FileStream, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *storage.FileStream); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.FileStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, file
+func (_m *FileStorage) Put(ctx context.Context, file *storage.File) error {
+	ret := _m.Called(ctx, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.File) error); ok {
+		r0 = rf(ctx, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutStream provides a mock function with given fields: ctx, key, contentType, r
+func (_m *FileStorage) PutStream(ctx context.Context, key string, contentType string, r io.Reader) error {
+	ret := _m.Called(ctx, key, contentType, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutStream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) error); ok {
+		r0 = rf(ctx, key, contentType, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFileStorage creates a new instance of FileStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFileStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FileStorage {
+	mock := &FileStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
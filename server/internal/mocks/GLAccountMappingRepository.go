@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GLAccountMappingRepository is an autogenerated mock type for the GLAccountMappingRepository type
+type GLAccountMappingRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, orgID, id
+func (_m *GLAccountMappingRepository) Delete(ctx context.Context, orgID uint64, id uint64) (bool, error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (bool, error)); ok {
+		return rf(ctx, orgID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) bool); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, orgID
+func (_m *GLAccountMappingRepository) List(ctx context.Context, orgID uint64) ([]entity.GLAccountMapping, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.GLAccountMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]entity.GLAccountMapping, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []entity.GLAccountMapping); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.GLAccountMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, mapping
+func (_m *GLAccountMappingRepository) Upsert(ctx context.Context, mapping *entity.GLAccountMapping) error {
+	ret := _m.Called(ctx, mapping)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.GLAccountMapping) error); ok {
+		r0 = rf(ctx, mapping)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGLAccountMappingRepository creates a new instance of GLAccountMappingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGLAccountMappingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GLAccountMappingRepository {
+	mock := &GLAccountMappingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
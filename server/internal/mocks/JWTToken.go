@@ -13,9 +13,16 @@ type JWTToken struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: userID, role
-func (_m *JWTToken) Create(userID string, role string) (string, error) {
-	ret := _m.Called(userID, role)
+// Create provides a mock function with given fields: userID, orgID, role, locale, amr
+func (_m *JWTToken) Create(userID string, orgID uint64, role string, locale string, amr ...string) (string, error) {
+	_va := make([]interface{}, len(amr))
+	for _i := range amr {
+		_va[_i] = amr[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, userID, orgID, role, locale)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -23,17 +30,17 @@ func (_m *JWTToken) Create(userID string, role string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(userID, role)
+	if rf, ok := ret.Get(0).(func(string, uint64, string, string, ...string) (string, error)); ok {
+		return rf(userID, orgID, role, locale, amr...)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(userID, role)
+	if rf, ok := ret.Get(0).(func(string, uint64, string, string, ...string) string); ok {
+		r0 = rf(userID, orgID, role, locale, amr...)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userID, role)
+	if rf, ok := ret.Get(1).(func(string, uint64, string, string, ...string) error); ok {
+		r1 = rf(userID, orgID, role, locale, amr...)
 	} else {
 		r1 = ret.Error(1)
 	}
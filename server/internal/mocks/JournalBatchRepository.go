@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JournalBatchRepository is an autogenerated mock type for the JournalBatchRepository type
+type JournalBatchRepository struct {
+	mock.Mock
+}
+
+// CreateLinesTx provides a mock function with given fields: ctx, exec, batchID, lines
+func (_m *JournalBatchRepository) CreateLinesTx(ctx context.Context, exec db.Executor, batchID uint64, lines []entity.JournalLine) error {
+	ret := _m.Called(ctx, exec, batchID, lines)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLinesTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, uint64, []entity.JournalLine) error); ok {
+		r0 = rf(ctx, exec, batchID, lines)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTx provides a mock function with given fields: ctx, exec, batch
+func (_m *JournalBatchRepository) CreateTx(ctx context.Context, exec db.Executor, batch *entity.JournalBatch) error {
+	ret := _m.Called(ctx, exec, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.JournalBatch) error); ok {
+		r0 = rf(ctx, exec, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, orgID, id
+func (_m *JournalBatchRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.JournalBatch, error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.JournalBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.JournalBatch, error)); ok {
+		return rf(ctx, orgID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.JournalBatch); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.JournalBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, orgID
+func (_m *JournalBatchRepository) List(ctx context.Context, orgID uint64) ([]entity.JournalBatch, error) {
+	ret := _m.Called(ctx, orgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.JournalBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]entity.JournalBatch, error)); ok {
+		return rf(ctx, orgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []entity.JournalBatch); ok {
+		r0 = rf(ctx, orgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.JournalBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, orgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLines provides a mock function with given fields: ctx, batchID
+func (_m *JournalBatchRepository) ListLines(ctx context.Context, batchID uint64) ([]entity.JournalLine, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLines")
+	}
+
+	var r0 []entity.JournalLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]entity.JournalLine, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []entity.JournalLine); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.JournalLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewJournalBatchRepository creates a new instance of JournalBatchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJournalBatchRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JournalBatchRepository {
+	mock := &JournalBatchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,227 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JournalUsecase is an autogenerated mock type for the JournalUsecase type
+type JournalUsecase struct {
+	mock.Mock
+}
+
+// CreateBatch provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) CreateBatch(ctx context.Context, req *model.CreateJournalBatchRequest) (*model.JournalBatchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 *model.JournalBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateJournalBatchRequest) (*model.JournalBatchResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreateJournalBatchRequest) *model.JournalBatchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JournalBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreateJournalBatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMapping provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) DeleteMapping(ctx context.Context, req *model.DeleteGLAccountMappingRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMapping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeleteGLAccountMappingRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadBatch provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) DownloadBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.FileResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadBatch")
+	}
+
+	var r0 *model.FileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetJournalBatchRequest) (*model.FileResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetJournalBatchRequest) *model.FileResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetJournalBatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatch provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) GetBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.JournalBatchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatch")
+	}
+
+	var r0 *model.JournalBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetJournalBatchRequest) (*model.JournalBatchResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetJournalBatchRequest) *model.JournalBatchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JournalBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetJournalBatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBatches provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) ListBatches(ctx context.Context, req *model.ListJournalBatchRequest) ([]model.JournalBatchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBatches")
+	}
+
+	var r0 []model.JournalBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListJournalBatchRequest) ([]model.JournalBatchResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListJournalBatchRequest) []model.JournalBatchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.JournalBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListJournalBatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMappings provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) ListMappings(ctx context.Context, req *model.ListGLAccountMappingRequest) ([]model.GLAccountMappingResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMappings")
+	}
+
+	var r0 []model.GLAccountMappingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListGLAccountMappingRequest) ([]model.GLAccountMappingResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListGLAccountMappingRequest) []model.GLAccountMappingResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.GLAccountMappingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListGLAccountMappingRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertMapping provides a mock function with given fields: ctx, req
+func (_m *JournalUsecase) UpsertMapping(ctx context.Context, req *model.UpsertGLAccountMappingRequest) (*model.GLAccountMappingResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertMapping")
+	}
+
+	var r0 *model.GLAccountMappingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpsertGLAccountMappingRequest) (*model.GLAccountMappingResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpsertGLAccountMappingRequest) *model.GLAccountMappingResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GLAccountMappingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpsertGLAccountMappingRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewJournalUsecase creates a new instance of JournalUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJournalUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JournalUsecase {
+	mock := &JournalUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
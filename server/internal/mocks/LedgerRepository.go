@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LedgerRepository is an autogenerated mock type for the LedgerRepository type
+type LedgerRepository struct {
+	mock.Mock
+}
+
+// FindEntryByID provides a mock function with given fields: ctx, orgID, id
+func (_m *LedgerRepository) FindEntryByID(ctx context.Context, orgID uint64, id uint64) (*entity.LedgerEntry, error) {
+	ret := _m.Called(ctx, orgID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntryByID")
+	}
+
+	var r0 *entity.LedgerEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.LedgerEntry, error)); ok {
+		return rf(ctx, orgID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.LedgerEntry); ok {
+		r0 = rf(ctx, orgID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBalances provides a mock function with given fields: ctx, orgID, userID
+func (_m *LedgerRepository) ListBalances(ctx context.Context, orgID uint64, userID *uint64) ([]entity.LedgerBalance, error) {
+	ret := _m.Called(ctx, orgID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBalances")
+	}
+
+	var r0 []entity.LedgerBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *uint64) ([]entity.LedgerBalance, error)); ok {
+		return rf(ctx, orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *uint64) []entity.LedgerBalance); ok {
+		r0 = rf(ctx, orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LedgerBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, *uint64) error); ok {
+		r1 = rf(ctx, orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEntries provides a mock function with given fields: ctx, req
+func (_m *LedgerRepository) ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]entity.LedgerEntry, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []entity.LedgerEntry
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerEntryRequest) ([]entity.LedgerEntry, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerEntryRequest) []entity.LedgerEntry); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListLedgerEntryRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListLedgerEntryRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PostTx provides a mock function with given fields: ctx, exec, entry
+func (_m *LedgerRepository) PostTx(ctx context.Context, exec db.Executor, entry *entity.LedgerEntry) (bool, error) {
+	ret := _m.Called(ctx, exec, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.LedgerEntry) (bool, error)); ok {
+		return rf(ctx, exec, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.LedgerEntry) bool); ok {
+		r0 = rf(ctx, exec, entry)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.Executor, *entity.LedgerEntry) error); ok {
+		r1 = rf(ctx, exec, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLedgerRepository creates a new instance of LedgerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerRepository {
+	mock := &LedgerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LedgerUsecase is an autogenerated mock type for the LedgerUsecase type
+type LedgerUsecase struct {
+	mock.Mock
+}
+
+// ListBalances provides a mock function with given fields: ctx, req
+func (_m *LedgerUsecase) ListBalances(ctx context.Context, req *model.ListLedgerBalanceRequest) ([]model.LedgerBalanceResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBalances")
+	}
+
+	var r0 []model.LedgerBalanceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerBalanceRequest) ([]model.LedgerBalanceResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerBalanceRequest) []model.LedgerBalanceResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.LedgerBalanceResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListLedgerBalanceRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEntries provides a mock function with given fields: ctx, req
+func (_m *LedgerUsecase) ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]model.LedgerEntryResponse, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []model.LedgerEntryResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerEntryRequest) ([]model.LedgerEntryResponse, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListLedgerEntryRequest) []model.LedgerEntryResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.LedgerEntryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListLedgerEntryRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListLedgerEntryRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reverse provides a mock function with given fields: ctx, req
+func (_m *LedgerUsecase) Reverse(ctx context.Context, req *model.ReverseLedgerEntryRequest) (*model.LedgerEntryResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 *model.LedgerEntryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReverseLedgerEntryRequest) (*model.LedgerEntryResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ReverseLedgerEntryRequest) *model.LedgerEntryResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LedgerEntryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ReverseLedgerEntryRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLedgerUsecase creates a new instance of LedgerUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerUsecase {
+	mock := &LedgerUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginGuard is an autogenerated mock type for the LoginGuard type
+type LoginGuard struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, email, ip
+func (_m *LoginGuard) Check(ctx context.Context, email string, ip string) (time.Duration, error) {
+	ret := _m.Called(ctx, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (time.Duration, error)); ok {
+		return rf(ctx, email, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) time.Duration); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: ctx, email
+func (_m *LoginGuard) Reset(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Succeed provides a mock function with given fields: ctx, email, ip
+func (_m *LoginGuard) Succeed(ctx context.Context, email string, ip string) error {
+	ret := _m.Called(ctx, email, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Succeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLoginGuard creates a new instance of LoginGuard. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginGuard(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginGuard {
+	mock := &LoginGuard{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	auth "expense-management-system/internal/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCProvider is an autogenerated mock type for the OIDCProvider type
+type OIDCProvider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce, codeChallenge
+func (_m *OIDCProvider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, codeChallenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, state, nonce, codeChallenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier, nonce
+func (_m *OIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*auth.OIDCIdentity, error) {
+	ret := _m.Called(ctx, code, codeVerifier, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 *auth.OIDCIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*auth.OIDCIdentity, error)); ok {
+		return rf(ctx, code, codeVerifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *auth.OIDCIdentity); ok {
+		r0 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.OIDCIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOIDCProvider creates a new instance of OIDCProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCProvider {
+	mock := &OIDCProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) FindByID(ctx context.Context, id uint64) (*entity.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entity.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*entity.Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, organization
+func (_m *OrganizationRepository) Update(ctx context.Context, organization *entity.Organization) error {
+	ret := _m.Called(ctx, organization)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Organization) error); ok {
+		r0 = rf(ctx, organization)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationRepository {
+	mock := &OrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationUsecase is an autogenerated mock type for the OrganizationUsecase type
+type OrganizationUsecase struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, req
+func (_m *OrganizationUsecase) Get(ctx context.Context, req *model.GetOrganizationRequest) (*model.OrganizationResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.OrganizationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetOrganizationRequest) (*model.OrganizationResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetOrganizationRequest) *model.OrganizationResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrganizationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetOrganizationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *OrganizationUsecase) Update(ctx context.Context, req *model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.OrganizationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateOrganizationRequest) (*model.OrganizationResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateOrganizationRequest) *model.OrganizationResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OrganizationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateOrganizationRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOrganizationUsecase creates a new instance of OrganizationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationUsecase {
+	mock := &OrganizationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
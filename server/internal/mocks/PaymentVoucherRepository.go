@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentVoucherRepository is an autogenerated mock type for the PaymentVoucherRepository type
+type PaymentVoucherRepository struct {
+	mock.Mock
+}
+
+// CreateTx provides a mock function with given fields: ctx, exec, voucher
+func (_m *PaymentVoucherRepository) CreateTx(ctx context.Context, exec db.Executor, voucher *entity.PaymentVoucher) error {
+	ret := _m.Called(ctx, exec, voucher)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.PaymentVoucher) error); ok {
+		r0 = rf(ctx, exec, voucher)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByExpenseID provides a mock function with given fields: ctx, orgID, expenseID
+func (_m *PaymentVoucherRepository) FindByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) (*entity.PaymentVoucher, error) {
+	ret := _m.Called(ctx, orgID, expenseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExpenseID")
+	}
+
+	var r0 *entity.PaymentVoucher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.PaymentVoucher, error)); ok {
+		return rf(ctx, orgID, expenseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.PaymentVoucher); ok {
+		r0 = rf(ctx, orgID, expenseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentVoucher)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, expenseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetFile provides a mock function with given fields: ctx, id, fileKey, generatedAt
+func (_m *PaymentVoucherRepository) SetFile(ctx context.Context, id uint64, fileKey string, generatedAt time.Time) error {
+	ret := _m.Called(ctx, id, fileKey, generatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, fileKey, generatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentVoucherRepository creates a new instance of PaymentVoucherRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentVoucherRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentVoucherRepository {
+	mock := &PaymentVoucherRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
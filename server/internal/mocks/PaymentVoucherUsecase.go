@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentVoucherUsecase is an autogenerated mock type for the PaymentVoucherUsecase type
+type PaymentVoucherUsecase struct {
+	mock.Mock
+}
+
+// Download provides a mock function with given fields: ctx, req
+func (_m *PaymentVoucherUsecase) Download(ctx context.Context, req *model.GetExpenseRequest) (*model.FileResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 *model.FileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseRequest) (*model.FileResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetExpenseRequest) *model.FileResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetExpenseRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentVoucherUsecase creates a new instance of PaymentVoucherUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentVoucherUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentVoucherUsecase {
+	mock := &PaymentVoucherUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
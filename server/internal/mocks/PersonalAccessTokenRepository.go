@@ -0,0 +1,172 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenRepository is an autogenerated mock type for the PersonalAccessTokenRepository type
+type PersonalAccessTokenRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, token
+func (_m *PersonalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PersonalAccessToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByTokenHash")
+	}
+
+	var r0 *entity.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PersonalAccessToken, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PersonalAccessToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUserID provides a mock function with given fields: ctx, userID
+func (_m *PersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uint64) ([]entity.PersonalAccessToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserID")
+	}
+
+	var r0 []entity.PersonalAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]entity.PersonalAccessToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []entity.PersonalAccessToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PersonalAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id, userID, revokedAt
+func (_m *PersonalAccessTokenRepository) Revoke(ctx context.Context, id uint64, userID uint64, revokedAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, userID, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time) (bool, error)); ok {
+		return rf(ctx, id, userID, revokedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time) bool); ok {
+		r0 = rf(ctx, id, userID, revokedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, time.Time) error); ok {
+		r1 = rf(ctx, id, userID, revokedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByUserID provides a mock function with given fields: ctx, userID, revokedAt
+func (_m *PersonalAccessTokenRepository) RevokeByUserID(ctx context.Context, userID uint64, revokedAt time.Time) error {
+	ret := _m.Called(ctx, userID, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByUserID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) error); ok {
+		r0 = rf(ctx, userID, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: ctx, id, lastUsedAt
+func (_m *PersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id uint64, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, id, lastUsedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) error); ok {
+		r0 = rf(ctx, id, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPersonalAccessTokenRepository creates a new instance of PersonalAccessTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenRepository {
+	mock := &PersonalAccessTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,138 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	auth "expense-management-system/internal/auth"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalAccessTokenUsecase is an autogenerated mock type for the PersonalAccessTokenUsecase type
+type PersonalAccessTokenUsecase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, apiKey
+func (_m *PersonalAccessTokenUsecase) Authenticate(ctx context.Context, apiKey string) (*auth.JWTClaims, error) {
+	ret := _m.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *auth.JWTClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.JWTClaims, error)); ok {
+		return rf(ctx, apiKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.JWTClaims); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.JWTClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, apiKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *PersonalAccessTokenUsecase) Create(ctx context.Context, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.CreatePersonalAccessTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.CreatePersonalAccessTokenRequest) *model.CreatePersonalAccessTokenResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CreatePersonalAccessTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.CreatePersonalAccessTokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, req
+func (_m *PersonalAccessTokenUsecase) List(ctx context.Context, req *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.PersonalAccessTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListPersonalAccessTokenRequest) []model.PersonalAccessTokenResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PersonalAccessTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListPersonalAccessTokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, req
+func (_m *PersonalAccessTokenUsecase) Revoke(ctx context.Context, req *model.RevokePersonalAccessTokenRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RevokePersonalAccessTokenRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPersonalAccessTokenUsecase creates a new instance of PersonalAccessTokenUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalAccessTokenUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalAccessTokenUsecase {
+	mock := &PersonalAccessTokenUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	redis_rate "github.com/go-redis/redis_rate/v10"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateLimiter is an autogenerated mock type for the RateLimiter type
+type RateLimiter struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, key, limit
+func (_m *RateLimiter) Allow(ctx context.Context, key string, limit redis_rate.Limit) (*redis_rate.Result, error) {
+	ret := _m.Called(ctx, key, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allow")
+	}
+
+	var r0 *redis_rate.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, redis_rate.Limit) (*redis_rate.Result, error)); ok {
+		return rf(ctx, key, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, redis_rate.Limit) *redis_rate.Result); ok {
+		r0 = rf(ctx, key, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*redis_rate.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, redis_rate.Limit) error); ok {
+		r1 = rf(ctx, key, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateLimiter creates a new instance of RateLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimiter {
+	mock := &RateLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,287 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SCIMUsecase is an autogenerated mock type for the SCIMUsecase type
+type SCIMUsecase struct {
+	mock.Mock
+}
+
+// CreateUser provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) CreateUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *model.SCIMUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMUserRequest) (*model.SCIMUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMUserRequest) *model.SCIMUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) DeleteUser(ctx context.Context, req *model.SCIMResourceRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMResourceRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroup provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) GetGroup(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMGroupResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroup")
+	}
+
+	var r0 *model.SCIMGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMResourceRequest) (*model.SCIMGroupResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMResourceRequest) *model.SCIMGroupResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMResourceRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) GetUser(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *model.SCIMUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMResourceRequest) (*model.SCIMUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMResourceRequest) *model.SCIMUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMResourceRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroups provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) ListGroups(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 *model.SCIMListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSCIMRequest) (*model.SCIMListResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSCIMRequest) *model.SCIMListResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListSCIMRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) ListUsers(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 *model.SCIMListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSCIMRequest) (*model.SCIMListResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListSCIMRequest) *model.SCIMListResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListSCIMRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchGroup provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) PatchGroup(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMGroupResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchGroup")
+	}
+
+	var r0 *model.SCIMGroupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMPatchRequest) (*model.SCIMGroupResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMPatchRequest) *model.SCIMGroupResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMGroupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMPatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchUser provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) PatchUser(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchUser")
+	}
+
+	var r0 *model.SCIMUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMPatchRequest) (*model.SCIMUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMPatchRequest) *model.SCIMUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMPatchRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceUser provides a mock function with given fields: ctx, req
+func (_m *SCIMUsecase) ReplaceUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUser")
+	}
+
+	var r0 *model.SCIMUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMUserRequest) (*model.SCIMUserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SCIMUserRequest) *model.SCIMUserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SCIMUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SCIMUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSCIMUsecase creates a new instance of SCIMUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSCIMUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SCIMUsecase {
+	mock := &SCIMUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
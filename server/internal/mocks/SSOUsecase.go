@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SSOUsecase is an autogenerated mock type for the SSOUsecase type
+type SSOUsecase struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: ctx
+func (_m *SSOUsecase) Authorize(ctx context.Context) (*model.SSOAuthorizeResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 *model.SSOAuthorizeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.SSOAuthorizeResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.SSOAuthorizeResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SSOAuthorizeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Callback provides a mock function with given fields: ctx, req
+func (_m *SSOUsecase) Callback(ctx context.Context, req *model.SSOCallbackRequest) (*model.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Callback")
+	}
+
+	var r0 *model.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SSOCallbackRequest) (*model.LoginResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SSOCallbackRequest) *model.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SSOCallbackRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSSOUsecase creates a new instance of SSOUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSSOUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SSOUsecase {
+	mock := &SSOUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StatementUsecase is an autogenerated mock type for the StatementUsecase type
+type StatementUsecase struct {
+	mock.Mock
+}
+
+// Download provides a mock function with given fields: ctx, req
+func (_m *StatementUsecase) Download(ctx context.Context, req *model.GetStatementRequest) (*model.FileResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 *model.FileResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetStatementRequest) (*model.FileResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetStatementRequest) *model.FileResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetStatementRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Summary provides a mock function with given fields: ctx, req
+func (_m *StatementUsecase) Summary(ctx context.Context, req *model.UserSummaryRequest) (*model.UserSummaryResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Summary")
+	}
+
+	var r0 *model.UserSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserSummaryRequest) (*model.UserSummaryResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserSummaryRequest) *model.UserSummaryResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UserSummaryRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStatementUsecase creates a new instance of StatementUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatementUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatementUsecase {
+	mock := &StatementUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
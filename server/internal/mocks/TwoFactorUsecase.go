@@ -0,0 +1,125 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "expense-management-system/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUsecase is an autogenerated mock type for the TwoFactorUsecase type
+type TwoFactorUsecase struct {
+	mock.Mock
+}
+
+// Activate provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) Activate(ctx context.Context, req *model.ActivateTwoFactorRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ActivateTwoFactorRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Disable provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) Disable(ctx context.Context, req *model.DisableTwoFactorRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DisableTwoFactorRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enroll provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) Enroll(ctx context.Context, req *model.EnrollTwoFactorRequest) (*model.TwoFactorEnrollResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 *model.TwoFactorEnrollResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EnrollTwoFactorRequest) (*model.TwoFactorEnrollResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EnrollTwoFactorRequest) *model.TwoFactorEnrollResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TwoFactorEnrollResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.EnrollTwoFactorRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Status provides a mock function with given fields: ctx, req
+func (_m *TwoFactorUsecase) Status(ctx context.Context, req *model.GetTwoFactorRequest) (*model.TwoFactorStatusResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 *model.TwoFactorStatusResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetTwoFactorRequest) (*model.TwoFactorStatusResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.GetTwoFactorRequest) *model.TwoFactorStatusResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TwoFactorStatusResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.GetTwoFactorRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTwoFactorUsecase creates a new instance of TwoFactorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorUsecase {
+	mock := &TwoFactorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	db "expense-management-system/internal/db"
+	entity "expense-management-system/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserIdentityRepository is an autogenerated mock type for the UserIdentityRepository type
+type UserIdentityRepository struct {
+	mock.Mock
+}
+
+// CreateTx provides a mock function with given fields: ctx, exec, identity
+func (_m *UserIdentityRepository) CreateTx(ctx context.Context, exec db.Executor, identity *entity.UserIdentity) error {
+	ret := _m.Called(ctx, exec, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.UserIdentity) error); ok {
+		r0 = rf(ctx, exec, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByIssuerAndSubject provides a mock function with given fields: ctx, issuer, subject
+func (_m *UserIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer string, subject string) (*entity.UserIdentity, error) {
+	ret := _m.Called(ctx, issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIssuerAndSubject")
+	}
+
+	var r0 *entity.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.UserIdentity, error)); ok {
+		return rf(ctx, issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.UserIdentity); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserIdentityRepository creates a new instance of UserIdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserIdentityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserIdentityRepository {
+	mock := &UserIdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
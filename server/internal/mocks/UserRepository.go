@@ -4,7 +4,9 @@ package mocks
 
 import (
 	context "context"
+	db "expense-management-system/internal/db"
 	entity "expense-management-system/internal/entity"
+	model "expense-management-system/internal/model"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -60,6 +62,24 @@ func (_m *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	return r0
 }
 
+// CreateTx provides a mock function with given fields: ctx, exec, user
+func (_m *UserRepository) CreateTx(ctx context.Context, exec db.Executor, user *entity.User) error {
+	ret := _m.Called(ctx, exec, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.Executor, *entity.User) error); ok {
+		r0 = rf(ctx, exec, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -90,9 +110,9 @@ func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (*entit
 	return r0, r1
 }
 
-// FindByID provides a mock function with given fields: ctx, id
-func (_m *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
-	ret := _m.Called(ctx, id)
+// FindByID provides a mock function with given fields: ctx, orgID, id
+func (_m *UserRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.User, error) {
+	ret := _m.Called(ctx, orgID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByID")
@@ -100,19 +120,19 @@ func (_m *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User
 
 	var r0 *entity.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*entity.User, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*entity.User, error)); ok {
+		return rf(ctx, orgID, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.User); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *entity.User); ok {
+		r0 = rf(ctx, orgID, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, orgID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -120,6 +140,127 @@ func (_m *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, req
+func (_m *UserRepository) List(ctx context.Context, req *model.ListUserRequest) ([]entity.User, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.User
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListUserRequest) ([]entity.User, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListUserRequest) []entity.User); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListUserRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListUserRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListByOrgIDAndIDs provides a mock function with given fields: ctx, orgID, ids
+func (_m *UserRepository) ListByOrgIDAndIDs(ctx context.Context, orgID uint64, ids []uint64) ([]entity.User, error) {
+	ret := _m.Called(ctx, orgID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByOrgIDAndIDs")
+	}
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []uint64) ([]entity.User, error)); ok {
+		return rf(ctx, orgID, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []uint64) []entity.User); ok {
+		r0 = rf(ctx, orgID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []uint64) error); ok {
+		r1 = rf(ctx, orgID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, user
+func (_m *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLocale provides a mock function with given fields: ctx, orgID, id, locale
+func (_m *UserRepository) UpdateLocale(ctx context.Context, orgID uint64, id uint64, locale string) error {
+	ret := _m.Called(ctx, orgID, id, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLocale")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, string) error); ok {
+		r0 = rf(ctx, orgID, id, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, orgID, id, passwordHash
+func (_m *UserRepository) UpdatePassword(ctx context.Context, orgID uint64, id uint64, passwordHash string) error {
+	ret := _m.Called(ctx, orgID, id, passwordHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, string) error); ok {
+		r0 = rf(ctx, orgID, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "expense-management-system/internal/entity"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserTOTPRepository is an autogenerated mock type for the UserTOTPRepository type
+type UserTOTPRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, userID
+func (_m *UserTOTPRepository) Delete(ctx context.Context, userID uint64) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, userID, enabledAt
+func (_m *UserTOTPRepository) Enable(ctx context.Context, userID uint64, enabledAt time.Time) error {
+	ret := _m.Called(ctx, userID, enabledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) error); ok {
+		r0 = rf(ctx, userID, enabledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserTOTPRepository) FindByUserID(ctx context.Context, userID uint64) (*entity.UserTOTP, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserID")
+	}
+
+	var r0 *entity.UserTOTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*entity.UserTOTP, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *entity.UserTOTP); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.UserTOTP)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, totp
+func (_m *UserTOTPRepository) Upsert(ctx context.Context, totp *entity.UserTOTP) error {
+	ret := _m.Called(ctx, totp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserTOTP) error); ok {
+		r0 = rf(ctx, totp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, hashedCode
+func (_m *UserTOTPRepository) UseRecoveryCode(ctx context.Context, userID uint64, hashedCode string) (bool, error) {
+	ret := _m.Called(ctx, userID, hashedCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) (bool, error)); ok {
+		return rf(ctx, userID, hashedCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) bool); ok {
+		r0 = rf(ctx, userID, hashedCode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = rf(ctx, userID, hashedCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTimeStep provides a mock function with given fields: ctx, userID, step
+func (_m *UserTOTPRepository) UseTimeStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTimeStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) (bool, error)); ok {
+		return rf(ctx, userID, step)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, int64) bool); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, int64) error); ok {
+		r1 = rf(ctx, userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUserTOTPRepository creates a new instance of UserTOTPRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserTOTPRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserTOTPRepository {
+	mock := &UserTOTPRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -44,6 +44,24 @@ func (_m *UserUsecase) Create(ctx context.Context, req *model.CreateUserRequest)
 	return r0, r1
 }
 
+// Deactivate provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) Deactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deactivate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateUserActiveRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) FindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -74,6 +92,121 @@ func (_m *UserUsecase) FindByID(ctx context.Context, req *model.GetUserRequest)
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) List(ctx context.Context, req *model.ListUserRequest) ([]model.UserResponse, int, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []model.UserResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListUserRequest) ([]model.UserResponse, int, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListUserRequest) []model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListUserRequest) int); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *model.ListUserRequest) error); ok {
+		r2 = rf(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reactivate provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) Reactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reactivate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateUserActiveRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) Update(ctx context.Context, req *model.UpdateUserRequest) (*model.UserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateUserRequest) (*model.UserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateUserRequest) *model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLocale provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) UpdateLocale(ctx context.Context, req *model.UpdateLocaleRequest) (*model.UserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLocale")
+	}
+
+	var r0 *model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateLocaleRequest) (*model.UserResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpdateLocaleRequest) *model.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpdateLocaleRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserUsecase creates a new instance of UserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecase(t interface {
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+type AnalyticsGroupBy string
+
+const (
+	AnalyticsGroupByStatus     AnalyticsGroupBy = "status"
+	AnalyticsGroupByMonth      AnalyticsGroupBy = "month" // of the submission, in UTC
+	AnalyticsGroupByDepartment AnalyticsGroupBy = "department"
+	AnalyticsGroupByCostCenter AnalyticsGroupBy = "cost_center"
+	AnalyticsGroupByEmployee   AnalyticsGroupBy = "employee"
+)
+
+func ParseAnalyticsGroupBy(str string) (AnalyticsGroupBy, bool) {
+	switch groupBy := AnalyticsGroupBy(str); groupBy {
+	case AnalyticsGroupByStatus, AnalyticsGroupByMonth, AnalyticsGroupByDepartment, AnalyticsGroupByCostCenter,
+		AnalyticsGroupByEmployee:
+		return groupBy, true
+	default:
+		return "", false
+	}
+}
+
+type AnalyticsRequest struct {
+	OrgID        uint64           `json:"org_id"`    // current user organization
+	UserRole     string           `json:"user_role"` // current user role
+	CreatedFrom  *time.Time       `json:"created_from"`
+	CreatedTo    *time.Time       `json:"created_to"`
+	DepartmentID *uint64          `json:"department_id"`
+	GroupBy      AnalyticsGroupBy `json:"group_by"` // breakdown only
+}
+
+type AnalyticsSummaryResponse struct {
+	TotalCount              int                        `json:"total_count"`
+	TotalAmountIDR          uint64                     `json:"total_amount_idr"`
+	TotalAmountFormatted    string                     `json:"total_amount_formatted"`
+	ByStatus                []AnalyticsGroupResponse   `json:"by_status"`
+	AvgTimeToApproveSeconds *int64                     `json:"avg_time_to_approve_seconds"` // null when nothing was approved by a manager
+	AvgTimeToPaySeconds     *int64                     `json:"avg_time_to_pay_seconds"`     // null when nothing was paid out
+	PendingCount            int                        `json:"pending_count"`
+	OldestPending           []AnalyticsPendingResponse `json:"oldest_pending"`
+}
+
+type AnalyticsGroupResponse struct {
+	Key             string `json:"key"`
+	Label           string `json:"label"`
+	Count           int    `json:"count"`
+	AmountIDR       uint64 `json:"amount_idr"`
+	AmountFormatted string `json:"amount_formatted"`
+}
+
+type AnalyticsPendingResponse struct {
+	ID              uint64             `json:"id"`
+	AmountIDR       uint64             `json:"amount_idr"`
+	AmountFormatted string             `json:"amount_formatted"`
+	Description     string             `json:"description"`
+	User            UserSimpleResponse `json:"user"`
+	CreatedAt       string             `json:"created_at"`
+	WaitingSeconds  int64              `json:"waiting_seconds"`
+}
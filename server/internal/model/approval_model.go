@@ -3,8 +3,27 @@ package model
 type ApprovalExpenseRequest struct {
 	ID       uint64  `json:"id"`
 	Notes    *string `json:"notes" validate:"omitempty,max=255"`
+	OrgID    uint64  `json:"org_id"`    // current user organization
 	UserID   uint64  `json:"user_id"`   // current user id
 	UserRole string  `json:"user_role"` // current user role
+	Version  *uint64 `json:"version"`   // from If-Match, nil when the client sent no precondition
+}
+
+type RequestChangesExpenseRequest struct {
+	ID       uint64  `json:"id"`
+	Notes    string  `json:"notes" validate:"required,max=255"` // what the submitter has to change
+	OrgID    uint64  `json:"org_id"`                            // current user organization
+	UserID   uint64  `json:"user_id"`                           // current user id
+	UserRole string  `json:"user_role"`                         // current user role
+	Version  *uint64 `json:"version"`                           // from If-Match, nil when the client sent no precondition
+}
+
+type ExpenseReviewRoundResponse struct {
+	Round         int                `json:"round"`
+	Reviewer      UserSimpleResponse `json:"reviewer"`
+	Notes         string             `json:"notes"`
+	RequestedAt   string             `json:"requested_at"`
+	ResubmittedAt *string            `json:"resubmitted_at"`
 }
 
 type ApprovalDetailResponse struct {
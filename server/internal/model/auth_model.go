@@ -3,14 +3,28 @@ package model
 import "expense-management-system/internal/auth"
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,min=4,max=100,email"`
-	Password string `json:"password" validate:"required,min=4,max=100"`
+	Email     string `json:"email" validate:"required,min=4,max=100,email"`
+	Password  string `json:"password" validate:"required,min=4,max=100"`
+	IPAddress string `json:"ip_address"` // current client ip
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required,max=100"`
+	Code           string `json:"code" validate:"required,min=6,max=20"` // totp or recovery code
 }
 
 type LogoutRequest struct {
 	Claims *auth.JWTClaims `json:"claims"`
 }
 
+type UnlockLoginRequest struct {
+	ID       uint64 `json:"id"`        // locked user id
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
 type LoginResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken       string `json:"access_token,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`     // exchanged for an access token with a totp code
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"` // true when a challenge token is returned
 }
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor turns the position of a page into an opaque token for the client
+func EncodeCursor(v any) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		cursor  string
+		want    model.ExpenseCursor
+		wantErr bool
+	}{
+		{
+			name:   "round trip",
+			cursor: model.EncodeCursor(model.ExpenseCursor{ID: 42}),
+			want:   model.ExpenseCursor{ID: 42},
+		},
+		{
+			name:    "not base64",
+			cursor:  "not a cursor!",
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			cursor:  "bm90LWpzb24",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got model.ExpenseCursor
+			err := model.DecodeCursor(tt.cursor, &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+type ListDepartmentRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type CreateDepartmentRequest struct {
+	OrgID      uint64 `json:"org_id"`    // current user organization
+	UserRole   string `json:"user_role"` // current user role
+	Name       string `json:"name" validate:"required,min=2,max=100"`
+	CostCenter string `json:"cost_center" validate:"required,min=2,max=50"`
+}
+
+type UpdateDepartmentRequest struct {
+	ID         uint64  `json:"id"`
+	OrgID      uint64  `json:"org_id"`    // current user organization
+	UserRole   string  `json:"user_role"` // current user role
+	Name       *string `json:"name" validate:"omitempty,min=2,max=100"`
+	CostCenter *string `json:"cost_center" validate:"omitempty,min=2,max=50"` // only new expenses get the new code
+}
+
+type ListDepartmentBudgetRequest struct {
+	DepartmentID uint64 `json:"department_id"`
+	OrgID        uint64 `json:"org_id"`    // current user organization
+	UserRole     string `json:"user_role"` // current user role
+}
+
+type CreateDepartmentBudgetRequest struct {
+	DepartmentID uint64 `json:"department_id"`
+	OrgID        uint64 `json:"org_id"`    // current user organization
+	UserRole     string `json:"user_role"` // current user role
+	PeriodStart  string `json:"period_start" validate:"required,datetime=2006-01-02"`
+	PeriodEnd    string `json:"period_end" validate:"required,datetime=2006-01-02"`
+	AmountIDR    uint64 `json:"amount_idr" validate:"required,gt=0"`
+}
+
+type DepartmentResponse struct {
+	ID         uint64 `json:"id"`
+	Name       string `json:"name"`
+	CostCenter string `json:"cost_center"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type DepartmentBudgetResponse struct {
+	ID                 uint64 `json:"id"`
+	DepartmentID       uint64 `json:"department_id"`
+	PeriodStart        string `json:"period_start"`
+	PeriodEnd          string `json:"period_end"`
+	AmountIDR          uint64 `json:"amount_idr"`
+	AmountFormatted    string `json:"amount_formatted"`
+	SpentIDR           uint64 `json:"spent_idr"`
+	SpentFormatted     string `json:"spent_formatted"`
+	RemainingIDR       int64  `json:"remaining_idr"` // negative once the budget is overspent
+	RemainingFormatted string `json:"remaining_formatted"`
+	CreatedAt          string `json:"created_at"`
+}
@@ -1,30 +1,122 @@
 package model
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
+// the messages of the codes are in the catalog, see message.go
 var (
-	ErrInternalServerError        = NewCustomError(http.StatusInternalServerError, 100, "Internal server error")
-	ErrUnauthorized               = NewCustomError(http.StatusUnauthorized, 101, "Unauthorized")
-	ErrBadRequest                 = NewCustomError(http.StatusBadRequest, 102, "Bad request")
-	ErrForbidden                  = NewCustomError(http.StatusForbidden, 103, "Forbidden")
-	ErrMissingOrInvalidAuthHeader = NewCustomError(http.StatusUnauthorized, 104, "Missing or invalid auth header")
-	ErrInvalidAuthToken           = NewCustomError(http.StatusUnauthorized, 105, "Invalid auth token")
-	ErrTokenRevoked               = NewCustomError(http.StatusUnauthorized, 106, "Token revoked")
-	ErrTooManyRequest             = NewCustomError(http.StatusTooManyRequests, 107, "Too many requests")
-
-	ErrEmailAlreadyExist         = NewCustomError(http.StatusBadRequest, 1000, "Email already exist")
-	ErrUserNotFound              = NewCustomError(http.StatusNotFound, 1001, "User not found")
-	ErrInvalidPassword           = NewCustomError(http.StatusUnauthorized, 1002, "Invalid password")
-	ErrExpenseNotFound           = NewCustomError(http.StatusNotFound, 1003, "Expense not found")
-	ErrExpenseMinAmount          = NewCustomError(http.StatusBadRequest, 1004, "Amount can't be less than Rp 10.000")
-	ErrExpenseMaxAmount          = NewCustomError(http.StatusBadRequest, 1005, "Amount can't be greater than Rp 50.000.000")
-	ErrExpenseAlreadyProcessed   = NewCustomError(http.StatusUnprocessableEntity, 1006, "Expense already processed")
-	ErrExpenseNotRequireApproval = NewCustomError(http.StatusUnprocessableEntity, 1007, "Expense don't require approval")
+	ErrInternalServerError        = newError(http.StatusInternalServerError, 100)
+	ErrUnauthorized               = newError(http.StatusUnauthorized, 101)
+	ErrBadRequest                 = newError(http.StatusBadRequest, 102)
+	ErrForbidden                  = newError(http.StatusForbidden, 103)
+	ErrMissingOrInvalidAuthHeader = newError(http.StatusUnauthorized, 104)
+	ErrInvalidAuthToken           = newError(http.StatusUnauthorized, 105)
+	ErrTokenRevoked               = newError(http.StatusUnauthorized, 106)
+	ErrTooManyRequest             = newError(http.StatusTooManyRequests, 107)
+	ErrServiceUnavailable         = newError(http.StatusServiceUnavailable, 108)
+
+	ErrEmailAlreadyExist         = newError(http.StatusBadRequest, 1000)
+	ErrUserNotFound              = newError(http.StatusNotFound, 1001)
+	ErrInvalidCredentials        = newError(http.StatusUnauthorized, 1002)
+	ErrExpenseNotFound           = newError(http.StatusNotFound, 1003)
+	ErrExpenseAlreadyProcessed   = newError(http.StatusUnprocessableEntity, 1006)
+	ErrExpenseNotRequireApproval = newError(http.StatusUnprocessableEntity, 1007)
+	ErrTooManyLoginAttempts      = newError(http.StatusTooManyRequests, 1008)
+	ErrInvalidTwoFactorCode      = newError(http.StatusUnauthorized, 1009)
+	ErrInvalidChallengeToken     = newError(http.StatusUnauthorized, 1010)
+	ErrTwoFactorRequired         = newError(http.StatusForbidden, 1011)
+	ErrTwoFactorAlreadyEnabled   = newError(http.StatusUnprocessableEntity, 1012)
+	ErrTwoFactorNotEnrolled      = newError(http.StatusUnprocessableEntity, 1013)
+	ErrInvalidResetToken         = newError(http.StatusBadRequest, 1014)
+	ErrInvalidCurrentPassword    = newError(http.StatusUnprocessableEntity, 1015)
+	ErrAccessTokenNotFound       = newError(http.StatusNotFound, 1016)
+	ErrInsufficientScope         = newError(http.StatusForbidden, 1017)
+	ErrSessionRequired           = newError(http.StatusForbidden, 1018)
+	ErrInvalidSSOState           = newError(http.StatusBadRequest, 1019)
+	ErrSSOLoginFailed            = newError(http.StatusUnauthorized, 1020)
+	ErrUserDeactivated           = newError(http.StatusForbidden, 1021)
+	ErrUserAlreadyExist          = newError(http.StatusConflict, 1022)
+	ErrInvalidSCIMFilter         = newError(http.StatusBadRequest, 1023)
+	ErrInvalidSCIMPatch          = newError(http.StatusBadRequest, 1024)
+	ErrGroupNotFound             = newError(http.StatusNotFound, 1025)
+	ErrCannotDeactivateSelf      = newError(http.StatusUnprocessableEntity, 1026)
+	ErrDepartmentNotFound        = newError(http.StatusNotFound, 1027)
+	ErrDepartmentAlreadyExist    = newError(http.StatusConflict, 1028)
+	ErrInvalidBudgetPeriod       = newError(http.StatusBadRequest, 1029)
+	ErrBudgetPeriodOverlap       = newError(http.StatusConflict, 1030)
+	ErrBudgetExceeded            = newError(http.StatusUnprocessableEntity, 1031)
+	ErrBudgetApprovalEscalated   = newError(http.StatusForbidden, 1032)
+	ErrOrganizationNotFound      = newError(http.StatusNotFound, 1033)
+	ErrInvalidExpensePolicy      = newError(http.StatusBadRequest, 1034)
+	ErrInvalidCursor             = newError(http.StatusBadRequest, 1035)
+	ErrInvalidIdempotencyKey     = newError(http.StatusBadRequest, 1037)
+	ErrIdempotencyKeyReused      = newError(http.StatusConflict, 1038)
+	ErrIdempotencyKeyInProgress  = newError(http.StatusConflict, 1039)
+	ErrExpenseModified           = newError(http.StatusPreconditionFailed, 1040)
+	ErrExpenseExportNotFound     = newError(http.StatusNotFound, 1041)
+	ErrExpenseExportNotReady     = newError(http.StatusConflict, 1042)
+	ErrGLAccountMappingNotFound  = newError(http.StatusNotFound, 1043)
+	ErrDefaultGLAccountMissing   = newError(http.StatusUnprocessableEntity, 1045)
+	ErrJournalBatchEmpty         = newError(http.StatusUnprocessableEntity, 1046)
+	ErrJournalBatchNotFound      = newError(http.StatusNotFound, 1047)
+	ErrLedgerEntryNotFound       = newError(http.StatusNotFound, 1048)
+	ErrLedgerEntryReversed       = newError(http.StatusConflict, 1049)
+	ErrLedgerEntryNotReversible  = newError(http.StatusUnprocessableEntity, 1050)
+	ErrInvalidStatementPeriod    = newError(http.StatusBadRequest, 1051)
+	ErrPaymentVoucherNotFound    = newError(http.StatusNotFound, 1052)
+	ErrExpenseCommentNotFound    = newError(http.StatusNotFound, 1053)
+	ErrInvalidCommentMention     = newError(http.StatusBadRequest, 1054)
+	ErrExpenseNotEditable        = newError(http.StatusUnprocessableEntity, 1056)
+	ErrLedgerApprovalUnpaid      = newError(http.StatusUnprocessableEntity, 1057)
+	ErrPaymentVoucherNotReady    = newError(http.StatusConflict, 1058)
 )
 
+// NewExpenseMinAmountError is returned with code 1004 when the amount is below the minimum of the organization
+func NewExpenseMinAmountError(minAmount uint64) *CustomError {
+	return newError(http.StatusBadRequest, 1004, FormatRupiah(minAmount))
+}
+
+// NewExpenseMaxAmountError is returned with code 1005 when the amount is above the maximum of the organization
+func NewExpenseMaxAmountError(maxAmount uint64) *CustomError {
+	return newError(http.StatusBadRequest, 1005, FormatRupiah(maxAmount))
+}
+
+// NewCommentEditWindowError is returned with code 1055 when the author changes a comment after the window
+func NewCommentEditWindowError(window time.Duration) *CustomError {
+	return newError(http.StatusUnprocessableEntity, 1055, int(window.Minutes()))
+}
+
+// NewInvalidFilterError is returned with code 1036 and one item for every filter that can't be used
+func NewInvalidFilterError(filters ...string) *CustomError {
+	err := &CustomError{HTTPStatus: http.StatusBadRequest}
+	for _, filter := range filters {
+		err.Append(newError(http.StatusBadRequest, 1036, filter).Errors[0])
+	}
+
+	return err
+}
+
+// NewGLAccountMappingMissingError is returned with code 1044 and one item for every cost center without a mapping
+// when the organization has no default mapping
+func NewGLAccountMappingMissingError(costCenters ...string) *CustomError {
+	err := &CustomError{HTTPStatus: http.StatusUnprocessableEntity}
+	for _, costCenter := range costCenters {
+		err.Append(newError(http.StatusUnprocessableEntity, 1044, costCenter).Errors[0])
+	}
+
+	return err
+}
+
 type ErrorItem struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule,omitempty"`
+
+	key  string // key in the catalog when it isn't the code
+	args []any  // values for the verbs of the message in the catalog
 }
 
 type CustomError struct {
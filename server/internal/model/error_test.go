@@ -22,14 +22,14 @@ func TestCustomError_Error(t *testing.T) {
 		{
 			name: "multiple errors",
 			customErr: func() *model.CustomError {
-				err := model.ErrInvalidPassword
+				err := model.ErrInvalidCredentials
 				err.Append(model.ErrorItem{
 					Code:    9999,
 					Message: "another error",
 				})
 				return err
 			}(),
-			wantMsg: "Invalid password",
+			wantMsg: "Invalid email or password",
 		},
 		{
 			name: "empty errors",
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// FormatETag returns the strong entity tag of a version of a resource
+func FormatETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// ParseIfMatch reads the version out of an If-Match header. It's nil when there
+// is no precondition, "*" matches any version as well. Weak tags never match
+// because If-Match uses the strong comparison.
+func ParseIfMatch(header string) (*uint64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, errors.New("invalid entity tag")
+	}
+
+	version, err := strconv.ParseUint(header[1:len(header)-1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
@@ -0,0 +1,67 @@
+package model_test
+
+import (
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatETag(t *testing.T) {
+	assert.Equal(t, `"3"`, model.FormatETag(3))
+}
+
+func TestParseIfMatch(t *testing.T) {
+	version := uint64(3)
+
+	tests := []struct {
+		name    string
+		header  string
+		want    *uint64
+		wantErr bool
+	}{
+		{
+			name:   "no precondition",
+			header: "",
+			want:   nil,
+		},
+		{
+			name:   "any version",
+			header: "*",
+			want:   nil,
+		},
+		{
+			name:   "strong tag",
+			header: model.FormatETag(3),
+			want:   &version,
+		},
+		{
+			name:    "weak tag",
+			header:  `W/"3"`,
+			wantErr: true,
+		},
+		{
+			name:    "unquoted",
+			header:  "3",
+			wantErr: true,
+		},
+		{
+			name:    "not a version",
+			header:  `"abc"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := model.ParseIfMatch(tt.header)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ type Event interface {
 
 type ExpenseApprovedEvent struct {
 	ID             uint64 `json:"id"`
+	OrgID          uint64 `json:"org_id"`
 	UserID         uint64 `json:"user_id"`
 	Amount         uint64 `json:"amount"`
 	IdempotencyKey string `json:"idempotency_key"`
@@ -16,3 +17,27 @@ type ExpenseApprovedEvent struct {
 func (u *ExpenseApprovedEvent) GetID() string {
 	return fmt.Sprintf("expense-%d", u.ID)
 }
+
+type ExpenseExportRequestedEvent struct {
+	ID     uint64 `json:"id"`
+	OrgID  uint64 `json:"org_id"`
+	UserID uint64 `json:"user_id"`
+}
+
+func (u *ExpenseExportRequestedEvent) GetID() string {
+	return fmt.Sprintf("expense-export-%d", u.ID)
+}
+
+// ExpenseCommentedEvent is sent for every new comment, so the recipients can be notified
+type ExpenseCommentedEvent struct {
+	ID               uint64   `json:"id"`
+	OrgID            uint64   `json:"org_id"`
+	ExpenseID        uint64   `json:"expense_id"`
+	UserID           uint64   `json:"user_id"` // the author
+	MentionedUserIDs []uint64 `json:"mentioned_user_ids"`
+	RecipientIDs     []uint64 `json:"recipient_ids"` // the submitter and the mentioned users, never the author
+}
+
+func (u *ExpenseCommentedEvent) GetID() string {
+	return fmt.Sprintf("expense-comment-%d", u.ID)
+}
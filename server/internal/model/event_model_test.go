@@ -33,3 +33,13 @@ func TestExpenseApprovedEvent_GetID(t *testing.T) {
 		})
 	}
 }
+
+func TestExpenseExportRequestedEvent_GetID(t *testing.T) {
+	event := &model.ExpenseExportRequestedEvent{
+		ID:     5,
+		OrgID:  1,
+		UserID: 2,
+	}
+
+	assert.Equal(t, "expense-export-5", event.GetID())
+}
@@ -0,0 +1,37 @@
+package model
+
+type CreateExpenseCommentRequest struct {
+	ExpenseID uint64   `json:"expense_id"`
+	OrgID     uint64   `json:"org_id"`    // current user organization
+	UserID    uint64   `json:"user_id"`   // current user id
+	UserRole  string   `json:"user_role"` // current user role
+	Body      string   `json:"body" validate:"required,max=2000"`
+	Mentions  []uint64 `json:"mentions" validate:"max=10"` // ids of the users to notify
+}
+
+type UpdateExpenseCommentRequest struct {
+	ID        uint64   `json:"id"`
+	ExpenseID uint64   `json:"expense_id"`
+	OrgID     uint64   `json:"org_id"`    // current user organization
+	UserID    uint64   `json:"user_id"`   // current user id
+	UserRole  string   `json:"user_role"` // current user role
+	Body      string   `json:"body" validate:"required,max=2000"`
+	Mentions  []uint64 `json:"mentions" validate:"max=10"` // replaces the mentions of the comment
+}
+
+type DeleteExpenseCommentRequest struct {
+	ID        uint64 `json:"id"`
+	ExpenseID uint64 `json:"expense_id"`
+	OrgID     uint64 `json:"org_id"`    // current user organization
+	UserID    uint64 `json:"user_id"`   // current user id
+	UserRole  string `json:"user_role"` // current user role
+}
+
+type ExpenseCommentResponse struct {
+	ID        uint64               `json:"id"`
+	Body      string               `json:"body"`
+	User      UserSimpleResponse   `json:"user"`
+	Mentions  []UserSimpleResponse `json:"mentions"`
+	CreatedAt string               `json:"created_at"`
+	EditedAt  *string              `json:"edited_at"`
+}
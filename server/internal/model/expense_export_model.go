@@ -0,0 +1,28 @@
+package model
+
+type CreateExpenseExportRequest struct {
+	ListExpenseRequest        // the filters and sort of the list, pagination is ignored
+	Format             string `json:"format"`
+	Async              bool   `json:"async"` // run in the background even when the export is small
+}
+
+type GetExpenseExportRequest struct {
+	ID     uint64 `json:"id"`
+	OrgID  uint64 `json:"org_id"`  // current user organization
+	UserID uint64 `json:"user_id"` // current user id, only the requester sees an export
+}
+
+type ProcessExpenseExportRequest struct {
+	ID    uint64 `json:"id"`
+	OrgID uint64 `json:"org_id"`
+}
+
+type ExpenseExportResponse struct {
+	ID          uint64  `json:"id"`
+	Format      string  `json:"format"`
+	Status      string  `json:"status"`
+	RowCount    *int    `json:"row_count"`
+	DownloadURL *string `json:"download_url"` // once completed
+	CreatedAt   string  `json:"created_at"`
+	CompletedAt *string `json:"completed_at"`
+}
@@ -1,31 +1,146 @@
 package model
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
 type ExpenseView string
 
 const (
 	ExpenseViewPersonal      ExpenseView = "personal"
 	ExpenseViewApprovalQueue ExpenseView = "approval_queue" // manager only
+	ExpenseViewOrganization  ExpenseView = "organization"   // manager only, every expense of the organization
 )
 
+// ManagerOnly tells whether the view shows expenses of other users
+func (v ExpenseView) ManagerOnly() bool {
+	return v == ExpenseViewApprovalQueue || v == ExpenseViewOrganization
+}
+
 type CreateExpenseRequest struct {
+	OrgID       uint64  `json:"org_id"`  // current user organization
 	UserID      uint64  `json:"user_id"` // current user id
 	AmountIDR   uint64  `json:"amount_idr" validate:"required,number,gt=0"`
 	Description string  `json:"description" validate:"required,max=255"`
 	ReceiptURL  *string `json:"receipt_url" validate:"omitempty,url"`
 }
 
+type UpdateExpenseRequest struct {
+	ID          uint64  `json:"id"`
+	OrgID       uint64  `json:"org_id"`    // current user organization
+	UserID      uint64  `json:"user_id"`   // current user id
+	UserRole    string  `json:"user_role"` // current user role
+	Version     *uint64 `json:"version"`   // from If-Match, nil when the client sent no precondition
+	AmountIDR   uint64  `json:"amount_idr" validate:"required,number,gt=0"`
+	Description string  `json:"description" validate:"required,max=255"`
+	ReceiptURL  *string `json:"receipt_url" validate:"omitempty,url"`
+}
+
+type ExpenseSortBy string
+
+const (
+	ExpenseSortByCreatedAt ExpenseSortBy = "created_at"
+	ExpenseSortByAmount    ExpenseSortBy = "amount"
+	ExpenseSortByStatus    ExpenseSortBy = "status"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
 type ListExpenseRequest struct {
-	UserID       uint64      `json:"user_id"`   // current user id
-	UserRole     string      `json:"user_role"` // current user role
-	View         ExpenseView `json:"view"`
-	Status       *string     `json:"status"`
-	AutoApproved bool        `json:"auto_approved"` // flag to filter by amount
-	Limit        int         `json:"limit"`
-	Offset       int         `json:"offset"`
+	OrgID         uint64         `json:"org_id"`    // current user organization
+	UserID        uint64         `json:"user_id"`   // current user id
+	UserRole      string         `json:"user_role"` // current user role
+	View          ExpenseView    `json:"view"`
+	Statuses      []string       `json:"statuses"`      // not on the approval queue
+	AutoApproved  bool           `json:"auto_approved"` // flag to filter by amount
+	UserIDs       []uint64       `json:"user_ids"`      // not on the personal view
+	CreatedFrom   *time.Time     `json:"created_from"`
+	CreatedTo     *time.Time     `json:"created_to"`
+	ProcessedFrom *time.Time     `json:"processed_from"`
+	ProcessedTo   *time.Time     `json:"processed_to"`
+	MinAmountIDR  *uint64        `json:"min_amount_idr"`
+	MaxAmountIDR  *uint64        `json:"max_amount_idr"`
+	SortBy        ExpenseSortBy  `json:"sort_by"`    // created_at when empty
+	SortOrder     SortOrder      `json:"sort_order"` // desc when empty
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	Cursor        *ExpenseCursor `json:"cursor"`     // keyset pagination, offset is ignored when set
+	SkipTotal     bool           `json:"skip_total"` // don't run the count query
+}
+
+// FilterHash sums up everything that decides which expenses are listed and in which order,
+// a cursor only continues the list it was made for.
+func (r *ListExpenseRequest) FilterHash() string {
+	b, _ := json.Marshal(struct {
+		View          ExpenseView   `json:"view"`
+		Statuses      []string      `json:"statuses"`
+		AutoApproved  bool          `json:"auto_approved"`
+		UserIDs       []uint64      `json:"user_ids"`
+		CreatedFrom   *time.Time    `json:"created_from"`
+		CreatedTo     *time.Time    `json:"created_to"`
+		ProcessedFrom *time.Time    `json:"processed_from"`
+		ProcessedTo   *time.Time    `json:"processed_to"`
+		MinAmountIDR  *uint64       `json:"min_amount_idr"`
+		MaxAmountIDR  *uint64       `json:"max_amount_idr"`
+		SortBy        ExpenseSortBy `json:"sort_by"`
+		SortOrder     SortOrder     `json:"sort_order"`
+	}{
+		r.View, r.Statuses, r.AutoApproved, r.UserIDs, r.CreatedFrom, r.CreatedTo,
+		r.ProcessedFrom, r.ProcessedTo, r.MinAmountIDR, r.MaxAmountIDR, r.SortBy, r.SortOrder,
+	})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ExpenseCursor points at the last expense of a page, the next page starts after it.
+// It carries the value of the sorted column because the id alone only orders by id.
+type ExpenseCursor struct {
+	ID        uint64        `json:"id"`
+	Filters   string        `json:"filters"` // FilterHash of the list the cursor was made for
+	SortBy    ExpenseSortBy `json:"sort_by"`
+	SortOrder SortOrder     `json:"sort_order"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	AmountIDR *uint64       `json:"amount_idr,omitempty"`
+	Status    *string       `json:"status,omitempty"`
+}
+
+// Matches reports whether the cursor was made for the given list, a cursor can't be reused after the filters or the sort change
+func (c *ExpenseCursor) Matches(req *ListExpenseRequest) bool {
+	if c.ID == 0 || c.SortBy != req.SortBy || c.SortOrder != req.SortOrder || c.Filters != req.FilterHash() {
+		return false
+	}
+
+	switch c.SortBy {
+	case ExpenseSortByAmount:
+		return c.AmountIDR != nil
+	case ExpenseSortByStatus:
+		return c.Status != nil
+	default:
+		return c.CreatedAt != nil
+	}
+}
+
+type SearchExpenseRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserID   uint64 `json:"user_id"`   // current user id
+	UserRole string `json:"user_role"` // current user role
+	Query    string `json:"query"`
+	OwnOnly  bool   `json:"own_only"` // set by the usecase, callers below manager only find their own expenses
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
 }
 
 type GetExpenseRequest struct {
 	ID       uint64 `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
 	UserID   uint64 `json:"user_id"`   // current user id
 	UserRole string `json:"user_role"` // current user role
 }
@@ -33,9 +148,11 @@ type GetExpenseRequest struct {
 type ExpenseCreateResponse struct {
 	ID               uint64  `json:"id"`
 	AmountIDR        uint64  `json:"amount_idr"`
+	AmountFormatted  string  `json:"amount_formatted"`
 	Description      string  `json:"description"`
 	ReceiptURL       *string `json:"receipt_url"`
 	Status           string  `json:"status"`
+	CostCenter       *string `json:"cost_center"`
 	RequiresApproval bool    `json:"requires_approval"`
 	AutoApproved     bool    `json:"auto_approved"`
 	CreatedAt        string  `json:"created_at"`
@@ -44,25 +161,46 @@ type ExpenseCreateResponse struct {
 type ExpenseWithUserResponse struct {
 	ID               uint64             `json:"id"`
 	AmountIDR        uint64             `json:"amount_idr"`
+	AmountFormatted  string             `json:"amount_formatted"`
 	Description      string             `json:"description"`
 	ReceiptURL       *string            `json:"receipt_url"`
 	Status           string             `json:"status"`
+	CostCenter       *string            `json:"cost_center"`
 	RequiresApproval bool               `json:"requires_approval"`
 	AutoApproved     bool               `json:"auto_approved"`
 	CreatedAt        string             `json:"created_at"`
 	User             UserSimpleResponse `json:"user"`
 }
 
+type ExpenseSearchResultResponse struct {
+	ExpenseWithUserResponse
+	Rank       float64                        `json:"rank"`
+	Highlights ExpenseSearchHighlightResponse `json:"highlights"`
+}
+
+// ExpenseSearchHighlightResponse wraps the matched words in <mark> tags, the rest of the text is HTML escaped
+type ExpenseSearchHighlightResponse struct {
+	Description   string  `json:"description"`
+	ApprovalNotes *string `json:"approval_notes"` // only when the notes matched
+	UserName      *string `json:"user_name"`      // only when the name matched
+}
+
 type ExpenseDetailResponse struct {
-	ID               uint64                  `json:"id"`
-	AmountIDR        uint64                  `json:"amount_idr"`
-	Description      string                  `json:"description"`
-	ReceiptURL       *string                 `json:"receipt_url"`
-	Status           string                  `json:"status"`
-	RequiresApproval bool                    `json:"requires_approval"`
-	AutoApproved     bool                    `json:"auto_approved"`
-	CreatedAt        string                  `json:"created_at"`
-	ProcessedAt      *string                 `json:"processed_at"`
-	User             UserSimpleResponse      `json:"user"`
-	Approval         *ApprovalDetailResponse `json:"approval"`
+	ID               uint64                       `json:"id"`
+	AmountIDR        uint64                       `json:"amount_idr"`
+	AmountFormatted  string                       `json:"amount_formatted"`
+	Description      string                       `json:"description"`
+	ReceiptURL       *string                      `json:"receipt_url"`
+	Status           string                       `json:"status"`
+	CostCenter       *string                      `json:"cost_center"`
+	RequiresApproval bool                         `json:"requires_approval"`
+	AutoApproved     bool                         `json:"auto_approved"`
+	CreatedAt        string                       `json:"created_at"`
+	ProcessedAt      *string                      `json:"processed_at"`
+	Version          uint64                       `json:"version"` // also sent as the ETag header
+	User             UserSimpleResponse           `json:"user"`
+	Approval         *ApprovalDetailResponse      `json:"approval"`
+	ReviewRounds     []ExpenseReviewRoundResponse `json:"review_rounds"`    // oldest first
+	Comments         []ExpenseCommentResponse     `json:"comments"`         // oldest first
+	Budget           *DepartmentBudgetResponse    `json:"budget,omitempty"` // only shown to approvers
 }
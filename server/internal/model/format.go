@@ -0,0 +1,27 @@
+package model
+
+import "strconv"
+
+// FormatRupiah writes the amount the Indonesian way, Rp with dots between thousands
+func FormatRupiah(amount uint64) string {
+	digits := strconv.FormatUint(amount, 10)
+
+	out := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range len(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+
+	return "Rp " + string(out)
+}
+
+// FormatRupiahSigned is FormatRupiah for amounts that can go below zero, like an overspent budget
+func FormatRupiahSigned(amount int64) string {
+	if amount < 0 {
+		return "-" + FormatRupiah(uint64(-amount))
+	}
+
+	return FormatRupiah(uint64(amount))
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRupiah(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount uint64
+		want   string
+	}{
+		{
+			name:   "zero",
+			amount: 0,
+			want:   "Rp 0",
+		},
+		{
+			name:   "hundreds",
+			amount: 500,
+			want:   "Rp 500",
+		},
+		{
+			name:   "thousands",
+			amount: 10000,
+			want:   "Rp 10.000",
+		},
+		{
+			name:   "millions",
+			amount: 50000000,
+			want:   "Rp 50.000.000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.FormatRupiah(tt.amount))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package model
+
+type ListGLAccountMappingRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type UpsertGLAccountMappingRequest struct {
+	OrgID          uint64  `json:"org_id"`                                                           // current user organization
+	UserRole       string  `json:"user_role"`                                                        // current user role
+	CostCenter     *string `json:"cost_center" validate:"omitempty,min=2,max=50"`                    // null for the default mapping
+	ExpenseAccount string  `json:"expense_account" validate:"required,max=50"`                       // debited
+	CreditAccount  string  `json:"credit_account" validate:"required,max=50,nefield=ExpenseAccount"` // credited
+}
+
+type DeleteGLAccountMappingRequest struct {
+	ID       uint64 `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type CreateJournalBatchRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserID   uint64 `json:"user_id"`   // current user id
+	UserRole string `json:"user_role"` // current user role
+	Period   string `json:"period" validate:"required,datetime=2006-01"`
+}
+
+type ListJournalBatchRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type GetJournalBatchRequest struct {
+	ID       uint64 `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type GLAccountMappingResponse struct {
+	ID             uint64  `json:"id"`
+	CostCenter     *string `json:"cost_center"` // null for the default mapping
+	ExpenseAccount string  `json:"expense_account"`
+	CreditAccount  string  `json:"credit_account"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+type JournalBatchResponse struct {
+	ID                   uint64                `json:"id"`
+	Period               string                `json:"period"`
+	CreatedBy            uint64                `json:"created_by"`
+	LineCount            int                   `json:"line_count"`
+	TotalAmountIDR       uint64                `json:"total_amount_idr"`
+	TotalAmountFormatted string                `json:"total_amount_formatted"`
+	DownloadURL          string                `json:"download_url"`
+	CreatedAt            string                `json:"created_at"`
+	Lines                []JournalLineResponse `json:"lines,omitempty"` // only on a single batch
+}
+
+type JournalLineResponse struct {
+	LineNo     int     `json:"line_no"`
+	ExpenseID  uint64  `json:"expense_id"`
+	EntryDate  string  `json:"entry_date"`
+	Account    string  `json:"account"`
+	DebitIDR   uint64  `json:"debit_idr"`
+	CreditIDR  uint64  `json:"credit_idr"`
+	CostCenter *string `json:"cost_center"`
+	Memo       string  `json:"memo"`
+}
@@ -0,0 +1,48 @@
+package model
+
+type ListLedgerBalanceRequest struct {
+	OrgID    uint64  `json:"org_id"`    // current user organization
+	UserRole string  `json:"user_role"` // current user role
+	UserID   *uint64 `json:"user_id"`   // only this employee
+}
+
+type ListLedgerEntryRequest struct {
+	OrgID     uint64  `json:"org_id"`    // current user organization
+	UserRole  string  `json:"user_role"` // current user role
+	ExpenseID *uint64 `json:"expense_id"`
+	UserID    *uint64 `json:"user_id"` // entries touching the payable of this employee
+	Limit     int     `json:"limit"`
+	Offset    int     `json:"offset"`
+}
+
+type ReverseLedgerEntryRequest struct {
+	ID       uint64 `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserID   uint64 `json:"user_id"`   // current user id
+	UserRole string `json:"user_role"` // current user role
+	Reason   string `json:"reason" validate:"required,max=200"`
+}
+
+type LedgerBalanceResponse struct {
+	User             UserSimpleResponse `json:"user"`
+	BalanceIDR       int64              `json:"balance_idr"` // still owed to the employee
+	BalanceFormatted string             `json:"balance_formatted"`
+}
+
+type LedgerEntryResponse struct {
+	ID              uint64                  `json:"id"`
+	ExpenseID       uint64                  `json:"expense_id"`
+	Type            string                  `json:"type"`
+	ReversesEntryID *uint64                 `json:"reverses_entry_id"`
+	Memo            string                  `json:"memo"`
+	CreatedBy       *uint64                 `json:"created_by"` // null when posted by the system
+	CreatedAt       string                  `json:"created_at"`
+	Postings        []LedgerPostingResponse `json:"postings"`
+}
+
+type LedgerPostingResponse struct {
+	Account   string  `json:"account"`
+	UserID    *uint64 `json:"user_id"` // owner of the employee payable account
+	DebitIDR  uint64  `json:"debit_idr"`
+	CreditIDR uint64  `json:"credit_idr"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocaleID Locale = "id"
+
+	DefaultLocale = LocaleEN
+)
+
+// ParseLocale accepts a supported language with or without a region (e.g. id, id-ID, en_US)
+func ParseLocale(str string) (Locale, bool) {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(str)), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+
+	switch Locale(lang) {
+	case LocaleEN:
+		return LocaleEN, true
+	case LocaleID:
+		return LocaleID, true
+	default:
+		return "", false
+	}
+}
+
+// NegotiateLocale picks the supported language with the highest weight in an Accept-Language header,
+// the first one wins a tie and the default locale is used when nothing matches
+func NegotiateLocale(acceptLanguage string) Locale {
+	best := DefaultLocale
+	bestWeight := 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		locale, ok := ParseLocale(tag)
+		if !ok {
+			continue
+		}
+
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		if weight > bestWeight {
+			best = locale
+			bestWeight = weight
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,53 @@
+package model_test
+
+import (
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           model.Locale
+	}{
+		{
+			name:           "empty header",
+			acceptLanguage: "",
+			want:           model.LocaleEN,
+		},
+		{
+			name:           "indonesian with region",
+			acceptLanguage: "id-ID",
+			want:           model.LocaleID,
+		},
+		{
+			name:           "highest weight wins",
+			acceptLanguage: "en-US;q=0.8, id;q=0.9",
+			want:           model.LocaleID,
+		},
+		{
+			name:           "first wins a tie",
+			acceptLanguage: "en, id",
+			want:           model.LocaleEN,
+		},
+		{
+			name:           "unsupported languages are skipped",
+			acceptLanguage: "fr-FR, de;q=0.9, id;q=0.5",
+			want:           model.LocaleID,
+		},
+		{
+			name:           "nothing supported",
+			acceptLanguage: "fr-FR, *;q=0.1",
+			want:           model.LocaleEN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.NegotiateLocale(tt.acceptLanguage))
+		})
+	}
+}
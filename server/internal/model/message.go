@@ -0,0 +1,241 @@
+package model
+
+import "fmt"
+
+type message map[Locale]string
+
+// errorMessages is the catalog of the error codes, %s verbs are filled with the args of the error
+var errorMessages = map[int]message{
+	100: {LocaleEN: "Internal server error", LocaleID: "Terjadi kesalahan pada server"},
+	101: {LocaleEN: "Unauthorized", LocaleID: "Tidak terautentikasi"},
+	102: {LocaleEN: "Bad request", LocaleID: "Permintaan tidak valid"},
+	103: {LocaleEN: "Forbidden", LocaleID: "Akses ditolak"},
+	104: {LocaleEN: "Missing or invalid auth header", LocaleID: "Header autentikasi tidak ada atau tidak valid"},
+	105: {LocaleEN: "Invalid auth token", LocaleID: "Token autentikasi tidak valid"},
+	106: {LocaleEN: "Token revoked", LocaleID: "Token sudah dicabut"},
+	107: {LocaleEN: "Too many requests", LocaleID: "Terlalu banyak permintaan"},
+	108: {
+		LocaleEN: "Service is temporarily unavailable, please try again later",
+		LocaleID: "Layanan sedang tidak tersedia, silakan coba lagi nanti",
+	},
+
+	1000: {LocaleEN: "Email already exist", LocaleID: "Email sudah terdaftar"},
+	1001: {LocaleEN: "User not found", LocaleID: "Pengguna tidak ditemukan"},
+	1002: {LocaleEN: "Invalid email or password", LocaleID: "Email atau kata sandi salah"},
+	1003: {LocaleEN: "Expense not found", LocaleID: "Pengeluaran tidak ditemukan"},
+	1004: {LocaleEN: "Amount can't be less than %s", LocaleID: "Jumlah tidak boleh kurang dari %s"},
+	1005: {LocaleEN: "Amount can't be greater than %s", LocaleID: "Jumlah tidak boleh lebih dari %s"},
+	1006: {LocaleEN: "Expense already processed", LocaleID: "Pengeluaran sudah diproses"},
+	1007: {LocaleEN: "Expense don't require approval", LocaleID: "Pengeluaran tidak memerlukan persetujuan"},
+	1008: {
+		LocaleEN: "Too many failed login attempts, please try again later",
+		LocaleID: "Terlalu banyak percobaan login yang gagal, silakan coba lagi nanti",
+	},
+	1009: {LocaleEN: "Invalid two-factor code", LocaleID: "Kode autentikasi dua faktor salah"},
+	1010: {LocaleEN: "Invalid or expired challenge token", LocaleID: "Token tantangan tidak valid atau sudah kedaluwarsa"},
+	1011: {LocaleEN: "Two-factor authentication is required", LocaleID: "Autentikasi dua faktor wajib diaktifkan"},
+	1012: {LocaleEN: "Two-factor authentication already enabled", LocaleID: "Autentikasi dua faktor sudah aktif"},
+	1013: {LocaleEN: "Two-factor authentication not enrolled", LocaleID: "Autentikasi dua faktor belum didaftarkan"},
+	1014: {
+		LocaleEN: "Invalid or expired password reset token",
+		LocaleID: "Token atur ulang kata sandi tidak valid atau sudah kedaluwarsa",
+	},
+	1015: {LocaleEN: "Current password is incorrect", LocaleID: "Kata sandi saat ini salah"},
+	1016: {LocaleEN: "Access token not found", LocaleID: "Token akses tidak ditemukan"},
+	1017: {LocaleEN: "Access token lacks the required scope", LocaleID: "Token akses tidak memiliki cakupan yang diperlukan"},
+	1018: {LocaleEN: "This action requires a login session", LocaleID: "Tindakan ini memerlukan sesi login"},
+	1019: {
+		LocaleEN: "Invalid or expired single sign-on state",
+		LocaleID: "State single sign-on tidak valid atau sudah kedaluwarsa",
+	},
+	1020: {LocaleEN: "Single sign-on login failed", LocaleID: "Login single sign-on gagal"},
+	1021: {LocaleEN: "User account is deactivated", LocaleID: "Akun pengguna sudah dinonaktifkan"},
+	1022: {
+		LocaleEN: "User with the same email or external id already exist",
+		LocaleID: "Pengguna dengan email atau external id yang sama sudah ada",
+	},
+	1023: {LocaleEN: "Invalid or unsupported SCIM filter", LocaleID: "Filter SCIM tidak valid atau tidak didukung"},
+	1024: {
+		LocaleEN: "Invalid or unsupported SCIM patch operation",
+		LocaleID: "Operasi patch SCIM tidak valid atau tidak didukung",
+	},
+	1025: {LocaleEN: "Group not found", LocaleID: "Grup tidak ditemukan"},
+	1026: {LocaleEN: "You can't deactivate your own account", LocaleID: "Anda tidak dapat menonaktifkan akun Anda sendiri"},
+	1027: {LocaleEN: "Department not found", LocaleID: "Departemen tidak ditemukan"},
+	1028: {
+		LocaleEN: "Department with the same name or cost center already exist",
+		LocaleID: "Departemen dengan nama atau cost center yang sama sudah ada",
+	},
+	1029: {
+		LocaleEN: "Budget period must end on or after its start",
+		LocaleID: "Akhir periode anggaran tidak boleh sebelum awal periode",
+	},
+	1030: {
+		LocaleEN: "Budget period overlaps another budget of the department",
+		LocaleID: "Periode anggaran bertabrakan dengan anggaran lain dari departemen",
+	},
+	1031: {
+		LocaleEN: "Expense exceeds the remaining department budget",
+		LocaleID: "Pengeluaran melebihi sisa anggaran departemen",
+	},
+	1032: {
+		LocaleEN: "Expense exceeds the remaining department budget and needs an admin approval",
+		LocaleID: "Pengeluaran melebihi sisa anggaran departemen dan memerlukan persetujuan admin",
+	},
+	1033: {LocaleEN: "Organization not found", LocaleID: "Organisasi tidak ditemukan"},
+	1034: {
+		LocaleEN: "Minimum amount can't be greater than the maximum amount",
+		LocaleID: "Jumlah minimum tidak boleh lebih besar dari jumlah maksimum",
+	},
+	1035: {LocaleEN: "Invalid cursor", LocaleID: "Cursor tidak valid"},
+	1036: {LocaleEN: "Invalid value for the '%s' filter", LocaleID: "Nilai filter '%s' tidak valid"},
+	1037: {
+		LocaleEN: "Idempotency-Key must be between 1 and 255 characters",
+		LocaleID: "Idempotency-Key harus terdiri dari 1 sampai 255 karakter",
+	},
+	1038: {
+		LocaleEN: "Idempotency-Key was already used for a different request",
+		LocaleID: "Idempotency-Key sudah digunakan untuk permintaan lain",
+	},
+	1039: {
+		LocaleEN: "A request with the same Idempotency-Key is still in progress",
+		LocaleID: "Permintaan dengan Idempotency-Key yang sama masih diproses",
+	},
+	1040: {
+		LocaleEN: "Expense was changed by someone else, reload it and try again",
+		LocaleID: "Pengeluaran sudah diubah oleh orang lain, muat ulang lalu coba lagi",
+	},
+	1041: {LocaleEN: "Export not found", LocaleID: "Ekspor tidak ditemukan"},
+	1042: {
+		LocaleEN: "Export is not ready yet, check its status and try again later",
+		LocaleID: "Ekspor belum siap, periksa statusnya lalu coba lagi nanti",
+	},
+	1043: {LocaleEN: "GL account mapping not found", LocaleID: "Pemetaan akun GL tidak ditemukan"},
+	1044: {
+		LocaleEN: "No GL account mapping for the '%s' cost center and no default mapping",
+		LocaleID: "Tidak ada pemetaan akun GL untuk cost center '%s' dan tidak ada pemetaan default",
+	},
+	1045: {
+		LocaleEN: "Expenses without a cost center need a default GL account mapping",
+		LocaleID: "Pengeluaran tanpa cost center memerlukan pemetaan akun GL default",
+	},
+	1046: {
+		LocaleEN: "No completed expenses left to export for the period",
+		LocaleID: "Tidak ada pengeluaran selesai yang belum diekspor pada periode ini",
+	},
+	1047: {LocaleEN: "Journal batch not found", LocaleID: "Batch jurnal tidak ditemukan"},
+	1048: {LocaleEN: "Ledger entry not found", LocaleID: "Entri buku besar tidak ditemukan"},
+	1049: {LocaleEN: "Ledger entry is already reversed", LocaleID: "Entri buku besar sudah dibalik"},
+	1050: {LocaleEN: "A reversal can't be reversed", LocaleID: "Entri pembalik tidak dapat dibalik"},
+	1051: {LocaleEN: "Statement period must be a month such as 2025-09", LocaleID: "Periode laporan harus berupa bulan seperti 2025-09"},
+	1052: {LocaleEN: "Payment voucher not found, the expense is not paid yet", LocaleID: "Voucher pembayaran tidak ditemukan, pengeluaran belum dibayar"},
+	1053: {LocaleEN: "Comment not found", LocaleID: "Komentar tidak ditemukan"},
+	1054: {
+		LocaleEN: "Only the submitter and the managers of the organization can be mentioned",
+		LocaleID: "Hanya pengaju dan manajer organisasi yang dapat disebut",
+	},
+	1055: {
+		LocaleEN: "Comments can only be edited or deleted within %d minutes of posting",
+		LocaleID: "Komentar hanya dapat diubah atau dihapus dalam %d menit setelah dikirim",
+	},
+	1056: {
+		LocaleEN: "Only expenses sent back with changes requested can be edited",
+		LocaleID: "Hanya pengeluaran yang diminta perubahan yang dapat diubah",
+	},
+	1057: {
+		LocaleEN: "The approval of an expense that is still waiting for payment can't be reversed",
+		LocaleID: "Persetujuan pengeluaran yang masih menunggu pembayaran tidak dapat dibalik",
+	},
+	1058: {
+		LocaleEN: "Payment voucher is not ready yet, try again later",
+		LocaleID: "Voucher pembayaran belum siap, silakan coba lagi nanti",
+	},
+}
+
+// validationMessages is the catalog of the validation rules, the first verb is always the field
+var validationMessages = map[string]message{
+	"required":    {LocaleEN: "%s is required", LocaleID: "%s wajib diisi"},
+	"email":       {LocaleEN: "%s must be a valid email address", LocaleID: "%s harus berupa alamat email yang valid"},
+	"url":         {LocaleEN: "%s must be a valid URL", LocaleID: "%s harus berupa URL yang valid"},
+	"number":      {LocaleEN: "%s must be a number", LocaleID: "%s harus berupa angka"},
+	"numeric":     {LocaleEN: "%s must only contain digits", LocaleID: "%s hanya boleh berisi angka"},
+	"datetime":    {LocaleEN: "%s must be in the %s format", LocaleID: "%s harus dalam format %s"},
+	"oneof":       {LocaleEN: "%s must be one of: %s", LocaleID: "%s harus salah satu dari: %s"},
+	"unique":      {LocaleEN: "%s must not contain duplicates", LocaleID: "%s tidak boleh berisi duplikat"},
+	"nefield":     {LocaleEN: "%s must be different from %s", LocaleID: "%s harus berbeda dari %s"},
+	"eqfield":     {LocaleEN: "%s must be the same as %s", LocaleID: "%s harus sama dengan %s"},
+	"len":         {LocaleEN: "%s must be exactly %s", LocaleID: "%s harus tepat %s"},
+	"min":         {LocaleEN: "%s must be %s or greater", LocaleID: "%s minimal %s"},
+	"min.size":    {LocaleEN: "%s must be at least %s", LocaleID: "%s minimal %s"},
+	"max":         {LocaleEN: "%s must be %s or less", LocaleID: "%s maksimal %s"},
+	"max.size":    {LocaleEN: "%s must be at most %s", LocaleID: "%s maksimal %s"},
+	"gt":          {LocaleEN: "%s must be greater than %s", LocaleID: "%s harus lebih besar dari %s"},
+	"gt.size":     {LocaleEN: "%s must be more than %s", LocaleID: "%s harus lebih dari %s"},
+	"lt":          {LocaleEN: "%s must be less than %s", LocaleID: "%s harus kurang dari %s"},
+	"default":     {LocaleEN: "%s failed on the '%s' rule", LocaleID: "%s tidak memenuhi aturan '%s'"},
+	"unit.char":   {LocaleEN: "%s characters", LocaleID: "%s karakter"},
+	"unit.char.1": {LocaleEN: "%s character", LocaleID: "%s karakter"},
+	"unit.item":   {LocaleEN: "%s items", LocaleID: "%s item"},
+	"unit.item.1": {LocaleEN: "%s item", LocaleID: "%s item"},
+}
+
+// localizedArg is an arg that reads differently per locale, like the unit of a size
+type localizedArg interface {
+	localize(locale Locale) string
+}
+
+func (m message) format(locale Locale, args []any) string {
+	tmpl, ok := m[locale]
+	if !ok {
+		tmpl = m[DefaultLocale]
+	}
+	if len(args) == 0 {
+		return tmpl
+	}
+
+	values := make([]any, len(args))
+	for i, arg := range args {
+		if la, ok := arg.(localizedArg); ok {
+			values[i] = la.localize(locale)
+		} else {
+			values[i] = arg
+		}
+	}
+
+	return fmt.Sprintf(tmpl, values...)
+}
+
+// Localize returns the items of the error with the messages in the given locale,
+// the error itself is left as is because most errors are shared package variables
+func (c *CustomError) Localize(locale Locale) []ErrorItem {
+	items := make([]ErrorItem, len(c.Errors))
+	for i, item := range c.Errors {
+		items[i] = item
+
+		var msg message
+		if item.key != "" {
+			msg = validationMessages[item.key]
+		} else {
+			msg = errorMessages[item.Code]
+		}
+		if msg != nil {
+			items[i].Message = msg.format(locale, item.args)
+		}
+	}
+
+	return items
+}
+
+// newError creates an error with the english message of its code in the catalog
+func newError(httpStatus int, code int, args ...any) *CustomError {
+	return &CustomError{
+		HTTPStatus: httpStatus,
+		Errors: []ErrorItem{
+			{
+				Code:    code,
+				Message: errorMessages[code].format(DefaultLocale, args),
+				args:    args,
+			},
+		},
+	}
+}
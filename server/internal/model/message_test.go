@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomError_Localize(t *testing.T) {
+	tests := []struct {
+		name      string
+		customErr *model.CustomError
+		locale    model.Locale
+		want      []string
+	}{
+		{
+			name:      "english",
+			customErr: model.ErrExpenseNotFound,
+			locale:    model.LocaleEN,
+			want:      []string{"Expense not found"},
+		},
+		{
+			name:      "indonesian",
+			customErr: model.ErrExpenseNotFound,
+			locale:    model.LocaleID,
+			want:      []string{"Pengeluaran tidak ditemukan"},
+		},
+		{
+			name:      "indonesian with amount",
+			customErr: model.NewExpenseMinAmountError(10000),
+			locale:    model.LocaleID,
+			want:      []string{"Jumlah tidak boleh kurang dari Rp 10.000"},
+		},
+		{
+			name:      "indonesian with several items",
+			customErr: model.NewInvalidFilterError("status", "cursor"),
+			locale:    model.LocaleID,
+			want:      []string{"Nilai filter 'status' tidak valid", "Nilai filter 'cursor' tidak valid"},
+		},
+		{
+			name:      "english with cost center",
+			customErr: model.NewGLAccountMappingMissingError("CC-100"),
+			locale:    model.LocaleEN,
+			want:      []string{"No GL account mapping for the 'CC-100' cost center and no default mapping"},
+		},
+		{
+			name:      "message outside the catalog is kept",
+			customErr: &model.CustomError{Errors: []model.ErrorItem{{Code: 9999, Message: "another error"}}},
+			locale:    model.LocaleID,
+			want:      []string{"another error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range tt.customErr.Localize(tt.locale) {
+				got = append(got, item.Message)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCustomError_LocalizeKeepsError(t *testing.T) {
+	_ = model.ErrExpenseNotFound.Localize(model.LocaleID)
+
+	assert.Equal(t, "Expense not found", model.ErrExpenseNotFound.Error())
+}
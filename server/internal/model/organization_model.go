@@ -0,0 +1,27 @@
+package model
+
+type GetOrganizationRequest struct {
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+// UpdateOrganizationRequest changes the expense policy, new expenses are checked against it
+type UpdateOrganizationRequest struct {
+	OrgID                      uint64  `json:"org_id"`    // current user organization
+	UserRole                   string  `json:"user_role"` // current user role
+	MinExpenseAmountIDR        *uint64 `json:"min_expense_amount_idr" validate:"omitempty,gt=0"`
+	MaxExpenseAmountIDR        *uint64 `json:"max_expense_amount_idr" validate:"omitempty,gt=0"`
+	ApprovalThresholdAmountIDR *uint64 `json:"approval_threshold_amount_idr" validate:"omitempty,gt=0"`
+}
+
+type OrganizationResponse struct {
+	ID                               uint64 `json:"id"`
+	Name                             string `json:"name"`
+	MinExpenseAmountIDR              uint64 `json:"min_expense_amount_idr"`
+	MinExpenseAmountFormatted        string `json:"min_expense_amount_formatted"`
+	MaxExpenseAmountIDR              uint64 `json:"max_expense_amount_idr"`
+	MaxExpenseAmountFormatted        string `json:"max_expense_amount_formatted"`
+	ApprovalThresholdAmountIDR       uint64 `json:"approval_threshold_amount_idr"`
+	ApprovalThresholdAmountFormatted string `json:"approval_threshold_amount_formatted"`
+	CreatedAt                        string `json:"created_at"`
+}
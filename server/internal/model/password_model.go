@@ -0,0 +1,17 @@
+package model
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,min=4,max=100,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,min=4,max=100"`
+}
+
+type ChangePasswordRequest struct {
+	UserID          uint64 `json:"user_id"` // current user id
+	OrgID           uint64 `json:"org_id"`  // current user organization
+	CurrentPassword string `json:"current_password" validate:"required,max=100"`
+	NewPassword     string `json:"new_password" validate:"required,min=4,max=100,nefield=CurrentPassword"`
+}
@@ -11,6 +11,7 @@ type PaymentPartnerResponse struct {
 
 type PaymentProcessorRequest struct {
 	ID             uint64 `json:"id"`
+	OrgID          uint64 `json:"org_id"`
 	UserID         uint64 `json:"user_id"`
 	Amount         uint64 `json:"amount"`
 	IdempotencyKey string `json:"idempotency_key"`
@@ -0,0 +1,34 @@
+package model
+
+type ListPersonalAccessTokenRequest struct {
+	UserID uint64 `json:"user_id"` // current user id
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	UserID        uint64   `json:"user_id"`   // current user id
+	OrgID         uint64   `json:"org_id"`    // current user organization
+	UserRole      string   `json:"user_role"` // current user role
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=expenses:read expenses:write scim"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"required,min=1,max=365"`
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	ID     uint64 `json:"id"`
+	UserID uint64 `json:"user_id"` // current user id
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	TokenPrefix string   `json:"token_prefix"`
+	Scopes      []string `json:"scopes"`
+	ExpiresAt   string   `json:"expires_at"`
+	LastUsedAt  *string  `json:"last_used_at"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"` // only shown once
+}
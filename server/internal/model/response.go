@@ -1,14 +1,37 @@
 package model
 
+import "io"
+
 type Meta struct {
 	HTTPStatus int `json:"http_status"`
 }
 
 type MetaWithPage struct {
-	Limit      int `json:"limit"`
-	Offset     int `json:"offset"`
-	Total      int `json:"total"`
-	HTTPStatus int `json:"http_status"`
+	Limit      int     `json:"limit"`
+	Offset     int     `json:"offset"`
+	Total      *int    `json:"total,omitempty"`       // omitted when the count was skipped
+	NextCursor *string `json:"next_cursor,omitempty"` // omitted on the last page
+	HTTPStatus int     `json:"http_status"`
+}
+
+// Page describes where a list stopped, filled by usecases that support cursors
+type Page struct {
+	Total      *int
+	NextCursor *string
+}
+
+// FileResponse is a generated file, the controller sends it as an attachment
+type FileResponse struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// FileStreamResponse is a file that's read from the storage while it's written to the response
+type FileStreamResponse struct {
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Content     io.Reader `json:"-"`
 }
 
 type SuccessResponse[T any] struct {
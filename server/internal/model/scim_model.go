@@ -0,0 +1,115 @@
+package model
+
+import "encoding/json"
+
+const (
+	SCIMContentType = "application/scim+json"
+
+	SCIMSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	SCIMDefaultCount = 100
+	SCIMMaxCount     = 200
+)
+
+type SCIMName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+}
+
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// SCIMUserRequest is used by both create and replace, the user name is the login email
+type SCIMUserRequest struct {
+	ID          string    `json:"-"`         // user id on replace
+	OrgID       uint64    `json:"org_id"`    // current user organization
+	UserRole    string    `json:"user_role"` // current user role
+	ExternalID  *string   `json:"externalId" validate:"omitempty,min=1,max=255"`
+	UserName    string    `json:"userName" validate:"required,min=4,max=100,email"`
+	Name        *SCIMName `json:"name"`
+	DisplayName string    `json:"displayName" validate:"max=100"`
+	Active      *bool     `json:"active"` // defaults to true
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op" validate:"required"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type SCIMPatchRequest struct {
+	ID         string               `json:"-"`         // user or group id
+	OrgID      uint64               `json:"org_id"`    // current user organization
+	UserRole   string               `json:"user_role"` // current user role
+	Operations []SCIMPatchOperation `json:"Operations" validate:"required,min=1,dive"`
+}
+
+type ListSCIMRequest struct {
+	OrgID      uint64 `json:"org_id"`    // current user organization
+	UserRole   string `json:"user_role"` // current user role
+	Filter     string `json:"filter"`
+	StartIndex int    `json:"startIndex"` // 1-based
+	Count      int    `json:"count"`      // SCIMDefaultCount when absent, 0 only returns the total
+}
+
+type SCIMResourceRequest struct {
+	ID       string `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserRole string `json:"user_role"` // current user role
+}
+
+type SCIMUserResponse struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	ExternalID  *string      `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        SCIMName     `json:"name"`
+	DisplayName string       `json:"displayName"`
+	Emails      []SCIMEmail  `json:"emails"`
+	Active      bool         `json:"active"`
+	Groups      []SCIMMember `json:"groups"`
+	Meta        SCIMMeta     `json:"meta"`
+}
+
+type SCIMGroupResponse struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Members     []SCIMMember `json:"members"`
+	Meta        SCIMMeta     `json:"meta"`
+}
+
+type SCIMListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+type SCIMErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
@@ -0,0 +1,30 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func ExpenseGroupStatToResponse(s *entity.ExpenseGroupStat) *model.AnalyticsGroupResponse {
+	return &model.AnalyticsGroupResponse{
+		Key:             s.Key,
+		Label:           s.Label,
+		Count:           s.Count,
+		AmountIDR:       s.Amount,
+		AmountFormatted: model.FormatRupiah(s.Amount),
+	}
+}
+
+// PendingExpenseToResponse includes how long the expense has been waiting at now
+func PendingExpenseToResponse(eu *entity.ExpenseWithUser, now time.Time) *model.AnalyticsPendingResponse {
+	return &model.AnalyticsPendingResponse{
+		ID:              eu.Expense.ID,
+		AmountIDR:       eu.Expense.Amount,
+		AmountFormatted: model.FormatRupiah(eu.Expense.Amount),
+		Description:     eu.Expense.Description,
+		User:            *UserSimpleToResponse(&eu.User),
+		CreatedAt:       eu.Expense.CreatedAt.UTC().Format(time.RFC3339),
+		WaitingSeconds:  int64(now.Sub(eu.Expense.CreatedAt) / time.Second),
+	}
+}
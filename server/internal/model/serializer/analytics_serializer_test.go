@@ -0,0 +1,54 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsSerializer_ExpenseGroupStatToResponse(t *testing.T) {
+	res := serializer.ExpenseGroupStatToResponse(&entity.ExpenseGroupStat{
+		Key:    "2",
+		Label:  "Engineering",
+		Count:  3,
+		Amount: 1_250_000,
+	})
+
+	assert.Equal(t, &model.AnalyticsGroupResponse{
+		Key:             "2",
+		Label:           "Engineering",
+		Count:           3,
+		AmountIDR:       1_250_000,
+		AmountFormatted: "Rp 1.250.000",
+	}, res)
+}
+
+func TestAnalyticsSerializer_PendingExpenseToResponse(t *testing.T) {
+	createdAt := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	res := serializer.PendingExpenseToResponse(&entity.ExpenseWithUser{
+		Expense: entity.Expense{
+			ID:          7,
+			UserID:      3,
+			Amount:      2_000_000,
+			Description: "Flight",
+			Status:      entity.ExpenseStatusAwaitingApproval,
+			CreatedAt:   createdAt,
+		},
+		User: entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+	}, createdAt.Add(26*time.Hour))
+
+	assert.Equal(t, &model.AnalyticsPendingResponse{
+		ID:              7,
+		AmountIDR:       2_000_000,
+		AmountFormatted: "Rp 2.000.000",
+		Description:     "Flight",
+		User:            model.UserSimpleResponse{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+		CreatedAt:       "2025-10-01T08:00:00Z",
+		WaitingSeconds:  93600,
+	}, res)
+}
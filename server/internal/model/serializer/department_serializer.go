@@ -0,0 +1,34 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+const budgetDateLayout = "2006-01-02"
+
+func DepartmentToResponse(d *entity.Department) *model.DepartmentResponse {
+	return &model.DepartmentResponse{
+		ID:         d.ID,
+		Name:       d.Name,
+		CostCenter: d.CostCenter,
+		CreatedAt:  d.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func DepartmentBudgetToResponse(b *entity.DepartmentBudget) *model.DepartmentBudgetResponse {
+	return &model.DepartmentBudgetResponse{
+		ID:                 b.ID,
+		DepartmentID:       b.DepartmentID,
+		PeriodStart:        b.PeriodStart.Format(budgetDateLayout),
+		PeriodEnd:          b.PeriodEnd.Format(budgetDateLayout),
+		AmountIDR:          b.Amount,
+		AmountFormatted:    model.FormatRupiah(b.Amount),
+		SpentIDR:           b.Spent,
+		SpentFormatted:     model.FormatRupiah(b.Spent),
+		RemainingIDR:       b.Remaining(),
+		RemainingFormatted: model.FormatRupiahSigned(b.Remaining()),
+		CreatedAt:          b.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,97 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepartmentSerializer_DepartmentToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	res := serializer.DepartmentToResponse(&entity.Department{
+		ID:         3,
+		Name:       "Finance",
+		CostCenter: "CC-100",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+
+	assert.Equal(t, &model.DepartmentResponse{
+		ID:         3,
+		Name:       "Finance",
+		CostCenter: "CC-100",
+		CreatedAt:  "2025-08-13T10:00:00Z",
+	}, res)
+}
+
+func TestDepartmentSerializer_DepartmentBudgetToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		param   *entity.DepartmentBudget
+		wantRes *model.DepartmentBudgetResponse
+	}{
+		{
+			name: "under budget",
+			param: &entity.DepartmentBudget{
+				ID:           5,
+				DepartmentID: 3,
+				PeriodStart:  time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:    time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+				Amount:       10_000_000,
+				Spent:        2_500_000,
+				CreatedAt:    now,
+			},
+			wantRes: &model.DepartmentBudgetResponse{
+				ID:                 5,
+				DepartmentID:       3,
+				PeriodStart:        "2025-08-01",
+				PeriodEnd:          "2025-08-31",
+				AmountIDR:          10_000_000,
+				AmountFormatted:    "Rp 10.000.000",
+				SpentIDR:           2_500_000,
+				SpentFormatted:     "Rp 2.500.000",
+				RemainingIDR:       7_500_000,
+				RemainingFormatted: "Rp 7.500.000",
+				CreatedAt:          "2025-08-13T10:00:00Z",
+			},
+		},
+		{
+			name: "overspent",
+			param: &entity.DepartmentBudget{
+				ID:           5,
+				DepartmentID: 3,
+				PeriodStart:  time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:    time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+				Amount:       10_000_000,
+				Spent:        11_000_000,
+				CreatedAt:    now,
+			},
+			wantRes: &model.DepartmentBudgetResponse{
+				ID:                 5,
+				DepartmentID:       3,
+				PeriodStart:        "2025-08-01",
+				PeriodEnd:          "2025-08-31",
+				AmountIDR:          10_000_000,
+				AmountFormatted:    "Rp 10.000.000",
+				SpentIDR:           11_000_000,
+				SpentFormatted:     "Rp 11.000.000",
+				RemainingIDR:       -1_000_000,
+				RemainingFormatted: "-Rp 1.000.000",
+				CreatedAt:          "2025-08-13T10:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRes, serializer.DepartmentBudgetToResponse(tt.param))
+		})
+	}
+}
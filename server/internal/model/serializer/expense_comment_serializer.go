@@ -0,0 +1,38 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func ExpenseCommentToResponse(c *entity.ExpenseComment) *model.ExpenseCommentResponse {
+	mentions := make([]model.UserSimpleResponse, 0, len(c.Mentions))
+	for i := range c.Mentions {
+		mentions = append(mentions, *UserSimpleToResponse(&c.Mentions[i]))
+	}
+
+	var editedAt *string
+	if c.EditedAt != nil {
+		str := c.EditedAt.UTC().Format(time.RFC3339)
+		editedAt = &str
+	}
+
+	return &model.ExpenseCommentResponse{
+		ID:        c.ID,
+		Body:      c.Body,
+		User:      *UserSimpleToResponse(&c.User),
+		Mentions:  mentions,
+		CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+		EditedAt:  editedAt,
+	}
+}
+
+func ExpenseCommentsToResponse(comments []entity.ExpenseComment) []model.ExpenseCommentResponse {
+	res := make([]model.ExpenseCommentResponse, 0, len(comments))
+	for i := range comments {
+		res = append(res, *ExpenseCommentToResponse(&comments[i]))
+	}
+
+	return res
+}
@@ -0,0 +1,49 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseCommentSerializer_ExpenseCommentsToResponse(t *testing.T) {
+	createdAt := time.Date(2025, 9, 14, 8, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(5 * time.Minute)
+
+	res := serializer.ExpenseCommentsToResponse([]entity.ExpenseComment{
+		{
+			ID: 5, ExpenseID: 7, UserID: 3, Body: "Which client was this for?", CreatedAt: createdAt, EditedAt: &editedAt,
+			User:     entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+			Mentions: []entity.UserSimple{{ID: 2, Email: "john@mail.com", Name: "John Doe"}},
+		},
+		{
+			ID: 6, ExpenseID: 7, UserID: 2, Body: "PT Maju", CreatedAt: createdAt,
+			User: entity.UserSimple{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+		},
+	})
+
+	edited := "2025-09-14T08:05:00Z"
+	assert.Equal(t, []model.ExpenseCommentResponse{
+		{
+			ID:        5,
+			Body:      "Which client was this for?",
+			User:      model.UserSimpleResponse{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+			Mentions:  []model.UserSimpleResponse{{ID: 2, Email: "john@mail.com", Name: "John Doe"}},
+			CreatedAt: "2025-09-14T08:00:00Z",
+			EditedAt:  &edited,
+		},
+		{
+			ID:        6,
+			Body:      "PT Maju",
+			User:      model.UserSimpleResponse{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+			Mentions:  []model.UserSimpleResponse{},
+			CreatedAt: "2025-09-14T08:00:00Z",
+		},
+	}, res)
+
+	assert.Equal(t, []model.ExpenseCommentResponse{}, serializer.ExpenseCommentsToResponse(nil))
+}
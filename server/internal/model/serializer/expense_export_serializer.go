@@ -0,0 +1,67 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"time"
+)
+
+// ExpenseExportHeader names the columns of ExpenseToExportRow
+var ExpenseExportHeader = []any{
+	"Expense ID", "Reference", "Created At", "Description", "Amount (IDR)", "Status", "Auto Approved",
+	"Cost Center", "Receipt URL", "Submitter Name", "Submitter Email",
+	"Approval Status", "Approver Name", "Approver Email", "Approval Notes", "Decided At",
+	"Payment Reference", "Paid At",
+}
+
+// ExpenseToExportRow returns the cells of the expense in the order of ExpenseExportHeader,
+// the payment reference is the key the payment partner received and only set once paid
+func ExpenseToExportRow(d *entity.ExpenseDetail) []any {
+	autoApproved := "no"
+	if d.AutoApproved() {
+		autoApproved = "yes"
+	}
+
+	row := []any{
+		d.ID, d.GetKey(), d.CreatedAt.UTC().Format(time.RFC3339), d.Description, d.Amount, string(d.Status), autoApproved,
+		d.CostCenter, d.ReceiptURL, d.User.Name, d.User.Email,
+	}
+
+	if d.Approval != nil {
+		row = append(row, string(d.Approval.Status), d.Approval.ApproverName, d.Approval.ApproverEmail,
+			d.Approval.Notes, d.Approval.CreatedAt.UTC().Format(time.RFC3339))
+	} else {
+		row = append(row, nil, nil, nil, nil, nil)
+	}
+
+	if d.Status == entity.ExpenseStatusCompleted && d.ProcessedAt != nil {
+		row = append(row, d.GetKey(), d.ProcessedAt.UTC().Format(time.RFC3339))
+	} else {
+		row = append(row, nil, nil)
+	}
+
+	return row
+}
+
+func ExpenseExportToResponse(e *entity.ExpenseExport) *model.ExpenseExportResponse {
+	res := &model.ExpenseExportResponse{
+		ID:        e.ID,
+		Format:    string(e.Format),
+		Status:    string(e.Status),
+		RowCount:  e.RowCount,
+		CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if e.Status == entity.ExpenseExportStatusCompleted {
+		downloadURL := fmt.Sprintf("/api/expenses/exports/%d/download", e.ID)
+		res.DownloadURL = &downloadURL
+	}
+
+	if e.CompletedAt != nil {
+		completedAt := e.CompletedAt.UTC().Format(time.RFC3339)
+		res.CompletedAt = &completedAt
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseExportSerializer_ExpenseToExportRow(t *testing.T) {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	costCenter := "CC-100"
+	notes := "ok"
+
+	tests := []struct {
+		name    string
+		detail  *entity.ExpenseDetail
+		wantRow []any
+	}{
+		{
+			name: "awaiting approval",
+			detail: &entity.ExpenseDetail{
+				Expense: entity.Expense{
+					ID:                7,
+					Amount:            2000000,
+					Description:       "hotel",
+					Status:            entity.ExpenseStatusAwaitingApproval,
+					ApprovalThreshold: 1000000,
+					CostCenter:        &costCenter,
+					CreatedAt:         now,
+				},
+				User: entity.UserSimple{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+			},
+			wantRow: []any{
+				uint64(7), "EXP-000000007", "2025-09-26T08:00:00Z", "hotel", uint64(2000000), "awaiting_approval", "no",
+				&costCenter, (*string)(nil), "John Doe", "john@mail.com",
+				nil, nil, nil, nil, nil,
+				nil, nil,
+			},
+		},
+		{
+			name: "paid after approval",
+			detail: &entity.ExpenseDetail{
+				Expense: entity.Expense{
+					ID:                7,
+					Amount:            2000000,
+					Description:       "hotel",
+					Status:            entity.ExpenseStatusCompleted,
+					ApprovalThreshold: 1000000,
+					CreatedAt:         now,
+					ProcessedAt:       &now,
+				},
+				User: entity.UserSimple{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+				Approval: &entity.ApprovalDetail{
+					ID:            4,
+					ApproverID:    3,
+					ApproverEmail: "budi@mail.com",
+					ApproverName:  "Budi",
+					Status:        entity.ApprovalStatusApproved,
+					Notes:         &notes,
+					CreatedAt:     now,
+				},
+			},
+			wantRow: []any{
+				uint64(7), "EXP-000000007", "2025-09-26T08:00:00Z", "hotel", uint64(2000000), "completed", "no",
+				(*string)(nil), (*string)(nil), "John Doe", "john@mail.com",
+				"approved", "Budi", "budi@mail.com", &notes, "2025-09-26T08:00:00Z",
+				"EXP-000000007", "2025-09-26T08:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := serializer.ExpenseToExportRow(tt.detail)
+
+			assert.Equal(t, tt.wantRow, row)
+			assert.Len(t, row, len(serializer.ExpenseExportHeader))
+		})
+	}
+}
+
+func TestExpenseExportSerializer_ExpenseExportToResponse(t *testing.T) {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	rowCount := 12000
+	downloadURL := "/api/expenses/exports/5/download"
+	completedAt := "2025-09-26T08:00:00Z"
+
+	tests := []struct {
+		name    string
+		export  *entity.ExpenseExport
+		wantRes *model.ExpenseExportResponse
+	}{
+		{
+			name: "pending",
+			export: &entity.ExpenseExport{
+				ID:        5,
+				Format:    entity.ExportFormatCSV,
+				Status:    entity.ExpenseExportStatusPending,
+				CreatedAt: now,
+			},
+			wantRes: &model.ExpenseExportResponse{
+				ID:        5,
+				Format:    "csv",
+				Status:    "pending",
+				CreatedAt: "2025-09-26T08:00:00Z",
+			},
+		},
+		{
+			name: "completed",
+			export: &entity.ExpenseExport{
+				ID:          5,
+				Format:      entity.ExportFormatXLSX,
+				Status:      entity.ExpenseExportStatusCompleted,
+				RowCount:    &rowCount,
+				CreatedAt:   now,
+				CompletedAt: &now,
+			},
+			wantRes: &model.ExpenseExportResponse{
+				ID:          5,
+				Format:      "xlsx",
+				Status:      "completed",
+				RowCount:    &rowCount,
+				DownloadURL: &downloadURL,
+				CreatedAt:   "2025-09-26T08:00:00Z",
+				CompletedAt: &completedAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRes, serializer.ExpenseExportToResponse(tt.export))
+		})
+	}
+}
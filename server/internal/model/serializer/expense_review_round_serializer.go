@@ -0,0 +1,32 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func ExpenseReviewRoundToResponse(r *entity.ExpenseReviewRound) *model.ExpenseReviewRoundResponse {
+	var resubmittedAt *string
+	if r.ResubmittedAt != nil {
+		str := r.ResubmittedAt.UTC().Format(time.RFC3339)
+		resubmittedAt = &str
+	}
+
+	return &model.ExpenseReviewRoundResponse{
+		Round:         r.Round,
+		Reviewer:      *UserSimpleToResponse(&r.Reviewer),
+		Notes:         r.Notes,
+		RequestedAt:   r.RequestedAt.UTC().Format(time.RFC3339),
+		ResubmittedAt: resubmittedAt,
+	}
+}
+
+func ExpenseReviewRoundsToResponse(rounds []entity.ExpenseReviewRound) []model.ExpenseReviewRoundResponse {
+	res := make([]model.ExpenseReviewRoundResponse, 0, len(rounds))
+	for i := range rounds {
+		res = append(res, *ExpenseReviewRoundToResponse(&rounds[i]))
+	}
+
+	return res
+}
@@ -0,0 +1,47 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseReviewRoundSerializer_ExpenseReviewRoundsToResponse(t *testing.T) {
+	requestedAt := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+	resubmittedAt := requestedAt.Add(2 * time.Hour)
+	reviewer := entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"}
+
+	res := serializer.ExpenseReviewRoundsToResponse([]entity.ExpenseReviewRound{
+		{
+			ID: 4, OrgID: 1, ExpenseID: 7, Round: 1, ReviewerID: 3, Notes: "Attach the receipt",
+			RequestedAt: requestedAt, ResubmittedAt: &resubmittedAt, Reviewer: reviewer,
+		},
+		{
+			ID: 5, OrgID: 1, ExpenseID: 7, Round: 2, ReviewerID: 3, Notes: "Split the hotel",
+			RequestedAt: resubmittedAt, Reviewer: reviewer,
+		},
+	})
+
+	resubmitted := "2025-10-01T10:00:00Z"
+	assert.Equal(t, []model.ExpenseReviewRoundResponse{
+		{
+			Round:         1,
+			Reviewer:      model.UserSimpleResponse{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+			Notes:         "Attach the receipt",
+			RequestedAt:   "2025-10-01T08:00:00Z",
+			ResubmittedAt: &resubmitted,
+		},
+		{
+			Round:       2,
+			Reviewer:    model.UserSimpleResponse{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+			Notes:       "Split the hotel",
+			RequestedAt: "2025-10-01T10:00:00Z",
+		},
+	}, res)
+
+	assert.Equal(t, []model.ExpenseReviewRoundResponse{}, serializer.ExpenseReviewRoundsToResponse(nil))
+}
@@ -3,16 +3,25 @@ package serializer
 import (
 	"expense-management-system/internal/entity"
 	"expense-management-system/internal/model"
+	"html"
+	"strings"
 	"time"
 )
 
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
 func ExpenseToCreateResponse(e *entity.Expense) *model.ExpenseCreateResponse {
 	return &model.ExpenseCreateResponse{
 		ID:               e.ID,
 		AmountIDR:        e.Amount,
+		AmountFormatted:  model.FormatRupiah(e.Amount),
 		Description:      e.Description,
 		ReceiptURL:       e.ReceiptURL,
 		Status:           string(e.Status),
+		CostCenter:       e.CostCenter,
 		RequiresApproval: e.RequiresApproval(),
 		AutoApproved:     e.AutoApproved(),
 		CreatedAt:        e.CreatedAt.UTC().Format(time.RFC3339),
@@ -23,9 +32,11 @@ func ExpenseWithUserToResponse(e *entity.ExpenseWithUser) *model.ExpenseWithUser
 	return &model.ExpenseWithUserResponse{
 		ID:               e.ID,
 		AmountIDR:        e.Amount,
+		AmountFormatted:  model.FormatRupiah(e.Amount),
 		Description:      e.Description,
 		ReceiptURL:       e.ReceiptURL,
 		Status:           string(e.Status),
+		CostCenter:       e.CostCenter,
 		RequiresApproval: e.RequiresApproval(),
 		AutoApproved:     e.AutoApproved(),
 		CreatedAt:        e.CreatedAt.UTC().Format(time.RFC3339),
@@ -43,6 +54,57 @@ func ListExpenseWithUserToResponse(expenses []entity.ExpenseWithUser) []model.Ex
 	return res
 }
 
+func ExpenseSearchResultToResponse(r *entity.ExpenseSearchResult) *model.ExpenseSearchResultResponse {
+	return &model.ExpenseSearchResultResponse{
+		ExpenseWithUserResponse: *ExpenseWithUserToResponse(&r.ExpenseWithUser),
+		Rank:                    r.Rank,
+		Highlights: model.ExpenseSearchHighlightResponse{
+			Description:   escapeHighlight(r.DescriptionHighlight),
+			ApprovalNotes: escapeHighlightPtr(r.ApprovalNotesHighlight),
+			UserName:      escapeHighlightPtr(r.UserNameHighlight),
+		},
+	}
+}
+
+func ListExpenseSearchResultToResponse(results []entity.ExpenseSearchResult) []model.ExpenseSearchResultResponse {
+	res := make([]model.ExpenseSearchResultResponse, len(results))
+
+	for i, r := range results {
+		res[i] = *ExpenseSearchResultToResponse(&r)
+	}
+
+	return res
+}
+
+// escapeHighlight escapes the user text around the <mark> tags added by the database,
+// so a client can render the highlight as HTML without trusting the description
+func escapeHighlight(s string) string {
+	var b strings.Builder
+
+	for _, marked := range strings.Split(s, highlightStart) {
+		parts := strings.Split(marked, highlightStop)
+		for i, part := range parts {
+			if i > 0 {
+				b.WriteString(highlightStop)
+			}
+			b.WriteString(html.EscapeString(part))
+		}
+
+		b.WriteString(highlightStart)
+	}
+
+	return strings.TrimSuffix(b.String(), highlightStart)
+}
+
+func escapeHighlightPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	escaped := escapeHighlight(*s)
+	return &escaped
+}
+
 func ExpenseDetailToResponse(e *entity.ExpenseDetail) *model.ExpenseDetailResponse {
 	var approval *model.ApprovalDetailResponse
 	if e.Approval != nil {
@@ -52,12 +114,15 @@ func ExpenseDetailToResponse(e *entity.ExpenseDetail) *model.ExpenseDetailRespon
 	return &model.ExpenseDetailResponse{
 		ID:               e.ID,
 		AmountIDR:        e.Amount,
+		AmountFormatted:  model.FormatRupiah(e.Amount),
 		Description:      e.Description,
 		ReceiptURL:       e.ReceiptURL,
 		Status:           string(e.Status),
+		CostCenter:       e.CostCenter,
 		RequiresApproval: e.RequiresApproval(),
 		AutoApproved:     e.AutoApproved(),
 		CreatedAt:        e.CreatedAt.UTC().Format(time.RFC3339),
+		Version:          e.Version,
 		User:             *UserSimpleToResponse(&e.User),
 		Approval:         approval,
 	}
@@ -23,17 +23,19 @@ func TestExpenseSerializer_ExpenseToCreateResponse(t *testing.T) {
 		{
 			name: "success",
 			param: &entity.Expense{
-				ID:          1,
-				UserID:      1,
-				Amount:      10000,
-				Description: description,
-				ReceiptURL:  &receipt,
-				Status:      entity.ExpenseStatusApproved,
-				CreatedAt:   now,
+				ID:                1,
+				UserID:            1,
+				Amount:            10000,
+				ApprovalThreshold: 1000000,
+				Description:       description,
+				ReceiptURL:        &receipt,
+				Status:            entity.ExpenseStatusApproved,
+				CreatedAt:         now,
 			},
 			wantRes: &model.ExpenseCreateResponse{
 				ID:               1,
 				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
 				Description:      description,
 				ReceiptURL:       &receipt,
 				Status:           "approved",
@@ -67,13 +69,14 @@ func TestExpenseSerializer_ExpenseWithUserToResponse(t *testing.T) {
 			name: "success",
 			param: &entity.ExpenseWithUser{
 				Expense: entity.Expense{
-					ID:          1,
-					UserID:      1,
-					Amount:      10000,
-					Description: description,
-					ReceiptURL:  &receipt,
-					Status:      entity.ExpenseStatusApproved,
-					CreatedAt:   now,
+					ID:                1,
+					UserID:            1,
+					Amount:            10000,
+					ApprovalThreshold: 1000000,
+					Description:       description,
+					ReceiptURL:        &receipt,
+					Status:            entity.ExpenseStatusApproved,
+					CreatedAt:         now,
 				},
 				User: entity.UserSimple{
 					ID:    1,
@@ -84,6 +87,7 @@ func TestExpenseSerializer_ExpenseWithUserToResponse(t *testing.T) {
 			wantRes: &model.ExpenseWithUserResponse{
 				ID:               1,
 				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
 				Description:      description,
 				ReceiptURL:       &receipt,
 				Status:           "approved",
@@ -123,13 +127,14 @@ func TestExpenseSerializer_ListExpenseWithUserToResponse(t *testing.T) {
 			param: []entity.ExpenseWithUser{
 				{
 					Expense: entity.Expense{
-						ID:          1,
-						UserID:      1,
-						Amount:      10000,
-						Description: description,
-						ReceiptURL:  &receipt,
-						Status:      entity.ExpenseStatusApproved,
-						CreatedAt:   now,
+						ID:                1,
+						UserID:            1,
+						Amount:            10000,
+						ApprovalThreshold: 1000000,
+						Description:       description,
+						ReceiptURL:        &receipt,
+						Status:            entity.ExpenseStatusApproved,
+						CreatedAt:         now,
 					},
 					User: entity.UserSimple{
 						ID:    1,
@@ -142,6 +147,7 @@ func TestExpenseSerializer_ListExpenseWithUserToResponse(t *testing.T) {
 				{
 					ID:               1,
 					AmountIDR:        10000,
+					AmountFormatted:  "Rp 10.000",
 					Description:      description,
 					ReceiptURL:       &receipt,
 					Status:           "approved",
@@ -167,6 +173,65 @@ func TestExpenseSerializer_ListExpenseWithUserToResponse(t *testing.T) {
 	}
 }
 
+func TestExpenseSerializer_ExpenseSearchResultToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	nameHighlight := "<mark>John</mark> <Doe>"
+	wantNameHighlight := "<mark>John</mark> &lt;Doe&gt;"
+
+	tests := []struct {
+		name    string
+		param   *entity.ExpenseSearchResult
+		wantRes *model.ExpenseSearchResultResponse
+	}{
+		{
+			name: "escapes text around the highlights",
+			param: &entity.ExpenseSearchResult{
+				ExpenseWithUser: entity.ExpenseWithUser{
+					Expense: entity.Expense{
+						ID:                1,
+						UserID:            1,
+						Amount:            10000,
+						ApprovalThreshold: 1000000,
+						Description:       `<script>alert("taxi")</script>`,
+						Status:            entity.ExpenseStatusApproved,
+						CreatedAt:         now,
+					},
+					User: entity.UserSimple{ID: 1, Email: "john@mail.com", Name: "John <Doe>"},
+				},
+				Rank:                 0.75,
+				DescriptionHighlight: `<script>alert("<mark>taxi</mark>")</script>`,
+				UserNameHighlight:    &nameHighlight,
+			},
+			wantRes: &model.ExpenseSearchResultResponse{
+				ExpenseWithUserResponse: model.ExpenseWithUserResponse{
+					ID:               1,
+					AmountIDR:        10000,
+					AmountFormatted:  "Rp 10.000",
+					Description:      `<script>alert("taxi")</script>`,
+					Status:           "approved",
+					RequiresApproval: false,
+					AutoApproved:     true,
+					CreatedAt:        now.Format(time.RFC3339),
+					User:             model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John <Doe>"},
+				},
+				Rank: 0.75,
+				Highlights: model.ExpenseSearchHighlightResponse{
+					Description: "&lt;script&gt;alert(&#34;<mark>taxi</mark>&#34;)&lt;/script&gt;",
+					UserName:    &wantNameHighlight,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.ExpenseSearchResultToResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
 func TestExpenseSerializer_ExpenseDetailToResponse(t *testing.T) {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 	description := "dummy description"
@@ -182,13 +247,14 @@ func TestExpenseSerializer_ExpenseDetailToResponse(t *testing.T) {
 			name: "success with approval",
 			param: &entity.ExpenseDetail{
 				Expense: entity.Expense{
-					ID:          1,
-					UserID:      1,
-					Amount:      10000,
-					Description: description,
-					ReceiptURL:  &receipt,
-					Status:      entity.ExpenseStatusApproved,
-					CreatedAt:   now,
+					ID:                1,
+					UserID:            1,
+					Amount:            10000,
+					ApprovalThreshold: 1000000,
+					Description:       description,
+					ReceiptURL:        &receipt,
+					Status:            entity.ExpenseStatusApproved,
+					CreatedAt:         now,
 				},
 				User: entity.UserSimple{
 					ID:    1,
@@ -208,6 +274,7 @@ func TestExpenseSerializer_ExpenseDetailToResponse(t *testing.T) {
 			wantRes: &model.ExpenseDetailResponse{
 				ID:               1,
 				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
 				Description:      description,
 				ReceiptURL:       &receipt,
 				Status:           "approved",
@@ -234,13 +301,14 @@ func TestExpenseSerializer_ExpenseDetailToResponse(t *testing.T) {
 			name: "success without approval",
 			param: &entity.ExpenseDetail{
 				Expense: entity.Expense{
-					ID:          1,
-					UserID:      1,
-					Amount:      10000,
-					Description: description,
-					ReceiptURL:  &receipt,
-					Status:      entity.ExpenseStatusApproved,
-					CreatedAt:   now,
+					ID:                1,
+					UserID:            1,
+					Amount:            10000,
+					ApprovalThreshold: 1000000,
+					Description:       description,
+					ReceiptURL:        &receipt,
+					Status:            entity.ExpenseStatusApproved,
+					CreatedAt:         now,
 				},
 				User: entity.UserSimple{
 					ID:    1,
@@ -252,6 +320,7 @@ func TestExpenseSerializer_ExpenseDetailToResponse(t *testing.T) {
 			wantRes: &model.ExpenseDetailResponse{
 				ID:               1,
 				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
 				Description:      description,
 				ReceiptURL:       &receipt,
 				Status:           "approved",
@@ -0,0 +1,66 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"time"
+)
+
+// JournalExportHeader names the columns of JournalLineToExportRow
+var JournalExportHeader = []any{
+	"Batch ID", "Period", "Line", "Entry Date", "Account", "Debit (IDR)", "Credit (IDR)", "Cost Center", "Expense ID", "Memo",
+}
+
+func GLAccountMappingToResponse(m *entity.GLAccountMapping) *model.GLAccountMappingResponse {
+	return &model.GLAccountMappingResponse{
+		ID:             m.ID,
+		CostCenter:     m.CostCenter,
+		ExpenseAccount: m.ExpenseAccount,
+		CreditAccount:  m.CreditAccount,
+		CreatedAt:      m.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      m.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func JournalBatchToResponse(b *entity.JournalBatch) *model.JournalBatchResponse {
+	return &model.JournalBatchResponse{
+		ID:                   b.ID,
+		Period:               b.Period,
+		CreatedBy:            b.CreatedBy,
+		LineCount:            b.LineCount,
+		TotalAmountIDR:       b.TotalAmount,
+		TotalAmountFormatted: model.FormatRupiah(b.TotalAmount),
+		DownloadURL:          fmt.Sprintf("/api/admin/journal-batches/%d/download", b.ID),
+		CreatedAt:            b.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func JournalLineToResponse(l *entity.JournalLine) *model.JournalLineResponse {
+	return &model.JournalLineResponse{
+		LineNo:     l.LineNo,
+		ExpenseID:  l.ExpenseID,
+		EntryDate:  l.EntryDate.Format(time.DateOnly),
+		Account:    l.Account,
+		DebitIDR:   l.Debit,
+		CreditIDR:  l.Credit,
+		CostCenter: l.CostCenter,
+		Memo:       l.Memo,
+	}
+}
+
+// JournalLineToExportRow returns the cells of the line in the order of JournalExportHeader,
+// the empty side of the entry is left blank
+func JournalLineToExportRow(b *entity.JournalBatch, l *entity.JournalLine) []any {
+	var debit, credit any
+	if l.Debit > 0 {
+		debit = l.Debit
+	}
+	if l.Credit > 0 {
+		credit = l.Credit
+	}
+
+	return []any{
+		b.ID, b.Period, l.LineNo, l.EntryDate.Format(time.DateOnly), l.Account, debit, credit, l.CostCenter, l.ExpenseID, l.Memo,
+	}
+}
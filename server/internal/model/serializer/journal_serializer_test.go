@@ -0,0 +1,74 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalSerializer_JournalBatchToResponse(t *testing.T) {
+	now := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	res := serializer.JournalBatchToResponse(&entity.JournalBatch{
+		ID:          4,
+		OrgID:       1,
+		Period:      "2025-09",
+		CreatedBy:   2,
+		LineCount:   6,
+		TotalAmount: 1_500_000,
+		CreatedAt:   now,
+	})
+
+	assert.Equal(t, &model.JournalBatchResponse{
+		ID:                   4,
+		Period:               "2025-09",
+		CreatedBy:            2,
+		LineCount:            6,
+		TotalAmountIDR:       1_500_000,
+		TotalAmountFormatted: "Rp 1.500.000",
+		DownloadURL:          "/api/admin/journal-batches/4/download",
+		CreatedAt:            "2025-10-01T08:00:00Z",
+	}, res)
+}
+
+func TestJournalSerializer_JournalLineToExportRow(t *testing.T) {
+	costCenter := "CC-100"
+	entryDate := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	batch := &entity.JournalBatch{ID: 4, Period: "2025-09"}
+
+	tests := []struct {
+		name    string
+		line    *entity.JournalLine
+		wantRow []any
+	}{
+		{
+			name: "debit",
+			line: &entity.JournalLine{
+				ExpenseID: 7, LineNo: 1, EntryDate: entryDate, Account: "6100", Debit: 150_000, CostCenter: &costCenter,
+				Memo: "EXP-000000007 Taxi",
+			},
+			wantRow: []any{
+				uint64(4), "2025-09", 1, "2025-09-14", "6100", uint64(150_000), nil, &costCenter, uint64(7), "EXP-000000007 Taxi",
+			},
+		},
+		{
+			name: "credit",
+			line: &entity.JournalLine{
+				ExpenseID: 7, LineNo: 2, EntryDate: entryDate, Account: "2100", Credit: 150_000, Memo: "EXP-000000007 Taxi",
+			},
+			wantRow: []any{
+				uint64(4), "2025-09", 2, "2025-09-14", "2100", nil, uint64(150_000), (*string)(nil), uint64(7), "EXP-000000007 Taxi",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRow, serializer.JournalLineToExportRow(batch, tt.line))
+		})
+	}
+}
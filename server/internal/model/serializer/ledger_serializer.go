@@ -0,0 +1,38 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func LedgerBalanceToResponse(b *entity.LedgerBalance) *model.LedgerBalanceResponse {
+	return &model.LedgerBalanceResponse{
+		User:             *UserSimpleToResponse(&b.User),
+		BalanceIDR:       b.Balance,
+		BalanceFormatted: model.FormatRupiahSigned(b.Balance),
+	}
+}
+
+func LedgerEntryToResponse(e *entity.LedgerEntry) *model.LedgerEntryResponse {
+	postings := make([]model.LedgerPostingResponse, 0, len(e.Postings))
+	for _, p := range e.Postings {
+		postings = append(postings, model.LedgerPostingResponse{
+			Account:   string(p.AccountType),
+			UserID:    p.UserID,
+			DebitIDR:  p.Debit,
+			CreditIDR: p.Credit,
+		})
+	}
+
+	return &model.LedgerEntryResponse{
+		ID:              e.ID,
+		ExpenseID:       e.ExpenseID,
+		Type:            string(e.Type),
+		ReversesEntryID: e.ReversesEntryID,
+		Memo:            e.Memo,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt.UTC().Format(time.RFC3339),
+		Postings:        postings,
+	}
+}
@@ -0,0 +1,60 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerSerializer_LedgerBalanceToResponse(t *testing.T) {
+	res := serializer.LedgerBalanceToResponse(&entity.LedgerBalance{
+		User:    entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+		Balance: 250_000,
+	})
+
+	assert.Equal(t, &model.LedgerBalanceResponse{
+		User:             model.UserSimpleResponse{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+		BalanceIDR:       250_000,
+		BalanceFormatted: "Rp 250.000",
+	}, res)
+}
+
+func TestLedgerSerializer_LedgerEntryToResponse(t *testing.T) {
+	now := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+	userID := uint64(3)
+	entryID := uint64(10)
+	createdBy := uint64(2)
+
+	res := serializer.LedgerEntryToResponse(&entity.LedgerEntry{
+		ID:              11,
+		OrgID:           1,
+		ExpenseID:       7,
+		Type:            entity.LedgerEntryTypeReversal,
+		ReversesEntryID: &entryID,
+		Memo:            "Bank transfer bounced",
+		CreatedBy:       &createdBy,
+		CreatedAt:       now,
+		Postings: []entity.LedgerPosting{
+			{ID: 21, EntryID: 11, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 50_000},
+			{ID: 22, EntryID: 11, AccountID: 2, AccountType: entity.LedgerAccountTypeCash, Debit: 50_000},
+		},
+	})
+
+	assert.Equal(t, &model.LedgerEntryResponse{
+		ID:              11,
+		ExpenseID:       7,
+		Type:            "reversal",
+		ReversesEntryID: &entryID,
+		Memo:            "Bank transfer bounced",
+		CreatedBy:       &createdBy,
+		CreatedAt:       "2025-10-01T08:00:00Z",
+		Postings: []model.LedgerPostingResponse{
+			{Account: "employee_payable", UserID: &userID, CreditIDR: 50_000},
+			{Account: "cash", DebitIDR: 50_000},
+		},
+	}, res)
+}
@@ -0,0 +1,21 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func OrganizationToResponse(o *entity.Organization) *model.OrganizationResponse {
+	return &model.OrganizationResponse{
+		ID:                               o.ID,
+		Name:                             o.Name,
+		MinExpenseAmountIDR:              o.MinExpenseAmount,
+		MinExpenseAmountFormatted:        model.FormatRupiah(o.MinExpenseAmount),
+		MaxExpenseAmountIDR:              o.MaxExpenseAmount,
+		MaxExpenseAmountFormatted:        model.FormatRupiah(o.MaxExpenseAmount),
+		ApprovalThresholdAmountIDR:       o.ApprovalThresholdAmount,
+		ApprovalThresholdAmountFormatted: model.FormatRupiah(o.ApprovalThresholdAmount),
+		CreatedAt:                        o.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
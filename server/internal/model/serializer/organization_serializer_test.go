@@ -0,0 +1,37 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationSerializer_OrganizationToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+
+	res := serializer.OrganizationToResponse(&entity.Organization{
+		ID:                      2,
+		Name:                    "Subsidiary",
+		MinExpenseAmount:        5000,
+		MaxExpenseAmount:        20_000_000,
+		ApprovalThresholdAmount: 500_000,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	})
+
+	assert.Equal(t, &model.OrganizationResponse{
+		ID:                               2,
+		Name:                             "Subsidiary",
+		MinExpenseAmountIDR:              5000,
+		MinExpenseAmountFormatted:        "Rp 5.000",
+		MaxExpenseAmountIDR:              20_000_000,
+		MaxExpenseAmountFormatted:        "Rp 20.000.000",
+		ApprovalThresholdAmountIDR:       500_000,
+		ApprovalThresholdAmountFormatted: "Rp 500.000",
+		CreatedAt:                        "2025-08-13T10:00:00Z",
+	}, res)
+}
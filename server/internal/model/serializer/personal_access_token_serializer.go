@@ -0,0 +1,25 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+func PersonalAccessTokenToResponse(t *entity.PersonalAccessToken) *model.PersonalAccessTokenResponse {
+	var lastUsedAt *string
+	if t.LastUsedAt != nil {
+		formatted := t.LastUsedAt.UTC().Format(time.RFC3339)
+		lastUsedAt = &formatted
+	}
+
+	return &model.PersonalAccessTokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		Scopes:      t.Scopes,
+		ExpiresAt:   t.ExpiresAt.UTC().Format(time.RFC3339),
+		LastUsedAt:  lastUsedAt,
+		CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,77 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonalAccessTokenSerializer_PersonalAccessTokenToResponse(t *testing.T) {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	lastUsedAt := now.Add(time.Hour)
+	lastUsedAtStr := lastUsedAt.Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		param   *entity.PersonalAccessToken
+		wantRes *model.PersonalAccessTokenResponse
+	}{
+		{
+			name: "never used",
+			param: &entity.PersonalAccessToken{
+				ID:          1,
+				UserID:      1,
+				Name:        "finance script",
+				TokenHash:   "hash",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   now.AddDate(0, 0, 30),
+				CreatedAt:   now,
+			},
+			wantRes: &model.PersonalAccessTokenResponse{
+				ID:          1,
+				Name:        "finance script",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   now.AddDate(0, 0, 30).Format(time.RFC3339),
+				LastUsedAt:  nil,
+				CreatedAt:   now.Format(time.RFC3339),
+			},
+		},
+		{
+			name: "used",
+			param: &entity.PersonalAccessToken{
+				ID:          1,
+				UserID:      1,
+				Name:        "finance script",
+				TokenHash:   "hash",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   now.AddDate(0, 0, 30),
+				LastUsedAt:  &lastUsedAt,
+				CreatedAt:   now,
+			},
+			wantRes: &model.PersonalAccessTokenResponse{
+				ID:          1,
+				Name:        "finance script",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   now.AddDate(0, 0, 30).Format(time.RFC3339),
+				LastUsedAt:  &lastUsedAtStr,
+				CreatedAt:   now.Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.PersonalAccessTokenToResponse(tt.param)
+
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	scimRoleGroupPrefix       = "role-"
+	scimDepartmentGroupPrefix = "department-"
+)
+
+// SCIMRoleGroupID names the virtual group that carries a role
+func SCIMRoleGroupID(role entity.UserRole) string {
+	return scimRoleGroupPrefix + string(role)
+}
+
+// SCIMRoleFromGroupID is the reverse of SCIMRoleGroupID
+func SCIMRoleFromGroupID(id string) (entity.UserRole, error) {
+	if !strings.HasPrefix(id, scimRoleGroupPrefix) {
+		return "", fmt.Errorf("invalid role group id = %s", id)
+	}
+
+	return entity.ParseUserRole(strings.TrimPrefix(id, scimRoleGroupPrefix))
+}
+
+// SCIMDepartmentGroupID names the group that carries a department
+func SCIMDepartmentGroupID(id uint64) string {
+	return fmt.Sprintf("%s%d", scimDepartmentGroupPrefix, id)
+}
+
+// SCIMDepartmentFromGroupID is the reverse of SCIMDepartmentGroupID
+func SCIMDepartmentFromGroupID(id string) (uint64, error) {
+	if !strings.HasPrefix(id, scimDepartmentGroupPrefix) {
+		return 0, fmt.Errorf("invalid department group id = %s", id)
+	}
+
+	return strconv.ParseUint(strings.TrimPrefix(id, scimDepartmentGroupPrefix), 10, 64)
+}
+
+func UserToSCIMResponse(u *entity.User) *model.SCIMUserResponse {
+	givenName, familyName, _ := strings.Cut(u.Name, " ")
+
+	groups := []model.SCIMMember{
+		{
+			Value:   SCIMRoleGroupID(u.Role),
+			Display: string(u.Role),
+		},
+	}
+	if u.DepartmentID != nil {
+		groups = append(groups, model.SCIMMember{Value: SCIMDepartmentGroupID(*u.DepartmentID)})
+	}
+
+	return &model.SCIMUserResponse{
+		Schemas:    []string{model.SCIMSchemaUser},
+		ID:         fmt.Sprint(u.ID),
+		ExternalID: u.ExternalID,
+		UserName:   u.Email,
+		Name: model.SCIMName{
+			Formatted:  u.Name,
+			GivenName:  givenName,
+			FamilyName: familyName,
+		},
+		DisplayName: u.Name,
+		Emails: []model.SCIMEmail{
+			{
+				Value:   u.Email,
+				Type:    "work",
+				Primary: true,
+			},
+		},
+		Active: u.Active,
+		Groups: groups,
+		Meta: model.SCIMMeta{
+			ResourceType: "User",
+			Created:      u.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: u.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     fmt.Sprintf("/scim/v2/Users/%d", u.ID),
+		},
+	}
+}
+
+func RoleToSCIMGroupResponse(role entity.UserRole, members []entity.User) *model.SCIMGroupResponse {
+	return scimGroupResponse(SCIMRoleGroupID(role), string(role), members)
+}
+
+func DepartmentToSCIMGroupResponse(d *entity.Department, members []entity.User) *model.SCIMGroupResponse {
+	return scimGroupResponse(SCIMDepartmentGroupID(d.ID), d.Name, members)
+}
+
+func scimGroupResponse(id string, displayName string, members []entity.User) *model.SCIMGroupResponse {
+	items := make([]model.SCIMMember, len(members))
+	for i, m := range members {
+		items[i] = model.SCIMMember{
+			Value:   fmt.Sprint(m.ID),
+			Display: m.Name,
+		}
+	}
+
+	return &model.SCIMGroupResponse{
+		Schemas:     []string{model.SCIMSchemaGroup},
+		ID:          id,
+		DisplayName: displayName,
+		Members:     items,
+		Meta: model.SCIMMeta{
+			ResourceType: "Group",
+			Location:     "/scim/v2/Groups/" + id,
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSCIMSerializer_UserToSCIMResponse(t *testing.T) {
+	now := time.Date(2025, 9, 19, 8, 0, 0, 0, time.UTC)
+	externalID := "emp-001"
+
+	tests := []struct {
+		name    string
+		param   *entity.User
+		wantRes *model.SCIMUserResponse
+	}{
+		{
+			name: "success",
+			param: &entity.User{
+				ID:         1,
+				Email:      "john@mail.com",
+				Name:       "John Doe",
+				Role:       "manager",
+				Active:     true,
+				ExternalID: &externalID,
+				CreatedAt:  now,
+				UpdatedAt:  now.Add(time.Hour),
+			},
+			wantRes: &model.SCIMUserResponse{
+				Schemas:     []string{model.SCIMSchemaUser},
+				ID:          "1",
+				ExternalID:  &externalID,
+				UserName:    "john@mail.com",
+				Name:        model.SCIMName{Formatted: "John Doe", GivenName: "John", FamilyName: "Doe"},
+				DisplayName: "John Doe",
+				Emails:      []model.SCIMEmail{{Value: "john@mail.com", Type: "work", Primary: true}},
+				Active:      true,
+				Groups:      []model.SCIMMember{{Value: "role-manager", Display: "manager"}},
+				Meta: model.SCIMMeta{
+					ResourceType: "User",
+					Created:      "2025-09-19T08:00:00Z",
+					LastModified: "2025-09-19T09:00:00Z",
+					Location:     "/scim/v2/Users/1",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serializer.UserToSCIMResponse(tt.param)
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestSCIMSerializer_SCIMRoleFromGroupID(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    string
+		wantRole entity.UserRole
+		wantErr  bool
+	}{
+		{name: "role group", param: "role-admin", wantRole: entity.UserRoleAdmin},
+		{name: "unknown role", param: "role-owner", wantErr: true},
+		{name: "not a role group", param: "manager", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := serializer.SCIMRoleFromGroupID(tt.param)
+			assert.Equal(t, tt.wantRole, role)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestSCIMSerializer_SCIMDepartmentFromGroupID(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		wantID  uint64
+		wantErr bool
+	}{
+		{name: "department group", param: "department-3", wantID: 3},
+		{name: "invalid department id", param: "department-finance", wantErr: true},
+		{name: "not a department group", param: "role-admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := serializer.SCIMDepartmentFromGroupID(tt.param)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package serializer
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"time"
+)
+
+const statementDateLayout = "2006-01-02"
+
+// StatementHeader names the columns of ExpenseToStatementRow
+var StatementHeader = []string{"Reference", "Submitted", "Description", "Amount", "Status", "Paid On"}
+
+// StatementColumnWidths are the widths in points of the StatementHeader columns on an A4 page
+var StatementColumnWidths = []float64{75, 58, 122, 80, 95, 65}
+
+var statementStatusLabels = map[entity.ExpenseStatus]string{
+	entity.ExpenseStatusAwaitingApproval: "Awaiting approval",
+	entity.ExpenseStatusApproved:         "Approved",
+	entity.ExpenseStatusRejected:         "Rejected",
+	entity.ExpenseStatusCompleted:        "Paid",
+	entity.ExpenseStatusChangesRequested: "Changes requested",
+}
+
+// ExpenseToStatementRow returns the cells of the expense in the order of StatementHeader, dates are in UTC
+func ExpenseToStatementRow(e *entity.Expense) []string {
+	paidOn := "-"
+	if e.Status == entity.ExpenseStatusCompleted && e.ProcessedAt != nil {
+		paidOn = e.ProcessedAt.UTC().Format(statementDateLayout)
+	}
+
+	return []string{
+		e.GetKey(),
+		e.CreatedAt.UTC().Format(statementDateLayout),
+		e.Description,
+		model.FormatRupiah(e.Amount),
+		statementStatusLabels[e.Status],
+		paidOn,
+	}
+}
+
+// UserSummaryToResponse splits the totals of all time and of every month from the first to the last month
+// into pending, approved but not paid yet and paid, months without expenses have zero totals
+func UserSummaryToResponse(totals []entity.ExpenseStatusTotal, monthly []entity.ExpenseStatusTotal, from time.Time,
+	to time.Time) *model.UserSummaryResponse {
+	res := &model.UserSummaryResponse{}
+	for _, t := range totals {
+		addSpendingTotal(&res.Pending, &res.ApprovedUnpaid, &res.Paid, &t)
+	}
+	formatSpendingTotals(&res.Pending, &res.ApprovedUnpaid, &res.Paid)
+
+	index := make(map[string]int)
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		period := month.Format("2006-01")
+		index[period] = len(res.Periods)
+		res.Periods = append(res.Periods, model.SpendingPeriodResponse{Period: period})
+	}
+
+	for _, t := range monthly {
+		i, ok := index[t.Month]
+		if !ok {
+			continue
+		}
+		p := &res.Periods[i]
+		addSpendingTotal(&p.Pending, &p.ApprovedUnpaid, &p.Paid, &t)
+	}
+	for i := range res.Periods {
+		p := &res.Periods[i]
+		formatSpendingTotals(&p.Pending, &p.ApprovedUnpaid, &p.Paid)
+	}
+
+	return res
+}
+
+func addSpendingTotal(pending, approvedUnpaid, paid *model.SpendingTotalResponse, t *entity.ExpenseStatusTotal) {
+	var dst *model.SpendingTotalResponse
+	switch t.Status {
+	case entity.ExpenseStatusAwaitingApproval, entity.ExpenseStatusChangesRequested:
+		dst = pending
+	case entity.ExpenseStatusApproved:
+		dst = approvedUnpaid
+	case entity.ExpenseStatusCompleted:
+		dst = paid
+	default:
+		return
+	}
+
+	dst.Count += t.Count
+	dst.AmountIDR += t.Amount
+}
+
+func formatSpendingTotals(totals ...*model.SpendingTotalResponse) {
+	for _, t := range totals {
+		t.AmountFormatted = model.FormatRupiah(t.AmountIDR)
+	}
+}
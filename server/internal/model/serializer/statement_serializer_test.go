@@ -0,0 +1,87 @@
+package serializer_test
+
+import (
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementSerializer_ExpenseToStatementRow(t *testing.T) {
+	createdAt := time.Date(2025, 9, 14, 8, 0, 0, 0, time.UTC)
+	processedAt := time.Date(2025, 9, 16, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expense *entity.Expense
+		want    []string
+	}{
+		{
+			name: "paid",
+			expense: &entity.Expense{
+				ID: 7, Amount: 150_000, Description: "Taxi", Status: entity.ExpenseStatusCompleted,
+				CreatedAt: createdAt, ProcessedAt: &processedAt,
+			},
+			want: []string{"EXP-000000007", "2025-09-14", "Taxi", "Rp 150.000", "Paid", "2025-09-16"},
+		},
+		{
+			name: "awaiting approval",
+			expense: &entity.Expense{
+				ID: 8, Amount: 2_000_000, Description: "Flight", Status: entity.ExpenseStatusAwaitingApproval,
+				CreatedAt: createdAt,
+			},
+			want: []string{"EXP-000000008", "2025-09-14", "Flight", "Rp 2.000.000", "Awaiting approval", "-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, serializer.ExpenseToStatementRow(tt.expense))
+		})
+	}
+}
+
+func TestStatementSerializer_UserSummaryToResponse(t *testing.T) {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	zero := model.SpendingTotalResponse{AmountFormatted: "Rp 0"}
+
+	res := serializer.UserSummaryToResponse(
+		[]entity.ExpenseStatusTotal{
+			{Status: entity.ExpenseStatusAwaitingApproval, Count: 1, Amount: 2_000_000},
+			{Status: entity.ExpenseStatusChangesRequested, Count: 1, Amount: 500_000},
+			{Status: entity.ExpenseStatusApproved, Count: 2, Amount: 75_000},
+			{Status: entity.ExpenseStatusCompleted, Count: 3, Amount: 300_000},
+		},
+		[]entity.ExpenseStatusTotal{
+			{Month: "2025-08", Status: entity.ExpenseStatusCompleted, Count: 1, Amount: 100_000},
+			{Month: "2025-10", Status: entity.ExpenseStatusAwaitingApproval, Count: 1, Amount: 2_000_000},
+			{Month: "2025-10", Status: entity.ExpenseStatusApproved, Count: 2, Amount: 75_000},
+		},
+		from, to,
+	)
+
+	assert.Equal(t, &model.UserSummaryResponse{
+		Pending:        model.SpendingTotalResponse{Count: 2, AmountIDR: 2_500_000, AmountFormatted: "Rp 2.500.000"},
+		ApprovedUnpaid: model.SpendingTotalResponse{Count: 2, AmountIDR: 75_000, AmountFormatted: "Rp 75.000"},
+		Paid:           model.SpendingTotalResponse{Count: 3, AmountIDR: 300_000, AmountFormatted: "Rp 300.000"},
+		Periods: []model.SpendingPeriodResponse{
+			{
+				Period:         "2025-08",
+				Pending:        zero,
+				ApprovedUnpaid: zero,
+				Paid:           model.SpendingTotalResponse{Count: 1, AmountIDR: 100_000, AmountFormatted: "Rp 100.000"},
+			},
+			{Period: "2025-09", Pending: zero, ApprovedUnpaid: zero, Paid: zero},
+			{
+				Period:         "2025-10",
+				Pending:        model.SpendingTotalResponse{Count: 1, AmountIDR: 2_000_000, AmountFormatted: "Rp 2.000.000"},
+				ApprovedUnpaid: model.SpendingTotalResponse{Count: 2, AmountIDR: 75_000, AmountFormatted: "Rp 75.000"},
+				Paid:           zero,
+			},
+		},
+	}, res)
+}
@@ -7,12 +7,20 @@ import (
 )
 
 func UserToResponse(u *entity.User) *model.UserResponse {
+	var locale *string
+	if u.Locale != "" {
+		locale = &u.Locale
+	}
+
 	return &model.UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Role:      string(u.Role),
-		CreatedAt: u.CreatedAt.UTC().Format(time.RFC3339),
+		ID:           u.ID,
+		Email:        u.Email,
+		Name:         u.Name,
+		Role:         string(u.Role),
+		Active:       u.Active,
+		DepartmentID: u.DepartmentID,
+		Locale:       locale,
+		CreatedAt:    u.CreatedAt.UTC().Format(time.RFC3339),
 	}
 }
 
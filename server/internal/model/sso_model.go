@@ -0,0 +1,14 @@
+package model
+
+type SSOCallbackRequest struct {
+	Code  string `json:"code" validate:"required,max=512"`  // authorization code from the identity provider
+	State string `json:"state" validate:"required,max=100"` // state returned by Authorize
+
+	StateCookie string `json:"-"` // state of the cookie set by Authorize, must be the same as State
+}
+
+type SSOAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+
+	State string `json:"-"` // set as cookie, ties the login to the browser that started it
+}
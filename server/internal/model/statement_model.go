@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type UserSummaryRequest struct {
+	UserID uint64    `json:"user_id"` // current user id
+	OrgID  uint64    `json:"org_id"`  // current user organization
+	From   time.Time `json:"from"`    // start of the first month
+	To     time.Time `json:"to"`      // start of the last month
+}
+
+type GetStatementRequest struct {
+	UserID uint64 `json:"user_id"` // current user id
+	OrgID  uint64 `json:"org_id"`  // current user organization
+	Period string `json:"period"`  // month of submission, e.g. 2025-09
+}
+
+type UserSummaryResponse struct {
+	Pending        SpendingTotalResponse    `json:"pending"`         // awaiting approval or sent back for changes
+	ApprovedUnpaid SpendingTotalResponse    `json:"approved_unpaid"` // still owed to the user
+	Paid           SpendingTotalResponse    `json:"paid"`
+	Periods        []SpendingPeriodResponse `json:"periods"`
+}
+
+type SpendingPeriodResponse struct {
+	Period         string                `json:"period"`
+	Pending        SpendingTotalResponse `json:"pending"`
+	ApprovedUnpaid SpendingTotalResponse `json:"approved_unpaid"`
+	Paid           SpendingTotalResponse `json:"paid"`
+}
+
+type SpendingTotalResponse struct {
+	Count           int    `json:"count"`
+	AmountIDR       uint64 `json:"amount_idr"`
+	AmountFormatted string `json:"amount_formatted"`
+}
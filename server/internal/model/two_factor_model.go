@@ -0,0 +1,34 @@
+package model
+
+type GetTwoFactorRequest struct {
+	UserID   uint64 `json:"user_id"`   // current user id
+	UserRole string `json:"user_role"` // current user role
+}
+
+type EnrollTwoFactorRequest struct {
+	UserID uint64 `json:"user_id"` // current user id
+	OrgID  uint64 `json:"org_id"`  // current user organization
+}
+
+type ActivateTwoFactorRequest struct {
+	UserID uint64 `json:"user_id"` // current user id
+	Code   string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type DisableTwoFactorRequest struct {
+	UserID   uint64 `json:"user_id"`                               // current user id
+	UserRole string `json:"user_role"`                             // current user role
+	Code     string `json:"code" validate:"required,min=6,max=20"` // totp or recovery code
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret          string   `json:"secret"`
+	ProvisioningURI string   `json:"provisioning_uri"`
+	RecoveryCodes   []string `json:"recovery_codes"` // only shown once
+}
@@ -7,16 +7,56 @@ type CreateUserRequest struct {
 	Role     string `json:"role" validate:"required,oneof=employee manager"`
 }
 
+type ListUserRequest struct {
+	OrgID        uint64  `json:"org_id"`      // current user organization
+	UserRole     string  `json:"user_role"`   // current user role
+	Search       *string `json:"search"`      // part of the name or email
+	Email        *string `json:"email"`       // exact match
+	ExternalID   *string `json:"external_id"` // exact match
+	Role         *string `json:"role"`
+	DepartmentID *uint64 `json:"department_id"`
+	Active       *bool   `json:"active"`
+	Limit        int     `json:"limit"` // zero means no limit
+	Offset       int     `json:"offset"`
+	CountOnly    bool    `json:"-"` // only the total is read
+}
+
 type GetUserRequest struct {
-	ID uint64 `json:"id"`
+	ID    uint64 `json:"id"`
+	OrgID uint64 `json:"org_id"` // current user organization
+}
+
+type UpdateUserRequest struct {
+	ID           uint64  `json:"id"`
+	OrgID        uint64  `json:"org_id"`    // current user organization
+	UserRole     string  `json:"user_role"` // current user role
+	Email        *string `json:"email" validate:"omitempty,min=4,max=100,email"`
+	Name         *string `json:"name" validate:"omitempty,min=4,max=100"`
+	DepartmentID *uint64 `json:"department_id"` // zero removes the user from their department
+}
+
+type UpdateUserActiveRequest struct {
+	ID       uint64 `json:"id"`
+	OrgID    uint64 `json:"org_id"`    // current user organization
+	UserID   uint64 `json:"user_id"`   // current user id
+	UserRole string `json:"user_role"` // current user role
+}
+
+type UpdateLocaleRequest struct {
+	UserID uint64 `json:"user_id"`                                 // current user id
+	OrgID  uint64 `json:"org_id"`                                  // current user organization
+	Locale string `json:"locale" validate:"omitempty,oneof=en id"` // empty removes the preference
 }
 
 type UserResponse struct {
-	ID        uint64 `json:"id"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	Role      string `json:"role"`
-	CreatedAt string `json:"created_at"`
+	ID           uint64  `json:"id"`
+	Email        string  `json:"email"`
+	Name         string  `json:"name"`
+	Role         string  `json:"role"`
+	Active       bool    `json:"active"`
+	DepartmentID *uint64 `json:"department_id"`
+	Locale       *string `json:"locale"`
+	CreatedAt    string  `json:"created_at"`
 }
 
 type UserSimpleResponse struct {
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validationCodes gives every rule its own code, so a client can branch on the code alone
+var validationCodes = map[string]int{
+	"required": 2000,
+	"email":    2001,
+	"url":      2002,
+	"number":   2003,
+	"numeric":  2004,
+	"datetime": 2005,
+	"oneof":    2006,
+	"unique":   2007,
+	"nefield":  2008,
+	"eqfield":  2009,
+	"len":      2010,
+	"min":      2011,
+	"max":      2012,
+	"gt":       2013,
+	"lt":       2014,
+	"gte":      2015,
+	"lte":      2016,
+}
+
+// validationDefaultCode is the code of the rules that have no code of their own
+const validationDefaultCode = 2099
+
+// NewValidationError translates the failed rules of validate.Struct into one error item per field,
+// the code of an item is the code of the rule it failed on
+func NewValidationError(errs validator.ValidationErrors) *CustomError {
+	err := &CustomError{HTTPStatus: http.StatusBadRequest}
+	for _, fe := range errs {
+		field := validationField(fe)
+		key, args := validationMessage(field, fe)
+		err.Append(ErrorItem{
+			Code:    validationCode(fe.Tag()),
+			Message: validationMessages[key].format(DefaultLocale, args),
+			Field:   field,
+			Rule:    fe.Tag(),
+			key:     key,
+			args:    args,
+		})
+	}
+
+	return err
+}
+
+func validationCode(rule string) int {
+	if code, ok := validationCodes[rule]; ok {
+		return code
+	}
+
+	return validationDefaultCode
+}
+
+// validationField returns the path of the field without the name of the request struct (e.g. scopes[0]),
+// the names come from the json tags registered by config.NewValidator
+func validationField(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+
+	return fe.Field()
+}
+
+// validationMessage returns the key of the rule in the catalog and the values for its verbs
+func validationMessage(field string, fe validator.FieldError) (string, []any) {
+	param := fe.Param()
+
+	switch fe.Tag() {
+	case "required", "email", "url", "number", "numeric", "unique":
+		return fe.Tag(), []any{field}
+	case "datetime":
+		return "datetime", []any{field, param}
+	case "oneof":
+		return "oneof", []any{field, strings.Join(strings.Fields(param), ", ")}
+	case "nefield", "eqfield":
+		return fe.Tag(), []any{field, snakeCase(param)}
+	case "len", "lt":
+		return fe.Tag(), []any{field, newSizeArg(fe.Kind(), param)}
+	case "min", "gte":
+		return sizeKey("min", fe.Kind()), []any{field, newSizeArg(fe.Kind(), param)}
+	case "max", "lte":
+		return sizeKey("max", fe.Kind()), []any{field, newSizeArg(fe.Kind(), param)}
+	case "gt":
+		return sizeKey("gt", fe.Kind()), []any{field, newSizeArg(fe.Kind(), param)}
+	default:
+		return "default", []any{field, fe.Tag()}
+	}
+}
+
+// sizeKey picks the wording of a rule that compares numbers by value and strings / lists by size
+func sizeKey(rule string, kind reflect.Kind) string {
+	if isNumber(kind) {
+		return rule
+	}
+
+	return rule + ".size"
+}
+
+// sizeArg is the param of a size rule with the unit it counts in, characters for strings and items for lists
+type sizeArg struct {
+	param string
+	unit  string
+}
+
+func newSizeArg(kind reflect.Kind, param string) any {
+	var unit string
+	switch kind {
+	case reflect.String:
+		unit = "unit.char"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = "unit.item"
+	default:
+		return param
+	}
+	if param == "1" {
+		unit += ".1"
+	}
+
+	return sizeArg{param: param, unit: unit}
+}
+
+func (a sizeArg) localize(locale Locale) string {
+	return validationMessages[a.unit].format(locale, []any{a.param})
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// snakeCase turns the struct field named by nefield / eqfield into the json name (CurrentPassword -> current_password)
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package model_test
+
+import (
+	"expense-management-system/internal/config"
+	"expense-management-system/internal/model"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationRequest struct {
+	Name            string   `json:"name" validate:"required,min=2,max=5"`
+	Amount          uint64   `json:"amount_idr" validate:"gt=0"`
+	Tags            []string `json:"tags" validate:"min=1,dive,oneof=a b"`
+	CurrentPassword string   `json:"current_password"`
+	NewPassword     string   `json:"new_password" validate:"nefield=CurrentPassword"`
+	Code            string   `json:"code" validate:"omitempty,alpha"`
+}
+
+func TestNewValidationError(t *testing.T) {
+	tests := []struct {
+		name    string
+		request validationRequest
+		want    []model.ErrorItem
+		wantID  []string
+	}{
+		{
+			name:    "required and gt",
+			request: validationRequest{Tags: []string{"a"}, NewPassword: "x"},
+			want: []model.ErrorItem{
+				{Code: 2000, Message: "name is required", Field: "name", Rule: "required"},
+				{Code: 2013, Message: "amount_idr must be greater than 0", Field: "amount_idr", Rule: "gt"},
+			},
+			wantID: []string{"name wajib diisi", "amount_idr harus lebih besar dari 0"},
+		},
+		{
+			name:    "string length",
+			request: validationRequest{Name: "abcdef", Amount: 1, Tags: []string{"a"}, NewPassword: "x"},
+			want: []model.ErrorItem{
+				{Code: 2012, Message: "name must be at most 5 characters", Field: "name", Rule: "max"},
+			},
+			wantID: []string{"name maksimal 5 karakter"},
+		},
+		{
+			name:    "list size and items",
+			request: validationRequest{Name: "ab", Amount: 1, Tags: []string{}, NewPassword: "x"},
+			want: []model.ErrorItem{
+				{Code: 2011, Message: "tags must be at least 1 item", Field: "tags", Rule: "min"},
+			},
+			wantID: []string{"tags minimal 1 item"},
+		},
+		{
+			name:    "oneof on item",
+			request: validationRequest{Name: "ab", Amount: 1, Tags: []string{"a", "c"}, NewPassword: "x"},
+			want: []model.ErrorItem{
+				{Code: 2006, Message: "tags[1] must be one of: a, b", Field: "tags[1]", Rule: "oneof"},
+			},
+			wantID: []string{"tags[1] harus salah satu dari: a, b"},
+		},
+		{
+			name:    "nefield",
+			request: validationRequest{Name: "ab", Amount: 1, Tags: []string{"a"}, CurrentPassword: "x", NewPassword: "x"},
+			want: []model.ErrorItem{
+				{Code: 2008, Message: "new_password must be different from current_password", Field: "new_password", Rule: "nefield"},
+			},
+			wantID: []string{"new_password harus berbeda dari current_password"},
+		},
+		{
+			name:    "rule without its own code",
+			request: validationRequest{Name: "ab", Amount: 1, Tags: []string{"a"}, NewPassword: "x", Code: "a1"},
+			want: []model.ErrorItem{
+				{Code: 2099, Message: "code failed on the 'alpha' rule", Field: "code", Rule: "alpha"},
+			},
+			wantID: []string{"code tidak memenuhi aturan 'alpha'"},
+		},
+	}
+
+	validate := config.NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+
+			var valErrs validator.ValidationErrors
+			require.ErrorAs(t, err, &valErrs)
+
+			got := model.NewValidationError(valErrs)
+			assert.Equal(t, 400, got.HTTPStatus)
+			assert.Equal(t, tt.want, exportedItems(got.Errors))
+
+			var gotID []string
+			for _, item := range got.Localize(model.LocaleID) {
+				gotID = append(gotID, item.Message)
+			}
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
+
+// exportedItems drops the catalog key and args so the items can be compared with a literal
+func exportedItems(items []model.ErrorItem) []model.ErrorItem {
+	out := make([]model.ErrorItem, len(items))
+	for i, item := range items {
+		out[i] = model.ErrorItem{Code: item.Code, Message: item.Message, Field: item.Field, Rule: item.Rule}
+	}
+
+	return out
+}
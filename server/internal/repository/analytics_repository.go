@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"strings"
+)
+
+// analyticsGroupColumns are the key and the label expressions of every breakdown
+var analyticsGroupColumns = map[model.AnalyticsGroupBy][2]string{
+	model.AnalyticsGroupByStatus: {"e.status::text", "e.status::text"},
+	model.AnalyticsGroupByMonth: {
+		"to_char(date_trunc('month', e.created_at AT TIME ZONE 'UTC'), 'YYYY-MM')",
+		"to_char(date_trunc('month', e.created_at AT TIME ZONE 'UTC'), 'YYYY-MM')",
+	},
+	model.AnalyticsGroupByDepartment: {"COALESCE(d.id::text, '')", "COALESCE(d.name, '')"},
+	model.AnalyticsGroupByCostCenter: {"COALESCE(e.cost_center, '')", "COALESCE(e.cost_center, '')"},
+	model.AnalyticsGroupByEmployee:   {"u.id::text", "u.name"},
+}
+
+type AnalyticsRepository struct {
+	db db.PgxIface
+}
+
+func NewAnalyticsRepository(db db.PgxIface) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db: db,
+	}
+}
+
+// analyticsFilters narrows the expenses to the organization and the requested range,
+// the queries stay on the org_id and created_at indexes of the expenses
+func analyticsFilters(req *model.AnalyticsRequest) ([]string, []any) {
+	whereClauses := []string{"e.org_id = $1"}
+	whereArgs := []any{req.OrgID}
+	argCount := 2
+
+	if req.CreatedFrom != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.created_at >= $%d", argCount))
+		whereArgs = append(whereArgs, *req.CreatedFrom)
+		argCount++
+	}
+
+	if req.CreatedTo != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.created_at <= $%d", argCount))
+		whereArgs = append(whereArgs, *req.CreatedTo)
+		argCount++
+	}
+
+	if req.DepartmentID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("e.department_id = $%d", argCount))
+		whereArgs = append(whereArgs, *req.DepartmentID)
+	}
+
+	return whereClauses, whereArgs
+}
+
+// GroupStats counts and sums the expenses per key, months come oldest first
+// and the other groups largest amount first
+func (r *AnalyticsRepository) GroupStats(ctx context.Context, req *model.AnalyticsRequest) ([]entity.ExpenseGroupStat, error) {
+	columns, ok := analyticsGroupColumns[req.GroupBy]
+	if !ok {
+		return nil, errors.New("unknown analytics group")
+	}
+
+	whereClauses, whereArgs := analyticsFilters(req)
+
+	orderBy := "amount DESC, key ASC"
+	if req.GroupBy == model.AnalyticsGroupByMonth {
+		orderBy = "key ASC"
+	}
+
+	query := `
+		SELECT ` + columns[0] + ` AS key, ` + columns[1] + ` AS label, COUNT(*) AS count, COALESCE(SUM(e.amount), 0)::BIGINT AS amount
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		LEFT JOIN departments AS d ON e.department_id = d.id
+		WHERE ` + strings.Join(whereClauses, " AND ") + `
+		GROUP BY 1, 2
+		ORDER BY ` + orderBy
+
+	rows, err := r.db.Query(ctx, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseGroupStat
+	for rows.Next() {
+		var s entity.ExpenseGroupStat
+		err := rows.Scan(&s.Key, &s.Label, &s.Count, &s.Amount)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, s)
+	}
+
+	return results, nil
+}
+
+// Turnaround averages the time from submission to the manager approval and from the approval to the payout,
+// expenses approved automatically are paid from their submission
+func (r *AnalyticsRepository) Turnaround(ctx context.Context, req *model.AnalyticsRequest) (*entity.ExpenseTurnaround, error) {
+	whereClauses, whereArgs := analyticsFilters(req)
+
+	query := `
+		SELECT
+			EXTRACT(EPOCH FROM AVG(a.created_at - e.created_at) FILTER (WHERE a.status = 'approved'))::BIGINT AS avg_seconds_to_approve,
+			EXTRACT(EPOCH FROM AVG(e.processed_at - COALESCE(a.created_at, e.created_at)) FILTER (WHERE e.status = 'completed'))::BIGINT AS avg_seconds_to_pay
+		FROM expenses AS e
+		LEFT JOIN approvals AS a ON a.expense_id = e.id
+		WHERE ` + strings.Join(whereClauses, " AND ")
+
+	var t entity.ExpenseTurnaround
+	err := r.db.QueryRow(ctx, query, whereArgs...).Scan(&t.AvgSecondsToApprove, &t.AvgSecondsToPay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// OldestPending returns the expenses waiting the longest for a manager
+func (r *AnalyticsRepository) OldestPending(ctx context.Context, req *model.AnalyticsRequest, limit int) ([]entity.ExpenseWithUser, error) {
+	whereClauses, whereArgs := analyticsFilters(req)
+	whereClauses = append(whereClauses, "e.status = 'awaiting_approval'")
+	whereArgs = append(whereArgs, limit)
+
+	query := `
+		SELECT
+			e.id, e.user_id, e.amount, e.description, e.status, e.created_at, e.department_id, e.cost_center, e.org_id,
+			u.id, u.email, u.name
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		WHERE ` + strings.Join(whereClauses, " AND ") + fmt.Sprintf(`
+		ORDER BY e.created_at ASC, e.id ASC
+		LIMIT $%d`, len(whereArgs))
+
+	rows, err := r.db.Query(ctx, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseWithUser
+	for rows.Next() {
+		var eu entity.ExpenseWithUser
+		err := rows.Scan(
+			&eu.Expense.ID, &eu.Expense.UserID, &eu.Expense.Amount, &eu.Expense.Description, &eu.Expense.Status,
+			&eu.Expense.CreatedAt, &eu.Expense.DepartmentID, &eu.Expense.CostCenter, &eu.Expense.OrgID,
+			&eu.User.ID, &eu.User.Email, &eu.User.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, eu)
+	}
+
+	return results, nil
+}
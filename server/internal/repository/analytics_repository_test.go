@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnalyticsRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.AnalyticsRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *AnalyticsRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewAnalyticsRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *AnalyticsRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *AnalyticsRepositorySuite) TestAnalyticsRepository_GroupStats() {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	departmentID := uint64(2)
+	columns := []string{"key", "label", "count", "amount"}
+
+	tests := []struct {
+		name     string
+		req      *model.AnalyticsRequest
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseGroupStat
+		wantErr  error
+	}{
+		{
+			name:     "error on unknown group",
+			req:      &model.AnalyticsRequest{OrgID: 1, GroupBy: "category"},
+			mockFunc: func(m pgxmock.PgxPoolIface) {},
+			wantRes:  nil,
+			wantErr:  errors.New("unknown analytics group"),
+		},
+		{
+			name: "error",
+			req:  &model.AnalyticsRequest{OrgID: 1, GroupBy: model.AnalyticsGroupByStatus},
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT e.status::text AS key, e.status::text AS label`)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "by month with filters",
+			req: &model.AnalyticsRequest{
+				OrgID: 1, CreatedFrom: &from, DepartmentID: &departmentID, GroupBy: model.AnalyticsGroupByMonth,
+			},
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT to_char(date_trunc('month', e.created_at AT TIME ZONE 'UTC'), 'YYYY-MM') AS key, to_char(date_trunc('month', e.created_at AT TIME ZONE 'UTC'), 'YYYY-MM') AS label, COUNT(*) AS count, COALESCE(SUM(e.amount), 0)::BIGINT AS amount FROM expenses AS e JOIN users AS u ON e.user_id = u.id LEFT JOIN departments AS d ON e.department_id = d.id WHERE e.org_id = $1 AND e.created_at >= $2 AND e.department_id = $3 GROUP BY 1, 2 ORDER BY key ASC`)).
+					WithArgs(uint64(1), from, departmentID).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow("2025-09", "2025-09", 2, uint64(150000)).
+						AddRow("2025-10", "2025-10", 1, uint64(20000)))
+			},
+			wantRes: []entity.ExpenseGroupStat{
+				{Key: "2025-09", Label: "2025-09", Count: 2, Amount: 150000},
+				{Key: "2025-10", Label: "2025-10", Count: 1, Amount: 20000},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "by employee",
+			req:  &model.AnalyticsRequest{OrgID: 1, GroupBy: model.AnalyticsGroupByEmployee},
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT u.id::text AS key, u.name AS label, COUNT(*) AS count, COALESCE(SUM(e.amount), 0)::BIGINT AS amount FROM expenses AS e JOIN users AS u ON e.user_id = u.id LEFT JOIN departments AS d ON e.department_id = d.id WHERE e.org_id = $1 GROUP BY 1, 2 ORDER BY amount DESC, key ASC`)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow("3", "John Doe", 2, uint64(150000)))
+			},
+			wantRes: []entity.ExpenseGroupStat{
+				{Key: "3", Label: "John Doe", Count: 2, Amount: 150000},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.GroupStats(s.ctx, tt.req)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *AnalyticsRepositorySuite) TestAnalyticsRepository_Turnaround() {
+	query := `SELECT EXTRACT(EPOCH FROM AVG(a.created_at - e.created_at) FILTER (WHERE a.status = 'approved'))::BIGINT AS avg_seconds_to_approve, EXTRACT(EPOCH FROM AVG(e.processed_at - COALESCE(a.created_at, e.created_at)) FILTER (WHERE e.status = 'completed'))::BIGINT AS avg_seconds_to_pay FROM expenses AS e LEFT JOIN approvals AS a ON a.expense_id = e.id WHERE e.org_id = $1`
+	toApprove := int64(3600)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.ExpenseTurnaround
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"avg_seconds_to_approve", "avg_seconds_to_pay"}).
+						AddRow(&toApprove, nil))
+			},
+			wantRes: &entity.ExpenseTurnaround{AvgSecondsToApprove: &toApprove},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.Turnaround(s.ctx, &model.AnalyticsRequest{OrgID: 1})
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *AnalyticsRepositorySuite) TestAnalyticsRepository_OldestPending() {
+	query := `SELECT e.id, e.user_id, e.amount, e.description, e.status, e.created_at, e.department_id, e.cost_center, e.org_id, u.id, u.email, u.name FROM expenses AS e JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.status = 'awaiting_approval' ORDER BY e.created_at ASC, e.id ASC LIMIT $2`
+	columns := []string{"id", "user_id", "amount", "description", "status", "created_at", "department_id", "cost_center", "org_id", "id", "email", "name"}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseWithUser
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), 5).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), 5).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(7), uint64(3), uint64(2000000), "Flight", entity.ExpenseStatusAwaitingApproval, s.now, nil, nil, uint64(1),
+							uint64(3), "john@mail.com", "John Doe"))
+			},
+			wantRes: []entity.ExpenseWithUser{
+				{
+					Expense: entity.Expense{
+						ID: 7, UserID: 3, Amount: 2000000, Description: "Flight", Status: entity.ExpenseStatusAwaitingApproval,
+						CreatedAt: s.now, OrgID: 1,
+					},
+					User: entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.OldestPending(s.ctx, &model.AnalyticsRequest{OrgID: 1}, 5)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAnalyticsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsRepositorySuite))
+}
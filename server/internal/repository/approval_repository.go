@@ -20,11 +20,12 @@ func NewApprovalRepository(db db.PgxIface) *ApprovalRepository {
 func (r *ApprovalRepository) CreateTx(ctx context.Context, exec db.Executor, approval *entity.Approval) error {
 	now := time.Now()
 	query := `
-		INSERT INTO approvals (expense_id, approver_id, status, notes, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO approvals (org_id, expense_id, approver_id, status, notes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id`
 
 	err := exec.QueryRow(ctx, query,
+		approval.OrgID,
 		approval.ExpenseID,
 		approval.ApproverID,
 		approval.Status,
@@ -45,12 +45,13 @@ func (s *ApprovalRepositorySuite) TestApprovalRepository_CreateTx() {
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO approvals (expense_id, approver_id, status, notes, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					`INSERT INTO approvals (org_id, expense_id, approver_id, status, notes, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
 				)).
-					WithArgs(uint64(1), uint64(1), pgxmock.AnyArg(), &notes, pgxmock.AnyArg()).
+					WithArgs(uint64(2), uint64(1), uint64(1), pgxmock.AnyArg(), &notes, pgxmock.AnyArg()).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &entity.Approval{
+				OrgID:      uint64(2),
 				ExpenseID:  uint64(1),
 				ApproverID: uint64(1),
 				Status:     entity.ApprovalStatusApproved,
@@ -62,12 +63,13 @@ func (s *ApprovalRepositorySuite) TestApprovalRepository_CreateTx() {
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO approvals (expense_id, approver_id, status, notes, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					`INSERT INTO approvals (org_id, expense_id, approver_id, status, notes, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
 				)).
-					WithArgs(uint64(1), uint64(1), pgxmock.AnyArg(), &notes, pgxmock.AnyArg()).
+					WithArgs(uint64(2), uint64(1), uint64(1), pgxmock.AnyArg(), &notes, pgxmock.AnyArg()).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
 			},
 			param: &entity.Approval{
+				OrgID:      uint64(2),
 				ExpenseID:  uint64(1),
 				ApproverID: uint64(1),
 				Status:     entity.ApprovalStatusApproved,
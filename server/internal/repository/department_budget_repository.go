@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// spent sums the approved and completed expenses that were created within the budget period,
+// period_end is inclusive so the range ends at the start of the next day
+const selectDepartmentBudget = `
+		SELECT
+			b.id, b.org_id, b.department_id, b.period_start, b.period_end, b.amount,
+			COALESCE((
+				SELECT SUM(e.amount) FROM expenses AS e
+				WHERE e.department_id = b.department_id
+					AND e.status IN ('approved', 'completed')
+					AND e.created_at >= b.period_start
+					AND e.created_at < b.period_end + 1
+			), 0) AS spent,
+			b.created_at
+		FROM department_budgets AS b`
+
+type DepartmentBudgetRepository struct {
+	db db.PgxIface
+}
+
+func NewDepartmentBudgetRepository(db db.PgxIface) *DepartmentBudgetRepository {
+	return &DepartmentBudgetRepository{
+		db: db,
+	}
+}
+
+// Create returns false when the period overlaps another budget of the department
+func (r *DepartmentBudgetRepository) Create(ctx context.Context, budget *entity.DepartmentBudget) (bool, error) {
+	now := time.Now()
+	query := `
+		INSERT INTO department_budgets (org_id, department_id, period_start, period_end, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ON CONSTRAINT excl_department_budgets_department_id_period DO NOTHING
+		RETURNING id`
+
+	err := r.db.QueryRow(ctx, query,
+		budget.OrgID,
+		budget.DepartmentID,
+		budget.PeriodStart,
+		budget.PeriodEnd,
+		budget.Amount,
+		now,
+	).Scan(&budget.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	budget.CreatedAt = now
+
+	return true, nil
+}
+
+func (r *DepartmentBudgetRepository) ListByDepartmentID(ctx context.Context, orgID uint64,
+	departmentID uint64) ([]entity.DepartmentBudget, error) {
+	query := selectDepartmentBudget + ` WHERE b.org_id = $1 AND b.department_id = $2 ORDER BY b.period_start DESC`
+
+	rows, err := r.db.Query(ctx, query, orgID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.DepartmentBudget
+	for rows.Next() {
+		b, err := scanDepartmentBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *b)
+	}
+
+	return results, nil
+}
+
+// FindByDate returns the budget whose period covers the date
+func (r *DepartmentBudgetRepository) FindByDate(ctx context.Context, orgID uint64, departmentID uint64,
+	date time.Time) (*entity.DepartmentBudget, error) {
+	query := selectDepartmentBudget +
+		` WHERE b.org_id = $1 AND b.department_id = $2 AND $3::date BETWEEN b.period_start AND b.period_end LIMIT 1`
+
+	return r.findOne(r.db.QueryRow(ctx, query, orgID, departmentID, date))
+}
+
+// FindByDateWithLock locks the budget so approvals against it are checked one at a time,
+// spent is summed after the lock is held so it sees the approvals committed while waiting for it
+func (r *DepartmentBudgetRepository) FindByDateWithLock(ctx context.Context, exec db.Executor, orgID uint64,
+	departmentID uint64, date time.Time) (*entity.DepartmentBudget, error) {
+	query := `
+		SELECT id, org_id, department_id, period_start, period_end, amount, created_at
+		FROM department_budgets
+		WHERE org_id = $1 AND department_id = $2 AND $3::date BETWEEN period_start AND period_end
+		LIMIT 1
+		FOR UPDATE`
+
+	var b entity.DepartmentBudget
+	err := exec.QueryRow(ctx, query, orgID, departmentID, date).
+		Scan(&b.ID, &b.OrgID, &b.DepartmentID, &b.PeriodStart, &b.PeriodEnd, &b.Amount, &b.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	spentQuery := `
+		SELECT COALESCE(SUM(amount), 0) FROM expenses
+		WHERE org_id = $1
+			AND department_id = $2
+			AND status IN ('approved', 'completed')
+			AND created_at >= $3::date
+			AND created_at < $4::date + 1`
+
+	err = exec.QueryRow(ctx, spentQuery, b.OrgID, b.DepartmentID, b.PeriodStart, b.PeriodEnd).Scan(&b.Spent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *DepartmentBudgetRepository) findOne(row pgx.Row) (*entity.DepartmentBudget, error) {
+	b, err := scanDepartmentBudget(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+func scanDepartmentBudget(row pgx.Row) (*entity.DepartmentBudget, error) {
+	var b entity.DepartmentBudget
+	err := row.Scan(&b.ID, &b.OrgID, &b.DepartmentID, &b.PeriodStart, &b.PeriodEnd, &b.Amount, &b.Spent, &b.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
@@ -0,0 +1,293 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+const selectDepartmentBudget = `SELECT b.id, b.org_id, b.department_id, b.period_start, b.period_end, b.amount, ` +
+	`COALESCE(( SELECT SUM(e.amount) FROM expenses AS e WHERE e.department_id = b.department_id ` +
+	`AND e.status IN ('approved', 'completed') AND e.created_at >= b.period_start ` +
+	`AND e.created_at < b.period_end + 1 ), 0) AS spent, b.created_at FROM department_budgets AS b`
+
+type DepartmentBudgetRepositorySuite struct {
+	suite.Suite
+	mock        pgxmock.PgxPoolIface
+	repo        *repository.DepartmentBudgetRepository
+	ctx         context.Context
+	now         time.Time
+	periodStart time.Time
+	periodEnd   time.Time
+	columns     []string
+}
+
+func (s *DepartmentBudgetRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewDepartmentBudgetRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.periodStart = time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	s.periodEnd = time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	s.columns = []string{"id", "org_id", "department_id", "period_start", "period_end", "amount", "spent", "created_at"}
+}
+
+func (s *DepartmentBudgetRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *DepartmentBudgetRepositorySuite) budget() *entity.DepartmentBudget {
+	return &entity.DepartmentBudget{
+		ID:           5,
+		OrgID:        1,
+		DepartmentID: 3,
+		PeriodStart:  s.periodStart,
+		PeriodEnd:    s.periodEnd,
+		Amount:       10_000_000,
+		Spent:        2_500_000,
+		CreatedAt:    s.now,
+	}
+}
+
+func (s *DepartmentBudgetRepositorySuite) rows() *pgxmock.Rows {
+	return pgxmock.NewRows(s.columns).
+		AddRow(uint64(5), uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), uint64(2_500_000), s.now)
+}
+
+func (s *DepartmentBudgetRepositorySuite) TestDepartmentBudgetRepository_Create() {
+	query := `INSERT INTO department_budgets (org_id, department_id, period_start, period_end, amount, created_at) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT ON CONSTRAINT excl_department_budgets_department_id_period DO NOTHING ` +
+		`RETURNING id`
+
+	tests := []struct {
+		name        string
+		mockFunc    func(pgxmock.PgxPoolIface)
+		wantID      uint64
+		wantCreated bool
+		wantErr     error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "overlap",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), pgxmock.AnyArg()).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantID:      0,
+			wantCreated: false,
+			wantErr:     nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+			},
+			wantID:      5,
+			wantCreated: true,
+			wantErr:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			budget := &entity.DepartmentBudget{
+				OrgID:        1,
+				DepartmentID: 3,
+				PeriodStart:  s.periodStart,
+				PeriodEnd:    s.periodEnd,
+				Amount:       10_000_000,
+			}
+			created, err := s.repo.Create(s.ctx, budget)
+			s.Equal(tt.wantID, budget.ID)
+			s.Equal(tt.wantCreated, created)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentBudgetRepositorySuite) TestDepartmentBudgetRepository_ListByDepartmentID() {
+	query := selectDepartmentBudget + ` WHERE b.org_id = $1 AND b.department_id = $2 ORDER BY b.period_start DESC`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.DepartmentBudget
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3)).
+					WillReturnRows(s.rows())
+			},
+			wantRes: []entity.DepartmentBudget{*s.budget()},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByDepartmentID(s.ctx, 1, 3)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentBudgetRepositorySuite) TestDepartmentBudgetRepository_FindByDate() {
+	query := selectDepartmentBudget +
+		` WHERE b.org_id = $1 AND b.department_id = $2 AND $3::date BETWEEN b.period_start AND b.period_end LIMIT 1`
+	date := time.Date(2025, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.DepartmentBudget
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnRows(s.rows())
+			},
+			wantRes: s.budget(),
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByDate(s.ctx, 1, 3, date)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentBudgetRepositorySuite) TestDepartmentBudgetRepository_FindByDateWithLock() {
+	query := `SELECT id, org_id, department_id, period_start, period_end, amount, created_at FROM department_budgets ` +
+		`WHERE org_id = $1 AND department_id = $2 AND $3::date BETWEEN period_start AND period_end LIMIT 1 FOR UPDATE`
+	spentQuery := `SELECT COALESCE(SUM(amount), 0) FROM expenses WHERE org_id = $1 AND department_id = $2 ` +
+		`AND status IN ('approved', 'completed') AND created_at >= $3::date AND created_at < $4::date + 1`
+	columns := []string{"id", "org_id", "department_id", "period_start", "period_end", "amount", "created_at"}
+	date := time.Date(2025, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.DepartmentBudget
+		wantErr  error
+	}{
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "error on spent",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(5), uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), s.now))
+				m.ExpectQuery(regexp.QuoteMeta(spentQuery)).
+					WithArgs(uint64(1), uint64(3), s.periodStart, s.periodEnd).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(3), date).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(5), uint64(1), uint64(3), s.periodStart, s.periodEnd, uint64(10_000_000), s.now))
+				m.ExpectQuery(regexp.QuoteMeta(spentQuery)).
+					WithArgs(uint64(1), uint64(3), s.periodStart, s.periodEnd).
+					WillReturnRows(pgxmock.NewRows([]string{"coalesce"}).AddRow(uint64(2_500_000)))
+			},
+			wantRes: s.budget(),
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByDateWithLock(s.ctx, s.mock, 1, 3, date)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestDepartmentBudgetRepositorySuite(t *testing.T) {
+	suite.Run(t, new(DepartmentBudgetRepositorySuite))
+}
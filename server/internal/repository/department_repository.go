@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type DepartmentRepository struct {
+	db db.PgxIface
+}
+
+func NewDepartmentRepository(db db.PgxIface) *DepartmentRepository {
+	return &DepartmentRepository{
+		db: db,
+	}
+}
+
+func (r *DepartmentRepository) Create(ctx context.Context, department *entity.Department) error {
+	now := time.Now()
+	query := `
+		INSERT INTO departments (org_id, name, cost_center, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id`
+
+	err := r.db.QueryRow(ctx, query,
+		department.OrgID,
+		department.Name,
+		department.CostCenter,
+		now,
+	).Scan(&department.ID)
+	if err != nil {
+		return err
+	}
+
+	department.CreatedAt = now
+	department.UpdatedAt = now
+
+	return nil
+}
+
+func (r *DepartmentRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Department, error) {
+	query := `SELECT id, org_id, name, cost_center, created_at, updated_at FROM departments WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	var d entity.Department
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(&d.ID, &d.OrgID, &d.Name, &d.CostCenter, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &d, nil
+}
+
+func (r *DepartmentRepository) List(ctx context.Context, orgID uint64) ([]entity.Department, error) {
+	query := `SELECT id, org_id, name, cost_center, created_at, updated_at FROM departments WHERE org_id = $1 ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.Department
+	for rows.Next() {
+		var d entity.Department
+		err := rows.Scan(&d.ID, &d.OrgID, &d.Name, &d.CostCenter, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, d)
+	}
+
+	return results, nil
+}
+
+// CountByNameOrCostCenter counts the departments of the organization other than id that already use the name or cost center
+func (r *DepartmentRepository) CountByNameOrCostCenter(ctx context.Context, orgID uint64, id uint64, name string,
+	costCenter string) (int, error) {
+	query := `SELECT COUNT(id) FROM departments WHERE org_id = $1 AND id != $2 AND (name = $3 OR cost_center = $4)`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, orgID, id, name, costCenter).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *DepartmentRepository) Update(ctx context.Context, department *entity.Department) error {
+	now := time.Now()
+	query := `UPDATE departments SET name = $1, cost_center = $2, updated_at = $3 WHERE id = $4 AND org_id = $5`
+
+	_, err := r.db.Exec(ctx, query, department.Name, department.CostCenter, now, department.ID, department.OrgID)
+	if err != nil {
+		return err
+	}
+
+	department.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type DepartmentRepositorySuite struct {
+	suite.Suite
+	mock    pgxmock.PgxPoolIface
+	repo    *repository.DepartmentRepository
+	ctx     context.Context
+	now     time.Time
+	columns []string
+}
+
+func (s *DepartmentRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewDepartmentRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.columns = []string{"id", "org_id", "name", "cost_center", "created_at", "updated_at"}
+}
+
+func (s *DepartmentRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *DepartmentRepositorySuite) TestDepartmentRepository_Create() {
+	query := `INSERT INTO departments (org_id, name, cost_center, created_at, updated_at) VALUES ($1, $2, $3, $4, $4) RETURNING id`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), "Finance", "CC-100", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), "Finance", "CC-100", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			department := &entity.Department{OrgID: 2, Name: "Finance", CostCenter: "CC-100"}
+			err := s.repo.Create(s.ctx, department)
+			s.Equal(tt.wantID, department.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentRepositorySuite) TestDepartmentRepository_FindByID() {
+	query := `SELECT id, org_id, name, cost_center, created_at, updated_at FROM departments WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.Department
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(3)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(3)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(3)).
+					WillReturnRows(pgxmock.NewRows(s.columns).AddRow(uint64(3), uint64(2), "Finance", "CC-100", s.now, s.now))
+			},
+			wantRes: &entity.Department{
+				ID:         3,
+				OrgID:      2,
+				Name:       "Finance",
+				CostCenter: "CC-100",
+				CreatedAt:  s.now,
+				UpdatedAt:  s.now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 2, 3)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentRepositorySuite) TestDepartmentRepository_List() {
+	query := `SELECT id, org_id, name, cost_center, created_at, updated_at FROM departments WHERE org_id = $1 ORDER BY name ASC`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.Department
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2)).
+					WillReturnRows(pgxmock.NewRows(s.columns).
+						AddRow(uint64(3), uint64(2), "Finance", "CC-100", s.now, s.now).
+						AddRow(uint64(4), uint64(2), "Sales", "CC-200", s.now, s.now))
+			},
+			wantRes: []entity.Department{
+				{ID: 3, OrgID: 2, Name: "Finance", CostCenter: "CC-100", CreatedAt: s.now, UpdatedAt: s.now},
+				{ID: 4, OrgID: 2, Name: "Sales", CostCenter: "CC-200", CreatedAt: s.now, UpdatedAt: s.now},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.List(s.ctx, 2)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentRepositorySuite) TestDepartmentRepository_CountByNameOrCostCenter() {
+	query := `SELECT COUNT(id) FROM departments WHERE org_id = $1 AND id != $2 AND (name = $3 OR cost_center = $4)`
+
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(0), "Finance", "CC-100").
+					WillReturnError(errors.New("something error"))
+			},
+			wantCount: 0,
+			wantErr:   errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(0), "Finance", "CC-100").
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			wantCount: 1,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			count, err := s.repo.CountByNameOrCostCenter(s.ctx, 2, 0, "Finance", "CC-100")
+			s.Equal(tt.wantCount, count)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DepartmentRepositorySuite) TestDepartmentRepository_Update() {
+	query := `UPDATE departments SET name = $1, cost_center = $2, updated_at = $3 WHERE id = $4 AND org_id = $5`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("Finance", "CC-101", pgxmock.AnyArg(), uint64(3), uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("Finance", "CC-101", pgxmock.AnyArg(), uint64(3), uint64(2)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Update(s.ctx, &entity.Department{ID: 3, OrgID: 2, Name: "Finance", CostCenter: "CC-101"})
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestDepartmentRepositorySuite(t *testing.T) {
+	suite.Run(t, new(DepartmentRepositorySuite))
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const expenseCommentColumns = `c.id, c.org_id, c.expense_id, c.user_id, c.body, c.created_at, c.edited_at, u.id, u.email, u.name`
+
+type ExpenseCommentRepository struct {
+	db db.PgxIface
+}
+
+func NewExpenseCommentRepository(db db.PgxIface) *ExpenseCommentRepository {
+	return &ExpenseCommentRepository{
+		db: db,
+	}
+}
+
+// CreateTx saves the comment with its mentions
+func (r *ExpenseCommentRepository) CreateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment) error {
+	now := time.Now()
+	query := `
+		INSERT INTO expense_comments (org_id, expense_id, user_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	err := exec.QueryRow(ctx, query,
+		comment.OrgID,
+		comment.ExpenseID,
+		comment.UserID,
+		comment.Body,
+		now,
+	).Scan(&comment.ID)
+	if err != nil {
+		return err
+	}
+
+	comment.CreatedAt = now
+
+	return r.createMentionsTx(ctx, exec, comment)
+}
+
+// UpdateTx saves the body of the comment and replaces its mentions, it returns false when the comment
+// is gone, isn't written by the user or is past the edit window
+func (r *ExpenseCommentRepository) UpdateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment,
+	window time.Duration) (bool, error) {
+	now := time.Now()
+	query := `UPDATE expense_comments SET body = $1, edited_at = $2 WHERE org_id = $3 AND id = $4 AND user_id = $5 AND created_at > NOW() - $6 * INTERVAL '1 second'`
+
+	tag, err := exec.Exec(ctx, query, comment.Body, now, comment.OrgID, comment.ID, comment.UserID, int64(window.Seconds()))
+	if err != nil {
+		return false, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	comment.EditedAt = &now
+
+	_, err = exec.Exec(ctx, `DELETE FROM expense_comment_mentions WHERE comment_id = $1`, comment.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.createMentionsTx(ctx, exec, comment)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ExpenseCommentRepository) createMentionsTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint64, len(comment.Mentions))
+	for i, m := range comment.Mentions {
+		userIDs[i] = m.ID
+	}
+
+	query := `INSERT INTO expense_comment_mentions (comment_id, user_id) SELECT $1, unnest($2::BIGINT[])`
+
+	_, err := exec.Exec(ctx, query, comment.ID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the comment of the user within the edit window, its mentions go with it.
+// It returns false when the comment is gone, isn't written by the user or is past the window.
+func (r *ExpenseCommentRepository) Delete(ctx context.Context, orgID uint64, id uint64, userID uint64,
+	window time.Duration) (bool, error) {
+	query := `DELETE FROM expense_comments WHERE org_id = $1 AND id = $2 AND user_id = $3 AND created_at > NOW() - $4 * INTERVAL '1 second'`
+
+	tag, err := r.db.Exec(ctx, query, orgID, id, userID, int64(window.Seconds()))
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListByExpenseID returns the thread of the expense oldest first with the authors and mentions
+func (r *ExpenseCommentRepository) ListByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) ([]entity.ExpenseComment, error) {
+	query := `
+		SELECT ` + expenseCommentColumns + `
+		FROM expense_comments AS c
+		JOIN users AS u ON u.id = c.user_id
+		WHERE c.org_id = $1 AND c.expense_id = $2
+		ORDER BY c.created_at ASC, c.id ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseComment
+	for rows.Next() {
+		c, err := scanExpenseComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *c)
+	}
+	rows.Close()
+
+	err = r.loadMentions(ctx, results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *ExpenseCommentRepository) FindByID(ctx context.Context, orgID uint64, expenseID uint64,
+	id uint64) (*entity.ExpenseComment, error) {
+	query := `
+		SELECT ` + expenseCommentColumns + `
+		FROM expense_comments AS c
+		JOIN users AS u ON u.id = c.user_id
+		WHERE c.org_id = $1 AND c.expense_id = $2 AND c.id = $3
+		LIMIT 1`
+
+	c, err := scanExpenseComment(r.db.QueryRow(ctx, query, orgID, expenseID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	comments := []entity.ExpenseComment{*c}
+	err = r.loadMentions(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comments[0], nil
+}
+
+// loadMentions fills the mentions of the comments with one query
+func (r *ExpenseCommentRepository) loadMentions(ctx context.Context, comments []entity.ExpenseComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(comments))
+	index := make(map[uint64]int, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+		index[c.ID] = i
+	}
+
+	query := `
+		SELECT m.comment_id, u.id, u.email, u.name
+		FROM expense_comment_mentions AS m
+		JOIN users AS u ON u.id = m.user_id
+		WHERE m.comment_id = ANY($1)
+		ORDER BY u.id ASC`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID uint64
+		var u entity.UserSimple
+		err := rows.Scan(&commentID, &u.ID, &u.Email, &u.Name)
+		if err != nil {
+			return err
+		}
+		i := index[commentID]
+		comments[i].Mentions = append(comments[i].Mentions, u)
+	}
+
+	return nil
+}
+
+func scanExpenseComment(row pgx.Row) (*entity.ExpenseComment, error) {
+	var c entity.ExpenseComment
+	err := row.Scan(
+		&c.ID, &c.OrgID, &c.ExpenseID, &c.UserID, &c.Body, &c.CreatedAt, &c.EditedAt,
+		&c.User.ID, &c.User.Email, &c.User.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,389 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+var expenseCommentColumns = []string{"id", "org_id", "expense_id", "user_id", "body", "created_at", "edited_at", "id", "email", "name"}
+
+const (
+	expenseCommentInsertQuery  = `INSERT INTO expense_comments (org_id, expense_id, user_id, body, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	expenseCommentMentionQuery = `INSERT INTO expense_comment_mentions (comment_id, user_id) SELECT $1, unnest($2::BIGINT[])`
+	expenseCommentMentionsLoad = `SELECT m.comment_id, u.id, u.email, u.name FROM expense_comment_mentions AS m JOIN users AS u ON u.id = m.user_id WHERE m.comment_id = ANY($1) ORDER BY u.id ASC`
+)
+
+type ExpenseCommentRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.ExpenseCommentRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *ExpenseCommentRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewExpenseCommentRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *ExpenseCommentRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *ExpenseCommentRepositorySuite) TestExpenseCommentRepository_CreateTx() {
+	tests := []struct {
+		name     string
+		mentions []entity.UserSimple
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error on comment",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentInsertQuery)).
+					WithArgs(uint64(1), uint64(7), uint64(2), "Which client was this for?", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name:     "error on mentions",
+			mentions: []entity.UserSimple{{ID: 3}},
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentInsertQuery)).
+					WithArgs(uint64(1), uint64(7), uint64(2), "Which client was this for?", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+				m.ExpectExec(regexp.QuoteMeta(expenseCommentMentionQuery)).
+					WithArgs(uint64(5), []uint64{3}).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  5,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success without mentions",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentInsertQuery)).
+					WithArgs(uint64(1), uint64(7), uint64(2), "Which client was this for?", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+		{
+			name:     "success with mentions",
+			mentions: []entity.UserSimple{{ID: 3}, {ID: 4}},
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentInsertQuery)).
+					WithArgs(uint64(1), uint64(7), uint64(2), "Which client was this for?", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+				m.ExpectExec(regexp.QuoteMeta(expenseCommentMentionQuery)).
+					WithArgs(uint64(5), []uint64{3, 4}).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			comment := &entity.ExpenseComment{OrgID: 1, ExpenseID: 7, UserID: 2, Body: "Which client was this for?", Mentions: tt.mentions}
+			err := s.repo.CreateTx(s.ctx, s.mock, comment)
+
+			s.Equal(tt.wantID, comment.ID)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ExpenseCommentRepositorySuite) TestExpenseCommentRepository_UpdateTx() {
+	updateQuery := `UPDATE expense_comments SET body = $1, edited_at = $2 WHERE org_id = $3 AND id = $4 AND user_id = $5 AND created_at > NOW() - $6 * INTERVAL '1 second'`
+	deleteQuery := `DELETE FROM expense_comment_mentions WHERE comment_id = $1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "error on update",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs("Fixed typo", pgxmock.AnyArg(), uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "expired or not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs("Fixed typo", pgxmock.AnyArg(), uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "error on delete mentions",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs("Fixed typo", pgxmock.AnyArg(), uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(uint64(5)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs("Fixed typo", pgxmock.AnyArg(), uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(uint64(5)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				m.ExpectExec(regexp.QuoteMeta(expenseCommentMentionQuery)).
+					WithArgs(uint64(5), []uint64{4}).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			comment := &entity.ExpenseComment{ID: 5, OrgID: 1, UserID: 3, Body: "Fixed typo", Mentions: []entity.UserSimple{{ID: 4}}}
+			res, err := s.repo.UpdateTx(s.ctx, s.mock, comment, 15*time.Minute)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+			if err == nil {
+				s.Equal(tt.wantRes, comment.EditedAt != nil)
+			}
+		})
+	}
+}
+
+func (s *ExpenseCommentRepositorySuite) TestExpenseCommentRepository_Delete() {
+	query := `DELETE FROM expense_comments WHERE org_id = $1 AND id = $2 AND user_id = $3 AND created_at > NOW() - $4 * INTERVAL '1 second'`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "expired or not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5), uint64(3), int64(900)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.Delete(s.ctx, 1, 5, 3, 15*time.Minute)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseCommentRepositorySuite) TestExpenseCommentRepository_ListByExpenseID() {
+	query := `SELECT c.id, c.org_id, c.expense_id, c.user_id, c.body, c.created_at, c.edited_at, u.id, u.email, u.name FROM expense_comments AS c JOIN users AS u ON u.id = c.user_id WHERE c.org_id = $1 AND c.expense_id = $2 ORDER BY c.created_at ASC, c.id ASC`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseComment
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "empty",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnRows(pgxmock.NewRows(expenseCommentColumns))
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "error on mentions",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnRows(pgxmock.NewRows(expenseCommentColumns).
+						AddRow(uint64(5), uint64(1), uint64(7), uint64(3), "Which client?", s.now, (*time.Time)(nil), uint64(3), "jane@mail.com", "Jane Roe"))
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentMentionsLoad)).
+					WithArgs([]uint64{5}).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnRows(pgxmock.NewRows(expenseCommentColumns).
+						AddRow(uint64(5), uint64(1), uint64(7), uint64(3), "Which client?", s.now, (*time.Time)(nil), uint64(3), "jane@mail.com", "Jane Roe").
+						AddRow(uint64(6), uint64(1), uint64(7), uint64(2), "PT Maju", s.now, &s.now, uint64(2), "john@mail.com", "John Doe"))
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentMentionsLoad)).
+					WithArgs([]uint64{5, 6}).
+					WillReturnRows(pgxmock.NewRows([]string{"comment_id", "id", "email", "name"}).
+						AddRow(uint64(5), uint64(2), "john@mail.com", "John Doe"))
+			},
+			wantRes: []entity.ExpenseComment{
+				{
+					ID: 5, OrgID: 1, ExpenseID: 7, UserID: 3, Body: "Which client?", CreatedAt: s.now,
+					User:     entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+					Mentions: []entity.UserSimple{{ID: 2, Email: "john@mail.com", Name: "John Doe"}},
+				},
+				{
+					ID: 6, OrgID: 1, ExpenseID: 7, UserID: 2, Body: "PT Maju", CreatedAt: s.now, EditedAt: &s.now,
+					User: entity.UserSimple{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByExpenseID(s.ctx, 1, 7)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseCommentRepositorySuite) TestExpenseCommentRepository_FindByID() {
+	query := `SELECT c.id, c.org_id, c.expense_id, c.user_id, c.body, c.created_at, c.edited_at, u.id, u.email, u.name FROM expense_comments AS c JOIN users AS u ON u.id = c.user_id WHERE c.org_id = $1 AND c.expense_id = $2 AND c.id = $3 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.ExpenseComment
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), uint64(5)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), uint64(5)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), uint64(5)).
+					WillReturnRows(pgxmock.NewRows(expenseCommentColumns).
+						AddRow(uint64(5), uint64(1), uint64(7), uint64(3), "Which client?", s.now, (*time.Time)(nil), uint64(3), "jane@mail.com", "Jane Roe"))
+				m.ExpectQuery(regexp.QuoteMeta(expenseCommentMentionsLoad)).
+					WithArgs([]uint64{5}).
+					WillReturnRows(pgxmock.NewRows([]string{"comment_id", "id", "email", "name"}))
+			},
+			wantRes: &entity.ExpenseComment{
+				ID: 5, OrgID: 1, ExpenseID: 7, UserID: 3, Body: "Which client?", CreatedAt: s.now,
+				User: entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 1, 7, 5)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestExpenseCommentRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ExpenseCommentRepositorySuite))
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ExpenseExportRepository struct {
+	db db.PgxIface
+}
+
+func NewExpenseExportRepository(db db.PgxIface) *ExpenseExportRepository {
+	return &ExpenseExportRepository{
+		db: db,
+	}
+}
+
+func (r *ExpenseExportRepository) Create(ctx context.Context, export *entity.ExpenseExport) error {
+	now := time.Now()
+	query := `
+		INSERT INTO expense_exports (org_id, user_id, format, filters, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	err := r.db.QueryRow(ctx, query,
+		export.OrgID,
+		export.UserID,
+		export.Format,
+		export.Filters,
+		export.Status,
+		now,
+	).Scan(&export.ID)
+	if err != nil {
+		return err
+	}
+
+	export.CreatedAt = now
+
+	return nil
+}
+
+func (r *ExpenseExportRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseExport, error) {
+	query := `
+		SELECT id, org_id, user_id, format, filters, status, row_count, file_key, created_at, completed_at
+		FROM expense_exports WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	var e entity.ExpenseExport
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(
+		&e.ID, &e.OrgID, &e.UserID, &e.Format, &e.Filters, &e.Status, &e.RowCount, &e.FileKey, &e.CreatedAt, &e.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &e, nil
+}
+
+func (r *ExpenseExportRepository) UpdateStatus(ctx context.Context, id uint64, status entity.ExpenseExportStatus) error {
+	query := `UPDATE expense_exports SET status = $1 WHERE id = $2`
+
+	_, err := r.db.Exec(ctx, query, status, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ExpenseExportRepository) Complete(ctx context.Context, id uint64, fileKey string, rowCount int, completedAt time.Time) error {
+	query := `UPDATE expense_exports SET status = 'completed', file_key = $1, row_count = $2, completed_at = $3 WHERE id = $4`
+
+	_, err := r.db.Exec(ctx, query, fileKey, rowCount, completedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
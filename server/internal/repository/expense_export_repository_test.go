@@ -0,0 +1,247 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpenseExportRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.ExpenseExportRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *ExpenseExportRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewExpenseExportRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *ExpenseExportRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *ExpenseExportRepositorySuite) TestExpenseExportRepository_Create() {
+	query := `
+		INSERT INTO expense_exports (org_id, user_id, format, filters, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	filters := []byte(`{"view":"organization"}`)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), entity.ExportFormatXLSX, filters, entity.ExpenseExportStatusPending, pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), entity.ExportFormatXLSX, filters, entity.ExpenseExportStatusPending, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+			},
+			wantID:  5,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			export := &entity.ExpenseExport{
+				OrgID:   1,
+				UserID:  2,
+				Format:  entity.ExportFormatXLSX,
+				Filters: filters,
+				Status:  entity.ExpenseExportStatusPending,
+			}
+			err := s.repo.Create(s.ctx, export)
+
+			s.Equal(tt.wantID, export.ID)
+			s.Equal(tt.wantErr, err)
+			if err == nil {
+				s.False(export.CreatedAt.IsZero())
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportRepositorySuite) TestExpenseExportRepository_FindByID() {
+	query := `
+		SELECT id, org_id, user_id, format, filters, status, row_count, file_key, created_at, completed_at
+		FROM expense_exports WHERE org_id = $1 AND id = $2 LIMIT 1`
+	columns := []string{"id", "org_id", "user_id", "format", "filters", "status", "row_count", "file_key", "created_at",
+		"completed_at"}
+	rowCount := 12000
+	fileKey := "exports/1/5.csv"
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.ExpenseExport
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(5)).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(
+						uint64(5), uint64(1), uint64(2), entity.ExportFormatCSV, []byte(`{}`),
+						entity.ExpenseExportStatusCompleted, &rowCount, &fileKey, s.now, &s.now,
+					))
+			},
+			wantRes: &entity.ExpenseExport{
+				ID:          5,
+				OrgID:       1,
+				UserID:      2,
+				Format:      entity.ExportFormatCSV,
+				Filters:     []byte(`{}`),
+				Status:      entity.ExpenseExportStatusCompleted,
+				RowCount:    &rowCount,
+				FileKey:     &fileKey,
+				CreatedAt:   s.now,
+				CompletedAt: &s.now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 1, 5)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseExportRepositorySuite) TestExpenseExportRepository_UpdateStatus() {
+	query := `UPDATE expense_exports SET status = $1 WHERE id = $2`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.ExpenseExportStatusProcessing, uint64(5)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.ExpenseExportStatusProcessing, uint64(5)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateStatus(s.ctx, 5, entity.ExpenseExportStatusProcessing)
+
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseExportRepositorySuite) TestExpenseExportRepository_Complete() {
+	query := `UPDATE expense_exports SET status = 'completed', file_key = $1, row_count = $2, completed_at = $3 WHERE id = $4`
+	fileKey := "exports/1/5.csv"
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(fileKey, 0, s.now, uint64(5)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(fileKey, 0, s.now, uint64(5)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Complete(s.ctx, 5, fileKey, 0, s.now)
+
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestExpenseExportRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ExpenseExportRepositorySuite))
+}
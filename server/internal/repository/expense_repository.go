@@ -25,20 +25,30 @@ func NewExpenseRepository(db db.PgxIface) *ExpenseRepository {
 }
 
 func (r *ExpenseRepository) Create(ctx context.Context, expense *entity.Expense) error {
+	return r.CreateTx(ctx, r.db, expense)
+}
+
+func (r *ExpenseRepository) CreateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error {
 	now := time.Now()
+	// the department and cost center are copied from the submitter in the same statement
 	query := `
-		INSERT INTO expenses (user_id, amount, description, receipt_url, status, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id`
-
-	err := r.db.QueryRow(ctx, query,
+		INSERT INTO expenses (org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at)
+		SELECT u.org_id, u.id, $3, $4, $5, $6, $7, u.department_id, d.cost_center, $8
+		FROM users AS u
+		LEFT JOIN departments AS d ON u.department_id = d.id
+		WHERE u.org_id = $1 AND u.id = $2
+		RETURNING id, department_id, cost_center`
+
+	err := exec.QueryRow(ctx, query,
+		expense.OrgID,
 		expense.UserID,
 		expense.Amount,
 		expense.Description,
 		expense.ReceiptURL,
 		expense.Status,
+		expense.ApprovalThreshold,
 		now,
-	).Scan(&expense.ID)
+	).Scan(&expense.ID, &expense.DepartmentID, &expense.CostCenter)
 
 	expense.CreatedAt = now
 
@@ -46,54 +56,206 @@ func (r *ExpenseRepository) Create(ctx context.Context, expense *entity.Expense)
 }
 
 func (r *ExpenseRepository) List(ctx context.Context, req *model.ListExpenseRequest) ([]entity.ExpenseWithUser, int, error) {
-	var whereClauses []string
-	whereArgs := make([]any, 0)
-	argCount := 1
+	whereClauses, whereArgs, err := expenseFilters(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	argCount := len(whereArgs) + 1
 
 	baseSelectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description, 
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
 		JOIN users AS u ON e.user_id = u.id`
 
 	baseCountQuery := `SELECT COUNT(*) FROM expenses AS e`
 
-	switch req.View {
-	case model.ExpenseViewPersonal:
-		whereClauses = append(whereClauses, fmt.Sprintf("e.user_id = $%d", argCount))
-		whereArgs = append(whereArgs, req.UserID)
-		argCount++
+	var total int
+	if !req.SkipTotal {
+		countQuery := baseCountQuery + " WHERE " + strings.Join(whereClauses, " AND ")
+		err := r.db.QueryRow(ctx, countQuery, whereArgs...).Scan(&total)
+		if err != nil {
+			return nil, 0, err
+		}
 
-		if req.Status != nil {
-			whereClauses = append(whereClauses, fmt.Sprintf("e.status = $%d", argCount))
-			whereArgs = append(whereArgs, *req.Status)
-			argCount++
+		if total == 0 {
+			return nil, 0, nil
 		}
+	}
 
-		if req.AutoApproved {
-			whereClauses = append(whereClauses, fmt.Sprintf("e.amount < %d", entity.ApprovalThresholdAmount))
+	// the id breaks ties so the order is stable and a cursor always points at one row
+	sortColumn, sortValue := expenseSortColumn(req)
+	direction, comparison := "DESC", "<"
+	if req.SortOrder == model.SortOrderAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	// the cursor only narrows the page, the total still counts every match
+	if req.Cursor != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s, e.id) %s ($%d, $%d)", sortColumn, comparison, argCount, argCount+1))
+		whereArgs = append(whereArgs, sortValue, req.Cursor.ID)
+		argCount += 2
+	}
+
+	selectQuery := baseSelectQuery + " WHERE " + strings.Join(whereClauses, " AND ")
+	selectQuery += fmt.Sprintf(" ORDER BY %s %s, e.id %s LIMIT $%d", sortColumn, direction, direction, argCount)
+	selectArgs := append(whereArgs, req.Limit)
+	if req.Cursor == nil {
+		selectQuery += fmt.Sprintf(" OFFSET $%d", argCount+1)
+		selectArgs = append(selectArgs, req.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, selectQuery, selectArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseWithUser
+	for rows.Next() {
+		var eu entity.ExpenseWithUser
+		err := rows.Scan(
+			&eu.Expense.ID, &eu.Expense.UserID, &eu.Expense.Amount, &eu.Expense.Description,
+			&eu.Expense.ReceiptURL, &eu.Expense.Status, &eu.Expense.CreatedAt, &eu.Expense.ProcessedAt,
+			&eu.Expense.DepartmentID, &eu.Expense.CostCenter,
+			&eu.Expense.OrgID, &eu.Expense.ApprovalThreshold,
+			&eu.User.ID, &eu.User.Email, &eu.User.Name,
+		)
+		if err != nil {
+			return nil, 0, err
 		}
+		results = append(results, eu)
+	}
 
-	case model.ExpenseViewApprovalQueue:
-		whereClauses = append(whereClauses, "e.status = 'awaiting_approval'")
-		whereClauses = append(whereClauses, fmt.Sprintf("e.user_id != $%d", argCount))
-		whereArgs = append(whereArgs, req.UserID)
-		argCount++
+	return results, total, nil
+}
 
-	default:
-		return nil, 0, errors.New("invalid view")
+func (r *ExpenseRepository) Count(ctx context.Context, req *model.ListExpenseRequest) (int, error) {
+	whereClauses, whereArgs, err := expenseFilters(req)
+	if err != nil {
+		return 0, err
 	}
 
-	whereQuery := ""
-	if len(whereClauses) > 0 {
-		whereQuery = " WHERE " + strings.Join(whereClauses, " AND ")
+	query := `SELECT COUNT(*) FROM expenses AS e WHERE ` + strings.Join(whereClauses, " AND ")
+
+	var total int
+	err = r.db.QueryRow(ctx, query, whereArgs...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// Stream calls fn for every expense of the list with its approval, in the order of the list and without
+// pagination. Rows are read one at a time so an export of a long period doesn't load them all at once.
+func (r *ExpenseRepository) Stream(ctx context.Context, req *model.ListExpenseRequest, fn func(detail *entity.ExpenseDetail) error) error {
+	whereClauses, whereArgs, err := expenseFilters(req)
+	if err != nil {
+		return err
+	}
+
+	sortColumn, _ := expenseSortColumn(req)
+	direction := "DESC"
+	if req.SortOrder == model.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	query := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold, e.version AS expense_version,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name,
+			a.id AS approval_id, a.approver_id, ua.email AS approver_email, ua.name AS approver_name,
+			a.status AS approval_status, a.notes AS approval_notes, a.created_at AS approval_created_at
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		LEFT JOIN approvals AS a ON e.id = a.expense_id
+		LEFT JOIN users AS ua ON a.approver_id = ua.id
+		WHERE ` + strings.Join(whereClauses, " AND ") +
+		fmt.Sprintf(" ORDER BY %s %s, e.id %s", sortColumn, direction, direction)
+
+	rows, err := r.db.Query(ctx, query, whereArgs...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var detail entity.ExpenseDetail
+
+		var approvalID, approvalApproverID sql.NullInt64
+		var approvalStatus sql.NullString
+		var approvalApproverEmail, approvalApproverName, approvalNotes sql.NullString
+		var approvalCreatedAt sql.NullTime
+
+		err := rows.Scan(
+			&detail.Expense.ID, &detail.Expense.UserID, &detail.Expense.Amount, &detail.Expense.Description,
+			&detail.Expense.ReceiptURL, &detail.Expense.Status, &detail.Expense.CreatedAt, &detail.Expense.ProcessedAt,
+			&detail.Expense.DepartmentID, &detail.Expense.CostCenter,
+			&detail.Expense.OrgID, &detail.Expense.ApprovalThreshold, &detail.Expense.Version,
+			&detail.User.ID, &detail.User.Email, &detail.User.Name,
+			&approvalID, &approvalApproverID, &approvalApproverEmail, &approvalApproverName, &approvalStatus, &approvalNotes, &approvalCreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		if approvalID.Valid {
+			detail.Approval = &entity.ApprovalDetail{
+				ID:            uint64(approvalID.Int64),
+				ApproverID:    uint64(approvalApproverID.Int64),
+				ApproverEmail: approvalApproverEmail.String,
+				ApproverName:  approvalApproverName.String,
+				Status:        entity.ApprovalStatus(approvalStatus.String),
+				Notes:         nullableStringPtr(approvalNotes),
+				CreatedAt:     approvalCreatedAt.Time,
+			}
+		}
+
+		err = fn(&detail)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Search ranks the expenses whose description, approval notes or submitter name match the query.
+// Words are matched with full-text search and misspellings with trigram word similarity.
+func (r *ExpenseRepository) Search(ctx context.Context, req *model.SearchExpenseRequest) ([]entity.ExpenseSearchResult, int, error) {
+	// 'simple' doesn't stem, descriptions mix Indonesian and English
+	withQuery := `WITH q AS (SELECT websearch_to_tsquery('simple', $2) AS query, $2::text AS raw)`
+	fromQuery := `
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		LEFT JOIN approvals AS a ON e.id = a.expense_id
+		CROSS JOIN q
+		WHERE e.org_id = $1
+		AND (
+			to_tsvector('simple', e.description) @@ q.query
+			OR to_tsvector('simple', COALESCE(a.notes, '')) @@ q.query
+			OR to_tsvector('simple', u.name) @@ q.query
+			OR q.raw <% e.description
+			OR q.raw <% a.notes
+			OR q.raw <% u.name
+		)`
+	args := []any{req.OrgID, req.Query}
+
+	if req.OwnOnly {
+		fromQuery += " AND e.user_id = $3"
+		args = append(args, req.UserID)
 	}
 
 	var total int
-	countQuery := baseCountQuery + whereQuery
-	err := r.db.QueryRow(ctx, countQuery, whereArgs...).Scan(&total)
+	err := r.db.QueryRow(ctx, withQuery+" SELECT COUNT(*) "+fromQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -102,38 +264,61 @@ func (r *ExpenseRepository) List(ctx context.Context, req *model.ListExpenseRequ
 		return nil, 0, nil
 	}
 
-	selectQuery := baseSelectQuery + whereQuery
-	selectQuery += fmt.Sprintf(" ORDER BY e.id DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
-	selectArgs := append(whereArgs, req.Limit, req.Offset)
-
-	rows, err := r.db.Query(ctx, selectQuery, selectArgs...)
+	// the description weighs more than the notes and the name, similarity lifts misspelled matches
+	selectQuery := withQuery + `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name,
+			ts_rank(
+				setweight(to_tsvector('simple', e.description), 'A') ||
+				setweight(to_tsvector('simple', COALESCE(a.notes, '')), 'B') ||
+				setweight(to_tsvector('simple', u.name), 'C'),
+				q.query
+			) + word_similarity(q.raw, e.description) AS rank,
+			ts_headline('simple', e.description, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS description_highlight,
+			CASE WHEN to_tsvector('simple', COALESCE(a.notes, '')) @@ q.query
+				THEN ts_headline('simple', a.notes, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') END AS approval_notes_highlight,
+			CASE WHEN to_tsvector('simple', u.name) @@ q.query
+				THEN ts_headline('simple', u.name, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') END AS user_name_highlight` + fromQuery
+	selectQuery += fmt.Sprintf(" ORDER BY rank DESC, e.created_at DESC, e.id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := r.db.Query(ctx, selectQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var results []entity.ExpenseWithUser
+	var results []entity.ExpenseSearchResult
 	for rows.Next() {
-		var eu entity.ExpenseWithUser
+		var sr entity.ExpenseSearchResult
 		err := rows.Scan(
-			&eu.Expense.ID, &eu.Expense.UserID, &eu.Expense.Amount, &eu.Expense.Description,
-			&eu.Expense.ReceiptURL, &eu.Expense.Status, &eu.Expense.CreatedAt, &eu.Expense.ProcessedAt,
-			&eu.User.ID, &eu.User.Email, &eu.User.Name,
+			&sr.Expense.ID, &sr.Expense.UserID, &sr.Expense.Amount, &sr.Expense.Description,
+			&sr.Expense.ReceiptURL, &sr.Expense.Status, &sr.Expense.CreatedAt, &sr.Expense.ProcessedAt,
+			&sr.Expense.DepartmentID, &sr.Expense.CostCenter,
+			&sr.Expense.OrgID, &sr.Expense.ApprovalThreshold,
+			&sr.User.ID, &sr.User.Email, &sr.User.Name,
+			&sr.Rank, &sr.DescriptionHighlight, &sr.ApprovalNotesHighlight, &sr.UserNameHighlight,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
-		results = append(results, eu)
+		results = append(results, sr)
 	}
 
 	return results, total, nil
 }
 
-func (r *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*entity.ExpenseDetail, error) {
+func (r *ExpenseRepository) FindDetailByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseDetail, error) {
 	query := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description, 
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold, e.version AS expense_version,
 			ue.id AS user_id, ue.email AS user_email, ue.name AS user_name,
 			a.id AS approval_id, a.approver_id, ua.email AS approver_email, ua.name AS approver_name,
 			a.status AS approval_status, a.notes AS approval_notes, a.created_at AS approval_created_at
@@ -141,7 +326,7 @@ func (r *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*ent
 		JOIN users AS ue ON e.user_id = ue.id
 		LEFT JOIN approvals AS a ON e.id = a.expense_id
 		LEFT JOIN users AS ua ON a.approver_id = ua.id
-		WHERE e.id = $1`
+		WHERE e.org_id = $1 AND e.id = $2`
 
 	var detail entity.ExpenseDetail
 
@@ -150,9 +335,11 @@ func (r *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*ent
 	var approvalApproverEmail, approvalApproverName, approvalNotes sql.NullString
 	var approvalCreatedAt sql.NullTime
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(
 		&detail.Expense.ID, &detail.Expense.UserID, &detail.Expense.Amount, &detail.Expense.Description,
 		&detail.Expense.ReceiptURL, &detail.Expense.Status, &detail.Expense.CreatedAt, &detail.Expense.ProcessedAt,
+		&detail.Expense.DepartmentID, &detail.Expense.CostCenter,
+		&detail.Expense.OrgID, &detail.Expense.ApprovalThreshold, &detail.Expense.Version,
 		&detail.User.ID, &detail.User.Email, &detail.User.Name,
 		&approvalID, &approvalApproverID, &approvalApproverEmail, &approvalApproverName, &approvalStatus, &approvalNotes, &approvalCreatedAt,
 	)
@@ -179,11 +366,12 @@ func (r *ExpenseRepository) FindDetailByID(ctx context.Context, id uint64) (*ent
 	return &detail, nil
 }
 
-func (r *ExpenseRepository) FindByID(ctx context.Context, id uint64) (*entity.Expense, error) {
-	query := `SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 LIMIT 1`
+func (r *ExpenseRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Expense, error) {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 LIMIT 1`
 
 	var e entity.Expense
-	err := r.db.QueryRow(ctx, query, id).Scan(&e.ID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.CreatedAt, &e.ProcessedAt)
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(&e.ID, &e.OrgID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.ApprovalThreshold,
+		&e.DepartmentID, &e.CostCenter, &e.CreatedAt, &e.ProcessedAt, &e.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -195,11 +383,12 @@ func (r *ExpenseRepository) FindByID(ctx context.Context, id uint64) (*entity.Ex
 	return &e, nil
 }
 
-func (r *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Executor, id uint64) (*entity.Expense, error) {
-	query := `SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 FOR UPDATE`
+func (r *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Executor, orgID uint64, id uint64) (*entity.Expense, error) {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 FOR UPDATE`
 
 	var e entity.Expense
-	err := exec.QueryRow(ctx, query, id).Scan(&e.ID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.CreatedAt, &e.ProcessedAt)
+	err := exec.QueryRow(ctx, query, orgID, id).Scan(&e.ID, &e.OrgID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.ApprovalThreshold,
+		&e.DepartmentID, &e.CostCenter, &e.CreatedAt, &e.ProcessedAt, &e.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -211,10 +400,41 @@ func (r *ExpenseRepository) FindByIDWithLock(ctx context.Context, exec db.Execut
 	return &e, nil
 }
 
-func (r *ExpenseRepository) UpdateStatusByIDTx(ctx context.Context, exec db.Executor, id uint64, status entity.ExpenseStatus) error {
-	query := `UPDATE expenses SET status = $1 WHERE id = $2`
+func (r *ExpenseRepository) UpdateStatusByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64,
+	status entity.ExpenseStatus) error {
+	// every change bumps the version, it's what the ETag of the expense is made of
+	query := `UPDATE expenses SET status = $1, version = version + 1 WHERE org_id = $2 AND id = $3`
+
+	_, err := exec.Exec(ctx, query, status, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateTx saves the fields the submitter can edit with the new status, the version is read back for the ETag
+func (r *ExpenseRepository) UpdateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error {
+	query := `
+		UPDATE expenses SET amount = $1, description = $2, receipt_url = $3, status = $4, version = version + 1
+		WHERE org_id = $5 AND id = $6
+		RETURNING version`
+
+	return exec.QueryRow(ctx, query,
+		expense.Amount,
+		expense.Description,
+		expense.ReceiptURL,
+		expense.Status,
+		expense.OrgID,
+		expense.ID,
+	).Scan(&expense.Version)
+}
 
-	_, err := exec.Exec(ctx, query, status, id)
+func (r *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64,
+	processedAt time.Time) error {
+	query := `UPDATE expenses SET status = 'completed', processed_at = $1, version = version + 1 WHERE org_id = $2 AND id = $3`
+
+	_, err := exec.Exec(ctx, query, processedAt, orgID, id)
 	if err != nil {
 		return err
 	}
@@ -222,10 +442,37 @@ func (r *ExpenseRepository) UpdateStatusByIDTx(ctx context.Context, exec db.Exec
 	return nil
 }
 
-func (r *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executor, id uint64, processedAt time.Time) error {
-	query := `UPDATE expenses SET status = 'completed', processed_at = $1 WHERE id = $2`
+// ListUnexportedWithLock locks the completed expenses paid within [from, to) that aren't in a journal batch yet,
+// a concurrent batch of the same period waits and then no longer sees them
+func (r *ExpenseRepository) ListUnexportedWithLock(ctx context.Context, exec db.Executor, orgID uint64, from time.Time,
+	to time.Time) ([]entity.Expense, error) {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND status = 'completed' AND journal_batch_id IS NULL AND processed_at >= $2 AND processed_at < $3 ORDER BY processed_at ASC, id ASC FOR UPDATE`
+
+	rows, err := exec.Query(ctx, query, orgID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.Expense
+	for rows.Next() {
+		var e entity.Expense
+		err := rows.Scan(&e.ID, &e.OrgID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.ApprovalThreshold,
+			&e.DepartmentID, &e.CostCenter, &e.CreatedAt, &e.ProcessedAt, &e.Version)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, e)
+	}
+
+	return results, nil
+}
+
+func (r *ExpenseRepository) SetJournalBatchIDTx(ctx context.Context, exec db.Executor, orgID uint64, ids []uint64,
+	batchID uint64) error {
+	query := `UPDATE expenses SET journal_batch_id = $1 WHERE org_id = $2 AND id = ANY($3)`
 
-	_, err := exec.Exec(ctx, query, processedAt, id)
+	_, err := exec.Exec(ctx, query, batchID, orgID, ids)
 	if err != nil {
 		return err
 	}
@@ -233,6 +480,180 @@ func (r *ExpenseRepository) CompleteByIDTx(ctx context.Context, exec db.Executor
 	return nil
 }
 
+// SumByUserStatus totals every expense of the user in the organization per status, rejected expenses are left out
+func (r *ExpenseRepository) SumByUserStatus(ctx context.Context, orgID uint64, userID uint64) ([]entity.ExpenseStatusTotal, error) {
+	query := `
+		SELECT status, COUNT(*), COALESCE(SUM(amount), 0)::BIGINT
+		FROM expenses
+		WHERE org_id = $1 AND user_id = $2 AND status <> 'rejected'
+		GROUP BY status`
+
+	rows, err := r.db.Query(ctx, query, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseStatusTotal
+	for rows.Next() {
+		var t entity.ExpenseStatusTotal
+		err := rows.Scan(&t.Status, &t.Count, &t.Amount)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+
+	return results, nil
+}
+
+// SumByUserMonth totals the expenses the user submitted in the organization within [from, to) per UTC month
+// and status, rejected expenses are left out
+func (r *ExpenseRepository) SumByUserMonth(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.ExpenseStatusTotal, error) {
+	query := `
+		SELECT to_char(date_trunc('month', created_at AT TIME ZONE 'UTC'), 'YYYY-MM') AS month, status, COUNT(*), COALESCE(SUM(amount), 0)::BIGINT
+		FROM expenses
+		WHERE org_id = $1 AND user_id = $2 AND status <> 'rejected' AND created_at >= $3 AND created_at < $4
+		GROUP BY 1, 2
+		ORDER BY 1 ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseStatusTotal
+	for rows.Next() {
+		var t entity.ExpenseStatusTotal
+		err := rows.Scan(&t.Month, &t.Status, &t.Count, &t.Amount)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+
+	return results, nil
+}
+
+// ListByUserCreated returns the expenses the user submitted in the organization within [from, to), oldest first
+func (r *ExpenseRepository) ListByUserCreated(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.Expense, error) {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND user_id = $2 AND created_at >= $3 AND created_at < $4 ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.Expense
+	for rows.Next() {
+		var e entity.Expense
+		err := rows.Scan(&e.ID, &e.OrgID, &e.UserID, &e.Amount, &e.Description, &e.ReceiptURL, &e.Status, &e.ApprovalThreshold,
+			&e.DepartmentID, &e.CostCenter, &e.CreatedAt, &e.ProcessedAt, &e.Version)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, e)
+	}
+
+	return results, nil
+}
+
+// expenseFilters returns the conditions of the view and filters of the list, every view is scoped
+// to the organization of the caller. The args are numbered from $1 in the order of the conditions.
+func expenseFilters(req *model.ListExpenseRequest) ([]string, []any, error) {
+	whereClauses := []string{"e.org_id = $1"}
+	whereArgs := []any{req.OrgID}
+	argCount := 2
+
+	addFilter := func(clause string, value any) {
+		whereClauses = append(whereClauses, fmt.Sprintf(clause, argCount))
+		whereArgs = append(whereArgs, value)
+		argCount++
+	}
+
+	switch req.View {
+	case model.ExpenseViewPersonal:
+		addFilter("e.user_id = $%d", req.UserID)
+
+		if len(req.Statuses) > 0 {
+			// sent as text[] because pgx doesn't know the oid of the enum array
+			addFilter("e.status = ANY($%d::text[]::expense_status[])", req.Statuses)
+		}
+
+		if req.AutoApproved {
+			whereClauses = append(whereClauses, "e.amount < e.approval_threshold")
+		}
+
+	case model.ExpenseViewApprovalQueue:
+		whereClauses = append(whereClauses, "e.status = 'awaiting_approval'")
+		addFilter("e.user_id != $%d", req.UserID)
+
+		if len(req.UserIDs) > 0 {
+			addFilter("e.user_id = ANY($%d)", req.UserIDs)
+		}
+
+	case model.ExpenseViewOrganization:
+		if len(req.UserIDs) > 0 {
+			addFilter("e.user_id = ANY($%d)", req.UserIDs)
+		}
+
+		if len(req.Statuses) > 0 {
+			addFilter("e.status = ANY($%d::text[]::expense_status[])", req.Statuses)
+		}
+
+		if req.AutoApproved {
+			whereClauses = append(whereClauses, "e.amount < e.approval_threshold")
+		}
+
+	default:
+		return nil, nil, errors.New("invalid view")
+	}
+
+	if req.CreatedFrom != nil {
+		addFilter("e.created_at >= $%d", *req.CreatedFrom)
+	}
+	if req.CreatedTo != nil {
+		addFilter("e.created_at <= $%d", *req.CreatedTo)
+	}
+	if req.ProcessedFrom != nil {
+		addFilter("e.processed_at >= $%d", *req.ProcessedFrom)
+	}
+	if req.ProcessedTo != nil {
+		addFilter("e.processed_at <= $%d", *req.ProcessedTo)
+	}
+	if req.MinAmountIDR != nil {
+		addFilter("e.amount >= $%d", *req.MinAmountIDR)
+	}
+	if req.MaxAmountIDR != nil {
+		addFilter("e.amount <= $%d", *req.MaxAmountIDR)
+	}
+
+	return whereClauses, whereArgs, nil
+}
+
+// expenseSortColumn returns the column to order by and the value of the cursor for it
+func expenseSortColumn(req *model.ListExpenseRequest) (string, any) {
+	switch req.SortBy {
+	case model.ExpenseSortByAmount:
+		if req.Cursor != nil && req.Cursor.AmountIDR != nil {
+			return "e.amount", *req.Cursor.AmountIDR
+		}
+		return "e.amount", nil
+	case model.ExpenseSortByStatus:
+		if req.Cursor != nil && req.Cursor.Status != nil {
+			return "e.status", *req.Cursor.Status
+		}
+		return "e.status", nil
+	default:
+		if req.Cursor != nil && req.Cursor.CreatedAt != nil {
+			return "e.created_at", *req.Cursor.CreatedAt
+		}
+		return "e.created_at", nil
+	}
+}
+
 func nullableStringPtr(ns sql.NullString) *string {
 	if ns.Valid {
 		return &ns.String
@@ -37,6 +37,8 @@ func (s *ExpenseRepositorySuite) TearDownTest() {
 func (s *ExpenseRepositorySuite) TestExpenseRepository_Create() {
 	description := "dummy description"
 	receiptUrl := "https://example.com/receipt.jpg"
+	departmentID := uint64(3)
+	costCenter := "CC-100"
 
 	tests := []struct {
 		name     string
@@ -48,17 +50,19 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_Create() {
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO expenses (user_id, amount, description, receipt_url, status, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					`INSERT INTO expenses (org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at) SELECT u.org_id, u.id, $3, $4, $5, $6, $7, u.department_id, d.cost_center, $8 FROM users AS u LEFT JOIN departments AS d ON u.department_id = d.id WHERE u.org_id = $1 AND u.id = $2 RETURNING id, department_id, cost_center`,
 				)).
-					WithArgs(uint64(1), uint64(15000), description, &receiptUrl, pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WithArgs(uint64(1), uint64(1), uint64(15000), description, &receiptUrl, pgxmock.AnyArg(), uint64(1000000), pgxmock.AnyArg()).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &entity.Expense{
-				UserID:      uint64(1),
-				Amount:      uint64(15000),
-				Description: description,
-				ReceiptURL:  &receiptUrl,
-				Status:      entity.ExpenseStatusApproved,
+				OrgID:             uint64(1),
+				UserID:            uint64(1),
+				Amount:            uint64(15000),
+				Description:       description,
+				ReceiptURL:        &receiptUrl,
+				Status:            entity.ExpenseStatusApproved,
+				ApprovalThreshold: uint64(1000000),
 			},
 			wantErr: errors.New("something error"),
 		},
@@ -66,17 +70,20 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_Create() {
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO expenses (user_id, amount, description, receipt_url, status, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					`INSERT INTO expenses (org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at) SELECT u.org_id, u.id, $3, $4, $5, $6, $7, u.department_id, d.cost_center, $8 FROM users AS u LEFT JOIN departments AS d ON u.department_id = d.id WHERE u.org_id = $1 AND u.id = $2 RETURNING id, department_id, cost_center`,
 				)).
-					WithArgs(uint64(1), uint64(15000), description, &receiptUrl, pgxmock.AnyArg(), pgxmock.AnyArg()).
-					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+					WithArgs(uint64(1), uint64(1), uint64(15000), description, &receiptUrl, pgxmock.AnyArg(), uint64(1000000), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "department_id", "cost_center"}).
+						AddRow(uint64(1), &departmentID, &costCenter))
 			},
 			param: &entity.Expense{
-				UserID:      uint64(1),
-				Amount:      uint64(15000),
-				Description: description,
-				ReceiptURL:  &receiptUrl,
-				Status:      entity.ExpenseStatusApproved,
+				OrgID:             uint64(1),
+				UserID:            uint64(1),
+				Amount:            uint64(15000),
+				Description:       description,
+				ReceiptURL:        &receiptUrl,
+				Status:            entity.ExpenseStatusApproved,
+				ApprovalThreshold: uint64(1000000),
 			},
 			wantErr: nil,
 		},
@@ -96,6 +103,10 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 	description := "dummy description"
 	status := "approved"
+	cursorAmount := uint64(15000)
+	createdFrom := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2025, 8, 31, 23, 59, 59, 0, time.UTC)
+	minAmount, maxAmount := uint64(10000), uint64(500000)
 
 	tests := []struct {
 		name      string
@@ -108,12 +119,13 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "not found",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewPersonal,
@@ -127,12 +139,13 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "count error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewPersonal,
@@ -146,23 +159,26 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "select error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
 				selectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
-		JOIN users AS u ON e.user_id = u.id WHERE e.user_id = $1 ORDER BY e.id DESC LIMIT $2 OFFSET $3`
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.user_id = $2 ORDER BY e.created_at DESC, e.id DESC LIMIT $3 OFFSET $4`
 
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
 				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
-					WithArgs(uint64(1), 10, 0).
+					WithArgs(uint64(1), uint64(1), 10, 0).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewPersonal,
@@ -173,35 +189,82 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 			wantTotal: 0,
 			wantErr:   errors.New("something error"),
 		},
+		{
+			name: "personal scoped to another organization",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(2), uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:    uint64(2),
+				UserID:   uint64(1),
+				UserRole: "manager",
+				View:     model.ExpenseViewPersonal,
+				Limit:    10,
+				Offset:   0,
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   nil,
+		},
+		{
+			name: "approval_queue scoped to another organization",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2`
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(2), uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:    uint64(2),
+				UserID:   uint64(1),
+				UserRole: "manager",
+				View:     model.ExpenseViewApprovalQueue,
+				Limit:    10,
+				Offset:   0,
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   nil,
+		},
 		{
 			name: "success personal with params user_id",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
 				selectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
-		JOIN users AS u ON e.user_id = u.id WHERE e.user_id = $1 ORDER BY e.id DESC LIMIT $2 OFFSET $3`
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.user_id = $2 ORDER BY e.created_at DESC, e.id DESC LIMIT $3 OFFSET $4`
 
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
 				rows := pgxmock.NewRows([]string{
 					"expense_id", "expense_user_id", "expense_amount", "expense_description",
 					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold",
 					"user_id", "user_email", "user_name",
 				}).AddRow(
 					uint64(1), uint64(1), uint64(15000), description,
 					nil, entity.ExpenseStatusApproved, now, nil,
+					nil, nil,
+					uint64(1), uint64(1000000),
 					uint64(1), "john@mail.com", "John Doe",
 				)
 				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
-					WithArgs(uint64(1), 10, 0).
+					WithArgs(uint64(1), uint64(1), 10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewPersonal,
@@ -211,14 +274,16 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 			wantRes: []entity.ExpenseWithUser{
 				{
 					Expense: entity.Expense{
-						ID:          uint64(1),
-						UserID:      uint64(1),
-						Amount:      uint64(15000),
-						Description: description,
-						ReceiptURL:  nil,
-						Status:      entity.ExpenseStatusApproved,
-						CreatedAt:   now,
-						ProcessedAt: nil,
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(1),
+						Amount:            uint64(15000),
+						Description:       description,
+						ReceiptURL:        nil,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       nil,
 					},
 					User: entity.UserSimple{
 						ID:    1,
@@ -233,50 +298,59 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "success personal with params user_id and status",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1 AND e.status = $2`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2 AND e.status = ANY($3::text[]::expense_status[])`
 				selectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
-		JOIN users AS u ON e.user_id = u.id WHERE e.user_id = $1 AND e.status = $2 ORDER BY e.id DESC LIMIT $3 OFFSET $4`
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.user_id = $2 AND e.status = ANY($3::text[]::expense_status[]) ORDER BY e.created_at DESC, e.id DESC LIMIT $4 OFFSET $5`
 
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1), "approved").
+					WithArgs(uint64(1), uint64(1), []string{"approved"}).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
 				rows := pgxmock.NewRows([]string{
 					"expense_id", "expense_user_id", "expense_amount", "expense_description",
 					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold",
 					"user_id", "user_email", "user_name",
 				}).AddRow(
 					uint64(1), uint64(1), uint64(15000), description,
 					nil, entity.ExpenseStatusApproved, now, nil,
+					nil, nil,
+					uint64(1), uint64(1000000),
 					uint64(1), "john@mail.com", "John Doe",
 				)
 				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
-					WithArgs(uint64(1), "approved", 10, 0).
+					WithArgs(uint64(1), uint64(1), []string{"approved"}, 10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewPersonal,
-				Status:   &status,
+				Statuses: []string{status},
 				Limit:    10,
 				Offset:   0,
 			},
 			wantRes: []entity.ExpenseWithUser{
 				{
 					Expense: entity.Expense{
-						ID:          uint64(1),
-						UserID:      uint64(1),
-						Amount:      uint64(15000),
-						Description: description,
-						ReceiptURL:  nil,
-						Status:      entity.ExpenseStatusApproved,
-						CreatedAt:   now,
-						ProcessedAt: nil,
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(1),
+						Amount:            uint64(15000),
+						Description:       description,
+						ReceiptURL:        nil,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       nil,
 					},
 					User: entity.UserSimple{
 						ID:    1,
@@ -291,36 +365,43 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "success personal with params user_id and status and auto_approved",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.user_id = $1 AND e.status = $2 AND e.amount < 1000000`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2 AND e.status = ANY($3::text[]::expense_status[]) AND e.amount < e.approval_threshold`
 				selectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
-		JOIN users AS u ON e.user_id = u.id WHERE e.user_id = $1 AND e.status = $2 AND e.amount < 1000000 ORDER BY e.id DESC LIMIT $3 OFFSET $4`
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.user_id = $2 AND e.status = ANY($3::text[]::expense_status[]) AND e.amount < e.approval_threshold ORDER BY e.created_at DESC, e.id DESC LIMIT $4 OFFSET $5`
 
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1), "approved").
+					WithArgs(uint64(1), uint64(1), []string{"approved"}).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
 				rows := pgxmock.NewRows([]string{
 					"expense_id", "expense_user_id", "expense_amount", "expense_description",
 					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold",
 					"user_id", "user_email", "user_name",
 				}).AddRow(
 					uint64(1), uint64(1), uint64(15000), description,
 					nil, entity.ExpenseStatusApproved, now, nil,
+					nil, nil,
+					uint64(1), uint64(1000000),
 					uint64(1), "john@mail.com", "John Doe",
 				)
 				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
-					WithArgs(uint64(1), "approved", 10, 0).
+					WithArgs(uint64(1), uint64(1), []string{"approved"}, 10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:        uint64(1),
 				UserID:       uint64(1),
 				UserRole:     "manager",
 				View:         model.ExpenseViewPersonal,
-				Status:       &status,
+				Statuses:     []string{status},
 				AutoApproved: true,
 				Limit:        10,
 				Offset:       0,
@@ -328,14 +409,16 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 			wantRes: []entity.ExpenseWithUser{
 				{
 					Expense: entity.Expense{
-						ID:          uint64(1),
-						UserID:      uint64(1),
-						Amount:      uint64(15000),
-						Description: description,
-						ReceiptURL:  nil,
-						Status:      entity.ExpenseStatusApproved,
-						CreatedAt:   now,
-						ProcessedAt: nil,
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(1),
+						Amount:            uint64(15000),
+						Description:       description,
+						ReceiptURL:        nil,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       nil,
 					},
 					User: entity.UserSimple{
 						ID:    1,
@@ -350,32 +433,39 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 		{
 			name: "success approval_queue",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.status = 'awaiting_approval' AND e.user_id != $1`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2`
 				selectQuery := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
 			u.id AS user_id, u.email AS user_email, u.name AS user_name
 		FROM expenses AS e
-		JOIN users AS u ON e.user_id = u.id WHERE e.status = 'awaiting_approval' AND e.user_id != $1 ORDER BY e.id DESC LIMIT $2 OFFSET $3`
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2 ORDER BY e.created_at DESC, e.id DESC LIMIT $3 OFFSET $4`
 
 				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
 				rows := pgxmock.NewRows([]string{
 					"expense_id", "expense_user_id", "expense_amount", "expense_description",
 					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold",
 					"user_id", "user_email", "user_name",
 				}).AddRow(
 					uint64(1), uint64(1), uint64(15000), description,
 					nil, entity.ExpenseStatusApproved, now, nil,
+					nil, nil,
+					uint64(1), uint64(1000000),
 					uint64(1), "john@mail.com", "John Doe",
 				)
 				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
-					WithArgs(uint64(1), 10, 0).
+					WithArgs(uint64(1), uint64(1), 10, 0).
 					WillReturnRows(rows)
 			},
 			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
 				UserID:   uint64(1),
 				UserRole: "manager",
 				View:     model.ExpenseViewApprovalQueue,
@@ -385,14 +475,16 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 			wantRes: []entity.ExpenseWithUser{
 				{
 					Expense: entity.Expense{
-						ID:          uint64(1),
-						UserID:      uint64(1),
-						Amount:      uint64(15000),
-						Description: description,
-						ReceiptURL:  nil,
-						Status:      entity.ExpenseStatusApproved,
-						CreatedAt:   now,
-						ProcessedAt: nil,
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(1),
+						Amount:            uint64(15000),
+						Description:       description,
+						ReceiptURL:        nil,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       nil,
 					},
 					User: entity.UserSimple{
 						ID:    1,
@@ -404,6 +496,150 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 			wantTotal: 1,
 			wantErr:   nil,
 		},
+		{
+			name: "success personal with amount cursor skips count and offset",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				selectQuery := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.user_id = $2 AND (e.amount, e.id) > ($3, $4) ORDER BY e.amount ASC, e.id ASC LIMIT $5`
+
+				rows := pgxmock.NewRows([]string{
+					"expense_id", "expense_user_id", "expense_amount", "expense_description",
+					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold",
+					"user_id", "user_email", "user_name",
+				}).AddRow(
+					uint64(4), uint64(1), uint64(15000), description,
+					nil, entity.ExpenseStatusApproved, now, nil,
+					nil, nil,
+					uint64(1), uint64(1000000),
+					uint64(1), "john@mail.com", "John Doe",
+				)
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(uint64(1), uint64(1), uint64(15000), uint64(5), 10).
+					WillReturnRows(rows)
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:     uint64(1),
+				UserID:    uint64(1),
+				UserRole:  "manager",
+				View:      model.ExpenseViewPersonal,
+				Limit:     10,
+				Offset:    20,
+				SortBy:    model.ExpenseSortByAmount,
+				SortOrder: model.SortOrderAsc,
+				Cursor: &model.ExpenseCursor{
+					ID:        5,
+					SortBy:    model.ExpenseSortByAmount,
+					SortOrder: model.SortOrderAsc,
+					AmountIDR: &cursorAmount,
+				},
+				SkipTotal: true,
+			},
+			wantRes: []entity.ExpenseWithUser{
+				{
+					Expense: entity.Expense{
+						ID:                uint64(4),
+						OrgID:             uint64(1),
+						UserID:            uint64(1),
+						Amount:            uint64(15000),
+						Description:       description,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+					},
+					User: entity.UserSimple{
+						ID:    1,
+						Email: "john@mail.com",
+						Name:  "John Doe",
+					},
+				},
+			},
+			wantTotal: 0,
+			wantErr:   nil,
+		},
+		{
+			name: "approval_queue with cursor counts without the cursor",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2`
+				selectQuery := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2 AND (e.created_at, e.id) < ($3, $4) ORDER BY e.created_at DESC, e.id DESC LIMIT $5`
+
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(8))
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(uint64(1), uint64(1), now, uint64(5), 10).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:    uint64(1),
+				UserID:   uint64(1),
+				UserRole: "manager",
+				View:     model.ExpenseViewApprovalQueue,
+				Limit:    10,
+				Cursor:   &model.ExpenseCursor{ID: 5, CreatedAt: &now},
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
+		{
+			name: "approval_queue with filters sorted by status",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				where := `WHERE e.org_id = $1 AND e.status = 'awaiting_approval' AND e.user_id != $2 AND e.user_id = ANY($3) ` +
+					`AND e.created_at >= $4 AND e.created_at <= $5 AND e.amount >= $6 AND e.amount <= $7`
+				countQuery := `SELECT COUNT(*) FROM expenses AS e ` + where
+				selectQuery := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id ` + where + ` ORDER BY e.status ASC, e.id ASC LIMIT $8 OFFSET $9`
+
+				args := []any{uint64(1), uint64(1), []uint64{2, 3}, createdFrom, createdTo, minAmount, maxAmount}
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(4))
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(append(args, 10, 0)...).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:        uint64(1),
+				UserID:       uint64(1),
+				UserRole:     "manager",
+				View:         model.ExpenseViewApprovalQueue,
+				UserIDs:      []uint64{2, 3},
+				CreatedFrom:  &createdFrom,
+				CreatedTo:    &createdTo,
+				MinAmountIDR: &minAmount,
+				MaxAmountIDR: &maxAmount,
+				SortBy:       model.ExpenseSortByStatus,
+				SortOrder:    model.SortOrderAsc,
+				Limit:        10,
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -419,14 +655,160 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_List() {
 	}
 }
 
+func (s *ExpenseRepositorySuite) TestExpenseRepository_Search() {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	description := "taxi from Surabaya airport"
+	highlight := "taxi from <mark>Surabaya</mark> airport"
+
+	from := `
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		LEFT JOIN approvals AS a ON e.id = a.expense_id
+		CROSS JOIN q
+		WHERE e.org_id = $1
+		AND (
+			to_tsvector('simple', e.description) @@ q.query
+			OR to_tsvector('simple', COALESCE(a.notes, '')) @@ q.query
+			OR to_tsvector('simple', u.name) @@ q.query
+			OR q.raw <% e.description
+			OR q.raw <% a.notes
+			OR q.raw <% u.name
+		)`
+	with := `WITH q AS (SELECT websearch_to_tsquery('simple', $2) AS query, $2::text AS raw)`
+	columns := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name,
+			ts_rank(
+				setweight(to_tsvector('simple', e.description), 'A') ||
+				setweight(to_tsvector('simple', COALESCE(a.notes, '')), 'B') ||
+				setweight(to_tsvector('simple', u.name), 'C'),
+				q.query
+			) + word_similarity(q.raw, e.description) AS rank,
+			ts_headline('simple', e.description, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS description_highlight,
+			CASE WHEN to_tsvector('simple', COALESCE(a.notes, '')) @@ q.query
+				THEN ts_headline('simple', a.notes, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') END AS approval_notes_highlight,
+			CASE WHEN to_tsvector('simple', u.name) @@ q.query
+				THEN ts_headline('simple', u.name, q.query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') END AS user_name_highlight`
+	resultColumns := []string{
+		"expense_id", "expense_user_id", "expense_amount", "expense_description",
+		"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+		"expense_department_id", "expense_cost_center",
+		"expense_org_id", "expense_approval_threshold",
+		"user_id", "user_email", "user_name",
+		"rank", "description_highlight", "approval_notes_highlight", "user_name_highlight",
+	}
+
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		param     *model.SearchExpenseRequest
+		wantRes   []entity.ExpenseSearchResult
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name: "count error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(with+` SELECT COUNT(*) `+from)).
+					WithArgs(uint64(1), "surabaya").
+					WillReturnError(errors.New("something error"))
+			},
+			param:   &model.SearchExpenseRequest{OrgID: 1, UserID: 1, Query: "surabaya", Limit: 10},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(with+` SELECT COUNT(*) `+from+` AND e.user_id = $3`)).
+					WithArgs(uint64(1), "surabaya", uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			param: &model.SearchExpenseRequest{OrgID: 1, UserID: 1, Query: "surabaya", OwnOnly: true, Limit: 10},
+		},
+		{
+			name: "select error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(with+` SELECT COUNT(*) `+from)).
+					WithArgs(uint64(1), "surabaya").
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(with+columns+from+` ORDER BY rank DESC, e.created_at DESC, e.id DESC LIMIT $3 OFFSET $4`)).
+					WithArgs(uint64(1), "surabaya", 10, 0).
+					WillReturnError(errors.New("something error"))
+			},
+			param:   &model.SearchExpenseRequest{OrgID: 1, UserID: 1, Query: "surabaya", Limit: 10},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success own expenses",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(with+` SELECT COUNT(*) `+from+` AND e.user_id = $3`)).
+					WithArgs(uint64(1), "surabaya", uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(with+columns+from+` AND e.user_id = $3 ORDER BY rank DESC, e.created_at DESC, e.id DESC LIMIT $4 OFFSET $5`)).
+					WithArgs(uint64(1), "surabaya", uint64(1), 10, 20).
+					WillReturnRows(pgxmock.NewRows(resultColumns).AddRow(
+						uint64(1), uint64(1), uint64(150000), description,
+						nil, entity.ExpenseStatusApproved, now, nil,
+						nil, nil,
+						uint64(1), uint64(1000000),
+						uint64(1), "john@mail.com", "John",
+						float64(0.6), highlight, nil, nil,
+					))
+			},
+			param: &model.SearchExpenseRequest{OrgID: 1, UserID: 1, Query: "surabaya", OwnOnly: true, Limit: 10, Offset: 20},
+			wantRes: []entity.ExpenseSearchResult{
+				{
+					ExpenseWithUser: entity.ExpenseWithUser{
+						Expense: entity.Expense{
+							ID:                1,
+							OrgID:             1,
+							UserID:            1,
+							Amount:            150000,
+							Description:       description,
+							Status:            entity.ExpenseStatusApproved,
+							ApprovalThreshold: 1000000,
+							CreatedAt:         now,
+						},
+						User: entity.UserSimple{ID: 1, Email: "john@mail.com", Name: "John"},
+					},
+					Rank:                 0.6,
+					DescriptionHighlight: highlight,
+				},
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, total, err := s.repo.Search(s.ctx, tt.param)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func (s *ExpenseRepositorySuite) TestExpenseRepository_FindDetailByID() {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 	description := "dummy description"
+	departmentID := uint64(3)
+	costCenter := "CC-100"
 
 	query := `
 		SELECT
 			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description, 
 			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold, e.version AS expense_version,
 			ue.id AS user_id, ue.email AS user_email, ue.name AS user_name,
 			a.id AS approval_id, a.approver_id, ua.email AS approver_email, ua.name AS approver_name,
 			a.status AS approval_status, a.notes AS approval_notes, a.created_at AS approval_created_at
@@ -434,11 +816,12 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindDetailByID() {
 		JOIN users AS ue ON e.user_id = ue.id
 		LEFT JOIN approvals AS a ON e.id = a.expense_id
 		LEFT JOIN users AS ua ON a.approver_id = ua.id
-		WHERE e.id = $1`
+		WHERE e.org_id = $1 AND e.id = $2`
 
 	tests := []struct {
 		name     string
 		mockFunc func(pgxmock.PgxPoolIface)
+		paramOrg uint64
 		paramID  uint64
 		wantRes  *entity.ExpenseDetail
 		wantErr  error
@@ -447,23 +830,37 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindDetailByID() {
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
-			paramID: uint64(1),
-			wantRes: nil,
-			wantErr: errors.New("something error"),
+			paramOrg: uint64(1),
+			paramID:  uint64(1),
+			wantRes:  nil,
+			wantErr:  errors.New("something error"),
 		},
 		{
 			name: "not found",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(pgx.ErrNoRows)
 			},
-			paramID: uint64(1),
-			wantRes: nil,
-			wantErr: nil,
+			paramOrg: uint64(1),
+			paramID:  uint64(1),
+			wantRes:  nil,
+			wantErr:  nil,
+		},
+		{
+			name: "expense of another organization",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(1)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			paramOrg: uint64(2),
+			paramID:  uint64(1),
+			wantRes:  nil,
+			wantErr:  nil,
 		},
 		{
 			name: "success with approval",
@@ -471,31 +868,41 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindDetailByID() {
 				rows := pgxmock.NewRows([]string{
 					"expense_id", "expense_user_id", "expense_amount", "expense_description",
 					"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+					"expense_department_id", "expense_cost_center",
+					"expense_org_id", "expense_approval_threshold", "expense_version",
 					"user_id", "user_email", "user_name",
 					"approval_id", "approver_id", "approver_email", "approver_name",
 					"approval_status", "approval_notes", "approval_created_at",
 				}).AddRow(
 					uint64(1), uint64(1), uint64(15000), description,
 					nil, entity.ExpenseStatusApproved, now, nil,
+					&departmentID, &costCenter,
+					uint64(1), uint64(1000000), uint64(2),
 					uint64(1), "john@mail.com", "John Doe",
 					uint64(1), uint64(2), "budi@mail.com", "Budi",
 					entity.ApprovalStatusApproved, nil, now,
 				)
 				m.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(rows)
 			},
-			paramID: uint64(1),
+			paramOrg: uint64(1),
+			paramID:  uint64(1),
 			wantRes: &entity.ExpenseDetail{
 				Expense: entity.Expense{
-					ID:          uint64(1),
-					UserID:      uint64(1),
-					Amount:      uint64(15000),
-					Description: description,
-					ReceiptURL:  nil,
-					Status:      entity.ExpenseStatusApproved,
-					CreatedAt:   now,
-					ProcessedAt: nil,
+					ID:                uint64(1),
+					OrgID:             uint64(1),
+					UserID:            uint64(1),
+					Amount:            uint64(15000),
+					Description:       description,
+					ReceiptURL:        nil,
+					Status:            entity.ExpenseStatusApproved,
+					ApprovalThreshold: uint64(1000000),
+					DepartmentID:      &departmentID,
+					CostCenter:        &costCenter,
+					CreatedAt:         now,
+					ProcessedAt:       nil,
+					Version:           uint64(2),
 				},
 				User: entity.UserSimple{
 					ID:    uint64(1),
@@ -520,7 +927,7 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindDetailByID() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			res, err := s.repo.FindDetailByID(s.ctx, tt.paramID)
+			res, err := s.repo.FindDetailByID(s.ctx, tt.paramOrg, tt.paramID)
 
 			s.Equal(tt.wantRes, res)
 			s.Equal(tt.wantErr, err)
@@ -543,9 +950,9 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByID() {
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 LIMIT 1`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 LIMIT 1`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
 			paramID: uint64(1),
@@ -556,9 +963,9 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByID() {
 			name: "not found",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 LIMIT 1`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 LIMIT 1`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(pgx.ErrNoRows)
 			},
 			paramID: uint64(1),
@@ -568,24 +975,27 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByID() {
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "user_id", "amount", "description", "receipt_url", "status", "created_at", "processed_at"}).
-					AddRow(uint64(1), uint64(1), uint64(15000), description, &receiptUrl, entity.ExpenseStatusApproved, s.now, nil)
+				rows := pgxmock.NewRows([]string{"id", "org_id", "user_id", "amount", "description", "receipt_url", "status", "approval_threshold", "department_id", "cost_center", "created_at", "processed_at", "version"}).
+					AddRow(uint64(1), uint64(1), uint64(1), uint64(15000), description, &receiptUrl, entity.ExpenseStatusApproved, uint64(1000000), nil, nil, s.now, nil, uint64(2))
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 LIMIT 1`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 LIMIT 1`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(rows)
 			},
 			paramID: uint64(1),
 			wantRes: &entity.Expense{
-				ID:          uint64(1),
-				UserID:      uint64(1),
-				Amount:      uint64(15000),
-				Description: description,
-				ReceiptURL:  &receiptUrl,
-				Status:      entity.ExpenseStatusApproved,
-				CreatedAt:   s.now,
-				ProcessedAt: nil,
+				ID:                uint64(1),
+				OrgID:             uint64(1),
+				UserID:            uint64(1),
+				Amount:            uint64(15000),
+				Description:       description,
+				ReceiptURL:        &receiptUrl,
+				Status:            entity.ExpenseStatusApproved,
+				ApprovalThreshold: uint64(1000000),
+				CreatedAt:         s.now,
+				ProcessedAt:       nil,
+				Version:           uint64(2),
 			},
 			wantErr: nil,
 		},
@@ -595,7 +1005,7 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByID() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			res, err := s.repo.FindByID(s.ctx, tt.paramID)
+			res, err := s.repo.FindByID(s.ctx, uint64(1), tt.paramID)
 
 			s.Equal(tt.wantRes, res)
 			s.Equal(tt.wantErr, err)
@@ -618,9 +1028,9 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByIDWithLock() {
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 FOR UPDATE`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 FOR UPDATE`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
 			paramID: uint64(1),
@@ -631,9 +1041,9 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByIDWithLock() {
 			name: "not found",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 FOR UPDATE`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 FOR UPDATE`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(pgx.ErrNoRows)
 			},
 			paramID: uint64(1),
@@ -643,24 +1053,27 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByIDWithLock() {
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "user_id", "amount", "description", "receipt_url", "status", "created_at", "processed_at"}).
-					AddRow(uint64(1), uint64(1), uint64(15000), description, &receiptUrl, entity.ExpenseStatusApproved, s.now, nil)
+				rows := pgxmock.NewRows([]string{"id", "org_id", "user_id", "amount", "description", "receipt_url", "status", "approval_threshold", "department_id", "cost_center", "created_at", "processed_at", "version"}).
+					AddRow(uint64(1), uint64(1), uint64(1), uint64(15000), description, &receiptUrl, entity.ExpenseStatusApproved, uint64(1000000), nil, nil, s.now, nil, uint64(2))
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, user_id, amount, description, receipt_url, status, created_at, processed_at FROM expenses WHERE id = $1 FOR UPDATE`,
+					`SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND id = $2 FOR UPDATE`,
 				)).
-					WithArgs(uint64(1)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(rows)
 			},
 			paramID: uint64(1),
 			wantRes: &entity.Expense{
-				ID:          uint64(1),
-				UserID:      uint64(1),
-				Amount:      uint64(15000),
-				Description: description,
-				ReceiptURL:  &receiptUrl,
-				Status:      entity.ExpenseStatusApproved,
-				CreatedAt:   s.now,
-				ProcessedAt: nil,
+				ID:                uint64(1),
+				OrgID:             uint64(1),
+				UserID:            uint64(1),
+				Amount:            uint64(15000),
+				Description:       description,
+				ReceiptURL:        &receiptUrl,
+				Status:            entity.ExpenseStatusApproved,
+				ApprovalThreshold: uint64(1000000),
+				CreatedAt:         s.now,
+				ProcessedAt:       nil,
+				Version:           uint64(2),
 			},
 			wantErr: nil,
 		},
@@ -670,7 +1083,7 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_FindByIDWithLock() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			res, err := s.repo.FindByIDWithLock(s.ctx, s.mock, tt.paramID)
+			res, err := s.repo.FindByIDWithLock(s.ctx, s.mock, uint64(1), tt.paramID)
 
 			s.Equal(tt.wantRes, res)
 			s.Equal(tt.wantErr, err)
@@ -689,8 +1102,8 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_UpdateStatusByIDTx() {
 		{
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = $1 WHERE id = $2`)).
-					WithArgs(pgxmock.AnyArg(), uint64(1)).
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = $1, version = version + 1 WHERE org_id = $2 AND id = $3`)).
+					WithArgs(pgxmock.AnyArg(), uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
 			paramID:     uint64(1),
@@ -700,8 +1113,8 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_UpdateStatusByIDTx() {
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = $1 WHERE id = $2`)).
-					WithArgs(pgxmock.AnyArg(), uint64(1)).
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = $1, version = version + 1 WHERE org_id = $2 AND id = $3`)).
+					WithArgs(pgxmock.AnyArg(), uint64(1), uint64(1)).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 			},
 			paramID:     uint64(1),
@@ -714,7 +1127,53 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_UpdateStatusByIDTx() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			err := s.repo.UpdateStatusByIDTx(s.ctx, s.mock, tt.paramID, tt.paramStatus)
+			err := s.repo.UpdateStatusByIDTx(s.ctx, s.mock, uint64(1), tt.paramID, tt.paramStatus)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_UpdateTx() {
+	query := `UPDATE expenses SET amount = $1, description = $2, receipt_url = $3, status = $4, version = version + 1 WHERE org_id = $5 AND id = $6 RETURNING version`
+
+	tests := []struct {
+		name        string
+		mockFunc    func(pgxmock.PgxPoolIface)
+		wantVersion uint64
+		wantErr     error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2000000), "Hotel only", (*string)(nil), entity.ExpenseStatusAwaitingApproval, uint64(1), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantVersion: 3,
+			wantErr:     errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2000000), "Hotel only", (*string)(nil), entity.ExpenseStatusAwaitingApproval, uint64(1), uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"version"}).AddRow(uint64(4)))
+			},
+			wantVersion: 4,
+			wantErr:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			expense := &entity.Expense{
+				ID: 1, OrgID: 1, Amount: 2000000, Description: "Hotel only", Status: entity.ExpenseStatusAwaitingApproval, Version: 3,
+			}
+			err := s.repo.UpdateTx(s.ctx, s.mock, expense)
+
+			s.Equal(tt.wantVersion, expense.Version)
 			s.Equal(tt.wantErr, err)
 		})
 	}
@@ -731,8 +1190,8 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_CompleteByIDTx() {
 		{
 			name: "error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = 'completed', processed_at = $1 WHERE id = $2`)).
-					WithArgs(pgxmock.AnyArg(), uint64(1)).
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = 'completed', processed_at = $1, version = version + 1 WHERE org_id = $2 AND id = $3`)).
+					WithArgs(pgxmock.AnyArg(), uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
 			paramID:     uint64(1),
@@ -742,8 +1201,8 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_CompleteByIDTx() {
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = 'completed', processed_at = $1 WHERE id = $2`)).
-					WithArgs(pgxmock.AnyArg(), uint64(1)).
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE expenses SET status = 'completed', processed_at = $1, version = version + 1 WHERE org_id = $2 AND id = $3`)).
+					WithArgs(pgxmock.AnyArg(), uint64(1), uint64(1)).
 					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 			},
 			paramID:     uint64(1),
@@ -756,7 +1215,483 @@ func (s *ExpenseRepositorySuite) TestExpenseRepository_CompleteByIDTx() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			err := s.repo.CompleteByIDTx(s.ctx, s.mock, tt.paramID, time.Now())
+			err := s.repo.CompleteByIDTx(s.ctx, s.mock, uint64(1), tt.paramID, time.Now())
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_Count() {
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		param     *model.ListExpenseRequest
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:     "invalid view",
+			mockFunc: func(m pgxmock.PgxPoolIface) {},
+			param: &model.ListExpenseRequest{
+				OrgID:  uint64(1),
+				UserID: uint64(1),
+				View:   model.ExpenseView("unknown"),
+			},
+			wantTotal: 0,
+			wantErr:   errors.New("invalid view"),
+		},
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = $2`
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:  uint64(1),
+				UserID: uint64(1),
+				View:   model.ExpenseViewPersonal,
+			},
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
+		{
+			name: "organization with filters",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				countQuery := `SELECT COUNT(*) FROM expenses AS e WHERE e.org_id = $1 AND e.user_id = ANY($2) ` +
+					`AND e.status = ANY($3::text[]::expense_status[]) AND e.amount < e.approval_threshold`
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), []uint64{2, 3}, []string{"completed"}).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			param: &model.ListExpenseRequest{
+				OrgID:        uint64(1),
+				UserID:       uint64(1),
+				View:         model.ExpenseViewOrganization,
+				UserIDs:      []uint64{2, 3},
+				Statuses:     []string{"completed"},
+				AutoApproved: true,
+			},
+			wantTotal: 7,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			total, err := s.repo.Count(s.ctx, tt.param)
+
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_Stream() {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	description := "dummy description"
+
+	query := `
+		SELECT
+			e.id AS expense_id, e.user_id AS expense_user_id, e.amount AS expense_amount, e.description AS expense_description,
+			e.receipt_url AS expense_receipt_url, e.status AS expense_status, e.created_at AS expense_created_at, e.processed_at AS expense_processed_at,
+			e.department_id AS expense_department_id, e.cost_center AS expense_cost_center,
+			e.org_id AS expense_org_id, e.approval_threshold AS expense_approval_threshold, e.version AS expense_version,
+			u.id AS user_id, u.email AS user_email, u.name AS user_name,
+			a.id AS approval_id, a.approver_id, ua.email AS approver_email, ua.name AS approver_name,
+			a.status AS approval_status, a.notes AS approval_notes, a.created_at AS approval_created_at
+		FROM expenses AS e
+		JOIN users AS u ON e.user_id = u.id
+		LEFT JOIN approvals AS a ON e.id = a.expense_id
+		LEFT JOIN users AS ua ON a.approver_id = ua.id
+		WHERE e.org_id = $1 AND e.created_at >= $2 ORDER BY e.amount ASC, e.id ASC`
+
+	param := &model.ListExpenseRequest{
+		OrgID:       uint64(1),
+		UserID:      uint64(1),
+		View:        model.ExpenseViewOrganization,
+		CreatedFrom: &now,
+		SortBy:      model.ExpenseSortByAmount,
+		SortOrder:   model.SortOrderAsc,
+	}
+
+	columns := []string{
+		"expense_id", "expense_user_id", "expense_amount", "expense_description",
+		"expense_receipt_url", "expense_status", "expense_created_at", "expense_processed_at",
+		"expense_department_id", "expense_cost_center",
+		"expense_org_id", "expense_approval_threshold", "expense_version",
+		"user_id", "user_email", "user_name",
+		"approval_id", "approver_id", "approver_email", "approver_name",
+		"approval_status", "approval_notes", "approval_created_at",
+	}
+	rows := func() *pgxmock.Rows {
+		return pgxmock.NewRows(columns).AddRow(
+			uint64(1), uint64(2), uint64(15000), description,
+			nil, entity.ExpenseStatusCompleted, now, &now,
+			nil, nil,
+			uint64(1), uint64(1000000), uint64(1),
+			uint64(2), "john@mail.com", "John Doe",
+			nil, nil, nil, nil,
+			nil, nil, nil,
+		).AddRow(
+			uint64(2), uint64(2), uint64(2000000), description,
+			nil, entity.ExpenseStatusApproved, now, nil,
+			nil, nil,
+			uint64(1), uint64(1000000), uint64(2),
+			uint64(2), "john@mail.com", "John Doe",
+			int64(4), int64(3), "budi@mail.com", "Budi",
+			"approved", nil, now,
+		)
+	}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		fnErr    error
+		wantRes  []entity.ExpenseDetail
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), now).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "error on callback",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), now).
+					WillReturnRows(rows())
+			},
+			fnErr: errors.New("write error"),
+			wantRes: []entity.ExpenseDetail{
+				{
+					Expense: entity.Expense{
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(2),
+						Amount:            uint64(15000),
+						Description:       description,
+						Status:            entity.ExpenseStatusCompleted,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       &now,
+						Version:           uint64(1),
+					},
+					User: entity.UserSimple{ID: uint64(2), Email: "john@mail.com", Name: "John Doe"},
+				},
+			},
+			wantErr: errors.New("write error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), now).
+					WillReturnRows(rows())
+			},
+			wantRes: []entity.ExpenseDetail{
+				{
+					Expense: entity.Expense{
+						ID:                uint64(1),
+						OrgID:             uint64(1),
+						UserID:            uint64(2),
+						Amount:            uint64(15000),
+						Description:       description,
+						Status:            entity.ExpenseStatusCompleted,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						ProcessedAt:       &now,
+						Version:           uint64(1),
+					},
+					User: entity.UserSimple{ID: uint64(2), Email: "john@mail.com", Name: "John Doe"},
+				},
+				{
+					Expense: entity.Expense{
+						ID:                uint64(2),
+						OrgID:             uint64(1),
+						UserID:            uint64(2),
+						Amount:            uint64(2000000),
+						Description:       description,
+						Status:            entity.ExpenseStatusApproved,
+						ApprovalThreshold: uint64(1000000),
+						CreatedAt:         now,
+						Version:           uint64(2),
+					},
+					User: entity.UserSimple{ID: uint64(2), Email: "john@mail.com", Name: "John Doe"},
+					Approval: &entity.ApprovalDetail{
+						ID:            uint64(4),
+						ApproverID:    uint64(3),
+						ApproverEmail: "budi@mail.com",
+						ApproverName:  "Budi",
+						Status:        entity.ApprovalStatusApproved,
+						CreatedAt:     now,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			var res []entity.ExpenseDetail
+			err := s.repo.Stream(s.ctx, param, func(detail *entity.ExpenseDetail) error {
+				res = append(res, *detail)
+				return tt.fnErr
+			})
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_ListUnexportedWithLock() {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND status = 'completed' AND journal_batch_id IS NULL AND processed_at >= $2 AND processed_at < $3 ORDER BY processed_at ASC, id ASC FOR UPDATE`
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	costCenter := "CC-100"
+	columns := []string{"id", "org_id", "user_id", "amount", "description", "receipt_url", "status", "approval_threshold",
+		"department_id", "cost_center", "created_at", "processed_at", "version"}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.Expense
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), from, to).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), from, to).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(uint64(7), uint64(1), uint64(2), uint64(150000), "Taxi", nil,
+						entity.ExpenseStatusCompleted, uint64(1000000), nil, &costCenter, s.now, &s.now, uint64(3)))
+			},
+			wantRes: []entity.Expense{
+				{
+					ID: 7, OrgID: 1, UserID: 2, Amount: 150000, Description: "Taxi", Status: entity.ExpenseStatusCompleted,
+					ApprovalThreshold: 1000000, CostCenter: &costCenter, CreatedAt: s.now, ProcessedAt: &s.now, Version: 3,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListUnexportedWithLock(s.ctx, s.mock, uint64(1), from, to)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_SetJournalBatchIDTx() {
+	query := `UPDATE expenses SET journal_batch_id = $1 WHERE org_id = $2 AND id = ANY($3)`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(4), uint64(1), []uint64{7, 8}).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(4), uint64(1), []uint64{7, 8}).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.SetJournalBatchIDTx(s.ctx, s.mock, uint64(1), []uint64{7, 8}, uint64(4))
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_SumByUserStatus() {
+	query := `SELECT status, COUNT(*), COALESCE(SUM(amount), 0)::BIGINT FROM expenses WHERE org_id = $1 AND user_id = $2 AND status <> 'rejected' GROUP BY status`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseStatusTotal
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnRows(pgxmock.NewRows([]string{"status", "count", "sum"}).
+						AddRow(entity.ExpenseStatusApproved, 1, uint64(50000)).
+						AddRow(entity.ExpenseStatusCompleted, 2, uint64(150000)))
+			},
+			wantRes: []entity.ExpenseStatusTotal{
+				{Status: entity.ExpenseStatusApproved, Count: 1, Amount: 50000},
+				{Status: entity.ExpenseStatusCompleted, Count: 2, Amount: 150000},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.SumByUserStatus(s.ctx, uint64(1), uint64(2))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_SumByUserMonth() {
+	query := `SELECT to_char(date_trunc('month', created_at AT TIME ZONE 'UTC'), 'YYYY-MM') AS month, status, COUNT(*), COALESCE(SUM(amount), 0)::BIGINT FROM expenses WHERE org_id = $1 AND user_id = $2 AND status <> 'rejected' AND created_at >= $3 AND created_at < $4 GROUP BY 1, 2 ORDER BY 1 ASC`
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseStatusTotal
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), from, to).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), from, to).
+					WillReturnRows(pgxmock.NewRows([]string{"month", "status", "count", "sum"}).
+						AddRow("2025-08", entity.ExpenseStatusCompleted, 2, uint64(150000)).
+						AddRow("2025-09", entity.ExpenseStatusAwaitingApproval, 1, uint64(2000000)))
+			},
+			wantRes: []entity.ExpenseStatusTotal{
+				{Month: "2025-08", Status: entity.ExpenseStatusCompleted, Count: 2, Amount: 150000},
+				{Month: "2025-09", Status: entity.ExpenseStatusAwaitingApproval, Count: 1, Amount: 2000000},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.SumByUserMonth(s.ctx, uint64(1), uint64(2), from, to)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseRepositorySuite) TestExpenseRepository_ListByUserCreated() {
+	query := `SELECT id, org_id, user_id, amount, description, receipt_url, status, approval_threshold, department_id, cost_center, created_at, processed_at, version FROM expenses WHERE org_id = $1 AND user_id = $2 AND created_at >= $3 AND created_at < $4 ORDER BY created_at ASC, id ASC`
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "org_id", "user_id", "amount", "description", "receipt_url", "status", "approval_threshold",
+		"department_id", "cost_center", "created_at", "processed_at", "version"}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.Expense
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), from, to).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2), from, to).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(uint64(7), uint64(1), uint64(2), uint64(150000), "Taxi", nil,
+						entity.ExpenseStatusCompleted, uint64(1000000), nil, nil, s.now, &s.now, uint64(3)))
+			},
+			wantRes: []entity.Expense{
+				{
+					ID: 7, OrgID: 1, UserID: 2, Amount: 150000, Description: "Taxi", Status: entity.ExpenseStatusCompleted,
+					ApprovalThreshold: 1000000, CreatedAt: s.now, ProcessedAt: &s.now, Version: 3,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByUserCreated(s.ctx, uint64(1), uint64(2), from, to)
+			s.Equal(tt.wantRes, res)
 			s.Equal(tt.wantErr, err)
 		})
 	}
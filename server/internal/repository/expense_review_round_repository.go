@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+)
+
+type ExpenseReviewRoundRepository struct {
+	db db.PgxIface
+}
+
+func NewExpenseReviewRoundRepository(db db.PgxIface) *ExpenseReviewRoundRepository {
+	return &ExpenseReviewRoundRepository{
+		db: db,
+	}
+}
+
+// CreateTx opens the next round of the expense, the caller holds the lock of the expense so the
+// numbering can't race
+func (r *ExpenseReviewRoundRepository) CreateTx(ctx context.Context, exec db.Executor, round *entity.ExpenseReviewRound) error {
+	now := time.Now()
+	query := `
+		INSERT INTO expense_review_rounds (org_id, expense_id, round, reviewer_id, notes, requested_at)
+		SELECT $1, $2, COALESCE(MAX(round), 0) + 1, $3, $4, $5
+		FROM expense_review_rounds WHERE org_id = $1 AND expense_id = $2
+		RETURNING id, round`
+
+	err := exec.QueryRow(ctx, query,
+		round.OrgID,
+		round.ExpenseID,
+		round.ReviewerID,
+		round.Notes,
+		now,
+	).Scan(&round.ID, &round.Round)
+	if err != nil {
+		return err
+	}
+
+	round.RequestedAt = now
+
+	return nil
+}
+
+// ResubmitTx closes the open round of the expense
+func (r *ExpenseReviewRoundRepository) ResubmitTx(ctx context.Context, exec db.Executor, orgID uint64, expenseID uint64,
+	resubmittedAt time.Time) error {
+	query := `UPDATE expense_review_rounds SET resubmitted_at = $1 WHERE org_id = $2 AND expense_id = $3 AND resubmitted_at IS NULL`
+
+	_, err := exec.Exec(ctx, query, resubmittedAt, orgID, expenseID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListByExpenseID returns the rounds of the expense in order with their reviewers
+func (r *ExpenseReviewRoundRepository) ListByExpenseID(ctx context.Context, orgID uint64,
+	expenseID uint64) ([]entity.ExpenseReviewRound, error) {
+	query := `
+		SELECT rr.id, rr.org_id, rr.expense_id, rr.round, rr.reviewer_id, rr.notes, rr.requested_at, rr.resubmitted_at,
+			u.id, u.email, u.name
+		FROM expense_review_rounds AS rr
+		JOIN users AS u ON u.id = rr.reviewer_id
+		WHERE rr.org_id = $1 AND rr.expense_id = $2
+		ORDER BY rr.round ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.ExpenseReviewRound
+	for rows.Next() {
+		var rr entity.ExpenseReviewRound
+		err := rows.Scan(
+			&rr.ID, &rr.OrgID, &rr.ExpenseID, &rr.Round, &rr.ReviewerID, &rr.Notes, &rr.RequestedAt, &rr.ResubmittedAt,
+			&rr.Reviewer.ID, &rr.Reviewer.Email, &rr.Reviewer.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rr)
+	}
+
+	return results, nil
+}
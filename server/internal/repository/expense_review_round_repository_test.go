@@ -0,0 +1,182 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpenseReviewRoundRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.ExpenseReviewRoundRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *ExpenseReviewRoundRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewExpenseReviewRoundRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *ExpenseReviewRoundRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *ExpenseReviewRoundRepositorySuite) TestExpenseReviewRoundRepository_CreateTx() {
+	query := `INSERT INTO expense_review_rounds (org_id, expense_id, round, reviewer_id, notes, requested_at) SELECT $1, $2, COALESCE(MAX(round), 0) + 1, $3, $4, $5 FROM expense_review_rounds WHERE org_id = $1 AND expense_id = $2 RETURNING id, round`
+
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		wantID    uint64
+		wantRound int
+		wantErr   error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), uint64(3), "Split the hotel and the flight", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), uint64(3), "Split the hotel and the flight", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "round"}).AddRow(uint64(5), 2))
+			},
+			wantID:    5,
+			wantRound: 2,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			round := &entity.ExpenseReviewRound{OrgID: 1, ExpenseID: 7, ReviewerID: 3, Notes: "Split the hotel and the flight"}
+			err := s.repo.CreateTx(s.ctx, s.mock, round)
+
+			s.Equal(tt.wantID, round.ID)
+			s.Equal(tt.wantRound, round.Round)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseReviewRoundRepositorySuite) TestExpenseReviewRoundRepository_ResubmitTx() {
+	query := `UPDATE expense_review_rounds SET resubmitted_at = $1 WHERE org_id = $2 AND expense_id = $3 AND resubmitted_at IS NULL`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1), uint64(7)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1), uint64(7)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.ResubmitTx(s.ctx, s.mock, 1, 7, s.now)
+
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *ExpenseReviewRoundRepositorySuite) TestExpenseReviewRoundRepository_ListByExpenseID() {
+	query := `SELECT rr.id, rr.org_id, rr.expense_id, rr.round, rr.reviewer_id, rr.notes, rr.requested_at, rr.resubmitted_at, u.id, u.email, u.name FROM expense_review_rounds AS rr JOIN users AS u ON u.id = rr.reviewer_id WHERE rr.org_id = $1 AND rr.expense_id = $2 ORDER BY rr.round ASC`
+	columns := []string{"id", "org_id", "expense_id", "round", "reviewer_id", "notes", "requested_at", "resubmitted_at",
+		"id", "email", "name"}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.ExpenseReviewRound
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(4), uint64(1), uint64(7), 1, uint64(3), "Attach the receipt", s.now, &s.now,
+							uint64(3), "jane@mail.com", "Jane Roe").
+						AddRow(uint64(5), uint64(1), uint64(7), 2, uint64(3), "Split the hotel", s.now, nil,
+							uint64(3), "jane@mail.com", "Jane Roe"))
+			},
+			wantRes: []entity.ExpenseReviewRound{
+				{
+					ID: 4, OrgID: 1, ExpenseID: 7, Round: 1, ReviewerID: 3, Notes: "Attach the receipt",
+					RequestedAt: s.now, ResubmittedAt: &s.now,
+					Reviewer: entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+				},
+				{
+					ID: 5, OrgID: 1, ExpenseID: 7, Round: 2, ReviewerID: 3, Notes: "Split the hotel",
+					RequestedAt: s.now,
+					Reviewer:    entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByExpenseID(s.ctx, 1, 7)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestExpenseReviewRoundRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ExpenseReviewRoundRepositorySuite))
+}
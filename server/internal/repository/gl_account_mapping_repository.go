@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+)
+
+type GLAccountMappingRepository struct {
+	db db.PgxIface
+}
+
+func NewGLAccountMappingRepository(db db.PgxIface) *GLAccountMappingRepository {
+	return &GLAccountMappingRepository{
+		db: db,
+	}
+}
+
+// List returns the default mapping first, then the cost centers by name
+func (r *GLAccountMappingRepository) List(ctx context.Context, orgID uint64) ([]entity.GLAccountMapping, error) {
+	query := `SELECT id, org_id, cost_center, expense_account, credit_account, created_at, updated_at FROM gl_account_mappings WHERE org_id = $1 ORDER BY cost_center ASC NULLS FIRST`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.GLAccountMapping
+	for rows.Next() {
+		var m entity.GLAccountMapping
+		err := rows.Scan(&m.ID, &m.OrgID, &m.CostCenter, &m.ExpenseAccount, &m.CreditAccount, &m.CreatedAt, &m.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, m)
+	}
+
+	return results, nil
+}
+
+// Upsert replaces the accounts of the cost center when it's already mapped
+func (r *GLAccountMappingRepository) Upsert(ctx context.Context, mapping *entity.GLAccountMapping) error {
+	now := time.Now()
+	query := `
+		INSERT INTO gl_account_mappings (org_id, cost_center, expense_account, credit_account, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT ON CONSTRAINT uq_gl_account_mappings_org_id_cost_center
+		DO UPDATE SET expense_account = EXCLUDED.expense_account, credit_account = EXCLUDED.credit_account, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		mapping.OrgID,
+		mapping.CostCenter,
+		mapping.ExpenseAccount,
+		mapping.CreditAccount,
+		now,
+	).Scan(&mapping.ID, &mapping.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	mapping.UpdatedAt = now
+
+	return nil
+}
+
+// Delete reports false when the mapping doesn't exist in the organization
+func (r *GLAccountMappingRepository) Delete(ctx context.Context, orgID uint64, id uint64) (bool, error) {
+	query := `DELETE FROM gl_account_mappings WHERE org_id = $1 AND id = $2`
+
+	tag, err := r.db.Exec(ctx, query, orgID, id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
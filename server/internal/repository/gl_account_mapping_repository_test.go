@@ -0,0 +1,182 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type GLAccountMappingRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.GLAccountMappingRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *GLAccountMappingRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewGLAccountMappingRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *GLAccountMappingRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *GLAccountMappingRepositorySuite) TestGLAccountMappingRepository_List() {
+	query := `SELECT id, org_id, cost_center, expense_account, credit_account, created_at, updated_at FROM gl_account_mappings WHERE org_id = $1 ORDER BY cost_center ASC NULLS FIRST`
+	columns := []string{"id", "org_id", "cost_center", "expense_account", "credit_account", "created_at", "updated_at"}
+	costCenter := "CC-100"
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.GLAccountMapping
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(1), uint64(1), nil, "6000", "2100", s.now, s.now).
+						AddRow(uint64(2), uint64(1), &costCenter, "6100", "2100", s.now, s.now))
+			},
+			wantRes: []entity.GLAccountMapping{
+				{ID: 1, OrgID: 1, ExpenseAccount: "6000", CreditAccount: "2100", CreatedAt: s.now, UpdatedAt: s.now},
+				{ID: 2, OrgID: 1, CostCenter: &costCenter, ExpenseAccount: "6100", CreditAccount: "2100", CreatedAt: s.now, UpdatedAt: s.now},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.List(s.ctx, uint64(1))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *GLAccountMappingRepositorySuite) TestGLAccountMappingRepository_Upsert() {
+	query := `INSERT INTO gl_account_mappings (org_id, cost_center, expense_account, credit_account, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $5) ON CONFLICT ON CONSTRAINT uq_gl_account_mappings_org_id_cost_center DO UPDATE SET expense_account = EXCLUDED.expense_account, credit_account = EXCLUDED.credit_account, updated_at = EXCLUDED.updated_at RETURNING id, created_at`
+	costCenter := "CC-100"
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), &costCenter, "6100", "2100", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), &costCenter, "6100", "2100", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uint64(2), s.now))
+			},
+			wantID:  2,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			mapping := &entity.GLAccountMapping{OrgID: 1, CostCenter: &costCenter, ExpenseAccount: "6100", CreditAccount: "2100"}
+			err := s.repo.Upsert(s.ctx, mapping)
+			s.Equal(tt.wantID, mapping.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *GLAccountMappingRepositorySuite) TestGLAccountMappingRepository_Delete() {
+	query := `DELETE FROM gl_account_mappings WHERE org_id = $1 AND id = $2`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.Delete(s.ctx, uint64(1), uint64(2))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestGLAccountMappingRepositorySuite(t *testing.T) {
+	suite.Run(t, new(GLAccountMappingRepositorySuite))
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type JournalBatchRepository struct {
+	db db.PgxIface
+}
+
+func NewJournalBatchRepository(db db.PgxIface) *JournalBatchRepository {
+	return &JournalBatchRepository{
+		db: db,
+	}
+}
+
+func (r *JournalBatchRepository) CreateTx(ctx context.Context, exec db.Executor, batch *entity.JournalBatch) error {
+	now := time.Now()
+	query := `
+		INSERT INTO journal_batches (org_id, period, created_by, line_count, total_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	err := exec.QueryRow(ctx, query,
+		batch.OrgID,
+		batch.Period,
+		batch.CreatedBy,
+		batch.LineCount,
+		batch.TotalAmount,
+		now,
+	).Scan(&batch.ID)
+	if err != nil {
+		return err
+	}
+
+	batch.CreatedAt = now
+
+	return nil
+}
+
+// CreateLinesTx inserts the lines of the batch in one statement whatever their number
+func (r *JournalBatchRepository) CreateLinesTx(ctx context.Context, exec db.Executor, batchID uint64, lines []entity.JournalLine) error {
+	query := `
+		INSERT INTO journal_lines (batch_id, expense_id, line_no, entry_date, account, debit, credit, cost_center, memo)
+		SELECT $1, * FROM unnest($2::BIGINT[], $3::INT[], $4::DATE[], $5::VARCHAR[], $6::BIGINT[], $7::BIGINT[], $8::VARCHAR[], $9::VARCHAR[])`
+
+	expenseIDs := make([]uint64, len(lines))
+	lineNos := make([]int, len(lines))
+	entryDates := make([]time.Time, len(lines))
+	accounts := make([]string, len(lines))
+	debits := make([]uint64, len(lines))
+	credits := make([]uint64, len(lines))
+	costCenters := make([]*string, len(lines))
+	memos := make([]string, len(lines))
+	for i, line := range lines {
+		expenseIDs[i] = line.ExpenseID
+		lineNos[i] = line.LineNo
+		entryDates[i] = line.EntryDate
+		accounts[i] = line.Account
+		debits[i] = line.Debit
+		credits[i] = line.Credit
+		costCenters[i] = line.CostCenter
+		memos[i] = line.Memo
+	}
+
+	_, err := exec.Exec(ctx, query, batchID, expenseIDs, lineNos, entryDates, accounts, debits, credits, costCenters, memos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *JournalBatchRepository) List(ctx context.Context, orgID uint64) ([]entity.JournalBatch, error) {
+	query := `SELECT id, org_id, period, created_by, line_count, total_amount, created_at FROM journal_batches WHERE org_id = $1 ORDER BY id DESC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.JournalBatch
+	for rows.Next() {
+		var b entity.JournalBatch
+		err := rows.Scan(&b.ID, &b.OrgID, &b.Period, &b.CreatedBy, &b.LineCount, &b.TotalAmount, &b.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, b)
+	}
+
+	return results, nil
+}
+
+func (r *JournalBatchRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.JournalBatch, error) {
+	query := `SELECT id, org_id, period, created_by, line_count, total_amount, created_at FROM journal_batches WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	var b entity.JournalBatch
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(&b.ID, &b.OrgID, &b.Period, &b.CreatedBy, &b.LineCount, &b.TotalAmount, &b.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &b, nil
+}
+
+func (r *JournalBatchRepository) ListLines(ctx context.Context, batchID uint64) ([]entity.JournalLine, error) {
+	query := `SELECT id, batch_id, expense_id, line_no, entry_date, account, debit, credit, cost_center, memo FROM journal_lines WHERE batch_id = $1 ORDER BY line_no ASC`
+
+	rows, err := r.db.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.JournalLine
+	for rows.Next() {
+		var l entity.JournalLine
+		err := rows.Scan(&l.ID, &l.BatchID, &l.ExpenseID, &l.LineNo, &l.EntryDate, &l.Account, &l.Debit, &l.Credit, &l.CostCenter, &l.Memo)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, l)
+	}
+
+	return results, nil
+}
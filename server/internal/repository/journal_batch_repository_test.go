@@ -0,0 +1,277 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalBatchRepositorySuite struct {
+	suite.Suite
+	mock    pgxmock.PgxPoolIface
+	repo    *repository.JournalBatchRepository
+	ctx     context.Context
+	now     time.Time
+	columns []string
+}
+
+func (s *JournalBatchRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewJournalBatchRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.columns = []string{"id", "org_id", "period", "created_by", "line_count", "total_amount", "created_at"}
+}
+
+func (s *JournalBatchRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *JournalBatchRepositorySuite) TestJournalBatchRepository_CreateTx() {
+	query := `INSERT INTO journal_batches (org_id, period, created_by, line_count, total_amount, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), "2025-09", uint64(2), 4, uint64(200000), pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), "2025-09", uint64(2), 4, uint64(200000), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+			},
+			wantID:  4,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			batch := &entity.JournalBatch{OrgID: 1, Period: "2025-09", CreatedBy: 2, LineCount: 4, TotalAmount: 200000}
+			err := s.repo.CreateTx(s.ctx, s.mock, batch)
+			s.Equal(tt.wantID, batch.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *JournalBatchRepositorySuite) TestJournalBatchRepository_CreateLinesTx() {
+	query := `INSERT INTO journal_lines (batch_id, expense_id, line_no, entry_date, account, debit, credit, cost_center, memo) SELECT $1, * FROM unnest($2::BIGINT[], $3::INT[], $4::DATE[], $5::VARCHAR[], $6::BIGINT[], $7::BIGINT[], $8::VARCHAR[], $9::VARCHAR[])`
+	entryDate := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	costCenter := "CC-100"
+	lines := []entity.JournalLine{
+		{ExpenseID: 7, LineNo: 1, EntryDate: entryDate, Account: "6100", Debit: 150000, CostCenter: &costCenter, Memo: "EXP-000000007 Taxi"},
+		{ExpenseID: 7, LineNo: 2, EntryDate: entryDate, Account: "2100", Credit: 150000, CostCenter: &costCenter, Memo: "EXP-000000007 Taxi"},
+	}
+	args := []any{
+		uint64(4), []uint64{7, 7}, []int{1, 2}, []time.Time{entryDate, entryDate}, []string{"6100", "2100"},
+		[]uint64{150000, 0}, []uint64{0, 150000}, []*string{&costCenter, &costCenter},
+		[]string{"EXP-000000007 Taxi", "EXP-000000007 Taxi"},
+	}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(args...).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(args...).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.CreateLinesTx(s.ctx, s.mock, uint64(4), lines)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *JournalBatchRepositorySuite) TestJournalBatchRepository_List() {
+	query := `SELECT id, org_id, period, created_by, line_count, total_amount, created_at FROM journal_batches WHERE org_id = $1 ORDER BY id DESC`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.JournalBatch
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows(s.columns).AddRow(uint64(4), uint64(1), "2025-09", uint64(2), 4, uint64(200000), s.now))
+			},
+			wantRes: []entity.JournalBatch{
+				{ID: 4, OrgID: 1, Period: "2025-09", CreatedBy: 2, LineCount: 4, TotalAmount: 200000, CreatedAt: s.now},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.List(s.ctx, uint64(1))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *JournalBatchRepositorySuite) TestJournalBatchRepository_FindByID() {
+	query := `SELECT id, org_id, period, created_by, line_count, total_amount, created_at FROM journal_batches WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.JournalBatch
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(4)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(4)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(4)).
+					WillReturnRows(pgxmock.NewRows(s.columns).AddRow(uint64(4), uint64(1), "2025-09", uint64(2), 4, uint64(200000), s.now))
+			},
+			wantRes: &entity.JournalBatch{ID: 4, OrgID: 1, Period: "2025-09", CreatedBy: 2, LineCount: 4, TotalAmount: 200000, CreatedAt: s.now},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, uint64(1), uint64(4))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *JournalBatchRepositorySuite) TestJournalBatchRepository_ListLines() {
+	query := `SELECT id, batch_id, expense_id, line_no, entry_date, account, debit, credit, cost_center, memo FROM journal_lines WHERE batch_id = $1 ORDER BY line_no ASC`
+	columns := []string{"id", "batch_id", "expense_id", "line_no", "entry_date", "account", "debit", "credit", "cost_center", "memo"}
+	entryDate := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.JournalLine
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(4)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(4)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(1), uint64(4), uint64(7), 1, entryDate, "6000", uint64(50000), uint64(0), nil, "EXP-000000007 Lunch").
+						AddRow(uint64(2), uint64(4), uint64(7), 2, entryDate, "2100", uint64(0), uint64(50000), nil, "EXP-000000007 Lunch"))
+			},
+			wantRes: []entity.JournalLine{
+				{ID: 1, BatchID: 4, ExpenseID: 7, LineNo: 1, EntryDate: entryDate, Account: "6000", Debit: 50000, Memo: "EXP-000000007 Lunch"},
+				{ID: 2, BatchID: 4, ExpenseID: 7, LineNo: 2, EntryDate: entryDate, Account: "2100", Credit: 50000, Memo: "EXP-000000007 Lunch"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListLines(s.ctx, uint64(4))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestJournalBatchRepositorySuite(t *testing.T) {
+	suite.Run(t, new(JournalBatchRepositorySuite))
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const ledgerEntryColumns = `id, org_id, expense_id, type, reverses_entry_id, memo, created_by, created_at`
+
+type LedgerRepository struct {
+	db db.PgxIface
+}
+
+func NewLedgerRepository(db db.PgxIface) *LedgerRepository {
+	return &LedgerRepository{
+		db: db,
+	}
+}
+
+// PostTx saves the entry and its postings, the accounts are opened on their first posting.
+// It returns false without posting when the expense already has an entry of the same type
+// or the entry was already reversed, so a retried step never posts twice.
+func (r *LedgerRepository) PostTx(ctx context.Context, exec db.Executor, entry *entity.LedgerEntry) (bool, error) {
+	if !entry.Balanced() {
+		return false, errors.New("ledger entry is not balanced")
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO ledger_entries (org_id, expense_id, type, reverses_entry_id, memo, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+		RETURNING id`
+
+	err := exec.QueryRow(ctx, query,
+		entry.OrgID,
+		entry.ExpenseID,
+		entry.Type,
+		entry.ReversesEntryID,
+		entry.Memo,
+		entry.CreatedBy,
+		now,
+	).Scan(&entry.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		} else {
+			return false, err
+		}
+	}
+
+	entry.CreatedAt = now
+
+	accountQuery := `
+		INSERT INTO ledger_accounts (org_id, type, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ON CONSTRAINT uq_ledger_accounts_org_id_type_user_id DO UPDATE SET type = EXCLUDED.type
+		RETURNING id`
+	postingQuery := `INSERT INTO ledger_postings (entry_id, account_id, debit, credit) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	for i := range entry.Postings {
+		p := &entry.Postings[i]
+
+		err := exec.QueryRow(ctx, accountQuery, entry.OrgID, p.AccountType, p.UserID, now).Scan(&p.AccountID)
+		if err != nil {
+			return false, err
+		}
+
+		p.EntryID = entry.ID
+		err = exec.QueryRow(ctx, postingQuery, p.EntryID, p.AccountID, p.Debit, p.Credit).Scan(&p.ID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (r *LedgerRepository) FindEntryByID(ctx context.Context, orgID uint64, id uint64) (*entity.LedgerEntry, error) {
+	query := `SELECT ` + ledgerEntryColumns + ` FROM ledger_entries WHERE org_id = $1 AND id = $2 LIMIT 1`
+
+	var e entity.LedgerEntry
+	err := r.db.QueryRow(ctx, query, orgID, id).Scan(&e.ID, &e.OrgID, &e.ExpenseID, &e.Type, &e.ReversesEntryID, &e.Memo, &e.CreatedBy, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	entries := []entity.LedgerEntry{e}
+	err = r.loadPostings(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entries[0], nil
+}
+
+// ListEntries returns the newest entries first with their postings
+func (r *LedgerRepository) ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]entity.LedgerEntry, int, error) {
+	whereClauses := []string{"org_id = $1"}
+	whereArgs := []any{req.OrgID}
+	argCount := 2
+
+	if req.ExpenseID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("expense_id = $%d", argCount))
+		whereArgs = append(whereArgs, *req.ExpenseID)
+		argCount++
+	}
+
+	if req.UserID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf(
+			"id IN (SELECT lp.entry_id FROM ledger_postings AS lp JOIN ledger_accounts AS la ON la.id = lp.account_id WHERE la.user_id = $%d)",
+			argCount,
+		))
+		whereArgs = append(whereArgs, *req.UserID)
+		argCount++
+	}
+
+	whereQuery := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM ledger_entries` + whereQuery
+	err := r.db.QueryRow(ctx, countQuery, whereArgs...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	selectQuery := `SELECT ` + ledgerEntryColumns + ` FROM ledger_entries` + whereQuery + ` ORDER BY id DESC`
+	selectArgs := whereArgs
+	if req.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount, argCount+1)
+		selectArgs = append(selectArgs, req.Limit, req.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, selectQuery, selectArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []entity.LedgerEntry
+	for rows.Next() {
+		var e entity.LedgerEntry
+		err := rows.Scan(&e.ID, &e.OrgID, &e.ExpenseID, &e.Type, &e.ReversesEntryID, &e.Memo, &e.CreatedBy, &e.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, e)
+	}
+	rows.Close()
+
+	err = r.loadPostings(ctx, results)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// loadPostings fills the postings of the entries with one query
+func (r *LedgerRepository) loadPostings(ctx context.Context, entries []entity.LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(entries))
+	index := make(map[uint64]int, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+		index[e.ID] = i
+	}
+
+	query := `
+		SELECT lp.id, lp.entry_id, lp.account_id, la.type, la.user_id, lp.debit, lp.credit
+		FROM ledger_postings AS lp
+		JOIN ledger_accounts AS la ON la.id = lp.account_id
+		WHERE lp.entry_id = ANY($1)
+		ORDER BY lp.id ASC`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p entity.LedgerPosting
+		err := rows.Scan(&p.ID, &p.EntryID, &p.AccountID, &p.AccountType, &p.UserID, &p.Debit, &p.Credit)
+		if err != nil {
+			return err
+		}
+		i := index[p.EntryID]
+		entries[i].Postings = append(entries[i].Postings, p)
+	}
+
+	return nil
+}
+
+// ListBalances returns the payable balance of every employee with a ledger account, ordered by name
+func (r *LedgerRepository) ListBalances(ctx context.Context, orgID uint64, userID *uint64) ([]entity.LedgerBalance, error) {
+	whereClauses := []string{"la.org_id = $1", "la.type = 'employee_payable'"}
+	whereArgs := []any{orgID}
+
+	if userID != nil {
+		whereClauses = append(whereClauses, "la.user_id = $2")
+		whereArgs = append(whereArgs, *userID)
+	}
+
+	query := `
+		SELECT u.id, u.email, u.name, (COALESCE(SUM(lp.credit), 0) - COALESCE(SUM(lp.debit), 0))::BIGINT AS balance
+		FROM ledger_accounts AS la
+		JOIN users AS u ON u.id = la.user_id
+		LEFT JOIN ledger_postings AS lp ON lp.account_id = la.id
+		WHERE ` + strings.Join(whereClauses, " AND ") + `
+		GROUP BY u.id, u.email, u.name
+		ORDER BY u.name ASC, u.id ASC`
+
+	rows, err := r.db.Query(ctx, query, whereArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.LedgerBalance
+	for rows.Next() {
+		var b entity.LedgerBalance
+		err := rows.Scan(&b.User.ID, &b.User.Email, &b.User.Name, &b.Balance)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, b)
+	}
+
+	return results, nil
+}
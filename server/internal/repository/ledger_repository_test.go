@@ -0,0 +1,399 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerRepositorySuite struct {
+	suite.Suite
+	mock           pgxmock.PgxPoolIface
+	repo           *repository.LedgerRepository
+	ctx            context.Context
+	now            time.Time
+	entryColumns   []string
+	postingColumns []string
+	postingQuery   string
+}
+
+func (s *LedgerRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewLedgerRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.entryColumns = []string{"id", "org_id", "expense_id", "type", "reverses_entry_id", "memo", "created_by", "created_at"}
+	s.postingColumns = []string{"id", "entry_id", "account_id", "type", "user_id", "debit", "credit"}
+	s.postingQuery = `SELECT lp.id, lp.entry_id, lp.account_id, la.type, la.user_id, lp.debit, lp.credit FROM ledger_postings AS lp JOIN ledger_accounts AS la ON la.id = lp.account_id WHERE lp.entry_id = ANY($1) ORDER BY lp.id ASC`
+}
+
+func (s *LedgerRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *LedgerRepositorySuite) TestLedgerRepository_PostTx() {
+	entryQuery := `INSERT INTO ledger_entries (org_id, expense_id, type, reverses_entry_id, memo, created_by, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING RETURNING id`
+	accountQuery := `INSERT INTO ledger_accounts (org_id, type, user_id, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT ON CONSTRAINT uq_ledger_accounts_org_id_type_user_id DO UPDATE SET type = EXCLUDED.type RETURNING id`
+	postingQuery := `INSERT INTO ledger_postings (entry_id, account_id, debit, credit) VALUES ($1, $2, $3, $4) RETURNING id`
+	userID := uint64(3)
+	entryArgs := []any{uint64(1), uint64(7), entity.LedgerEntryTypeApproval, (*uint64)(nil), "Expense approved", (*uint64)(nil), pgxmock.AnyArg()}
+
+	tests := []struct {
+		name     string
+		entry    *entity.LedgerEntry
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name:     "error on unbalanced entry",
+			entry:    &entity.LedgerEntry{OrgID: 1, ExpenseID: 7, Postings: []entity.LedgerPosting{{Debit: 50000}}},
+			mockFunc: func(m pgxmock.PgxPoolIface) {},
+			wantRes:  false,
+			wantErr:  errors.New("ledger entry is not balanced"),
+		},
+		{
+			name:  "error on insert entry",
+			entry: entity.NewApprovalLedgerEntry(&entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50000}),
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(entryQuery)).
+					WithArgs(entryArgs...).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name:  "already posted",
+			entry: entity.NewApprovalLedgerEntry(&entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50000}),
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(entryQuery)).
+					WithArgs(entryArgs...).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name:  "error on account",
+			entry: entity.NewApprovalLedgerEntry(&entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50000}),
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(entryQuery)).
+					WithArgs(entryArgs...).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(10)))
+				m.ExpectQuery(regexp.QuoteMeta(accountQuery)).
+					WithArgs(uint64(1), entity.LedgerAccountTypeReimbursementExpense, (*uint64)(nil), pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name:  "success",
+			entry: entity.NewApprovalLedgerEntry(&entity.Expense{ID: 7, OrgID: 1, UserID: userID, Amount: 50000}),
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(entryQuery)).
+					WithArgs(entryArgs...).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(10)))
+				m.ExpectQuery(regexp.QuoteMeta(accountQuery)).
+					WithArgs(uint64(1), entity.LedgerAccountTypeReimbursementExpense, (*uint64)(nil), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+				m.ExpectQuery(regexp.QuoteMeta(postingQuery)).
+					WithArgs(uint64(10), uint64(1), uint64(50000), uint64(0)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(20)))
+				m.ExpectQuery(regexp.QuoteMeta(accountQuery)).
+					WithArgs(uint64(1), entity.LedgerAccountTypeEmployeePayable, &userID, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+				m.ExpectQuery(regexp.QuoteMeta(postingQuery)).
+					WithArgs(uint64(10), uint64(4), uint64(0), uint64(50000)).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(21)))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.PostTx(s.ctx, s.mock, tt.entry)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+			if tt.wantRes {
+				s.Equal(uint64(10), tt.entry.ID)
+				s.Equal(uint64(4), tt.entry.Postings[1].AccountID)
+				s.Equal(uint64(21), tt.entry.Postings[1].ID)
+			}
+		})
+	}
+}
+
+func (s *LedgerRepositorySuite) TestLedgerRepository_FindEntryByID() {
+	query := `SELECT id, org_id, expense_id, type, reverses_entry_id, memo, created_by, created_at FROM ledger_entries WHERE org_id = $1 AND id = $2 LIMIT 1`
+	userID := uint64(3)
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.LedgerEntry
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(10)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(10)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "error on postings",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(10)).
+					WillReturnRows(pgxmock.NewRows(s.entryColumns).
+						AddRow(uint64(10), uint64(1), uint64(7), entity.LedgerEntryTypeApproval, nil, "Expense approved", nil, s.now))
+				m.ExpectQuery(regexp.QuoteMeta(s.postingQuery)).
+					WithArgs([]uint64{10}).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(10)).
+					WillReturnRows(pgxmock.NewRows(s.entryColumns).
+						AddRow(uint64(10), uint64(1), uint64(7), entity.LedgerEntryTypeApproval, nil, "Expense approved", nil, s.now))
+				m.ExpectQuery(regexp.QuoteMeta(s.postingQuery)).
+					WithArgs([]uint64{10}).
+					WillReturnRows(pgxmock.NewRows(s.postingColumns).
+						AddRow(uint64(20), uint64(10), uint64(1), entity.LedgerAccountTypeReimbursementExpense, nil, uint64(50000), uint64(0)).
+						AddRow(uint64(21), uint64(10), uint64(4), entity.LedgerAccountTypeEmployeePayable, &userID, uint64(0), uint64(50000)))
+			},
+			wantRes: &entity.LedgerEntry{
+				ID:        10,
+				OrgID:     1,
+				ExpenseID: 7,
+				Type:      entity.LedgerEntryTypeApproval,
+				Memo:      "Expense approved",
+				CreatedAt: s.now,
+				Postings: []entity.LedgerPosting{
+					{ID: 20, EntryID: 10, AccountID: 1, AccountType: entity.LedgerAccountTypeReimbursementExpense, Debit: 50000},
+					{ID: 21, EntryID: 10, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 50000},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindEntryByID(s.ctx, uint64(1), uint64(10))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *LedgerRepositorySuite) TestLedgerRepository_ListEntries() {
+	countQuery := `SELECT COUNT(*) FROM ledger_entries WHERE org_id = $1 AND expense_id = $2 AND id IN (SELECT lp.entry_id FROM ledger_postings AS lp JOIN ledger_accounts AS la ON la.id = lp.account_id WHERE la.user_id = $3)`
+	selectQuery := `SELECT id, org_id, expense_id, type, reverses_entry_id, memo, created_by, created_at FROM ledger_entries WHERE org_id = $1 AND expense_id = $2 AND id IN (SELECT lp.entry_id FROM ledger_postings AS lp JOIN ledger_accounts AS la ON la.id = lp.account_id WHERE la.user_id = $3) ORDER BY id DESC LIMIT $4 OFFSET $5`
+	expenseID := uint64(7)
+	userID := uint64(3)
+	createdBy := uint64(2)
+	reversedID := uint64(10)
+	req := &model.ListLedgerEntryRequest{OrgID: 1, ExpenseID: &expenseID, UserID: &userID, Limit: 10, Offset: 0}
+
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		wantRes   []entity.LedgerEntry
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name: "error on count",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), expenseID, userID).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
+		{
+			name: "empty",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), expenseID, userID).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   nil,
+		},
+		{
+			name: "error on select",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), expenseID, userID).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(uint64(1), expenseID, userID, 10, 0).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes:   nil,
+			wantTotal: 0,
+			wantErr:   errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), expenseID, userID).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(uint64(1), expenseID, userID, 10, 0).
+					WillReturnRows(pgxmock.NewRows(s.entryColumns).
+						AddRow(uint64(11), uint64(1), uint64(7), entity.LedgerEntryTypeReversal, &reversedID, "Approved by mistake", &createdBy, s.now).
+						AddRow(uint64(10), uint64(1), uint64(7), entity.LedgerEntryTypeApproval, nil, "Expense approved", nil, s.now))
+				m.ExpectQuery(regexp.QuoteMeta(s.postingQuery)).
+					WithArgs([]uint64{11, 10}).
+					WillReturnRows(pgxmock.NewRows(s.postingColumns).
+						AddRow(uint64(20), uint64(10), uint64(1), entity.LedgerAccountTypeReimbursementExpense, nil, uint64(50000), uint64(0)).
+						AddRow(uint64(21), uint64(10), uint64(4), entity.LedgerAccountTypeEmployeePayable, &userID, uint64(0), uint64(50000)).
+						AddRow(uint64(22), uint64(11), uint64(1), entity.LedgerAccountTypeReimbursementExpense, nil, uint64(0), uint64(50000)).
+						AddRow(uint64(23), uint64(11), uint64(4), entity.LedgerAccountTypeEmployeePayable, &userID, uint64(50000), uint64(0)))
+			},
+			wantRes: []entity.LedgerEntry{
+				{
+					ID: 11, OrgID: 1, ExpenseID: 7, Type: entity.LedgerEntryTypeReversal, ReversesEntryID: &reversedID,
+					Memo: "Approved by mistake", CreatedBy: &createdBy, CreatedAt: s.now,
+					Postings: []entity.LedgerPosting{
+						{ID: 22, EntryID: 11, AccountID: 1, AccountType: entity.LedgerAccountTypeReimbursementExpense, Credit: 50000},
+						{ID: 23, EntryID: 11, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Debit: 50000},
+					},
+				},
+				{
+					ID: 10, OrgID: 1, ExpenseID: 7, Type: entity.LedgerEntryTypeApproval, Memo: "Expense approved", CreatedAt: s.now,
+					Postings: []entity.LedgerPosting{
+						{ID: 20, EntryID: 10, AccountID: 1, AccountType: entity.LedgerAccountTypeReimbursementExpense, Debit: 50000},
+						{ID: 21, EntryID: 10, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &userID, Credit: 50000},
+					},
+				},
+			},
+			wantTotal: 2,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, total, err := s.repo.ListEntries(s.ctx, req)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *LedgerRepositorySuite) TestLedgerRepository_ListBalances() {
+	query := `SELECT u.id, u.email, u.name, (COALESCE(SUM(lp.credit), 0) - COALESCE(SUM(lp.debit), 0))::BIGINT AS balance FROM ledger_accounts AS la JOIN users AS u ON u.id = la.user_id LEFT JOIN ledger_postings AS lp ON lp.account_id = la.id WHERE la.org_id = $1 AND la.type = 'employee_payable'`
+	columns := []string{"id", "email", "name", "balance"}
+	userID := uint64(3)
+
+	tests := []struct {
+		name     string
+		userID   *uint64
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.LedgerBalance
+		wantErr  error
+	}{
+		{
+			name:   "error",
+			userID: nil,
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query + ` GROUP BY`)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name:   "success",
+			userID: nil,
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query + ` GROUP BY`)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(3), "john@mail.com", "John Doe", int64(50000)).
+						AddRow(uint64(4), "jane@mail.com", "Jane Doe", int64(0)))
+			},
+			wantRes: []entity.LedgerBalance{
+				{User: entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"}, Balance: 50000},
+				{User: entity.UserSimple{ID: 4, Email: "jane@mail.com", Name: "Jane Doe"}, Balance: 0},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "success with user",
+			userID: &userID,
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query+` AND la.user_id = $2 GROUP BY`)).
+					WithArgs(uint64(1), userID).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(3), "john@mail.com", "John Doe", int64(50000)))
+			},
+			wantRes: []entity.LedgerBalance{
+				{User: entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"}, Balance: 50000},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListBalances(s.ctx, uint64(1), tt.userID)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestLedgerRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LedgerRepositorySuite))
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type OrganizationRepository struct {
+	db db.PgxIface
+}
+
+func NewOrganizationRepository(db db.PgxIface) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (r *OrganizationRepository) FindByID(ctx context.Context, id uint64) (*entity.Organization, error) {
+	query := `
+		SELECT id, name, min_expense_amount, max_expense_amount, approval_threshold_amount, created_at, updated_at
+		FROM organizations WHERE id = $1 LIMIT 1`
+
+	var o entity.Organization
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&o.ID, &o.Name, &o.MinExpenseAmount, &o.MaxExpenseAmount, &o.ApprovalThresholdAmount,
+		&o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &o, nil
+}
+
+// Update saves the name and the expense policy of the organization
+func (r *OrganizationRepository) Update(ctx context.Context, organization *entity.Organization) error {
+	now := time.Now()
+	query := `
+		UPDATE organizations SET name = $1, min_expense_amount = $2, max_expense_amount = $3,
+		approval_threshold_amount = $4, updated_at = $5
+		WHERE id = $6`
+
+	_, err := r.db.Exec(ctx, query,
+		organization.Name,
+		organization.MinExpenseAmount,
+		organization.MaxExpenseAmount,
+		organization.ApprovalThresholdAmount,
+		now,
+		organization.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	organization.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrganizationRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.OrganizationRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *OrganizationRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewOrganizationRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *OrganizationRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *OrganizationRepositorySuite) TestOrganizationRepository_FindByID() {
+	query := `SELECT id, name, min_expense_amount, max_expense_amount, approval_threshold_amount, created_at, updated_at ` +
+		`FROM organizations WHERE id = $1 LIMIT 1`
+	columns := []string{
+		"id", "name", "min_expense_amount", "max_expense_amount", "approval_threshold_amount", "created_at", "updated_at",
+	}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.Organization
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2)).
+					WillReturnRows(pgxmock.NewRows(columns).
+						AddRow(uint64(2), "Subsidiary", uint64(5000), uint64(20000000), uint64(500000), s.now, s.now))
+			},
+			wantRes: &entity.Organization{
+				ID:                      2,
+				Name:                    "Subsidiary",
+				MinExpenseAmount:        5000,
+				MaxExpenseAmount:        20000000,
+				ApprovalThresholdAmount: 500000,
+				CreatedAt:               s.now,
+				UpdatedAt:               s.now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByID(s.ctx, 2)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *OrganizationRepositorySuite) TestOrganizationRepository_Update() {
+	query := `UPDATE organizations SET name = $1, min_expense_amount = $2, max_expense_amount = $3, ` +
+		`approval_threshold_amount = $4, updated_at = $5 WHERE id = $6`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("Subsidiary", uint64(5000), uint64(20000000), uint64(500000), pgxmock.AnyArg(), uint64(2)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("Subsidiary", uint64(5000), uint64(20000000), uint64(500000), pgxmock.AnyArg(), uint64(2)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Update(s.ctx, &entity.Organization{
+				ID:                      2,
+				Name:                    "Subsidiary",
+				MinExpenseAmount:        5000,
+				MaxExpenseAmount:        20000000,
+				ApprovalThresholdAmount: 500000,
+			})
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestOrganizationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(OrganizationRepositorySuite))
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type PaymentVoucherRepository struct {
+	db db.PgxIface
+}
+
+func NewPaymentVoucherRepository(db db.PgxIface) *PaymentVoucherRepository {
+	return &PaymentVoucherRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentVoucherRepository) CreateTx(ctx context.Context, exec db.Executor, voucher *entity.PaymentVoucher) error {
+	now := time.Now()
+	query := `
+		INSERT INTO payment_vouchers (org_id, expense_id, partner_transaction_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	err := exec.QueryRow(ctx, query,
+		voucher.OrgID,
+		voucher.ExpenseID,
+		voucher.PartnerTransactionID,
+		now,
+	).Scan(&voucher.ID)
+	if err != nil {
+		return err
+	}
+
+	voucher.CreatedAt = now
+
+	return nil
+}
+
+func (r *PaymentVoucherRepository) FindByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) (*entity.PaymentVoucher, error) {
+	query := `
+		SELECT id, org_id, expense_id, partner_transaction_id, file_key, created_at, generated_at
+		FROM payment_vouchers WHERE org_id = $1 AND expense_id = $2 LIMIT 1`
+
+	var v entity.PaymentVoucher
+	err := r.db.QueryRow(ctx, query, orgID, expenseID).Scan(
+		&v.ID, &v.OrgID, &v.ExpenseID, &v.PartnerTransactionID, &v.FileKey, &v.CreatedAt, &v.GeneratedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &v, nil
+}
+
+func (r *PaymentVoucherRepository) SetFile(ctx context.Context, id uint64, fileKey string, generatedAt time.Time) error {
+	query := `UPDATE payment_vouchers SET file_key = $1, generated_at = $2 WHERE id = $3`
+
+	_, err := r.db.Exec(ctx, query, fileKey, generatedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaymentVoucherRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.PaymentVoucherRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *PaymentVoucherRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewPaymentVoucherRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *PaymentVoucherRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *PaymentVoucherRepositorySuite) TestPaymentVoucherRepository_CreateTx() {
+	query := `INSERT INTO payment_vouchers (org_id, expense_id, partner_transaction_id, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), "trx-123", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7), "trx-123", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			voucher := &entity.PaymentVoucher{OrgID: 1, ExpenseID: 7, PartnerTransactionID: "trx-123"}
+			err := s.repo.CreateTx(s.ctx, s.mock, voucher)
+
+			s.Equal(tt.wantID, voucher.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PaymentVoucherRepositorySuite) TestPaymentVoucherRepository_FindByExpenseID() {
+	query := `SELECT id, org_id, expense_id, partner_transaction_id, file_key, created_at, generated_at FROM payment_vouchers WHERE org_id = $1 AND expense_id = $2 LIMIT 1`
+	columns := []string{"id", "org_id", "expense_id", "partner_transaction_id", "file_key", "created_at", "generated_at"}
+	fileKey := "vouchers/1/7.pdf"
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.PaymentVoucher
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(7)).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(
+						uint64(3), uint64(1), uint64(7), "trx-123", &fileKey, s.now, &s.now,
+					))
+			},
+			wantRes: &entity.PaymentVoucher{
+				ID:                   3,
+				OrgID:                1,
+				ExpenseID:            7,
+				PartnerTransactionID: "trx-123",
+				FileKey:              &fileKey,
+				CreatedAt:            s.now,
+				GeneratedAt:          &s.now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByExpenseID(s.ctx, 1, 7)
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PaymentVoucherRepositorySuite) TestPaymentVoucherRepository_SetFile() {
+	query := `UPDATE payment_vouchers SET file_key = $1, generated_at = $2 WHERE id = $3`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf", s.now, uint64(3)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf", s.now, uint64(3)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.SetFile(s.ctx, 3, "vouchers/1/7.pdf", s.now)
+
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestPaymentVoucherRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PaymentVoucherRepositorySuite))
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type PersonalAccessTokenRepository struct {
+	db db.PgxIface
+}
+
+func NewPersonalAccessTokenRepository(db db.PgxIface) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PersonalAccessTokenRepository) Create(ctx context.Context, token *entity.PersonalAccessToken) error {
+	now := time.Now()
+	query := `
+		INSERT INTO personal_access_tokens (org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	err := r.db.QueryRow(
+		ctx,
+		query,
+		token.OrgID,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		token.TokenPrefix,
+		token.Scopes,
+		token.ExpiresAt,
+		now,
+	).Scan(&token.ID)
+	if err != nil {
+		return err
+	}
+
+	token.CreatedAt = now
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error) {
+	query := `
+		SELECT id, org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM personal_access_tokens
+		WHERE token_hash = $1 LIMIT 1`
+
+	var t entity.PersonalAccessToken
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.OrgID, &t.UserID, &t.Name, &t.TokenHash, &t.TokenPrefix, &t.Scopes,
+		&t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// ListByUserID returns the tokens that are not revoked yet, expired ones are kept so the owner can see them
+func (r *PersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uint64) ([]entity.PersonalAccessToken, error) {
+	query := `
+		SELECT id, org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY id DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.PersonalAccessToken
+	for rows.Next() {
+		var t entity.PersonalAccessToken
+		err := rows.Scan(
+			&t.ID, &t.OrgID, &t.UserID, &t.Name, &t.TokenHash, &t.TokenPrefix, &t.Scopes,
+			&t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt, &t.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+
+	return results, nil
+}
+
+// Revoke reports false when the token doesn't exist, belongs to another user or is already revoked
+func (r *PersonalAccessTokenRepository) Revoke(ctx context.Context, id uint64, userID uint64, revokedAt time.Time) (bool, error) {
+	query := `UPDATE personal_access_tokens SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, revokedAt, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RevokeByUserID revokes every active token of the user, used when the credentials or the role
+// of the user change so the tokens don't outlive the session revoke in Redis
+func (r *PersonalAccessTokenRepository) RevokeByUserID(ctx context.Context, userID uint64, revokedAt time.Time) error {
+	query := `UPDATE personal_access_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, revokedAt, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id uint64, lastUsedAt time.Time) error {
+	query := `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`
+
+	_, err := r.db.Exec(ctx, query, lastUsedAt, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonalAccessTokenRepositorySuite struct {
+	suite.Suite
+	mock    pgxmock.PgxPoolIface
+	repo    *repository.PersonalAccessTokenRepository
+	ctx     context.Context
+	now     time.Time
+	columns []string
+}
+
+func (s *PersonalAccessTokenRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewPersonalAccessTokenRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+	s.columns = []string{
+		"id", "org_id", "user_id", "name", "token_hash", "token_prefix", "scopes",
+		"expires_at", "last_used_at", "revoked_at", "created_at",
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_Create() {
+	query := `INSERT INTO personal_access_tokens (org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, created_at) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1), "script", "hash", "ems_AbCd", []string{"expenses:read"}, s.now, pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(7)))
+			},
+			wantID:  7,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1), "script", "hash", "ems_AbCd", []string{"expenses:read"}, s.now, pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			token := &entity.PersonalAccessToken{
+				OrgID:       1,
+				UserID:      1,
+				Name:        "script",
+				TokenHash:   "hash",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   s.now,
+			}
+			err := s.repo.Create(s.ctx, token)
+			s.Equal(tt.wantID, token.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_FindByTokenHash() {
+	query := `SELECT id, org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at ` +
+		`FROM personal_access_tokens WHERE token_hash = $1 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.PersonalAccessToken
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(s.columns).AddRow(
+					uint64(1), uint64(1), uint64(1), "script", "hash", "ems_AbCd", []string{"expenses:read"},
+					s.now, nil, nil, s.now,
+				)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("hash").
+					WillReturnRows(rows)
+			},
+			wantRes: &entity.PersonalAccessToken{
+				ID:          1,
+				OrgID:       1,
+				UserID:      1,
+				Name:        "script",
+				TokenHash:   "hash",
+				TokenPrefix: "ems_AbCd",
+				Scopes:      []string{"expenses:read"},
+				ExpiresAt:   s.now,
+				CreatedAt:   s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("hash").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("hash").
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByTokenHash(s.ctx, "hash")
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_ListByUserID() {
+	query := `SELECT id, org_id, user_id, name, token_hash, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at ` +
+		`FROM personal_access_tokens WHERE user_id = $1 AND revoked_at IS NULL ORDER BY id DESC`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.PersonalAccessToken
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(s.columns).
+					AddRow(uint64(2), uint64(1), uint64(1), "second", "hash2", "ems_EfGh", []string{"expenses:write"},
+						s.now, &s.now, nil, s.now).
+					AddRow(uint64(1), uint64(1), uint64(1), "first", "hash1", "ems_AbCd", []string{"expenses:read"},
+						s.now, nil, nil, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnRows(rows)
+			},
+			wantRes: []entity.PersonalAccessToken{
+				{
+					ID: 2, OrgID: 1, UserID: 1, Name: "second", TokenHash: "hash2", TokenPrefix: "ems_EfGh",
+					Scopes: []string{"expenses:write"}, ExpiresAt: s.now, LastUsedAt: &s.now, CreatedAt: s.now,
+				},
+				{
+					ID: 1, OrgID: 1, UserID: 1, Name: "first", TokenHash: "hash1", TokenPrefix: "ems_AbCd",
+					Scopes: []string{"expenses:read"}, ExpiresAt: s.now, CreatedAt: s.now,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByUserID(s.ctx, uint64(1))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_Revoke() {
+	query := `UPDATE personal_access_tokens SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(2), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(2), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(2), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.Revoke(s.ctx, uint64(2), uint64(1), s.now)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_RevokeByUserID() {
+	query := `UPDATE personal_access_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.RevokeByUserID(s.ctx, uint64(1), s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *PersonalAccessTokenRepositorySuite) TestPersonalAccessTokenRepository_UpdateLastUsedAt() {
+	query := `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateLastUsedAt(s.ctx, uint64(1), s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestPersonalAccessTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenRepositorySuite))
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type UserIdentityRepository struct {
+	db db.PgxIface
+}
+
+func NewUserIdentityRepository(db db.PgxIface) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		db: db,
+	}
+}
+
+func (r *UserIdentityRepository) CreateTx(ctx context.Context, exec db.Executor, identity *entity.UserIdentity) error {
+	now := time.Now()
+	query := `
+		INSERT INTO user_identities (org_id, user_id, issuer, subject, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	err := exec.QueryRow(ctx, query, identity.OrgID, identity.UserID, identity.Issuer, identity.Subject, now).Scan(&identity.ID)
+	if err != nil {
+		return err
+	}
+
+	identity.CreatedAt = now
+
+	return nil
+}
+
+func (r *UserIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer string,
+	subject string) (*entity.UserIdentity, error) {
+	query := `
+		SELECT id, org_id, user_id, issuer, subject, created_at
+		FROM user_identities
+		WHERE issuer = $1 AND subject = $2 LIMIT 1`
+
+	var i entity.UserIdentity
+	err := r.db.QueryRow(ctx, query, issuer, subject).Scan(&i.ID, &i.OrgID, &i.UserID, &i.Issuer, &i.Subject, &i.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &i, nil
+}
@@ -0,0 +1,148 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserIdentityRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.UserIdentityRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *UserIdentityRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewUserIdentityRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *UserIdentityRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_CreateTx() {
+	query := `INSERT INTO user_identities (org_id, user_id, issuer, subject, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantID   uint64
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1), "https://idp.example.com", "sub-1", pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+			},
+			wantID:  3,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1), "https://idp.example.com", "sub-1", pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantID:  0,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			identity := &entity.UserIdentity{
+				OrgID:   1,
+				UserID:  1,
+				Issuer:  "https://idp.example.com",
+				Subject: "sub-1",
+			}
+			err := s.repo.CreateTx(s.ctx, s.mock, identity)
+			s.Equal(tt.wantID, identity.ID)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserIdentityRepositorySuite) TestUserIdentityRepository_FindByIssuerAndSubject() {
+	query := `SELECT id, org_id, user_id, issuer, subject, created_at FROM user_identities ` +
+		`WHERE issuer = $1 AND subject = $2 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.UserIdentity
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"id", "org_id", "user_id", "issuer", "subject", "created_at"}).
+					AddRow(uint64(3), uint64(1), uint64(1), "https://idp.example.com", "sub-1", s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("https://idp.example.com", "sub-1").
+					WillReturnRows(rows)
+			},
+			wantRes: &entity.UserIdentity{
+				ID:        3,
+				OrgID:     1,
+				UserID:    1,
+				Issuer:    "https://idp.example.com",
+				Subject:   "sub-1",
+				CreatedAt: s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("https://idp.example.com", "sub-1").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("https://idp.example.com", "sub-1").
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByIssuerAndSubject(s.ctx, "https://idp.example.com", "sub-1")
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestUserIdentityRepositorySuite(t *testing.T) {
+	suite.Run(t, new(UserIdentityRepositorySuite))
+}
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"expense-management-system/internal/db"
 	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
+const userColumns = `id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at`
+
 type UserRepository struct {
 	db db.PgxIface
 }
@@ -21,27 +26,41 @@ func NewUserRepository(db db.PgxIface) *UserRepository {
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	return r.CreateTx(ctx, r.db, user)
+}
+
+func (r *UserRepository) CreateTx(ctx context.Context, exec db.Executor, user *entity.User) error {
 	now := time.Now()
 	query := `
-		INSERT INTO users (email, name, password_hash, role, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (org_id, email, name, password_hash, role, active, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
 		RETURNING id`
 
-	err := r.db.QueryRow(ctx, query, user.Email, user.Name, user.PasswordHash, user.Role, now).Scan(&user.ID)
+	err := exec.QueryRow(ctx, query,
+		user.OrgID,
+		user.Email,
+		user.Name,
+		user.PasswordHash,
+		user.Role,
+		user.Active,
+		user.ExternalID,
+		now,
+	).Scan(&user.ID)
 	if err != nil {
 		return err
 	}
 
 	user.CreatedAt = now
+	user.UpdatedAt = now
 
 	return nil
 }
 
-func (r *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User, error) {
-	query := `SELECT id, email, name, password_hash, role, created_at FROM users WHERE id = $1 LIMIT 1`
+// FindByID only finds the users of the organization
+func (r *UserRepository) FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE org_id = $1 AND id = $2 LIMIT 1`
 
-	var u entity.User
-	err := r.db.QueryRow(ctx, query, id).Scan(&u.ID, &u.Email, &u.Name, &u.PasswordHash, &u.Role, &u.CreatedAt)
+	u, err := scanUser(r.db.QueryRow(ctx, query, orgID, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -50,14 +69,35 @@ func (r *UserRepository) FindByID(ctx context.Context, id uint64) (*entity.User,
 		}
 	}
 
-	return &u, nil
+	return u, nil
+}
+
+// ListByOrgIDAndIDs returns the users of the organization among the ids, ordered by id
+func (r *UserRepository) ListByOrgIDAndIDs(ctx context.Context, orgID uint64, ids []uint64) ([]entity.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE org_id = $1 AND id = ANY($2) ORDER BY id ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []entity.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *u)
+	}
+
+	return results, nil
 }
 
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	query := `SELECT id, email, name, password_hash, role, created_at FROM users WHERE email = $1 LIMIT 1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1 LIMIT 1`
 
-	var u entity.User
-	err := r.db.QueryRow(ctx, query, email).Scan(&u.ID, &u.Email, &u.Name, &u.PasswordHash, &u.Role, &u.CreatedAt)
+	u, err := scanUser(r.db.QueryRow(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -66,7 +106,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity
 		}
 	}
 
-	return &u, nil
+	return u, nil
 }
 
 func (r *UserRepository) CountByEmail(ctx context.Context, email string) (int, error) {
@@ -80,3 +120,152 @@ func (r *UserRepository) CountByEmail(ctx context.Context, email string) (int, e
 
 	return count, nil
 }
+
+func (r *UserRepository) List(ctx context.Context, req *model.ListUserRequest) ([]entity.User, int, error) {
+	whereClauses := []string{"org_id = $1"}
+	whereArgs := []any{req.OrgID}
+	argCount := 2
+
+	if req.Search != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("(name ILIKE $%d OR email ILIKE $%d)", argCount, argCount))
+		whereArgs = append(whereArgs, "%"+escapeLike(*req.Search)+"%")
+		argCount++
+	}
+
+	if req.Email != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("email = $%d", argCount))
+		whereArgs = append(whereArgs, *req.Email)
+		argCount++
+	}
+
+	if req.ExternalID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("external_id = $%d", argCount))
+		whereArgs = append(whereArgs, *req.ExternalID)
+		argCount++
+	}
+
+	if req.Role != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("role = $%d", argCount))
+		whereArgs = append(whereArgs, *req.Role)
+		argCount++
+	}
+
+	if req.DepartmentID != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("department_id = $%d", argCount))
+		whereArgs = append(whereArgs, *req.DepartmentID)
+		argCount++
+	}
+
+	if req.Active != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("active = $%d", argCount))
+		whereArgs = append(whereArgs, *req.Active)
+		argCount++
+	}
+
+	whereQuery := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users` + whereQuery
+	err := r.db.QueryRow(ctx, countQuery, whereArgs...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 || req.CountOnly {
+		return nil, total, nil
+	}
+
+	selectQuery := `SELECT ` + userColumns + ` FROM users` + whereQuery + ` ORDER BY id ASC`
+	selectArgs := whereArgs
+	if req.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount, argCount+1)
+		selectArgs = append(selectArgs, req.Limit, req.Offset)
+	}
+
+	rows, err := r.db.Query(ctx, selectQuery, selectArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []entity.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, *u)
+	}
+
+	return results, total, nil
+}
+
+// Update saves the profile, role, department and active flag, the password has its own UpdatePassword
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	now := time.Now()
+	query := `
+		UPDATE users SET email = $1, name = $2, role = $3, active = $4, external_id = $5, department_id = $6,
+		updated_at = $7
+		WHERE id = $8 AND org_id = $9`
+
+	_, err := r.db.Exec(ctx, query,
+		user.Email,
+		user.Name,
+		user.Role,
+		user.Active,
+		user.ExternalID,
+		user.DepartmentID,
+		now,
+		user.ID,
+		user.OrgID,
+	)
+	if err != nil {
+		return err
+	}
+
+	user.UpdatedAt = now
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, orgID uint64, id uint64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1 WHERE org_id = $2 AND id = $3`
+
+	_, err := r.db.Exec(ctx, query, passwordHash, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateLocale(ctx context.Context, orgID uint64, id uint64, locale string) error {
+	query := `UPDATE users SET locale = $1 WHERE org_id = $2 AND id = $3`
+
+	_, err := r.db.Exec(ctx, query, locale, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var u entity.User
+	err := row.Scan(
+		&u.ID, &u.OrgID, &u.Email, &u.Name, &u.PasswordHash, &u.Role,
+		&u.Active, &u.ExternalID, &u.DepartmentID, &u.Locale, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes user input match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
 	"expense-management-system/internal/repository"
 	"regexp"
 	"testing"
@@ -14,6 +15,10 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+var userColumns = []string{
+	"id", "org_id", "email", "name", "password_hash", "role", "active", "external_id", "department_id", "locale", "created_at", "updated_at",
+}
+
 type UserRepositorySuite struct {
 	suite.Suite
 	mock pgxmock.PgxPoolIface
@@ -44,16 +49,18 @@ func (s *UserRepositorySuite) TestUserRepository_Create() {
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO users (email, name, password_hash, role, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					`INSERT INTO users (org_id, email, name, password_hash, role, active, external_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8) RETURNING id`,
 				)).
-					WithArgs("john@mail.com", "John Doe", "password", entity.UserRoleManager, pgxmock.AnyArg()).
+					WithArgs(uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, true, (*string)(nil), pgxmock.AnyArg()).
 					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
 			},
 			param: &entity.User{
+				OrgID:        uint64(1),
 				Email:        "john@mail.com",
 				Name:         "John Doe",
 				PasswordHash: "password",
 				Role:         entity.UserRoleManager,
+				Active:       true,
 			},
 			wantErr: nil,
 		},
@@ -61,16 +68,18 @@ func (s *UserRepositorySuite) TestUserRepository_Create() {
 			name: "unexpected error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO users (email, name, password_hash, role, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					`INSERT INTO users (org_id, email, name, password_hash, role, active, external_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8) RETURNING id`,
 				)).
-					WithArgs("john@mail.com", "John Doe", "password", entity.UserRoleManager, pgxmock.AnyArg()).
+					WithArgs(uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, true, (*string)(nil), pgxmock.AnyArg()).
 					WillReturnError(errors.New("something error"))
 			},
 			param: &entity.User{
+				OrgID:        uint64(1),
 				Email:        "john@mail.com",
 				Name:         "John Doe",
 				PasswordHash: "password",
 				Role:         entity.UserRoleManager,
+				Active:       true,
 			},
 			wantErr: errors.New("something error"),
 		},
@@ -87,58 +96,57 @@ func (s *UserRepositorySuite) TestUserRepository_Create() {
 }
 
 func (s *UserRepositorySuite) TestUserRepository_FindByID() {
+	query := `SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE org_id = $1 AND id = $2 LIMIT 1`
+
 	tests := []struct {
 		name     string
 		mockFunc func(pgxmock.PgxPoolIface)
-		paramID  uint64
+		paramOrg uint64
 		wantUser *entity.User
 		wantErr  error
 	}{
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "email", "name", "password_hash", "role", "created_at"}).
-					AddRow(uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, s.now)
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE id = $1 LIMIT 1`,
-				)).
-					WithArgs(uint64(1)).
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(uint64(1), uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, true, (*string)(nil), (*uint64)(nil), "", s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnRows(rows)
 			},
-			paramID: uint64(1),
+			paramOrg: uint64(1),
 			wantUser: &entity.User{
 				ID:           uint64(1),
+				OrgID:        uint64(1),
 				Email:        "john@mail.com",
 				Name:         "John Doe",
 				PasswordHash: "password",
 				Role:         entity.UserRoleManager,
+				Active:       true,
 				CreatedAt:    s.now,
+				UpdatedAt:    s.now,
 			},
 			wantErr: nil,
 		},
 		{
-			name: "not found",
+			name: "user of another organization",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE id = $1 LIMIT 1`,
-				)).
-					WithArgs(uint64(1)).
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(2), uint64(1)).
 					WillReturnError(pgx.ErrNoRows)
 			},
-			paramID:  uint64(1),
+			paramOrg: uint64(2),
 			wantUser: nil,
 			wantErr:  nil,
 		},
 		{
 			name: "unexpected error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE id = $1 LIMIT 1`,
-				)).
-					WithArgs(uint64(1)).
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), uint64(1)).
 					WillReturnError(errors.New("something error"))
 			},
-			paramID:  uint64(1),
+			paramOrg: uint64(1),
 			wantUser: nil,
 			wantErr:  errors.New("something error"),
 		},
@@ -148,13 +156,70 @@ func (s *UserRepositorySuite) TestUserRepository_FindByID() {
 		s.Run(tt.name, func() {
 			tt.mockFunc(s.mock)
 
-			res, err := s.repo.FindByID(s.ctx, tt.paramID)
+			res, err := s.repo.FindByID(s.ctx, tt.paramOrg, uint64(1))
 			s.Equal(tt.wantUser, res)
 			s.Equal(tt.wantErr, err)
 		})
 	}
 }
 
+func (s *UserRepositorySuite) TestUserRepository_ListByOrgIDAndIDs() {
+	query := `SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE org_id = $1 AND id = ANY($2) ORDER BY id ASC`
+	ids := []uint64{1, 3}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  []entity.User
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), ids).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(uint64(1), uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, true, (*string)(nil), (*uint64)(nil), "", s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), ids).
+					WillReturnRows(rows)
+			},
+			wantRes: []entity.User{
+				{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: "password",
+					Role:         entity.UserRoleManager,
+					Active:       true,
+					CreatedAt:    s.now,
+					UpdatedAt:    s.now,
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.ListByOrgIDAndIDs(s.ctx, 1, ids)
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
 	tests := []struct {
 		name       string
@@ -166,10 +231,10 @@ func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
 		{
 			name: "success",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
-				rows := pgxmock.NewRows([]string{"id", "email", "name", "password_hash", "role", "created_at"}).
-					AddRow(uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, s.now)
+				rows := pgxmock.NewRows(userColumns).
+					AddRow(uint64(1), uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleManager, true, (*string)(nil), (*uint64)(nil), "", s.now, s.now)
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE email = $1 LIMIT 1`,
+					`SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE email = $1 LIMIT 1`,
 				)).
 					WithArgs("john@mail.com").
 					WillReturnRows(rows)
@@ -177,11 +242,14 @@ func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
 			paramEmail: "john@mail.com",
 			wantUser: &entity.User{
 				ID:           uint64(1),
+				OrgID:        uint64(1),
 				Email:        "john@mail.com",
 				Name:         "John Doe",
 				PasswordHash: "password",
 				Role:         entity.UserRoleManager,
+				Active:       true,
 				CreatedAt:    s.now,
+				UpdatedAt:    s.now,
 			},
 			wantErr: nil,
 		},
@@ -189,7 +257,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
 			name: "not found",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE email = $1 LIMIT 1`,
+					`SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE email = $1 LIMIT 1`,
 				)).
 					WithArgs("john@mail.com").
 					WillReturnError(pgx.ErrNoRows)
@@ -202,7 +270,7 @@ func (s *UserRepositorySuite) TestUserRepository_FindByEmail() {
 			name: "unexpected error",
 			mockFunc: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(regexp.QuoteMeta(
-					`SELECT id, email, name, password_hash, role, created_at FROM users WHERE email = $1 LIMIT 1`,
+					`SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE email = $1 LIMIT 1`,
 				)).
 					WithArgs("john@mail.com").
 					WillReturnError(errors.New("something error"))
@@ -278,6 +346,253 @@ func (s *UserRepositorySuite) TestUserRepository_CountByEmail() {
 	}
 }
 
+func (s *UserRepositorySuite) TestUserRepository_UpdatePassword() {
+	query := `UPDATE users SET password_hash = $1 WHERE org_id = $2 AND id = $3`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("new-hash", uint64(1), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("new-hash", uint64(1), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdatePassword(s.ctx, uint64(1), uint64(1), "new-hash")
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_UpdateLocale() {
+	query := `UPDATE users SET locale = $1 WHERE org_id = $2 AND id = $3`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("id", uint64(1), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("id", uint64(1), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.UpdateLocale(s.ctx, uint64(1), uint64(1), "id")
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_List() {
+	countQuery := `SELECT COUNT(*) FROM users WHERE org_id = $1 AND external_id = $2 AND active = $3`
+	selectQuery := `SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE org_id = $1 AND external_id = $2 AND active = $3 ORDER BY id ASC LIMIT $4 OFFSET $5`
+	externalID := "emp-001"
+	active := true
+	search := "50%_off"
+
+	tests := []struct {
+		name      string
+		mockFunc  func(pgxmock.PgxPoolIface)
+		param     *model.ListUserRequest
+		wantUsers []entity.User
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name: "count error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), externalID, active).
+					WillReturnError(errors.New("something error"))
+			},
+			param:   &model.ListUserRequest{OrgID: 1, ExternalID: &externalID, Active: &active, Limit: 10},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "empty",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), externalID, active).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			param:     &model.ListUserRequest{OrgID: 1, ExternalID: &externalID, Active: &active, Limit: 10},
+			wantUsers: nil,
+			wantTotal: 0,
+		},
+		{
+			name: "count only",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), externalID, active).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(4))
+			},
+			param:     &model.ListUserRequest{OrgID: 1, ExternalID: &externalID, Active: &active, CountOnly: true},
+			wantUsers: nil,
+			wantTotal: 4,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(uint64(1), externalID, active).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(uint64(1), externalID, active, 10, 0).
+					WillReturnRows(pgxmock.NewRows(userColumns).
+						AddRow(uint64(1), uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleEmployee, true, &externalID, (*uint64)(nil), "", s.now, s.now))
+			},
+			param: &model.ListUserRequest{OrgID: 1, ExternalID: &externalID, Active: &active, Limit: 10},
+			wantUsers: []entity.User{
+				{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: "password",
+					Role:         entity.UserRoleEmployee,
+					Active:       true,
+					ExternalID:   &externalID,
+					CreatedAt:    s.now,
+					UpdatedAt:    s.now,
+				},
+			},
+			wantTotal: 1,
+		},
+		{
+			name: "search",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users WHERE org_id = $1 AND (name ILIKE $2 OR email ILIKE $2)`)).
+					WithArgs(uint64(1), `%50\%\_off%`).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			param:     &model.ListUserRequest{OrgID: 1, Search: &search, Limit: 10},
+			wantUsers: nil,
+			wantTotal: 0,
+		},
+		{
+			name: "no limit",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users WHERE org_id = $1`)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT id, org_id, email, name, password_hash, role, active, external_id, department_id, locale, created_at, updated_at FROM users WHERE org_id = $1 ORDER BY id ASC`)).
+					WithArgs(uint64(1)).
+					WillReturnRows(pgxmock.NewRows(userColumns).
+						AddRow(uint64(1), uint64(1), "john@mail.com", "John Doe", "password", entity.UserRoleEmployee, true, (*string)(nil), (*uint64)(nil), "", s.now, s.now))
+			},
+			param: &model.ListUserRequest{OrgID: 1},
+			wantUsers: []entity.User{
+				{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: "password",
+					Role:         entity.UserRoleEmployee,
+					Active:       true,
+					CreatedAt:    s.now,
+					UpdatedAt:    s.now,
+				},
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, total, err := s.repo.List(s.ctx, tt.param)
+			s.Equal(tt.wantUsers, res)
+			s.Equal(tt.wantTotal, total)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserRepositorySuite) TestUserRepository_Update() {
+	query := `UPDATE users SET email = $1, name = $2, role = $3, active = $4, external_id = $5, department_id = $6, updated_at = $7 WHERE id = $8 AND org_id = $9`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("john@mail.com", "John Doe", entity.UserRoleManager, false, (*string)(nil), (*uint64)(nil), pgxmock.AnyArg(), uint64(1), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("john@mail.com", "John Doe", entity.UserRoleManager, false, (*string)(nil), (*uint64)(nil), pgxmock.AnyArg(), uint64(1), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Update(s.ctx, &entity.User{
+				ID:    uint64(1),
+				OrgID: uint64(1),
+				Email: "john@mail.com",
+				Name:  "John Doe",
+				Role:  entity.UserRoleManager,
+			})
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
 func TestUserRepositorySuite(t *testing.T) {
 	suite.Run(t, new(UserRepositorySuite))
 }
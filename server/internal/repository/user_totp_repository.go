@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type UserTOTPRepository struct {
+	db db.PgxIface
+}
+
+func NewUserTOTPRepository(db db.PgxIface) *UserTOTPRepository {
+	return &UserTOTPRepository{
+		db: db,
+	}
+}
+
+// Upsert stores a new pending enrollment, replacing any previous one
+func (r *UserTOTPRepository) Upsert(ctx context.Context, totp *entity.UserTOTP) error {
+	now := time.Now()
+	query := `
+		INSERT INTO user_totps (user_id, secret, recovery_codes, enabled_at, created_at)
+		VALUES ($1, $2, $3, NULL, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, recovery_codes = EXCLUDED.recovery_codes, enabled_at = NULL, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Exec(ctx, query, totp.UserID, totp.Secret, totp.RecoveryCodes, now)
+	if err != nil {
+		return err
+	}
+
+	totp.EnabledAt = nil
+	totp.CreatedAt = now
+
+	return nil
+}
+
+func (r *UserTOTPRepository) FindByUserID(ctx context.Context, userID uint64) (*entity.UserTOTP, error) {
+	query := `SELECT user_id, secret, recovery_codes, enabled_at, created_at FROM user_totps WHERE user_id = $1 LIMIT 1`
+
+	var t entity.UserTOTP
+	err := r.db.QueryRow(ctx, query, userID).Scan(&t.UserID, &t.Secret, &t.RecoveryCodes, &t.EnabledAt, &t.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+func (r *UserTOTPRepository) Enable(ctx context.Context, userID uint64, enabledAt time.Time) error {
+	query := `UPDATE user_totps SET enabled_at = $1 WHERE user_id = $2`
+
+	_, err := r.db.Exec(ctx, query, enabledAt, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UseTimeStep reports false when a code of the same or a later time step was already accepted
+func (r *UserTOTPRepository) UseTimeStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	query := `
+		UPDATE user_totps SET last_used_step = $1
+		WHERE user_id = $2 AND (last_used_step IS NULL OR last_used_step < $1)`
+
+	tag, err := r.db.Exec(ctx, query, step, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// UseRecoveryCode removes the code in a single statement and reports false when it
+// isn't there, so two requests spending the same code can't both succeed
+func (r *UserTOTPRepository) UseRecoveryCode(ctx context.Context, userID uint64, hashedCode string) (bool, error) {
+	query := `
+		UPDATE user_totps SET recovery_codes = array_remove(recovery_codes, $1)
+		WHERE user_id = $2 AND $1 = ANY(recovery_codes)`
+
+	tag, err := r.db.Exec(ctx, query, hashedCode, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *UserTOTPRepository) Delete(ctx context.Context, userID uint64) error {
+	query := `DELETE FROM user_totps WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/repository"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserTOTPRepositorySuite struct {
+	suite.Suite
+	mock pgxmock.PgxPoolIface
+	repo *repository.UserTOTPRepository
+	ctx  context.Context
+	now  time.Time
+}
+
+func (s *UserTOTPRepositorySuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.repo = repository.NewUserTOTPRepository(s.mock)
+	s.ctx = context.Background()
+	s.now = time.Now()
+}
+
+func (s *UserTOTPRepositorySuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_Upsert() {
+	upsertQuery := `INSERT INTO user_totps (user_id, secret, recovery_codes, enabled_at, created_at) VALUES ($1, $2, $3, NULL, $4) ` +
+		`ON CONFLICT (user_id) DO UPDATE ` +
+		`SET secret = EXCLUDED.secret, recovery_codes = EXCLUDED.recovery_codes, enabled_at = NULL, created_at = EXCLUDED.created_at`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		param    *entity.UserTOTP
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(upsertQuery)).
+					WithArgs(uint64(1), "SECRET", []string{"hash"}, pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			param: &entity.UserTOTP{
+				UserID:        1,
+				Secret:        "SECRET",
+				RecoveryCodes: []string{"hash"},
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(upsertQuery)).
+					WithArgs(uint64(1), "SECRET", []string{"hash"}, pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			},
+			param: &entity.UserTOTP{
+				UserID:        1,
+				Secret:        "SECRET",
+				RecoveryCodes: []string{"hash"},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Upsert(s.ctx, tt.param)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_FindByUserID() {
+	query := `SELECT user_id, secret, recovery_codes, enabled_at, created_at FROM user_totps WHERE user_id = $1 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *entity.UserTOTP
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				rows := pgxmock.NewRows([]string{"user_id", "secret", "recovery_codes", "enabled_at", "created_at"}).
+					AddRow(uint64(1), "SECRET", []string{"hash"}, &s.now, s.now)
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnRows(rows)
+			},
+			wantRes: &entity.UserTOTP{
+				UserID:        1,
+				Secret:        "SECRET",
+				RecoveryCodes: []string{"hash"},
+				EnabledAt:     &s.now,
+				CreatedAt:     s.now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "unexpected error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.FindByUserID(s.ctx, uint64(1))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_Enable() {
+	query := `UPDATE user_totps SET enabled_at = $1 WHERE user_id = $2`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(s.now, uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Enable(s.ctx, uint64(1), s.now)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_UseTimeStep() {
+	query := `
+		UPDATE user_totps SET last_used_step = $1
+		WHERE user_id = $2 AND (last_used_step IS NULL OR last_used_step < $1)`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(int64(100), uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "already used",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(int64(100), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(int64(100), uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.UseTimeStep(s.ctx, uint64(1), int64(100))
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_UseRecoveryCode() {
+	query := `
+		UPDATE user_totps SET recovery_codes = array_remove(recovery_codes, $1)
+		WHERE user_id = $2 AND $1 = ANY(recovery_codes)`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  bool
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("hash", uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: false,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("hash", uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantRes: false,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("hash", uint64(1)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+			wantRes: true,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.repo.UseRecoveryCode(s.ctx, uint64(1), "hash")
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *UserTOTPRepositorySuite) TestUserTOTPRepository_Delete() {
+	query := `DELETE FROM user_totps WHERE user_id = $1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1)).
+					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.repo.Delete(s.ctx, uint64(1))
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestUserTOTPRepositorySuite(t *testing.T) {
+	suite.Run(t, new(UserTOTPRepositorySuite))
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FileChunkSize is the size of the parts a streamed file is stored in
+const FileChunkSize = 1 << 20
+
+// File is a generated document kept in the file storage
+type File struct {
+	Key         string
+	ContentType string
+	Content     []byte
+}
+
+// FileStream is a file stored with PutStream, its content is loaded one chunk at a time while it's read
+type FileStream struct {
+	Key         string
+	ContentType string
+	Content     io.Reader
+}
+
+//go:generate mockery --name=FileStorage --structname FileStorage --outpkg=mocks --output=./../mocks
+type FileStorage interface {
+	Put(ctx context.Context, file *File) error
+	Get(ctx context.Context, key string) (*File, error)
+	PutStream(ctx context.Context, key string, contentType string, r io.Reader) error
+	Open(ctx context.Context, key string) (*FileStream, error)
+}
+
+// DatabaseFileStorage keeps the files in postgres, so the api and the consumers share them
+// without another service to run
+type DatabaseFileStorage struct {
+	db db.PgxIface
+}
+
+func NewDatabaseFileStorage(db db.PgxIface) *DatabaseFileStorage {
+	return &DatabaseFileStorage{
+		db: db,
+	}
+}
+
+// Put replaces the file stored under the same key
+func (s *DatabaseFileStorage) Put(ctx context.Context, file *File) error {
+	query := `
+		INSERT INTO files (key, content_type, content, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET content_type = EXCLUDED.content_type, content = EXCLUDED.content, created_at = EXCLUDED.created_at`
+
+	_, err := s.db.Exec(ctx, query, file.Key, file.ContentType, file.Content, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get returns nil when there's no file under the key
+func (s *DatabaseFileStorage) Get(ctx context.Context, key string) (*File, error) {
+	query := `SELECT key, content_type, content FROM files WHERE key = $1 LIMIT 1`
+
+	var f File
+	err := s.db.QueryRow(ctx, query, key).Scan(&f.Key, &f.ContentType, &f.Content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	return &f, nil
+}
+
+// PutStream replaces the file stored under the same key with what's read from r, in chunks of
+// FileChunkSize so a large file is never held in memory whole. The file is only visible once it's complete.
+func (s *DatabaseFileStorage) PutStream(ctx context.Context, key string, contentType string, r io.Reader) error {
+	return db.NewTransactioner(s.db).Do(ctx, func(tx db.Executor) error {
+		query := `
+			INSERT INTO files (key, content_type, content, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (key) DO UPDATE SET content_type = EXCLUDED.content_type, content = EXCLUDED.content, created_at = EXCLUDED.created_at`
+
+		_, err := tx.Exec(ctx, query, key, contentType, []byte{}, time.Now())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM file_chunks WHERE key = $1`, key)
+		if err != nil {
+			return err
+		}
+
+		chunk := make([]byte, FileChunkSize)
+		for seq := 0; ; seq++ {
+			n, readErr := io.ReadFull(r, chunk)
+			if n > 0 {
+				_, err = tx.Exec(ctx, `INSERT INTO file_chunks (key, seq, content) VALUES ($1, $2, $3)`, key, seq, chunk[:n])
+				if err != nil {
+					return err
+				}
+			}
+
+			if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			if readErr != nil {
+				return readErr
+			}
+		}
+	})
+}
+
+// Open returns nil when there's no file under the key, the content is read from the chunks
+// stored by PutStream
+func (s *DatabaseFileStorage) Open(ctx context.Context, key string) (*FileStream, error) {
+	query := `SELECT key, content_type FROM files WHERE key = $1 LIMIT 1`
+
+	var f FileStream
+	err := s.db.QueryRow(ctx, query, key).Scan(&f.Key, &f.ContentType)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+
+	f.Content = &chunkReader{ctx: ctx, db: s.db, key: key}
+
+	return &f, nil
+}
+
+// chunkReader loads the next chunk of the file once the current one was read
+type chunkReader struct {
+	ctx   context.Context
+	db    db.PgxIface
+	key   string
+	seq   int
+	chunk []byte
+	done  bool
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		query := `SELECT content FROM file_chunks WHERE key = $1 AND seq = $2`
+
+		err := r.db.QueryRow(r.ctx, query, r.key, r.seq).Scan(&r.chunk)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				r.done = true
+				continue
+			}
+			return 0, err
+		}
+		r.seq++
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+
+	return n, nil
+}
@@ -0,0 +1,302 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/storage"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseFileStorageSuite struct {
+	suite.Suite
+	mock    pgxmock.PgxPoolIface
+	storage *storage.DatabaseFileStorage
+	ctx     context.Context
+}
+
+func (s *DatabaseFileStorageSuite) SetupTest() {
+	s.mock, _ = pgxmock.NewPool()
+	s.storage = storage.NewDatabaseFileStorage(s.mock)
+	s.ctx = context.Background()
+}
+
+func (s *DatabaseFileStorageSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *DatabaseFileStorageSuite) TestDatabaseFileStorage_Put() {
+	query := `INSERT INTO files (key, content_type, content, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (key) DO UPDATE SET content_type = EXCLUDED.content_type, content = EXCLUDED.content, created_at = EXCLUDED.created_at`
+	file := &storage.File{Key: "vouchers/1/7.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf", "application/pdf", []byte("%PDF-1.4"), pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf", "application/pdf", []byte("%PDF-1.4"), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.storage.Put(s.ctx, file)
+
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DatabaseFileStorageSuite) TestDatabaseFileStorage_Get() {
+	query := `SELECT key, content_type, content FROM files WHERE key = $1 LIMIT 1`
+
+	tests := []struct {
+		name     string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantRes  *storage.File
+		wantErr  error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf").
+					WillReturnError(errors.New("something error"))
+			},
+			wantRes: nil,
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantRes: nil,
+			wantErr: nil,
+		},
+		{
+			name: "success",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("vouchers/1/7.pdf").
+					WillReturnRows(pgxmock.NewRows([]string{"key", "content_type", "content"}).
+						AddRow("vouchers/1/7.pdf", "application/pdf", []byte("%PDF-1.4")))
+			},
+			wantRes: &storage.File{Key: "vouchers/1/7.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.storage.Get(s.ctx, "vouchers/1/7.pdf")
+
+			s.Equal(tt.wantRes, res)
+			s.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func (s *DatabaseFileStorageSuite) TestDatabaseFileStorage_PutStream() {
+	fileQuery := `INSERT INTO files (key, content_type, content, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (key) DO UPDATE SET content_type = EXCLUDED.content_type, content = EXCLUDED.content, created_at = EXCLUDED.created_at`
+	deleteQuery := `DELETE FROM file_chunks WHERE key = $1`
+	chunkQuery := `INSERT INTO file_chunks (key, seq, content) VALUES ($1, $2, $3)`
+
+	tests := []struct {
+		name     string
+		content  string
+		mockFunc func(pgxmock.PgxPoolIface)
+		wantErr  string
+	}{
+		{
+			name:    "error on file",
+			content: "Expense ID\n",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv", "text/csv", []byte{}, pgxmock.AnyArg()).
+					WillReturnError(errors.New("something error"))
+				m.ExpectRollback()
+			},
+			wantErr: "something error",
+		},
+		{
+			name:    "error on chunk",
+			content: "Expense ID\n",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv", "text/csv", []byte{}, pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				m.ExpectExec(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 0, []byte("Expense ID\n")).
+					WillReturnError(errors.New("something error"))
+				m.ExpectRollback()
+			},
+			wantErr: "something error",
+		},
+		{
+			name:    "success",
+			content: "Expense ID\n",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv", "text/csv", []byte{}, pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnResult(pgxmock.NewResult("DELETE", 2))
+				m.ExpectExec(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 0, []byte("Expense ID\n")).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "success empty",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv", "text/csv", []byte{}, pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				m.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				m.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			err := s.storage.PutStream(s.ctx, "exports/1/5.csv", "text/csv", strings.NewReader(tt.content))
+
+			if tt.wantErr != "" {
+				s.EqualError(err, tt.wantErr)
+			} else {
+				s.Nil(err)
+			}
+			s.Nil(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *DatabaseFileStorageSuite) TestDatabaseFileStorage_Open() {
+	fileQuery := `SELECT key, content_type FROM files WHERE key = $1 LIMIT 1`
+	chunkQuery := `SELECT content FROM file_chunks WHERE key = $1 AND seq = $2`
+
+	tests := []struct {
+		name        string
+		mockFunc    func(pgxmock.PgxPoolIface)
+		wantFound   bool
+		wantContent string
+		wantErr     error
+		wantReadErr error
+	}{
+		{
+			name: "error",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnError(errors.New("something error"))
+			},
+			wantErr: errors.New("something error"),
+		},
+		{
+			name: "not found",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnError(pgx.ErrNoRows)
+			},
+		},
+		{
+			name: "error on chunk",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnRows(pgxmock.NewRows([]string{"key", "content_type"}).AddRow("exports/1/5.csv", "text/csv"))
+				m.ExpectQuery(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 0).
+					WillReturnError(errors.New("something error"))
+			},
+			wantFound:   true,
+			wantReadErr: errors.New("something error"),
+		},
+		{
+			name: "success reads every chunk",
+			mockFunc: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(regexp.QuoteMeta(fileQuery)).
+					WithArgs("exports/1/5.csv").
+					WillReturnRows(pgxmock.NewRows([]string{"key", "content_type"}).AddRow("exports/1/5.csv", "text/csv"))
+				m.ExpectQuery(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 0).
+					WillReturnRows(pgxmock.NewRows([]string{"content"}).AddRow([]byte("Expense ID\n")))
+				m.ExpectQuery(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 1).
+					WillReturnRows(pgxmock.NewRows([]string{"content"}).AddRow([]byte("7\n")))
+				m.ExpectQuery(regexp.QuoteMeta(chunkQuery)).
+					WithArgs("exports/1/5.csv", 2).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantFound:   true,
+			wantContent: "Expense ID\n7\n",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mockFunc(s.mock)
+
+			res, err := s.storage.Open(s.ctx, "exports/1/5.csv")
+
+			s.Equal(tt.wantErr, err)
+			s.Equal(tt.wantFound, res != nil)
+			if res != nil {
+				s.Equal("text/csv", res.ContentType)
+
+				content, err := io.ReadAll(res.Content)
+				s.Equal(tt.wantReadErr, err)
+				s.Equal(tt.wantContent, string(content))
+			}
+			s.Nil(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDatabaseFileStorageSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseFileStorageSuite))
+}
@@ -0,0 +1,12 @@
+package storage
+
+import (
+	"context"
+
+	redisrate "github.com/go-redis/redis_rate/v10"
+)
+
+//go:generate mockery --name=RateLimiter --structname RateLimiter --outpkg=mocks --output=./../mocks
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit redisrate.Limit) (*redisrate.Result, error)
+}
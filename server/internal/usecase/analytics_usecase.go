@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// analyticsOldestPendingLimit is how many waiting expenses the summary lists
+const analyticsOldestPendingLimit = 5
+
+type analyticsUsecase struct {
+	log                 *zap.Logger
+	analyticsRepository AnalyticsRepository
+}
+
+func NewAnalyticsUsecase(log *zap.Logger, analyticsRepository AnalyticsRepository) AnalyticsUsecase {
+	return &analyticsUsecase{
+		log:                 log,
+		analyticsRepository: analyticsRepository,
+	}
+}
+
+// Summary totals the expenses of the organization per status with the approval and payout turnaround
+// and the expenses waiting the longest for a manager
+func (c *analyticsUsecase) Summary(ctx context.Context, req *model.AnalyticsRequest) (*model.AnalyticsSummaryResponse, error) {
+	if !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return nil, model.ErrForbidden
+	}
+
+	statusReq := *req
+	statusReq.GroupBy = model.AnalyticsGroupByStatus
+	stats, err := c.analyticsRepository.GroupStats(ctx, &statusReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense stats by status = %w", err)
+	}
+
+	turnaround, err := c.analyticsRepository.Turnaround(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense turnaround = %w", err)
+	}
+
+	pending, err := c.analyticsRepository.OldestPending(ctx, req, analyticsOldestPendingLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oldest pending expenses = %w", err)
+	}
+
+	res := &model.AnalyticsSummaryResponse{
+		ByStatus:                make([]model.AnalyticsGroupResponse, len(stats)),
+		AvgTimeToApproveSeconds: turnaround.AvgSecondsToApprove,
+		AvgTimeToPaySeconds:     turnaround.AvgSecondsToPay,
+		OldestPending:           make([]model.AnalyticsPendingResponse, len(pending)),
+	}
+
+	for i := range stats {
+		res.ByStatus[i] = *serializer.ExpenseGroupStatToResponse(&stats[i])
+		res.TotalCount += stats[i].Count
+		res.TotalAmountIDR += stats[i].Amount
+		if stats[i].Key == string(entity.ExpenseStatusAwaitingApproval) {
+			res.PendingCount = stats[i].Count
+		}
+	}
+	res.TotalAmountFormatted = model.FormatRupiah(res.TotalAmountIDR)
+
+	now := time.Now()
+	for i := range pending {
+		res.OldestPending[i] = *serializer.PendingExpenseToResponse(&pending[i], now)
+	}
+
+	return res, nil
+}
+
+// Breakdown counts and sums the expenses of the organization per the requested group
+func (c *analyticsUsecase) Breakdown(ctx context.Context, req *model.AnalyticsRequest) ([]model.AnalyticsGroupResponse, error) {
+	if !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return nil, model.ErrForbidden
+	}
+
+	if _, ok := model.ParseAnalyticsGroupBy(string(req.GroupBy)); !ok {
+		return nil, model.NewInvalidFilterError("group_by")
+	}
+
+	stats, err := c.analyticsRepository.GroupStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense stats by %s = %w", req.GroupBy, err)
+	}
+
+	res := make([]model.AnalyticsGroupResponse, len(stats))
+	for i := range stats {
+		res[i] = *serializer.ExpenseGroupStatToResponse(&stats[i])
+	}
+
+	return res, nil
+}
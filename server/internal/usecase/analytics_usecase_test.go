@@ -0,0 +1,225 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AnalyticsUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type AnalyticsMockFunc func(ar *mocks.AnalyticsRepository)
+
+func (s *AnalyticsUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *AnalyticsUsecaseSuite) newUsecase(mockFunc AnalyticsMockFunc) usecase.AnalyticsUsecase {
+	ar := mocks.NewAnalyticsRepository(s.T())
+	mockFunc(ar)
+
+	return usecase.NewAnalyticsUsecase(s.log, ar)
+}
+
+func (s *AnalyticsUsecaseSuite) TestAnalyticsUsecase_Summary() {
+	request := &model.AnalyticsRequest{OrgID: 1, UserRole: "manager"}
+	byStatus := mock.MatchedBy(func(req *model.AnalyticsRequest) bool {
+		return req.OrgID == 1 && req.GroupBy == model.AnalyticsGroupByStatus
+	})
+	toApprove := int64(3600)
+	toPay := int64(7200)
+	createdAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		request    *model.AnalyticsRequest
+		mockFunc   AnalyticsMockFunc
+		wantRes    *model.AnalyticsSummaryResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not manager",
+			request:    &model.AnalyticsRequest{OrgID: 1, UserRole: "employee"},
+			mockFunc:   func(ar *mocks.AnalyticsRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on group stats",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, byStatus).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to get expense stats by status = something error",
+		},
+		{
+			name:    "error on turnaround",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, byStatus).Return(nil, nil)
+				ar.On("Turnaround", mock.Anything, request).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to get expense turnaround = something error",
+		},
+		{
+			name:    "error on oldest pending",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, byStatus).Return(nil, nil)
+				ar.On("Turnaround", mock.Anything, request).Return(&entity.ExpenseTurnaround{}, nil)
+				ar.On("OldestPending", mock.Anything, request, 5).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to get oldest pending expenses = something error",
+		},
+		{
+			name:    "success without expenses",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, byStatus).Return(nil, nil)
+				ar.On("Turnaround", mock.Anything, request).Return(&entity.ExpenseTurnaround{}, nil)
+				ar.On("OldestPending", mock.Anything, request, 5).Return(nil, nil)
+			},
+			wantRes: &model.AnalyticsSummaryResponse{
+				TotalAmountFormatted: "Rp 0",
+				ByStatus:             []model.AnalyticsGroupResponse{},
+				OldestPending:        []model.AnalyticsPendingResponse{},
+			},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, byStatus).Return([]entity.ExpenseGroupStat{
+					{Key: "completed", Label: "completed", Count: 2, Amount: 150000},
+					{Key: "awaiting_approval", Label: "awaiting_approval", Count: 1, Amount: 2000000},
+				}, nil)
+				ar.On("Turnaround", mock.Anything, request).Return(&entity.ExpenseTurnaround{
+					AvgSecondsToApprove: &toApprove,
+					AvgSecondsToPay:     &toPay,
+				}, nil)
+				ar.On("OldestPending", mock.Anything, request, 5).Return([]entity.ExpenseWithUser{
+					{
+						Expense: entity.Expense{ID: 7, UserID: 3, Amount: 2000000, Description: "Flight", CreatedAt: createdAt},
+						User:    entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+					},
+				}, nil)
+			},
+			wantRes: &model.AnalyticsSummaryResponse{
+				TotalCount:           3,
+				TotalAmountIDR:       2150000,
+				TotalAmountFormatted: "Rp 2.150.000",
+				ByStatus: []model.AnalyticsGroupResponse{
+					{Key: "completed", Label: "completed", Count: 2, AmountIDR: 150000, AmountFormatted: "Rp 150.000"},
+					{Key: "awaiting_approval", Label: "awaiting_approval", Count: 1, AmountIDR: 2000000, AmountFormatted: "Rp 2.000.000"},
+				},
+				AvgTimeToApproveSeconds: &toApprove,
+				AvgTimeToPaySeconds:     &toPay,
+				PendingCount:            1,
+				OldestPending: []model.AnalyticsPendingResponse{
+					{
+						ID:              7,
+						AmountIDR:       2000000,
+						AmountFormatted: "Rp 2.000.000",
+						Description:     "Flight",
+						User:            model.UserSimpleResponse{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+						CreatedAt:       createdAt.UTC().Format(time.RFC3339),
+						WaitingSeconds:  3600,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Summary(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				for i := range res.OldestPending {
+					// the waiting time keeps growing while the test runs
+					s.InDelta(tt.wantRes.OldestPending[i].WaitingSeconds, res.OldestPending[i].WaitingSeconds, 5)
+					res.OldestPending[i].WaitingSeconds = tt.wantRes.OldestPending[i].WaitingSeconds
+				}
+				s.Equal(tt.wantRes, res)
+			}
+		})
+	}
+}
+
+func (s *AnalyticsUsecaseSuite) TestAnalyticsUsecase_Breakdown() {
+	request := &model.AnalyticsRequest{OrgID: 1, UserRole: "admin", GroupBy: model.AnalyticsGroupByCostCenter}
+
+	tests := []struct {
+		name       string
+		request    *model.AnalyticsRequest
+		mockFunc   AnalyticsMockFunc
+		wantRes    []model.AnalyticsGroupResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not manager",
+			request:    &model.AnalyticsRequest{OrgID: 1, UserRole: "employee", GroupBy: model.AnalyticsGroupByStatus},
+			mockFunc:   func(ar *mocks.AnalyticsRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:       "error on unknown group",
+			request:    &model.AnalyticsRequest{OrgID: 1, UserRole: "manager", GroupBy: "category"},
+			mockFunc:   func(ar *mocks.AnalyticsRepository) {},
+			wantErrMsg: "Invalid value for the 'group_by' filter",
+		},
+		{
+			name:    "error on group stats",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, request).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to get expense stats by cost_center = something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(ar *mocks.AnalyticsRepository) {
+				ar.On("GroupStats", mock.Anything, request).Return([]entity.ExpenseGroupStat{
+					{Key: "CC-100", Label: "CC-100", Count: 2, Amount: 150000},
+				}, nil)
+			},
+			wantRes: []model.AnalyticsGroupResponse{
+				{Key: "CC-100", Label: "CC-100", Count: 2, AmountIDR: 150000, AmountFormatted: "Rp 150.000"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Breakdown(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRes, res)
+			}
+		})
+	}
+}
+
+func TestAnalyticsUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsUsecaseSuite))
+}
@@ -14,21 +14,31 @@ import (
 )
 
 type approvalUsecase struct {
-	log                     *zap.Logger
-	tx                      db.Transactioner
-	approvalRepository      ApprovalRepository
-	expenseRepository       ExpenseRepository
-	expenseApprovedProducer *messaging.ExpenseApprovedProducer
+	log                        *zap.Logger
+	tx                         db.Transactioner
+	approvalRepository         ApprovalRepository
+	expenseRepository          ExpenseRepository
+	departmentBudgetRepository DepartmentBudgetRepository
+	ledgerRepository           LedgerRepository
+	reviewRoundRepository      ExpenseReviewRoundRepository
+	expenseApprovedProducer    *messaging.ExpenseApprovedProducer
+	budgetPolicy               entity.BudgetPolicy // what happens to approvals over the remaining budget
 }
 
 func NewApprovalUsecase(log *zap.Logger, tx db.Transactioner, approvalRepository ApprovalRepository,
-	expenseRepository ExpenseRepository, expenseApprovedProducer *messaging.ExpenseApprovedProducer) ApprovalUsecase {
+	expenseRepository ExpenseRepository, departmentBudgetRepository DepartmentBudgetRepository, ledgerRepository LedgerRepository,
+	reviewRoundRepository ExpenseReviewRoundRepository, expenseApprovedProducer *messaging.ExpenseApprovedProducer,
+	budgetPolicy entity.BudgetPolicy) ApprovalUsecase {
 	return &approvalUsecase{
-		log:                     log,
-		tx:                      tx,
-		approvalRepository:      approvalRepository,
-		expenseRepository:       expenseRepository,
-		expenseApprovedProducer: expenseApprovedProducer,
+		log:                        log,
+		tx:                         tx,
+		approvalRepository:         approvalRepository,
+		expenseRepository:          expenseRepository,
+		departmentBudgetRepository: departmentBudgetRepository,
+		ledgerRepository:           ledgerRepository,
+		reviewRoundRepository:      reviewRoundRepository,
+		expenseApprovedProducer:    expenseApprovedProducer,
+		budgetPolicy:               budgetPolicy,
 	}
 }
 
@@ -41,7 +51,7 @@ func (c *approvalUsecase) Reject(ctx context.Context, req *model.ApprovalExpense
 }
 
 func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.ApprovalExpenseRequest, approvalStatus entity.ApprovalStatus) error {
-	if req.UserRole != string(entity.UserRoleManager) {
+	if !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
 		return model.ErrForbidden
 	}
 
@@ -51,22 +61,16 @@ func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.Approva
 	)
 
 	err := c.tx.Do(ctx, func(exec db.Executor) error {
-		expense, txErr := c.expenseRepository.FindByIDWithLock(ctx, exec, req.ID)
+		expense, txErr := c.findPendingExpenseWithLock(ctx, exec, req.OrgID, req.ID, req.UserID, req.Version)
 		if txErr != nil {
-			return fmt.Errorf("failed to find expense by id (%d) with lock = %w", req.ID, txErr)
+			return txErr
 		}
 
-		if expense == nil {
-			return model.ErrExpenseNotFound
-		}
-		if expense.UserID == req.UserID {
-			return model.ErrForbidden
-		}
-		if expense.Status != entity.ExpenseStatusAwaitingApproval {
-			return model.ErrExpenseAlreadyProcessed
-		}
-		if !expense.RequiresApproval() {
-			return model.ErrExpenseNotRequireApproval
+		if approvalStatus == entity.ApprovalStatusApproved {
+			txErr = c.checkBudget(ctx, exec, expense, req.UserRole)
+			if txErr != nil {
+				return txErr
+			}
 		}
 
 		idemKey = expense.GetKey()
@@ -79,6 +83,7 @@ func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.Approva
 		}
 
 		approval := &entity.Approval{
+			OrgID:      req.OrgID,
 			ExpenseID:  req.ID,
 			ApproverID: req.UserID,
 			Status:     approvalStatus,
@@ -97,11 +102,18 @@ func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.Approva
 			expenseStatus = entity.ExpenseStatusRejected
 		}
 
-		txErr = c.expenseRepository.UpdateStatusByIDTx(ctx, exec, req.ID, expenseStatus)
+		txErr = c.expenseRepository.UpdateStatusByIDTx(ctx, exec, req.OrgID, req.ID, expenseStatus)
 		if txErr != nil {
 			return fmt.Errorf("failed to to update expense for id (%d) = %w", req.ID, txErr)
 		}
 
+		if approvalStatus == entity.ApprovalStatusApproved {
+			_, txErr = c.ledgerRepository.PostTx(ctx, exec, entity.NewApprovalLedgerEntry(expense))
+			if txErr != nil {
+				return fmt.Errorf("failed to post approval ledger entry for expense id (%d) = %w", req.ID, txErr)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -111,6 +123,7 @@ func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.Approva
 	if approvalStatus == entity.ApprovalStatusApproved {
 		event := model.ExpenseApprovedEvent{
 			ID:             req.ID,
+			OrgID:          req.OrgID,
 			UserID:         req.UserID,
 			Amount:         expAmount,
 			IdempotencyKey: idemKey,
@@ -135,3 +148,91 @@ func (c *approvalUsecase) updateApproval(ctx context.Context, req *model.Approva
 
 	return nil
 }
+
+// RequestChanges sends the expense back to the submitter with the notes of what to change. Each
+// request opens a review round, the expense returns to the approval queue once the submitter edits it.
+func (c *approvalUsecase) RequestChanges(ctx context.Context, req *model.RequestChangesExpenseRequest) error {
+	if !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return model.ErrForbidden
+	}
+
+	return c.tx.Do(ctx, func(exec db.Executor) error {
+		expense, txErr := c.findPendingExpenseWithLock(ctx, exec, req.OrgID, req.ID, req.UserID, req.Version)
+		if txErr != nil {
+			return txErr
+		}
+
+		round := &entity.ExpenseReviewRound{
+			OrgID:      req.OrgID,
+			ExpenseID:  expense.ID,
+			ReviewerID: req.UserID,
+			Notes:      strings.TrimSpace(req.Notes),
+		}
+		txErr = c.reviewRoundRepository.CreateTx(ctx, exec, round)
+		if txErr != nil {
+			return fmt.Errorf("failed to create review round for expense id (%d) = %w", req.ID, txErr)
+		}
+
+		txErr = c.expenseRepository.UpdateStatusByIDTx(ctx, exec, req.OrgID, req.ID, entity.ExpenseStatusChangesRequested)
+		if txErr != nil {
+			return fmt.Errorf("failed to to update expense for id (%d) = %w", req.ID, txErr)
+		}
+
+		return nil
+	})
+}
+
+// findPendingExpenseWithLock locks the expense and checks that the user can decide on it now
+func (c *approvalUsecase) findPendingExpenseWithLock(ctx context.Context, exec db.Executor, orgID uint64, id uint64,
+	userID uint64, version *uint64) (*entity.Expense, error) {
+	expense, err := c.expenseRepository.FindByIDWithLock(ctx, exec, orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense by id (%d) with lock = %w", id, err)
+	}
+
+	if expense == nil {
+		return nil, model.ErrExpenseNotFound
+	}
+	if expense.UserID == userID {
+		return nil, model.ErrForbidden
+	}
+	// the row is locked, so the version can't change between this check and the update
+	if version != nil && *version != expense.Version {
+		return nil, model.ErrExpenseModified
+	}
+	if expense.Status != entity.ExpenseStatusAwaitingApproval {
+		return nil, model.ErrExpenseAlreadyProcessed
+	}
+	if !expense.RequiresApproval() {
+		return nil, model.ErrExpenseNotRequireApproval
+	}
+
+	return expense, nil
+}
+
+// checkBudget applies the budget policy when the expense doesn't fit in what is left of the
+// department budget, the budget row stays locked until the approval is committed
+func (c *approvalUsecase) checkBudget(ctx context.Context, exec db.Executor, expense *entity.Expense, userRole string) error {
+	if expense.DepartmentID == nil {
+		return nil
+	}
+
+	budget, err := c.departmentBudgetRepository.FindByDateWithLock(ctx, exec, expense.OrgID, *expense.DepartmentID, expense.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to find budget for department id (%d) with lock = %w", *expense.DepartmentID, err)
+	}
+
+	if !budget.Exceeded(expense.Amount) {
+		return nil
+	}
+
+	switch c.budgetPolicy {
+	case entity.BudgetPolicyEscalate:
+		if userRole != string(entity.UserRoleAdmin) {
+			return model.ErrBudgetApprovalEscalated
+		}
+		return nil
+	default:
+		return model.ErrBudgetExceeded
+	}
+}
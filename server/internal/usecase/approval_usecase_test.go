@@ -10,6 +10,7 @@ import (
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,8 @@ type AuMockFunc func(
 	tx db.Transactioner,
 	ar *mocks.ApprovalRepository,
 	er *mocks.ExpenseRepository,
+	dbr *mocks.DepartmentBudgetRepository,
+	lr *mocks.LedgerRepository,
 	p *mocks.Producer[*model.ExpenseApprovedEvent],
 )
 
@@ -38,16 +41,22 @@ func (s *ApprovalUsecaseSuite) SetupTest() {
 
 func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 	notes := "dummy notes"
+	version := uint64(2)
+	staleVersion := uint64(1)
+	departmentID := uint64(3)
+	createdAt := time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name       string
 		request    *model.ApprovalExpenseRequest
+		policy     entity.BudgetPolicy
 		mockFunc   AuMockFunc
 		wantErrMsg string
 	}{
 		{
 			name: "invalid role",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -58,6 +67,8 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 			},
@@ -66,6 +77,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 		{
 			name: "error on find expense with lock",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -76,10 +88,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(nil, errors.New("something error"))
 				db.ExpectRollback()
 			},
@@ -88,6 +102,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 		{
 			name: "error on expense not found",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -98,10 +113,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(nil, nil)
 				db.ExpectRollback()
 			},
@@ -110,6 +127,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 		{
 			name: "error on same user id",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -120,18 +138,47 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 1}, nil)
 				db.ExpectRollback()
 			},
 			wantErrMsg: "Forbidden",
 		},
+		{
+			name: "error on version mismatch",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+				Version:  &staleVersion,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval, Version: 2}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Expense was changed by someone else, reload it and try again",
+		},
 		{
 			name: "error on expense already processed",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -142,18 +189,204 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusApproved}, nil)
 				db.ExpectRollback()
 			},
 			wantErrMsg: "Expense already processed",
 		},
+		{
+			name: "error on find budget with lock",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{
+						UserID:       2,
+						Amount:       1500000,
+						Status:       entity.ExpenseStatusAwaitingApproval,
+						DepartmentID: &departmentID,
+						CreatedAt:    createdAt,
+					}, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, createdAt).
+					Return(nil, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to find budget for department id (3) with lock = something error",
+		},
+		{
+			name: "error on budget exceeded",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			policy: entity.BudgetPolicyBlock,
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{
+						UserID:       2,
+						Amount:       1500000,
+						Status:       entity.ExpenseStatusAwaitingApproval,
+						DepartmentID: &departmentID,
+						CreatedAt:    createdAt,
+					}, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, createdAt).
+					Return(&entity.DepartmentBudget{Amount: 5000000, Spent: 4000000}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Expense exceeds the remaining department budget",
+		},
+		{
+			name: "error on budget exceeded escalated",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			policy: entity.BudgetPolicyEscalate,
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{
+						UserID:       2,
+						Amount:       1500000,
+						Status:       entity.ExpenseStatusAwaitingApproval,
+						DepartmentID: &departmentID,
+						CreatedAt:    createdAt,
+					}, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, createdAt).
+					Return(&entity.DepartmentBudget{Amount: 5000000, Spent: 4000000}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Expense exceeds the remaining department budget and needs an admin approval",
+		},
+		{
+			name: "success on budget exceeded approved by admin",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "admin",
+			},
+			policy: entity.BudgetPolicyEscalate,
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{
+						UserID:       2,
+						Amount:       1500000,
+						Status:       entity.ExpenseStatusAwaitingApproval,
+						DepartmentID: &departmentID,
+						CreatedAt:    createdAt,
+					}, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, createdAt).
+					Return(&entity.DepartmentBudget{Amount: 5000000, Spent: 4000000}, nil)
+				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success within budget",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			policy: entity.BudgetPolicyBlock,
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{
+						UserID:       2,
+						Amount:       1500000,
+						Status:       entity.ExpenseStatusAwaitingApproval,
+						DepartmentID: &departmentID,
+						CreatedAt:    createdAt,
+					}, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, createdAt).
+					Return(&entity.DepartmentBudget{Amount: 5000000, Spent: 1000000}, nil)
+				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
 		{
 			name: "error on create approval",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -164,10 +397,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(errors.New("something error"))
@@ -178,6 +413,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 		{
 			name: "error on update expense",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -188,22 +424,58 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
-				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
 					Return(errors.New("something error"))
 				db.ExpectRollback()
 			},
 			wantErrMsg: "failed to to update expense for id (1) = something error",
 		},
+		{
+			name: "error on post ledger entry",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
+				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypeApproval && e.ExpenseID == 1 && *e.Postings[1].UserID == 2
+				})).
+					Return(false, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to post approval ledger entry for expense id (1) = something error",
+		},
 		{
 			name: "success but error send event",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -214,15 +486,19 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
-				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
 					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
 				db.ExpectCommit()
 				p.On("Send", mock.Anything).Return(errors.New("something error"))
 			},
@@ -231,6 +507,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 		{
 			name: "success",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -241,15 +518,52 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
-				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
 					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success with matching version",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+				Version:  &version,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval, Version: 2}, nil)
+				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusApproved).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
 				db.ExpectCommit()
 				p.On("Send", mock.Anything).Return(nil)
 			},
@@ -265,14 +579,16 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 
 			ar := mocks.NewApprovalRepository(s.T())
 			er := mocks.NewExpenseRepository(s.T())
+			dbr := mocks.NewDepartmentBudgetRepository(s.T())
+			lr := mocks.NewLedgerRepository(s.T())
 
 			p := mocks.NewProducer[*model.ExpenseApprovedEvent](s.T())
 			eap := &messaging.ExpenseApprovedProducer{
 				Producer: p,
 			}
 
-			usecase := usecase.NewApprovalUsecase(s.log, tx, ar, er, eap)
-			tt.mockFunc(dbMock, tx, ar, er, p)
+			usecase := usecase.NewApprovalUsecase(s.log, tx, ar, er, dbr, lr, nil, eap, tt.policy)
+			tt.mockFunc(dbMock, tx, ar, er, dbr, lr, p)
 
 			err := usecase.Approve(s.ctx, tt.request)
 
@@ -287,6 +603,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Approve() {
 
 func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 	notes := "dummy notes"
+	staleVersion := uint64(1)
 
 	tests := []struct {
 		name       string
@@ -297,6 +614,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "invalid role",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -307,6 +625,8 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 			},
@@ -315,6 +635,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "error on find expense with lock",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -325,10 +646,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(nil, errors.New("something error"))
 				db.ExpectRollback()
 			},
@@ -337,6 +660,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "error on expense not found",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -347,10 +671,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(nil, nil)
 				db.ExpectRollback()
 			},
@@ -359,6 +685,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "error on same user id",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -369,18 +696,47 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 1}, nil)
 				db.ExpectRollback()
 			},
 			wantErrMsg: "Forbidden",
 		},
+		{
+			name: "error on version mismatch",
+			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    &notes,
+				UserID:   1,
+				UserRole: "manager",
+				Version:  &staleVersion,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				tx db.Transactioner,
+				ar *mocks.ApprovalRepository,
+				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval, Version: 2}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Expense was changed by someone else, reload it and try again",
+		},
 		{
 			name: "error on expense already processed",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -391,10 +747,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusApproved}, nil)
 				db.ExpectRollback()
 			},
@@ -403,6 +761,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "error on create approval",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -413,10 +772,12 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(errors.New("something error"))
@@ -427,6 +788,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "error on update expense",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -437,14 +799,16 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
-				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusRejected).
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusRejected).
 					Return(errors.New("something error"))
 				db.ExpectRollback()
 			},
@@ -453,6 +817,7 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 		{
 			name: "success",
 			request: &model.ApprovalExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				Notes:    &notes,
 				UserID:   1,
@@ -463,14 +828,16 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 				tx db.Transactioner,
 				ar *mocks.ApprovalRepository,
 				er *mocks.ExpenseRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				lr *mocks.LedgerRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
 				db.ExpectBegin()
-				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1)).
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.Expense{UserID: 2, Amount: 1500000, Status: entity.ExpenseStatusAwaitingApproval}, nil)
 				ar.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
-				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusRejected).
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, entity.ExpenseStatusRejected).
 					Return(nil)
 				db.ExpectCommit()
 			},
@@ -486,14 +853,16 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 
 			ar := mocks.NewApprovalRepository(s.T())
 			er := mocks.NewExpenseRepository(s.T())
+			dbr := mocks.NewDepartmentBudgetRepository(s.T())
+			lr := mocks.NewLedgerRepository(s.T())
 
 			p := mocks.NewProducer[*model.ExpenseApprovedEvent](s.T())
 			eap := &messaging.ExpenseApprovedProducer{
 				Producer: p,
 			}
 
-			usecase := usecase.NewApprovalUsecase(s.log, tx, ar, er, eap)
-			tt.mockFunc(dbMock, tx, ar, er, p)
+			usecase := usecase.NewApprovalUsecase(s.log, tx, ar, er, dbr, lr, nil, eap, entity.BudgetPolicyBlock)
+			tt.mockFunc(dbMock, tx, ar, er, dbr, lr, p)
 
 			err := usecase.Reject(s.ctx, tt.request)
 
@@ -506,6 +875,171 @@ func (s *ApprovalUsecaseSuite) TestApprovalUsecase_Reject() {
 	}
 }
 
+func (s *ApprovalUsecaseSuite) TestApprovalUsecase_RequestChanges() {
+	staleVersion := uint64(1)
+	pending := &entity.Expense{
+		ID:                1,
+		OrgID:             1,
+		UserID:            2,
+		Amount:            2000000,
+		ApprovalThreshold: 1000000,
+		Status:            entity.ExpenseStatusAwaitingApproval,
+		Version:           2,
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.RequestChangesExpenseRequest
+		mockFunc   func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "invalid role",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "employee",
+			},
+			mockFunc:   func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error on find expense with lock",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(nil, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to find expense by id (1) with lock = something error",
+		},
+		{
+			name: "error on version mismatch",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "manager",
+				Version:  &staleVersion,
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, UserID: 2, Status: entity.ExpenseStatusAwaitingApproval, Version: 2}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: model.ErrExpenseModified.Error(),
+		},
+		{
+			name: "error on changes already requested",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, UserID: 2, Status: entity.ExpenseStatusChangesRequested}, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: model.ErrExpenseAlreadyProcessed.Error(),
+		},
+		{
+			name: "error on create review round",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(pending, nil)
+				rrr.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to create review round for expense id (1) = something error",
+		},
+		{
+			name: "error on update expense status",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "Wrong amount",
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(pending, nil)
+				rrr.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), entity.ExpenseStatusChangesRequested).
+					Return(errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to to update expense for id (1) = something error",
+		},
+		{
+			name: "success",
+			request: &model.RequestChangesExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				Notes:    "  Wrong amount ",
+				UserID:   1,
+				UserRole: "manager",
+				Version:  &pending.Version,
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, rrr *mocks.ExpenseReviewRoundRepository) {
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(pending, nil)
+				rrr.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(r *entity.ExpenseReviewRound) bool {
+					return r.OrgID == 1 && r.ExpenseID == 1 && r.ReviewerID == 1 && r.Notes == "Wrong amount"
+				})).Return(nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), entity.ExpenseStatusChangesRequested).
+					Return(nil)
+				db.ExpectCommit()
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dbMock, _ := pgxmock.NewPool()
+			defer dbMock.Close()
+			tx := db.NewTransactioner(dbMock)
+
+			er := mocks.NewExpenseRepository(s.T())
+			rrr := mocks.NewExpenseReviewRoundRepository(s.T())
+
+			usecase := usecase.NewApprovalUsecase(s.log, tx, nil, er, nil, nil, rrr, nil, entity.BudgetPolicyBlock)
+			tt.mockFunc(dbMock, er, rrr)
+
+			err := usecase.RequestChanges(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func TestApprovalUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(ApprovalUsecaseSuite))
 }
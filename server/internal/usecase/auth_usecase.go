@@ -2,47 +2,136 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/storage"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const twoFactorChallengeTTL = 5 * time.Minute
+
+// used to compare against when the email is unknown, so a missing account
+// takes about as long to reject as a wrong password
+const dummyPasswordHash = "$2a$10$OUjs79L8Z1zWrJJ68Gbfne7GjNuWzOAjPc2qB3ZUYKuW/MsfualmK"
+
 type authUsecase struct {
-	log            *zap.Logger
-	redisClient    storage.RedisClient
-	jwtToken       auth.JWTToken
-	userRepository UserRepository
+	log                *zap.Logger
+	redisClient        storage.RedisClient
+	jwtToken           auth.JWTToken
+	totp               auth.TOTP
+	loginGuard         auth.LoginGuard
+	userRepository     UserRepository
+	userTOTPRepository UserTOTPRepository
 }
 
-func NewAuthUsecase(log *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken, userRepository UserRepository) AuthUsecase {
+func NewAuthUsecase(log *zap.Logger, redisClient storage.RedisClient, jwtToken auth.JWTToken, totp auth.TOTP,
+	loginGuard auth.LoginGuard, userRepository UserRepository, userTOTPRepository UserTOTPRepository) AuthUsecase {
 	return &authUsecase{
-		log:            log,
-		redisClient:    redisClient,
-		jwtToken:       jwtToken,
-		userRepository: userRepository,
+		log:                log,
+		redisClient:        redisClient,
+		jwtToken:           jwtToken,
+		totp:               totp,
+		loginGuard:         loginGuard,
+		userRepository:     userRepository,
+		userTOTPRepository: userTOTPRepository,
 	}
 }
 
 func (c *authUsecase) Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error) {
+	retryAfter, err := c.loginGuard.Check(ctx, req.Email, req.IPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login attempts for email (%s) = %w", req.Email, err)
+	}
+	if retryAfter > 0 {
+		return nil, model.ErrTooManyLoginAttempts
+	}
+
 	user, err := c.userRepository.FindByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by email (%s) = %w", req.Email, err)
 	}
+
+	passwordHash := dummyPasswordHash
+	if user != nil {
+		passwordHash = user.PasswordHash
+	}
+
+	// a deactivated user gets the same answer as a wrong password, so it doesn't tell the password was right
+	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password))
+	if user == nil || err != nil || !user.Active {
+		return nil, model.ErrInvalidCredentials
+	}
+
+	err = c.loginGuard.Succeed(ctx, req.Email, req.IPAddress)
+	if err != nil {
+		c.log.Warn(
+			fmt.Sprintf("failed to reset login attempts for email (%s) = %s", req.Email, err.Error()),
+			zap.Strings("tags", []string{"auth", "login", "reset-attempt"}),
+		)
+	}
+
+	return issueLogin(ctx, c.redisClient, c.jwtToken, c.userTOTPRepository, user)
+}
+
+// VerifyTwoFactor exchanges a login challenge for an access token, the challenge
+// is single-use so a wrong code means starting over from the password step
+func (c *authUsecase) VerifyTwoFactor(ctx context.Context, req *model.VerifyTwoFactorRequest) (*model.LoginResponse, error) {
+	challengeKey := fmt.Sprintf("%s:%s", auth.PrefixTwoFactorChallengeKey, req.ChallengeToken)
+	val, err := c.redisClient.GetDel(ctx, challengeKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, model.ErrInvalidChallengeToken
+		}
+		return nil, fmt.Errorf("failed to get two-factor challenge = %w", err)
+	}
+
+	orgID, userID, err := parseUserKey(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse two-factor challenge user id (%s) = %w", val, err)
+	}
+
+	user, err := c.userRepository.FindByID(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", userID, err)
+	}
+
 	if user == nil {
-		return nil, model.ErrUserNotFound
+		return nil, model.ErrInvalidChallengeToken
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
+	if !user.Active {
+		return nil, model.ErrUserDeactivated
+	}
+
+	userTOTP, err := c.userTOTPRepository.FindByUserID(ctx, userID)
 	if err != nil {
-		return nil, model.ErrInvalidPassword
+		return nil, fmt.Errorf("failed to find totp by user id (%d) = %w", userID, err)
 	}
 
-	accessToken, err := c.jwtToken.Create(fmt.Sprint(user.ID), string(user.Role))
+	if !userTOTP.Enabled() {
+		return nil, model.ErrInvalidChallengeToken
+	}
+
+	valid, err := verifySecondFactor(ctx, c.totp, c.userTOTPRepository, userTOTP, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !valid {
+		return nil, model.ErrInvalidTwoFactorCode
+	}
+
+	accessToken, err := c.jwtToken.Create(fmt.Sprint(user.ID), user.OrgID, string(user.Role), user.Locale, auth.AMRTOTP)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token for id (%d) = %w", user.ID, err)
 	}
@@ -63,3 +152,88 @@ func (c *authUsecase) Logout(ctx context.Context, req *model.LogoutRequest) erro
 
 	return nil
 }
+
+func (c *authUsecase) Unlock(ctx context.Context, req *model.UnlockLoginRequest) error {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return model.ErrForbidden
+	}
+
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id (%d) = %w", req.ID, err)
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	err = c.loginGuard.Reset(ctx, user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to reset login attempts for id (%d) = %w", req.ID, err)
+	}
+
+	return nil
+}
+
+// issueLogin finishes a successful first factor, users with totp enabled get a
+// challenge token instead of an access token
+func issueLogin(ctx context.Context, redisClient storage.RedisClient, jwtToken auth.JWTToken,
+	userTOTPRepository UserTOTPRepository, user *entity.User) (*model.LoginResponse, error) {
+	if !user.Active {
+		return nil, model.ErrInvalidCredentials
+	}
+
+	userTOTP, err := userTOTPRepository.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp by user id (%d) = %w", user.ID, err)
+	}
+
+	if userTOTP.Enabled() {
+		challengeToken := uuid.NewString()
+		challengeKey := fmt.Sprintf("%s:%s", auth.PrefixTwoFactorChallengeKey, challengeToken)
+
+		err = redisClient.SetEx(ctx, challengeKey, userKey(user), twoFactorChallengeTTL).Err()
+		if err != nil {
+			return nil, fmt.Errorf("failed to set two-factor challenge for id (%d) = %w", user.ID, err)
+		}
+
+		return &model.LoginResponse{
+			ChallengeToken:    challengeToken,
+			TwoFactorRequired: true,
+		}, nil
+	}
+
+	accessToken, err := jwtToken.Create(fmt.Sprint(user.ID), user.OrgID, string(user.Role), user.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token for id (%d) = %w", user.ID, err)
+	}
+
+	return &model.LoginResponse{
+		AccessToken: accessToken,
+	}, nil
+}
+
+// userKey is the value of a redis key issued to a user, it keeps the organization
+// so the user is only looked up within it
+func userKey(user *entity.User) string {
+	return fmt.Sprintf("%d:%d", user.OrgID, user.ID)
+}
+
+func parseUserKey(val string) (uint64, uint64, error) {
+	orgStr, idStr, found := strings.Cut(val, ":")
+	if !found {
+		return 0, 0, errors.New("missing organization")
+	}
+
+	orgID, err := strconv.ParseUint(orgStr, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	userID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return orgID, userID, nil
+}
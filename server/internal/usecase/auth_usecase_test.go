@@ -29,7 +29,9 @@ type MockFunc func(
 	c context.Context,
 	rc *mocks.RedisClient,
 	jwt *mocks.JWTToken,
+	lg *mocks.LoginGuard,
 	ur *mocks.UserRepository,
+	utr *mocks.UserTOTPRepository,
 )
 
 func (s *AuthUsecaseSuite) SetupTest() {
@@ -48,18 +50,64 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 		wantRes    *model.LoginResponse
 		wantErrMsg string
 	}{
+		{
+			name: "error on check login attempts",
+			request: &model.LoginRequest{
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
+				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
+			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").
+					Return(time.Duration(0), errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to check login attempts for email (john@mail.com) = something error",
+		},
+		{
+			name: "error too many login attempts",
+			request: &model.LoginRequest{
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
+				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
+			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").
+					Return(30*time.Second, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "Too many failed login attempts, please try again later",
+		},
 		{
 			name: "error on find by email",
 			request: &model.LoginRequest{
-				Email:    "john@mail.com",
-				Password: "password",
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
 				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
 			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
 				ur.On("FindByEmail", mock.Anything, "john@mail.com").
 					Return(nil, errors.New("something error"))
 			},
@@ -69,66 +117,185 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 		{
 			name: "error user not found",
 			request: &model.LoginRequest{
-				Email:    "john@mail.com",
-				Password: "password",
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
 				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
 			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
 				ur.On("FindByEmail", mock.Anything, "john@mail.com").
 					Return(nil, nil)
 			},
 			wantRes:    nil,
-			wantErrMsg: "User not found",
+			wantErrMsg: "Invalid email or password",
 		},
 		{
 			name: "error invalid password",
 			request: &model.LoginRequest{
-				Email:    "john@mail.com",
-				Password: "invalid_password",
+				Email:     "john@mail.com",
+				Password:  "invalid_password",
+				IPAddress: "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
+				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
+			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: string(passwordHash),
+					Role:         "manager",
+					Active:       true,
+					CreatedAt:    now,
+				}, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "Invalid email or password",
+		},
+		{
+			name: "error deactivated user",
+			request: &model.LoginRequest{
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
 				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
 			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
 				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
 					ID:           uint64(1),
+					OrgID:        uint64(1),
 					Email:        "john@mail.com",
 					Name:         "John Doe",
 					PasswordHash: string(passwordHash),
 					Role:         "manager",
+					Active:       false,
 					CreatedAt:    now,
 				}, nil)
 			},
 			wantRes:    nil,
-			wantErrMsg: "Invalid password",
+			wantErrMsg: "Invalid email or password",
+		},
+		{
+			name: "error on find totp",
+			request: &model.LoginRequest{
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
+				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
+			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: string(passwordHash),
+					Role:         "manager",
+					Active:       true,
+					CreatedAt:    now,
+				}, nil)
+				lg.On("Succeed", mock.Anything, "john@mail.com", "127.0.0.1").Return(nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find totp by user id (1) = something error",
+		},
+		{
+			name: "error on set two-factor challenge",
+			request: &model.LoginRequest{
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
+			},
+			mockFunc: func(
+				c context.Context,
+				rc *mocks.RedisClient,
+				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
+				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
+			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
+					ID:           uint64(1),
+					OrgID:        uint64(1),
+					Email:        "john@mail.com",
+					Name:         "John Doe",
+					PasswordHash: string(passwordHash),
+					Role:         "manager",
+					Active:       true,
+					CreatedAt:    now,
+				}, nil)
+				lg.On("Succeed", mock.Anything, "john@mail.com", "127.0.0.1").Return(nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:    1,
+					Secret:    "JBSWY3DPEHPK3PXP",
+					EnabledAt: &now,
+				}, nil)
+				setCmd := redis.NewStatusCmd(c)
+				setCmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, mock.Anything, "1:1", mock.Anything).Return(setCmd)
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to set two-factor challenge for id (1) = something error",
 		},
 		{
 			name: "error on create jwt token",
 			request: &model.LoginRequest{
-				Email:    "john@mail.com",
-				Password: "password",
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
 				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
 			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
 				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
 					ID:           uint64(1),
+					OrgID:        uint64(1),
 					Email:        "john@mail.com",
 					Name:         "John Doe",
 					PasswordHash: string(passwordHash),
 					Role:         "manager",
+					Active:       true,
 					CreatedAt:    now,
 				}, nil)
-				jwt.On("Create", "1", "manager").Return("", errors.New("something error"))
+				lg.On("Succeed", mock.Anything, "john@mail.com", "127.0.0.1").Return(nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				jwt.On("Create", "1", uint64(1), "manager", "").Return("", errors.New("something error"))
 			},
 			wantRes:    nil,
 			wantErrMsg: "failed to create access token for id (1) = something error",
@@ -136,24 +303,32 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 		{
 			name: "success",
 			request: &model.LoginRequest{
-				Email:    "john@mail.com",
-				Password: "password",
+				Email:     "john@mail.com",
+				Password:  "password",
+				IPAddress: "127.0.0.1",
 			},
 			mockFunc: func(
 				c context.Context,
 				rc *mocks.RedisClient,
 				jwt *mocks.JWTToken,
+				lg *mocks.LoginGuard,
 				ur *mocks.UserRepository,
+				utr *mocks.UserTOTPRepository,
 			) {
+				lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
 				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
 					ID:           uint64(1),
+					OrgID:        uint64(1),
 					Email:        "john@mail.com",
 					Name:         "John Doe",
 					PasswordHash: string(passwordHash),
 					Role:         "manager",
+					Active:       true,
 					CreatedAt:    now,
 				}, nil)
-				jwt.On("Create", "1", "manager").Return("qwerty-12345", nil)
+				lg.On("Succeed", mock.Anything, "john@mail.com", "127.0.0.1").Return(nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				jwt.On("Create", "1", uint64(1), "manager", "").Return("qwerty-12345", nil)
 			},
 			wantRes: &model.LoginResponse{
 				AccessToken: "qwerty-12345",
@@ -166,9 +341,11 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 		s.Run(tt.name, func() {
 			rc := mocks.NewRedisClient(s.T())
 			jwt := mocks.NewJWTToken(s.T())
+			lg := mocks.NewLoginGuard(s.T())
 			ur := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, ur)
-			tt.mockFunc(s.ctx, rc, jwt, ur)
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, mocks.NewTOTP(s.T()), lg, ur, utr)
+			tt.mockFunc(s.ctx, rc, jwt, lg, ur, utr)
 
 			res, err := usecase.Login(s.ctx, tt.request)
 
@@ -183,6 +360,243 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Login() {
 	}
 }
 
+func (s *AuthUsecaseSuite) TestAuthUsecase_LoginTwoFactorChallenge() {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	now := time.Now()
+
+	rc := mocks.NewRedisClient(s.T())
+	jwt := mocks.NewJWTToken(s.T())
+	lg := mocks.NewLoginGuard(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	utr := mocks.NewUserTOTPRepository(s.T())
+	usecase := usecase.NewAuthUsecase(s.log, rc, jwt, mocks.NewTOTP(s.T()), lg, ur, utr)
+
+	lg.On("Check", mock.Anything, "john@mail.com", "127.0.0.1").Return(time.Duration(0), nil)
+	ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{
+		ID:           uint64(1),
+		OrgID:        uint64(1),
+		Email:        "john@mail.com",
+		PasswordHash: string(passwordHash),
+		Role:         "manager",
+		Active:       true,
+	}, nil)
+	lg.On("Succeed", mock.Anything, "john@mail.com", "127.0.0.1").Return(nil)
+	utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+		UserID:    1,
+		Secret:    "JBSWY3DPEHPK3PXP",
+		EnabledAt: &now,
+	}, nil)
+
+	var challengeKey string
+	rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 5*time.Minute).
+		Run(func(args mock.Arguments) { challengeKey = args.String(1) }).
+		Return(redis.NewStatusCmd(s.ctx))
+
+	res, err := usecase.Login(s.ctx, &model.LoginRequest{
+		Email:     "john@mail.com",
+		Password:  "password",
+		IPAddress: "127.0.0.1",
+	})
+
+	s.Nil(err)
+	s.True(res.TwoFactorRequired)
+	s.Empty(res.AccessToken)
+	s.NotEmpty(res.ChallengeToken)
+	s.Equal("2fa-challenge:"+res.ChallengeToken, challengeKey)
+}
+
+func (s *AuthUsecaseSuite) TestAuthUsecase_VerifyTwoFactor() {
+	now := time.Now()
+	recoveryHash := auth.HashRecoveryCode("abcde-12345")
+
+	request := &model.VerifyTwoFactorRequest{
+		ChallengeToken: "challenge-123",
+		Code:           "123456",
+	}
+
+	getDel := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+
+	tests := []struct {
+		name     string
+		request  *model.VerifyTwoFactorRequest
+		mockFunc func(
+			rc *mocks.RedisClient,
+			jwt *mocks.JWTToken,
+			totp *mocks.TOTP,
+			ur *mocks.UserRepository,
+			utr *mocks.UserTOTPRepository,
+		)
+		wantRes    *model.LoginResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error challenge not found",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("", redis.Nil))
+			},
+			wantErrMsg: "Invalid or expired challenge token",
+		},
+		{
+			name:    "error on get challenge",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").
+					Return(getDel("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to get two-factor challenge = something error",
+		},
+		{
+			name:    "error user not found",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Invalid or expired challenge token",
+		},
+		{
+			name:    "error totp not enabled",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{UserID: 1}, nil)
+			},
+			wantErrMsg: "Invalid or expired challenge token",
+		},
+		{
+			name:    "error invalid code",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:        1,
+					Secret:        "secret",
+					RecoveryCodes: []string{recoveryHash},
+					EnabledAt:     &now,
+				}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(0), false)
+				utr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashRecoveryCode("123456")).Return(false, nil)
+			},
+			wantErrMsg: "Invalid two-factor code",
+		},
+		{
+			name:    "error replayed code",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:    1,
+					Secret:    "secret",
+					EnabledAt: &now,
+				}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(false, nil)
+			},
+			wantErrMsg: "Invalid two-factor code",
+		},
+		{
+			name:    "error on use recovery code",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:    1,
+					Secret:    "secret",
+					EnabledAt: &now,
+				}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(0), false)
+				utr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashRecoveryCode("123456")).
+					Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to use recovery code for user id (1) = something error",
+		},
+		{
+			name: "success with recovery code",
+			request: &model.VerifyTwoFactorRequest{
+				ChallengeToken: "challenge-123",
+				Code:           "ABCDE-12345",
+			},
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:        1,
+					Secret:        "secret",
+					RecoveryCodes: []string{"other", recoveryHash},
+					EnabledAt:     &now,
+				}, nil)
+				totp.On("Validate", "secret", "ABCDE-12345").Return(int64(0), false)
+				utr.On("UseRecoveryCode", mock.Anything, uint64(1), recoveryHash).Return(true, nil)
+				jwt.On("Create", "1", uint64(1), "manager", "", "otp").Return("qwerty-12345", nil)
+			},
+			wantRes: &model.LoginResponse{
+				AccessToken: "qwerty-12345",
+			},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, jwt *mocks.JWTToken, totp *mocks.TOTP,
+				ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, "2fa-challenge:challenge-123").Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, OrgID: 1, Role: "manager", Active: true}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:    1,
+					Secret:    "secret",
+					EnabledAt: &now,
+				}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(true, nil)
+				jwt.On("Create", "1", uint64(1), "manager", "", "otp").Return("qwerty-12345", nil)
+			},
+			wantRes: &model.LoginResponse{
+				AccessToken: "qwerty-12345",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			totp := mocks.NewTOTP(s.T())
+			lg := mocks.NewLoginGuard(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, totp, lg, ur, utr)
+			tt.mockFunc(rc, jwt, totp, ur, utr)
+
+			res, err := usecase.VerifyTwoFactor(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func (s *AuthUsecaseSuite) TestAuthUsecase_Logout() {
 	now := time.Now()
 
@@ -237,8 +651,10 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Logout() {
 		s.Run(tt.name, func() {
 			rc := mocks.NewRedisClient(s.T())
 			jwt := mocks.NewJWTToken(s.T())
+			lg := mocks.NewLoginGuard(s.T())
 			ur := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, ur)
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, mocks.NewTOTP(s.T()), lg, ur, utr)
 			tt.mockFunc(s.ctx, rc)
 
 			err := usecase.Logout(s.ctx, tt.request)
@@ -252,6 +668,103 @@ func (s *AuthUsecaseSuite) TestAuthUsecase_Logout() {
 	}
 }
 
+func (s *AuthUsecaseSuite) TestAuthUsecase_Unlock() {
+	tests := []struct {
+		name       string
+		request    *model.UnlockLoginRequest
+		mockFunc   func(lg *mocks.LoginGuard, ur *mocks.UserRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "invalid role",
+			request: &model.UnlockLoginRequest{
+				OrgID:    1,
+				ID:       1,
+				UserRole: "manager",
+			},
+			mockFunc:   func(lg *mocks.LoginGuard, ur *mocks.UserRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error on find by id",
+			request: &model.UnlockLoginRequest{
+				OrgID:    1,
+				ID:       1,
+				UserRole: "admin",
+			},
+			mockFunc: func(lg *mocks.LoginGuard, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (1) = something error",
+		},
+		{
+			name: "error user not found",
+			request: &model.UnlockLoginRequest{
+				OrgID:    1,
+				ID:       1,
+				UserRole: "admin",
+			},
+			mockFunc: func(lg *mocks.LoginGuard, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name: "error on reset login attempts",
+			request: &model.UnlockLoginRequest{
+				OrgID:    1,
+				ID:       1,
+				UserRole: "admin",
+			},
+			mockFunc: func(lg *mocks.LoginGuard, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{
+					ID:    1,
+					Email: "john@mail.com",
+				}, nil)
+				lg.On("Reset", mock.Anything, "john@mail.com").Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to reset login attempts for id (1) = something error",
+		},
+		{
+			name: "success",
+			request: &model.UnlockLoginRequest{
+				OrgID:    1,
+				ID:       1,
+				UserRole: "admin",
+			},
+			mockFunc: func(lg *mocks.LoginGuard, ur *mocks.UserRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{
+					ID:    1,
+					Email: "john@mail.com",
+				}, nil)
+				lg.On("Reset", mock.Anything, "john@mail.com").Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			jwt := mocks.NewJWTToken(s.T())
+			lg := mocks.NewLoginGuard(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewAuthUsecase(s.log, rc, jwt, mocks.NewTOTP(s.T()), lg, ur, utr)
+			tt.mockFunc(lg, ur)
+
+			err := usecase.Unlock(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func TestAuthUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(AuthUsecaseSuite))
 }
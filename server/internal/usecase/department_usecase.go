@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type departmentUsecase struct {
+	log                        *zap.Logger
+	departmentRepository       DepartmentRepository
+	departmentBudgetRepository DepartmentBudgetRepository
+}
+
+func NewDepartmentUsecase(log *zap.Logger, departmentRepository DepartmentRepository,
+	departmentBudgetRepository DepartmentBudgetRepository) DepartmentUsecase {
+	return &departmentUsecase{
+		log:                        log,
+		departmentRepository:       departmentRepository,
+		departmentBudgetRepository: departmentBudgetRepository,
+	}
+}
+
+func (c *departmentUsecase) List(ctx context.Context, req *model.ListDepartmentRequest) ([]model.DepartmentResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	departments, err := c.departmentRepository.List(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list departments = %w", err)
+	}
+
+	res := make([]model.DepartmentResponse, len(departments))
+	for i := range departments {
+		res[i] = *serializer.DepartmentToResponse(&departments[i])
+	}
+
+	return res, nil
+}
+
+func (c *departmentUsecase) Create(ctx context.Context, req *model.CreateDepartmentRequest) (*model.DepartmentResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	department := &entity.Department{
+		OrgID:      req.OrgID,
+		Name:       strings.TrimSpace(req.Name),
+		CostCenter: strings.TrimSpace(req.CostCenter),
+	}
+
+	err := c.checkUnique(ctx, department)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.departmentRepository.Create(ctx, department)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create department (%s) = %w", department.Name, err)
+	}
+
+	return serializer.DepartmentToResponse(department), nil
+}
+
+func (c *departmentUsecase) Update(ctx context.Context, req *model.UpdateDepartmentRequest) (*model.DepartmentResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	department, err := c.findDepartment(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		department.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.CostCenter != nil {
+		department.CostCenter = strings.TrimSpace(*req.CostCenter)
+	}
+
+	err = c.checkUnique(ctx, department)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.departmentRepository.Update(ctx, department)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update department for id (%d) = %w", department.ID, err)
+	}
+
+	return serializer.DepartmentToResponse(department), nil
+}
+
+func (c *departmentUsecase) ListBudgets(ctx context.Context, req *model.ListDepartmentBudgetRequest) ([]model.DepartmentBudgetResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	_, err := c.findDepartment(ctx, req.OrgID, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := c.departmentBudgetRepository.ListByDepartmentID(ctx, req.OrgID, req.DepartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets for department id (%d) = %w", req.DepartmentID, err)
+	}
+
+	res := make([]model.DepartmentBudgetResponse, len(budgets))
+	for i := range budgets {
+		res[i] = *serializer.DepartmentBudgetToResponse(&budgets[i])
+	}
+
+	return res, nil
+}
+
+// CreateBudget adds a budget for a period, periods of the same department can't overlap
+// so every expense is counted against exactly one budget
+func (c *departmentUsecase) CreateBudget(ctx context.Context, req *model.CreateDepartmentBudgetRequest) (*model.DepartmentBudgetResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	periodStart, err := time.Parse(time.DateOnly, req.PeriodStart)
+	if err != nil {
+		return nil, model.ErrInvalidBudgetPeriod
+	}
+
+	periodEnd, err := time.Parse(time.DateOnly, req.PeriodEnd)
+	if err != nil || periodEnd.Before(periodStart) {
+		return nil, model.ErrInvalidBudgetPeriod
+	}
+
+	_, err = c.findDepartment(ctx, req.OrgID, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &entity.DepartmentBudget{
+		OrgID:        req.OrgID,
+		DepartmentID: req.DepartmentID,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		Amount:       req.AmountIDR,
+	}
+	created, err := c.departmentBudgetRepository.Create(ctx, budget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create budget for department id (%d) = %w", req.DepartmentID, err)
+	}
+
+	if !created {
+		return nil, model.ErrBudgetPeriodOverlap
+	}
+
+	return serializer.DepartmentBudgetToResponse(budget), nil
+}
+
+func (c *departmentUsecase) findDepartment(ctx context.Context, orgID uint64, id uint64) (*entity.Department, error) {
+	department, err := c.departmentRepository.FindByID(ctx, orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find department by id (%d) = %w", id, err)
+	}
+
+	if department == nil {
+		return nil, model.ErrDepartmentNotFound
+	}
+
+	return department, nil
+}
+
+func (c *departmentUsecase) checkUnique(ctx context.Context, department *entity.Department) error {
+	total, err := c.departmentRepository.CountByNameOrCostCenter(ctx, department.OrgID, department.ID, department.Name, department.CostCenter)
+	if err != nil {
+		return fmt.Errorf("failed to count departments by name (%s) or cost center (%s) = %w",
+			department.Name, department.CostCenter, err)
+	}
+
+	if total > 0 {
+		return model.ErrDepartmentAlreadyExist
+	}
+
+	return nil
+}
@@ -0,0 +1,442 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type DepartmentUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+	now time.Time
+}
+
+type DepartmentMockFunc func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository)
+
+func (s *DepartmentUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+	s.now = time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+}
+
+func (s *DepartmentUsecaseSuite) department() *entity.Department {
+	return &entity.Department{ID: 3, OrgID: 1, Name: "Finance", CostCenter: "CC-100", CreatedAt: s.now, UpdatedAt: s.now}
+}
+
+func (s *DepartmentUsecaseSuite) TestDepartmentUsecase_List() {
+	tests := []struct {
+		name       string
+		request    *model.ListDepartmentRequest
+		mockFunc   DepartmentMockFunc
+		wantRes    []model.DepartmentResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ListDepartmentRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListDepartmentRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list departments = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListDepartmentRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return([]entity.Department{*s.department()}, nil)
+			},
+			wantRes: []model.DepartmentResponse{
+				{ID: 3, Name: "Finance", CostCenter: "CC-100", CreatedAt: "2025-10-01T08:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			budgetRepository := mocks.NewDepartmentBudgetRepository(s.T())
+			usecase := usecase.NewDepartmentUsecase(s.log, departmentRepository, budgetRepository)
+			tt.mockFunc(departmentRepository, budgetRepository)
+
+			res, err := usecase.List(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *DepartmentUsecaseSuite) TestDepartmentUsecase_Create() {
+	tests := []struct {
+		name       string
+		request    *model.CreateDepartmentRequest
+		mockFunc   DepartmentMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.CreateDepartmentRequest{OrgID: 1, UserRole: "employee", Name: "Finance", CostCenter: "CC-100"},
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error already exist",
+			request: &model.CreateDepartmentRequest{OrgID: 1, UserRole: "admin", Name: " Finance ", CostCenter: "CC-100"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(0), "Finance", "CC-100").Return(1, nil)
+			},
+			wantErrMsg: "Department with the same name or cost center already exist",
+		},
+		{
+			name:    "error on create",
+			request: &model.CreateDepartmentRequest{OrgID: 1, UserRole: "admin", Name: "Finance", CostCenter: "CC-100"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(0), "Finance", "CC-100").Return(0, nil)
+				dr.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create department (Finance) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.CreateDepartmentRequest{OrgID: 1, UserRole: "admin", Name: "Finance", CostCenter: "CC-100"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(0), "Finance", "CC-100").Return(0, nil)
+				dr.On("Create", mock.Anything, &entity.Department{OrgID: 1, Name: "Finance", CostCenter: "CC-100"}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			budgetRepository := mocks.NewDepartmentBudgetRepository(s.T())
+			usecase := usecase.NewDepartmentUsecase(s.log, departmentRepository, budgetRepository)
+			tt.mockFunc(departmentRepository, budgetRepository)
+
+			res, err := usecase.Create(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal("Finance", res.Name)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *DepartmentUsecaseSuite) TestDepartmentUsecase_Update() {
+	costCenter := "CC-101"
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateDepartmentRequest
+		mockFunc   DepartmentMockFunc
+		wantRes    *model.DepartmentResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.UpdateDepartmentRequest{OrgID: 1, ID: 3, UserRole: "manager", CostCenter: &costCenter},
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error not found",
+			request: &model.UpdateDepartmentRequest{OrgID: 1, ID: 3, UserRole: "admin", CostCenter: &costCenter},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(nil, nil)
+			},
+			wantErrMsg: "Department not found",
+		},
+		{
+			name:    "error department of another organization",
+			request: &model.UpdateDepartmentRequest{OrgID: 2, ID: 3, UserRole: "admin", CostCenter: &costCenter},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(2), uint64(3)).Return(nil, nil)
+			},
+			wantErrMsg: "Department not found",
+		},
+		{
+			name:    "error already exist",
+			request: &model.UpdateDepartmentRequest{OrgID: 1, ID: 3, UserRole: "admin", CostCenter: &costCenter},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(3), "Finance", "CC-101").Return(1, nil)
+			},
+			wantErrMsg: "Department with the same name or cost center already exist",
+		},
+		{
+			name:    "error on update",
+			request: &model.UpdateDepartmentRequest{OrgID: 1, ID: 3, UserRole: "admin", CostCenter: &costCenter},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(3), "Finance", "CC-101").Return(0, nil)
+				dr.On("Update", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update department for id (3) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.UpdateDepartmentRequest{OrgID: 1, ID: 3, UserRole: "admin", CostCenter: &costCenter},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				dr.On("CountByNameOrCostCenter", mock.Anything, uint64(1), uint64(3), "Finance", "CC-101").Return(0, nil)
+				dr.On("Update", mock.Anything, mock.MatchedBy(func(d *entity.Department) bool {
+					return d.CostCenter == "CC-101"
+				})).Return(nil)
+			},
+			wantRes: &model.DepartmentResponse{ID: 3, Name: "Finance", CostCenter: "CC-101", CreatedAt: "2025-10-01T08:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			budgetRepository := mocks.NewDepartmentBudgetRepository(s.T())
+			usecase := usecase.NewDepartmentUsecase(s.log, departmentRepository, budgetRepository)
+			tt.mockFunc(departmentRepository, budgetRepository)
+
+			res, err := usecase.Update(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *DepartmentUsecaseSuite) TestDepartmentUsecase_ListBudgets() {
+	periodStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		request    *model.ListDepartmentBudgetRequest
+		mockFunc   DepartmentMockFunc
+		wantRes    []model.DepartmentBudgetResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ListDepartmentBudgetRequest{OrgID: 1, DepartmentID: 3, UserRole: "manager"},
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error department not found",
+			request: &model.ListDepartmentBudgetRequest{OrgID: 1, DepartmentID: 3, UserRole: "admin"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(nil, nil)
+			},
+			wantErrMsg: "Department not found",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListDepartmentBudgetRequest{OrgID: 1, DepartmentID: 3, UserRole: "admin"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				br.On("ListByDepartmentID", mock.Anything, mock.Anything, uint64(3)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list budgets for department id (3) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListDepartmentBudgetRequest{OrgID: 1, DepartmentID: 3, UserRole: "admin"},
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				br.On("ListByDepartmentID", mock.Anything, mock.Anything, uint64(3)).Return([]entity.DepartmentBudget{
+					{
+						ID:           5,
+						DepartmentID: 3,
+						PeriodStart:  periodStart,
+						PeriodEnd:    periodEnd,
+						Amount:       10_000_000,
+						Spent:        4_000_000,
+						CreatedAt:    s.now,
+					},
+				}, nil)
+			},
+			wantRes: []model.DepartmentBudgetResponse{
+				{
+					ID:                 5,
+					DepartmentID:       3,
+					PeriodStart:        "2025-10-01",
+					PeriodEnd:          "2025-10-31",
+					AmountIDR:          10_000_000,
+					AmountFormatted:    "Rp 10.000.000",
+					SpentIDR:           4_000_000,
+					SpentFormatted:     "Rp 4.000.000",
+					RemainingIDR:       6_000_000,
+					RemainingFormatted: "Rp 6.000.000",
+					CreatedAt:          "2025-10-01T08:00:00Z",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			budgetRepository := mocks.NewDepartmentBudgetRepository(s.T())
+			usecase := usecase.NewDepartmentUsecase(s.log, departmentRepository, budgetRepository)
+			tt.mockFunc(departmentRepository, budgetRepository)
+
+			res, err := usecase.ListBudgets(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *DepartmentUsecaseSuite) TestDepartmentUsecase_CreateBudget() {
+	periodStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	request := func(start, end string) *model.CreateDepartmentBudgetRequest {
+		return &model.CreateDepartmentBudgetRequest{
+			OrgID:        1,
+			DepartmentID: 3,
+			UserRole:     "admin",
+			PeriodStart:  start,
+			PeriodEnd:    end,
+			AmountIDR:    10_000_000,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.CreateDepartmentBudgetRequest
+		mockFunc   DepartmentMockFunc
+		wantRes    *model.DepartmentBudgetResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error not admin",
+			request: &model.CreateDepartmentBudgetRequest{
+				OrgID:        1,
+				DepartmentID: 3,
+				UserRole:     "manager",
+				PeriodStart:  "2025-10-01",
+				PeriodEnd:    "2025-10-31",
+				AmountIDR:    10_000_000,
+			},
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:       "error end before start",
+			request:    request("2025-10-31", "2025-10-01"),
+			mockFunc:   func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {},
+			wantErrMsg: "Budget period must end on or after its start",
+		},
+		{
+			name:    "error department not found",
+			request: request("2025-10-01", "2025-10-31"),
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(nil, nil)
+			},
+			wantErrMsg: "Department not found",
+		},
+		{
+			name:    "error overlap",
+			request: request("2025-10-01", "2025-10-31"),
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				br.On("Create", mock.Anything, mock.Anything).Return(false, nil)
+			},
+			wantErrMsg: "Budget period overlaps another budget of the department",
+		},
+		{
+			name:    "error on create",
+			request: request("2025-10-01", "2025-10-31"),
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				br.On("Create", mock.Anything, mock.Anything).Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to create budget for department id (3) = something error",
+		},
+		{
+			name:    "success",
+			request: request("2025-10-01", "2025-10-31"),
+			mockFunc: func(dr *mocks.DepartmentRepository, br *mocks.DepartmentBudgetRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).Return(s.department(), nil)
+				br.On("Create", mock.Anything, &entity.DepartmentBudget{
+					OrgID:        1,
+					DepartmentID: 3,
+					PeriodStart:  periodStart,
+					PeriodEnd:    periodEnd,
+					Amount:       10_000_000,
+				}).Return(true, nil)
+			},
+			wantRes: &model.DepartmentBudgetResponse{
+				DepartmentID:       3,
+				PeriodStart:        "2025-10-01",
+				PeriodEnd:          "2025-10-31",
+				AmountIDR:          10_000_000,
+				AmountFormatted:    "Rp 10.000.000",
+				SpentIDR:           0,
+				SpentFormatted:     "Rp 0",
+				RemainingIDR:       10_000_000,
+				RemainingFormatted: "Rp 10.000.000",
+				CreatedAt:          "0001-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			budgetRepository := mocks.NewDepartmentBudgetRepository(s.T())
+			usecase := usecase.NewDepartmentUsecase(s.log, departmentRepository, budgetRepository)
+			tt.mockFunc(departmentRepository, budgetRepository)
+
+			res, err := usecase.CreateBudget(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestDepartmentUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(DepartmentUsecaseSuite))
+}
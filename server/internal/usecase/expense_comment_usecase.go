@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/metrics"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type expenseCommentUsecase struct {
+	log                      *zap.Logger
+	tx                       db.Transactioner
+	expenseRepository        ExpenseRepository
+	userRepository           UserRepository
+	expenseCommentRepository ExpenseCommentRepository
+	expenseCommentedProducer *messaging.ExpenseCommentedProducer
+	editWindow               time.Duration
+}
+
+func NewExpenseCommentUsecase(log *zap.Logger, tx db.Transactioner, expenseRepository ExpenseRepository,
+	userRepository UserRepository, expenseCommentRepository ExpenseCommentRepository,
+	expenseCommentedProducer *messaging.ExpenseCommentedProducer, editWindow time.Duration) ExpenseCommentUsecase {
+	return &expenseCommentUsecase{
+		log:                      log,
+		tx:                       tx,
+		expenseRepository:        expenseRepository,
+		userRepository:           userRepository,
+		expenseCommentRepository: expenseCommentRepository,
+		expenseCommentedProducer: expenseCommentedProducer,
+		editWindow:               editWindow,
+	}
+}
+
+func (c *expenseCommentUsecase) List(ctx context.Context, req *model.GetExpenseRequest) ([]model.ExpenseCommentResponse, error) {
+	expense, err := c.findExpense(ctx, req.OrgID, req.ID, req.UserID, req.UserRole)
+	if err != nil {
+		return []model.ExpenseCommentResponse{}, err
+	}
+
+	comments, err := c.expenseCommentRepository.ListByExpenseID(ctx, expense.OrgID, expense.ID)
+	if err != nil {
+		return []model.ExpenseCommentResponse{}, fmt.Errorf("failed to list comments of expense id (%d) = %w", expense.ID, err)
+	}
+
+	return serializer.ExpenseCommentsToResponse(comments), nil
+}
+
+func (c *expenseCommentUsecase) Create(ctx context.Context, req *model.CreateExpenseCommentRequest) (*model.ExpenseCommentResponse, error) {
+	expense, err := c.findExpense(ctx, req.OrgID, req.ExpenseID, req.UserID, req.UserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := c.userRepository.FindByID(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", req.UserID, err)
+	}
+
+	if author == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	mentions, err := c.findMentions(ctx, expense, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.ExpenseComment{
+		OrgID:     expense.OrgID,
+		ExpenseID: expense.ID,
+		UserID:    author.ID,
+		Body:      strings.TrimSpace(req.Body),
+		User:      entity.UserSimple{ID: author.ID, Email: author.Email, Name: author.Name},
+		Mentions:  mentions,
+	}
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		txErr := c.expenseCommentRepository.CreateTx(ctx, exec, comment)
+		if txErr != nil {
+			return fmt.Errorf("failed to create comment = %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.sendCommentedEvent(newExpenseCommentedEvent(expense, comment))
+
+	return serializer.ExpenseCommentToResponse(comment), nil
+}
+
+// Update changes the body and the mentions of the comment, only the author can do it within the
+// edit window. Only the users newly mentioned by the edit are notified.
+func (c *expenseCommentUsecase) Update(ctx context.Context, req *model.UpdateExpenseCommentRequest) (*model.ExpenseCommentResponse, error) {
+	expense, comment, err := c.findOwnComment(ctx, req.OrgID, req.ExpenseID, req.ID, req.UserID, req.UserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := c.findMentions(ctx, expense, req.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := comment.Mentions
+	comment.Body = strings.TrimSpace(req.Body)
+	comment.Mentions = mentions
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		// the author and the window are checked again in the query, an edit racing the window can't slip through
+		updated, txErr := c.expenseCommentRepository.UpdateTx(ctx, exec, comment, c.editWindow)
+		if txErr != nil {
+			return fmt.Errorf("failed to update comment id (%d) = %w", comment.ID, txErr)
+		}
+
+		if !updated {
+			return model.NewCommentEditWindowError(c.editWindow)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.sendCommentedEvent(newExpenseMentionedEvent(expense, comment, previous))
+
+	return serializer.ExpenseCommentToResponse(comment), nil
+}
+
+func (c *expenseCommentUsecase) Delete(ctx context.Context, req *model.DeleteExpenseCommentRequest) error {
+	_, comment, err := c.findOwnComment(ctx, req.OrgID, req.ExpenseID, req.ID, req.UserID, req.UserRole)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := c.expenseCommentRepository.Delete(ctx, comment.OrgID, comment.ID, comment.UserID, c.editWindow)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment id (%d) = %w", comment.ID, err)
+	}
+
+	if !deleted {
+		return model.NewCommentEditWindowError(c.editWindow)
+	}
+
+	return nil
+}
+
+// sendCommentedEvent publishes the event when someone has to be notified. Same as the expense-approved
+// event, a failed publish is logged and counted, the comment stays.
+func (c *expenseCommentUsecase) sendCommentedEvent(event *model.ExpenseCommentedEvent) {
+	if len(event.RecipientIDs) == 0 {
+		return
+	}
+
+	eventStatus := "success"
+	err := c.expenseCommentedProducer.Send(event)
+	if err != nil {
+		eventStatus = "fail"
+		c.log.Error(
+			fmt.Sprintf("failed to send expense-commented event for id (%d) = %s", event.ID, err.Error()),
+			zap.Any("event", event),
+			zap.Strings("tags", []string{"expense", "comment", "send-event", "expense-commented"}),
+		)
+	}
+	metrics.IncrementEvent(metrics.EventPublishExpenseCommented, eventStatus)
+}
+
+// findExpense returns the expense when the user can see it, the same rule as the expense detail
+func (c *expenseCommentUsecase) findExpense(ctx context.Context, orgID uint64, id uint64, userID uint64, userRole string) (*entity.Expense, error) {
+	expense, err := c.expenseRepository.FindByID(ctx, orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense by id (%d) = %w", id, err)
+	}
+
+	if expense == nil {
+		return nil, model.ErrExpenseNotFound
+	}
+
+	if userID != expense.UserID && !entity.UserRole(userRole).AtLeast(entity.UserRoleManager) {
+		return nil, model.ErrForbidden
+	}
+
+	return expense, nil
+}
+
+// findOwnComment returns the comment when the user wrote it and it is still within the edit window
+func (c *expenseCommentUsecase) findOwnComment(ctx context.Context, orgID uint64, expenseID uint64, id uint64,
+	userID uint64, userRole string) (*entity.Expense, *entity.ExpenseComment, error) {
+	expense, err := c.findExpense(ctx, orgID, expenseID, userID, userRole)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment, err := c.expenseCommentRepository.FindByID(ctx, expense.OrgID, expense.ID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find comment by id (%d) = %w", id, err)
+	}
+
+	if comment == nil {
+		return nil, nil, model.ErrExpenseCommentNotFound
+	}
+
+	if comment.UserID != userID {
+		return nil, nil, model.ErrForbidden
+	}
+
+	if !comment.Editable(c.editWindow, time.Now()) {
+		return nil, nil, model.NewCommentEditWindowError(c.editWindow)
+	}
+
+	return expense, comment, nil
+}
+
+// findMentions resolves the mentioned users, each one must be active and able to see the expense
+func (c *expenseCommentUsecase) findMentions(ctx context.Context, expense *entity.Expense, ids []uint64) ([]entity.UserSimple, error) {
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	users, err := c.userRepository.ListByOrgIDAndIDs(ctx, expense.OrgID, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentioned users = %w", err)
+	}
+
+	if len(users) != len(unique) {
+		return nil, model.ErrInvalidCommentMention
+	}
+
+	mentions := make([]entity.UserSimple, len(users))
+	for i, u := range users {
+		if !u.Active || !u.CanSeeExpense(expense) {
+			return nil, model.ErrInvalidCommentMention
+		}
+		mentions[i] = entity.UserSimple{ID: u.ID, Email: u.Email, Name: u.Name}
+	}
+
+	return mentions, nil
+}
+
+// newExpenseCommentedEvent notifies the submitter and the mentioned users, never the author
+func newExpenseCommentedEvent(expense *entity.Expense, comment *entity.ExpenseComment) *model.ExpenseCommentedEvent {
+	event := &model.ExpenseCommentedEvent{
+		ID:               comment.ID,
+		OrgID:            expense.OrgID,
+		ExpenseID:        expense.ID,
+		UserID:           comment.UserID,
+		MentionedUserIDs: []uint64{},
+		RecipientIDs:     []uint64{},
+	}
+
+	if expense.UserID != comment.UserID {
+		event.RecipientIDs = append(event.RecipientIDs, expense.UserID)
+	}
+
+	for _, m := range comment.Mentions {
+		event.MentionedUserIDs = append(event.MentionedUserIDs, m.ID)
+		if m.ID != comment.UserID && m.ID != expense.UserID {
+			event.RecipientIDs = append(event.RecipientIDs, m.ID)
+		}
+	}
+
+	return event
+}
+
+// newExpenseMentionedEvent notifies the users mentioned by an edit that weren't mentioned before. The submitter
+// and the author are skipped, the submitter already heard about the comment when it was created.
+func newExpenseMentionedEvent(expense *entity.Expense, comment *entity.ExpenseComment,
+	previous []entity.UserSimple) *model.ExpenseCommentedEvent {
+	event := &model.ExpenseCommentedEvent{
+		ID:               comment.ID,
+		OrgID:            expense.OrgID,
+		ExpenseID:        expense.ID,
+		UserID:           comment.UserID,
+		MentionedUserIDs: []uint64{},
+		RecipientIDs:     []uint64{},
+	}
+
+	mentioned := make(map[uint64]bool, len(previous))
+	for _, m := range previous {
+		mentioned[m.ID] = true
+	}
+
+	for _, m := range comment.Mentions {
+		if mentioned[m.ID] {
+			continue
+		}
+
+		event.MentionedUserIDs = append(event.MentionedUserIDs, m.ID)
+		if m.ID != comment.UserID && m.ID != expense.UserID {
+			event.RecipientIDs = append(event.RecipientIDs, m.ID)
+		}
+	}
+
+	return event
+}
@@ -0,0 +1,471 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseCommentUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type ExpenseCommentMockFunc func(
+	db pgxmock.PgxPoolIface,
+	er *mocks.ExpenseRepository,
+	ur *mocks.UserRepository,
+	ecr *mocks.ExpenseCommentRepository,
+	p *mocks.Producer[*model.ExpenseCommentedEvent],
+)
+
+func (s *ExpenseCommentUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *ExpenseCommentUsecaseSuite) run(mockFunc ExpenseCommentMockFunc, fn func(u usecase.ExpenseCommentUsecase)) {
+	dbMock, _ := pgxmock.NewPool()
+	defer dbMock.Close()
+	tx := db.NewTransactioner(dbMock)
+
+	er := mocks.NewExpenseRepository(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	ecr := mocks.NewExpenseCommentRepository(s.T())
+	p := mocks.NewProducer[*model.ExpenseCommentedEvent](s.T())
+	ecp := &messaging.ExpenseCommentedProducer{
+		Producer: p,
+	}
+	mockFunc(dbMock, er, ur, ecr, p)
+
+	fn(usecase.NewExpenseCommentUsecase(s.log, tx, er, ur, ecr, ecp, 15*time.Minute))
+	s.Nil(dbMock.ExpectationsWereMet())
+}
+
+func (s *ExpenseCommentUsecaseSuite) TestExpenseCommentUsecase_List() {
+	now := time.Date(2025, 9, 30, 8, 0, 0, 0, time.UTC)
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: 2}
+
+	tests := []struct {
+		name       string
+		request    *model.GetExpenseRequest
+		mockFunc   ExpenseCommentMockFunc
+		wantRes    []model.ExpenseCommentResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error on expense not found",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 2, UserRole: "employee"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(nil, nil)
+			},
+			wantErrMsg: "Expense not found",
+		},
+		{
+			name:    "error on expense of another employee",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 5, UserRole: "employee"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list comments",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 2, UserRole: "employee"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(7)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list comments of expense id (7) = something error",
+		},
+		{
+			name:    "success as manager",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 3, UserRole: "manager"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(7)).Return([]entity.ExpenseComment{
+					{
+						ID: 1, ExpenseID: 7, UserID: 3, Body: "Which client was this for?", CreatedAt: now,
+						User:     entity.UserSimple{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+						Mentions: []entity.UserSimple{{ID: 2, Email: "john@mail.com", Name: "John Doe"}},
+					},
+				}, nil)
+			},
+			wantRes: []model.ExpenseCommentResponse{
+				{
+					ID:        1,
+					Body:      "Which client was this for?",
+					User:      model.UserSimpleResponse{ID: 3, Email: "jane@mail.com", Name: "Jane Roe"},
+					Mentions:  []model.UserSimpleResponse{{ID: 2, Email: "john@mail.com", Name: "John Doe"}},
+					CreatedAt: now.Format(time.RFC3339),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.run(tt.mockFunc, func(u usecase.ExpenseCommentUsecase) {
+				res, err := u.List(s.ctx, tt.request)
+
+				if tt.wantErrMsg != "" {
+					s.Equal(tt.wantErrMsg, err.Error())
+				} else {
+					s.Nil(err)
+					s.Equal(tt.wantRes, res)
+				}
+			})
+		})
+	}
+}
+
+func (s *ExpenseCommentUsecaseSuite) TestExpenseCommentUsecase_Create() {
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: 2}
+	manager := &entity.User{ID: 3, OrgID: 1, Email: "jane@mail.com", Name: "Jane Roe", Role: entity.UserRoleManager, Active: true}
+	submitter := entity.User{ID: 2, OrgID: 1, Email: "john@mail.com", Name: "John Doe", Role: entity.UserRoleEmployee, Active: true}
+	request := &model.CreateExpenseCommentRequest{
+		ExpenseID: 7, OrgID: 1, UserID: 3, UserRole: "manager",
+		Body: "  Please attach the boarding pass  ", Mentions: []uint64{2, 2},
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.CreateExpenseCommentRequest
+		mockFunc   ExpenseCommentMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find expense",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find expense by id (7) = something error",
+		},
+		{
+			name: "error on expense of another employee",
+			request: &model.CreateExpenseCommentRequest{
+				ExpenseID: 7, OrgID: 1, UserID: 5, UserRole: "employee", Body: "Hello",
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on mentioned user not found",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(3)).Return(manager, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{2}).Return([]entity.User{}, nil)
+			},
+			wantErrMsg: "Only the submitter and the managers of the organization can be mentioned",
+		},
+		{
+			name: "error on mentioned user can't see the expense",
+			request: &model.CreateExpenseCommentRequest{
+				ExpenseID: 7, OrgID: 1, UserID: 3, UserRole: "manager", Body: "Hello", Mentions: []uint64{5},
+			},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(3)).Return(manager, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{5}).Return([]entity.User{
+					{ID: 5, OrgID: 1, Role: entity.UserRoleEmployee, Active: true},
+				}, nil)
+			},
+			wantErrMsg: "Only the submitter and the managers of the organization can be mentioned",
+		},
+		{
+			name:    "error on create comment",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(3)).Return(manager, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{2}).Return([]entity.User{submitter}, nil)
+				db.ExpectBegin()
+				ecr.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to create comment = something error",
+		},
+		{
+			name:    "success with failed event",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(3)).Return(manager, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{2}).Return([]entity.User{submitter}, nil)
+				db.ExpectBegin()
+				ecr.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				db.ExpectCommit()
+				p.On("Send", mock.Anything).Return(errors.New("something error"))
+			},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(3)).Return(manager, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{2}).Return([]entity.User{submitter}, nil)
+				db.ExpectBegin()
+				ecr.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(c *entity.ExpenseComment) bool {
+					return c.ExpenseID == 7 && c.UserID == 3 && c.Body == "Please attach the boarding pass" &&
+						len(c.Mentions) == 1 && c.Mentions[0].ID == 2
+				})).Run(func(args mock.Arguments) {
+					args.Get(2).(*entity.ExpenseComment).ID = 11
+				}).Return(nil)
+				db.ExpectCommit()
+				p.On("Send", &model.ExpenseCommentedEvent{
+					ID: 11, OrgID: 1, ExpenseID: 7, UserID: 3,
+					MentionedUserIDs: []uint64{2},
+					RecipientIDs:     []uint64{2},
+				}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.run(tt.mockFunc, func(u usecase.ExpenseCommentUsecase) {
+				res, err := u.Create(s.ctx, tt.request)
+
+				if tt.wantErrMsg != "" {
+					s.Equal(tt.wantErrMsg, err.Error())
+				} else {
+					s.Nil(err)
+					s.Equal("Please attach the boarding pass", res.Body)
+					s.Equal(uint64(3), res.User.ID)
+					s.Len(res.Mentions, 1)
+				}
+			})
+		})
+	}
+}
+
+func (s *ExpenseCommentUsecaseSuite) TestExpenseCommentUsecase_Update() {
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: 2}
+	request := &model.UpdateExpenseCommentRequest{
+		ID: 11, ExpenseID: 7, OrgID: 1, UserID: 2, UserRole: "employee", Body: "Attached now",
+	}
+	mentionRequest := &model.UpdateExpenseCommentRequest{
+		ID: 11, ExpenseID: 7, OrgID: 1, UserID: 2, UserRole: "employee", Body: "Attached now", Mentions: []uint64{4, 5},
+	}
+	manager := entity.User{ID: 4, OrgID: 1, Email: "jane@mail.com", Name: "Jane Roe", Role: entity.UserRoleManager, Active: true}
+	admin := entity.User{ID: 5, OrgID: 1, Email: "mike@mail.com", Name: "Mike Poe", Role: entity.UserRoleAdmin, Active: true}
+	comment := func(userID uint64, createdAt time.Time) *entity.ExpenseComment {
+		return &entity.ExpenseComment{
+			ID: 11, ExpenseID: 7, UserID: userID, Body: "Attached", CreatedAt: createdAt,
+			User: entity.UserSimple{ID: userID},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateExpenseCommentRequest
+		mockFunc   ExpenseCommentMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error on comment not found",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(nil, nil)
+			},
+			wantErrMsg: "Comment not found",
+		},
+		{
+			name:    "error on comment of another user",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment(3, time.Now()), nil)
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on edit window passed",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment(2, time.Now().Add(-time.Hour)), nil)
+			},
+			wantErrMsg: "Comments can only be edited or deleted within 15 minutes of posting",
+		},
+		{
+			name:    "error on update comment",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment(2, time.Now()), nil)
+				db.ExpectBegin()
+				ecr.On("UpdateTx", mock.Anything, mock.Anything, mock.Anything, 15*time.Minute).Return(false, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to update comment id (11) = something error",
+		},
+		{
+			name:    "error on edit window passed while updating",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment(2, time.Now()), nil)
+				db.ExpectBegin()
+				ecr.On("UpdateTx", mock.Anything, mock.Anything, mock.Anything, 15*time.Minute).Return(false, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Comments can only be edited or deleted within 15 minutes of posting",
+		},
+		{
+			name:    "success notifies newly mentioned users",
+			request: mentionRequest,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				mentioned := comment(2, time.Now())
+				mentioned.Mentions = []entity.UserSimple{{ID: 4}}
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(mentioned, nil)
+				ur.On("ListByOrgIDAndIDs", mock.Anything, uint64(1), []uint64{4, 5}).Return([]entity.User{manager, admin}, nil)
+				db.ExpectBegin()
+				ecr.On("UpdateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(c *entity.ExpenseComment) bool {
+					return c.Body == "Attached now" && len(c.Mentions) == 2
+				}), 15*time.Minute).Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", &model.ExpenseCommentedEvent{
+					ID: 11, OrgID: 1, ExpenseID: 7, UserID: 2,
+					MentionedUserIDs: []uint64{5},
+					RecipientIDs:     []uint64{5},
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment(2, time.Now()), nil)
+				db.ExpectBegin()
+				ecr.On("UpdateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(c *entity.ExpenseComment) bool {
+					return c.Body == "Attached now" && len(c.Mentions) == 0
+				}), 15*time.Minute).Return(true, nil)
+				db.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.run(tt.mockFunc, func(u usecase.ExpenseCommentUsecase) {
+				res, err := u.Update(s.ctx, tt.request)
+
+				if tt.wantErrMsg != "" {
+					s.Equal(tt.wantErrMsg, err.Error())
+				} else {
+					s.Nil(err)
+					s.Equal("Attached now", res.Body)
+				}
+			})
+		})
+	}
+}
+
+func (s *ExpenseCommentUsecaseSuite) TestExpenseCommentUsecase_Delete() {
+	expense := &entity.Expense{ID: 7, OrgID: 1, UserID: 2}
+	request := &model.DeleteExpenseCommentRequest{ID: 11, ExpenseID: 7, OrgID: 1, UserID: 3, UserRole: "manager"}
+	comment := &entity.ExpenseComment{ID: 11, ExpenseID: 7, UserID: 3, CreatedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		mockFunc   ExpenseCommentMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on find comment",
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find comment by id (11) = something error",
+		},
+		{
+			name: "error on delete comment",
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment, nil)
+				ecr.On("Delete", mock.Anything, mock.Anything, uint64(11), uint64(3), 15*time.Minute).Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete comment id (11) = something error",
+		},
+		{
+			name: "error on edit window passed while deleting",
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment, nil)
+				ecr.On("Delete", mock.Anything, mock.Anything, uint64(11), uint64(3), 15*time.Minute).Return(false, nil)
+			},
+			wantErrMsg: "Comments can only be edited or deleted within 15 minutes of posting",
+		},
+		{
+			name: "success",
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, ur *mocks.UserRepository,
+				ecr *mocks.ExpenseCommentRepository, p *mocks.Producer[*model.ExpenseCommentedEvent]) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(expense, nil)
+				ecr.On("FindByID", mock.Anything, mock.Anything, uint64(7), uint64(11)).Return(comment, nil)
+				ecr.On("Delete", mock.Anything, mock.Anything, uint64(11), uint64(3), 15*time.Minute).Return(true, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.run(tt.mockFunc, func(u usecase.ExpenseCommentUsecase) {
+				err := u.Delete(s.ctx, request)
+
+				if tt.wantErrMsg != "" {
+					s.Equal(tt.wantErrMsg, err.Error())
+				} else {
+					s.Nil(err)
+				}
+			})
+		})
+	}
+}
+
+func TestExpenseCommentUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseCommentUsecaseSuite))
+}
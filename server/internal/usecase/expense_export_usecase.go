@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/export"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type expenseExportUsecase struct {
+	log                            *zap.Logger
+	expenseRepository              ExpenseRepository
+	expenseExportRepository        ExpenseExportRepository
+	fileStorage                    storage.FileStorage
+	expenseExportRequestedProducer *messaging.ExpenseExportRequestedProducer
+	syncMaxRows                    int
+}
+
+func NewExpenseExportUsecase(log *zap.Logger, expenseRepository ExpenseRepository,
+	expenseExportRepository ExpenseExportRepository, fileStorage storage.FileStorage,
+	expenseExportRequestedProducer *messaging.ExpenseExportRequestedProducer, syncMaxRows int) ExpenseExportUsecase {
+	return &expenseExportUsecase{
+		log:                            log,
+		expenseRepository:              expenseRepository,
+		expenseExportRepository:        expenseExportRepository,
+		fileStorage:                    fileStorage,
+		expenseExportRequestedProducer: expenseExportRequestedProducer,
+		syncMaxRows:                    syncMaxRows,
+	}
+}
+
+// Create queues the export as a background job when it's asked for or has more than the
+// sync limit of rows, a nil response means it's small enough to be streamed with Stream
+func (c *expenseExportUsecase) Create(ctx context.Context, req *model.CreateExpenseExportRequest) (*model.ExpenseExportResponse, error) {
+	if req.View.ManagerOnly() && !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return nil, model.ErrForbidden
+	}
+
+	format, err := entity.ParseExportFormat(req.Format)
+	if err != nil {
+		return nil, model.NewInvalidFilterError("format")
+	}
+
+	if !req.Async {
+		total, err := c.expenseRepository.Count(ctx, &req.ListExpenseRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count expenses = %w", err)
+		}
+
+		if total <= c.syncMaxRows {
+			return nil, nil
+		}
+	}
+
+	filters, err := json.Marshal(req.ListExpenseRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export filters = %w", err)
+	}
+
+	expenseExport := &entity.ExpenseExport{
+		OrgID:   req.OrgID,
+		UserID:  req.UserID,
+		Format:  format,
+		Filters: filters,
+		Status:  entity.ExpenseExportStatusPending,
+	}
+	err = c.expenseExportRepository.Create(ctx, expenseExport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create expense export = %w", err)
+	}
+
+	event := model.ExpenseExportRequestedEvent{
+		ID:     expenseExport.ID,
+		OrgID:  expenseExport.OrgID,
+		UserID: expenseExport.UserID,
+	}
+	err = c.expenseExportRequestedProducer.Send(&event)
+	if err != nil {
+		// nothing would pick the export up, the user gets an error and can ask again
+		statusErr := c.expenseExportRepository.UpdateStatus(ctx, expenseExport.ID, entity.ExpenseExportStatusFailed)
+		if statusErr != nil {
+			c.log.Error(
+				fmt.Sprintf("failed to mark expense export as failed for id (%d) = %s", expenseExport.ID, statusErr.Error()),
+				zap.Strings("tags", []string{"expense-export", "create", "update-status"}),
+			)
+		}
+		return nil, fmt.Errorf("failed to send expense-export-requested event for id (%d) = %w", expenseExport.ID, err)
+	}
+
+	return serializer.ExpenseExportToResponse(expenseExport), nil
+}
+
+// Stream writes the export to w while the rows are read, it returns the number of expenses written
+func (c *expenseExportUsecase) Stream(ctx context.Context, req *model.CreateExpenseExportRequest, w io.Writer) (int, error) {
+	format, err := entity.ParseExportFormat(req.Format)
+	if err != nil {
+		return 0, model.NewInvalidFilterError("format")
+	}
+
+	return c.write(ctx, &req.ListExpenseRequest, format, w)
+}
+
+func (c *expenseExportUsecase) Get(ctx context.Context, req *model.GetExpenseExportRequest) (*model.ExpenseExportResponse, error) {
+	expenseExport, err := c.find(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.ExpenseExportToResponse(expenseExport), nil
+}
+
+// Download opens the file of a completed export, the content is read from the file storage
+// while it's written so a large export is never held in memory whole
+func (c *expenseExportUsecase) Download(ctx context.Context, req *model.GetExpenseExportRequest) (*model.FileStreamResponse, error) {
+	expenseExport, err := c.find(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if expenseExport.Status != entity.ExpenseExportStatusCompleted || expenseExport.FileKey == nil {
+		return nil, model.ErrExpenseExportNotReady
+	}
+
+	file, err := c.fileStorage.Open(ctx, *expenseExport.FileKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open expense export file (%s) = %w", *expenseExport.FileKey, err)
+	}
+
+	if file == nil {
+		return nil, fmt.Errorf("expense export file (%s) not found", *expenseExport.FileKey)
+	}
+
+	return &model.FileStreamResponse{
+		Name:        ExpenseExportFileName(expenseExport.CreatedAt, expenseExport.Format),
+		ContentType: file.ContentType,
+		Content:     file.Content,
+	}, nil
+}
+
+// Process runs an export that was queued by Create, a redelivered event of a completed export is skipped
+func (c *expenseExportUsecase) Process(ctx context.Context, req *model.ProcessExpenseExportRequest) error {
+	expenseExport, err := c.expenseExportRepository.FindByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find expense export by id (%d) = %w", req.ID, err)
+	}
+
+	if expenseExport == nil {
+		c.log.Warn(fmt.Sprintf("expense export not found for id (%d)", req.ID))
+		return nil
+	}
+
+	if expenseExport.Status == entity.ExpenseExportStatusCompleted {
+		return nil
+	}
+
+	err = c.expenseExportRepository.UpdateStatus(ctx, expenseExport.ID, entity.ExpenseExportStatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to update expense export status for id (%d) = %w", expenseExport.ID, err)
+	}
+
+	filters := new(model.ListExpenseRequest)
+	err = json.Unmarshal(expenseExport.Filters, filters)
+	if err != nil {
+		return c.fail(ctx, expenseExport.ID, fmt.Errorf("failed to unmarshal export filters for id (%d) = %w", expenseExport.ID, err))
+	}
+
+	fileKey := entity.ExpenseExportFileKey(expenseExport.OrgID, expenseExport.ID, expenseExport.Format)
+	rowCount, err := c.store(ctx, filters, expenseExport.Format, fileKey)
+	if err != nil {
+		return c.fail(ctx, expenseExport.ID, fmt.Errorf("failed to write expense export for id (%d) = %w", expenseExport.ID, err))
+	}
+
+	err = c.expenseExportRepository.Complete(ctx, expenseExport.ID, fileKey, rowCount, time.Now())
+	if err != nil {
+		return c.fail(ctx, expenseExport.ID, fmt.Errorf("failed to complete expense export for id (%d) = %w", expenseExport.ID, err))
+	}
+
+	return nil
+}
+
+// ExpenseExportFileName is the name of the downloaded file, e.g. expenses-20251019-080000.xlsx
+func ExpenseExportFileName(at time.Time, format entity.ExportFormat) string {
+	return fmt.Sprintf("expenses-%s.%s", at.UTC().Format("20060102-150405"), format)
+}
+
+func (c *expenseExportUsecase) find(ctx context.Context, req *model.GetExpenseExportRequest) (*entity.ExpenseExport, error) {
+	expenseExport, err := c.expenseExportRepository.FindByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense export by id (%d) = %w", req.ID, err)
+	}
+
+	// the file has the expenses the requester was allowed to see, nobody else gets it
+	if expenseExport == nil || expenseExport.UserID != req.UserID {
+		return nil, model.ErrExpenseExportNotFound
+	}
+
+	return expenseExport, nil
+}
+
+// store writes the export to a temporary file first and then streams it into the file storage,
+// so the rows aren't held in memory and the database transaction doesn't wait on the export query
+func (c *expenseExportUsecase) store(ctx context.Context, filters *model.ListExpenseRequest, format entity.ExportFormat,
+	key string) (int, error) {
+	file, err := os.CreateTemp("", "expense-export-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file = %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	rowCount, err := c.write(ctx, filters, format, file)
+	if err != nil {
+		return rowCount, err
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return rowCount, fmt.Errorf("failed to rewind temporary file = %w", err)
+	}
+
+	err = c.fileStorage.PutStream(ctx, key, format.ContentType(), file)
+	if err != nil {
+		return rowCount, fmt.Errorf("failed to store file (%s) = %w", key, err)
+	}
+
+	return rowCount, nil
+}
+
+func (c *expenseExportUsecase) write(ctx context.Context, filters *model.ListExpenseRequest, format entity.ExportFormat,
+	w io.Writer) (int, error) {
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writer.Write(serializer.ExpenseExportHeader)
+	if err != nil {
+		return 0, err
+	}
+
+	rowCount := 0
+	err = c.expenseRepository.Stream(ctx, filters, func(detail *entity.ExpenseDetail) error {
+		rowCount++
+		return writer.Write(serializer.ExpenseToExportRow(detail))
+	})
+	if err != nil {
+		return rowCount, fmt.Errorf("failed to stream expenses = %w", err)
+	}
+
+	return rowCount, writer.Close()
+}
+
+// fail marks the export as failed and returns err, the consumer retries it
+func (c *expenseExportUsecase) fail(ctx context.Context, id uint64, err error) error {
+	statusErr := c.expenseExportRepository.UpdateStatus(ctx, id, entity.ExpenseExportStatusFailed)
+	if statusErr != nil {
+		c.log.Error(
+			fmt.Sprintf("failed to mark expense export as failed for id (%d) = %s", id, statusErr.Error()),
+			zap.Strings("tags", []string{"expense-export", "process", "update-status"}),
+		)
+	}
+
+	return err
+}
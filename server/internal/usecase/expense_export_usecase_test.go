@@ -0,0 +1,552 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/messaging"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"expense-management-system/internal/usecase"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type ExpenseExportUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+func (s *ExpenseExportUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *ExpenseExportUsecaseSuite) TestExpenseExportUsecase_Create() {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		request  *model.CreateExpenseExportRequest
+		mockFunc func(
+			er *mocks.ExpenseRepository,
+			eer *mocks.ExpenseExportRepository,
+			p *mocks.Producer[*model.ExpenseExportRequestedEvent],
+		)
+		wantRes    *model.ExpenseExportResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error forbidden organization",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "employee", View: model.ExpenseViewOrganization},
+				Format:             "csv",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error invalid format",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "employee", View: model.ExpenseViewPersonal},
+				Format:             "pdf",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+			},
+			wantErrMsg: "Invalid value for the 'format' filter",
+		},
+		{
+			name: "error on count",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "employee", View: model.ExpenseViewPersonal},
+				Format:             "csv",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+				er.On("Count", mock.Anything, mock.Anything).Return(0, errors.New("something error"))
+			},
+			wantErrMsg: "failed to count expenses = something error",
+		},
+		{
+			name: "success small export is streamed",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "employee", View: model.ExpenseViewPersonal},
+				Format:             "csv",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+				er.On("Count", mock.Anything, mock.Anything).Return(100, nil)
+			},
+			wantRes:    nil,
+			wantErrMsg: "",
+		},
+		{
+			name: "error on create",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "manager", View: model.ExpenseViewOrganization},
+				Format:             "xlsx",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+				er.On("Count", mock.Anything, mock.Anything).Return(101, nil)
+				eer.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create expense export = something error",
+		},
+		{
+			name: "error on send marks the export as failed",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{UserRole: "manager", View: model.ExpenseViewOrganization},
+				Format:             "xlsx",
+				Async:              true,
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+				eer.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.ExpenseExport).ID = 5
+				}).Return(nil)
+				p.On("Send", mock.Anything).Return(errors.New("something error"))
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusFailed).Return(nil)
+			},
+			wantErrMsg: "failed to send expense-export-requested event for id (5) = something error",
+		},
+		{
+			name: "success large export is queued",
+			request: &model.CreateExpenseExportRequest{
+				ListExpenseRequest: model.ListExpenseRequest{
+					OrgID:    1,
+					UserID:   2,
+					UserRole: "manager",
+					View:     model.ExpenseViewOrganization,
+				},
+				Format: "xlsx",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository,
+				p *mocks.Producer[*model.ExpenseExportRequestedEvent]) {
+				er.On("Count", mock.Anything, mock.Anything).Return(101, nil)
+				eer.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.ExpenseExport) bool {
+					return e.OrgID == 1 && e.UserID == 2 && e.Format == entity.ExportFormatXLSX &&
+						e.Status == entity.ExpenseExportStatusPending && bytes.Contains(e.Filters, []byte(`"view":"organization"`))
+				})).Run(func(args mock.Arguments) {
+					e := args.Get(1).(*entity.ExpenseExport)
+					e.ID = 5
+					e.CreatedAt = now
+				}).Return(nil)
+				p.On("Send", &model.ExpenseExportRequestedEvent{ID: 5, OrgID: 1, UserID: 2}).Return(nil)
+			},
+			wantRes: &model.ExpenseExportResponse{
+				ID:        5,
+				Format:    "xlsx",
+				Status:    "pending",
+				CreatedAt: "2025-09-26T08:00:00Z",
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			er := mocks.NewExpenseRepository(s.T())
+			eer := mocks.NewExpenseExportRepository(s.T())
+			p := mocks.NewProducer[*model.ExpenseExportRequestedEvent](s.T())
+			eerp := &messaging.ExpenseExportRequestedProducer{
+				Producer: p,
+			}
+
+			usecase := usecase.NewExpenseExportUsecase(s.log, er, eer, nil, eerp, 100)
+			tt.mockFunc(er, eer, p)
+
+			res, err := usecase.Create(s.ctx, tt.request)
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportUsecaseSuite) TestExpenseExportUsecase_Stream() {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	detail := &entity.ExpenseDetail{
+		Expense: entity.Expense{
+			ID:                7,
+			Amount:            15000,
+			Description:       "taxi",
+			Status:            entity.ExpenseStatusApproved,
+			ApprovalThreshold: 1000000,
+			CreatedAt:         now,
+		},
+		User: entity.UserSimple{ID: 2, Email: "john@mail.com", Name: "John Doe"},
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(er *mocks.ExpenseRepository)
+		wantRows   int
+		wantOutput string
+		wantErrMsg string
+	}{
+		{
+			name: "error on stream",
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to stream expenses = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("Stream", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					fn := args.Get(2).(func(*entity.ExpenseDetail) error)
+					_ = fn(detail)
+				}).Return(nil)
+			},
+			wantRows: 1,
+			wantOutput: "Expense ID,Reference,Created At,Description,Amount (IDR),Status,Auto Approved,Cost Center,Receipt URL," +
+				"Submitter Name,Submitter Email,Approval Status,Approver Name,Approver Email,Approval Notes,Decided At," +
+				"Payment Reference,Paid At\n" +
+				"7,EXP-000000007," + now.Format(time.RFC3339) + ",taxi,15000,approved,yes,,,John Doe,john@mail.com,,,,,,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			er := mocks.NewExpenseRepository(s.T())
+
+			usecase := usecase.NewExpenseExportUsecase(s.log, er, nil, nil, nil, 100)
+			tt.mockFunc(er)
+
+			var buf bytes.Buffer
+			rows, err := usecase.Stream(s.ctx, &model.CreateExpenseExportRequest{Format: "csv"}, &buf)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRows, rows)
+				s.Equal(tt.wantOutput, buf.String())
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportUsecaseSuite) TestExpenseExportUsecase_Get() {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	rowCount := 12000
+
+	tests := []struct {
+		name       string
+		mockFunc   func(eer *mocks.ExpenseExportRepository)
+		wantRes    *model.ExpenseExportResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			mockFunc: func(eer *mocks.ExpenseExportRepository) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find expense export by id (5) = something error",
+		},
+		{
+			name: "error not found",
+			mockFunc: func(eer *mocks.ExpenseExportRepository) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(nil, nil)
+			},
+			wantErrMsg: "Export not found",
+		},
+		{
+			name: "error export of another user",
+			mockFunc: func(eer *mocks.ExpenseExportRepository) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{ID: 5, UserID: 3}, nil)
+			},
+			wantErrMsg: "Export not found",
+		},
+		{
+			name: "success",
+			mockFunc: func(eer *mocks.ExpenseExportRepository) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:          5,
+					OrgID:       1,
+					UserID:      2,
+					Format:      entity.ExportFormatCSV,
+					Status:      entity.ExpenseExportStatusCompleted,
+					RowCount:    &rowCount,
+					CreatedAt:   now,
+					CompletedAt: &now,
+				}, nil)
+			},
+			wantRes: &model.ExpenseExportResponse{
+				ID:          5,
+				Format:      "csv",
+				Status:      "completed",
+				RowCount:    &rowCount,
+				DownloadURL: func() *string { s := "/api/expenses/exports/5/download"; return &s }(),
+				CreatedAt:   "2025-09-26T08:00:00Z",
+				CompletedAt: func() *string { s := "2025-09-26T08:00:00Z"; return &s }(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eer := mocks.NewExpenseExportRepository(s.T())
+
+			usecase := usecase.NewExpenseExportUsecase(s.log, nil, eer, nil, nil, 100)
+			tt.mockFunc(eer)
+
+			res, err := usecase.Get(s.ctx, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 2})
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportUsecaseSuite) TestExpenseExportUsecase_Download() {
+	now := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	fileKey := "exports/1/5.xlsx"
+	content := strings.NewReader("PK")
+
+	tests := []struct {
+		name       string
+		mockFunc   func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage)
+		wantRes    *model.FileStreamResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error not found",
+			mockFunc: func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(nil, nil)
+			},
+			wantErrMsg: "Export not found",
+		},
+		{
+			name: "error not ready",
+			mockFunc: func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:     5,
+					UserID: 2,
+					Status: entity.ExpenseExportStatusProcessing,
+				}, nil)
+			},
+			wantErrMsg: "Export is not ready yet, check its status and try again later",
+		},
+		{
+			name: "error on open file",
+			mockFunc: func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:      5,
+					UserID:  2,
+					Status:  entity.ExpenseExportStatusCompleted,
+					FileKey: &fileKey,
+				}, nil)
+				fs.On("Open", mock.Anything, fileKey).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to open expense export file (exports/1/5.xlsx) = something error",
+		},
+		{
+			name: "error file not found",
+			mockFunc: func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:      5,
+					UserID:  2,
+					Status:  entity.ExpenseExportStatusCompleted,
+					FileKey: &fileKey,
+				}, nil)
+				fs.On("Open", mock.Anything, fileKey).Return(nil, nil)
+			},
+			wantErrMsg: "expense export file (exports/1/5.xlsx) not found",
+		},
+		{
+			name: "success",
+			mockFunc: func(eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:        5,
+					UserID:    2,
+					Format:    entity.ExportFormatXLSX,
+					Status:    entity.ExpenseExportStatusCompleted,
+					FileKey:   &fileKey,
+					CreatedAt: now,
+				}, nil)
+				fs.On("Open", mock.Anything, fileKey).Return(&storage.FileStream{
+					Key:         fileKey,
+					ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+					Content:     content,
+				}, nil)
+			},
+			wantRes: &model.FileStreamResponse{
+				Name:        "expenses-20250926-080000.xlsx",
+				ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+				Content:     content,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			eer := mocks.NewExpenseExportRepository(s.T())
+			fs := mocks.NewFileStorage(s.T())
+
+			usecase := usecase.NewExpenseExportUsecase(s.log, nil, eer, fs, nil, 100)
+			tt.mockFunc(eer, fs)
+
+			res, err := usecase.Download(s.ctx, &model.GetExpenseExportRequest{ID: 5, OrgID: 1, UserID: 2})
+
+			s.Equal(tt.wantRes, res)
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *ExpenseExportUsecaseSuite) TestExpenseExportUsecase_Process() {
+	pending := func() *entity.ExpenseExport {
+		return &entity.ExpenseExport{
+			ID:      5,
+			OrgID:   1,
+			UserID:  2,
+			Format:  entity.ExportFormatCSV,
+			Filters: []byte(`{"org_id":1,"user_id":2,"view":"personal"}`),
+			Status:  entity.ExpenseExportStatusPending,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find expense export by id (5) = something error",
+		},
+		{
+			name: "success skip not found",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(nil, nil)
+			},
+		},
+		{
+			name: "success skip completed",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(&entity.ExpenseExport{
+					ID:     5,
+					Status: entity.ExpenseExportStatusCompleted,
+				}, nil)
+			},
+		},
+		{
+			name: "error on update status",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(pending(), nil)
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusProcessing).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update expense export status for id (5) = something error",
+		},
+		{
+			name: "error on stream marks the export as failed",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(pending(), nil)
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusProcessing).Return(nil)
+				er.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusFailed).Return(nil)
+			},
+			wantErrMsg: "failed to write expense export for id (5) = failed to stream expenses = something error",
+		},
+		{
+			name: "error on store file marks the export as failed",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(pending(), nil)
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusProcessing).Return(nil)
+				er.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				fs.On("PutStream", mock.Anything, "exports/1/5.csv", "text/csv; charset=utf-8", mock.Anything).
+					Return(errors.New("something error"))
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusFailed).Return(nil)
+			},
+			wantErrMsg: "failed to write expense export for id (5) = failed to store file (exports/1/5.csv) = something error",
+		},
+		{
+			name: "error on complete marks the export as failed",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(pending(), nil)
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusProcessing).Return(nil)
+				er.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				fs.On("PutStream", mock.Anything, "exports/1/5.csv", "text/csv; charset=utf-8", mock.Anything).Return(nil)
+				eer.On("Complete", mock.Anything, uint64(5), "exports/1/5.csv", 0, mock.Anything).
+					Return(errors.New("something error"))
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusFailed).Return(nil)
+			},
+			wantErrMsg: "failed to complete expense export for id (5) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(er *mocks.ExpenseRepository, eer *mocks.ExpenseExportRepository, fs *mocks.FileStorage) {
+				eer.On("FindByID", mock.Anything, uint64(1), uint64(5)).Return(pending(), nil)
+				eer.On("UpdateStatus", mock.Anything, uint64(5), entity.ExpenseExportStatusProcessing).Return(nil)
+				er.On("Stream", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.OrgID == 1 && r.UserID == 2 && r.View == model.ExpenseViewPersonal
+				}), mock.Anything).Run(func(args mock.Arguments) {
+					fn := args.Get(2).(func(*entity.ExpenseDetail) error)
+					_ = fn(&entity.ExpenseDetail{Expense: entity.Expense{ID: 7}})
+					_ = fn(&entity.ExpenseDetail{Expense: entity.Expense{ID: 8}})
+				}).Return(nil)
+				fs.On("PutStream", mock.Anything, "exports/1/5.csv", "text/csv; charset=utf-8", mock.Anything).
+					Run(func(args mock.Arguments) {
+						content, err := io.ReadAll(args.Get(3).(io.Reader))
+						s.Nil(err)
+						s.True(bytes.HasPrefix(content, []byte("Expense ID,")))
+					}).Return(nil)
+				eer.On("Complete", mock.Anything, uint64(5), "exports/1/5.csv", 2, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			er := mocks.NewExpenseRepository(s.T())
+			eer := mocks.NewExpenseExportRepository(s.T())
+			fs := mocks.NewFileStorage(s.T())
+
+			usecase := usecase.NewExpenseExportUsecase(s.log, er, eer, fs, nil, 100)
+			tt.mockFunc(er, eer, fs)
+
+			err := usecase.Process(s.ctx, &model.ProcessExpenseExportRequest{ID: 5, OrgID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestExpenseExportUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ExpenseExportUsecaseSuite))
+}
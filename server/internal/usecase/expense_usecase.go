@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"expense-management-system/internal/db"
 	"expense-management-system/internal/entity"
 	"expense-management-system/internal/messaging"
 	"expense-management-system/internal/metrics"
@@ -9,98 +10,321 @@ import (
 	"expense-management-system/internal/model/serializer"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 type expenseUsecase struct {
-	log                     *zap.Logger
-	expenseRepository       ExpenseRepository
-	expenseApprovedProducer *messaging.ExpenseApprovedProducer
+	log                        *zap.Logger
+	tx                         db.Transactioner
+	expenseRepository          ExpenseRepository
+	organizationRepository     OrganizationRepository
+	departmentBudgetRepository DepartmentBudgetRepository
+	ledgerRepository           LedgerRepository
+	expenseCommentRepository   ExpenseCommentRepository
+	reviewRoundRepository      ExpenseReviewRoundRepository
+	expenseApprovedProducer    *messaging.ExpenseApprovedProducer
 }
 
-func NewExpenseUsecase(log *zap.Logger, expenseRepository ExpenseRepository,
-	expenseApprovedProducer *messaging.ExpenseApprovedProducer) ExpenseUsecase {
+func NewExpenseUsecase(log *zap.Logger, tx db.Transactioner, expenseRepository ExpenseRepository,
+	organizationRepository OrganizationRepository, departmentBudgetRepository DepartmentBudgetRepository,
+	ledgerRepository LedgerRepository, expenseCommentRepository ExpenseCommentRepository,
+	reviewRoundRepository ExpenseReviewRoundRepository, expenseApprovedProducer *messaging.ExpenseApprovedProducer) ExpenseUsecase {
 	return &expenseUsecase{
-		log:                     log,
-		expenseRepository:       expenseRepository,
-		expenseApprovedProducer: expenseApprovedProducer,
+		log:                        log,
+		tx:                         tx,
+		expenseRepository:          expenseRepository,
+		organizationRepository:     organizationRepository,
+		departmentBudgetRepository: departmentBudgetRepository,
+		ledgerRepository:           ledgerRepository,
+		expenseCommentRepository:   expenseCommentRepository,
+		reviewRoundRepository:      reviewRoundRepository,
+		expenseApprovedProducer:    expenseApprovedProducer,
 	}
 }
 
 func (c *expenseUsecase) Create(ctx context.Context, req *model.CreateExpenseRequest) (*model.ExpenseCreateResponse, error) {
-	if req.AmountIDR < entity.MinExpenseAmount {
-		return nil, model.ErrExpenseMinAmount
-	} else if req.AmountIDR > entity.MaxExpenseAmount {
-		return nil, model.ErrExpenseMaxAmount
+	organization, err := c.checkAmount(ctx, req.OrgID, req.AmountIDR)
+	if err != nil {
+		return nil, err
 	}
 
 	var status entity.ExpenseStatus
-	if req.AmountIDR >= entity.ApprovalThresholdAmount {
+	if req.AmountIDR >= organization.ApprovalThresholdAmount {
 		status = entity.ExpenseStatusAwaitingApproval
 	} else {
 		status = entity.ExpenseStatusApproved
 	}
 
 	expense := &entity.Expense{
-		UserID:      req.UserID,
-		Amount:      req.AmountIDR,
-		Description: strings.TrimSpace(req.Description),
-		ReceiptURL:  req.ReceiptURL,
-		Status:      status,
+		OrgID:             req.OrgID,
+		UserID:            req.UserID,
+		Amount:            req.AmountIDR,
+		Description:       strings.TrimSpace(req.Description),
+		ReceiptURL:        req.ReceiptURL,
+		Status:            status,
+		ApprovalThreshold: organization.ApprovalThresholdAmount,
 	}
-	err := c.expenseRepository.Create(ctx, expense)
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		txErr := c.expenseRepository.CreateTx(ctx, exec, expense)
+		if txErr != nil {
+			return fmt.Errorf("failed to create expense = %w", txErr)
+		}
+
+		// the department is only known once the expense is stored, by then the spent of the
+		// budget already counts it, so the budget is exceeded when nothing is left
+		if expense.Status == entity.ExpenseStatusApproved {
+			var exceeded bool
+			exceeded, txErr = c.budgetExceeded(ctx, exec, expense, 0)
+			if txErr != nil {
+				return txErr
+			}
+
+			if exceeded {
+				expense.Status = entity.ExpenseStatusAwaitingApproval
+				txErr = c.expenseRepository.UpdateStatusByIDTx(ctx, exec, expense.OrgID, expense.ID, expense.Status)
+				if txErr != nil {
+					return fmt.Errorf("failed to to update expense for id (%d) = %w", expense.ID, txErr)
+				}
+			}
+		}
+
+		// an auto approved expense is owed to the submitter right away
+		if expense.Status == entity.ExpenseStatusApproved {
+			_, txErr = c.ledgerRepository.PostTx(ctx, exec, entity.NewApprovalLedgerEntry(expense))
+			if txErr != nil {
+				return fmt.Errorf("failed to post approval ledger entry for expense id (%d) = %w", expense.ID, txErr)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create expense = %w", err)
+		return nil, err
 	}
 
 	if expense.Status == entity.ExpenseStatusApproved {
-		event := model.ExpenseApprovedEvent{
-			ID:             expense.ID,
-			UserID:         expense.UserID,
-			Amount:         expense.Amount,
-			IdempotencyKey: expense.GetKey(),
+		c.sendExpenseApproved(expense, "create")
+	}
+
+	return serializer.ExpenseToCreateResponse(expense), nil
+}
+
+// Update lets the submitter edit an expense sent back with changes requested. The edit closes the
+// review round and returns the expense to the approval queue, or approves it like a new expense when
+// the amount drops below the approval threshold the expense was submitted with.
+func (c *expenseUsecase) Update(ctx context.Context, req *model.UpdateExpenseRequest) (*model.ExpenseDetailResponse, error) {
+	_, err := c.checkAmount(ctx, req.OrgID, req.AmountIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	var expense *entity.Expense
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		var txErr error
+		expense, txErr = c.expenseRepository.FindByIDWithLock(ctx, exec, req.OrgID, req.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to find expense by id (%d) with lock = %w", req.ID, txErr)
 		}
 
-		// publish after the expense is successfully created
-		// if publishing fails, log the error and send a metric to notify us
-		// later, we can run a script to retry sending failed events
-		// a more robust solution would be to use the outbox pattern
-		eventStatus := "success"
-		err = c.expenseApprovedProducer.Send(&event)
-		if err != nil {
-			eventStatus = "fail"
-			c.log.Error(
-				fmt.Sprintf("failed to send expense-approved event for id (%d) = %s", event.ID, err.Error()),
-				zap.Any("event", event),
-				zap.Strings("tags", []string{"expense", "create", "send-event", "expense-approved"}),
-			)
+		if expense == nil {
+			return model.ErrExpenseNotFound
+		}
+		if expense.UserID != req.UserID {
+			return model.ErrForbidden
+		}
+		// the row is locked, so the version can't change between this check and the update
+		if req.Version != nil && *req.Version != expense.Version {
+			return model.ErrExpenseModified
+		}
+		if expense.Status != entity.ExpenseStatusChangesRequested {
+			return model.ErrExpenseNotEditable
+		}
+
+		expense.Amount = req.AmountIDR
+		expense.Description = strings.TrimSpace(req.Description)
+		expense.ReceiptURL = req.ReceiptURL
+		expense.Status = entity.ExpenseStatusAwaitingApproval
+		if !expense.RequiresApproval() {
+			// waiting for changes the expense isn't counted in the spent of the budget yet
+			var exceeded bool
+			exceeded, txErr = c.budgetExceeded(ctx, exec, expense, expense.Amount)
+			if txErr != nil {
+				return txErr
+			}
+
+			if !exceeded {
+				expense.Status = entity.ExpenseStatusApproved
+			}
+		}
+
+		txErr = c.expenseRepository.UpdateTx(ctx, exec, expense)
+		if txErr != nil {
+			return fmt.Errorf("failed to update expense for id (%d) = %w", req.ID, txErr)
 		}
-		metrics.IncrementEvent(metrics.EventPusblishExpenseApprove, eventStatus)
+
+		txErr = c.reviewRoundRepository.ResubmitTx(ctx, exec, expense.OrgID, expense.ID, time.Now())
+		if txErr != nil {
+			return fmt.Errorf("failed to resubmit review round of expense id (%d) = %w", req.ID, txErr)
+		}
+
+		if expense.Status == entity.ExpenseStatusApproved {
+			_, txErr = c.ledgerRepository.PostTx(ctx, exec, entity.NewApprovalLedgerEntry(expense))
+			if txErr != nil {
+				return fmt.Errorf("failed to post approval ledger entry for expense id (%d) = %w", expense.ID, txErr)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return serializer.ExpenseToCreateResponse(expense), nil
+	if expense.Status == entity.ExpenseStatusApproved {
+		c.sendExpenseApproved(expense, "update")
+	}
+
+	return c.FindByID(ctx, &model.GetExpenseRequest{
+		ID:       req.ID,
+		OrgID:    req.OrgID,
+		UserID:   req.UserID,
+		UserRole: req.UserRole,
+	})
 }
 
-func (c *expenseUsecase) List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, int, error) {
-	if req.View == model.ExpenseViewApprovalQueue && req.UserRole != string(entity.UserRoleManager) {
-		return []model.ExpenseWithUserResponse{}, 0, model.ErrForbidden
+// budgetExceeded locks the department budget of the expense like an approval does and reports whether
+// amount no longer fits in it. An auto approval over the budget goes to the approval queue instead,
+// where the budget policy applies.
+func (c *expenseUsecase) budgetExceeded(ctx context.Context, exec db.Executor, expense *entity.Expense, amount uint64) (bool, error) {
+	if expense.DepartmentID == nil {
+		return false, nil
 	}
 
-	expenses, total, err := c.expenseRepository.List(ctx, req)
+	budget, err := c.departmentBudgetRepository.FindByDateWithLock(ctx, exec, expense.OrgID, *expense.DepartmentID, expense.CreatedAt)
 	if err != nil {
-		return []model.ExpenseWithUserResponse{}, 0, fmt.Errorf("failed to get expenses = %w", err)
+		return false, fmt.Errorf("failed to find budget for department id (%d) with lock = %w", *expense.DepartmentID, err)
+	}
+
+	return budget.Exceeded(amount), nil
+}
+
+// checkAmount returns the organization of the submitter when the amount is within its limits
+func (c *expenseUsecase) checkAmount(ctx context.Context, orgID uint64, amount uint64) (*entity.Organization, error) {
+	organization, err := c.organizationRepository.FindByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization by id (%d) = %w", orgID, err)
+	}
+
+	if organization == nil {
+		return nil, model.ErrOrganizationNotFound
+	}
+
+	if amount < organization.MinExpenseAmount {
+		return nil, model.NewExpenseMinAmountError(organization.MinExpenseAmount)
+	} else if amount > organization.MaxExpenseAmount {
+		return nil, model.NewExpenseMaxAmountError(organization.MaxExpenseAmount)
+	}
+
+	return organization, nil
+}
+
+// sendExpenseApproved publishes the approval of an expense that didn't need an approver
+// if publishing fails, log the error and send a metric to notify us
+// later, we can run a script to retry sending failed events
+// a more robust solution would be to use the outbox pattern
+func (c *expenseUsecase) sendExpenseApproved(expense *entity.Expense, action string) {
+	event := model.ExpenseApprovedEvent{
+		ID:             expense.ID,
+		OrgID:          expense.OrgID,
+		UserID:         expense.UserID,
+		Amount:         expense.Amount,
+		IdempotencyKey: expense.GetKey(),
+	}
+
+	eventStatus := "success"
+	err := c.expenseApprovedProducer.Send(&event)
+	if err != nil {
+		eventStatus = "fail"
+		c.log.Error(
+			fmt.Sprintf("failed to send expense-approved event for id (%d) = %s", event.ID, err.Error()),
+			zap.Any("event", event),
+			zap.Strings("tags", []string{"expense", action, "send-event", "expense-approved"}),
+		)
+	}
+	metrics.IncrementEvent(metrics.EventPusblishExpenseApprove, eventStatus)
+}
+
+func (c *expenseUsecase) List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, *model.Page, error) {
+	if req.View.ManagerOnly() && !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return []model.ExpenseWithUserResponse{}, nil, model.ErrForbidden
+	}
+
+	// one extra row tells whether there is a next page without counting
+	query := *req
+	query.Limit = req.Limit + 1
+
+	expenses, total, err := c.expenseRepository.List(ctx, &query)
+	if err != nil {
+		return []model.ExpenseWithUserResponse{}, nil, fmt.Errorf("failed to get expenses = %w", err)
+	}
+
+	page := &model.Page{}
+	if !req.SkipTotal {
+		page.Total = &total
 	}
 
 	if len(expenses) == 0 {
-		return []model.ExpenseWithUserResponse{}, 0, nil
+		return []model.ExpenseWithUserResponse{}, page, nil
 	}
 
-	return serializer.ListExpenseWithUserToResponse(expenses), total, nil
+	if len(expenses) > req.Limit {
+		expenses = expenses[:req.Limit]
+		nextCursor := model.EncodeCursor(newExpenseCursor(req, expenses[len(expenses)-1].Expense))
+		page.NextCursor = &nextCursor
+	}
+
+	return serializer.ListExpenseWithUserToResponse(expenses), page, nil
+}
+
+// newExpenseCursor keeps the sorted value of the last expense, the next page continues after it
+func newExpenseCursor(req *model.ListExpenseRequest, last entity.Expense) model.ExpenseCursor {
+	cursor := model.ExpenseCursor{
+		ID:        last.ID,
+		Filters:   req.FilterHash(),
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+	}
+
+	switch req.SortBy {
+	case model.ExpenseSortByAmount:
+		cursor.AmountIDR = &last.Amount
+	case model.ExpenseSortByStatus:
+		status := string(last.Status)
+		cursor.Status = &status
+	default:
+		cursor.CreatedAt = &last.CreatedAt
+	}
+
+	return cursor
+}
+
+func (c *expenseUsecase) Search(ctx context.Context, req *model.SearchExpenseRequest) ([]model.ExpenseSearchResultResponse, int, error) {
+	// same visibility as FindByID, approvers find everything in the organization
+	query := *req
+	query.OwnOnly = !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager)
+
+	results, total, err := c.expenseRepository.Search(ctx, &query)
+	if err != nil {
+		return []model.ExpenseSearchResultResponse{}, 0, fmt.Errorf("failed to search expenses = %w", err)
+	}
+
+	return serializer.ListExpenseSearchResultToResponse(results), total, nil
 }
 
 func (c *expenseUsecase) FindByID(ctx context.Context, req *model.GetExpenseRequest) (*model.ExpenseDetailResponse, error) {
-	expense, err := c.expenseRepository.FindDetailByID(ctx, req.ID)
+	expense, err := c.expenseRepository.FindDetailByID(ctx, req.OrgID, req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expense by id (%d) = %w", req.ID, err)
 	}
@@ -109,9 +333,36 @@ func (c *expenseUsecase) FindByID(ctx context.Context, req *model.GetExpenseRequ
 		return nil, model.ErrExpenseNotFound
 	}
 
-	if req.UserID != expense.UserID && req.UserRole != string(entity.UserRoleManager) {
+	approver := entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager)
+	if req.UserID != expense.UserID && !approver {
 		return nil, model.ErrForbidden
 	}
 
-	return serializer.ExpenseDetailToResponse(expense), nil
+	res := serializer.ExpenseDetailToResponse(expense)
+
+	comments, err := c.expenseCommentRepository.ListByExpenseID(ctx, expense.OrgID, expense.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments of expense id (%d) = %w", expense.ID, err)
+	}
+	res.Comments = serializer.ExpenseCommentsToResponse(comments)
+
+	rounds, err := c.reviewRoundRepository.ListByExpenseID(ctx, expense.OrgID, expense.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review rounds of expense id (%d) = %w", expense.ID, err)
+	}
+	res.ReviewRounds = serializer.ExpenseReviewRoundsToResponse(rounds)
+
+	// approvers see how much budget the department has left for the period of the expense
+	if approver && expense.DepartmentID != nil {
+		budget, err := c.departmentBudgetRepository.FindByDate(ctx, expense.OrgID, *expense.DepartmentID, expense.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find budget for department id (%d) = %w", *expense.DepartmentID, err)
+		}
+
+		if budget != nil {
+			res.Budget = serializer.DepartmentBudgetToResponse(budget)
+		}
+	}
+
+	return res, nil
 }
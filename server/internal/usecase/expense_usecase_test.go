@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"expense-management-system/internal/db"
 	"expense-management-system/internal/entity"
 	"expense-management-system/internal/messaging"
 	"expense-management-system/internal/mocks"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -29,77 +31,217 @@ func (s *ExpenseUsecaseSuite) SetupTest() {
 
 func (s *ExpenseUsecaseSuite) TestExpenseUsecase_Create() {
 	receiptUrl := "https://example.com/receipt.jpg"
+	departmentID := uint64(3)
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	organization := &entity.Organization{
+		ID:                      1,
+		Name:                    "Default",
+		MinExpenseAmount:        10000,
+		MaxExpenseAmount:        50000000,
+		ApprovalThresholdAmount: 1000000,
+	}
 
 	tests := []struct {
 		name     string
 		request  *model.CreateExpenseRequest
 		mockFunc func(
+			db pgxmock.PgxPoolIface,
 			er *mocks.ExpenseRepository,
+			or *mocks.OrganizationRepository,
+			lr *mocks.LedgerRepository,
+			dbr *mocks.DepartmentBudgetRepository,
 			p *mocks.Producer[*model.ExpenseApprovedEvent],
 		)
 		wantErrMsg string
 	}{
+		{
+			name: "error on find organization",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find organization by id (1) = something error",
+		},
+		{
+			name: "error organization not found",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: model.ErrOrganizationNotFound.Error(),
+		},
 		{
 			name: "error on min amount",
 			request: &model.CreateExpenseRequest{
+				OrgID:       1,
 				UserID:      1,
 				AmountIDR:   500,
 				Description: "dummy description",
 				ReceiptURL:  &receiptUrl,
 			},
 			mockFunc: func(
+				db pgxmock.PgxPoolIface,
 				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
 			},
 			wantErrMsg: "Amount can't be less than Rp 10.000",
 		},
 		{
 			name: "error on max amount",
 			request: &model.CreateExpenseRequest{
+				OrgID:       1,
 				UserID:      1,
 				AmountIDR:   250000000,
 				Description: "dummy description",
 				ReceiptURL:  &receiptUrl,
 			},
 			mockFunc: func(
+				db pgxmock.PgxPoolIface,
 				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
 			},
 			wantErrMsg: "Amount can't be greater than Rp 50.000.000",
 		},
+		{
+			name: "error on max amount of another organization",
+			request: &model.CreateExpenseRequest{
+				OrgID:       2,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(2)).Return(&entity.Organization{
+					ID:                      2,
+					Name:                    "Subsidiary",
+					MinExpenseAmount:        5000,
+					MaxExpenseAmount:        15000,
+					ApprovalThresholdAmount: 10000,
+				}, nil)
+			},
+			wantErrMsg: "Amount can't be greater than Rp 15.000",
+		},
 		{
 			name: "error on create",
 			request: &model.CreateExpenseRequest{
+				OrgID:       1,
 				UserID:      1,
 				AmountIDR:   15500,
 				Description: "dummy description",
 				ReceiptURL:  &receiptUrl,
 			},
 			mockFunc: func(
+				db pgxmock.PgxPoolIface,
 				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
-
-				er.On("Create", mock.Anything, mock.Anything).
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
 					Return(errors.New("something error"))
+				db.ExpectRollback()
 			},
 			wantErrMsg: "failed to create expense = something error",
 		},
+		{
+			name: "error on post ledger entry",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(*entity.Expense).ID = 7
+					}).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(false, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to post approval ledger entry for expense id (7) = something error",
+		},
 		{
 			name: "success but error send event",
 			request: &model.CreateExpenseRequest{
+				OrgID:       1,
 				UserID:      1,
 				AmountIDR:   15500,
 				Description: "dummy description",
 				ReceiptURL:  &receiptUrl,
 			},
 			mockFunc: func(
+				db pgxmock.PgxPoolIface,
 				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
-				er.On("Create", mock.Anything, mock.Anything).Return(nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				db.ExpectCommit()
 				p.On("Send", mock.Anything).Return(errors.New("something error"))
 			},
 			wantErrMsg: "",
@@ -107,32 +249,190 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_Create() {
 		{
 			name: "success",
 			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.Expense) bool {
+					return e.OrgID == 1 && e.Status == entity.ExpenseStatusApproved && e.ApprovalThreshold == 1000000
+				})).Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypeApproval && e.OrgID == 1 && e.Postings[1].Credit == 15500
+				})).Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", mock.MatchedBy(func(e *model.ExpenseApprovedEvent) bool {
+					return e.OrgID == 1
+				})).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on find budget",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						e := args.Get(2).(*entity.Expense)
+						e.ID = 7
+						e.DepartmentID = &departmentID
+						e.CreatedAt = now
+					}).
+					Return(nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, now).
+					Return(nil, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to find budget for department id (3) with lock = something error",
+		},
+		{
+			name: "success awaiting approval when the auto approval exceeds the budget",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						e := args.Get(2).(*entity.Expense)
+						e.ID = 7
+						e.DepartmentID = &departmentID
+						e.CreatedAt = now
+					}).
+					Return(nil)
+				// the spent already counts the new expense
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, now).
+					Return(&entity.DepartmentBudget{ID: 1, DepartmentID: departmentID, Amount: 20000, Spent: 25500}, nil)
+				er.On("UpdateStatusByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(7), entity.ExpenseStatusAwaitingApproval).
+					Return(nil)
+				db.ExpectCommit()
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success auto approved within the budget",
+			request: &model.CreateExpenseRequest{
+				OrgID:       1,
 				UserID:      1,
 				AmountIDR:   15500,
 				Description: "dummy description",
 				ReceiptURL:  &receiptUrl,
 			},
 			mockFunc: func(
+				db pgxmock.PgxPoolIface,
 				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
 				p *mocks.Producer[*model.ExpenseApprovedEvent],
 			) {
-				er.On("Create", mock.Anything, mock.Anything).Return(nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						e := args.Get(2).(*entity.Expense)
+						e.ID = 7
+						e.DepartmentID = &departmentID
+						e.CreatedAt = now
+					}).
+					Return(nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, now).
+					Return(&entity.DepartmentBudget{ID: 1, DepartmentID: departmentID, Amount: 20000, Spent: 15500}, nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				db.ExpectCommit()
 				p.On("Send", mock.Anything).Return(nil)
 			},
 			wantErrMsg: "",
 		},
+		{
+			name: "success awaiting approval by the threshold of the organization",
+			request: &model.CreateExpenseRequest{
+				OrgID:       2,
+				UserID:      1,
+				AmountIDR:   15500,
+				Description: "dummy description",
+				ReceiptURL:  &receiptUrl,
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(2)).Return(&entity.Organization{
+					ID:                      2,
+					Name:                    "Subsidiary",
+					MinExpenseAmount:        5000,
+					MaxExpenseAmount:        20000000,
+					ApprovalThresholdAmount: 10000,
+				}, nil)
+				db.ExpectBegin()
+				er.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.Expense) bool {
+					return e.OrgID == 2 && e.Status == entity.ExpenseStatusAwaitingApproval && e.ApprovalThreshold == 10000
+				})).Return(nil)
+				db.ExpectCommit()
+			},
+			wantErrMsg: "",
+		},
 	}
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
+			dbMock, _ := pgxmock.NewPool()
+			defer dbMock.Close()
+			tx := db.NewTransactioner(dbMock)
+
 			er := mocks.NewExpenseRepository(s.T())
+			or := mocks.NewOrganizationRepository(s.T())
+			lr := mocks.NewLedgerRepository(s.T())
+			dbr := mocks.NewDepartmentBudgetRepository(s.T())
 			p := mocks.NewProducer[*model.ExpenseApprovedEvent](s.T())
 			eap := &messaging.ExpenseApprovedProducer{
 				Producer: p,
 			}
 
-			usecase := usecase.NewExpenseUsecase(s.log, er, eap)
-			tt.mockFunc(er, p)
+			usecase := usecase.NewExpenseUsecase(s.log, tx, er, or, dbr, lr, nil, nil, eap)
+			tt.mockFunc(dbMock, er, or, lr, dbr, p)
 
 			_, err := usecase.Create(s.ctx, tt.request)
 
@@ -145,19 +445,380 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_Create() {
 	}
 }
 
+func (s *ExpenseUsecaseSuite) TestExpenseUsecase_Update() {
+	staleVersion := uint64(1)
+	departmentID := uint64(3)
+	organization := &entity.Organization{
+		ID:                      1,
+		Name:                    "Default",
+		MinExpenseAmount:        10000,
+		MaxExpenseAmount:        50000000,
+		ApprovalThresholdAmount: 1000000,
+	}
+	newExpense := func() *entity.Expense {
+		return &entity.Expense{
+			ID:                1,
+			OrgID:             1,
+			UserID:            1,
+			Amount:            2000000,
+			ApprovalThreshold: 1000000,
+			Description:       "dummy description",
+			Status:            entity.ExpenseStatusChangesRequested,
+			Version:           2,
+		}
+	}
+	findDetail := func(er *mocks.ExpenseRepository, ecr *mocks.ExpenseCommentRepository,
+		rrr *mocks.ExpenseReviewRoundRepository, status entity.ExpenseStatus) {
+		er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+			Return(&entity.ExpenseDetail{
+				Expense: entity.Expense{ID: 1, UserID: 1, Status: status, Version: 3},
+			}, nil)
+		ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+		rrr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+	}
+
+	tests := []struct {
+		name     string
+		request  *model.UpdateExpenseRequest
+		mockFunc func(
+			db pgxmock.PgxPoolIface,
+			er *mocks.ExpenseRepository,
+			or *mocks.OrganizationRepository,
+			lr *mocks.LedgerRepository,
+			dbr *mocks.DepartmentBudgetRepository,
+			ecr *mocks.ExpenseCommentRepository,
+			rrr *mocks.ExpenseReviewRoundRepository,
+			p *mocks.Producer[*model.ExpenseApprovedEvent],
+		)
+		wantStatus string
+		wantErrMsg string
+	}{
+		{
+			name:    "error on max amount",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 250000000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+			},
+			wantErrMsg: "Amount can't be greater than Rp 50.000.000",
+		},
+		{
+			name:    "error on expense not found",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 1500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(nil, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Expense not found",
+		},
+		{
+			name:    "error on other user expense",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 2, AmountIDR: 1500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(newExpense(), nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error on version mismatch",
+			request: &model.UpdateExpenseRequest{
+				ID: 1, OrgID: 1, UserID: 1, Version: &staleVersion, AmountIDR: 1500000, Description: "dummy description",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(newExpense(), nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: model.ErrExpenseModified.Error(),
+		},
+		{
+			name:    "error on expense not editable",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 1500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				expense := newExpense()
+				expense.Status = entity.ExpenseStatusAwaitingApproval
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(expense, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: model.ErrExpenseNotEditable.Error(),
+		},
+		{
+			name:    "error on resubmit review round",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 1500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(newExpense(), nil)
+				er.On("UpdateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				rrr.On("ResubmitTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to resubmit review round of expense id (1) = something error",
+		},
+		{
+			name:    "success back to awaiting approval",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 1500000, Description: " fixed description "},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(newExpense(), nil)
+				er.On("UpdateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.Expense) bool {
+					return e.Amount == 1500000 && e.Description == "fixed description" && e.Status == entity.ExpenseStatusAwaitingApproval
+				})).Return(nil)
+				rrr.On("ResubmitTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				db.ExpectCommit()
+				findDetail(er, ecr, rrr, entity.ExpenseStatusAwaitingApproval)
+			},
+			wantStatus: "awaiting_approval",
+		},
+		{
+			name:    "success back to awaiting approval when the budget is exceeded",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				expense := newExpense()
+				expense.DepartmentID = &departmentID
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(expense, nil)
+				dbr.On("FindByDateWithLock", mock.Anything, mock.Anything, mock.Anything, departmentID, mock.Anything).
+					Return(&entity.DepartmentBudget{ID: 1, DepartmentID: departmentID, Amount: 1000000, Spent: 800000}, nil)
+				er.On("UpdateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.Expense) bool {
+					return e.Amount == 500000 && e.Status == entity.ExpenseStatusAwaitingApproval
+				})).Return(nil)
+				rrr.On("ResubmitTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				db.ExpectCommit()
+				findDetail(er, ecr, rrr, entity.ExpenseStatusAwaitingApproval)
+			},
+			wantStatus: "awaiting_approval",
+		},
+		{
+			name:    "success approved below the threshold of the expense",
+			request: &model.UpdateExpenseRequest{ID: 1, OrgID: 1, UserID: 1, AmountIDR: 500000, Description: "dummy description"},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				er *mocks.ExpenseRepository,
+				or *mocks.OrganizationRepository,
+				lr *mocks.LedgerRepository,
+				dbr *mocks.DepartmentBudgetRepository,
+				ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository,
+				p *mocks.Producer[*model.ExpenseApprovedEvent],
+			) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				db.ExpectBegin()
+				er.On("FindByIDWithLock", mock.Anything, mock.Anything, uint64(1), uint64(1)).Return(newExpense(), nil)
+				er.On("UpdateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.Expense) bool {
+					return e.Amount == 500000 && e.Status == entity.ExpenseStatusApproved
+				})).Return(nil)
+				rrr.On("ResubmitTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypeApproval && e.Postings[1].Credit == 500000
+				})).Return(true, nil)
+				db.ExpectCommit()
+				p.On("Send", mock.MatchedBy(func(e *model.ExpenseApprovedEvent) bool {
+					return e.ID == 1 && e.Amount == 500000
+				})).Return(nil)
+				findDetail(er, ecr, rrr, entity.ExpenseStatusApproved)
+			},
+			wantStatus: "approved",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dbMock, _ := pgxmock.NewPool()
+			defer dbMock.Close()
+			tx := db.NewTransactioner(dbMock)
+
+			er := mocks.NewExpenseRepository(s.T())
+			or := mocks.NewOrganizationRepository(s.T())
+			lr := mocks.NewLedgerRepository(s.T())
+			dbr := mocks.NewDepartmentBudgetRepository(s.T())
+			ecr := mocks.NewExpenseCommentRepository(s.T())
+			rrr := mocks.NewExpenseReviewRoundRepository(s.T())
+			p := mocks.NewProducer[*model.ExpenseApprovedEvent](s.T())
+			eap := &messaging.ExpenseApprovedProducer{
+				Producer: p,
+			}
+
+			usecase := usecase.NewExpenseUsecase(s.log, tx, er, or, dbr, lr, ecr, rrr, eap)
+			tt.mockFunc(dbMock, er, or, lr, dbr, ecr, rrr, p)
+
+			res, err := usecase.Update(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantStatus, res.Status)
+				s.Equal(uint64(3), res.Version)
+			}
+		})
+	}
+}
+
 func (s *ExpenseUsecaseSuite) TestExpenseUsecase_List() {
 	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
 	description := "dummy description"
 	receipt := "https://example.com/receipt.jpg"
+	zero, one := 0, 1
+	amount := uint64(10000)
+	nextCursor := model.EncodeCursor(model.ExpenseCursor{
+		ID:        3,
+		Filters:   (&model.ListExpenseRequest{SortBy: model.ExpenseSortByAmount, SortOrder: model.SortOrderDesc}).FilterHash(),
+		SortBy:    model.ExpenseSortByAmount,
+		SortOrder: model.SortOrderDesc,
+		AmountIDR: &amount,
+	})
+
+	expense := func(id uint64) entity.ExpenseWithUser {
+		return entity.ExpenseWithUser{
+			Expense: entity.Expense{
+				ID:                id,
+				UserID:            1,
+				Amount:            10000,
+				ApprovalThreshold: 1000000,
+				Description:       description,
+				ReceiptURL:        &receipt,
+				Status:            entity.ExpenseStatusApproved,
+				CreatedAt:         now,
+			},
+			User: entity.UserSimple{
+				ID:    1,
+				Email: "john@mail.com",
+				Name:  "John Doe",
+			},
+		}
+	}
+	response := func(id uint64) model.ExpenseWithUserResponse {
+		return model.ExpenseWithUserResponse{
+			ID:               id,
+			AmountIDR:        10000,
+			AmountFormatted:  "Rp 10.000",
+			Description:      description,
+			ReceiptURL:       &receipt,
+			Status:           "approved",
+			RequiresApproval: false,
+			AutoApproved:     true,
+			CreatedAt:        now.Format(time.RFC3339),
+			User: model.UserSimpleResponse{
+				ID:    1,
+				Email: "john@mail.com",
+				Name:  "John Doe",
+			},
+		}
+	}
 
 	tests := []struct {
 		name       string
 		request    *model.ListExpenseRequest
 		mockFunc   func(er *mocks.ExpenseRepository)
 		wantRes    []model.ExpenseWithUserResponse
-		wantTotal  int
+		wantPage   *model.Page
 		wantErrMsg string
 	}{
+		{
+			name: "error forbidden approval queue",
+			request: &model.ListExpenseRequest{
+				UserRole: "employee",
+				View:     model.ExpenseViewApprovalQueue,
+				Limit:    10,
+			},
+			mockFunc:   func(er *mocks.ExpenseRepository) {},
+			wantRes:    []model.ExpenseWithUserResponse{},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error forbidden organization",
+			request: &model.ListExpenseRequest{
+				UserRole: "employee",
+				View:     model.ExpenseViewOrganization,
+				Limit:    10,
+			},
+			mockFunc:   func(er *mocks.ExpenseRepository) {},
+			wantRes:    []model.ExpenseWithUserResponse{},
+			wantErrMsg: "Forbidden",
+		},
 		{
 			name: "error on list",
 			request: &model.ListExpenseRequest{
@@ -169,7 +830,6 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_List() {
 					Return(nil, 0, errors.New("something error"))
 			},
 			wantRes:    []model.ExpenseWithUserResponse{},
-			wantTotal:  0,
 			wantErrMsg: "failed to get expenses = something error",
 		},
 		{
@@ -182,9 +842,8 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_List() {
 				er.On("List", mock.Anything, mock.Anything).
 					Return([]entity.ExpenseWithUser{}, 0, nil)
 			},
-			wantRes:    []model.ExpenseWithUserResponse{},
-			wantTotal:  0,
-			wantErrMsg: "",
+			wantRes:  []model.ExpenseWithUserResponse{},
+			wantPage: &model.Page{Total: &zero},
 		},
 		{
 			name: "success",
@@ -193,44 +852,29 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_List() {
 				Limit:  10,
 			},
 			mockFunc: func(er *mocks.ExpenseRepository) {
-				er.On("List", mock.Anything, mock.Anything).Return([]entity.ExpenseWithUser{
-					{
-						Expense: entity.Expense{
-							ID:          1,
-							UserID:      1,
-							Amount:      10000,
-							Description: description,
-							ReceiptURL:  &receipt,
-							Status:      entity.ExpenseStatusApproved,
-							CreatedAt:   now,
-						},
-						User: entity.UserSimple{
-							ID:    1,
-							Email: "john@mail.com",
-							Name:  "John Doe",
-						},
-					},
-				}, 1, nil)
+				er.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.Limit == 11
+				})).Return([]entity.ExpenseWithUser{expense(1)}, 1, nil)
 			},
-			wantRes: []model.ExpenseWithUserResponse{
-				{
-					ID:               1,
-					AmountIDR:        10000,
-					Description:      description,
-					ReceiptURL:       &receipt,
-					Status:           "approved",
-					RequiresApproval: false,
-					AutoApproved:     true,
-					CreatedAt:        now.Format(time.RFC3339),
-					User: model.UserSimpleResponse{
-						ID:    1,
-						Email: "john@mail.com",
-						Name:  "John Doe",
-					},
-				},
+			wantRes:  []model.ExpenseWithUserResponse{response(1)},
+			wantPage: &model.Page{Total: &one},
+		},
+		{
+			name: "success with next cursor",
+			request: &model.ListExpenseRequest{
+				Limit:     1,
+				SortBy:    model.ExpenseSortByAmount,
+				SortOrder: model.SortOrderDesc,
+				Cursor:    &model.ExpenseCursor{ID: 5},
+				SkipTotal: true,
 			},
-			wantTotal:  1,
-			wantErrMsg: "",
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("List", mock.Anything, mock.MatchedBy(func(r *model.ListExpenseRequest) bool {
+					return r.Limit == 2 && r.Cursor.ID == 5 && r.SkipTotal
+				})).Return([]entity.ExpenseWithUser{expense(3), expense(2)}, 0, nil)
+			},
+			wantRes:  []model.ExpenseWithUserResponse{response(3)},
+			wantPage: &model.Page{NextCursor: &nextCursor},
 		},
 	}
 
@@ -242,22 +886,123 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_List() {
 				Producer: p,
 			}
 
-			usecase := usecase.NewExpenseUsecase(s.log, er, eap)
+			usecase := usecase.NewExpenseUsecase(s.log, nil, er, nil, nil, nil, nil, nil, eap)
+			tt.mockFunc(er)
+
+			res, page, err := usecase.List(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Empty(res)
+				s.Nil(page)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Equal(tt.wantPage, page)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *ExpenseUsecaseSuite) TestExpenseUsecase_Search() {
+	now := time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC)
+	notesHighlight := "<mark>Surabaya</mark> trip & hotel"
+	wantNotesHighlight := "<mark>Surabaya</mark> trip &amp; hotel"
+
+	results := []entity.ExpenseSearchResult{
+		{
+			ExpenseWithUser: entity.ExpenseWithUser{
+				Expense: entity.Expense{
+					ID:                1,
+					UserID:            2,
+					Amount:            150000,
+					ApprovalThreshold: 1000000,
+					Description:       "taxi <airport>",
+					Status:            entity.ExpenseStatusApproved,
+					CreatedAt:         now,
+				},
+				User: entity.UserSimple{ID: 2, Email: "jane@mail.com", Name: "Jane"},
+			},
+			Rank:                   0.5,
+			DescriptionHighlight:   "taxi <airport>",
+			ApprovalNotesHighlight: &notesHighlight,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.SearchExpenseRequest
+		mockFunc   func(er *mocks.ExpenseRepository)
+		wantRes    []model.ExpenseSearchResultResponse
+		wantTotal  int
+		wantErrMsg string
+	}{
+		{
+			name:    "error on search",
+			request: &model.SearchExpenseRequest{OrgID: 1, UserID: 1, UserRole: "manager", Query: "surabaya", Limit: 10},
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("Search", mock.Anything, mock.Anything).Return(nil, 0, errors.New("something error"))
+			},
+			wantErrMsg: "failed to search expenses = something error",
+		},
+		{
+			name:    "success employee only finds own expenses",
+			request: &model.SearchExpenseRequest{OrgID: 1, UserID: 1, UserRole: "employee", Query: "surabaya", Limit: 10},
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("Search", mock.Anything, &model.SearchExpenseRequest{
+					OrgID: 1, UserID: 1, UserRole: "employee", Query: "surabaya", OwnOnly: true, Limit: 10,
+				}).Return(nil, 0, nil)
+			},
+			wantRes: []model.ExpenseSearchResultResponse{},
+		},
+		{
+			name:    "success manager finds every expense",
+			request: &model.SearchExpenseRequest{OrgID: 1, UserID: 1, UserRole: "manager", Query: "surabaya", Limit: 10},
+			mockFunc: func(er *mocks.ExpenseRepository) {
+				er.On("Search", mock.Anything, &model.SearchExpenseRequest{
+					OrgID: 1, UserID: 1, UserRole: "manager", Query: "surabaya", Limit: 10,
+				}).Return(results, 1, nil)
+			},
+			wantRes: []model.ExpenseSearchResultResponse{
+				{
+					ExpenseWithUserResponse: model.ExpenseWithUserResponse{
+						ID:               1,
+						AmountIDR:        150000,
+						AmountFormatted:  "Rp 150.000",
+						Description:      "taxi <airport>",
+						Status:           "approved",
+						RequiresApproval: false,
+						AutoApproved:     true,
+						CreatedAt:        now.Format(time.RFC3339),
+						User:             model.UserSimpleResponse{ID: 2, Email: "jane@mail.com", Name: "Jane"},
+					},
+					Rank: 0.5,
+					Highlights: model.ExpenseSearchHighlightResponse{
+						Description:   "taxi &lt;airport&gt;",
+						ApprovalNotes: &wantNotesHighlight,
+					},
+				},
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			er := mocks.NewExpenseRepository(s.T())
+			usecase := usecase.NewExpenseUsecase(s.log, nil, er, nil, nil, nil, nil, nil, nil)
 			tt.mockFunc(er)
 
-			res, total, err := usecase.List(s.ctx, tt.request)
+			res, total, err := usecase.Search(s.ctx, tt.request)
 
 			if tt.wantErrMsg != "" {
 				s.Empty(res)
 				s.Equal(tt.wantErrMsg, err.Error())
 			} else {
-				if len(res) > 0 {
-					s.Equal(tt.wantRes[0], res[0])
-				}
-				s.Len(res, len(tt.wantRes))
+				s.Equal(tt.wantRes, res)
+				s.Equal(tt.wantTotal, total)
 				s.Nil(err)
 			}
-			s.Equal(tt.wantTotal, total)
 		})
 	}
 }
@@ -267,23 +1012,28 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 	description := "dummy description"
 	notes := "dummy notes"
 	receipt := "https://example.com/receipt.jpg"
+	departmentID := uint64(3)
+	costCenter := "CC-100"
+	resubmittedAt := now.Format(time.RFC3339)
 
 	tests := []struct {
 		name       string
 		request    *model.GetExpenseRequest
-		mockFunc   func(er *mocks.ExpenseRepository)
+		mockFunc   func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository, rrr *mocks.ExpenseReviewRoundRepository)
 		wantRes    *model.ExpenseDetailResponse
 		wantErrMsg string
 	}{
 		{
 			name: "error on list",
 			request: &model.GetExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				UserID:   1,
 				UserRole: "manager",
 			},
-			mockFunc: func(er *mocks.ExpenseRepository) {
-				er.On("FindDetailByID", mock.Anything, uint64(1)).
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
 					Return(nil, errors.New("something error"))
 			},
 			wantRes:    &model.ExpenseDetailResponse{},
@@ -292,12 +1042,14 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 		{
 			name: "error on expense not found",
 			request: &model.GetExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				UserID:   1,
 				UserRole: "manager",
 			},
-			mockFunc: func(er *mocks.ExpenseRepository) {
-				er.On("FindDetailByID", mock.Anything, uint64(1)).
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
 					Return(nil, nil)
 			},
 			wantRes:    nil,
@@ -306,12 +1058,14 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 		{
 			name: "error on invalid access",
 			request: &model.GetExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				UserID:   1,
 				UserRole: "employee",
 			},
-			mockFunc: func(er *mocks.ExpenseRepository) {
-				er.On("FindDetailByID", mock.Anything, uint64(1)).
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.ExpenseDetail{
 						Expense: entity.Expense{ID: 1, UserID: 2},
 					}, nil)
@@ -319,24 +1073,68 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 			wantRes:    nil,
 			wantErrMsg: "Forbidden",
 		},
+		{
+			name: "error on list comments",
+			request: &model.GetExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.ExpenseDetail{
+						Expense: entity.Expense{ID: 1, UserID: 2},
+					}, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to list comments of expense id (1) = something error",
+		},
+		{
+			name: "error on list review rounds",
+			request: &model.GetExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.ExpenseDetail{
+						Expense: entity.Expense{ID: 1, UserID: 2},
+					}, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+				rrr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to list review rounds of expense id (1) = something error",
+		},
 		{
 			name: "success",
 			request: &model.GetExpenseRequest{
+				OrgID:    1,
 				ID:       1,
 				UserID:   1,
 				UserRole: "manager",
 			},
-			mockFunc: func(er *mocks.ExpenseRepository) {
-				er.On("FindDetailByID", mock.Anything, uint64(1)).
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
 					Return(&entity.ExpenseDetail{
 						Expense: entity.Expense{
-							ID:          1,
-							UserID:      2,
-							Amount:      10000,
-							Description: description,
-							ReceiptURL:  &receipt,
-							Status:      entity.ExpenseStatusApproved,
-							CreatedAt:   now,
+							ID:                1,
+							UserID:            2,
+							Amount:            10000,
+							ApprovalThreshold: 1000000,
+							Description:       description,
+							ReceiptURL:        &receipt,
+							Status:            entity.ExpenseStatusApproved,
+							CreatedAt:         now,
 						},
 						User: entity.UserSimple{
 							ID:    1,
@@ -353,10 +1151,35 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 							CreatedAt:     now,
 						},
 					}, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).
+					Return([]entity.ExpenseComment{
+						{
+							ID:        5,
+							ExpenseID: 1,
+							UserID:    1,
+							Body:      "Please attach the boarding pass",
+							CreatedAt: now,
+							User:      entity.UserSimple{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+						},
+					}, nil)
+				rrr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).
+					Return([]entity.ExpenseReviewRound{
+						{
+							ID:            2,
+							ExpenseID:     1,
+							Round:         1,
+							ReviewerID:    1,
+							Notes:         "Wrong amount",
+							RequestedAt:   now,
+							ResubmittedAt: &now,
+							Reviewer:      entity.UserSimple{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+						},
+					}, nil)
 			},
 			wantRes: &model.ExpenseDetailResponse{
 				ID:               1,
 				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
 				Description:      description,
 				ReceiptURL:       &receipt,
 				Status:           "approved",
@@ -377,6 +1200,121 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 					Notes:         &notes,
 					CreatedAt:     now.Format(time.RFC3339),
 				},
+				ReviewRounds: []model.ExpenseReviewRoundResponse{
+					{
+						Round:         1,
+						Reviewer:      model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+						Notes:         "Wrong amount",
+						RequestedAt:   now.Format(time.RFC3339),
+						ResubmittedAt: &resubmittedAt,
+					},
+				},
+				Comments: []model.ExpenseCommentResponse{
+					{
+						ID:        5,
+						Body:      "Please attach the boarding pass",
+						User:      model.UserSimpleResponse{ID: 1, Email: "john@mail.com", Name: "John Doe"},
+						Mentions:  []model.UserSimpleResponse{},
+						CreatedAt: now.Format(time.RFC3339),
+					},
+				},
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on find budget",
+			request: &model.GetExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.ExpenseDetail{
+						Expense: entity.Expense{ID: 1, UserID: 2, DepartmentID: &departmentID, CreatedAt: now},
+					}, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+				rrr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+				dbr.On("FindByDate", mock.Anything, mock.Anything, departmentID, now).
+					Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find budget for department id (3) = something error",
+		},
+		{
+			name: "success with budget",
+			request: &model.GetExpenseRequest{
+				OrgID:    1,
+				ID:       1,
+				UserID:   1,
+				UserRole: "admin",
+			},
+			mockFunc: func(er *mocks.ExpenseRepository, dbr *mocks.DepartmentBudgetRepository, ecr *mocks.ExpenseCommentRepository,
+				rrr *mocks.ExpenseReviewRoundRepository) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.ExpenseDetail{
+						Expense: entity.Expense{
+							ID:                1,
+							UserID:            2,
+							Amount:            10000,
+							ApprovalThreshold: 1000000,
+							Description:       description,
+							Status:            entity.ExpenseStatusApproved,
+							DepartmentID:      &departmentID,
+							CostCenter:        &costCenter,
+							CreatedAt:         now,
+						},
+						User: entity.UserSimple{
+							ID:    2,
+							Email: "jane@mail.com",
+							Name:  "Jane Doe",
+						},
+					}, nil)
+				ecr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+				rrr.On("ListByExpenseID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+				dbr.On("FindByDate", mock.Anything, mock.Anything, departmentID, now).
+					Return(&entity.DepartmentBudget{
+						ID:           1,
+						DepartmentID: departmentID,
+						PeriodStart:  time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+						PeriodEnd:    time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC),
+						Amount:       50000,
+						Spent:        20000,
+						CreatedAt:    now,
+					}, nil)
+			},
+			wantRes: &model.ExpenseDetailResponse{
+				ID:               1,
+				AmountIDR:        10000,
+				AmountFormatted:  "Rp 10.000",
+				Description:      description,
+				Status:           "approved",
+				CostCenter:       &costCenter,
+				RequiresApproval: false,
+				AutoApproved:     true,
+				CreatedAt:        now.Format(time.RFC3339),
+				User: model.UserSimpleResponse{
+					ID:    2,
+					Email: "jane@mail.com",
+					Name:  "Jane Doe",
+				},
+				ReviewRounds: []model.ExpenseReviewRoundResponse{},
+				Comments:     []model.ExpenseCommentResponse{},
+				Budget: &model.DepartmentBudgetResponse{
+					ID:                 1,
+					DepartmentID:       departmentID,
+					PeriodStart:        "2025-07-01",
+					PeriodEnd:          "2025-09-30",
+					AmountIDR:          50000,
+					AmountFormatted:    "Rp 50.000",
+					SpentIDR:           20000,
+					SpentFormatted:     "Rp 20.000",
+					RemainingIDR:       30000,
+					RemainingFormatted: "Rp 30.000",
+					CreatedAt:          now.Format(time.RFC3339),
+				},
 			},
 			wantErrMsg: "",
 		},
@@ -385,13 +1323,16 @@ func (s *ExpenseUsecaseSuite) TestExpenseUsecase_FindByID() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			er := mocks.NewExpenseRepository(s.T())
+			dbr := mocks.NewDepartmentBudgetRepository(s.T())
+			ecr := mocks.NewExpenseCommentRepository(s.T())
+			rrr := mocks.NewExpenseReviewRoundRepository(s.T())
 			p := mocks.NewProducer[*model.ExpenseApprovedEvent](s.T())
 			eap := &messaging.ExpenseApprovedProducer{
 				Producer: p,
 			}
 
-			usecase := usecase.NewExpenseUsecase(s.log, er, eap)
-			tt.mockFunc(er)
+			usecase := usecase.NewExpenseUsecase(s.log, nil, er, nil, dbr, nil, ecr, rrr, eap)
+			tt.mockFunc(er, dbr, ecr, rrr)
 
 			res, err := usecase.FindByID(s.ctx, tt.request)
 
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/export"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type journalUsecase struct {
+	log                        *zap.Logger
+	tx                         db.Transactioner
+	expenseRepository          ExpenseRepository
+	glAccountMappingRepository GLAccountMappingRepository
+	journalBatchRepository     JournalBatchRepository
+}
+
+func NewJournalUsecase(log *zap.Logger, tx db.Transactioner, expenseRepository ExpenseRepository,
+	glAccountMappingRepository GLAccountMappingRepository, journalBatchRepository JournalBatchRepository) JournalUsecase {
+	return &journalUsecase{
+		log:                        log,
+		tx:                         tx,
+		expenseRepository:          expenseRepository,
+		glAccountMappingRepository: glAccountMappingRepository,
+		journalBatchRepository:     journalBatchRepository,
+	}
+}
+
+func (c *journalUsecase) ListMappings(ctx context.Context, req *model.ListGLAccountMappingRequest) ([]model.GLAccountMappingResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	mappings, err := c.glAccountMappingRepository.List(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gl account mappings = %w", err)
+	}
+
+	res := make([]model.GLAccountMappingResponse, len(mappings))
+	for i := range mappings {
+		res[i] = *serializer.GLAccountMappingToResponse(&mappings[i])
+	}
+
+	return res, nil
+}
+
+// UpsertMapping sets the accounts of a cost center, or of the default mapping when the cost center is empty.
+// Batches already created keep the accounts they were posted to.
+func (c *journalUsecase) UpsertMapping(ctx context.Context, req *model.UpsertGLAccountMappingRequest) (*model.GLAccountMappingResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	mapping := &entity.GLAccountMapping{
+		OrgID:          req.OrgID,
+		ExpenseAccount: strings.TrimSpace(req.ExpenseAccount),
+		CreditAccount:  strings.TrimSpace(req.CreditAccount),
+	}
+	// an empty cost center is the default mapping, which is stored as null
+	if req.CostCenter != nil && strings.TrimSpace(*req.CostCenter) != "" {
+		costCenter := strings.TrimSpace(*req.CostCenter)
+		mapping.CostCenter = &costCenter
+	}
+
+	err := c.glAccountMappingRepository.Upsert(ctx, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert gl account mapping = %w", err)
+	}
+
+	return serializer.GLAccountMappingToResponse(mapping), nil
+}
+
+func (c *journalUsecase) DeleteMapping(ctx context.Context, req *model.DeleteGLAccountMappingRequest) error {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return model.ErrForbidden
+	}
+
+	deleted, err := c.glAccountMappingRepository.Delete(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete gl account mapping for id (%d) = %w", req.ID, err)
+	}
+
+	if !deleted {
+		return model.ErrGLAccountMappingNotFound
+	}
+
+	return nil
+}
+
+// CreateBatch posts the completed expenses of the period that aren't in a batch yet, every expense
+// is a balanced pair of lines. A period can get more batches when expenses are completed later.
+func (c *journalUsecase) CreateBatch(ctx context.Context, req *model.CreateJournalBatchRequest) (*model.JournalBatchResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	from, to, err := entity.ParseAccountingPeriod(req.Period)
+	if err != nil {
+		return nil, model.ErrBadRequest
+	}
+
+	mappings, err := c.glAccountMappingRepository.List(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gl account mappings = %w", err)
+	}
+
+	batch := &entity.JournalBatch{
+		OrgID:     req.OrgID,
+		Period:    req.Period,
+		CreatedBy: req.UserID,
+	}
+	var lines []entity.JournalLine
+
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		expenses, txErr := c.expenseRepository.ListUnexportedWithLock(ctx, exec, req.OrgID, from, to)
+		if txErr != nil {
+			return fmt.Errorf("failed to list unexported expenses of period (%s) = %w", req.Period, txErr)
+		}
+
+		if len(expenses) == 0 {
+			return model.ErrJournalBatchEmpty
+		}
+
+		lines, txErr = c.journalLines(expenses, mappings)
+		if txErr != nil {
+			return txErr
+		}
+
+		ids := make([]uint64, len(expenses))
+		for i := range expenses {
+			ids[i] = expenses[i].ID
+			batch.TotalAmount += expenses[i].Amount
+		}
+		batch.LineCount = len(lines)
+
+		txErr = c.journalBatchRepository.CreateTx(ctx, exec, batch)
+		if txErr != nil {
+			return fmt.Errorf("failed to create journal batch of period (%s) = %w", req.Period, txErr)
+		}
+
+		txErr = c.journalBatchRepository.CreateLinesTx(ctx, exec, batch.ID, lines)
+		if txErr != nil {
+			return fmt.Errorf("failed to create journal lines for batch id (%d) = %w", batch.ID, txErr)
+		}
+
+		txErr = c.expenseRepository.SetJournalBatchIDTx(ctx, exec, req.OrgID, ids, batch.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to mark expenses as exported for batch id (%d) = %w", batch.ID, txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.toResponse(batch, lines), nil
+}
+
+func (c *journalUsecase) ListBatches(ctx context.Context, req *model.ListJournalBatchRequest) ([]model.JournalBatchResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	batches, err := c.journalBatchRepository.List(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list journal batches = %w", err)
+	}
+
+	res := make([]model.JournalBatchResponse, len(batches))
+	for i := range batches {
+		res[i] = *serializer.JournalBatchToResponse(&batches[i])
+	}
+
+	return res, nil
+}
+
+func (c *journalUsecase) GetBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.JournalBatchResponse, error) {
+	batch, lines, err := c.findBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.toResponse(batch, lines), nil
+}
+
+// DownloadBatch returns the lines of the batch as a csv file to import into the general ledger
+func (c *journalUsecase) DownloadBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.FileResponse, error) {
+	batch, lines, err := c.findBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := export.NewCSVWriter(&buf)
+	err = writer.Write(serializer.JournalExportHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write journal batch for id (%d) = %w", batch.ID, err)
+	}
+	for i := range lines {
+		err = writer.Write(serializer.JournalLineToExportRow(batch, &lines[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write journal batch for id (%d) = %w", batch.ID, err)
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write journal batch for id (%d) = %w", batch.ID, err)
+	}
+
+	return &model.FileResponse{
+		Name:        fmt.Sprintf("journal-%s-%d.csv", batch.Period, batch.ID),
+		ContentType: entity.ExportFormatCSV.ContentType(),
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+func (c *journalUsecase) findBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*entity.JournalBatch, []entity.JournalLine, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, nil, model.ErrForbidden
+	}
+
+	batch, err := c.journalBatchRepository.FindByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find journal batch by id (%d) = %w", req.ID, err)
+	}
+
+	if batch == nil {
+		return nil, nil, model.ErrJournalBatchNotFound
+	}
+
+	lines, err := c.journalBatchRepository.ListLines(ctx, batch.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list journal lines for batch id (%d) = %w", batch.ID, err)
+	}
+
+	return batch, lines, nil
+}
+
+// journalLines maps every expense to its accounts, nothing is posted while a cost center has no
+// mapping so the error names all of them at once
+func (c *journalUsecase) journalLines(expenses []entity.Expense, mappings entity.GLAccountMappings) ([]entity.JournalLine, error) {
+	var (
+		lines          []entity.JournalLine
+		missing        []string
+		missingDefault bool
+	)
+	for i := range expenses {
+		mapping := mappings.Resolve(expenses[i].CostCenter)
+		if mapping == nil {
+			if expenses[i].CostCenter == nil {
+				missingDefault = true
+			} else if !slices.Contains(missing, *expenses[i].CostCenter) {
+				missing = append(missing, *expenses[i].CostCenter)
+			}
+			continue
+		}
+
+		lines = append(lines, entity.NewJournalLines(&expenses[i], mapping, len(lines)+1)...)
+	}
+
+	if len(missing) == 0 && !missingDefault {
+		return lines, nil
+	}
+
+	err := model.NewGLAccountMappingMissingError(missing...)
+	if missingDefault {
+		err.Append(model.ErrDefaultGLAccountMissing.Errors[0])
+	}
+
+	return nil, err
+}
+
+func (c *journalUsecase) toResponse(batch *entity.JournalBatch, lines []entity.JournalLine) *model.JournalBatchResponse {
+	res := serializer.JournalBatchToResponse(batch)
+	res.Lines = make([]model.JournalLineResponse, len(lines))
+	for i := range lines {
+		res.Lines[i] = *serializer.JournalLineToResponse(&lines[i])
+	}
+
+	return res
+}
@@ -0,0 +1,473 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type JournalUsecaseSuite struct {
+	suite.Suite
+	log        *zap.Logger
+	ctx        context.Context
+	now        time.Time
+	costCenter string
+}
+
+type JournalMockFunc func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+	br *mocks.JournalBatchRepository)
+
+func (s *JournalUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+	s.now = time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+	s.costCenter = "CC-100"
+}
+
+func (s *JournalUsecaseSuite) newUsecase(mockFunc JournalMockFunc) usecase.JournalUsecase {
+	dbMock, _ := pgxmock.NewPool()
+	s.T().Cleanup(dbMock.Close)
+
+	er := mocks.NewExpenseRepository(s.T())
+	mr := mocks.NewGLAccountMappingRepository(s.T())
+	br := mocks.NewJournalBatchRepository(s.T())
+	mockFunc(dbMock, er, mr, br)
+
+	return usecase.NewJournalUsecase(s.log, db.NewTransactioner(dbMock), er, mr, br)
+}
+
+func (s *JournalUsecaseSuite) mappings() []entity.GLAccountMapping {
+	return []entity.GLAccountMapping{
+		{ID: 1, OrgID: 1, ExpenseAccount: "6000", CreditAccount: "2100", CreatedAt: s.now, UpdatedAt: s.now},
+		{ID: 2, OrgID: 1, CostCenter: &s.costCenter, ExpenseAccount: "6100", CreditAccount: "2100", CreatedAt: s.now, UpdatedAt: s.now},
+	}
+}
+
+func (s *JournalUsecaseSuite) batch() *entity.JournalBatch {
+	return &entity.JournalBatch{ID: 4, OrgID: 1, Period: "2025-09", CreatedBy: 2, LineCount: 2, TotalAmount: 150_000, CreatedAt: s.now}
+}
+
+func (s *JournalUsecaseSuite) lines() []entity.JournalLine {
+	entryDate := time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC)
+	return []entity.JournalLine{
+		{ID: 1, BatchID: 4, ExpenseID: 7, LineNo: 1, EntryDate: entryDate, Account: "6100", Debit: 150_000, CostCenter: &s.costCenter, Memo: "EXP-000000007 Taxi"},
+		{ID: 2, BatchID: 4, ExpenseID: 7, LineNo: 2, EntryDate: entryDate, Account: "2100", Credit: 150_000, CostCenter: &s.costCenter, Memo: "EXP-000000007 Taxi"},
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_ListMappings() {
+	tests := []struct {
+		name       string
+		request    *model.ListGLAccountMappingRequest
+		mockFunc   JournalMockFunc
+		wantRes    []model.GLAccountMappingResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.ListGLAccountMappingRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListGLAccountMappingRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list gl account mappings = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListGLAccountMappingRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(s.mappings(), nil)
+			},
+			wantRes: []model.GLAccountMappingResponse{
+				{ID: 1, ExpenseAccount: "6000", CreditAccount: "2100", CreatedAt: "2025-10-01T08:00:00Z", UpdatedAt: "2025-10-01T08:00:00Z"},
+				{ID: 2, CostCenter: &s.costCenter, ExpenseAccount: "6100", CreditAccount: "2100", CreatedAt: "2025-10-01T08:00:00Z",
+					UpdatedAt: "2025-10-01T08:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).ListMappings(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_UpsertMapping() {
+	costCenter := " CC-100 "
+	emptyCostCenter := "  "
+
+	tests := []struct {
+		name       string
+		request    *model.UpsertGLAccountMappingRequest
+		mockFunc   JournalMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.UpsertGLAccountMappingRequest{OrgID: 1, UserRole: "employee", ExpenseAccount: "6000", CreditAccount: "2100"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on upsert",
+			request: &model.UpsertGLAccountMappingRequest{OrgID: 1, UserRole: "admin", ExpenseAccount: "6000", CreditAccount: "2100"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Upsert", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to upsert gl account mapping = something error",
+		},
+		{
+			name:    "success default",
+			request: &model.UpsertGLAccountMappingRequest{OrgID: 1, UserRole: "admin", ExpenseAccount: " 6000 ", CreditAccount: "2100"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Upsert", mock.Anything, &entity.GLAccountMapping{OrgID: 1, ExpenseAccount: "6000", CreditAccount: "2100"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "success empty cost center is the default",
+			request: &model.UpsertGLAccountMappingRequest{OrgID: 1, UserRole: "admin", CostCenter: &emptyCostCenter,
+				ExpenseAccount: "6000", CreditAccount: "2100"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Upsert", mock.Anything, &entity.GLAccountMapping{OrgID: 1, ExpenseAccount: "6000", CreditAccount: "2100"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "success cost center",
+			request: &model.UpsertGLAccountMappingRequest{OrgID: 1, UserRole: "admin", CostCenter: &costCenter, ExpenseAccount: "6100",
+				CreditAccount: "2100"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Upsert", mock.Anything, &entity.GLAccountMapping{OrgID: 1, CostCenter: &s.costCenter, ExpenseAccount: "6100",
+					CreditAccount: "2100"}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).UpsertMapping(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.NotNil(res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_DeleteMapping() {
+	tests := []struct {
+		name       string
+		request    *model.DeleteGLAccountMappingRequest
+		mockFunc   JournalMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "manager"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on delete",
+			request: &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Delete", mock.Anything, uint64(1), uint64(2)).Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete gl account mapping for id (2) = something error",
+		},
+		{
+			name:    "error not found",
+			request: &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Delete", mock.Anything, uint64(1), uint64(2)).Return(false, nil)
+			},
+			wantErrMsg: "GL account mapping not found",
+		},
+		{
+			name:    "success",
+			request: &model.DeleteGLAccountMappingRequest{ID: 2, OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("Delete", mock.Anything, uint64(1), uint64(2)).Return(true, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.newUsecase(tt.mockFunc).DeleteMapping(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_CreateBatch() {
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	processedAt := time.Date(2025, 9, 14, 10, 30, 0, 0, time.UTC)
+	otherCostCenter := "CC-200"
+	request := &model.CreateJournalBatchRequest{OrgID: 1, UserID: 2, UserRole: "admin", Period: "2025-09"}
+	expenses := []entity.Expense{
+		{ID: 7, OrgID: 1, Amount: 150_000, Description: "Taxi", CostCenter: &s.costCenter, Status: entity.ExpenseStatusCompleted,
+			ProcessedAt: &processedAt},
+		{ID: 8, OrgID: 1, Amount: 50_000, Description: "Lunch", Status: entity.ExpenseStatusCompleted, ProcessedAt: &processedAt},
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.CreateJournalBatchRequest
+		mockFunc   JournalMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.CreateJournalBatchRequest{OrgID: 1, UserID: 2, UserRole: "manager", Period: "2025-09"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error invalid period",
+			request: &model.CreateJournalBatchRequest{OrgID: 1, UserID: 2, UserRole: "admin", Period: "2025-13"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Bad request",
+		},
+		{
+			name:    "error on list mappings",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list gl account mappings = something error",
+		},
+		{
+			name:    "error nothing to export",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(s.mappings(), nil)
+				db.ExpectBegin()
+				er.On("ListUnexportedWithLock", mock.Anything, mock.Anything, uint64(1), from, to).Return(nil, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "No completed expenses left to export for the period",
+		},
+		{
+			name:    "error missing mapping",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(s.mappings()[1:], nil)
+				db.ExpectBegin()
+				er.On("ListUnexportedWithLock", mock.Anything, mock.Anything, uint64(1), from, to).Return(append(expenses,
+					entity.Expense{ID: 9, Amount: 10_000, CostCenter: &otherCostCenter, ProcessedAt: &processedAt}), nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "No GL account mapping for the 'CC-200' cost center and no default mapping",
+		},
+		{
+			name:    "error on create lines",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(s.mappings(), nil)
+				db.ExpectBegin()
+				er.On("ListUnexportedWithLock", mock.Anything, mock.Anything, uint64(1), from, to).Return(expenses, nil)
+				br.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil).
+					Run(func(args mock.Arguments) { args.Get(2).(*entity.JournalBatch).ID = 4 })
+				br.On("CreateLinesTx", mock.Anything, mock.Anything, uint64(4), mock.Anything).Return(errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to create journal lines for batch id (4) = something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				mr.On("List", mock.Anything, uint64(1)).Return(s.mappings(), nil)
+				db.ExpectBegin()
+				er.On("ListUnexportedWithLock", mock.Anything, mock.Anything, uint64(1), from, to).Return(expenses, nil)
+				br.On("CreateTx", mock.Anything, mock.Anything, &entity.JournalBatch{
+					OrgID: 1, Period: "2025-09", CreatedBy: 2, LineCount: 4, TotalAmount: 200_000,
+				}).Return(nil).Run(func(args mock.Arguments) { args.Get(2).(*entity.JournalBatch).ID = 4 })
+				br.On("CreateLinesTx", mock.Anything, mock.Anything, uint64(4), mock.MatchedBy(func(lines []entity.JournalLine) bool {
+					// the expense without a cost center falls back to the default accounts
+					return len(lines) == 4 && lines[0].Account == "6100" && lines[1].Account == "2100" &&
+						lines[2].Account == "6000" && lines[2].LineNo == 3 && lines[3].Credit == 50_000
+				})).Return(nil)
+				er.On("SetJournalBatchIDTx", mock.Anything, mock.Anything, mock.Anything, []uint64{7, 8}, uint64(4)).Return(nil)
+				db.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).CreateBatch(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(uint64(4), res.ID)
+				s.Equal("Rp 200.000", res.TotalAmountFormatted)
+				s.Len(res.Lines, 4)
+			}
+		})
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_GetBatch() {
+	request := &model.GetJournalBatchRequest{ID: 4, OrgID: 1, UserRole: "admin"}
+
+	tests := []struct {
+		name       string
+		request    *model.GetJournalBatchRequest
+		mockFunc   JournalMockFunc
+		wantRes    *model.JournalBatchResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.GetJournalBatchRequest{ID: 4, OrgID: 1, UserRole: "employee"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error not found",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				br.On("FindByID", mock.Anything, uint64(1), uint64(4)).Return(nil, nil)
+			},
+			wantErrMsg: "Journal batch not found",
+		},
+		{
+			name:    "error on list lines",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				br.On("FindByID", mock.Anything, uint64(1), uint64(4)).Return(s.batch(), nil)
+				br.On("ListLines", mock.Anything, uint64(4)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list journal lines for batch id (4) = something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+				br *mocks.JournalBatchRepository) {
+				br.On("FindByID", mock.Anything, uint64(1), uint64(4)).Return(s.batch(), nil)
+				br.On("ListLines", mock.Anything, uint64(4)).Return(s.lines(), nil)
+			},
+			wantRes: &model.JournalBatchResponse{
+				ID:                   4,
+				Period:               "2025-09",
+				CreatedBy:            2,
+				LineCount:            2,
+				TotalAmountIDR:       150_000,
+				TotalAmountFormatted: "Rp 150.000",
+				DownloadURL:          "/api/admin/journal-batches/4/download",
+				CreatedAt:            "2025-10-01T08:00:00Z",
+				Lines: []model.JournalLineResponse{
+					{LineNo: 1, ExpenseID: 7, EntryDate: "2025-09-14", Account: "6100", DebitIDR: 150_000, CostCenter: &s.costCenter,
+						Memo: "EXP-000000007 Taxi"},
+					{LineNo: 2, ExpenseID: 7, EntryDate: "2025-09-14", Account: "2100", CreditIDR: 150_000, CostCenter: &s.costCenter,
+						Memo: "EXP-000000007 Taxi"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).GetBatch(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *JournalUsecaseSuite) TestJournalUsecase_DownloadBatch() {
+	usecase := s.newUsecase(func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, mr *mocks.GLAccountMappingRepository,
+		br *mocks.JournalBatchRepository) {
+		br.On("FindByID", mock.Anything, uint64(1), uint64(4)).Return(s.batch(), nil)
+		br.On("ListLines", mock.Anything, uint64(4)).Return(s.lines(), nil)
+	})
+
+	res, err := usecase.DownloadBatch(s.ctx, &model.GetJournalBatchRequest{ID: 4, OrgID: 1, UserRole: "admin"})
+
+	s.Nil(err)
+	s.Equal("journal-2025-09-4.csv", res.Name)
+	s.Equal("text/csv; charset=utf-8", res.ContentType)
+	s.Equal("Batch ID,Period,Line,Entry Date,Account,Debit (IDR),Credit (IDR),Cost Center,Expense ID,Memo\n"+
+		"4,2025-09,1,2025-09-14,6100,150000,,CC-100,7,EXP-000000007 Taxi\n"+
+		"4,2025-09,2,2025-09-14,2100,,150000,CC-100,7,EXP-000000007 Taxi\n", string(res.Content))
+}
+
+func TestJournalUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(JournalUsecaseSuite))
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type ledgerUsecase struct {
+	log               *zap.Logger
+	tx                db.Transactioner
+	expenseRepository ExpenseRepository
+	ledgerRepository  LedgerRepository
+}
+
+func NewLedgerUsecase(log *zap.Logger, tx db.Transactioner, expenseRepository ExpenseRepository,
+	ledgerRepository LedgerRepository) LedgerUsecase {
+	return &ledgerUsecase{
+		log:               log,
+		tx:                tx,
+		expenseRepository: expenseRepository,
+		ledgerRepository:  ledgerRepository,
+	}
+}
+
+func (c *ledgerUsecase) ListBalances(ctx context.Context, req *model.ListLedgerBalanceRequest) ([]model.LedgerBalanceResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	balances, err := c.ledgerRepository.ListBalances(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger balances = %w", err)
+	}
+
+	res := make([]model.LedgerBalanceResponse, len(balances))
+	for i := range balances {
+		res[i] = *serializer.LedgerBalanceToResponse(&balances[i])
+	}
+
+	return res, nil
+}
+
+func (c *ledgerUsecase) ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]model.LedgerEntryResponse, int, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, 0, model.ErrForbidden
+	}
+
+	entries, total, err := c.ledgerRepository.ListEntries(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list ledger entries = %w", err)
+	}
+
+	res := make([]model.LedgerEntryResponse, len(entries))
+	for i := range entries {
+		res[i] = *serializer.LedgerEntryToResponse(&entries[i])
+	}
+
+	return res, total, nil
+}
+
+// Reverse posts the offsetting entry of an approval or a payout, the expense itself is left as it is.
+// The approval of an expense that is still approved can't be reversed, the payment consumer would
+// pay it out anyway and the payable of the employee would go negative.
+func (c *ledgerUsecase) Reverse(ctx context.Context, req *model.ReverseLedgerEntryRequest) (*model.LedgerEntryResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	entry, err := c.ledgerRepository.FindEntryByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ledger entry by id (%d) = %w", req.ID, err)
+	}
+
+	if entry == nil {
+		return nil, model.ErrLedgerEntryNotFound
+	}
+	if entry.Type == entity.LedgerEntryTypeReversal {
+		return nil, model.ErrLedgerEntryNotReversible
+	}
+
+	if entry.Type == entity.LedgerEntryTypeApproval {
+		expense, err := c.expenseRepository.FindByID(ctx, req.OrgID, entry.ExpenseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find expense by id (%d) = %w", entry.ExpenseID, err)
+		}
+
+		if expense == nil {
+			return nil, model.ErrExpenseNotFound
+		}
+		if expense.Status == entity.ExpenseStatusApproved {
+			return nil, model.ErrLedgerApprovalUnpaid
+		}
+	}
+
+	reversal := entry.Reverse(strings.TrimSpace(req.Reason), req.UserID)
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		posted, txErr := c.ledgerRepository.PostTx(ctx, exec, reversal)
+		if txErr != nil {
+			return fmt.Errorf("failed to post reversal of ledger entry id (%d) = %w", req.ID, txErr)
+		}
+
+		if !posted {
+			return model.ErrLedgerEntryReversed
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.LedgerEntryToResponse(reversal), nil
+}
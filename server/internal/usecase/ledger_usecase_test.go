@@ -0,0 +1,311 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type LedgerUsecaseSuite struct {
+	suite.Suite
+	log    *zap.Logger
+	ctx    context.Context
+	now    time.Time
+	userID uint64
+}
+
+type LedgerMockFunc func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository)
+
+func (s *LedgerUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+	s.now = time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+	s.userID = 3
+}
+
+func (s *LedgerUsecaseSuite) newUsecase(mockFunc LedgerMockFunc) usecase.LedgerUsecase {
+	dbMock, _ := pgxmock.NewPool()
+	s.T().Cleanup(dbMock.Close)
+
+	er := mocks.NewExpenseRepository(s.T())
+	lr := mocks.NewLedgerRepository(s.T())
+	mockFunc(dbMock, er, lr)
+
+	return usecase.NewLedgerUsecase(s.log, db.NewTransactioner(dbMock), er, lr)
+}
+
+func (s *LedgerUsecaseSuite) approvalEntry() *entity.LedgerEntry {
+	return &entity.LedgerEntry{
+		ID:        10,
+		OrgID:     1,
+		ExpenseID: 7,
+		Type:      entity.LedgerEntryTypeApproval,
+		Memo:      "Expense approved",
+		CreatedAt: s.now,
+		Postings: []entity.LedgerPosting{
+			{ID: 20, EntryID: 10, AccountID: 1, AccountType: entity.LedgerAccountTypeReimbursementExpense, Debit: 50000},
+			{ID: 21, EntryID: 10, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &s.userID, Credit: 50000},
+		},
+	}
+}
+
+func (s *LedgerUsecaseSuite) payoutEntry() *entity.LedgerEntry {
+	return &entity.LedgerEntry{
+		ID:        10,
+		OrgID:     1,
+		ExpenseID: 7,
+		Type:      entity.LedgerEntryTypePayout,
+		Memo:      "Expense paid out",
+		CreatedAt: s.now,
+		Postings: []entity.LedgerPosting{
+			{ID: 20, EntryID: 10, AccountID: 4, AccountType: entity.LedgerAccountTypeEmployeePayable, UserID: &s.userID, Debit: 50000},
+			{ID: 21, EntryID: 10, AccountID: 2, AccountType: entity.LedgerAccountTypeCash, Credit: 50000},
+		},
+	}
+}
+
+func (s *LedgerUsecaseSuite) TestLedgerUsecase_ListBalances() {
+	tests := []struct {
+		name       string
+		request    *model.ListLedgerBalanceRequest
+		mockFunc   LedgerMockFunc
+		wantRes    []model.LedgerBalanceResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ListLedgerBalanceRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc:   func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListLedgerBalanceRequest{OrgID: 1, UserRole: "admin", UserID: &s.userID},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("ListBalances", mock.Anything, uint64(1), &s.userID).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list ledger balances = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListLedgerBalanceRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("ListBalances", mock.Anything, uint64(1), (*uint64)(nil)).Return([]entity.LedgerBalance{
+					{User: entity.UserSimple{ID: 3, Email: "john@mail.com", Name: "John Doe"}, Balance: 50000},
+				}, nil)
+			},
+			wantRes: []model.LedgerBalanceResponse{
+				{
+					User:             model.UserSimpleResponse{ID: 3, Email: "john@mail.com", Name: "John Doe"},
+					BalanceIDR:       50000,
+					BalanceFormatted: "Rp 50.000",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).ListBalances(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRes, res)
+			}
+		})
+	}
+}
+
+func (s *LedgerUsecaseSuite) TestLedgerUsecase_ListEntries() {
+	tests := []struct {
+		name       string
+		request    *model.ListLedgerEntryRequest
+		mockFunc   LedgerMockFunc
+		wantLen    int
+		wantTotal  int
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ListLedgerEntryRequest{OrgID: 1, UserRole: "employee", Limit: 10},
+			mockFunc:   func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListLedgerEntryRequest{OrgID: 1, UserRole: "admin", Limit: 10},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("ListEntries", mock.Anything, mock.Anything).Return(nil, 0, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list ledger entries = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListLedgerEntryRequest{OrgID: 1, UserRole: "admin", Limit: 10},
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("ListEntries", mock.Anything, mock.MatchedBy(func(req *model.ListLedgerEntryRequest) bool {
+					return req.OrgID == 1 && req.Limit == 10
+				})).Return([]entity.LedgerEntry{*s.payoutEntry()}, 3, nil)
+			},
+			wantLen:   1,
+			wantTotal: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, total, err := s.newUsecase(tt.mockFunc).ListEntries(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Len(res, tt.wantLen)
+				s.Equal(tt.wantTotal, total)
+			}
+		})
+	}
+}
+
+func (s *LedgerUsecaseSuite) TestLedgerUsecase_Reverse() {
+	request := &model.ReverseLedgerEntryRequest{ID: 10, OrgID: 1, UserID: 2, UserRole: "admin", Reason: " Bank transfer bounced "}
+
+	tests := []struct {
+		name       string
+		request    *model.ReverseLedgerEntryRequest
+		mockFunc   LedgerMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ReverseLedgerEntryRequest{ID: 10, OrgID: 1, UserID: 2, UserRole: "manager", Reason: "Mistake"},
+			mockFunc:   func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on find entry",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find ledger entry by id (10) = something error",
+		},
+		{
+			name:    "error entry not found",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(nil, nil)
+			},
+			wantErrMsg: "Ledger entry not found",
+		},
+		{
+			name:    "error on reversing a reversal",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				entry := s.payoutEntry()
+				entry.Type = entity.LedgerEntryTypeReversal
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(entry, nil)
+			},
+			wantErrMsg: "A reversal can't be reversed",
+		},
+		{
+			name:    "error on find expense of an approval",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.approvalEntry(), nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find expense by id (7) = something error",
+		},
+		{
+			name:    "error on reversing the approval of an unpaid expense",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.approvalEntry(), nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).
+					Return(&entity.Expense{ID: 7, OrgID: 1, Status: entity.ExpenseStatusApproved}, nil)
+			},
+			wantErrMsg: "The approval of an expense that is still waiting for payment can't be reversed",
+		},
+		{
+			name:    "error on post",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.payoutEntry(), nil)
+				db.ExpectBegin()
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("something error"))
+				db.ExpectRollback()
+			},
+			wantErrMsg: "failed to post reversal of ledger entry id (10) = something error",
+		},
+		{
+			name:    "error already reversed",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.payoutEntry(), nil)
+				db.ExpectBegin()
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+				db.ExpectRollback()
+			},
+			wantErrMsg: "Ledger entry is already reversed",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.payoutEntry(), nil)
+				db.ExpectBegin()
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypeReversal && *e.ReversesEntryID == 10 && *e.CreatedBy == 2 &&
+						e.Memo == "Bank transfer bounced" && e.Postings[0].Credit == 50000 && e.Postings[1].Debit == 50000
+				})).Return(true, nil)
+				db.ExpectCommit()
+			},
+		},
+		{
+			name:    "success approval of a paid expense",
+			request: request,
+			mockFunc: func(db pgxmock.PgxPoolIface, er *mocks.ExpenseRepository, lr *mocks.LedgerRepository) {
+				lr.On("FindEntryByID", mock.Anything, uint64(1), uint64(10)).Return(s.approvalEntry(), nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(7)).
+					Return(&entity.Expense{ID: 7, OrgID: 1, Status: entity.ExpenseStatusCompleted}, nil)
+				db.ExpectBegin()
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypeReversal && *e.ReversesEntryID == 10 &&
+						e.Postings[0].Credit == 50000 && e.Postings[1].Debit == 50000
+				})).Return(true, nil)
+				db.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Reverse(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("reversal", res.Type)
+			}
+		})
+	}
+}
+
+func TestLedgerUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(LedgerUsecaseSuite))
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type organizationUsecase struct {
+	log                    *zap.Logger
+	organizationRepository OrganizationRepository
+}
+
+func NewOrganizationUsecase(log *zap.Logger, organizationRepository OrganizationRepository) OrganizationUsecase {
+	return &organizationUsecase{
+		log:                    log,
+		organizationRepository: organizationRepository,
+	}
+}
+
+func (c *organizationUsecase) Get(ctx context.Context, req *model.GetOrganizationRequest) (*model.OrganizationResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	organization, err := c.findOrganization(ctx, req.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.OrganizationToResponse(organization), nil
+}
+
+// Update changes the expense policy, existing expenses keep the threshold they were created with
+func (c *organizationUsecase) Update(ctx context.Context, req *model.UpdateOrganizationRequest) (*model.OrganizationResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	organization, err := c.findOrganization(ctx, req.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MinExpenseAmountIDR != nil {
+		organization.MinExpenseAmount = *req.MinExpenseAmountIDR
+	}
+	if req.MaxExpenseAmountIDR != nil {
+		organization.MaxExpenseAmount = *req.MaxExpenseAmountIDR
+	}
+	if req.ApprovalThresholdAmountIDR != nil {
+		organization.ApprovalThresholdAmount = *req.ApprovalThresholdAmountIDR
+	}
+
+	if organization.MinExpenseAmount > organization.MaxExpenseAmount {
+		return nil, model.ErrInvalidExpensePolicy
+	}
+
+	err = c.organizationRepository.Update(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update organization for id (%d) = %w", organization.ID, err)
+	}
+
+	return serializer.OrganizationToResponse(organization), nil
+}
+
+func (c *organizationUsecase) findOrganization(ctx context.Context, id uint64) (*entity.Organization, error) {
+	organization, err := c.organizationRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization by id (%d) = %w", id, err)
+	}
+
+	if organization == nil {
+		return nil, model.ErrOrganizationNotFound
+	}
+
+	return organization, nil
+}
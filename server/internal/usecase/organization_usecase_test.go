@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type OrganizationUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+	now time.Time
+}
+
+func (s *OrganizationUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+	s.now = time.Date(2025, 9, 21, 8, 0, 0, 0, time.UTC)
+}
+
+func (s *OrganizationUsecaseSuite) organization() *entity.Organization {
+	return &entity.Organization{
+		ID:                      1,
+		Name:                    "Default",
+		MinExpenseAmount:        10000,
+		MaxExpenseAmount:        50000000,
+		ApprovalThresholdAmount: 1000000,
+		CreatedAt:               s.now,
+		UpdatedAt:               s.now,
+	}
+}
+
+func (s *OrganizationUsecaseSuite) TestOrganizationUsecase_Get() {
+	tests := []struct {
+		name       string
+		request    *model.GetOrganizationRequest
+		mockFunc   func(or *mocks.OrganizationRepository)
+		wantRes    *model.OrganizationResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.GetOrganizationRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc:   func(or *mocks.OrganizationRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on find",
+			request: &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find organization by id (1) = something error",
+		},
+		{
+			name:    "error not found",
+			request: &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Organization not found",
+		},
+		{
+			name:    "success",
+			request: &model.GetOrganizationRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(s.organization(), nil)
+			},
+			wantRes: &model.OrganizationResponse{
+				ID:                               1,
+				Name:                             "Default",
+				MinExpenseAmountIDR:              10000,
+				MinExpenseAmountFormatted:        "Rp 10.000",
+				MaxExpenseAmountIDR:              50000000,
+				MaxExpenseAmountFormatted:        "Rp 50.000.000",
+				ApprovalThresholdAmountIDR:       1000000,
+				ApprovalThresholdAmountFormatted: "Rp 1.000.000",
+				CreatedAt:                        "2025-09-21T08:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			organizationRepository := mocks.NewOrganizationRepository(s.T())
+			usecase := usecase.NewOrganizationUsecase(s.log, organizationRepository)
+			tt.mockFunc(organizationRepository)
+
+			res, err := usecase.Get(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *OrganizationUsecaseSuite) TestOrganizationUsecase_Update() {
+	minAmount := uint64(100000000)
+	threshold := uint64(2000000)
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateOrganizationRequest
+		mockFunc   func(or *mocks.OrganizationRepository)
+		wantRes    *model.OrganizationResponse
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.UpdateOrganizationRequest{OrgID: 1, UserRole: "employee", ApprovalThresholdAmountIDR: &threshold},
+			mockFunc:   func(or *mocks.OrganizationRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error not found",
+			request: &model.UpdateOrganizationRequest{OrgID: 1, UserRole: "admin", ApprovalThresholdAmountIDR: &threshold},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Organization not found",
+		},
+		{
+			name:    "error min greater than max",
+			request: &model.UpdateOrganizationRequest{OrgID: 1, UserRole: "admin", MinExpenseAmountIDR: &minAmount},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(s.organization(), nil)
+			},
+			wantErrMsg: "Minimum amount can't be greater than the maximum amount",
+		},
+		{
+			name:    "error on update",
+			request: &model.UpdateOrganizationRequest{OrgID: 1, UserRole: "admin", ApprovalThresholdAmountIDR: &threshold},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(s.organization(), nil)
+				or.On("Update", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update organization for id (1) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.UpdateOrganizationRequest{OrgID: 1, UserRole: "admin", ApprovalThresholdAmountIDR: &threshold},
+			mockFunc: func(or *mocks.OrganizationRepository) {
+				or.On("FindByID", mock.Anything, uint64(1)).Return(s.organization(), nil)
+				or.On("Update", mock.Anything, mock.MatchedBy(func(o *entity.Organization) bool {
+					return o.ApprovalThresholdAmount == 2000000 && o.MinExpenseAmount == 10000
+				})).Return(nil)
+			},
+			wantRes: &model.OrganizationResponse{
+				ID:                               1,
+				Name:                             "Default",
+				MinExpenseAmountIDR:              10000,
+				MinExpenseAmountFormatted:        "Rp 10.000",
+				MaxExpenseAmountIDR:              50000000,
+				MaxExpenseAmountFormatted:        "Rp 50.000.000",
+				ApprovalThresholdAmountIDR:       2000000,
+				ApprovalThresholdAmountFormatted: "Rp 2.000.000",
+				CreatedAt:                        "2025-09-21T08:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			organizationRepository := mocks.NewOrganizationRepository(s.T())
+			usecase := usecase.NewOrganizationUsecase(s.log, organizationRepository)
+			tt.mockFunc(organizationRepository)
+
+			res, err := usecase.Update(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestOrganizationUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationUsecaseSuite))
+}
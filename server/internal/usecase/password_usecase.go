@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mail"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// resetMailTimeout bounds the background work of a forgot request, it no longer has the request context
+const resetMailTimeout = 30 * time.Second
+
+type PasswordConfig struct {
+	ResetURL       string // page of the web client, the token is added as query param
+	ResetTokenTTL  time.Duration
+	ForgotThrottle time.Duration // minimum time between two reset mails to the same email
+	SessionTTL     time.Duration // access token lifetime, a user-wide revoke must outlive every issued token
+}
+
+type passwordUsecase struct {
+	log                           *zap.Logger
+	redisClient                   storage.RedisClient
+	mailSender                    mail.Sender
+	loginGuard                    auth.LoginGuard
+	userRepository                UserRepository
+	personalAccessTokenRepository PersonalAccessTokenRepository
+	cfg                           PasswordConfig
+}
+
+func NewPasswordUsecase(log *zap.Logger, redisClient storage.RedisClient, mailSender mail.Sender,
+	loginGuard auth.LoginGuard, userRepository UserRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository, cfg PasswordConfig) PasswordUsecase {
+	return &passwordUsecase{
+		log:                           log,
+		redisClient:                   redisClient,
+		mailSender:                    mailSender,
+		loginGuard:                    loginGuard,
+		userRepository:                userRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		cfg:                           cfg,
+	}
+}
+
+// Forgot always succeeds for a valid email so the response can't be used to probe accounts,
+// the account is looked up and the mail sent in the background so the response time doesn't tell either.
+// Requests for an email that got one within the throttle are dropped the same silent way
+func (c *passwordUsecase) Forgot(ctx context.Context, req *model.ForgotPasswordRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	forgotKey := fmt.Sprintf("%s:%s", auth.PrefixPasswordForgotKey, email)
+	ok, err := c.redisClient.SetNX(ctx, forgotKey, 1, c.cfg.ForgotThrottle).Result()
+	if err != nil {
+		return fmt.Errorf("failed to throttle reset password for email (%s) = %w", req.Email, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resetMailTimeout)
+		defer cancel()
+
+		err := c.sendResetMail(ctx, req.Email)
+		if err != nil {
+			c.log.Error(
+				fmt.Sprintf("failed to send reset password mail for email (%s) = %s", req.Email, err.Error()),
+				zap.Strings("tags", []string{"password", "forgot", "send-mail"}),
+			)
+		}
+	}()
+
+	return nil
+}
+
+func (c *passwordUsecase) sendResetMail(ctx context.Context, email string) error {
+	user, err := c.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user by email (%s) = %w", email, err)
+	}
+
+	// deactivated users get the same silent response as unknown emails
+	if user == nil || !user.Active {
+		return nil
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token for id (%d) = %w", user.ID, err)
+	}
+
+	tokenHash := auth.HashOpaqueToken(token)
+	resetKey := fmt.Sprintf("%s:%s", auth.PrefixPasswordResetKey, tokenHash)
+	err = c.redisClient.SetEx(ctx, resetKey, userKey(user), c.cfg.ResetTokenTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set reset token for id (%d) = %w", user.ID, err)
+	}
+
+	// the index lives as long as the newest token, so a password change can delete every open one
+	indexKey := fmt.Sprintf("%s:%d", auth.PrefixPasswordResetUserKey, user.ID)
+	err = c.redisClient.SAdd(ctx, indexKey, tokenHash).Err()
+	if err != nil {
+		return fmt.Errorf("failed to index reset token for id (%d) = %w", user.ID, err)
+	}
+
+	err = c.redisClient.Expire(ctx, indexKey, c.cfg.ResetTokenTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to expire reset token index for id (%d) = %w", user.ID, err)
+	}
+
+	return c.mailSender.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n"+
+				"%s\n\nThe link expires in %d minutes and can only be used once. "+
+				"If you didn't request this, you can ignore this email.\n",
+			user.Name,
+			c.resetLink(token),
+			int(c.cfg.ResetTokenTTL.Minutes()),
+		),
+	})
+}
+
+func (c *passwordUsecase) Reset(ctx context.Context, req *model.ResetPasswordRequest) error {
+	resetKey := fmt.Sprintf("%s:%s", auth.PrefixPasswordResetKey, auth.HashOpaqueToken(req.Token))
+	val, err := c.redisClient.GetDel(ctx, resetKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return model.ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to get reset token = %w", err)
+	}
+
+	orgID, userID, err := parseUserKey(val)
+	if err != nil {
+		return fmt.Errorf("failed to parse reset token user id (%s) = %w", val, err)
+	}
+
+	user, err := c.userRepository.FindByID(ctx, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id (%d) = %w", userID, err)
+	}
+
+	if user == nil {
+		return model.ErrInvalidResetToken
+	}
+
+	err = c.updatePassword(ctx, user, req.Password)
+	if err != nil {
+		return err
+	}
+
+	// the user proved access to the mailbox, a lockout from earlier guesses no longer applies
+	err = c.loginGuard.Reset(ctx, user.Email)
+	if err != nil {
+		c.log.Warn(
+			fmt.Sprintf("failed to reset login attempts for email (%s) = %s", user.Email, err.Error()),
+			zap.Strings("tags", []string{"password", "reset", "reset-attempt"}),
+		)
+	}
+
+	return nil
+}
+
+func (c *passwordUsecase) Change(ctx context.Context, req *model.ChangePasswordRequest) error {
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by id (%d) = %w", req.UserID, err)
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword))
+	if err != nil {
+		return model.ErrInvalidCurrentPassword
+	}
+
+	return c.updatePassword(ctx, user, req.NewPassword)
+}
+
+func (c *passwordUsecase) updatePassword(ctx context.Context, user *entity.User, password string) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to generate password hash for id (%d) = %w", user.ID, err)
+	}
+
+	err = c.userRepository.UpdatePassword(ctx, user.OrgID, user.ID, string(passwordHash))
+	if err != nil {
+		return fmt.Errorf("failed to update password for id (%d) = %w", user.ID, err)
+	}
+
+	err = c.deleteResetTokens(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = revokeUserTokens(ctx, c.redisClient, c.personalAccessTokenRepository, user.ID, c.cfg.SessionTTL)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deleteResetTokens deletes every reset token still open for the user, a link
+// mailed before the password changed must not be usable afterwards
+func (c *passwordUsecase) deleteResetTokens(ctx context.Context, userID uint64) error {
+	indexKey := fmt.Sprintf("%s:%d", auth.PrefixPasswordResetUserKey, userID)
+	tokenHashes, err := c.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get reset tokens for id (%d) = %w", userID, err)
+	}
+
+	keys := make([]string, 0, len(tokenHashes)+1)
+	for _, tokenHash := range tokenHashes {
+		keys = append(keys, fmt.Sprintf("%s:%s", auth.PrefixPasswordResetKey, tokenHash))
+	}
+	keys = append(keys, indexKey)
+
+	err = c.redisClient.Del(ctx, keys...).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete reset tokens for id (%d) = %w", userID, err)
+	}
+
+	return nil
+}
+
+func (c *passwordUsecase) resetLink(token string) string {
+	u, err := url.Parse(c.cfg.ResetURL)
+	if err != nil {
+		return c.cfg.ResetURL + "?token=" + url.QueryEscape(token)
+	}
+
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// revokeUserTokens invalidates every access token issued to the user up to now,
+// the auth middleware compares it against the token iat. The key only outlives the
+// sessions, so personal access tokens, which live longer, are revoked in the database
+func revokeUserTokens(ctx context.Context, redisClient storage.RedisClient,
+	personalAccessTokenRepository PersonalAccessTokenRepository, userID uint64, ttl time.Duration) error {
+	now := time.Now()
+	revokeKey := fmt.Sprintf("%s:%d", auth.PrefixRevokeUserKey, userID)
+
+	err := redisClient.SetEx(ctx, revokeKey, now.Unix(), ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke user tokens for id (%d) = %w", userID, err)
+	}
+
+	err = personalAccessTokenRepository.RevokeByUserID(ctx, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access tokens for user id (%d) = %w", userID, err)
+	}
+
+	return nil
+}
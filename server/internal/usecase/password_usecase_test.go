@@ -0,0 +1,524 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mail"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+	cfg usecase.PasswordConfig
+}
+
+type PasswordMockFunc func(
+	rc *mocks.RedisClient,
+	ms *mocks.Sender,
+	lg *mocks.LoginGuard,
+	ur *mocks.UserRepository,
+	pr *mocks.PersonalAccessTokenRepository,
+)
+
+func (s *PasswordUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+	s.cfg = usecase.PasswordConfig{
+		ResetURL:       "http://localhost:5173/reset-password",
+		ResetTokenTTL:  30 * time.Minute,
+		ForgotThrottle: time.Minute,
+		SessionTTL:     24 * time.Hour,
+	}
+}
+
+func (s *PasswordUsecaseSuite) statusCmd(err error) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *PasswordUsecaseSuite) boolCmd(val bool, err error) *redis.BoolCmd {
+	cmd := redis.NewBoolCmd(s.ctx)
+	cmd.SetVal(val)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *PasswordUsecaseSuite) intCmd(err error) *redis.IntCmd {
+	cmd := redis.NewIntCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *PasswordUsecaseSuite) stringSliceCmd(val []string, err error) *redis.StringSliceCmd {
+	cmd := redis.NewStringSliceCmd(s.ctx)
+	cmd.SetVal(val)
+	cmd.SetErr(err)
+	return cmd
+}
+
+// waitBackground waits for the last call the background part of a forgot request makes
+func (s *PasswordUsecaseSuite) waitBackground(finished chan struct{}) {
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		s.Fail("reset password mail was not handled in the background")
+	}
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_Forgot() {
+	user := &entity.User{ID: 1, OrgID: 1, Email: "john@mail.com", Name: "John Doe", Active: true}
+
+	tests := []struct {
+		name string
+		// done must run on the last call of the background part
+		mockFunc   func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments))
+		background bool
+		wantErrMsg string
+	}{
+		{
+			name: "error on throttle",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).
+					Return(s.boolCmd(false, errors.New("something error")))
+			},
+			wantErrMsg: "failed to throttle reset password for email (John@Mail.com) = something error",
+		},
+		{
+			name: "success throttled",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).
+					Return(s.boolCmd(false, nil))
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success even when find by email fails",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Run(done).Return(nil, errors.New("something error"))
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success unknown email",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Run(done).Return(nil, nil)
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success deactivated user",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Run(done).
+					Return(&entity.User{ID: 1, Email: "john@mail.com", Active: false}, nil)
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success even when set reset token fails",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Return(user, nil)
+				rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 30*time.Minute).Run(done).
+					Return(s.statusCmd(errors.New("something error")))
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success even when index reset token fails",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Return(user, nil)
+				rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 30*time.Minute).Return(s.statusCmd(nil))
+				rc.On("SAdd", mock.Anything, "password-reset-user:1", mock.Anything).Run(done).
+					Return(s.intCmd(errors.New("something error")))
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success even when mail fails",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Return(user, nil)
+				rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 30*time.Minute).Return(s.statusCmd(nil))
+				rc.On("SAdd", mock.Anything, "password-reset-user:1", mock.Anything).Return(s.intCmd(nil))
+				rc.On("Expire", mock.Anything, "password-reset-user:1", 30*time.Minute).Return(s.boolCmd(true, nil))
+				ms.On("Send", mock.Anything, mock.Anything).Run(done).Return(errors.New("something error"))
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, ur *mocks.UserRepository, done func(mock.Arguments)) {
+				rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+				ur.On("FindByEmail", mock.Anything, "John@Mail.com").Return(user, nil)
+				rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 30*time.Minute).Return(s.statusCmd(nil))
+				rc.On("SAdd", mock.Anything, "password-reset-user:1", mock.Anything).Return(s.intCmd(nil))
+				rc.On("Expire", mock.Anything, "password-reset-user:1", 30*time.Minute).Return(s.boolCmd(true, nil))
+				ms.On("Send", mock.Anything, mock.MatchedBy(func(msg *mail.Message) bool {
+					return msg.To == "john@mail.com" &&
+						strings.Contains(msg.Body, "http://localhost:5173/reset-password?token=")
+				})).Run(done).Return(nil)
+			},
+			background: true,
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			ms := mocks.NewSender(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewPasswordUsecase(s.log, rc, ms, mocks.NewLoginGuard(s.T()), ur,
+				mocks.NewPersonalAccessTokenRepository(s.T()), s.cfg)
+			finished := make(chan struct{})
+			tt.mockFunc(rc, ms, ur, func(mock.Arguments) { close(finished) })
+
+			err := usecase.Forgot(s.ctx, &model.ForgotPasswordRequest{Email: "John@Mail.com"})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+
+			if tt.background {
+				s.waitBackground(finished)
+			}
+		})
+	}
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_ForgotTokenMatchesKey() {
+	rc := mocks.NewRedisClient(s.T())
+	ms := mocks.NewSender(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	usecase := usecase.NewPasswordUsecase(s.log, rc, ms, mocks.NewLoginGuard(s.T()), ur, nil, s.cfg)
+
+	var resetKey string
+	var indexedHash string
+	var body string
+	finished := make(chan struct{})
+	rc.On("SetNX", mock.Anything, "password-forgot:john@mail.com", 1, time.Minute).Return(s.boolCmd(true, nil))
+	ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{ID: 1, OrgID: 1, Email: "john@mail.com", Active: true}, nil)
+	rc.On("SetEx", mock.Anything, mock.Anything, "1:1", 30*time.Minute).
+		Run(func(args mock.Arguments) { resetKey = args.String(1) }).
+		Return(s.statusCmd(nil))
+	rc.On("SAdd", mock.Anything, "password-reset-user:1", mock.Anything).
+		Run(func(args mock.Arguments) { indexedHash = args.String(2) }).
+		Return(s.intCmd(nil))
+	rc.On("Expire", mock.Anything, "password-reset-user:1", 30*time.Minute).Return(s.boolCmd(true, nil))
+	ms.On("Send", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			body = args.Get(1).(*mail.Message).Body
+			close(finished)
+		}).
+		Return(nil)
+
+	err := usecase.Forgot(s.ctx, &model.ForgotPasswordRequest{Email: "john@mail.com"})
+	s.Nil(err)
+	s.waitBackground(finished)
+
+	// the mailed token is never stored as is, only its hash is part of the key
+	start := strings.Index(body, "http://")
+	link, _ := url.Parse(strings.Fields(body[start:])[0])
+	token := link.Query().Get("token")
+	s.NotEmpty(token)
+	s.NotContains(resetKey, token)
+	s.Equal("password-reset:"+auth.HashOpaqueToken(token), resetKey)
+	s.Equal(auth.HashOpaqueToken(token), indexedHash)
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_Reset() {
+	getDel := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+	// sha256 of "reset-token"
+	resetKey := "password-reset:7c18b43a1d8227cddb332e67971e790ce35ac2303f4fccfb2a565622f2fe1cec"
+	user := &entity.User{ID: 1, OrgID: 1, Email: "john@mail.com"}
+
+	tests := []struct {
+		name       string
+		mockFunc   PasswordMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error token not found",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("", redis.Nil))
+			},
+			wantErrMsg: "Invalid or expired password reset token",
+		},
+		{
+			name: "error on get token",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to get reset token = something error",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Invalid or expired password reset token",
+		},
+		{
+			name: "error on update password",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update password for id (1) = something error",
+		},
+		{
+			name: "error on get reset tokens",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").
+					Return(s.stringSliceCmd(nil, errors.New("something error")))
+			},
+			wantErrMsg: "failed to get reset tokens for id (1) = something error",
+		},
+		{
+			name: "error on delete reset tokens",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd([]string{"hash"}, nil))
+				rc.On("Del", mock.Anything, "password-reset:hash", "password-reset-user:1").
+					Return(s.intCmd(errors.New("something error")))
+			},
+			wantErrMsg: "failed to delete reset tokens for id (1) = something error",
+		},
+		{
+			name: "error on revoke tokens",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd([]string{"hash"}, nil))
+				rc.On("Del", mock.Anything, "password-reset:hash", "password-reset-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(errors.New("something error")))
+			},
+			wantErrMsg: "failed to revoke user tokens for id (1) = something error",
+		},
+		{
+			name: "error on revoke access tokens",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd([]string{"hash"}, nil))
+				rc.On("Del", mock.Anything, "password-reset:hash", "password-reset-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to revoke access tokens for user id (1) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				rc.On("GetDel", mock.Anything, resetKey).Return(getDel("1:1", nil))
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.MatchedBy(func(hash string) bool {
+					return bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")) == nil
+				})).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd([]string{"hash"}, nil))
+				rc.On("Del", mock.Anything, "password-reset:hash", "password-reset-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+				lg.On("Reset", mock.Anything, "john@mail.com").Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			ms := mocks.NewSender(s.T())
+			lg := mocks.NewLoginGuard(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPasswordUsecase(s.log, rc, ms, lg, ur, pr, s.cfg)
+			tt.mockFunc(rc, ms, lg, ur, pr)
+
+			err := usecase.Reset(s.ctx, &model.ResetPasswordRequest{
+				Token:    "reset-token",
+				Password: "new-password",
+			})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_Change() {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := &entity.User{ID: 1, Email: "john@mail.com", PasswordHash: string(passwordHash)}
+
+	tests := []struct {
+		name       string
+		request    *model.ChangePasswordRequest
+		mockFunc   PasswordMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find by id",
+			request: &model.ChangePasswordRequest{UserID: 1, CurrentPassword: "password", NewPassword: "new-password"},
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (1) = something error",
+		},
+		{
+			name:    "error user not found",
+			request: &model.ChangePasswordRequest{UserID: 1, CurrentPassword: "password", NewPassword: "new-password"},
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error invalid current password",
+			request: &model.ChangePasswordRequest{UserID: 1, CurrentPassword: "wrong", NewPassword: "new-password"},
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+			},
+			wantErrMsg: "Current password is incorrect",
+		},
+		{
+			name:    "success",
+			request: &model.ChangePasswordRequest{UserID: 1, CurrentPassword: "password", NewPassword: "new-password"},
+			mockFunc: func(rc *mocks.RedisClient, ms *mocks.Sender, lg *mocks.LoginGuard, ur *mocks.UserRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+				rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd([]string{"hash"}, nil))
+				rc.On("Del", mock.Anything, "password-reset:hash", "password-reset-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rc := mocks.NewRedisClient(s.T())
+			ms := mocks.NewSender(s.T())
+			lg := mocks.NewLoginGuard(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPasswordUsecase(s.log, rc, ms, lg, ur, pr, s.cfg)
+			tt.mockFunc(rc, ms, lg, ur, pr)
+
+			err := usecase.Change(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+// the revoke key in Redis expires with the sessions, an access token must stay revoked after that
+func (s *PasswordUsecaseSuite) TestPasswordUsecase_ChangeRevokesAccessTokens() {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	apiKey := "ems_dummy-key"
+	token := &entity.PersonalAccessToken{
+		ID:        3,
+		UserID:    1,
+		TokenHash: auth.HashOpaqueToken(apiKey),
+		ExpiresAt: time.Now().Add(365 * 24 * time.Hour),
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+
+	rc := mocks.NewRedisClient(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	pr := mocks.NewPersonalAccessTokenRepository(s.T())
+	ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
+		Return(&entity.User{ID: 1, Email: "john@mail.com", PasswordHash: string(passwordHash), Active: true}, nil)
+	ur.On("UpdatePassword", mock.Anything, mock.Anything, uint64(1), mock.Anything).Return(nil)
+	rc.On("SMembers", mock.Anything, "password-reset-user:1").Return(s.stringSliceCmd(nil, nil))
+	rc.On("Del", mock.Anything, "password-reset-user:1").Return(s.intCmd(nil))
+	rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).Return(s.statusCmd(nil))
+	pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).
+		Run(func(args mock.Arguments) {
+			revokedAt := args.Get(2).(time.Time)
+			token.RevokedAt = &revokedAt
+		}).
+		Return(nil)
+	pr.On("FindByTokenHash", mock.Anything, token.TokenHash).Return(token, nil)
+
+	passwordUsecase := usecase.NewPasswordUsecase(s.log, rc, mocks.NewSender(s.T()), mocks.NewLoginGuard(s.T()), ur, pr, s.cfg)
+	err := passwordUsecase.Change(s.ctx, &model.ChangePasswordRequest{UserID: 1, CurrentPassword: "password", NewPassword: "new-password"})
+	s.Nil(err)
+
+	// no Redis lookup is involved, the token is refused from the database alone
+	tokenUsecase := usecase.NewPersonalAccessTokenUsecase(s.log, ur, pr)
+	claims, err := tokenUsecase.Authenticate(s.ctx, apiKey)
+	s.Nil(claims)
+	s.Equal("access token (3) is revoked or expired", err.Error())
+}
+
+func TestPasswordUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordUsecaseSuite))
+}
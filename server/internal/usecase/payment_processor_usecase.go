@@ -4,6 +4,7 @@ import (
 	"context"
 	"expense-management-system/internal/db"
 	"expense-management-system/internal/entity"
+	"expense-management-system/internal/export"
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/storage"
 	"fmt"
@@ -13,8 +14,9 @@ import (
 )
 
 const (
-	prefixLockKey = "expense-payment:lock:"
-	lockValue     = "lock"
+	prefixLockKey     = "expense-payment:lock:"
+	lockValue         = "lock"
+	voucherTimeLayout = "2006-01-02 15:04 UTC"
 )
 
 type paymentProcessorUsecase struct {
@@ -22,25 +24,31 @@ type paymentProcessorUsecase struct {
 	redisClient              storage.RedisClient
 	tx                       db.Transactioner
 	expenseRepository        ExpenseRepository
+	ledgerRepository         LedgerRepository
 	paymentPartnerRepository PaymentPartnerRepository
+	paymentVoucherRepository PaymentVoucherRepository
+	fileStorage              storage.FileStorage
 	paymentLockDuration      int
 }
 
 func NewPaymentProcessorUsecase(log *zap.Logger, redisClient storage.RedisClient, tx db.Transactioner,
-	expenseRepository ExpenseRepository, paymentPartnerRepository PaymentPartnerRepository,
-	paymentLockDuration int) PaymentProcessorUsecase {
+	expenseRepository ExpenseRepository, ledgerRepository LedgerRepository, paymentPartnerRepository PaymentPartnerRepository,
+	paymentVoucherRepository PaymentVoucherRepository, fileStorage storage.FileStorage, paymentLockDuration int) PaymentProcessorUsecase {
 	return &paymentProcessorUsecase{
 		log:                      log,
 		redisClient:              redisClient,
 		tx:                       tx,
 		expenseRepository:        expenseRepository,
+		ledgerRepository:         ledgerRepository,
 		paymentPartnerRepository: paymentPartnerRepository,
+		paymentVoucherRepository: paymentVoucherRepository,
+		fileStorage:              fileStorage,
 		paymentLockDuration:      paymentLockDuration,
 	}
 }
 
 func (c *paymentProcessorUsecase) Execute(ctx context.Context, req *model.PaymentProcessorRequest) error {
-	expense, err := c.expenseRepository.FindByID(ctx, req.ID)
+	expense, err := c.expenseRepository.FindByID(ctx, req.OrgID, req.ID)
 	if err != nil {
 		return fmt.Errorf("failed to find expense by id (%d) = %w", req.ID, err)
 	}
@@ -53,6 +61,12 @@ func (c *paymentProcessorUsecase) Execute(ctx context.Context, req *model.Paymen
 		return nil
 	}
 
+	// the voucher is issued after the payout is committed, a retry of a paid expense only
+	// issues the voucher that failed before
+	if expense.Status == entity.ExpenseStatusCompleted {
+		return c.issueVoucher(ctx, req.OrgID, req.ID)
+	}
+
 	if expense.Status != entity.ExpenseStatusApproved {
 		c.log.Info(
 			fmt.Sprintf("invalid expense status for id (%d) = %s", req.ID, expense.Status),
@@ -90,22 +104,126 @@ func (c *paymentProcessorUsecase) Execute(ctx context.Context, req *model.Paymen
 		// since the partner guarantees idempotency, next retries will succeed
 		// and indicate that the payment was already executed, avoiding double payment
 		// afterwards, we can safely retry updating the expense to completed
-		err = c.expenseRepository.CompleteByIDTx(ctx, exec, req.ID, time.Now())
+		err = c.expenseRepository.CompleteByIDTx(ctx, exec, req.OrgID, req.ID, time.Now())
 		if err != nil {
 			return fmt.Errorf("failed to update expense for id (%d) = %w", req.ID, err)
 		}
 
+		// the payout settles the payable in the same transaction, so the ledger never
+		// shows a payment the expense doesn't
+		_, err = c.ledgerRepository.PostTx(ctx, exec, entity.NewPayoutLedgerEntry(expense))
+		if err != nil {
+			return fmt.Errorf("failed to post payout ledger entry for expense id (%d) = %w", req.ID, err)
+		}
+
 		partnerReq := &model.PaymentPartnerRequest{
 			Amount:     req.Amount,
 			ExternalID: req.IdempotencyKey,
 		}
-		_, err = c.paymentPartnerRepository.Execute(ctx, partnerReq)
+		partnerRes, err := c.paymentPartnerRepository.Execute(ctx, partnerReq)
 		if err != nil {
 			return fmt.Errorf("failed to call partner for expense id (%d) = %w", req.ID, err)
 		}
 
+		voucher := &entity.PaymentVoucher{
+			OrgID:                expense.OrgID,
+			ExpenseID:            expense.ID,
+			PartnerTransactionID: partnerRes.PartnerID,
+		}
+		err = c.paymentVoucherRepository.CreateTx(ctx, exec, voucher)
+		if err != nil {
+			return fmt.Errorf("failed to create payment voucher for expense id (%d) = %w", req.ID, err)
+		}
+
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return c.issueVoucher(ctx, req.OrgID, req.ID)
+}
+
+// issueVoucher stores the voucher pdf of the paid expense once, expenses paid before vouchers
+// existed have none
+func (c *paymentProcessorUsecase) issueVoucher(ctx context.Context, orgID uint64, id uint64) error {
+	voucher, err := c.paymentVoucherRepository.FindByExpenseID(ctx, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to find payment voucher of expense id (%d) = %w", id, err)
+	}
+
+	if voucher == nil || voucher.FileKey != nil {
+		return nil
+	}
+
+	expense, err := c.expenseRepository.FindDetailByID(ctx, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to find expense by id (%d) = %w", id, err)
+	}
+
+	_, err = issuePaymentVoucher(ctx, c.paymentVoucherRepository, c.fileStorage, expense, voucher)
+	if err != nil {
+		return fmt.Errorf("failed to issue payment voucher of expense id (%d) = %w", id, err)
+	}
+
+	return nil
+}
+
+// issuePaymentVoucher renders the voucher of the paid expense and keeps it in the file storage
+func issuePaymentVoucher(ctx context.Context, paymentVoucherRepository PaymentVoucherRepository, fileStorage storage.FileStorage,
+	expense *entity.ExpenseDetail, voucher *entity.PaymentVoucher) (*storage.File, error) {
+	now := time.Now()
+	file := &storage.File{
+		Key:         entity.PaymentVoucherFileKey(voucher.OrgID, voucher.ExpenseID),
+		ContentType: "application/pdf",
+		Content:     renderPaymentVoucher(expense, voucher, now),
+	}
+
+	err := fileStorage.Put(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store file (%s) = %w", file.Key, err)
+	}
+
+	err = paymentVoucherRepository.SetFile(ctx, voucher.ID, file.Key, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set file of payment voucher id (%d) = %w", voucher.ID, err)
+	}
+
+	return file, nil
+}
+
+func renderPaymentVoucher(expense *entity.ExpenseDetail, voucher *entity.PaymentVoucher, generatedAt time.Time) []byte {
+	doc := export.NewPDF()
+	doc.Title("Payment Voucher")
+	doc.Field("Reference", expense.GetKey())
+	doc.Field("Partner transaction", voucher.PartnerTransactionID)
+	doc.Field("Amount", model.FormatRupiah(expense.Amount))
+	doc.Space()
+
+	doc.Field("Payee", fmt.Sprintf("%s <%s>", expense.User.Name, expense.User.Email))
+	doc.Field("Description", expense.Description)
+	if expense.CostCenter != nil {
+		doc.Field("Cost center", *expense.CostCenter)
+	}
+	doc.Field("Submitted", expense.CreatedAt.UTC().Format(voucherTimeLayout))
+	doc.Space()
+
+	if expense.Approval != nil {
+		doc.Field("Approved by", fmt.Sprintf("%s <%s>", expense.Approval.ApproverName, expense.Approval.ApproverEmail))
+		doc.Field("Approved", expense.Approval.CreatedAt.UTC().Format(voucherTimeLayout))
+		if expense.Approval.Notes != nil && *expense.Approval.Notes != "" {
+			doc.Field("Approval notes", *expense.Approval.Notes)
+		}
+	} else {
+		doc.Field("Approved by", "Automatically approved")
+	}
+
+	if expense.ProcessedAt != nil {
+		doc.Field("Paid", expense.ProcessedAt.UTC().Format(voucherTimeLayout))
+	}
+	doc.Space()
+
+	doc.Field("Generated", generatedAt.UTC().Format(voucherTimeLayout))
 
-	return err
+	return doc.Bytes()
 }
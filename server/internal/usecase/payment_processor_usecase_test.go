@@ -1,14 +1,17 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"expense-management-system/internal/db"
 	"expense-management-system/internal/entity"
 	"expense-management-system/internal/mocks"
 	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
 	"expense-management-system/internal/usecase"
 	"testing"
+	"time"
 
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/redis/go-redis/v9"
@@ -22,7 +25,10 @@ type PpMockFunc func(
 	rc *mocks.RedisClient,
 	tx db.Transactioner,
 	er *mocks.ExpenseRepository,
+	lr *mocks.LedgerRepository,
 	ppr *mocks.PaymentPartnerRepository,
+	pvr *mocks.PaymentVoucherRepository,
+	fs *mocks.FileStorage,
 )
 
 type PaymentProcessorUsecaseSuite struct {
@@ -37,6 +43,16 @@ func (s *PaymentProcessorUsecaseSuite) SetupTest() {
 }
 
 func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
+	fileKey := "vouchers/1/1.pdf"
+	processedAt := time.Date(2025, 9, 15, 3, 0, 0, 0, time.UTC)
+	detail := &entity.ExpenseDetail{
+		Expense: entity.Expense{
+			ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Description: "Taxi", Status: entity.ExpenseStatusCompleted,
+			CreatedAt: processedAt.Add(-time.Hour), ProcessedAt: &processedAt,
+		},
+		User: entity.UserSimple{ID: 2, Name: "Budi", Email: "budi@example.com"},
+	}
+
 	tests := []struct {
 		name       string
 		request    *model.PaymentProcessorRequest
@@ -46,6 +62,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 		{
 			name: "error on find expense",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -56,9 +73,12 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
 					Return(nil, errors.New("something error"))
 			},
 			wantErrMsg: "failed to find expense by id (1) = something error",
@@ -66,6 +86,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 		{
 			name: "success with expense not found",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -76,9 +97,12 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
 					Return(nil, nil)
 			},
 			wantErrMsg: "",
@@ -86,6 +110,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 		{
 			name: "success with invalid expense status",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -96,16 +121,20 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusCompleted}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusRejected}, nil)
 			},
 			wantErrMsg: "",
 		},
 		{
 			name: "error on acquire lock",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -116,10 +145,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusApproved}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
 
 				boolCmd := redis.NewBoolCmd(context.Background())
 				boolCmd.SetErr(errors.New("something error"))
@@ -131,6 +163,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 		{
 			name: "success with lock already acquired",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -141,10 +174,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusApproved}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
 
 				boolCmd := redis.NewBoolCmd(context.Background())
 				boolCmd.SetVal(false)
@@ -156,6 +192,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 		{
 			name: "error on update expense",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -166,10 +203,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusApproved}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
 
 				boolCmd := redis.NewBoolCmd(context.Background())
 				boolCmd.SetVal(true)
@@ -177,7 +217,7 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 					Return(boolCmd)
 
 				db.ExpectBegin()
-				er.On("CompleteByIDTx", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+				er.On("CompleteByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).
 					Return(errors.New("something error"))
 				db.ExpectRollback()
 
@@ -186,9 +226,51 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 			},
 			wantErrMsg: "failed to update expense for id (1) = something error",
 		},
+		{
+			name: "error on post ledger entry",
+			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
+				ID:             1,
+				UserID:         1,
+				Amount:         17000,
+				IdempotencyKey: "EXP-000ABC123",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				rc *mocks.RedisClient,
+				tx db.Transactioner,
+				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
+				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
+			) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
+
+				boolCmd := redis.NewBoolCmd(context.Background())
+				boolCmd.SetVal(true)
+				rc.On("SetNX", mock.Anything, "expense-payment:lock:1", "lock", mock.Anything).
+					Return(boolCmd)
+
+				db.ExpectBegin()
+				er.On("CompleteByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.MatchedBy(func(e *entity.LedgerEntry) bool {
+					return e.Type == entity.LedgerEntryTypePayout && *e.Postings[0].UserID == 2 && e.Postings[0].Debit == 17000
+				})).
+					Return(false, errors.New("something error"))
+				db.ExpectRollback()
+
+				intCmd := redis.NewIntCmd(context.Background())
+				rc.On("Del", mock.Anything, "expense-payment:lock:1").Return(intCmd)
+			},
+			wantErrMsg: "failed to post payout ledger entry for expense id (1) = something error",
+		},
 		{
 			name: "error on payment partner",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -199,10 +281,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusApproved}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
 
 				boolCmd := redis.NewBoolCmd(context.Background())
 				boolCmd.SetVal(true)
@@ -210,8 +295,10 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 					Return(boolCmd)
 
 				db.ExpectBegin()
-				er.On("CompleteByIDTx", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+				er.On("CompleteByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).
 					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
 				ppr.On("Execute", mock.Anything, mock.Anything).
 					Return(nil, errors.New("something error"))
 				db.ExpectRollback()
@@ -221,9 +308,111 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 			},
 			wantErrMsg: "failed to call partner for expense id (1) = something error",
 		},
+		{
+			name: "success with paid expense and stored voucher",
+			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
+				ID:             1,
+				UserID:         1,
+				Amount:         17000,
+				IdempotencyKey: "EXP-000ABC123",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				rc *mocks.RedisClient,
+				tx db.Transactioner,
+				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
+				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
+			) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, Status: entity.ExpenseStatusCompleted}, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.PaymentVoucher{ID: 3, OrgID: 1, ExpenseID: 1, FileKey: &fileKey}, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "error on issue voucher of paid expense",
+			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
+				ID:             1,
+				UserID:         1,
+				Amount:         17000,
+				IdempotencyKey: "EXP-000ABC123",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				rc *mocks.RedisClient,
+				tx db.Transactioner,
+				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
+				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
+			) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, Status: entity.ExpenseStatusCompleted}, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.PaymentVoucher{ID: 3, OrgID: 1, ExpenseID: 1, PartnerTransactionID: "sample-id"}, nil)
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(detail, nil)
+				fs.On("Put", mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to issue payment voucher of expense id (1) = failed to store file (vouchers/1/1.pdf) = something error",
+		},
+		{
+			name: "error on create voucher",
+			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
+				ID:             1,
+				UserID:         1,
+				Amount:         17000,
+				IdempotencyKey: "EXP-000ABC123",
+			},
+			mockFunc: func(
+				db pgxmock.PgxPoolIface,
+				rc *mocks.RedisClient,
+				tx db.Transactioner,
+				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
+				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
+			) {
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
+
+				boolCmd := redis.NewBoolCmd(context.Background())
+				boolCmd.SetVal(true)
+				rc.On("SetNX", mock.Anything, "expense-payment:lock:1", "lock", mock.Anything).
+					Return(boolCmd)
+
+				db.ExpectBegin()
+				er.On("CompleteByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).
+					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
+				ppr.On("Execute", mock.Anything, mock.Anything).
+					Return(&model.PaymentPartnerResponse{
+						PartnerID: "sample-id",
+					}, nil)
+				pvr.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("something error"))
+				db.ExpectRollback()
+
+				intCmd := redis.NewIntCmd(context.Background())
+				rc.On("Del", mock.Anything, "expense-payment:lock:1").Return(intCmd)
+			},
+			wantErrMsg: "failed to create payment voucher for expense id (1) = something error",
+		},
 		{
 			name: "success",
 			request: &model.PaymentProcessorRequest{
+				OrgID:          1,
 				ID:             1,
 				UserID:         1,
 				Amount:         17000,
@@ -234,10 +423,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 				rc *mocks.RedisClient,
 				tx db.Transactioner,
 				er *mocks.ExpenseRepository,
+				lr *mocks.LedgerRepository,
 				ppr *mocks.PaymentPartnerRepository,
+				pvr *mocks.PaymentVoucherRepository,
+				fs *mocks.FileStorage,
 			) {
-				er.On("FindByID", mock.Anything, uint64(1)).
-					Return(&entity.Expense{ID: 1, Status: entity.ExpenseStatusApproved}, nil)
+				er.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.Expense{ID: 1, OrgID: 1, UserID: 2, Amount: 17000, Status: entity.ExpenseStatusApproved}, nil)
 
 				boolCmd := redis.NewBoolCmd(context.Background())
 				boolCmd.SetVal(true)
@@ -245,14 +437,46 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 					Return(boolCmd)
 
 				db.ExpectBegin()
-				er.On("CompleteByIDTx", mock.Anything, mock.Anything, uint64(1), mock.Anything).
+				er.On("CompleteByIDTx", mock.Anything, mock.Anything, mock.Anything, uint64(1), mock.Anything).
 					Return(nil)
+				lr.On("PostTx", mock.Anything, mock.Anything, mock.Anything).
+					Return(true, nil)
 				ppr.On("Execute", mock.Anything, mock.Anything).
 					Return(&model.PaymentPartnerResponse{
 						PartnerID: "sample-id",
 					}, nil)
+				pvr.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(v *entity.PaymentVoucher) bool {
+					return v.OrgID == 1 && v.ExpenseID == 1 && v.PartnerTransactionID == "sample-id"
+				})).
+					Return(nil)
 				db.ExpectCommit()
 
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.PaymentVoucher{ID: 3, OrgID: 1, ExpenseID: 1, PartnerTransactionID: "sample-id"}, nil)
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(1)).
+					Return(detail, nil)
+				fs.On("Put", mock.Anything, mock.MatchedBy(func(f *storage.File) bool {
+					if f.Key != "vouchers/1/1.pdf" || f.ContentType != "application/pdf" || !bytes.HasPrefix(f.Content, []byte("%PDF-1.4")) {
+						return false
+					}
+					for _, want := range []string{
+						"(Payment Voucher) Tj",
+						"(EXP-000000001) Tj",
+						"(sample-id) Tj",
+						"(Rp 17.000) Tj",
+						"(Budi <budi@example.com>) Tj",
+						"(Automatically approved) Tj",
+					} {
+						if !bytes.Contains(f.Content, []byte(want)) {
+							return false
+						}
+					}
+					return true
+				})).
+					Return(nil)
+				pvr.On("SetFile", mock.Anything, uint64(3), "vouchers/1/1.pdf", mock.Anything).
+					Return(nil)
+
 				intCmd := redis.NewIntCmd(context.Background())
 				rc.On("Del", mock.Anything, "expense-payment:lock:1").Return(intCmd)
 			},
@@ -268,10 +492,13 @@ func (s *PaymentProcessorUsecaseSuite) TestPaymentProcessorUsecase_Execute() {
 
 			rc := mocks.NewRedisClient(s.T())
 			er := mocks.NewExpenseRepository(s.T())
+			lr := mocks.NewLedgerRepository(s.T())
 			ppr := mocks.NewPaymentPartnerRepository(s.T())
+			pvr := mocks.NewPaymentVoucherRepository(s.T())
+			fs := mocks.NewFileStorage(s.T())
 
-			usecase := usecase.NewPaymentProcessorUsecase(s.log, rc, tx, er, ppr, 1)
-			tt.mockFunc(dbMock, rc, tx, er, ppr)
+			usecase := usecase.NewPaymentProcessorUsecase(s.log, rc, tx, er, lr, ppr, pvr, fs, 1)
+			tt.mockFunc(dbMock, rc, tx, er, lr, ppr, pvr, fs)
 
 			err := usecase.Execute(s.ctx, tt.request)
 
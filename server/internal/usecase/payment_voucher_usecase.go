@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type paymentVoucherUsecase struct {
+	log                      *zap.Logger
+	expenseRepository        ExpenseRepository
+	paymentVoucherRepository PaymentVoucherRepository
+	fileStorage              storage.FileStorage
+}
+
+func NewPaymentVoucherUsecase(log *zap.Logger, expenseRepository ExpenseRepository,
+	paymentVoucherRepository PaymentVoucherRepository, fileStorage storage.FileStorage) PaymentVoucherUsecase {
+	return &paymentVoucherUsecase{
+		log:                      log,
+		expenseRepository:        expenseRepository,
+		paymentVoucherRepository: paymentVoucherRepository,
+		fileStorage:              fileStorage,
+	}
+}
+
+// Download returns the stored voucher of a paid expense to whoever can see the expense. It never
+// renders one, only the payment consumer issues vouchers, so a voucher that failed to be stored
+// after the payout is not ready until the retried message stores it.
+func (c *paymentVoucherUsecase) Download(ctx context.Context, req *model.GetExpenseRequest) (*model.FileResponse, error) {
+	expense, err := c.expenseRepository.FindDetailByID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense by id (%d) = %w", req.ID, err)
+	}
+
+	if expense == nil {
+		return nil, model.ErrExpenseNotFound
+	}
+
+	if req.UserID != expense.UserID && !entity.UserRole(req.UserRole).AtLeast(entity.UserRoleManager) {
+		return nil, model.ErrForbidden
+	}
+
+	voucher, err := c.paymentVoucherRepository.FindByExpenseID(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment voucher of expense id (%d) = %w", req.ID, err)
+	}
+
+	if voucher == nil {
+		return nil, model.ErrPaymentVoucherNotFound
+	}
+
+	if voucher.FileKey == nil {
+		return nil, model.ErrPaymentVoucherNotReady
+	}
+
+	file, err := c.fileStorage.Get(ctx, *voucher.FileKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment voucher file (%s) = %w", *voucher.FileKey, err)
+	}
+
+	if file == nil {
+		return nil, model.ErrPaymentVoucherNotReady
+	}
+
+	return &model.FileResponse{
+		Name:        fmt.Sprintf("voucher-%s.pdf", expense.GetKey()),
+		ContentType: file.ContentType,
+		Content:     file.Content,
+	}, nil
+}
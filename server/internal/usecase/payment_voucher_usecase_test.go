@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"expense-management-system/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PaymentVoucherUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type PaymentVoucherMockFunc func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage)
+
+func (s *PaymentVoucherUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *PaymentVoucherUsecaseSuite) newUsecase(mockFunc PaymentVoucherMockFunc) usecase.PaymentVoucherUsecase {
+	er := mocks.NewExpenseRepository(s.T())
+	pvr := mocks.NewPaymentVoucherRepository(s.T())
+	fs := mocks.NewFileStorage(s.T())
+	mockFunc(er, pvr, fs)
+
+	return usecase.NewPaymentVoucherUsecase(s.log, er, pvr, fs)
+}
+
+func (s *PaymentVoucherUsecaseSuite) TestPaymentVoucherUsecase_Download() {
+	request := &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 2, UserRole: "employee"}
+	createdAt := time.Date(2025, 9, 14, 8, 0, 0, 0, time.UTC)
+	approvedAt := time.Date(2025, 9, 15, 9, 30, 0, 0, time.UTC)
+	processedAt := time.Date(2025, 9, 15, 9, 31, 0, 0, time.UTC)
+	notes := "Receipt checked"
+	detail := &entity.ExpenseDetail{
+		Expense: entity.Expense{
+			ID: 7, OrgID: 1, UserID: 2, Amount: 1500000, Description: "Flight to Surabaya",
+			Status: entity.ExpenseStatusCompleted, CreatedAt: createdAt, ProcessedAt: &processedAt,
+		},
+		User: entity.UserSimple{ID: 2, Name: "John Doe", Email: "john@mail.com"},
+		Approval: &entity.ApprovalDetail{
+			ID: 4, ApproverID: 3, ApproverName: "Jane Roe", ApproverEmail: "jane@mail.com",
+			Status: entity.ApprovalStatusApproved, Notes: &notes, CreatedAt: approvedAt,
+		},
+	}
+	fileKey := "vouchers/1/7.pdf"
+	stored := &entity.PaymentVoucher{ID: 3, OrgID: 1, ExpenseID: 7, PartnerTransactionID: "trx-123", FileKey: &fileKey}
+	unstored := &entity.PaymentVoucher{ID: 3, OrgID: 1, ExpenseID: 7, PartnerTransactionID: "trx-123"}
+
+	tests := []struct {
+		name         string
+		request      *model.GetExpenseRequest
+		mockFunc     PaymentVoucherMockFunc
+		wantContains []string
+		wantErrMsg   string
+	}{
+		{
+			name:    "error on find expense",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find expense by id (7) = something error",
+		},
+		{
+			name:    "error on expense not found",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(nil, nil)
+			},
+			wantErrMsg: "Expense not found",
+		},
+		{
+			name:    "error on expense of another employee",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 5, UserRole: "employee"},
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on voucher not found",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(7)).Return(nil, nil)
+			},
+			wantErrMsg: "Payment voucher not found, the expense is not paid yet",
+		},
+		{
+			name:    "error on get file",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(7)).Return(stored, nil)
+				fs.On("Get", mock.Anything, fileKey).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to get payment voucher file (vouchers/1/7.pdf) = something error",
+		},
+		{
+			name:    "error on voucher not stored yet",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(7)).Return(unstored, nil)
+			},
+			wantErrMsg: "Payment voucher is not ready yet, try again later",
+		},
+		{
+			name:    "error on missing file",
+			request: request,
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(7)).Return(stored, nil)
+				fs.On("Get", mock.Anything, fileKey).Return(nil, nil)
+			},
+			wantErrMsg: "Payment voucher is not ready yet, try again later",
+		},
+		{
+			name:    "success with stored voucher",
+			request: &model.GetExpenseRequest{ID: 7, OrgID: 1, UserID: 3, UserRole: "manager"},
+			mockFunc: func(er *mocks.ExpenseRepository, pvr *mocks.PaymentVoucherRepository, fs *mocks.FileStorage) {
+				er.On("FindDetailByID", mock.Anything, uint64(1), uint64(7)).Return(detail, nil)
+				pvr.On("FindByExpenseID", mock.Anything, uint64(1), uint64(7)).Return(stored, nil)
+				fs.On("Get", mock.Anything, fileKey).
+					Return(&storage.File{Key: fileKey, ContentType: "application/pdf", Content: []byte("%PDF-1.4 stored")}, nil)
+			},
+			wantContains: []string{"stored"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Download(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("voucher-EXP-000000007.pdf", res.Name)
+				s.Equal("application/pdf", res.ContentType)
+				s.True(strings.HasPrefix(string(res.Content), "%PDF-1.4"))
+				for _, want := range tt.wantContains {
+					s.Contains(string(res.Content), want)
+				}
+			}
+		})
+	}
+}
+
+func TestPaymentVoucherUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PaymentVoucherUsecaseSuite))
+}
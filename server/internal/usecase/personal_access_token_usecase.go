@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	tokenPrefixLength = 8 // api key prefix plus a few characters, kept to tell keys apart
+
+	// last_used_at is only refreshed once per interval so a busy script doesn't write on every request
+	lastUsedUpdateInterval = time.Minute
+)
+
+type personalAccessTokenUsecase struct {
+	log                           *zap.Logger
+	userRepository                UserRepository
+	personalAccessTokenRepository PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenUsecase(log *zap.Logger, userRepository UserRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository) PersonalAccessTokenUsecase {
+	return &personalAccessTokenUsecase{
+		log:                           log,
+		userRepository:                userRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+	}
+}
+
+func (c *personalAccessTokenUsecase) List(ctx context.Context,
+	req *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error) {
+	tokens, err := c.personalAccessTokenRepository.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access tokens for user id (%d) = %w", req.UserID, err)
+	}
+
+	res := make([]model.PersonalAccessTokenResponse, len(tokens))
+	for i, t := range tokens {
+		res[i] = *serializer.PersonalAccessTokenToResponse(&t)
+	}
+
+	return res, nil
+}
+
+func (c *personalAccessTokenUsecase) Create(ctx context.Context,
+	req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error) {
+	if slices.Contains(req.Scopes, auth.ScopeSCIM) && req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	secret, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token for user id (%d) = %w", req.UserID, err)
+	}
+
+	apiKey := auth.APIKeyPrefix + secret
+	token := &entity.PersonalAccessToken{
+		OrgID:       req.OrgID,
+		UserID:      req.UserID,
+		Name:        req.Name,
+		TokenHash:   auth.HashOpaqueToken(apiKey),
+		TokenPrefix: apiKey[:tokenPrefixLength],
+		Scopes:      req.Scopes,
+		ExpiresAt:   time.Now().AddDate(0, 0, req.ExpiresInDays),
+	}
+
+	err = c.personalAccessTokenRepository.Create(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token for user id (%d) = %w", req.UserID, err)
+	}
+
+	return &model.CreatePersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: *serializer.PersonalAccessTokenToResponse(token),
+		Token:                       apiKey,
+	}, nil
+}
+
+func (c *personalAccessTokenUsecase) Revoke(ctx context.Context, req *model.RevokePersonalAccessTokenRequest) error {
+	revoked, err := c.personalAccessTokenRepository.Revoke(ctx, req.ID, req.UserID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token for id (%d) = %w", req.ID, err)
+	}
+
+	if !revoked {
+		return model.ErrAccessTokenNotFound
+	}
+
+	return nil
+}
+
+// Authenticate maps a personal access token onto the claims of a login session,
+// the role is read from the user so a demotion applies to existing keys right away
+func (c *personalAccessTokenUsecase) Authenticate(ctx context.Context, apiKey string) (*auth.JWTClaims, error) {
+	token, err := c.personalAccessTokenRepository.FindByTokenHash(ctx, auth.HashOpaqueToken(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find access token = %w", err)
+	}
+
+	if token == nil {
+		return nil, errors.New("access token not found")
+	}
+
+	now := time.Now()
+	if !token.Active(now) {
+		return nil, fmt.Errorf("access token (%d) is revoked or expired", token.ID)
+	}
+
+	user, err := c.userRepository.FindByID(ctx, token.OrgID, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", token.UserID, err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user (%d) of access token (%d) not found", token.UserID, token.ID)
+	}
+
+	if !user.Active {
+		return nil, fmt.Errorf("user (%d) of access token (%d) is deactivated", token.UserID, token.ID)
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedUpdateInterval {
+		err = c.personalAccessTokenRepository.UpdateLastUsedAt(ctx, token.ID, now)
+		if err != nil {
+			c.log.Warn(
+				fmt.Sprintf("failed to update last used of access token (%d) = %s", token.ID, err.Error()),
+				zap.Strings("tags", []string{"access-token", "authenticate", "last-used"}),
+			)
+		}
+	}
+
+	return &auth.JWTClaims{
+		UserID:   fmt.Sprint(user.ID),
+		OrgID:    user.OrgID,
+		Role:     string(user.Role),
+		Locale:   user.Locale,
+		APIKeyID: token.ID,
+		Scopes:   token.Scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(token.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(token.CreatedAt),
+			ID:        fmt.Sprintf("pat-%d", token.ID),
+		},
+	}, nil
+}
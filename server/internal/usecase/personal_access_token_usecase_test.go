@@ -0,0 +1,319 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type PersonalAccessTokenUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_List() {
+	now := time.Date(2025, 9, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.PersonalAccessTokenRepository)
+		wantRes    []model.PersonalAccessTokenResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on list",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("ListByUserID", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to list access tokens for user id (1) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("ListByUserID", mock.Anything, uint64(1)).Return([]entity.PersonalAccessToken{
+					{
+						ID:          1,
+						UserID:      1,
+						Name:        "script",
+						TokenPrefix: "ems_AbCd",
+						Scopes:      []string{"expenses:read"},
+						ExpiresAt:   now,
+						CreatedAt:   now,
+					},
+				}, nil)
+			},
+			wantRes: []model.PersonalAccessTokenResponse{
+				{
+					ID:          1,
+					Name:        "script",
+					TokenPrefix: "ems_AbCd",
+					Scopes:      []string{"expenses:read"},
+					ExpiresAt:   "2025-09-17T10:00:00Z",
+					CreatedAt:   "2025-09-17T10:00:00Z",
+				},
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, mocks.NewUserRepository(s.T()), pr)
+			tt.mockFunc(pr)
+
+			res, err := usecase.List(s.ctx, &model.ListPersonalAccessTokenRequest{UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Create() {
+	request := &model.CreatePersonalAccessTokenRequest{
+		UserID:        1,
+		UserRole:      "employee",
+		Name:          "script",
+		Scopes:        []string{"expenses:read"},
+		ExpiresInDays: 30,
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.CreatePersonalAccessTokenRequest
+		mockFunc   func(r *mocks.PersonalAccessTokenRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error scim scope for non admin",
+			request: &model.CreatePersonalAccessTokenRequest{
+				UserID:        1,
+				UserRole:      "manager",
+				Name:          "hr sync",
+				Scopes:        []string{"scim"},
+				ExpiresInDays: 30,
+			},
+			mockFunc:   func(r *mocks.PersonalAccessTokenRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name: "error on create",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create access token for user id (1) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("Create", mock.Anything, mock.MatchedBy(func(t *entity.PersonalAccessToken) bool {
+					return t.UserID == 1 && t.Name == "script" && len(t.TokenHash) == 64 &&
+						strings.HasPrefix(t.TokenPrefix, "ems_") &&
+						t.ExpiresAt.After(time.Now().AddDate(0, 0, 29))
+				})).Run(func(args mock.Arguments) {
+					t := args.Get(1).(*entity.PersonalAccessToken)
+					t.ID = 1
+				}).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, mocks.NewUserRepository(s.T()), pr)
+			tt.mockFunc(pr)
+
+			req := request
+			if tt.request != nil {
+				req = tt.request
+			}
+
+			res, err := usecase.Create(s.ctx, req)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(uint64(1), res.ID)
+				s.True(strings.HasPrefix(res.Token, res.TokenPrefix))
+				s.True(auth.IsAPIKey(res.Token))
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Revoke() {
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mocks.PersonalAccessTokenRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on revoke",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("Revoke", mock.Anything, uint64(2), uint64(1), mock.Anything).
+					Return(false, errors.New("something error"))
+			},
+			wantErrMsg: "failed to revoke access token for id (2) = something error",
+		},
+		{
+			name: "error not found",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("Revoke", mock.Anything, uint64(2), uint64(1), mock.Anything).Return(false, nil)
+			},
+			wantErrMsg: "Access token not found",
+		},
+		{
+			name: "success",
+			mockFunc: func(r *mocks.PersonalAccessTokenRepository) {
+				r.On("Revoke", mock.Anything, uint64(2), uint64(1), mock.Anything).Return(true, nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, mocks.NewUserRepository(s.T()), pr)
+			tt.mockFunc(pr)
+
+			err := usecase.Revoke(s.ctx, &model.RevokePersonalAccessTokenRequest{ID: 2, UserID: 1})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *PersonalAccessTokenUsecaseSuite) TestPersonalAccessTokenUsecase_Authenticate() {
+	apiKey := "ems_dummy-key"
+	tokenHash := auth.HashOpaqueToken(apiKey)
+	now := time.Now()
+	recentlyUsed := now.Add(-10 * time.Second)
+	revokedAt := now.Add(-time.Hour)
+
+	activeToken := func(lastUsedAt *time.Time) *entity.PersonalAccessToken {
+		return &entity.PersonalAccessToken{
+			ID:         3,
+			UserID:     1,
+			Scopes:     []string{"expenses:read"},
+			ExpiresAt:  now.Add(time.Hour),
+			LastUsedAt: lastUsedAt,
+			CreatedAt:  now.Add(-time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mockFunc   func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository)
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find access token = something error",
+		},
+		{
+			name: "error not found",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(nil, nil)
+			},
+			wantErrMsg: "access token not found",
+		},
+		{
+			name: "error revoked",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				token := activeToken(nil)
+				token.RevokedAt = &revokedAt
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(token, nil)
+			},
+			wantErrMsg: "access token (3) is revoked or expired",
+		},
+		{
+			name: "error user not found",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(activeToken(nil), nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "user (1) of access token (3) not found",
+		},
+		{
+			name: "success skips recent last used update",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(activeToken(&recentlyUsed), nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Role: "employee", Active: true}, nil)
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success even when last used update fails",
+			mockFunc: func(ur *mocks.UserRepository, pr *mocks.PersonalAccessTokenRepository) {
+				pr.On("FindByTokenHash", mock.Anything, tokenHash).Return(activeToken(nil), nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Role: "employee", Active: true}, nil)
+				pr.On("UpdateLastUsedAt", mock.Anything, uint64(3), mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ur := mocks.NewUserRepository(s.T())
+			pr := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewPersonalAccessTokenUsecase(s.log, ur, pr)
+			tt.mockFunc(ur, pr)
+
+			claims, err := usecase.Authenticate(s.ctx, apiKey)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(claims)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("1", claims.UserID)
+				s.Equal("employee", claims.Role)
+				s.Equal(uint64(3), claims.APIKeyID)
+				s.Equal("pat-3", claims.ID)
+				s.True(claims.HasScope(auth.ScopeExpensesRead))
+				s.False(claims.HasScope(auth.ScopeExpensesWrite))
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenUsecaseSuite))
+}
@@ -11,20 +11,124 @@ import (
 //go:generate mockery --name=UserRepository --structname UserRepository --outpkg=mocks --output=./../mocks
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
-	FindByID(ctx context.Context, id uint64) (*entity.User, error)
+	CreateTx(ctx context.Context, exec db.Executor, user *entity.User) error
+	FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	CountByEmail(ctx context.Context, email string) (int, error)
+	List(ctx context.Context, req *model.ListUserRequest) ([]entity.User, int, error)
+	Update(ctx context.Context, user *entity.User) error
+	UpdatePassword(ctx context.Context, orgID uint64, id uint64, passwordHash string) error
+	UpdateLocale(ctx context.Context, orgID uint64, id uint64, locale string) error
+	ListByOrgIDAndIDs(ctx context.Context, orgID uint64, ids []uint64) ([]entity.User, error)
+}
+
+//go:generate mockery --name=UserTOTPRepository --structname UserTOTPRepository --outpkg=mocks --output=./../mocks
+type UserTOTPRepository interface {
+	Upsert(ctx context.Context, totp *entity.UserTOTP) error
+	FindByUserID(ctx context.Context, userID uint64) (*entity.UserTOTP, error)
+	Enable(ctx context.Context, userID uint64, enabledAt time.Time) error
+	UseTimeStep(ctx context.Context, userID uint64, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID uint64, hashedCode string) (bool, error)
+	Delete(ctx context.Context, userID uint64) error
+}
+
+//go:generate mockery --name=UserIdentityRepository --structname UserIdentityRepository --outpkg=mocks --output=./../mocks
+type UserIdentityRepository interface {
+	CreateTx(ctx context.Context, exec db.Executor, identity *entity.UserIdentity) error
+	FindByIssuerAndSubject(ctx context.Context, issuer string, subject string) (*entity.UserIdentity, error)
+}
+
+//go:generate mockery --name=PersonalAccessTokenRepository --structname PersonalAccessTokenRepository --outpkg=mocks --output=./../mocks
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *entity.PersonalAccessToken) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PersonalAccessToken, error)
+	ListByUserID(ctx context.Context, userID uint64) ([]entity.PersonalAccessToken, error)
+	Revoke(ctx context.Context, id uint64, userID uint64, revokedAt time.Time) (bool, error)
+	RevokeByUserID(ctx context.Context, userID uint64, revokedAt time.Time) error
+	UpdateLastUsedAt(ctx context.Context, id uint64, lastUsedAt time.Time) error
+}
+
+//go:generate mockery --name=DepartmentRepository --structname DepartmentRepository --outpkg=mocks --output=./../mocks
+type DepartmentRepository interface {
+	Create(ctx context.Context, department *entity.Department) error
+	FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Department, error)
+	List(ctx context.Context, orgID uint64) ([]entity.Department, error)
+	CountByNameOrCostCenter(ctx context.Context, orgID uint64, id uint64, name string, costCenter string) (int, error)
+	Update(ctx context.Context, department *entity.Department) error
+}
+
+//go:generate mockery --name=DepartmentBudgetRepository --structname DepartmentBudgetRepository --outpkg=mocks --output=./../mocks
+type DepartmentBudgetRepository interface {
+	Create(ctx context.Context, budget *entity.DepartmentBudget) (bool, error)
+	ListByDepartmentID(ctx context.Context, orgID uint64, departmentID uint64) ([]entity.DepartmentBudget, error)
+	FindByDate(ctx context.Context, orgID uint64, departmentID uint64, date time.Time) (*entity.DepartmentBudget, error)
+	FindByDateWithLock(ctx context.Context, exec db.Executor, orgID uint64, departmentID uint64, date time.Time) (*entity.DepartmentBudget, error)
 }
 
 //go:generate mockery --name=ExpenseRepository --structname ExpenseRepository --outpkg=mocks --output=./../mocks
 type ExpenseRepository interface {
 	Create(ctx context.Context, expense *entity.Expense) error
+	CreateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error
 	List(ctx context.Context, req *model.ListExpenseRequest) ([]entity.ExpenseWithUser, int, error)
-	FindDetailByID(ctx context.Context, id uint64) (*entity.ExpenseDetail, error)
-	FindByID(ctx context.Context, id uint64) (*entity.Expense, error)
-	FindByIDWithLock(ctx context.Context, exec db.Executor, id uint64) (*entity.Expense, error)
-	UpdateStatusByIDTx(ctx context.Context, exec db.Executor, id uint64, status entity.ExpenseStatus) error
-	CompleteByIDTx(ctx context.Context, exec db.Executor, id uint64, processedAt time.Time) error
+	Count(ctx context.Context, req *model.ListExpenseRequest) (int, error)
+	Stream(ctx context.Context, req *model.ListExpenseRequest, fn func(detail *entity.ExpenseDetail) error) error
+	Search(ctx context.Context, req *model.SearchExpenseRequest) ([]entity.ExpenseSearchResult, int, error)
+	FindDetailByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseDetail, error)
+	FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.Expense, error)
+	FindByIDWithLock(ctx context.Context, exec db.Executor, orgID uint64, id uint64) (*entity.Expense, error)
+	UpdateStatusByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64, status entity.ExpenseStatus) error
+	UpdateTx(ctx context.Context, exec db.Executor, expense *entity.Expense) error
+	CompleteByIDTx(ctx context.Context, exec db.Executor, orgID uint64, id uint64, processedAt time.Time) error
+	ListUnexportedWithLock(ctx context.Context, exec db.Executor, orgID uint64, from time.Time, to time.Time) ([]entity.Expense, error)
+	SetJournalBatchIDTx(ctx context.Context, exec db.Executor, orgID uint64, ids []uint64, batchID uint64) error
+	SumByUserStatus(ctx context.Context, orgID uint64, userID uint64) ([]entity.ExpenseStatusTotal, error)
+	SumByUserMonth(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.ExpenseStatusTotal, error)
+	ListByUserCreated(ctx context.Context, orgID uint64, userID uint64, from time.Time, to time.Time) ([]entity.Expense, error)
+}
+
+//go:generate mockery --name=ExpenseExportRepository --structname ExpenseExportRepository --outpkg=mocks --output=./../mocks
+type ExpenseExportRepository interface {
+	Create(ctx context.Context, export *entity.ExpenseExport) error
+	FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.ExpenseExport, error)
+	UpdateStatus(ctx context.Context, id uint64, status entity.ExpenseExportStatus) error
+	Complete(ctx context.Context, id uint64, fileKey string, rowCount int, completedAt time.Time) error
+}
+
+//go:generate mockery --name=GLAccountMappingRepository --structname GLAccountMappingRepository --outpkg=mocks --output=./../mocks
+type GLAccountMappingRepository interface {
+	List(ctx context.Context, orgID uint64) ([]entity.GLAccountMapping, error)
+	Upsert(ctx context.Context, mapping *entity.GLAccountMapping) error
+	Delete(ctx context.Context, orgID uint64, id uint64) (bool, error)
+}
+
+//go:generate mockery --name=JournalBatchRepository --structname JournalBatchRepository --outpkg=mocks --output=./../mocks
+type JournalBatchRepository interface {
+	CreateTx(ctx context.Context, exec db.Executor, batch *entity.JournalBatch) error
+	CreateLinesTx(ctx context.Context, exec db.Executor, batchID uint64, lines []entity.JournalLine) error
+	List(ctx context.Context, orgID uint64) ([]entity.JournalBatch, error)
+	FindByID(ctx context.Context, orgID uint64, id uint64) (*entity.JournalBatch, error)
+	ListLines(ctx context.Context, batchID uint64) ([]entity.JournalLine, error)
+}
+
+//go:generate mockery --name=LedgerRepository --structname LedgerRepository --outpkg=mocks --output=./../mocks
+type LedgerRepository interface {
+	PostTx(ctx context.Context, exec db.Executor, entry *entity.LedgerEntry) (bool, error)
+	FindEntryByID(ctx context.Context, orgID uint64, id uint64) (*entity.LedgerEntry, error)
+	ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]entity.LedgerEntry, int, error)
+	ListBalances(ctx context.Context, orgID uint64, userID *uint64) ([]entity.LedgerBalance, error)
+}
+
+//go:generate mockery --name=AnalyticsRepository --structname AnalyticsRepository --outpkg=mocks --output=./../mocks
+type AnalyticsRepository interface {
+	GroupStats(ctx context.Context, req *model.AnalyticsRequest) ([]entity.ExpenseGroupStat, error)
+	Turnaround(ctx context.Context, req *model.AnalyticsRequest) (*entity.ExpenseTurnaround, error)
+	OldestPending(ctx context.Context, req *model.AnalyticsRequest, limit int) ([]entity.ExpenseWithUser, error)
+}
+
+//go:generate mockery --name=OrganizationRepository --structname OrganizationRepository --outpkg=mocks --output=./../mocks
+type OrganizationRepository interface {
+	FindByID(ctx context.Context, id uint64) (*entity.Organization, error)
+	Update(ctx context.Context, organization *entity.Organization) error
 }
 
 //go:generate mockery --name=ApprovalRepository --structname ApprovalRepository --outpkg=mocks --output=./../mocks
@@ -32,6 +136,29 @@ type ApprovalRepository interface {
 	CreateTx(ctx context.Context, exec db.Executor, approval *entity.Approval) error
 }
 
+//go:generate mockery --name=PaymentVoucherRepository --structname PaymentVoucherRepository --outpkg=mocks --output=./../mocks
+type PaymentVoucherRepository interface {
+	CreateTx(ctx context.Context, exec db.Executor, voucher *entity.PaymentVoucher) error
+	FindByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) (*entity.PaymentVoucher, error)
+	SetFile(ctx context.Context, id uint64, fileKey string, generatedAt time.Time) error
+}
+
+//go:generate mockery --name=ExpenseCommentRepository --structname ExpenseCommentRepository --outpkg=mocks --output=./../mocks
+type ExpenseCommentRepository interface {
+	CreateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment) error
+	UpdateTx(ctx context.Context, exec db.Executor, comment *entity.ExpenseComment, window time.Duration) (bool, error)
+	Delete(ctx context.Context, orgID uint64, id uint64, userID uint64, window time.Duration) (bool, error)
+	ListByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) ([]entity.ExpenseComment, error)
+	FindByID(ctx context.Context, orgID uint64, expenseID uint64, id uint64) (*entity.ExpenseComment, error)
+}
+
+//go:generate mockery --name=ExpenseReviewRoundRepository --structname ExpenseReviewRoundRepository --outpkg=mocks --output=./../mocks
+type ExpenseReviewRoundRepository interface {
+	CreateTx(ctx context.Context, exec db.Executor, round *entity.ExpenseReviewRound) error
+	ResubmitTx(ctx context.Context, exec db.Executor, orgID uint64, expenseID uint64, resubmittedAt time.Time) error
+	ListByExpenseID(ctx context.Context, orgID uint64, expenseID uint64) ([]entity.ExpenseReviewRound, error)
+}
+
 //go:generate mockery --name=PaymentPartnerRepository --structname PaymentPartnerRepository --outpkg=mocks --output=./../mocks
 type PaymentPartnerRepository interface {
 	Execute(ctx context.Context, req *model.PaymentPartnerRequest) (*model.PaymentPartnerResponse, error)
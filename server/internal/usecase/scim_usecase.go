@@ -0,0 +1,897 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// only the simple `attribute eq value` form is supported, which is what hr systems use to look up a user
+var scimFilterRegex = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+(?:"((?:[^"\\]|\\.)*)"|(true|false))\s*$`)
+
+// role groups are listed in this order
+var scimRoles = []entity.UserRole{entity.UserRoleEmployee, entity.UserRoleManager, entity.UserRoleAdmin}
+
+type scimFilter struct {
+	Attribute string // lower cased
+	Value     string
+}
+
+// scimGroup is either a role group or a department group
+type scimGroup struct {
+	role       entity.UserRole
+	department *entity.Department
+}
+
+type scimUsecase struct {
+	log                           *zap.Logger
+	redisClient                   storage.RedisClient
+	userRepository                UserRepository
+	personalAccessTokenRepository PersonalAccessTokenRepository
+	departmentRepository          DepartmentRepository
+	sessionTTL                    time.Duration
+}
+
+func NewSCIMUsecase(log *zap.Logger, redisClient storage.RedisClient, userRepository UserRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository, departmentRepository DepartmentRepository,
+	sessionTTL time.Duration) SCIMUsecase {
+	return &scimUsecase{
+		log:                           log,
+		redisClient:                   redisClient,
+		userRepository:                userRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		departmentRepository:          departmentRepository,
+		sessionTTL:                    sessionTTL,
+	}
+}
+
+func (c *scimUsecase) ListUsers(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	listReq := &model.ListUserRequest{OrgID: req.OrgID}
+	if req.Filter != "" {
+		filter, err := parseSCIMFilter(req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		switch filter.Attribute {
+		case "username", "emails", "emails.value":
+			listReq.Email = &filter.Value
+		case "externalid":
+			listReq.ExternalID = &filter.Value
+		case "active":
+			active, err := strconv.ParseBool(filter.Value)
+			if err != nil {
+				return nil, model.ErrInvalidSCIMFilter
+			}
+			listReq.Active = &active
+		default:
+			return nil, model.ErrInvalidSCIMFilter
+		}
+	}
+
+	startIndex, count := scimPage(req)
+	listReq.Limit = count
+	listReq.Offset = startIndex - 1
+	listReq.CountOnly = count == 0
+
+	users, total, err := c.userRepository.List(ctx, listReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users = %w", err)
+	}
+
+	resources := make([]model.SCIMUserResponse, len(users))
+	for i := range users {
+		resources[i] = *serializer.UserToSCIMResponse(&users[i])
+	}
+
+	return newSCIMListResponse(resources, total, startIndex, len(resources)), nil
+}
+
+func (c *scimUsecase) GetUser(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMUserResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	user, err := c.findUser(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.UserToSCIMResponse(user), nil
+}
+
+func (c *scimUsecase) CreateUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	err := c.checkUnique(ctx, req.OrgID, 0, req.UserName, req.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := randomPasswordHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password for email (%s) = %w", req.UserName, err)
+	}
+
+	user := &entity.User{
+		OrgID:        req.OrgID,
+		Email:        req.UserName,
+		Name:         scimUserName(req),
+		PasswordHash: passwordHash,
+		Role:         entity.UserRoleEmployee,
+		Active:       req.Active == nil || *req.Active,
+		ExternalID:   req.ExternalID,
+	}
+
+	err = c.userRepository.Create(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user for email (%s) = %w", req.UserName, err)
+	}
+
+	return serializer.UserToSCIMResponse(user), nil
+}
+
+// ReplaceUser overwrites the provisioned attributes, the role is only managed through groups
+func (c *scimUsecase) ReplaceUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	user, err := c.findUser(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.checkUnique(ctx, req.OrgID, user.ID, req.UserName, req.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *user
+	user.Email = req.UserName
+	user.Name = scimUserName(req)
+	user.ExternalID = req.ExternalID
+	if req.Active != nil {
+		user.Active = *req.Active
+	}
+
+	err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.UserToSCIMResponse(user), nil
+}
+
+func (c *scimUsecase) PatchUser(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMUserResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	user, err := c.findUser(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *user
+	patch := &scimUserPatch{user: user}
+	for _, op := range req.Operations {
+		err = patch.apply(op)
+		if err != nil {
+			return nil, err
+		}
+	}
+	patch.finish()
+
+	if user.Email != before.Email || !equalStringPtr(user.ExternalID, before.ExternalID) {
+		err = c.checkUnique(ctx, req.OrgID, user.ID, user.Email, user.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.UserToSCIMResponse(user), nil
+}
+
+// DeleteUser only deactivates, expenses and approvals keep referencing the user
+func (c *scimUsecase) DeleteUser(ctx context.Context, req *model.SCIMResourceRequest) error {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return model.ErrForbidden
+	}
+
+	user, err := c.findUser(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	// a deactivated user is saved again, so a retry finishes what a failed deactivation left out
+	before := *user
+	user.Active = false
+
+	return saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+}
+
+func (c *scimUsecase) ListGroups(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	var filter *scimFilter
+	if req.Filter != "" {
+		var err error
+		filter, err = parseSCIMFilter(req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Attribute != "displayname" && filter.Attribute != "id" {
+			return nil, model.ErrInvalidSCIMFilter
+		}
+	}
+
+	departments, err := c.departmentRepository.List(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list departments = %w", err)
+	}
+
+	// role groups come first, then the departments by name
+	groups := make([]scimGroup, 0, len(scimRoles)+len(departments))
+	for _, role := range scimRoles {
+		groups = append(groups, scimGroup{role: role})
+	}
+	for i := range departments {
+		groups = append(groups, scimGroup{department: &departments[i]})
+	}
+
+	if filter != nil {
+		var matched []scimGroup
+		for _, g := range groups {
+			id, displayName := g.identity()
+			if (filter.Attribute == "id" && id == filter.Value) ||
+				(filter.Attribute == "displayname" && displayName == filter.Value) {
+				matched = append(matched, g)
+			}
+		}
+		groups = matched
+	}
+
+	startIndex, count := scimPage(req)
+	total := len(groups)
+	groups = groups[min(startIndex-1, total):min(startIndex-1+count, total)]
+
+	resources := make([]model.SCIMGroupResponse, len(groups))
+	for i, g := range groups {
+		group, err := c.findGroup(ctx, req.OrgID, g)
+		if err != nil {
+			return nil, err
+		}
+		resources[i] = *group
+	}
+
+	return newSCIMListResponse(resources, total, startIndex, len(resources)), nil
+}
+
+func (c *scimUsecase) GetGroup(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMGroupResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	group, err := c.resolveGroup(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findGroup(ctx, req.OrgID, group)
+}
+
+// PatchGroup assigns the role of the group to added members, removed members fall back to employee,
+// for a department group members join or leave the department
+func (c *scimUsecase) PatchGroup(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMGroupResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	group, err := c.resolveGroup(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		members, err := parseSCIMMembers(op)
+		if err != nil {
+			return nil, err
+		}
+
+		if group.department != nil {
+			err = c.patchDepartmentMembers(ctx, req.OrgID, op.Op, members, group.department.ID)
+		} else {
+			err = c.patchRoleMembers(ctx, req.OrgID, op.Op, members, group.role)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.findGroup(ctx, req.OrgID, group)
+}
+
+func (c *scimUsecase) patchRoleMembers(ctx context.Context, orgID uint64, op string, members []string, role entity.UserRole) error {
+	switch strings.ToLower(op) {
+	case "add":
+		return c.setRole(ctx, orgID, members, role)
+	case "remove":
+		return c.removeFromRole(ctx, orgID, members, role)
+	case "replace":
+		return c.replaceRoleMembers(ctx, orgID, members, role)
+	default:
+		return model.ErrInvalidSCIMPatch
+	}
+}
+
+func (c *scimUsecase) patchDepartmentMembers(ctx context.Context, orgID uint64, op string, members []string, departmentID uint64) error {
+	switch strings.ToLower(op) {
+	case "add":
+		return c.setDepartment(ctx, orgID, members, &departmentID)
+	case "remove":
+		return c.removeFromDepartment(ctx, orgID, members, departmentID)
+	case "replace":
+		return c.replaceDepartmentMembers(ctx, orgID, members, departmentID)
+	default:
+		return model.ErrInvalidSCIMPatch
+	}
+}
+
+// resolveGroup looks up the role or the department behind a group id
+func (c *scimUsecase) resolveGroup(ctx context.Context, orgID uint64, id string) (scimGroup, error) {
+	role, err := serializer.SCIMRoleFromGroupID(id)
+	if err == nil {
+		return scimGroup{role: role}, nil
+	}
+
+	departmentID, err := serializer.SCIMDepartmentFromGroupID(id)
+	if err != nil {
+		return scimGroup{}, model.ErrGroupNotFound
+	}
+
+	department, err := c.departmentRepository.FindByID(ctx, orgID, departmentID)
+	if err != nil {
+		return scimGroup{}, fmt.Errorf("failed to find department by id (%d) = %w", departmentID, err)
+	}
+
+	if department == nil {
+		return scimGroup{}, model.ErrGroupNotFound
+	}
+
+	return scimGroup{department: department}, nil
+}
+
+func (c *scimUsecase) findUser(ctx context.Context, orgID uint64, id string) (*entity.User, error) {
+	userID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	user, err := c.userRepository.FindByID(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", userID, err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (c *scimUsecase) findGroup(ctx context.Context, orgID uint64, group scimGroup) (*model.SCIMGroupResponse, error) {
+	if group.department != nil {
+		members, _, err := c.userRepository.List(ctx, &model.ListUserRequest{
+			OrgID:        orgID,
+			DepartmentID: &group.department.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users by department id (%d) = %w", group.department.ID, err)
+		}
+
+		return serializer.DepartmentToSCIMGroupResponse(group.department, members), nil
+	}
+
+	roleStr := string(group.role)
+	members, _, err := c.userRepository.List(ctx, &model.ListUserRequest{
+		OrgID: orgID,
+		Role:  &roleStr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by role (%s) = %w", group.role, err)
+	}
+
+	return serializer.RoleToSCIMGroupResponse(group.role, members), nil
+}
+
+// checkUnique makes sure no other user than id owns the email or external id
+func (c *scimUsecase) checkUnique(ctx context.Context, orgID uint64, id uint64, email string, externalID *string) error {
+	user, err := c.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user by email (%s) = %w", email, err)
+	}
+
+	if user != nil && user.ID != id {
+		return model.ErrUserAlreadyExist
+	}
+
+	if externalID == nil {
+		return nil
+	}
+
+	users, _, err := c.userRepository.List(ctx, &model.ListUserRequest{
+		OrgID:      orgID,
+		ExternalID: externalID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users by external id (%s) = %w", *externalID, err)
+	}
+
+	for _, u := range users {
+		if u.ID != id {
+			return model.ErrUserAlreadyExist
+		}
+	}
+
+	return nil
+}
+
+func (c *scimUsecase) setRole(ctx context.Context, orgID uint64, ids []string, role entity.UserRole) error {
+	for _, id := range ids {
+		user, err := c.findUser(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if user.Role == role {
+			continue
+		}
+
+		before := *user
+		user.Role = role
+
+		err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *scimUsecase) removeFromRole(ctx context.Context, orgID uint64, ids []string, role entity.UserRole) error {
+	for _, id := range ids {
+		user, err := c.findUser(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if user.Role != role || role == entity.UserRoleEmployee {
+			continue
+		}
+
+		before := *user
+		user.Role = entity.UserRoleEmployee
+
+		err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *scimUsecase) replaceRoleMembers(ctx context.Context, orgID uint64, ids []string, role entity.UserRole) error {
+	roleStr := string(role)
+	current, _, err := c.userRepository.List(ctx, &model.ListUserRequest{
+		OrgID: orgID,
+		Role:  &roleStr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users by role (%s) = %w", role, err)
+	}
+
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	var removed []string
+	for _, u := range current {
+		if !keep[fmt.Sprint(u.ID)] {
+			removed = append(removed, fmt.Sprint(u.ID))
+		}
+	}
+
+	err = c.removeFromRole(ctx, orgID, removed, role)
+	if err != nil {
+		return err
+	}
+
+	return c.setRole(ctx, orgID, ids, role)
+}
+
+// setDepartment moves the users into the department, nil takes them out of any department
+func (c *scimUsecase) setDepartment(ctx context.Context, orgID uint64, ids []string, departmentID *uint64) error {
+	for _, id := range ids {
+		user, err := c.findUser(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if equalUint64Ptr(user.DepartmentID, departmentID) {
+			continue
+		}
+
+		before := *user
+		user.DepartmentID = departmentID
+
+		err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *scimUsecase) removeFromDepartment(ctx context.Context, orgID uint64, ids []string, departmentID uint64) error {
+	var members []string
+	for _, id := range ids {
+		user, err := c.findUser(ctx, orgID, id)
+		if err != nil {
+			return err
+		}
+
+		if user.DepartmentID != nil && *user.DepartmentID == departmentID {
+			members = append(members, id)
+		}
+	}
+
+	return c.setDepartment(ctx, orgID, members, nil)
+}
+
+func (c *scimUsecase) replaceDepartmentMembers(ctx context.Context, orgID uint64, ids []string, departmentID uint64) error {
+	current, _, err := c.userRepository.List(ctx, &model.ListUserRequest{
+		OrgID:        orgID,
+		DepartmentID: &departmentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users by department id (%d) = %w", departmentID, err)
+	}
+
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+
+	var removed []string
+	for _, u := range current {
+		if !keep[fmt.Sprint(u.ID)] {
+			removed = append(removed, fmt.Sprint(u.ID))
+		}
+	}
+
+	err = c.setDepartment(ctx, orgID, removed, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.setDepartment(ctx, orgID, ids, &departmentID)
+}
+
+// identity is the group id and display name as seen by scim clients
+func (g scimGroup) identity() (string, string) {
+	if g.department != nil {
+		return serializer.SCIMDepartmentGroupID(g.department.ID), g.department.Name
+	}
+
+	return serializer.SCIMRoleGroupID(g.role), string(g.role)
+}
+
+// scimUserPatch applies patch operations one by one, name parts are
+// collected and only merged into the name once every operation is applied
+type scimUserPatch struct {
+	user        *entity.User
+	displayName *string
+	givenName   *string
+	familyName  *string
+}
+
+func (p *scimUserPatch) apply(op model.SCIMPatchOperation) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		if op.Path != "" {
+			return p.set(op.Path, op.Value)
+		}
+
+		var values map[string]json.RawMessage
+		err := json.Unmarshal(op.Value, &values)
+		if err != nil {
+			return model.ErrInvalidSCIMPatch
+		}
+
+		for attr, value := range values {
+			err = p.set(attr, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "remove":
+		if strings.EqualFold(op.Path, "externalId") {
+			p.user.ExternalID = nil
+			return nil
+		}
+
+		return model.ErrInvalidSCIMPatch
+	default:
+		return model.ErrInvalidSCIMPatch
+	}
+}
+
+func (p *scimUserPatch) set(attr string, value json.RawMessage) error {
+	var err error
+
+	switch strings.ToLower(attr) {
+	case "active":
+		p.user.Active, err = parseSCIMBool(value)
+	case "username", `emails[type eq "work"].value`:
+		p.user.Email, err = parseSCIMEmail(value)
+	case "emails":
+		var emails []model.SCIMEmail
+		err = json.Unmarshal(value, &emails)
+		if err == nil && len(emails) > 0 {
+			email := emails[0]
+			for _, e := range emails {
+				if e.Primary {
+					email = e
+				}
+			}
+			p.user.Email, err = checkSCIMEmail(email.Value)
+		}
+	case "externalid":
+		var externalID string
+		err = json.Unmarshal(value, &externalID)
+		p.user.ExternalID = &externalID
+		if externalID == "" {
+			p.user.ExternalID = nil
+		}
+	case "displayname", "name.formatted":
+		p.displayName, err = parseSCIMString(value)
+	case "name.givenname":
+		p.givenName, err = parseSCIMString(value)
+	case "name.familyname":
+		p.familyName, err = parseSCIMString(value)
+	case "name":
+		var name model.SCIMName
+		err = json.Unmarshal(value, &name)
+		if name.Formatted != "" {
+			p.displayName = &name.Formatted
+		}
+		if name.GivenName != "" {
+			p.givenName = &name.GivenName
+		}
+		if name.FamilyName != "" {
+			p.familyName = &name.FamilyName
+		}
+	default:
+		return model.ErrInvalidSCIMPatch
+	}
+
+	if err != nil {
+		return model.ErrInvalidSCIMPatch
+	}
+
+	return nil
+}
+
+func (p *scimUserPatch) finish() {
+	if p.displayName != nil {
+		p.user.Name = *p.displayName
+		return
+	}
+
+	if p.givenName == nil && p.familyName == nil {
+		return
+	}
+
+	givenName, familyName, _ := strings.Cut(p.user.Name, " ")
+	if p.givenName != nil {
+		givenName = *p.givenName
+	}
+	if p.familyName != nil {
+		familyName = *p.familyName
+	}
+
+	p.user.Name = strings.TrimSpace(givenName + " " + familyName)
+}
+
+func parseSCIMFilter(filter string) (*scimFilter, error) {
+	m := scimFilterRegex.FindStringSubmatch(filter)
+	if m == nil {
+		return nil, model.ErrInvalidSCIMFilter
+	}
+
+	value := m[3]
+	if m[3] == "" {
+		unquoted, err := strconv.Unquote(`"` + m[2] + `"`)
+		if err != nil {
+			return nil, model.ErrInvalidSCIMFilter
+		}
+		value = unquoted
+	}
+
+	return &scimFilter{
+		Attribute: strings.ToLower(m[1]),
+		Value:     value,
+	}, nil
+}
+
+// parseSCIMMembers reads the member ids of a group operation, either from the value
+// or from a `members[value eq "id"]` path as sent on removal
+func parseSCIMMembers(op model.SCIMPatchOperation) ([]string, error) {
+	path := strings.TrimSpace(op.Path)
+	if strings.HasPrefix(strings.ToLower(path), "members[") && strings.HasSuffix(path, "]") {
+		filter, err := parseSCIMFilter(path[len("members[") : len(path)-1])
+		if err != nil || filter.Attribute != "value" {
+			return nil, model.ErrInvalidSCIMPatch
+		}
+
+		return []string{filter.Value}, nil
+	}
+
+	value := op.Value
+	if path == "" {
+		var values map[string]json.RawMessage
+		err := json.Unmarshal(op.Value, &values)
+		if err != nil {
+			return nil, model.ErrInvalidSCIMPatch
+		}
+		value = values["members"]
+	} else if !strings.EqualFold(path, "members") {
+		return nil, model.ErrInvalidSCIMPatch
+	}
+
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	var members []model.SCIMMember
+	err := json.Unmarshal(value, &members)
+	if err != nil {
+		return nil, model.ErrInvalidSCIMPatch
+	}
+
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.Value
+	}
+
+	return ids, nil
+}
+
+// parseSCIMBool also takes "True" and "False" strings, some providers send booleans that way
+func parseSCIMBool(value json.RawMessage) (bool, error) {
+	var b bool
+	err := json.Unmarshal(value, &b)
+	if err == nil {
+		return b, nil
+	}
+
+	var s string
+	err = json.Unmarshal(value, &s)
+	if err != nil {
+		return false, err
+	}
+
+	return strconv.ParseBool(s)
+}
+
+func parseSCIMString(value json.RawMessage) (*string, error) {
+	var s string
+	err := json.Unmarshal(value, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func parseSCIMEmail(value json.RawMessage) (string, error) {
+	var s string
+	err := json.Unmarshal(value, &s)
+	if err != nil {
+		return "", err
+	}
+
+	return checkSCIMEmail(s)
+}
+
+// checkSCIMEmail only accepts a bare address since the user name is the login email
+func checkSCIMEmail(s string) (string, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return "", fmt.Errorf("invalid email = %s", s)
+	}
+
+	return s, nil
+}
+
+// scimUserName picks the most complete name the provider sent, falling back to the email
+func scimUserName(req *model.SCIMUserRequest) string {
+	switch {
+	case req.DisplayName != "":
+		return req.DisplayName
+	case req.Name != nil && req.Name.Formatted != "":
+		return req.Name.Formatted
+	case req.Name != nil && (req.Name.GivenName != "" || req.Name.FamilyName != ""):
+		return strings.TrimSpace(req.Name.GivenName + " " + req.Name.FamilyName)
+	default:
+		return req.UserName
+	}
+}
+
+// scimPage normalizes the 1-based start index and clamps the page size. A count of 0 asks for
+// the total only and a negative one counts as 0 (RFC 7644 3.4.2.4), the controller sets the
+// default when the count is absent
+func scimPage(req *model.ListSCIMRequest) (int, int) {
+	startIndex := max(req.StartIndex, 1)
+	count := max(req.Count, 0)
+
+	return startIndex, min(count, model.SCIMMaxCount)
+}
+
+func newSCIMListResponse(resources interface{}, total, startIndex, itemsPerPage int) *model.SCIMListResponse {
+	return &model.SCIMListResponse{
+		Schemas:      []string{model.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalUint64Ptr(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,657 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SCIMUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type SCIMMockFunc func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+	pr *mocks.PersonalAccessTokenRepository)
+
+func (s *SCIMUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *SCIMUsecaseSuite) statusCmd(err error) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *SCIMUsecaseSuite) intCmd(err error) *redis.IntCmd {
+	cmd := redis.NewIntCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *SCIMUsecaseSuite) newUsecase(mockFunc SCIMMockFunc) usecase.SCIMUsecase {
+	rc := mocks.NewRedisClient(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	dr := mocks.NewDepartmentRepository(s.T())
+	pr := mocks.NewPersonalAccessTokenRepository(s.T())
+	mockFunc(rc, ur, dr, pr)
+
+	return usecase.NewSCIMUsecase(s.log, rc, ur, pr, dr, 24*time.Hour)
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_ListUsers() {
+	externalID := "emp-001"
+
+	tests := []struct {
+		name       string
+		request    *model.ListSCIMRequest
+		mockFunc   SCIMMockFunc
+		wantTotal  int
+		wantItems  int
+		wantErrMsg string
+	}{
+		{
+			name:    "error forbidden",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error unsupported filter",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "admin", Filter: `userName sw "john"`},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Invalid or unsupported SCIM filter",
+		},
+		{
+			name:    "error unknown attribute",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "admin", Filter: `title eq "cto"`},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Invalid or unsupported SCIM filter",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list users = something error",
+		},
+		{
+			name:    "success filter external id",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "admin", Filter: `externalId eq "emp-001"`, StartIndex: 3, Count: 500},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("List", mock.Anything, &model.ListUserRequest{
+					OrgID:      1,
+					ExternalID: &externalID,
+					Limit:      model.SCIMMaxCount,
+					Offset:     2,
+				}).Return([]entity.User{{ID: 1, Email: "john@mail.com", ExternalID: &externalID}}, 3, nil)
+			},
+			wantTotal: 3,
+			wantItems: 1,
+		},
+		{
+			name:    "success count only",
+			request: &model.ListSCIMRequest{OrgID: 1, UserRole: "admin", StartIndex: 1, Count: 0},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("List", mock.Anything, &model.ListUserRequest{
+					OrgID:     1,
+					Offset:    0,
+					CountOnly: true,
+				}).Return(nil, 7, nil)
+			},
+			wantTotal: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).ListUsers(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantTotal, res.TotalResults)
+				s.Equal(tt.request.StartIndex, res.StartIndex)
+				s.Equal(tt.wantItems, res.ItemsPerPage)
+			}
+		})
+	}
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_CreateUser() {
+	externalID := "emp-001"
+	request := &model.SCIMUserRequest{
+		OrgID:      1,
+		UserRole:   "admin",
+		ExternalID: &externalID,
+		UserName:   "john@mail.com",
+		Name:       &model.SCIMName{GivenName: "John", FamilyName: "Doe"},
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.SCIMUserRequest
+		mockFunc   SCIMMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error forbidden",
+			request: &model.SCIMUserRequest{OrgID: 1, UserRole: "employee", UserName: "john@mail.com"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error email already exist",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(&entity.User{ID: 2}, nil)
+			},
+			wantErrMsg: "User with the same email or external id already exist",
+		},
+		{
+			name:    "error external id already exist",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(nil, nil)
+				ur.On("List", mock.Anything, &model.ListUserRequest{OrgID: 1, ExternalID: &externalID}).
+					Return([]entity.User{{ID: 2}}, 1, nil)
+			},
+			wantErrMsg: "User with the same email or external id already exist",
+		},
+		{
+			name:    "error on create",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(nil, nil)
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+				ur.On("Create", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to create user for email (john@mail.com) = something error",
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(nil, nil)
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+				ur.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.OrgID == 1 && u.Email == "john@mail.com" && u.Name == "John Doe" && u.Active &&
+						u.Role == entity.UserRoleEmployee && *u.ExternalID == "emp-001" && u.PasswordHash != ""
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*entity.User).ID = 1
+				}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).CreateUser(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("1", res.ID)
+				s.Equal("John Doe", res.DisplayName)
+			}
+		})
+	}
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_PatchUser() {
+	user := func() *entity.User {
+		return &entity.User{ID: 1, Email: "john@mail.com", Name: "John Doe", Role: "employee", Active: true}
+	}
+
+	tests := []struct {
+		name       string
+		operations []model.SCIMPatchOperation
+		mockFunc   SCIMMockFunc
+		wantName   string
+		wantErrMsg string
+	}{
+		{
+			name:       "error unsupported operation",
+			operations: []model.SCIMPatchOperation{{Op: "move", Path: "active"}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(user(), nil)
+			},
+			wantErrMsg: "Invalid or unsupported SCIM patch operation",
+		},
+		{
+			name:       "error invalid user name",
+			operations: []model.SCIMPatchOperation{{Op: "replace", Path: "userName", Value: json.RawMessage(`"john"`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(user(), nil)
+			},
+			wantErrMsg: "Invalid or unsupported SCIM patch operation",
+		},
+		{
+			name:       "error user not found",
+			operations: []model.SCIMPatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`false`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:       "error on set deactivated user",
+			operations: []model.SCIMPatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(user(), nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(errors.New("something error")))
+			},
+			wantErrMsg: "failed to set deactivated user for id (1) = something error",
+		},
+		{
+			name:       "success deactivate",
+			operations: []model.SCIMPatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(user(), nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return !u.Active
+				})).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+			},
+			wantName: "John Doe",
+		},
+		{
+			name: "success pathless replace",
+			operations: []model.SCIMPatchOperation{
+				{Op: "replace", Value: json.RawMessage(`{"name.familyName":"Smith","externalId":"emp-001"}`)},
+			},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(user(), nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(user(), nil)
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Active && *u.ExternalID == "emp-001"
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+			},
+			wantName: "John Smith",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).PatchUser(s.ctx, &model.SCIMPatchRequest{
+				OrgID:      1,
+				ID:         "1",
+				UserRole:   "admin",
+				Operations: tt.operations,
+			})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantName, res.DisplayName)
+			}
+		})
+	}
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_DeleteUser() {
+	tests := []struct {
+		name       string
+		mockFunc   SCIMMockFunc
+		wantErrMsg string
+	}{
+		{
+			// a retry after the marker failed to be set finds the user inactive already
+			name: "success retry on already deactivated",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, Active: false}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "error on update",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update user for id (1) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).Return(&entity.User{ID: 1, Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.newUsecase(tt.mockFunc).DeleteUser(s.ctx, &model.SCIMResourceRequest{OrgID: 1, ID: "1", UserRole: "admin"})
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_ReplaceUser() {
+	rc := mocks.NewRedisClient(s.T())
+	ur := mocks.NewUserRepository(s.T())
+	usecase := usecase.NewSCIMUsecase(s.log, rc, ur, mocks.NewPersonalAccessTokenRepository(s.T()), nil, 24*time.Hour)
+
+	active := true
+	ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+		Return(&entity.User{ID: 1, Email: "john@mail.com", Name: "John Doe", Role: "employee", Active: false}, nil)
+	ur.On("FindByEmail", mock.Anything, "john.doe@mail.com").Return(nil, nil)
+	ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Email == "john.doe@mail.com" && u.Name == "Johnny" && u.Active && u.ExternalID == nil
+	})).Return(nil)
+	rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+
+	res, err := usecase.ReplaceUser(s.ctx, &model.SCIMUserRequest{
+		OrgID:       1,
+		ID:          "1",
+		UserRole:    "admin",
+		UserName:    "john.doe@mail.com",
+		DisplayName: "Johnny",
+		Active:      &active,
+	})
+
+	s.Nil(err)
+	s.True(res.Active)
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_ListGroups() {
+	manager := "manager"
+	departmentID := uint64(3)
+	departments := []entity.Department{{ID: 3, Name: "Finance", CostCenter: "CC-100"}}
+
+	tests := []struct {
+		name       string
+		filter     string
+		mockFunc   SCIMMockFunc
+		wantIDs    []string
+		wantErrMsg string
+	}{
+		{
+			name:   "error unsupported filter",
+			filter: `members eq "1"`,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Invalid or unsupported SCIM filter",
+		},
+		{
+			name:   "error on list departments",
+			filter: `displayName eq "manager"`,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list departments = something error",
+		},
+		{
+			name:   "success unknown group",
+			filter: `displayName eq "finance"`,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(departments, nil)
+			},
+			wantIDs: []string{},
+		},
+		{
+			name:   "success filter department id",
+			filter: `id eq "department-3"`,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(departments, nil)
+				ur.On("List", mock.Anything, &model.ListUserRequest{OrgID: 1, DepartmentID: &departmentID}).
+					Return([]entity.User{{ID: 2, Name: "Jane Doe", Role: "manager", DepartmentID: &departmentID}}, 1, nil)
+			},
+			wantIDs: []string{"department-3"},
+		},
+		{
+			name:   "success filter display name",
+			filter: `displayName eq "manager"`,
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(departments, nil)
+				ur.On("List", mock.Anything, &model.ListUserRequest{OrgID: 1, Role: &manager}).
+					Return([]entity.User{{ID: 2, Name: "Jane Doe", Role: "manager"}}, 1, nil)
+			},
+			wantIDs: []string{"role-manager"},
+		},
+		{
+			name: "success",
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("List", mock.Anything, uint64(1)).Return(departments, nil)
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+			},
+			wantIDs: []string{"role-employee", "role-manager", "role-admin", "department-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).ListGroups(s.ctx, &model.ListSCIMRequest{
+				OrgID:    1,
+				UserRole: "admin",
+				Filter:   tt.filter,
+				Count:    model.SCIMDefaultCount,
+			})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				ids := []string{}
+				for _, g := range res.Resources.([]model.SCIMGroupResponse) {
+					ids = append(ids, g.ID)
+				}
+				s.Equal(tt.wantIDs, ids)
+				s.Equal(len(tt.wantIDs), res.TotalResults)
+			}
+		})
+	}
+}
+
+func (s *SCIMUsecaseSuite) TestSCIMUsecase_PatchGroup() {
+	departmentID := uint64(3)
+	otherDepartmentID := uint64(4)
+
+	tests := []struct {
+		name       string
+		groupID    string
+		operations []model.SCIMPatchOperation
+		mockFunc   SCIMMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:       "error group not found",
+			groupID:    "finance",
+			operations: []model.SCIMPatchOperation{{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"1"}]`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Group not found",
+		},
+		{
+			name:       "error invalid path",
+			groupID:    "role-manager",
+			operations: []model.SCIMPatchOperation{{Op: "replace", Path: "displayName", Value: json.RawMessage(`"boss"`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Invalid or unsupported SCIM patch operation",
+		},
+		{
+			name:       "success add member",
+			groupID:    "role-manager",
+			operations: []model.SCIMPatchOperation{{Op: "Add", Path: "members", Value: json.RawMessage(`[{"value":"1"}]`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.User{ID: 1, Role: "employee", Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Role == entity.UserRoleManager
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+				ur.On("List", mock.Anything, mock.Anything).
+					Return([]entity.User{{ID: 1, Role: "manager"}}, 1, nil)
+			},
+		},
+		{
+			name:       "success remove member by filter",
+			groupID:    "role-manager",
+			operations: []model.SCIMPatchOperation{{Op: "remove", Path: `members[value eq "1"]`}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.User{ID: 1, Role: "manager", Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Role == entity.UserRoleEmployee
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:1", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(1), mock.Anything).Return(nil)
+				ur.On("List", mock.Anything, mock.Anything).Return(nil, 0, nil)
+			},
+		},
+		{
+			name:       "error department group not found",
+			groupID:    "department-9",
+			operations: []model.SCIMPatchOperation{{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"1"}]`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(9)).Return(nil, nil)
+			},
+			wantErrMsg: "Group not found",
+		},
+		{
+			name:       "success add department member",
+			groupID:    "department-3",
+			operations: []model.SCIMPatchOperation{{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"1"}]`)}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).
+					Return(&entity.Department{ID: 3, Name: "Finance", CostCenter: "CC-100"}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.User{ID: 1, Role: "employee", Active: true, DepartmentID: &otherDepartmentID}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return *u.DepartmentID == departmentID && u.Role == entity.UserRoleEmployee
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+				ur.On("List", mock.Anything, &model.ListUserRequest{OrgID: 1, DepartmentID: &departmentID}).
+					Return([]entity.User{{ID: 1, Role: "employee", DepartmentID: &departmentID}}, 1, nil)
+			},
+		},
+		{
+			name:       "success remove department member",
+			groupID:    "department-3",
+			operations: []model.SCIMPatchOperation{{Op: "remove", Path: `members[value eq "1"]`}},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				dr.On("FindByID", mock.Anything, uint64(1), uint64(3)).
+					Return(&entity.Department{ID: 3, Name: "Finance", CostCenter: "CC-100"}, nil)
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(1)).
+					Return(&entity.User{ID: 1, Role: "employee", Active: true, DepartmentID: &departmentID}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.DepartmentID == nil
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:1").Return(s.intCmd(nil))
+				ur.On("List", mock.Anything, &model.ListUserRequest{OrgID: 1, DepartmentID: &departmentID}).Return(nil, 0, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).PatchGroup(s.ctx, &model.SCIMPatchRequest{
+				OrgID:      1,
+				ID:         tt.groupID,
+				UserRole:   "admin",
+				Operations: tt.operations,
+			})
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.groupID, res.ID)
+			}
+		})
+	}
+}
+
+func TestSCIMUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SCIMUsecaseSuite))
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/storage"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// SSOStateTTL is how long a login started with Authorize can be finished, the state cookie lives as long
+const SSOStateTTL = 10 * time.Minute
+
+type SSOConfig struct {
+	OrgDomains map[string]uint64 // organization of the users of an email domain, other domains can't sign in
+}
+
+// ssoState is kept server side between Authorize and Callback, the browser only carries the state key
+type ssoState struct {
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+type ssoUsecase struct {
+	log                    *zap.Logger
+	redisClient            storage.RedisClient
+	tx                     db.Transactioner
+	jwtToken               auth.JWTToken
+	oidcProvider           auth.OIDCProvider
+	userRepository         UserRepository
+	userIdentityRepository UserIdentityRepository
+	userTOTPRepository     UserTOTPRepository
+	cfg                    SSOConfig
+}
+
+func NewSSOUsecase(log *zap.Logger, redisClient storage.RedisClient, tx db.Transactioner, jwtToken auth.JWTToken,
+	oidcProvider auth.OIDCProvider, userRepository UserRepository, userIdentityRepository UserIdentityRepository,
+	userTOTPRepository UserTOTPRepository, cfg SSOConfig) SSOUsecase {
+	return &ssoUsecase{
+		log:                    log,
+		redisClient:            redisClient,
+		tx:                     tx,
+		jwtToken:               jwtToken,
+		oidcProvider:           oidcProvider,
+		userRepository:         userRepository,
+		userIdentityRepository: userIdentityRepository,
+		userTOTPRepository:     userTOTPRepository,
+		cfg:                    cfg,
+	}
+}
+
+func (c *ssoUsecase) Authorize(ctx context.Context) (*model.SSOAuthorizeResponse, error) {
+	state, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sso state = %w", err)
+	}
+
+	nonce, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sso nonce = %w", err)
+	}
+
+	codeVerifier, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sso code verifier = %w", err)
+	}
+
+	authorizationURL, err := c.oidcProvider.AuthCodeURL(ctx, state, nonce, auth.PKCEChallenge(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sso authorization url = %w", err)
+	}
+
+	val, err := json.Marshal(&ssoState{
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sso state = %w", err)
+	}
+
+	stateKey := fmt.Sprintf("%s:%s", auth.PrefixSSOStateKey, state)
+	err = c.redisClient.SetEx(ctx, stateKey, string(val), SSOStateTTL).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set sso state = %w", err)
+	}
+
+	return &model.SSOAuthorizeResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+	}, nil
+}
+
+// Callback finishes the authorization code flow, the state is single-use
+// so a replayed callback has to start over from Authorize. The state must also match the
+// cookie set by Authorize, so a callback of someone else's login can't sign this browser in
+func (c *ssoUsecase) Callback(ctx context.Context, req *model.SSOCallbackRequest) (*model.LoginResponse, error) {
+	if subtle.ConstantTimeCompare([]byte(req.State), []byte(req.StateCookie)) != 1 {
+		return nil, model.ErrInvalidSSOState
+	}
+
+	stateKey := fmt.Sprintf("%s:%s", auth.PrefixSSOStateKey, req.State)
+	val, err := c.redisClient.GetDel(ctx, stateKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, model.ErrInvalidSSOState
+		}
+		return nil, fmt.Errorf("failed to get sso state = %w", err)
+	}
+
+	var state ssoState
+	err = json.Unmarshal([]byte(val), &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sso state = %w", err)
+	}
+
+	identity, err := c.oidcProvider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		if errors.Is(err, auth.ErrOIDCInvalidGrant) || errors.Is(err, auth.ErrOIDCInvalidIDToken) {
+			c.log.Warn(
+				fmt.Sprintf("failed to exchange sso code = %s", err.Error()),
+				zap.Strings("tags", []string{"auth", "sso", "exchange"}),
+			)
+			return nil, model.ErrSSOLoginFailed
+		}
+		return nil, fmt.Errorf("failed to exchange sso code = %w", err)
+	}
+
+	user, err := c.findOrProvisionUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueLogin(ctx, c.redisClient, c.jwtToken, c.userTOTPRepository, user)
+}
+
+// findOrProvisionUser resolves the local user of an identity. An unknown identity needs an email
+// the provider verified and whose domain maps to an organization, it is linked to the user with
+// the same email in that organization, otherwise a new employee of the organization is created
+func (c *ssoUsecase) findOrProvisionUser(ctx context.Context, identity *auth.OIDCIdentity) (*entity.User, error) {
+	userIdentity, err := c.userIdentityRepository.FindByIssuerAndSubject(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find identity by subject (%s) = %w", identity.Subject, err)
+	}
+
+	if userIdentity != nil {
+		user, err := c.userRepository.FindByID(ctx, userIdentity.OrgID, userIdentity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user by id (%d) = %w", userIdentity.UserID, err)
+		}
+
+		if user == nil {
+			return nil, fmt.Errorf("user of identity (%d) not found", userIdentity.ID)
+		}
+
+		return user, nil
+	}
+
+	// only an email the provider verified may be linked or provisioned
+	if identity.Email == "" || !identity.EmailVerified {
+		c.log.Warn(
+			fmt.Sprintf("sso identity (%s) has no verified email", identity.Subject),
+			zap.Strings("tags", []string{"auth", "sso", "provision"}),
+		)
+		return nil, model.ErrSSOLoginFailed
+	}
+
+	orgID, ok := c.cfg.OrgDomains[emailDomain(identity.Email)]
+	if !ok {
+		c.log.Warn(
+			fmt.Sprintf("sso identity (%s) has email (%s) of a domain without organization", identity.Subject, identity.Email),
+			zap.Strings("tags", []string{"auth", "sso", "provision"}),
+		)
+		return nil, model.ErrSSOLoginFailed
+	}
+
+	user, err := c.userRepository.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by email (%s) = %w", identity.Email, err)
+	}
+
+	if user != nil && user.OrgID != orgID {
+		c.log.Warn(
+			fmt.Sprintf("sso identity (%s) maps to organization (%d) but user (%d) is in another", identity.Subject, orgID, user.ID),
+			zap.Strings("tags", []string{"auth", "sso", "provision"}),
+		)
+		return nil, model.ErrSSOLoginFailed
+	}
+
+	if user == nil {
+		user, err = newSSOUser(identity, orgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.tx.Do(ctx, func(exec db.Executor) error {
+		if user.ID == 0 {
+			err := c.userRepository.CreateTx(ctx, exec, user)
+			if err != nil {
+				return fmt.Errorf("failed to create user for email (%s) = %w", user.Email, err)
+			}
+		}
+
+		err := c.userIdentityRepository.CreateTx(ctx, exec, &entity.UserIdentity{
+			OrgID:   user.OrgID,
+			UserID:  user.ID,
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create identity for user id (%d) = %w", user.ID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// newSSOUser builds a just-in-time provisioned employee of the organization
+func newSSOUser(identity *auth.OIDCIdentity, orgID uint64) (*entity.User, error) {
+	passwordHash, err := randomPasswordHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password for email (%s) = %w", identity.Email, err)
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	return &entity.User{
+		OrgID:        orgID,
+		Email:        identity.Email,
+		Name:         name,
+		PasswordHash: passwordHash,
+		Role:         entity.UserRoleEmployee,
+		Active:       true,
+	}, nil
+}
+
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+
+	return strings.ToLower(email[i+1:])
+}
@@ -0,0 +1,393 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/db"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type SSOUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+	cfg usecase.SSOConfig
+}
+
+type SSOMockFunc func(
+	dbMock pgxmock.PgxPoolIface,
+	rc *mocks.RedisClient,
+	j *mocks.JWTToken,
+	op *mocks.OIDCProvider,
+	ur *mocks.UserRepository,
+	uir *mocks.UserIdentityRepository,
+	utr *mocks.UserTOTPRepository,
+)
+
+func (s *SSOUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+	s.cfg = usecase.SSOConfig{
+		OrgDomains: map[string]uint64{"mail.com": 1, "other.com": 2},
+	}
+}
+
+func (s *SSOUsecaseSuite) TestSSOUsecase_Authorize() {
+	tests := []struct {
+		name       string
+		mockFunc   SSOMockFunc
+		wantErrMsg string
+	}{
+		{
+			name: "error on auth code url",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				op.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("", errors.New("something error"))
+			},
+			wantErrMsg: "failed to build sso authorization url = something error",
+		},
+		{
+			name: "error on set state",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				op.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("https://idp.example.com/authorize", nil)
+				cmd := redis.NewStatusCmd(s.ctx)
+				cmd.SetErr(errors.New("something error"))
+				rc.On("SetEx", mock.Anything, mock.Anything, mock.Anything, 10*time.Minute).Return(cmd)
+			},
+			wantErrMsg: "failed to set sso state = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				var state, nonce, challenge string
+				op.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						state, nonce, challenge = args.String(1), args.String(2), args.String(3)
+					}).
+					Return("https://idp.example.com/authorize", nil)
+				rc.On("SetEx", mock.Anything, mock.Anything, mock.Anything, 10*time.Minute).
+					Run(func(args mock.Arguments) {
+						// the stored verifier must be the one the challenge was derived from
+						var st struct {
+							CodeVerifier string `json:"code_verifier"`
+							Nonce        string `json:"nonce"`
+						}
+						s.Nil(json.Unmarshal([]byte(args.String(2)), &st))
+						s.Equal("sso-state:"+state, args.String(1))
+						s.Equal(nonce, st.Nonce)
+						s.Equal(challenge, auth.PKCEChallenge(st.CodeVerifier))
+					}).
+					Return(redis.NewStatusCmd(s.ctx))
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dbMock, _ := pgxmock.NewPool()
+			defer dbMock.Close()
+
+			rc := mocks.NewRedisClient(s.T())
+			j := mocks.NewJWTToken(s.T())
+			op := mocks.NewOIDCProvider(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			uir := mocks.NewUserIdentityRepository(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewSSOUsecase(s.log, rc, db.NewTransactioner(dbMock), j, op, ur, uir, utr, s.cfg)
+			tt.mockFunc(dbMock, rc, j, op, ur, uir, utr)
+
+			res, err := usecase.Authorize(s.ctx)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("https://idp.example.com/authorize", res.AuthorizationURL)
+				s.NotEmpty(res.State)
+			}
+		})
+	}
+}
+
+func (s *SSOUsecaseSuite) TestSSOUsecase_Callback() {
+	getDel := func(val string, err error) *redis.StringCmd {
+		cmd := redis.NewStringCmd(s.ctx)
+		cmd.SetVal(val)
+		cmd.SetErr(err)
+		return cmd
+	}
+	stateKey := "sso-state:state-1"
+	stateVal := `{"code_verifier":"verifier-1","nonce":"nonce-1"}`
+	identity := &auth.OIDCIdentity{
+		Issuer:        "https://idp.example.com",
+		Subject:       "sub-1",
+		Email:         "john@mail.com",
+		EmailVerified: true,
+		Name:          "John Doe",
+	}
+	user := &entity.User{ID: 1, OrgID: 1, Email: "john@mail.com", Name: "John Doe", Role: entity.UserRoleEmployee, Active: true}
+	userIdentity := &entity.UserIdentity{ID: 3, OrgID: 1, UserID: 1, Issuer: "https://idp.example.com", Subject: "sub-1"}
+
+	tests := []struct {
+		name       string
+		request    *model.SSOCallbackRequest // the state and its cookie match when nil
+		mockFunc   SSOMockFunc
+		wantRes    *model.LoginResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error state cookie mismatch",
+			request: &model.SSOCallbackRequest{Code: "code-1", State: "state-1", StateCookie: "state-2"},
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+			},
+			wantErrMsg: "Invalid or expired single sign-on state",
+		},
+		{
+			name:    "error without state cookie",
+			request: &model.SSOCallbackRequest{Code: "code-1", State: "state-1"},
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+			},
+			wantErrMsg: "Invalid or expired single sign-on state",
+		},
+		{
+			name: "error state not found",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel("", redis.Nil))
+			},
+			wantErrMsg: "Invalid or expired single sign-on state",
+		},
+		{
+			name: "error on get state",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel("", errors.New("something error")))
+			},
+			wantErrMsg: "failed to get sso state = something error",
+		},
+		{
+			name: "error invalid grant",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").
+					Return(nil, fmt.Errorf("%w with status code = 400", auth.ErrOIDCInvalidGrant))
+			},
+			wantErrMsg: "Single sign-on login failed",
+		},
+		{
+			name: "error on exchange",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to exchange sso code = something error",
+		},
+		{
+			name: "success linked identity",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(identity, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").
+					Return(userIdentity, nil)
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(user, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				j.On("Create", "1", uint64(1), "employee", "").Return("access-token", nil)
+			},
+			wantRes: &model.LoginResponse{AccessToken: "access-token"},
+		},
+		{
+			name: "error unverified email",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(&auth.OIDCIdentity{
+					Issuer:  "https://idp.example.com",
+					Subject: "sub-1",
+					Email:   "john@mail.com",
+				}, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+			},
+			wantErrMsg: "Single sign-on login failed",
+		},
+		{
+			name: "error email domain without organization",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(&auth.OIDCIdentity{
+					Issuer:        "https://idp.example.com",
+					Subject:       "sub-1",
+					Email:         "john@unknown.com",
+					EmailVerified: true,
+				}, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+			},
+			wantErrMsg: "Single sign-on login failed",
+		},
+		{
+			name: "error existing user of another organization",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(&auth.OIDCIdentity{
+					Issuer:        "https://idp.example.com",
+					Subject:       "sub-1",
+					Email:         "john@other.com",
+					EmailVerified: true,
+				}, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "john@other.com").
+					Return(&entity.User{ID: 1, OrgID: 1, Email: "john@other.com", Active: true}, nil)
+			},
+			wantErrMsg: "Single sign-on login failed",
+		},
+		{
+			name: "success link existing user",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(identity, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(user, nil)
+				dbMock.ExpectBegin()
+				uir.On("CreateTx", mock.Anything, mock.Anything, &entity.UserIdentity{
+					OrgID:   1,
+					UserID:  1,
+					Issuer:  "https://idp.example.com",
+					Subject: "sub-1",
+				}).Return(nil)
+				dbMock.ExpectCommit()
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				j.On("Create", "1", uint64(1), "employee", "").Return("access-token", nil)
+			},
+			wantRes: &model.LoginResponse{AccessToken: "access-token"},
+		},
+		{
+			name: "error on create user",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(identity, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(nil, nil)
+				dbMock.ExpectBegin()
+				ur.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("something error"))
+				dbMock.ExpectRollback()
+			},
+			wantErrMsg: "failed to create user for email (john@mail.com) = something error",
+		},
+		{
+			name: "success provision new employee of mapped organization",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(&auth.OIDCIdentity{
+					Issuer:        "https://idp.example.com",
+					Subject:       "sub-1",
+					Email:         "jane@Other.com",
+					EmailVerified: true,
+				}, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "jane@Other.com").Return(nil, nil)
+				dbMock.ExpectBegin()
+				ur.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.OrgID == 2 && u.Name == "jane@Other.com"
+				})).Run(func(args mock.Arguments) {
+					args.Get(2).(*entity.User).ID = 10
+				}).Return(nil)
+				uir.On("CreateTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				dbMock.ExpectCommit()
+				utr.On("FindByUserID", mock.Anything, uint64(10)).Return(nil, nil)
+				j.On("Create", "10", uint64(2), "employee", "").Return("access-token", nil)
+			},
+			wantRes: &model.LoginResponse{AccessToken: "access-token"},
+		},
+		{
+			name: "success provision new employee",
+			mockFunc: func(dbMock pgxmock.PgxPoolIface, rc *mocks.RedisClient, j *mocks.JWTToken, op *mocks.OIDCProvider,
+				ur *mocks.UserRepository, uir *mocks.UserIdentityRepository, utr *mocks.UserTOTPRepository) {
+				rc.On("GetDel", mock.Anything, stateKey).Return(getDel(stateVal, nil))
+				op.On("Exchange", mock.Anything, "code-1", "verifier-1", "nonce-1").Return(identity, nil)
+				uir.On("FindByIssuerAndSubject", mock.Anything, "https://idp.example.com", "sub-1").Return(nil, nil)
+				ur.On("FindByEmail", mock.Anything, "john@mail.com").Return(nil, nil)
+				dbMock.ExpectBegin()
+				ur.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.OrgID == entity.DefaultOrganizationID && u.Email == "john@mail.com" && u.Name == "John Doe" &&
+						u.Role == entity.UserRoleEmployee && strings.HasPrefix(u.PasswordHash, "$2a$")
+				})).Run(func(args mock.Arguments) {
+					args.Get(2).(*entity.User).ID = 9
+				}).Return(nil)
+				uir.On("CreateTx", mock.Anything, mock.Anything, &entity.UserIdentity{
+					OrgID:   1,
+					UserID:  9,
+					Issuer:  "https://idp.example.com",
+					Subject: "sub-1",
+				}).Return(nil)
+				dbMock.ExpectCommit()
+				utr.On("FindByUserID", mock.Anything, uint64(9)).Return(nil, nil)
+				j.On("Create", "9", uint64(1), "employee", "").Return("access-token", nil)
+			},
+			wantRes: &model.LoginResponse{AccessToken: "access-token"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dbMock, _ := pgxmock.NewPool()
+			defer dbMock.Close()
+
+			rc := mocks.NewRedisClient(s.T())
+			j := mocks.NewJWTToken(s.T())
+			op := mocks.NewOIDCProvider(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			uir := mocks.NewUserIdentityRepository(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewSSOUsecase(s.log, rc, db.NewTransactioner(dbMock), j, op, ur, uir, utr, s.cfg)
+			tt.mockFunc(dbMock, rc, j, op, ur, uir, utr)
+
+			request := tt.request
+			if request == nil {
+				request = &model.SSOCallbackRequest{Code: "code-1", State: "state-1", StateCookie: "state-1"}
+			}
+			res, err := usecase.Callback(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRes, res)
+			}
+			s.Nil(dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSSOUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SSOUsecaseSuite))
+}
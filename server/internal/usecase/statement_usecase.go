@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/export"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/model/serializer"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type statementUsecase struct {
+	log                    *zap.Logger
+	userRepository         UserRepository
+	organizationRepository OrganizationRepository
+	expenseRepository      ExpenseRepository
+}
+
+func NewStatementUsecase(log *zap.Logger, userRepository UserRepository, organizationRepository OrganizationRepository,
+	expenseRepository ExpenseRepository) StatementUsecase {
+	return &statementUsecase{
+		log:                    log,
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+		expenseRepository:      expenseRepository,
+	}
+}
+
+// Summary returns what the current user is still owed and was paid, of all time and per month of submission
+func (c *statementUsecase) Summary(ctx context.Context, req *model.UserSummaryRequest) (*model.UserSummaryResponse, error) {
+	totals, err := c.expenseRepository.SumByUserStatus(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum expenses of user id (%d) = %w", req.UserID, err)
+	}
+
+	monthly, err := c.expenseRepository.SumByUserMonth(ctx, req.OrgID, req.UserID, req.From, req.To.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum monthly expenses of user id (%d) = %w", req.UserID, err)
+	}
+
+	return serializer.UserSummaryToResponse(totals, monthly, req.From, req.To), nil
+}
+
+// Download renders the reimbursement statement of the expenses the current user submitted in the month as a pdf
+func (c *statementUsecase) Download(ctx context.Context, req *model.GetStatementRequest) (*model.FileResponse, error) {
+	from, to, err := entity.ParseAccountingPeriod(req.Period)
+	if err != nil {
+		return nil, model.ErrInvalidStatementPeriod
+	}
+
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", req.UserID, err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	organization, err := c.organizationRepository.FindByID(ctx, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization by id (%d) = %w", req.OrgID, err)
+	}
+
+	if organization == nil {
+		return nil, model.ErrOrganizationNotFound
+	}
+
+	expenses, err := c.expenseRepository.ListByUserCreated(ctx, req.OrgID, req.UserID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expenses of user id (%d) = %w", req.UserID, err)
+	}
+
+	doc := export.NewPDF()
+	doc.Title("Reimbursement Statement")
+	doc.Field("Employee", fmt.Sprintf("%s <%s>", user.Name, user.Email))
+	doc.Field("Organization", organization.Name)
+	doc.Field("Period", from.Format("January 2006"))
+	doc.Field("Generated", time.Now().UTC().Format("2006-01-02 15:04 UTC"))
+	doc.Space()
+
+	totals := make(map[entity.ExpenseStatus]uint64)
+	if len(expenses) == 0 {
+		doc.Text("No expenses were submitted in this period.")
+	} else {
+		doc.Table(serializer.StatementColumnWidths, serializer.StatementHeader...)
+		for i := range expenses {
+			doc.Row(serializer.ExpenseToStatementRow(&expenses[i])...)
+			totals[expenses[i].Status] += expenses[i].Amount
+		}
+	}
+
+	doc.Space()
+	doc.Field("Awaiting approval", model.FormatRupiah(totals[entity.ExpenseStatusAwaitingApproval]))
+	doc.Field("Changes requested", model.FormatRupiah(totals[entity.ExpenseStatusChangesRequested]))
+	doc.Field("Approved, unpaid", model.FormatRupiah(totals[entity.ExpenseStatusApproved]))
+	doc.Field("Paid", model.FormatRupiah(totals[entity.ExpenseStatusCompleted]))
+	doc.Field("Rejected", model.FormatRupiah(totals[entity.ExpenseStatusRejected]))
+
+	return &model.FileResponse{
+		Name:        fmt.Sprintf("statement-%s.pdf", req.Period),
+		ContentType: "application/pdf",
+		Content:     doc.Bytes(),
+	}, nil
+}
@@ -0,0 +1,220 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type StatementUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+type StatementMockFunc func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository)
+
+func (s *StatementUsecaseSuite) SetupTest() {
+	s.log = zap.NewNop()
+	s.ctx = context.Background()
+}
+
+func (s *StatementUsecaseSuite) newUsecase(mockFunc StatementMockFunc) usecase.StatementUsecase {
+	ur := mocks.NewUserRepository(s.T())
+	or := mocks.NewOrganizationRepository(s.T())
+	er := mocks.NewExpenseRepository(s.T())
+	mockFunc(ur, or, er)
+
+	return usecase.NewStatementUsecase(s.log, ur, or, er)
+}
+
+func (s *StatementUsecaseSuite) TestStatementUsecase_Summary() {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	request := &model.UserSummaryRequest{UserID: 2, OrgID: 1, From: from, To: to}
+	end := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		mockFunc   StatementMockFunc
+		wantRes    *model.UserSummaryResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on totals",
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				er.On("SumByUserStatus", mock.Anything, uint64(1), uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to sum expenses of user id (2) = something error",
+		},
+		{
+			name: "error on monthly totals",
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				er.On("SumByUserStatus", mock.Anything, uint64(1), uint64(2)).Return(nil, nil)
+				er.On("SumByUserMonth", mock.Anything, uint64(1), uint64(2), from, end).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to sum monthly expenses of user id (2) = something error",
+		},
+		{
+			name: "success",
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				er.On("SumByUserStatus", mock.Anything, uint64(1), uint64(2)).Return([]entity.ExpenseStatusTotal{
+					{Status: entity.ExpenseStatusApproved, Count: 1, Amount: 50000},
+				}, nil)
+				er.On("SumByUserMonth", mock.Anything, uint64(1), uint64(2), from, end).Return([]entity.ExpenseStatusTotal{
+					{Month: "2025-09", Status: entity.ExpenseStatusApproved, Count: 1, Amount: 50000},
+				}, nil)
+			},
+			wantRes: &model.UserSummaryResponse{
+				Pending:        model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+				ApprovedUnpaid: model.SpendingTotalResponse{Count: 1, AmountIDR: 50000, AmountFormatted: "Rp 50.000"},
+				Paid:           model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+				Periods: []model.SpendingPeriodResponse{
+					{
+						Period:         "2025-08",
+						Pending:        model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+						ApprovedUnpaid: model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+						Paid:           model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+					},
+					{
+						Period:         "2025-09",
+						Pending:        model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+						ApprovedUnpaid: model.SpendingTotalResponse{Count: 1, AmountIDR: 50000, AmountFormatted: "Rp 50.000"},
+						Paid:           model.SpendingTotalResponse{AmountFormatted: "Rp 0"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Summary(s.ctx, request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal(tt.wantRes, res)
+			}
+		})
+	}
+}
+
+func (s *StatementUsecaseSuite) TestStatementUsecase_Download() {
+	request := &model.GetStatementRequest{UserID: 2, OrgID: 1, Period: "2025-09"}
+	from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	user := &entity.User{ID: 2, OrgID: 1, Name: "John Doe", Email: "john@mail.com"}
+	organization := &entity.Organization{ID: 1, Name: "Acme"}
+	createdAt := time.Date(2025, 9, 14, 8, 0, 0, 0, time.UTC)
+	processedAt := time.Date(2025, 9, 16, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		request      *model.GetStatementRequest
+		mockFunc     StatementMockFunc
+		wantContains []string
+		wantErrMsg   string
+	}{
+		{
+			name:       "error on invalid period",
+			request:    &model.GetStatementRequest{UserID: 2, OrgID: 1, Period: "2025-9"},
+			mockFunc:   func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {},
+			wantErrMsg: "Statement period must be a month such as 2025-09",
+		},
+		{
+			name:    "error on find user",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (2) = something error",
+		},
+		{
+			name:    "error user not found",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error organization not found",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(user, nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Organization not found",
+		},
+		{
+			name:    "error on list expenses",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(user, nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				er.On("ListByUserCreated", mock.Anything, uint64(1), uint64(2), from, to).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list expenses of user id (2) = something error",
+		},
+		{
+			name:    "success without expenses",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(user, nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				er.On("ListByUserCreated", mock.Anything, uint64(1), uint64(2), from, to).Return(nil, nil)
+			},
+			wantContains: []string{"(No expenses were submitted in this period.) Tj", "(Rp 0) Tj"},
+		},
+		{
+			name:    "success",
+			request: request,
+			mockFunc: func(ur *mocks.UserRepository, or *mocks.OrganizationRepository, er *mocks.ExpenseRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(2)).Return(user, nil)
+				or.On("FindByID", mock.Anything, uint64(1)).Return(organization, nil)
+				er.On("ListByUserCreated", mock.Anything, uint64(1), uint64(2), from, to).Return([]entity.Expense{
+					{ID: 7, UserID: 2, Amount: 150000, Description: "Taxi", Status: entity.ExpenseStatusCompleted, CreatedAt: createdAt, ProcessedAt: &processedAt},
+					{ID: 8, UserID: 2, Amount: 50000, Description: "Lunch", Status: entity.ExpenseStatusApproved, CreatedAt: createdAt},
+				}, nil)
+			},
+			wantContains: []string{
+				"(John Doe <john@mail.com>) Tj", "(Acme) Tj", "(September 2025) Tj",
+				"(EXP-000000007) Tj", "(2025-09-16) Tj", "(EXP-000000008) Tj", "(Rp 150.000) Tj", "(Rp 50.000) Tj",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			res, err := s.newUsecase(tt.mockFunc).Download(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("statement-2025-09.pdf", res.Name)
+				s.Equal("application/pdf", res.ContentType)
+				s.True(strings.HasPrefix(string(res.Content), "%PDF-1.4"))
+				for _, want := range tt.wantContains {
+					s.Contains(string(res.Content), want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatementUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(StatementUsecaseSuite))
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/model"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type twoFactorUsecase struct {
+	log                *zap.Logger
+	totp               auth.TOTP
+	userRepository     UserRepository
+	userTOTPRepository UserTOTPRepository
+}
+
+func NewTwoFactorUsecase(log *zap.Logger, totp auth.TOTP, userRepository UserRepository,
+	userTOTPRepository UserTOTPRepository) TwoFactorUsecase {
+	return &twoFactorUsecase{
+		log:                log,
+		totp:               totp,
+		userRepository:     userRepository,
+		userTOTPRepository: userTOTPRepository,
+	}
+}
+
+func (c *twoFactorUsecase) Status(ctx context.Context, req *model.GetTwoFactorRequest) (*model.TwoFactorStatusResponse, error) {
+	userTOTP, err := c.userTOTPRepository.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp by user id (%d) = %w", req.UserID, err)
+	}
+
+	res := &model.TwoFactorStatusResponse{
+		Enabled:  userTOTP.Enabled(),
+		Required: entity.UserRole(req.UserRole).RequiresTwoFactor(),
+	}
+	if userTOTP.Enabled() {
+		res.RecoveryCodesRemaining = len(userTOTP.RecoveryCodes)
+	}
+
+	return res, nil
+}
+
+func (c *twoFactorUsecase) Enroll(ctx context.Context, req *model.EnrollTwoFactorRequest) (*model.TwoFactorEnrollResponse, error) {
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", req.UserID, err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	userTOTP, err := c.userTOTPRepository.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find totp by user id (%d) = %w", req.UserID, err)
+	}
+
+	if userTOTP.Enabled() {
+		return nil, model.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := c.totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret for user id (%d) = %w", req.UserID, err)
+	}
+
+	recoveryCodes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes for user id (%d) = %w", req.UserID, err)
+	}
+
+	hashedCodes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashedCodes[i] = auth.HashRecoveryCode(code)
+	}
+
+	err = c.userTOTPRepository.Upsert(ctx, &entity.UserTOTP{
+		UserID:        req.UserID,
+		Secret:        secret,
+		RecoveryCodes: hashedCodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert totp for user id (%d) = %w", req.UserID, err)
+	}
+
+	return &model.TwoFactorEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: c.totp.ProvisioningURI(secret, user.Email),
+		RecoveryCodes:   recoveryCodes,
+	}, nil
+}
+
+func (c *twoFactorUsecase) Activate(ctx context.Context, req *model.ActivateTwoFactorRequest) error {
+	userTOTP, err := c.userTOTPRepository.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find totp by user id (%d) = %w", req.UserID, err)
+	}
+
+	if userTOTP == nil {
+		return model.ErrTwoFactorNotEnrolled
+	}
+	if userTOTP.Enabled() {
+		return model.ErrTwoFactorAlreadyEnabled
+	}
+
+	// only a totp code proves the authenticator app was set up correctly
+	valid, err := verifyTOTPCode(ctx, c.totp, c.userTOTPRepository, userTOTP, req.Code)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return model.ErrInvalidTwoFactorCode
+	}
+
+	err = c.userTOTPRepository.Enable(ctx, req.UserID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to enable totp for user id (%d) = %w", req.UserID, err)
+	}
+
+	return nil
+}
+
+func (c *twoFactorUsecase) Disable(ctx context.Context, req *model.DisableTwoFactorRequest) error {
+	if entity.UserRole(req.UserRole).RequiresTwoFactor() {
+		return model.ErrTwoFactorRequired
+	}
+
+	userTOTP, err := c.userTOTPRepository.FindByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find totp by user id (%d) = %w", req.UserID, err)
+	}
+
+	if !userTOTP.Enabled() {
+		return model.ErrTwoFactorNotEnrolled
+	}
+
+	valid, err := verifySecondFactor(ctx, c.totp, c.userTOTPRepository, userTOTP, req.Code)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return model.ErrInvalidTwoFactorCode
+	}
+
+	err = c.userTOTPRepository.Delete(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete totp for user id (%d) = %w", req.UserID, err)
+	}
+
+	return nil
+}
+
+// verifySecondFactor accepts either a current totp code or one of the unused
+// recovery codes, a matching recovery code is consumed so it can't be replayed
+func verifySecondFactor(ctx context.Context, totp auth.TOTP, userTOTPRepository UserTOTPRepository,
+	userTOTP *entity.UserTOTP, code string) (bool, error) {
+	if step, ok := totp.Validate(userTOTP.Secret, code); ok {
+		return useTOTPTimeStep(ctx, userTOTPRepository, userTOTP.UserID, step)
+	}
+
+	valid, err := userTOTPRepository.UseRecoveryCode(ctx, userTOTP.UserID, auth.HashRecoveryCode(code))
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code for user id (%d) = %w", userTOTP.UserID, err)
+	}
+
+	return valid, nil
+}
+
+// verifyTOTPCode accepts only a totp code, see useTOTPTimeStep
+func verifyTOTPCode(ctx context.Context, totp auth.TOTP, userTOTPRepository UserTOTPRepository,
+	userTOTP *entity.UserTOTP, code string) (bool, error) {
+	step, ok := totp.Validate(userTOTP.Secret, code)
+	if !ok {
+		return false, nil
+	}
+
+	return useTOTPTimeStep(ctx, userTOTPRepository, userTOTP.UserID, step)
+}
+
+// useTOTPTimeStep accepts a totp code once, a code of a time step that was already
+// used (or one before it) is refused even while it is still in the accepted window
+func useTOTPTimeStep(ctx context.Context, userTOTPRepository UserTOTPRepository, userID uint64, step int64) (bool, error) {
+	valid, err := userTOTPRepository.UseTimeStep(ctx, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use totp time step for user id (%d) = %w", userID, err)
+	}
+
+	return valid, nil
+}
@@ -0,0 +1,355 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
+	"expense-management-system/internal/mocks"
+	"expense-management-system/internal/model"
+	"expense-management-system/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type TwoFactorUsecaseSuite struct {
+	suite.Suite
+	log *zap.Logger
+	ctx context.Context
+}
+
+func (s *TwoFactorUsecaseSuite) SetupTest() {
+	s.log, _ = zap.NewDevelopment()
+	s.ctx = context.Background()
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_Status() {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.GetTwoFactorRequest
+		mockFunc   func(utr *mocks.UserTOTPRepository)
+		wantRes    *model.TwoFactorStatusResponse
+		wantErrMsg string
+	}{
+		{
+			name: "error on find",
+			request: &model.GetTwoFactorRequest{
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantRes:    nil,
+			wantErrMsg: "failed to find totp by user id (1) = something error",
+		},
+		{
+			name: "success not enrolled",
+			request: &model.GetTwoFactorRequest{
+				UserID:   1,
+				UserRole: "employee",
+			},
+			mockFunc: func(utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantRes: &model.TwoFactorStatusResponse{
+				Enabled:  false,
+				Required: false,
+			},
+			wantErrMsg: "",
+		},
+		{
+			name: "success enabled",
+			request: &model.GetTwoFactorRequest{
+				UserID:   1,
+				UserRole: "manager",
+			},
+			mockFunc: func(utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{
+					UserID:        1,
+					RecoveryCodes: []string{"a", "b"},
+					EnabledAt:     &now,
+				}, nil)
+			},
+			wantRes: &model.TwoFactorStatusResponse{
+				Enabled:                true,
+				Required:               true,
+				RecoveryCodesRemaining: 2,
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, mocks.NewTOTP(s.T()), mocks.NewUserRepository(s.T()), utr)
+			tt.mockFunc(utr)
+
+			res, err := usecase.Status(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantRes, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_Enroll() {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.EnrollTwoFactorRequest
+		mockFunc   func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find user",
+			request: &model.EnrollTwoFactorRequest{UserID: 1},
+			mockFunc: func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (1) = something error",
+		},
+		{
+			name:    "error user not found",
+			request: &model.EnrollTwoFactorRequest{UserID: 1},
+			mockFunc: func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error already enabled",
+			request: &model.EnrollTwoFactorRequest{UserID: 1},
+			mockFunc: func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Email: "john@mail.com"}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, EnabledAt: &now}, nil)
+			},
+			wantErrMsg: "Two-factor authentication already enabled",
+		},
+		{
+			name:    "error on upsert",
+			request: &model.EnrollTwoFactorRequest{UserID: 1},
+			mockFunc: func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Email: "john@mail.com"}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				totp.On("GenerateSecret").Return("secret", nil)
+				utr.On("Upsert", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to upsert totp for user id (1) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.EnrollTwoFactorRequest{UserID: 1},
+			mockFunc: func(totp *mocks.TOTP, ur *mocks.UserRepository, utr *mocks.UserTOTPRepository) {
+				ur.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{ID: 1, Email: "john@mail.com"}, nil)
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+				totp.On("GenerateSecret").Return("secret", nil)
+				utr.On("Upsert", mock.Anything, mock.MatchedBy(func(t *entity.UserTOTP) bool {
+					return t.UserID == 1 && t.Secret == "secret" && len(t.RecoveryCodes) == 10
+				})).Return(nil)
+				totp.On("ProvisioningURI", "secret", "john@mail.com").Return("otpauth://totp/john")
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			totp := mocks.NewTOTP(s.T())
+			ur := mocks.NewUserRepository(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, totp, ur, utr)
+			tt.mockFunc(totp, ur, utr)
+
+			res, err := usecase.Enroll(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+				s.Equal("secret", res.Secret)
+				s.Equal("otpauth://totp/john", res.ProvisioningURI)
+				s.Len(res.RecoveryCodes, 10)
+			}
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_Activate() {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.ActivateTwoFactorRequest
+		mockFunc   func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "error not enrolled",
+			request: &model.ActivateTwoFactorRequest{UserID: 1, Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(nil, nil)
+			},
+			wantErrMsg: "Two-factor authentication not enrolled",
+		},
+		{
+			name:    "error already enabled",
+			request: &model.ActivateTwoFactorRequest{UserID: 1, Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, EnabledAt: &now}, nil)
+			},
+			wantErrMsg: "Two-factor authentication already enabled",
+		},
+		{
+			name:    "error invalid code",
+			request: &model.ActivateTwoFactorRequest{UserID: 1, Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret"}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(0), false)
+			},
+			wantErrMsg: "Invalid two-factor code",
+		},
+		{
+			name:    "error on enable",
+			request: &model.ActivateTwoFactorRequest{UserID: 1, Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret"}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(true, nil)
+				utr.On("Enable", mock.Anything, uint64(1), mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to enable totp for user id (1) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ActivateTwoFactorRequest{UserID: 1, Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret"}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(true, nil)
+				utr.On("Enable", mock.Anything, uint64(1), mock.Anything).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			totp := mocks.NewTOTP(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, totp, mocks.NewUserRepository(s.T()), utr)
+			tt.mockFunc(totp, utr)
+
+			err := usecase.Activate(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseSuite) TestTwoFactorUsecase_Disable() {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		request    *model.DisableTwoFactorRequest
+		mockFunc   func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository)
+		wantErrMsg string
+	}{
+		{
+			name:       "error required for manager",
+			request:    &model.DisableTwoFactorRequest{UserID: 1, UserRole: "manager", Code: "123456"},
+			mockFunc:   func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {},
+			wantErrMsg: "Two-factor authentication is required",
+		},
+		{
+			name:    "error not enrolled",
+			request: &model.DisableTwoFactorRequest{UserID: 1, UserRole: "employee", Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).Return(&entity.UserTOTP{UserID: 1}, nil)
+			},
+			wantErrMsg: "Two-factor authentication not enrolled",
+		},
+		{
+			name:    "error invalid code",
+			request: &model.DisableTwoFactorRequest{UserID: 1, UserRole: "employee", Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret", EnabledAt: &now}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(0), false)
+				utr.On("UseRecoveryCode", mock.Anything, uint64(1), auth.HashRecoveryCode("123456")).Return(false, nil)
+			},
+			wantErrMsg: "Invalid two-factor code",
+		},
+		{
+			name:    "error on delete",
+			request: &model.DisableTwoFactorRequest{UserID: 1, UserRole: "employee", Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret", EnabledAt: &now}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(true, nil)
+				utr.On("Delete", mock.Anything, uint64(1)).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to delete totp for user id (1) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.DisableTwoFactorRequest{UserID: 1, UserRole: "employee", Code: "123456"},
+			mockFunc: func(totp *mocks.TOTP, utr *mocks.UserTOTPRepository) {
+				utr.On("FindByUserID", mock.Anything, uint64(1)).
+					Return(&entity.UserTOTP{UserID: 1, Secret: "secret", EnabledAt: &now}, nil)
+				totp.On("Validate", "secret", "123456").Return(int64(100), true)
+				utr.On("UseTimeStep", mock.Anything, uint64(1), int64(100)).Return(true, nil)
+				utr.On("Delete", mock.Anything, uint64(1)).Return(nil)
+			},
+			wantErrMsg: "",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			totp := mocks.NewTOTP(s.T())
+			utr := mocks.NewUserTOTPRepository(s.T())
+			usecase := usecase.NewTwoFactorUsecase(s.log, totp, mocks.NewUserRepository(s.T()), utr)
+			tt.mockFunc(totp, utr)
+
+			err := usecase.Disable(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func TestTwoFactorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUsecaseSuite))
+}
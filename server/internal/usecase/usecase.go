@@ -2,32 +2,153 @@ package usecase
 
 import (
 	"context"
+	"expense-management-system/internal/auth"
 	"expense-management-system/internal/model"
+	"io"
 )
 
 //go:generate mockery --name=AuthUsecase --structname AuthUsecase --outpkg=mocks --output=./../mocks
 type AuthUsecase interface {
 	Login(ctx context.Context, req *model.LoginRequest) (*model.LoginResponse, error)
+	VerifyTwoFactor(ctx context.Context, req *model.VerifyTwoFactorRequest) (*model.LoginResponse, error)
 	Logout(ctx context.Context, req *model.LogoutRequest) error
+	Unlock(ctx context.Context, req *model.UnlockLoginRequest) error
+}
+
+//go:generate mockery --name=SSOUsecase --structname SSOUsecase --outpkg=mocks --output=./../mocks
+type SSOUsecase interface {
+	Authorize(ctx context.Context) (*model.SSOAuthorizeResponse, error)
+	Callback(ctx context.Context, req *model.SSOCallbackRequest) (*model.LoginResponse, error)
 }
 
 //go:generate mockery --name=UserUsecase --structname UserUsecase --outpkg=mocks --output=./../mocks
 type UserUsecase interface {
 	Create(ctx context.Context, req *model.CreateUserRequest) (*model.UserResponse, error)
 	FindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error)
+	List(ctx context.Context, req *model.ListUserRequest) ([]model.UserResponse, int, error)
+	Update(ctx context.Context, req *model.UpdateUserRequest) (*model.UserResponse, error)
+	Deactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error
+	Reactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error
+	UpdateLocale(ctx context.Context, req *model.UpdateLocaleRequest) (*model.UserResponse, error)
+}
+
+//go:generate mockery --name=SCIMUsecase --structname SCIMUsecase --outpkg=mocks --output=./../mocks
+type SCIMUsecase interface {
+	ListUsers(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error)
+	GetUser(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMUserResponse, error)
+	CreateUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error)
+	ReplaceUser(ctx context.Context, req *model.SCIMUserRequest) (*model.SCIMUserResponse, error)
+	PatchUser(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMUserResponse, error)
+	DeleteUser(ctx context.Context, req *model.SCIMResourceRequest) error
+	ListGroups(ctx context.Context, req *model.ListSCIMRequest) (*model.SCIMListResponse, error)
+	GetGroup(ctx context.Context, req *model.SCIMResourceRequest) (*model.SCIMGroupResponse, error)
+	PatchGroup(ctx context.Context, req *model.SCIMPatchRequest) (*model.SCIMGroupResponse, error)
+}
+
+//go:generate mockery --name=PasswordUsecase --structname PasswordUsecase --outpkg=mocks --output=./../mocks
+type PasswordUsecase interface {
+	Forgot(ctx context.Context, req *model.ForgotPasswordRequest) error
+	Reset(ctx context.Context, req *model.ResetPasswordRequest) error
+	Change(ctx context.Context, req *model.ChangePasswordRequest) error
+}
+
+//go:generate mockery --name=PersonalAccessTokenUsecase --structname PersonalAccessTokenUsecase --outpkg=mocks --output=./../mocks
+type PersonalAccessTokenUsecase interface {
+	List(ctx context.Context, req *model.ListPersonalAccessTokenRequest) ([]model.PersonalAccessTokenResponse, error)
+	Create(ctx context.Context, req *model.CreatePersonalAccessTokenRequest) (*model.CreatePersonalAccessTokenResponse, error)
+	Revoke(ctx context.Context, req *model.RevokePersonalAccessTokenRequest) error
+	Authenticate(ctx context.Context, apiKey string) (*auth.JWTClaims, error)
+}
+
+//go:generate mockery --name=TwoFactorUsecase --structname TwoFactorUsecase --outpkg=mocks --output=./../mocks
+type TwoFactorUsecase interface {
+	Status(ctx context.Context, req *model.GetTwoFactorRequest) (*model.TwoFactorStatusResponse, error)
+	Enroll(ctx context.Context, req *model.EnrollTwoFactorRequest) (*model.TwoFactorEnrollResponse, error)
+	Activate(ctx context.Context, req *model.ActivateTwoFactorRequest) error
+	Disable(ctx context.Context, req *model.DisableTwoFactorRequest) error
+}
+
+//go:generate mockery --name=OrganizationUsecase --structname OrganizationUsecase --outpkg=mocks --output=./../mocks
+type OrganizationUsecase interface {
+	Get(ctx context.Context, req *model.GetOrganizationRequest) (*model.OrganizationResponse, error)
+	Update(ctx context.Context, req *model.UpdateOrganizationRequest) (*model.OrganizationResponse, error)
+}
+
+//go:generate mockery --name=DepartmentUsecase --structname DepartmentUsecase --outpkg=mocks --output=./../mocks
+type DepartmentUsecase interface {
+	List(ctx context.Context, req *model.ListDepartmentRequest) ([]model.DepartmentResponse, error)
+	Create(ctx context.Context, req *model.CreateDepartmentRequest) (*model.DepartmentResponse, error)
+	Update(ctx context.Context, req *model.UpdateDepartmentRequest) (*model.DepartmentResponse, error)
+	ListBudgets(ctx context.Context, req *model.ListDepartmentBudgetRequest) ([]model.DepartmentBudgetResponse, error)
+	CreateBudget(ctx context.Context, req *model.CreateDepartmentBudgetRequest) (*model.DepartmentBudgetResponse, error)
+}
+
+//go:generate mockery --name=JournalUsecase --structname JournalUsecase --outpkg=mocks --output=./../mocks
+type JournalUsecase interface {
+	ListMappings(ctx context.Context, req *model.ListGLAccountMappingRequest) ([]model.GLAccountMappingResponse, error)
+	UpsertMapping(ctx context.Context, req *model.UpsertGLAccountMappingRequest) (*model.GLAccountMappingResponse, error)
+	DeleteMapping(ctx context.Context, req *model.DeleteGLAccountMappingRequest) error
+	CreateBatch(ctx context.Context, req *model.CreateJournalBatchRequest) (*model.JournalBatchResponse, error)
+	ListBatches(ctx context.Context, req *model.ListJournalBatchRequest) ([]model.JournalBatchResponse, error)
+	GetBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.JournalBatchResponse, error)
+	DownloadBatch(ctx context.Context, req *model.GetJournalBatchRequest) (*model.FileResponse, error)
+}
+
+//go:generate mockery --name=LedgerUsecase --structname LedgerUsecase --outpkg=mocks --output=./../mocks
+type LedgerUsecase interface {
+	ListBalances(ctx context.Context, req *model.ListLedgerBalanceRequest) ([]model.LedgerBalanceResponse, error)
+	ListEntries(ctx context.Context, req *model.ListLedgerEntryRequest) ([]model.LedgerEntryResponse, int, error)
+	Reverse(ctx context.Context, req *model.ReverseLedgerEntryRequest) (*model.LedgerEntryResponse, error)
+}
+
+//go:generate mockery --name=AnalyticsUsecase --structname AnalyticsUsecase --outpkg=mocks --output=./../mocks
+type AnalyticsUsecase interface {
+	Summary(ctx context.Context, req *model.AnalyticsRequest) (*model.AnalyticsSummaryResponse, error)
+	Breakdown(ctx context.Context, req *model.AnalyticsRequest) ([]model.AnalyticsGroupResponse, error)
+}
+
+//go:generate mockery --name=StatementUsecase --structname StatementUsecase --outpkg=mocks --output=./../mocks
+type StatementUsecase interface {
+	Summary(ctx context.Context, req *model.UserSummaryRequest) (*model.UserSummaryResponse, error)
+	Download(ctx context.Context, req *model.GetStatementRequest) (*model.FileResponse, error)
 }
 
 //go:generate mockery --name=ExpenseUsecase --structname ExpenseUsecase --outpkg=mocks --output=./../mocks
 type ExpenseUsecase interface {
 	Create(ctx context.Context, req *model.CreateExpenseRequest) (*model.ExpenseCreateResponse, error)
-	List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, int, error)
+	List(ctx context.Context, req *model.ListExpenseRequest) ([]model.ExpenseWithUserResponse, *model.Page, error)
+	Search(ctx context.Context, req *model.SearchExpenseRequest) ([]model.ExpenseSearchResultResponse, int, error)
 	FindByID(ctx context.Context, req *model.GetExpenseRequest) (*model.ExpenseDetailResponse, error)
+	Update(ctx context.Context, req *model.UpdateExpenseRequest) (*model.ExpenseDetailResponse, error)
+}
+
+//go:generate mockery --name=PaymentVoucherUsecase --structname PaymentVoucherUsecase --outpkg=mocks --output=./../mocks
+type PaymentVoucherUsecase interface {
+	Download(ctx context.Context, req *model.GetExpenseRequest) (*model.FileResponse, error)
+}
+
+//go:generate mockery --name=ExpenseCommentUsecase --structname ExpenseCommentUsecase --outpkg=mocks --output=./../mocks
+type ExpenseCommentUsecase interface {
+	List(ctx context.Context, req *model.GetExpenseRequest) ([]model.ExpenseCommentResponse, error)
+	Create(ctx context.Context, req *model.CreateExpenseCommentRequest) (*model.ExpenseCommentResponse, error)
+	Update(ctx context.Context, req *model.UpdateExpenseCommentRequest) (*model.ExpenseCommentResponse, error)
+	Delete(ctx context.Context, req *model.DeleteExpenseCommentRequest) error
+}
+
+//go:generate mockery --name=ExpenseExportUsecase --structname ExpenseExportUsecase --outpkg=mocks --output=./../mocks
+type ExpenseExportUsecase interface {
+	Create(ctx context.Context, req *model.CreateExpenseExportRequest) (*model.ExpenseExportResponse, error)
+	Stream(ctx context.Context, req *model.CreateExpenseExportRequest, w io.Writer) (int, error)
+	Get(ctx context.Context, req *model.GetExpenseExportRequest) (*model.ExpenseExportResponse, error)
+	Download(ctx context.Context, req *model.GetExpenseExportRequest) (*model.FileStreamResponse, error)
+	Process(ctx context.Context, req *model.ProcessExpenseExportRequest) error
 }
 
 //go:generate mockery --name=ApprovalUsecase --structname ApprovalUsecase --outpkg=mocks --output=./../mocks
 type ApprovalUsecase interface {
 	Approve(ctx context.Context, req *model.ApprovalExpenseRequest) error
 	Reject(ctx context.Context, req *model.ApprovalExpenseRequest) error
+	RequestChanges(ctx context.Context, req *model.RequestChangesExpenseRequest) error
 }
 
 //go:generate mockery --name=PaymentProcessorUsecase --structname PaymentProcessorUsecase --outpkg=mocks --output=./../mocks
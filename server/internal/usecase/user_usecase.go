@@ -2,24 +2,37 @@ package usecase
 
 import (
 	"context"
+	"expense-management-system/internal/auth"
 	"expense-management-system/internal/entity"
 	"expense-management-system/internal/model"
 	"expense-management-system/internal/model/serializer"
+	"expense-management-system/internal/storage"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type userUsecase struct {
-	log            *zap.Logger
-	userRepository UserRepository
+	log                           *zap.Logger
+	redisClient                   storage.RedisClient
+	userRepository                UserRepository
+	personalAccessTokenRepository PersonalAccessTokenRepository
+	departmentRepository          DepartmentRepository
+	sessionTTL                    time.Duration // access token lifetime, a user-wide revoke must outlive every issued token
 }
 
-func NewUserUsecase(log *zap.Logger, userRepository UserRepository) UserUsecase {
+func NewUserUsecase(log *zap.Logger, redisClient storage.RedisClient, userRepository UserRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository, departmentRepository DepartmentRepository,
+	sessionTTL time.Duration) UserUsecase {
 	return &userUsecase{
-		log:            log,
-		userRepository: userRepository,
+		log:                           log,
+		redisClient:                   redisClient,
+		userRepository:                userRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		departmentRepository:          departmentRepository,
+		sessionTTL:                    sessionTTL,
 	}
 }
 
@@ -43,11 +56,14 @@ func (c *userUsecase) Create(ctx context.Context, req *model.CreateUserRequest)
 		return nil, fmt.Errorf("failed to parse user role for email (%s) = %w", req.Email, err)
 	}
 
+	// self registration always joins the default organization
 	user := &entity.User{
+		OrgID:        entity.DefaultOrganizationID,
 		Email:        req.Email,
 		Name:         req.Name,
 		PasswordHash: string(password),
 		Role:         role,
+		Active:       true,
 	}
 
 	err = c.userRepository.Create(ctx, user)
@@ -59,7 +75,7 @@ func (c *userUsecase) Create(ctx context.Context, req *model.CreateUserRequest)
 }
 
 func (c *userUsecase) FindByID(ctx context.Context, req *model.GetUserRequest) (*model.UserResponse, error) {
-	user, err := c.userRepository.FindByID(ctx, req.ID)
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by id (%d) = %w", req.ID, err)
 	}
@@ -70,3 +86,193 @@ func (c *userUsecase) FindByID(ctx context.Context, req *model.GetUserRequest) (
 
 	return serializer.UserToResponse(user), nil
 }
+
+func (c *userUsecase) List(ctx context.Context, req *model.ListUserRequest) ([]model.UserResponse, int, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, 0, model.ErrForbidden
+	}
+
+	users, total, err := c.userRepository.List(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users = %w", err)
+	}
+
+	res := make([]model.UserResponse, len(users))
+	for i := range users {
+		res[i] = *serializer.UserToResponse(&users[i])
+	}
+
+	return res, total, nil
+}
+
+func (c *userUsecase) Update(ctx context.Context, req *model.UpdateUserRequest) (*model.UserResponse, error) {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return nil, model.ErrForbidden
+	}
+
+	user, err := c.findUser(ctx, req.OrgID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *user
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+
+	if req.Email != nil && *req.Email != user.Email {
+		total, err := c.userRepository.CountByEmail(ctx, *req.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count by email (%s) = %w", *req.Email, err)
+		}
+
+		if total > 0 {
+			return nil, model.ErrEmailAlreadyExist
+		}
+
+		user.Email = *req.Email
+	}
+
+	if req.DepartmentID != nil {
+		user.DepartmentID = nil
+		if *req.DepartmentID != 0 {
+			department, err := c.departmentRepository.FindByID(ctx, req.OrgID, *req.DepartmentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find department by id (%d) = %w", *req.DepartmentID, err)
+			}
+
+			if department == nil {
+				return nil, model.ErrDepartmentNotFound
+			}
+
+			user.DepartmentID = &department.ID
+		}
+	}
+
+	err = saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.UserToResponse(user), nil
+}
+
+// Deactivate blocks login and every session of the user, their expenses and approvals are kept
+func (c *userUsecase) Deactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return model.ErrForbidden
+	}
+
+	if req.ID == req.UserID {
+		return model.ErrCannotDeactivateSelf
+	}
+
+	return c.setActive(ctx, req.OrgID, req.ID, false)
+}
+
+func (c *userUsecase) Reactivate(ctx context.Context, req *model.UpdateUserActiveRequest) error {
+	if req.UserRole != string(entity.UserRoleAdmin) {
+		return model.ErrForbidden
+	}
+
+	return c.setActive(ctx, req.OrgID, req.ID, true)
+}
+
+// UpdateLocale saves the preferred language of the messages, the access token carries it from the next login
+func (c *userUsecase) UpdateLocale(ctx context.Context, req *model.UpdateLocaleRequest) (*model.UserResponse, error) {
+	user, err := c.userRepository.FindByID(ctx, req.OrgID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", req.UserID, err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	err = c.userRepository.UpdateLocale(ctx, user.OrgID, user.ID, req.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update locale for id (%d) = %w", user.ID, err)
+	}
+
+	user.Locale = req.Locale
+	return serializer.UserToResponse(user), nil
+}
+
+func (c *userUsecase) setActive(ctx context.Context, orgID uint64, id uint64, active bool) error {
+	user, err := c.findUser(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	// saved even when unchanged, so a retry finishes what a failed call left out
+	before := *user
+	user.Active = active
+
+	return saveUser(ctx, c.redisClient, c.userRepository, c.personalAccessTokenRepository, before, user, c.sessionTTL)
+}
+
+func (c *userUsecase) findUser(ctx context.Context, orgID uint64, id uint64) (*entity.User, error) {
+	user, err := c.userRepository.FindByID(ctx, orgID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by id (%d) = %w", id, err)
+	}
+
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// saveUser persists profile, role and active changes made on user. Tokens carry the role and
+// are checked against the deactivated marker, so both changes are reflected on the sessions too.
+// The marker and the revoke follow the saved state rather than the change, the database is written
+// first and a retry after a failure in between must still deactivate the sessions
+func saveUser(ctx context.Context, redisClient storage.RedisClient, userRepository UserRepository,
+	personalAccessTokenRepository PersonalAccessTokenRepository, before entity.User, user *entity.User,
+	sessionTTL time.Duration) error {
+	err := userRepository.Update(ctx, user)
+	if err != nil {
+		return fmt.Errorf("failed to update user for id (%d) = %w", user.ID, err)
+	}
+
+	deactivatedKey := fmt.Sprintf("%s:%d", auth.PrefixDeactivatedUserKey, user.ID)
+	if !user.Active {
+		// every session issued before outlives the marker by no more than its ttl,
+		// new logins are refused from the database
+		err = redisClient.Set(ctx, deactivatedKey, time.Now().Unix(), sessionTTL).Err()
+		if err != nil {
+			return fmt.Errorf("failed to set deactivated user for id (%d) = %w", user.ID, err)
+		}
+
+		// revoked as well so reactivating doesn't bring the old sessions back
+		return revokeUserTokens(ctx, redisClient, personalAccessTokenRepository, user.ID, sessionTTL)
+	}
+
+	err = redisClient.Del(ctx, deactivatedKey).Err()
+	if err != nil {
+		return fmt.Errorf("failed to delete deactivated user for id (%d) = %w", user.ID, err)
+	}
+
+	if before.Role != user.Role {
+		return revokeUserTokens(ctx, redisClient, personalAccessTokenRepository, user.ID, sessionTTL)
+	}
+
+	return nil
+}
+
+// randomPasswordHash is given to users that are provisioned from outside, it keeps
+// password login closed until the user resets it
+func randomPasswordHash() (string, error) {
+	password, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(passwordHash), nil
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -21,11 +22,26 @@ type UserUsecaseSuite struct {
 	ctx context.Context
 }
 
+type UserMockFunc func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+	pr *mocks.PersonalAccessTokenRepository)
+
 func (s *UserUsecaseSuite) SetupTest() {
 	s.log, _ = zap.NewDevelopment()
 	s.ctx = context.Background()
 }
 
+func (s *UserUsecaseSuite) statusCmd(err error) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
+func (s *UserUsecaseSuite) intCmd(err error) *redis.IntCmd {
+	cmd := redis.NewIntCmd(s.ctx)
+	cmd.SetErr(err)
+	return cmd
+}
+
 func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 	now := time.Now()
 
@@ -126,7 +142,8 @@ func (s *UserUsecaseSuite) TestUserUsecase_Create() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, userRepository)
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewRedisClient(s.T()), userRepository,
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewDepartmentRepository(s.T()), 24*time.Hour)
 			tt.mockFunc(userRepository)
 
 			_, err := usecase.Create(s.ctx, tt.request)
@@ -156,7 +173,7 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 				ID: 1,
 			},
 			mockFunc: func(r *mocks.UserRepository) {
-				r.On("FindByID", mock.Anything, uint64(1)).
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
 					Return(nil, errors.New("something error"))
 			},
 			wantUser:   nil,
@@ -168,7 +185,7 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 				ID: 1,
 			},
 			mockFunc: func(r *mocks.UserRepository) {
-				r.On("FindByID", mock.Anything, uint64(1)).
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
 					Return(nil, nil)
 			},
 			wantUser:   nil,
@@ -180,7 +197,7 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 				ID: 1,
 			},
 			mockFunc: func(r *mocks.UserRepository) {
-				r.On("FindByID", mock.Anything, uint64(1)).Return(&entity.User{
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{
 					ID:           1,
 					Email:        "john@mail.com",
 					Name:         "John Doe",
@@ -203,7 +220,8 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			userRepository := mocks.NewUserRepository(s.T())
-			usecase := usecase.NewUserUsecase(s.log, userRepository)
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewRedisClient(s.T()), userRepository,
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewDepartmentRepository(s.T()), 24*time.Hour)
 			tt.mockFunc(userRepository)
 
 			res, err := usecase.FindByID(s.ctx, tt.request)
@@ -219,6 +237,487 @@ func (s *UserUsecaseSuite) TestUserUsecase_FindByID() {
 	}
 }
 
+func (s *UserUsecaseSuite) TestUserUsecase_UpdateLocale() {
+	now := time.Now()
+	locale := "id"
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateLocaleRequest
+		mockFunc   func(r *mocks.UserRepository)
+		wantUser   *model.UserResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error on find",
+			request: &model.UpdateLocaleRequest{UserID: 1, Locale: "id"},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
+					Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (1) = something error",
+		},
+		{
+			name:    "not found",
+			request: &model.UpdateLocaleRequest{UserID: 1, Locale: "id"},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
+					Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error on update",
+			request: &model.UpdateLocaleRequest{UserID: 1, Locale: "id"},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).
+					Return(&entity.User{ID: 1, CreatedAt: now}, nil)
+				r.On("UpdateLocale", mock.Anything, mock.Anything, uint64(1), "id").
+					Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update locale for id (1) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.UpdateLocaleRequest{UserID: 1, Locale: "id"},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Email:     "john@mail.com",
+					Name:      "John Doe",
+					Role:      "manager",
+					CreatedAt: now,
+				}, nil)
+				r.On("UpdateLocale", mock.Anything, mock.Anything, uint64(1), "id").Return(nil)
+			},
+			wantUser: &model.UserResponse{
+				ID:        1,
+				Email:     "john@mail.com",
+				Name:      "John Doe",
+				Role:      "manager",
+				Locale:    &locale,
+				CreatedAt: now.UTC().Format(time.RFC3339),
+			},
+		},
+		{
+			name:    "success remove preference",
+			request: &model.UpdateLocaleRequest{UserID: 1, Locale: ""},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("FindByID", mock.Anything, mock.Anything, uint64(1)).Return(&entity.User{
+					ID:        1,
+					Email:     "john@mail.com",
+					Name:      "John Doe",
+					Role:      "manager",
+					Locale:    "id",
+					CreatedAt: now,
+				}, nil)
+				r.On("UpdateLocale", mock.Anything, mock.Anything, uint64(1), "").Return(nil)
+			},
+			wantUser: &model.UserResponse{
+				ID:        1,
+				Email:     "john@mail.com",
+				Name:      "John Doe",
+				Role:      "manager",
+				CreatedAt: now.UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewRedisClient(s.T()), userRepository,
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewDepartmentRepository(s.T()), 24*time.Hour)
+			tt.mockFunc(userRepository)
+
+			res, err := usecase.UpdateLocale(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(*tt.wantUser, *res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_List() {
+	now := time.Now()
+	search := "john"
+
+	tests := []struct {
+		name       string
+		request    *model.ListUserRequest
+		mockFunc   func(r *mocks.UserRepository)
+		wantRes    []model.UserResponse
+		wantTotal  int
+		wantErrMsg string
+	}{
+		{
+			name:       "error not admin",
+			request:    &model.ListUserRequest{OrgID: 1, UserRole: "manager"},
+			mockFunc:   func(r *mocks.UserRepository) {},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error on list",
+			request: &model.ListUserRequest{OrgID: 1, UserRole: "admin", Search: &search},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("List", mock.Anything, mock.Anything).
+					Return(nil, 0, errors.New("something error"))
+			},
+			wantErrMsg: "failed to list users = something error",
+		},
+		{
+			name:    "success",
+			request: &model.ListUserRequest{OrgID: 1, UserRole: "admin", Search: &search, Limit: 10},
+			mockFunc: func(r *mocks.UserRepository) {
+				r.On("List", mock.Anything, mock.Anything).Return([]entity.User{
+					{ID: 1, Email: "john@mail.com", Name: "John Doe", Role: "manager", Active: true, CreatedAt: now},
+				}, 1, nil)
+			},
+			wantRes: []model.UserResponse{
+				{ID: 1, Email: "john@mail.com", Name: "John Doe", Role: "manager", Active: true,
+					CreatedAt: now.UTC().Format(time.RFC3339)},
+			},
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			userRepository := mocks.NewUserRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, mocks.NewRedisClient(s.T()), userRepository,
+				mocks.NewPersonalAccessTokenRepository(s.T()), mocks.NewDepartmentRepository(s.T()), 24*time.Hour)
+			tt.mockFunc(userRepository)
+
+			res, total, err := usecase.List(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Equal(tt.wantTotal, total)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_Update() {
+	now := time.Now()
+	name := "John Smith"
+	email := "smith@mail.com"
+	departmentID := uint64(3)
+	noDepartment := uint64(0)
+
+	user := func() *entity.User {
+		return &entity.User{ID: 2, Email: "john@mail.com", Name: "John Doe", Role: "manager", Active: true,
+			CreatedAt: now}
+	}
+
+	tests := []struct {
+		name       string
+		request    *model.UpdateUserRequest
+		mockFunc   UserMockFunc
+		wantRes    *model.UserResponse
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "manager", Name: &name},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error user not found",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", Name: &name},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error user of another organization",
+			request: &model.UpdateUserRequest{OrgID: 2, ID: 2, UserRole: "admin", Name: &name},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(2), uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error email already exist",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", Email: &email},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(user(), nil)
+				ur.On("CountByEmail", mock.Anything, email).Return(1, nil)
+			},
+			wantErrMsg: "Email already exist",
+		},
+		{
+			name:    "error on update",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", Name: &name},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(user(), nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(errors.New("something error"))
+			},
+			wantErrMsg: "failed to update user for id (2) = something error",
+		},
+		{
+			name:    "error department not found",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", DepartmentID: &departmentID},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(user(), nil)
+				dr.On("FindByID", mock.Anything, uint64(1), departmentID).Return(nil, nil)
+			},
+			wantErrMsg: "Department not found",
+		},
+		{
+			name:    "success assign department",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", DepartmentID: &departmentID},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(user(), nil)
+				dr.On("FindByID", mock.Anything, uint64(1), departmentID).Return(&entity.Department{ID: 3}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.DepartmentID != nil && *u.DepartmentID == departmentID
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:2").Return(s.intCmd(nil))
+			},
+			wantRes: &model.UserResponse{ID: 2, Email: "john@mail.com", Name: "John Doe", Role: "manager", Active: true,
+				DepartmentID: &departmentID, CreatedAt: now.UTC().Format(time.RFC3339)},
+		},
+		{
+			name:    "success remove department",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", DepartmentID: &noDepartment},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				u := user()
+				u.DepartmentID = &departmentID
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(u, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.DepartmentID == nil
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:2").Return(s.intCmd(nil))
+			},
+			wantRes: &model.UserResponse{ID: 2, Email: "john@mail.com", Name: "John Doe", Role: "manager", Active: true,
+				CreatedAt: now.UTC().Format(time.RFC3339)},
+		},
+		{
+			name:    "success",
+			request: &model.UpdateUserRequest{OrgID: 1, ID: 2, UserRole: "admin", Name: &name, Email: &email},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(user(), nil)
+				ur.On("CountByEmail", mock.Anything, email).Return(0, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Name == name && u.Email == email && u.Active
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:2").Return(s.intCmd(nil))
+			},
+			wantRes: &model.UserResponse{ID: 2, Email: email, Name: name, Role: "manager", Active: true,
+				CreatedAt: now.UTC().Format(time.RFC3339)},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			redisClient := mocks.NewRedisClient(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			tokenRepository := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, redisClient, userRepository, tokenRepository, departmentRepository, 24*time.Hour)
+			tt.mockFunc(redisClient, userRepository, departmentRepository, tokenRepository)
+
+			res, err := usecase.Update(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Nil(res)
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Equal(tt.wantRes, res)
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_Deactivate() {
+	tests := []struct {
+		name       string
+		request    *model.UpdateUserActiveRequest
+		mockFunc   UserMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "manager"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error deactivate self",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 1, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "You can't deactivate your own account",
+		},
+		{
+			name:    "error on find",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(nil, errors.New("something error"))
+			},
+			wantErrMsg: "failed to find user by id (2) = something error",
+		},
+		{
+			name:    "error on set deactivated",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{ID: 2, Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:2", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(errors.New("something error")))
+			},
+			wantErrMsg: "failed to set deactivated user for id (2) = something error",
+		},
+		{
+			// a retry after the marker failed to be set finds the user inactive already
+			name:    "success retry on already inactive",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{ID: 2}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:2", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:2", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(2), mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:    "success",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{ID: 2, Active: true}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return !u.Active
+				})).Return(nil)
+				rc.On("Set", mock.Anything, "deactivated-user:2", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				rc.On("SetEx", mock.Anything, "revoke-user-tokens:2", mock.Anything, 24*time.Hour).
+					Return(s.statusCmd(nil))
+				pr.On("RevokeByUserID", mock.Anything, uint64(2), mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			redisClient := mocks.NewRedisClient(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			tokenRepository := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, redisClient, userRepository, tokenRepository, departmentRepository, 24*time.Hour)
+			tt.mockFunc(redisClient, userRepository, departmentRepository, tokenRepository)
+
+			err := usecase.Deactivate(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
+func (s *UserUsecaseSuite) TestUserUsecase_Reactivate() {
+	tests := []struct {
+		name       string
+		request    *model.UpdateUserActiveRequest
+		mockFunc   UserMockFunc
+		wantErrMsg string
+	}{
+		{
+			name:    "error not admin",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "employee"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+			},
+			wantErrMsg: "Forbidden",
+		},
+		{
+			name:    "error user not found",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(nil, nil)
+			},
+			wantErrMsg: "User not found",
+		},
+		{
+			name:    "error on delete deactivated",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{ID: 2}, nil)
+				ur.On("Update", mock.Anything, mock.Anything).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:2").Return(s.intCmd(errors.New("something error")))
+			},
+			wantErrMsg: "failed to delete deactivated user for id (2) = something error",
+		},
+		{
+			name:    "success",
+			request: &model.UpdateUserActiveRequest{OrgID: 1, ID: 2, UserID: 1, UserRole: "admin"},
+			mockFunc: func(rc *mocks.RedisClient, ur *mocks.UserRepository, dr *mocks.DepartmentRepository,
+				pr *mocks.PersonalAccessTokenRepository) {
+				ur.On("FindByID", mock.Anything, uint64(1), uint64(2)).Return(&entity.User{ID: 2}, nil)
+				ur.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Active
+				})).Return(nil)
+				rc.On("Del", mock.Anything, "deactivated-user:2").Return(s.intCmd(nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			redisClient := mocks.NewRedisClient(s.T())
+			userRepository := mocks.NewUserRepository(s.T())
+			departmentRepository := mocks.NewDepartmentRepository(s.T())
+			tokenRepository := mocks.NewPersonalAccessTokenRepository(s.T())
+			usecase := usecase.NewUserUsecase(s.log, redisClient, userRepository, tokenRepository, departmentRepository, 24*time.Hour)
+			tt.mockFunc(redisClient, userRepository, departmentRepository, tokenRepository)
+
+			err := usecase.Reactivate(s.ctx, tt.request)
+
+			if tt.wantErrMsg != "" {
+				s.Equal(tt.wantErrMsg, err.Error())
+			} else {
+				s.Nil(err)
+			}
+		})
+	}
+}
+
 func TestUserUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(UserUsecaseSuite))
 }
@@ -10,7 +10,7 @@ import (
 )
 
 func NewApi(logger *zap.Logger) *gin.Engine {
-	app := config.NewGin(logger)
+	app, _ := config.NewGin(&config.Env{}, logger)
 
 	app.Use(requestid.New())
 	app.Use(middleware.NewRequestLoggerMiddleware(logger))
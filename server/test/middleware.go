@@ -2,6 +2,7 @@ package test
 
 import (
 	"expense-management-system/internal/auth"
+	"expense-management-system/internal/entity"
 	"fmt"
 	"time"
 
@@ -10,11 +11,13 @@ import (
 	"github.com/google/uuid"
 )
 
-func NewAuthMiddleware(userID uint64, role string) gin.HandlerFunc {
+func NewAuthMiddleware(userID uint64, role string, amr ...string) gin.HandlerFunc {
 	now := time.Now()
 	claims := &auth.JWTClaims{
 		UserID: fmt.Sprint(userID),
+		OrgID:  entity.DefaultOrganizationID,
 		Role:   role,
+		AMR:    amr,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -27,3 +30,24 @@ func NewAuthMiddleware(userID uint64, role string) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+func NewAPIKeyMiddleware(userID uint64, role string, scopes ...string) gin.HandlerFunc {
+	now := time.Now()
+	claims := &auth.JWTClaims{
+		UserID:   fmt.Sprint(userID),
+		OrgID:    entity.DefaultOrganizationID,
+		Role:     role,
+		APIKeyID: 1,
+		Scopes:   scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        "pat-1",
+		},
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Set("claims", claims)
+		ctx.Next()
+	}
+}